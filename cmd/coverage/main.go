@@ -0,0 +1,174 @@
+// Command coverage compares the routes the API lists at /v2.json against the
+// endpoints the client implements and prints the ones it is missing. With
+// -doc it prints the endpoint registry as a Markdown reference instead.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// Route is an entry of the API's route list
+type Route struct {
+	Path   string `json:"path"`
+	Lang   bool   `json:"lang"`
+	Auth   bool   `json:"auth"`
+	Active bool   `json:"active"`
+}
+
+type routeList struct {
+	Routes []Route `json:"routes"`
+}
+
+// Report is the result of comparing routes against the endpoint registry
+type Report struct {
+	Covered int
+	Missing []Route
+	// Extra lists registered endpoints that cover no listed route
+	Extra []gw2api.Endpoint
+	// AuthMismatch lists routes whose need for an API key disagrees with the
+	// scopes the registry has for the endpoints covering them
+	AuthMismatch []Route
+}
+
+// compare checks each route against the endpoints. Inactive routes are
+// skipped unless all is set.
+func compare(routes []Route, endpoints []gw2api.Endpoint, all bool) Report {
+	var report Report
+	used := make([]bool, len(endpoints))
+	for _, route := range routes {
+		if !route.Active && !all {
+			continue
+		}
+		covered, scoped := false, false
+		for i, endpoint := range endpoints {
+			if endpoint.Covers(route.Path) {
+				used[i] = true
+				covered = true
+				scoped = scoped || len(endpoint.Scopes) > 0
+			}
+		}
+		if covered {
+			report.Covered++
+			if route.Auth != scoped {
+				report.AuthMismatch = append(report.AuthMismatch, route)
+			}
+		} else {
+			report.Missing = append(report.Missing, route)
+		}
+	}
+	for i, endpoint := range endpoints {
+		if !used[i] {
+			report.Extra = append(report.Extra, endpoint)
+		}
+	}
+	byPath := func(a, b Route) int {
+		return strings.Compare(a.Path, b.Path)
+	}
+	slices.SortFunc(report.Missing, byPath)
+	slices.SortFunc(report.AuthMismatch, byPath)
+	return report
+}
+
+func loadRoutes(ctx context.Context, file string) ([]Route, error) {
+	var data []byte
+	var err error
+	if file != "" {
+		data, err = os.ReadFile(file)
+	} else {
+		data, _, err = gw2api.NewClient().GetRaw(ctx, "/v2.json")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load route list: %w", err)
+	}
+
+	var list routeList
+	if err := json.Unmarshal(data, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse route list: %w", err)
+	}
+	return list.Routes, nil
+}
+
+func printReport(w io.Writer, report Report) {
+	total := report.Covered + len(report.Missing)
+	fmt.Fprintf(w, "Covered %d of %d routes\n", report.Covered, total)
+
+	if len(report.Missing) > 0 {
+		fmt.Fprintln(w, "\nMissing:")
+		for _, route := range report.Missing {
+			var notes string
+			if route.Auth {
+				notes += " (auth)"
+			}
+			if !route.Active {
+				notes += " (inactive)"
+			}
+			fmt.Fprintf(w, "  %s%s\n", route.Path, notes)
+		}
+	}
+
+	if len(report.Extra) > 0 {
+		fmt.Fprintln(w, "\nNot in route list:")
+		for _, endpoint := range report.Extra {
+			fmt.Fprintf(w, "  %s\n", endpoint.Path)
+		}
+	}
+
+	if len(report.AuthMismatch) > 0 {
+		fmt.Fprintln(w, "\nScopes disagree with the route list:")
+		for _, route := range report.AuthMismatch {
+			if route.Auth {
+				fmt.Fprintf(w, "  %s needs a key, but no scopes are documented\n", route.Path)
+			} else {
+				fmt.Fprintf(w, "  %s is public, but scopes are documented\n", route.Path)
+			}
+		}
+	}
+}
+
+// printDoc writes the endpoints as a Markdown table
+func printDoc(w io.Writer, endpoints []gw2api.Endpoint) {
+	fmt.Fprintln(w, "| Path | Scopes | IDs | Methods |")
+	fmt.Fprintln(w, "| --- | --- | --- | --- |")
+	for _, endpoint := range endpoints {
+		scopes := strings.Join(endpoint.Scopes, ", ")
+		if scopes == "" {
+			scopes = "public"
+		}
+		ids := string(endpoint.IDs)
+		if ids == "" {
+			ids = "-"
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s |\n", endpoint.Path, scopes, ids, strings.Join(endpoint.Methods, ", "))
+	}
+}
+
+func main() {
+	var (
+		file = flag.String("file", "", "Read the route list from a file instead of the API")
+		all  = flag.Bool("all", false, "Include inactive routes")
+		doc  = flag.Bool("doc", false, "Print the endpoint registry as Markdown instead of comparing")
+	)
+	flag.Parse()
+
+	if *doc {
+		printDoc(os.Stdout, gw2api.Endpoints)
+		return
+	}
+
+	routes, err := loadRoutes(context.Background(), *file)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "coverage: %v\n", err)
+		os.Exit(1)
+	}
+
+	printReport(os.Stdout, compare(routes, gw2api.Endpoints, *all))
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+var buildDecodeCmd = &cobra.Command{
+	Use:   "decode <chat link>",
+	Short: "Decode a build template chat link",
+	Long: `Decode a build template chat link, as copied from the in-game build
+template panel, and show its profession, traits, skills and pets. Quote the
+link so the shell leaves the brackets alone.
+
+Examples:
+  gw2api build decode '[&DQQIGx4...]'
+  gw2api build decode '[&DQQIGx4...]' --output json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		template, err := gw2api.ParseBuildTemplateLink(args[0])
+		if err != nil {
+			return err
+		}
+
+		ctx := context.Background()
+		build, err := client.ResolveBuildTemplate(ctx, template)
+		if err != nil {
+			return err
+		}
+
+		outputData(build)
+		return nil
+	},
+}
+
+func outputBuildTemplate(build *gw2api.ResolvedBuild) {
+	fmt.Println(build.Profession.Name)
+
+	for _, line := range build.Specializations {
+		var traits []string
+		for _, trait := range line.Traits {
+			traits = append(traits, traitName(trait))
+		}
+		fmt.Printf("  %-16s %s\n", line.Specialization.Name, strings.Join(traits, " / "))
+	}
+
+	for _, skills := range []struct {
+		name   string
+		skills gw2api.ResolvedSkills
+	}{{"Terrestrial", build.Terrestrial}, {"Aquatic", build.Aquatic}} {
+		fmt.Printf("%s skills:\n", skills.name)
+		fmt.Printf("  %-9s %s\n", "Heal", skillName(skills.skills.Heal))
+		for i, utility := range skills.skills.Utilities {
+			fmt.Printf("  %-9s %s\n", fmt.Sprintf("Utility %d", i+1), skillName(utility))
+		}
+		fmt.Printf("  %-9s %s\n", "Elite", skillName(skills.skills.Elite))
+	}
+
+	template := build.Template
+	if build.TerrestrialPets != [2]*gw2api.Pet{} || build.AquaticPets != [2]*gw2api.Pet{} {
+		fmt.Printf("Pets: %s, %s (aquatic %s, %s)\n", petName(build.TerrestrialPets[0]), petName(build.TerrestrialPets[1]),
+			petName(build.AquaticPets[0]), petName(build.AquaticPets[1]))
+	}
+	if template.TerrestrialLegends != [2]int{} {
+		fmt.Printf("Legends: %d, %d (aquatic %d, %d)\n", template.TerrestrialLegends[0], template.TerrestrialLegends[1],
+			template.AquaticLegends[0], template.AquaticLegends[1])
+	}
+	if len(template.Weapons) > 0 {
+		fmt.Printf("Weapons: %v\n", template.Weapons)
+	}
+	if len(template.WeaponSkills) > 0 {
+		fmt.Printf("Weapon skill variants: %v\n", template.WeaponSkills)
+	}
+}
+
+// traitName, skillName and petName show "-" for empty or unknown slots
+func traitName(trait *gw2api.Trait) string {
+	if trait == nil {
+		return "-"
+	}
+	return trait.Name
+}
+
+func skillName(skill *gw2api.Skill) string {
+	if skill == nil {
+		return "-"
+	}
+	return skill.Name
+}
+
+func petName(pet *gw2api.Pet) string {
+	if pet == nil {
+		return "-"
+	}
+	return pet.Name
+}
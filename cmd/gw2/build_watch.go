@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// lastBuildPath returns the file remembering the last build announced by
+// build --watch, kept next to the config file
+func lastBuildPath() (string, error) {
+	path, _, err := resolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "last-build"), nil
+}
+
+// loadLastBuild returns the build stored at path, or 0 if none was stored
+func loadLastBuild(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	build, err := strconv.Atoi(strings.TrimSpace(string(data)))
+	if err != nil {
+		return 0, fmt.Errorf("invalid build in %s: %w", path, err)
+	}
+	return build, nil
+}
+
+// saveLastBuild stores build at path, creating its directory if needed
+func saveLastBuild(path string, build int) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, []byte(strconv.Itoa(build)+"\n"), 0o644)
+}
+
+// watchBuild prints each new build until interrupted, running command for each.
+// The last announced build is stored so a restart doesn't announce it again.
+func watchBuild(interval time.Duration, command string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	statePath, err := lastBuildPath()
+	if err != nil {
+		return err
+	}
+	lastBuild, err := loadLastBuild(statePath)
+	if err != nil {
+		return err
+	}
+
+	changes, err := client.WatchBuild(ctx, interval)
+	if err != nil {
+		return fmt.Errorf("failed to get build: %w", err)
+	}
+
+	for change := range changes {
+		timestamp := change.Time.Format(time.DateTime)
+		if lastBuild == change.Current {
+			fmt.Printf("%s Watching build %d every %s\n", timestamp, change.Current, interval)
+			continue
+		}
+		if lastBuild == 0 {
+			// Nothing to compare the first build against
+			fmt.Printf("%s Watching build %d every %s\n", timestamp, change.Current, interval)
+		} else {
+			fmt.Printf("%s New build %d (was %d)\n", timestamp, change.Current, lastBuild)
+			if command != "" {
+				if err := runBuildCommand(ctx, command, change.Current, lastBuild); err != nil && ctx.Err() == nil {
+					fmt.Fprintf(os.Stderr, "Warning: --exec command failed: %v\n", err)
+				}
+			}
+		}
+
+		lastBuild = change.Current
+		if err := saveLastBuild(statePath, lastBuild); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save last build: %v\n", err)
+		}
+	}
+	return nil
+}
+
+// runBuildCommand runs command through the shell with the builds in
+// GW2_BUILD and GW2_PREVIOUS_BUILD
+func runBuildCommand(ctx context.Context, command string, build, previous int) error {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(),
+		"GW2_BUILD="+strconv.Itoa(build),
+		"GW2_PREVIOUS_BUILD="+strconv.Itoa(previous),
+	)
+	return cmd.Run()
+}
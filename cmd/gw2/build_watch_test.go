@@ -0,0 +1,31 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLastBuild(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gw2api", "last-build")
+
+	build, err := loadLastBuild(path)
+	if err != nil || build != 0 {
+		t.Fatalf("loadLastBuild without a file = %d, %v", build, err)
+	}
+
+	if err := saveLastBuild(path, 115267); err != nil {
+		t.Fatalf("saveLastBuild: %v", err)
+	}
+	build, err = loadLastBuild(path)
+	if err != nil || build != 115267 {
+		t.Errorf("loadLastBuild = %d, %v, expected 115267", build, err)
+	}
+
+	if err := os.WriteFile(path, []byte("soon\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := loadLastBuild(path); err == nil {
+		t.Error("loadLastBuild accepted an invalid build")
+	}
+}
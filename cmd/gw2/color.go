@@ -0,0 +1,38 @@
+package main
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// noColor is the --no-color flag
+var noColor bool
+
+// ANSI colors for table hints
+const (
+	ansiGreen = "\x1b[32m"
+	ansiRed   = "\x1b[31m"
+	ansiReset = "\x1b[0m"
+)
+
+// useColor reports whether table output may be colored: only on a terminal,
+// and not with --no-color or the NO_COLOR environment variable
+func useColor() bool {
+	if noColor || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// colorSign colors text green when sign is positive and red when it is
+// negative, leaving it alone at zero or when color is off
+func colorSign(text string, sign float64) string {
+	if sign == 0 || !useColor() {
+		return text
+	}
+	if sign > 0 {
+		return ansiGreen + text + ansiReset
+	}
+	return ansiRed + text + ansiReset
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// Values offered when completing --output and --lang
+var (
+	outputFormats = []string{"json", "table", "yaml"}
+	languages     = []string{"en", "es", "de", "fr", "zh"}
+)
+
+// characterCompletionTimeout bounds the character names request made while
+// completing, so a slow or unreachable API never hangs the shell
+const characterCompletionTimeout = 2 * time.Second
+
+var completionCmd = &cobra.Command{
+	Use:   "completion bash|zsh|fish|powershell",
+	Short: "Generate a shell completion script",
+	Long: `Generate a completion script for the given shell and print it.
+
+Bash:    source <(gw2api completion bash)
+Zsh:     gw2api completion zsh > "${fpath[1]}/_gw2api"
+Fish:    gw2api completion fish > ~/.config/fish/completions/gw2api.fish
+PowerShell:
+         gw2api completion powershell | Out-String | Invoke-Expression
+
+Besides commands and flags, the scripts complete the values of --lang,
+--output, --rarity and --type, cache kinds, and character names when an API
+key is configured.`,
+	ValidArgs:             []string{"bash", "zsh", "fish", "powershell"},
+	Args:                  cobra.MatchAll(cobra.ExactArgs(1), cobra.OnlyValidArgs),
+	DisableFlagsInUseLine: true,
+	// Generating scripts needs no config or API client
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		root := cmd.Root()
+		switch args[0] {
+		case "bash":
+			return root.GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			return root.GenZshCompletion(os.Stdout)
+		case "fish":
+			return root.GenFishCompletion(os.Stdout, true)
+		default:
+			return root.GenPowerShellCompletionWithDesc(os.Stdout)
+		}
+	},
+}
+
+// completeValues returns a completion function offering values that start
+// with what has been typed, ignoring case
+func completeValues(values []string) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return matchPrefix(values, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// matchPrefix returns the values starting with prefix, ignoring case
+func matchPrefix(values []string, prefix string) []string {
+	var matches []string
+	for _, value := range values {
+		if strings.HasPrefix(strings.ToLower(value), strings.ToLower(prefix)) {
+			matches = append(matches, value)
+		}
+	}
+	return matches
+}
+
+// completeCacheKinds offers the cache kinds not already given as arguments
+func completeCacheKinds(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var kinds []string
+	for _, kind := range gw2api.CacheKinds {
+		if !containsFold(args, string(kind)) {
+			kinds = append(kinds, string(kind))
+		}
+	}
+	return matchPrefix(kinds, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// containsFold reports whether values holds s, ignoring case
+func containsFold(values []string, s string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// completeCharacterName offers the account's character names for a command's
+// only argument. It needs an API key from the flags, environment or config
+// file, and offers nothing if there is none or the API doesn't answer in time.
+func completeCharacterName(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	cfg, err := resolveConfig(cmd)
+	if err != nil || cfg.APIKey == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), characterCompletionTimeout)
+	defer cancel()
+	completionClient := gw2api.NewClient(gw2api.WithAPIKey(cfg.APIKey), gw2api.WithRetries(0), gw2api.WithTimeout(characterCompletionTimeout))
+	names, err := completionClient.GetCharacterNames(ctx)
+	if err != nil {
+		cobra.CompDebugln(fmt.Sprintf("character names: %v", err), true)
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return matchPrefix(names, toComplete), cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func TestFlagCompletions(t *testing.T) {
+	tests := []struct {
+		cmd        *cobra.Command
+		flag       string
+		toComplete string
+		expected   []string
+	}{
+		{rootCmd, "output", "", []string{"json", "table", "yaml"}},
+		{rootCmd, "output", "Y", []string{"yaml"}},
+		{rootCmd, "lang", "", []string{"en", "es", "de", "fr", "zh"}},
+		{rootCmd, "lang", "e", []string{"en", "es"}},
+		{itemsSearchCmd, "rarity", "ex", []string{"Exotic"}},
+		{itemsSearchCmd, "type", "tr", []string{"Trait", "Trinket", "Trophy"}},
+		{skinsSearchCmd, "rarity", "ba", []string{"Basic"}},
+	}
+	for _, test := range tests {
+		complete, found := test.cmd.GetFlagCompletionFunc(test.flag)
+		if !found {
+			t.Errorf("%s --%s has no completions", test.cmd.Name(), test.flag)
+			continue
+		}
+		completions, directive := complete(test.cmd, nil, test.toComplete)
+		if !slices.Equal(completions, test.expected) {
+			t.Errorf("%s --%s %q = %v, expected %v", test.cmd.Name(), test.flag, test.toComplete, completions, test.expected)
+		}
+		if directive != cobra.ShellCompDirectiveNoFileComp {
+			t.Errorf("%s --%s directive = %v, expected no file completion", test.cmd.Name(), test.flag, directive)
+		}
+	}
+}
+
+func TestArgCompletions(t *testing.T) {
+	completions, _ := completeCacheKinds(cacheCompactCmd, []string{"items"}, "")
+	if slices.Contains(completions, "items") || !slices.Contains(completions, "skills") {
+		t.Errorf("cache kinds = %v, expected every kind but items", completions)
+	}
+	if completions, _ := completeCacheKinds(cacheCompactCmd, nil, "ski"); !slices.Equal(completions, []string{"skills", "skins"}) {
+		t.Errorf("cache kinds for ski = %v, expected skills and skins", completions)
+	}
+
+	// Without an API key character names aren't looked up
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("GW2_API_KEY", "")
+	if completions, _ := completeCharacterName(charactersGearCmd, nil, ""); len(completions) != 0 {
+		t.Errorf("character names without a key = %v, expected none", completions)
+	}
+}
+
+func TestWriteManPages(t *testing.T) {
+	dir := t.TempDir()
+	written, err := writeManPages(rootCmd, dir, time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("writeManPages: %v", err)
+	}
+	if !slices.Contains(written, filepath.Join(dir, "gw2api-items-search.1")) {
+		t.Fatalf("written = %v, expected gw2api-items-search.1", written)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "gw2api-items-search.1"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	page := string(data)
+	for _, expected := range []string{`.TH "GW2API-ITEMS-SEARCH" "1" "Jan 2026"`, ".SH SYNOPSIS", `\fB\-\-rarity\fR`, ".SH OPTIONS INHERITED FROM PARENT COMMANDS", ".BR gw2api-items (1)"} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("man page is missing %q:\n%s", expected, page)
+		}
+	}
+}
@@ -0,0 +1,448 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// cliConfig holds the settings that can come from flags, the environment or the config file
+type cliConfig struct {
+	APIKey    string
+	APIKeys   map[string]string // Named keys, selected with --key-name
+	KeyName   string
+	Language  string
+	Output    string
+	Timeout   int
+	DataDir   string
+	RateLimit float64
+	PriceTTL  time.Duration
+}
+
+// configPath is the --config override, empty for the default location
+var configPath string
+
+// defaultConfigPath returns ~/.config/gw2api/config.yaml
+func defaultConfigPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".config", "gw2api", "config.yaml"), nil
+}
+
+// resolveConfigPath returns the config file to use and whether it was chosen explicitly
+func resolveConfigPath() (string, bool, error) {
+	if configPath != "" {
+		return configPath, true, nil
+	}
+	path, err := defaultConfigPath()
+	return path, false, err
+}
+
+// parseConfig reads a "key: value" YAML config. The only nested mapping is
+// api_keys, whose indented "name: key" lines follow it. Only the keys the CLI
+// understands are accepted so typos are reported instead of silently ignored.
+func parseConfig(r io.Reader, cfg *cliConfig) error {
+	scanner := bufio.NewScanner(r)
+	inAPIKeys := false
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		raw := scanner.Text()
+		line := strings.TrimSpace(raw)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		key, value, found := strings.Cut(line, ":")
+		if !found {
+			return fmt.Errorf("line %d: expected \"key: value\"", lineNum)
+		}
+		key = strings.TrimSpace(key)
+		value = unquote(stripComment(strings.TrimSpace(value)))
+
+		indented := strings.HasPrefix(raw, " ") || strings.HasPrefix(raw, "\t")
+		if indented {
+			if !inAPIKeys {
+				return fmt.Errorf("line %d: unexpected indentation", lineNum)
+			}
+			if cfg.APIKeys == nil {
+				cfg.APIKeys = make(map[string]string)
+			}
+			cfg.APIKeys[unquote(key)] = value
+			continue
+		}
+		inAPIKeys = false
+
+		var err error
+		switch key {
+		case "api_key":
+			cfg.APIKey = value
+		case "api_keys":
+			if value != "" {
+				return fmt.Errorf("line %d: api_keys takes indented \"name: key\" lines", lineNum)
+			}
+			inAPIKeys = true
+		case "key_name":
+			cfg.KeyName = value
+		case "language":
+			cfg.Language = value
+		case "output":
+			cfg.Output = value
+		case "timeout":
+			cfg.Timeout, err = strconv.Atoi(value)
+		case "data_dir":
+			cfg.DataDir = value
+		case "rate_limit":
+			cfg.RateLimit, err = strconv.ParseFloat(value, 64)
+		case "price_ttl":
+			cfg.PriceTTL, err = time.ParseDuration(value)
+		default:
+			return fmt.Errorf("line %d: unknown key %q", lineNum, key)
+		}
+		if err != nil {
+			return fmt.Errorf("line %d: invalid %s: %w", lineNum, key, err)
+		}
+	}
+	return scanner.Err()
+}
+
+// stripComment removes a trailing " # comment" from an unquoted value
+func stripComment(value string) string {
+	if strings.HasPrefix(value, `"`) || strings.HasPrefix(value, "'") {
+		return value
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		return strings.TrimSpace(value[:i])
+	}
+	return value
+}
+
+// unquote removes matching single or double quotes around a value
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// loadConfigFile merges the config file into cfg. A missing file is only an
+// error when it was requested with --config.
+func loadConfigFile(cfg *cliConfig) error {
+	path, explicit, err := resolveConfigPath()
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) && !explicit {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open config: %w", err)
+	}
+	defer f.Close()
+
+	if err := parseConfig(f, cfg); err != nil {
+		return fmt.Errorf("invalid config %s: %w", path, err)
+	}
+	return nil
+}
+
+// applyEnv merges GW2_* environment variables into cfg
+func applyEnv(cfg *cliConfig) error {
+	if v := os.Getenv("GW2_API_KEY"); v != "" {
+		cfg.APIKey = v
+		cfg.KeyName = ""
+	}
+	if v := os.Getenv("GW2_KEY_NAME"); v != "" {
+		cfg.KeyName = v
+	}
+	if v := os.Getenv("GW2_LANG"); v != "" {
+		cfg.Language = v
+	}
+	if v := os.Getenv("GW2_OUTPUT"); v != "" {
+		cfg.Output = v
+	}
+	if v := os.Getenv("GW2_DATA_DIR"); v != "" {
+		cfg.DataDir = v
+	}
+	if v := os.Getenv("GW2_TIMEOUT"); v != "" {
+		timeout, err := strconv.Atoi(v)
+		if err != nil {
+			return fmt.Errorf("invalid GW2_TIMEOUT: %w", err)
+		}
+		cfg.Timeout = timeout
+	}
+	if v := os.Getenv("GW2_RATE_LIMIT"); v != "" {
+		rateLimit, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return fmt.Errorf("invalid GW2_RATE_LIMIT: %w", err)
+		}
+		cfg.RateLimit = rateLimit
+	}
+	if v := os.Getenv("GW2_PRICE_TTL"); v != "" {
+		priceTTL, err := time.ParseDuration(v)
+		if err != nil {
+			return fmt.Errorf("invalid GW2_PRICE_TTL: %w", err)
+		}
+		cfg.PriceTTL = priceTTL
+	}
+	return nil
+}
+
+// resolveConfig merges settings with precedence flags > env > config file > defaults
+func resolveConfig(cmd *cobra.Command) (*cliConfig, error) {
+	cfg := &cliConfig{Language: "en", Output: "table", Timeout: 30, PriceTTL: defaultPriceTTL}
+	if err := loadConfigFile(cfg); err != nil {
+		return nil, err
+	}
+	if err := applyEnv(cfg); err != nil {
+		return nil, err
+	}
+
+	flags := cmd.Flags()
+	if flags.Changed("api-key") {
+		cfg.APIKey = apiKey
+		cfg.KeyName = "" // An explicit key wins over a named one from the config
+	}
+	if flags.Changed("key-name") {
+		cfg.KeyName = keyName
+	}
+	if flags.Changed("lang") {
+		cfg.Language = language
+	}
+	if flags.Changed("output") {
+		cfg.Output = outputFormat
+	}
+	if flags.Changed("timeout") {
+		cfg.Timeout = timeout
+	}
+	if flags.Changed("data-dir") || flags.Changed("cache-dir") {
+		cfg.DataDir = dataDir
+	}
+	if flags.Changed("rate-limit") {
+		cfg.RateLimit = rateLimit
+	}
+	if flags.Changed("price-ttl") {
+		cfg.PriceTTL = priceTTL
+	}
+
+	// A named key replaces api_key
+	if cfg.KeyName != "" {
+		key, found := cfg.APIKeys[cfg.KeyName]
+		if !found {
+			return nil, fmt.Errorf("no API key named %q in api_keys", cfg.KeyName)
+		}
+		cfg.APIKey = key
+	}
+
+	cfg.DataDir = expandHome(cfg.DataDir)
+	return cfg, nil
+}
+
+// expandHome replaces a leading ~ with the user's home directory
+func expandHome(path string) string {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[1:])
+}
+
+const configTemplate = `# gw2api CLI configuration
+# Settings here are overridden by GW2_* environment variables and command-line flags.
+
+api_key: %q
+
+# Named keys, chosen with --key-name or key_name
+# api_keys:
+#   main: "..."
+#   guild: "..."
+
+language: en
+output: table
+timeout: 30
+
+# Directory containing items.json, skills.json, ... from updatedb
+# data_dir: ~/.config/gw2api/data
+
+# Requests per second
+# rate_limit: 10
+
+# How long trading post prices are reused across commands (0 to always fetch)
+# price_ttl: 5m
+`
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the CLI config file",
+	// Config commands must work even when the current config is broken
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var configInitCmd = &cobra.Command{
+	Use:   "init",
+	Short: "Write a config file template, prompting for the API key",
+	Run: func(cmd *cobra.Command, args []string) {
+		path, _, err := resolveConfigPath()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		force, _ := cmd.Flags().GetBool("force")
+		if _, err := os.Stat(path); err == nil && !force {
+			fmt.Fprintf(os.Stderr, "Error: %s already exists (use --force to overwrite)\n", path)
+			os.Exit(1)
+		}
+
+		fmt.Print("API key (leave empty for public endpoints only): ")
+		var key []byte
+		if term.IsTerminal(int(os.Stdin.Fd())) {
+			key, err = term.ReadPassword(int(os.Stdin.Fd()))
+			fmt.Println()
+		} else {
+			// Piped input, e.g. from a password manager
+			var line string
+			line, err = bufio.NewReader(os.Stdin).ReadString('\n')
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			key = []byte(line)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: failed to read API key: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		// The file holds a credential, so keep it private
+		content := fmt.Sprintf(configTemplate, strings.TrimSpace(string(key)))
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Wrote %s\n", path)
+	},
+}
+
+var configShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the effective config with the API key redacted",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := resolveConfig(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		path, _, _ := resolveConfigPath()
+		fmt.Printf("# %s\n", path)
+		fmt.Printf("api_key: %s\n", redactKey(cfg.APIKey))
+		if len(cfg.APIKeys) > 0 {
+			fmt.Println("api_keys:")
+			for _, name := range slices.Sorted(maps.Keys(cfg.APIKeys)) {
+				fmt.Printf("  %s: %s\n", name, redactKey(cfg.APIKeys[name]))
+			}
+		}
+		if cfg.KeyName != "" {
+			fmt.Printf("key_name: %s\n", cfg.KeyName)
+		}
+		fmt.Printf("language: %s\n", cfg.Language)
+		fmt.Printf("output: %s\n", cfg.Output)
+		fmt.Printf("timeout: %d\n", cfg.Timeout)
+		fmt.Printf("data_dir: %s\n", cfg.DataDir)
+		fmt.Printf("rate_limit: %g\n", cfg.RateLimit)
+		fmt.Printf("price_ttl: %s\n", cfg.PriceTTL)
+	},
+}
+
+// redactKey keeps only the last four characters of an API key
+func redactKey(key string) string {
+	if key == "" {
+		return ""
+	}
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// KeyStatus is what the API reports for one named key, without the key itself
+type KeyStatus struct {
+	Name        string   `json:"name"`
+	Account     string   `json:"account,omitempty"`
+	TokenName   string   `json:"token_name,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	Error       string   `json:"error,omitempty"`
+}
+
+var configKeysCmd = &cobra.Command{
+	Use:   "keys",
+	Short: "List the named API keys with their account and scopes",
+	// Unlike other config commands this one calls the API, so it needs a client
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.PersistentPreRun(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ring := client.KeyRing()
+		if ring == nil {
+			return errors.New("no api_keys in the config file")
+		}
+
+		ctx := context.Background()
+		var statuses []KeyStatus
+		for _, name := range ring.Names() {
+			status := KeyStatus{Name: name}
+			// One revoked key shouldn't hide the others, so errors are listed per key
+			if err := lookupKeyStatus(ctx, name, &status); err != nil {
+				status.Error = err.Error()
+			}
+			statuses = append(statuses, status)
+		}
+
+		outputData(statuses)
+		return nil
+	},
+}
+
+// lookupKeyStatus fills in the token info and account name for a named key
+func lookupKeyStatus(ctx context.Context, name string, status *KeyStatus) error {
+	keyClient, err := client.WithKey(name)
+	if err != nil {
+		return err
+	}
+	info, err := keyClient.CachedTokenInfo(ctx)
+	if err != nil {
+		return err
+	}
+	status.TokenName = info.Name
+	status.Permissions = info.Permissions
+
+	account, err := keyClient.GetAccount(ctx)
+	if err != nil {
+		return err
+	}
+	status.Account = account.Name
+	return nil
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseConfig(t *testing.T) {
+	input := `# comment
+api_key: "ABCD-1234"
+language: de
+output: json # inline comment
+timeout: 10
+data_dir: '~/gw2 data'
+rate_limit: 2.5
+price_ttl: 90s
+`
+	cfg := &cliConfig{}
+	if err := parseConfig(strings.NewReader(input), cfg); err != nil {
+		t.Fatalf("parseConfig: %v", err)
+	}
+
+	expected := cliConfig{APIKey: "ABCD-1234", Language: "de", Output: "json", Timeout: 10, DataDir: "~/gw2 data", RateLimit: 2.5, PriceTTL: 90 * time.Second}
+	if !reflect.DeepEqual(*cfg, expected) {
+		t.Errorf("cfg = %+v\nexpected %+v", *cfg, expected)
+	}
+}
+
+func TestParseConfigAPIKeys(t *testing.T) {
+	input := `api_keys:
+  main: "AAAA-1111"
+  # comment
+  guild: BBBB-2222 # leader key
+key_name: guild
+language: fr
+`
+	cfg := &cliConfig{}
+	if err := parseConfig(strings.NewReader(input), cfg); err != nil {
+		t.Fatalf("parseConfig: %v", err)
+	}
+
+	expected := cliConfig{
+		APIKeys:  map[string]string{"main": "AAAA-1111", "guild": "BBBB-2222"},
+		KeyName:  "guild",
+		Language: "fr",
+	}
+	if !reflect.DeepEqual(*cfg, expected) {
+		t.Errorf("cfg = %+v\nexpected %+v", *cfg, expected)
+	}
+}
+
+func TestParseConfigErrors(t *testing.T) {
+	tests := map[string]string{
+		"unknown key":     "apikey: abc",
+		"missing colon":   "api_key abc",
+		"invalid timeout": "timeout: soon",
+		"invalid ttl":     "price_ttl: 5",
+		"stray indent":    "  main: abc",
+		"inline api_keys": "api_keys: abc",
+	}
+	for name, input := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := parseConfig(strings.NewReader(input), &cliConfig{}); err == nil || !strings.Contains(err.Error(), "line 1") {
+				t.Errorf("parseConfig(%q) error = %v, expected a line 1 error", input, err)
+			}
+		})
+	}
+}
+
+func TestRedactKey(t *testing.T) {
+	if got := redactKey("ABCDEFGH-1234"); got != "****1234" {
+		t.Errorf("redactKey = %q", got)
+	}
+	if got := redactKey(""); got != "" {
+		t.Errorf("redactKey(\"\") = %q, expected empty", got)
+	}
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// manSection is the manual section the pages are written for
+const manSection = "1"
+
+var docsCmd = &cobra.Command{
+	Use:   "docs",
+	Short: "Generate documentation for the CLI",
+	// Generating documentation needs no config or API client
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+}
+
+var docsManCmd = &cobra.Command{
+	Use:   "man [dir]",
+	Short: "Write a man page for every command",
+	Long: `Write a man page for every command to dir (default the current directory),
+named after the command path, such as gw2api-items-search.1. Install them by
+copying them to a man1 directory on your MANPATH.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) > 0 {
+			dir = args[0]
+		}
+		written, err := writeManPages(cmd.Root(), dir, time.Now())
+		for _, path := range written {
+			fmt.Println(path)
+		}
+		return err
+	},
+}
+
+// writeManPages writes a man page for root and each of its available
+// subcommands to dir, returning the paths written
+func writeManPages(root *cobra.Command, dir string, date time.Time) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	var written []string
+	var walk func(cmd *cobra.Command) error
+	walk = func(cmd *cobra.Command) error {
+		if !cmd.IsAvailableCommand() && cmd != root {
+			return nil
+		}
+		path := filepath.Join(dir, manPageName(cmd))
+		var buf bytes.Buffer
+		writeManPage(&buf, cmd, date)
+		if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+			return err
+		}
+		written = append(written, path)
+		for _, child := range cmd.Commands() {
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return written, walk(root)
+}
+
+// manPageName is the file name of cmd's man page
+func manPageName(cmd *cobra.Command) string {
+	return strings.ReplaceAll(cmd.CommandPath(), " ", "-") + "." + manSection
+}
+
+// writeManPage writes cmd's man page in roff to w
+func writeManPage(w io.Writer, cmd *cobra.Command, date time.Time) {
+	name := strings.ReplaceAll(cmd.CommandPath(), " ", "-")
+	fmt.Fprintf(w, ".TH %q %q %q %q %q\n", strings.ToUpper(name), manSection, date.Format("Jan 2006"), cmd.Root().Name(), "Guild Wars 2 API CLI")
+
+	fmt.Fprintln(w, ".SH NAME")
+	fmt.Fprintf(w, "%s \\- %s\n", roffEscape(name), roffEscape(cmd.Short))
+
+	fmt.Fprintln(w, ".SH SYNOPSIS")
+	fmt.Fprintf(w, ".B %s\n", roffEscape(cmd.UseLine()))
+
+	fmt.Fprintln(w, ".SH DESCRIPTION")
+	description := cmd.Long
+	if description == "" {
+		description = cmd.Short
+	}
+	writeRoffText(w, description)
+
+	if cmd.HasAvailableLocalFlags() {
+		fmt.Fprintln(w, ".SH OPTIONS")
+		writeManFlags(w, cmd.NonInheritedFlags())
+	}
+	if cmd.HasAvailableInheritedFlags() {
+		fmt.Fprintln(w, ".SH OPTIONS INHERITED FROM PARENT COMMANDS")
+		writeManFlags(w, cmd.InheritedFlags())
+	}
+
+	var related []string
+	if cmd.HasParent() {
+		related = append(related, manReference(cmd.Parent()))
+	}
+	for _, child := range cmd.Commands() {
+		if child.IsAvailableCommand() {
+			related = append(related, manReference(child))
+		}
+	}
+	if len(related) > 0 {
+		fmt.Fprintln(w, ".SH SEE ALSO")
+		fmt.Fprintln(w, strings.Join(related, ",\n"))
+	}
+}
+
+// manReference refers to cmd's man page, as in gw2api-items(1)
+func manReference(cmd *cobra.Command) string {
+	return fmt.Sprintf(".BR %s (%s)", strings.ReplaceAll(cmd.CommandPath(), " ", "-"), manSection)
+}
+
+// writeManFlags lists flags as tagged paragraphs
+func writeManFlags(w io.Writer, flags *pflag.FlagSet) {
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Hidden {
+			return
+		}
+		fmt.Fprintln(w, ".TP")
+		names := "\\fB" + roffEscape("--"+flag.Name) + "\\fR"
+		if flag.Shorthand != "" {
+			names = "\\fB" + roffEscape("-"+flag.Shorthand) + "\\fR, " + names
+		}
+		if flag.Value.Type() != "bool" {
+			names += " \\fI" + roffEscape(flag.Value.Type()) + "\\fR"
+		}
+		fmt.Fprintln(w, names)
+		usage := flag.Usage
+		if flag.DefValue != "" && flag.DefValue != "false" && flag.DefValue != "[]" && flag.DefValue != "0" {
+			usage += fmt.Sprintf(" (default %s)", flag.DefValue)
+		}
+		writeRoffText(w, usage)
+	})
+}
+
+// writeRoffText writes text as paragraphs, keeping indented lines such as
+// examples as they are
+func writeRoffText(w io.Writer, text string) {
+	for i, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if i > 0 {
+			fmt.Fprintln(w, ".PP")
+		}
+		preformatted := strings.Contains(paragraph, "\n ")
+		if preformatted {
+			fmt.Fprintln(w, ".nf")
+		}
+		for _, line := range strings.Split(paragraph, "\n") {
+			fmt.Fprintln(w, roffLine(line))
+		}
+		if preformatted {
+			fmt.Fprintln(w, ".fi")
+		}
+	}
+}
+
+// roffLine escapes a line of text, protecting a leading . or ' that roff
+// would read as a request
+func roffLine(line string) string {
+	line = roffEscape(line)
+	if strings.HasPrefix(line, ".") || strings.HasPrefix(line, "'") {
+		line = "\\&" + line
+	}
+	return line
+}
+
+// roffEscape escapes the characters roff treats specially within a line
+func roffEscape(s string) string {
+	s = strings.ReplaceAll(s, "\\", "\\e")
+	return strings.ReplaceAll(s, "-", "\\-")
+}
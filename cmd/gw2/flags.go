@@ -0,0 +1,61 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// enumListFlag is a comma-separated flag whose values are checked as the flag
+// is parsed, so a typo fails before any request is made
+type enumListFlag[T ~string] struct {
+	name   string
+	values []T
+	valid  []T
+	parse  func(string) (T, error)
+}
+
+func newEnumListFlag[T ~string](name string, valid []T, parse func(string) (T, error)) *enumListFlag[T] {
+	return &enumListFlag[T]{name: name, valid: valid, parse: parse}
+}
+
+func (f *enumListFlag[T]) String() string {
+	parts := make([]string, len(f.values))
+	for i, value := range f.values {
+		parts[i] = string(value)
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set adds each comma-separated value, so the flag can also be repeated
+func (f *enumListFlag[T]) Set(s string) error {
+	for part := range strings.SplitSeq(s, ",") {
+		value, err := f.parse(part)
+		if err != nil {
+			return err
+		}
+		f.values = append(f.values, value)
+	}
+	return nil
+}
+
+func (f *enumListFlag[T]) Type() string {
+	return f.name
+}
+
+// complete offers the valid values for shell completion
+func (f *enumListFlag[T]) complete(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var matches []string
+	for _, value := range f.valid {
+		if strings.HasPrefix(strings.ToLower(string(value)), strings.ToLower(toComplete)) {
+			matches = append(matches, string(value))
+		}
+	}
+	return matches, cobra.ShellCompDirectiveNoFileComp
+}
+
+// addEnumListFlag registers the flag on cmd along with its completions
+func addEnumListFlag[T ~string](cmd *cobra.Command, f *enumListFlag[T], shorthand, usage string) {
+	cmd.Flags().VarP(f, f.name, shorthand, usage)
+	cmd.RegisterFlagCompletionFunc(f.name, f.complete)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"slices"
+	"testing"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestEnumListFlag(t *testing.T) {
+	f := newEnumListFlag("rarity", gw2api.Rarities, gw2api.ParseRarity)
+	if err := f.Set("exotic,Ascended"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := f.Set("LEGENDARY"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if expected := []gw2api.Rarity{"Exotic", "Ascended", "Legendary"}; !slices.Equal(f.values, expected) {
+		t.Errorf("values = %v, expected %v", f.values, expected)
+	}
+	if err := f.Set("rare,shiny"); err == nil {
+		t.Error("Set accepted an unknown rarity")
+	}
+
+	completions, _ := f.complete(nil, nil, "as")
+	if !slices.Equal(completions, []string{"Ascended"}) {
+		t.Errorf("completions = %v, expected Ascended", completions)
+	}
+}
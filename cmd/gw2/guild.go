@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// guildLogKinds is the --type flag of guild log
+var guildLogKinds = newEnumListFlag("type", gw2api.GuildLogKinds, gw2api.ParseGuildLogKind)
+
+// GuildLogReport is the entries of a guild's log that passed a filter, with
+// the names of the items they involve
+type GuildLogReport struct {
+	Guild   string            `json:"guild"`
+	Entries []gw2api.GuildLog `json:"entries"`
+	Items   map[int]string    `json:"items,omitempty"` // Item names by ID
+}
+
+// guildLogReport fetches a guild's log, filters it, and names the items in
+// the entries left, from the item cache when one is loaded
+func guildLogReport(ctx context.Context, guild *gw2api.Guild, filter gw2api.GuildLogFilter) (*GuildLogReport, error) {
+	log, err := client.GetGuildLog(ctx, guild.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the log of %s: %w", guild.Name, scopeError(err, "guilds"))
+	}
+
+	report := &GuildLogReport{Guild: guild.Name, Entries: filter.Apply(log)}
+	if ids := gw2api.GuildLogItemIDs(report.Entries); len(ids) > 0 {
+		if items, err := client.GetItems(ctx, ids); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to get item names: %v\n", err)
+		} else {
+			report.Items = make(map[int]string, len(items))
+			for _, item := range items {
+				report.Items[item.ID] = item.Name
+			}
+		}
+	}
+	return report, nil
+}
+
+// findGuild returns one of the account's guilds by its ID, name or tag, ignoring
+// case. The log and most other guild endpoints only answer for members.
+func findGuild(ctx context.Context, name string) (*gw2api.Guild, error) {
+	account, err := client.GetAccount(ctx)
+	if err != nil {
+		return nil, scopeError(err, "account")
+	}
+	var names []string
+	for _, id := range account.Guilds {
+		guild, err := client.GetGuild(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get guild %s: %w", id, err)
+		}
+		if strings.EqualFold(guild.ID, name) || strings.EqualFold(guild.Name, name) || strings.EqualFold(guild.Tag, name) {
+			return guild, nil
+		}
+		names = append(names, fmt.Sprintf("%s [%s]", guild.Name, guild.Tag))
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("the account is not in any guild")
+	}
+	return nil, fmt.Errorf("the account is not in a guild named %q; its guilds are %s", name, strings.Join(names, ", "))
+}
+
+func outputGuildLogTable(report *GuildLogReport) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Time", "Type", "Entry")
+	for _, entry := range report.Entries {
+		table.Append(
+			entry.Time.Local().Format("2006-01-02 15:04"),
+			entry.Type,
+			truncate(entry.Summary(report.Items), 90),
+		)
+	}
+	table.Render()
+}
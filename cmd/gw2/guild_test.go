@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestGuildLogReport(t *testing.T) {
+	const guildID = "4BBB52AA-D768-4FC6-8EDE-C299F2822F0F"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/account":
+			w.Write([]byte(`{"name": "Banker.4821", "guilds": ["` + guildID + `"]}`))
+		case "/v2/guild/" + guildID:
+			w.Write([]byte(`{"id": "` + guildID + `", "name": "Bank of Tyria", "tag": "BANK"}`))
+		case "/v2/guild/" + guildID + "/log":
+			w.Write([]byte(`[
+				{"id": 3, "time": "2026-10-15T20:41:12Z", "user": "Banker.4821", "type": "stash", "operation": "deposit", "item_id": 19721, "count": 10, "coins": 0},
+				{"id": 2, "time": "2026-10-15T20:00:00Z", "user": "Officer.1357", "type": "motd", "motd": "Hello"},
+				{"id": 1, "time": "2026-09-01T20:00:00Z", "user": "Banker.4821", "type": "stash", "operation": "withdraw", "item_id": 19976, "count": 1, "coins": 0}
+			]`))
+		case "/v2/items":
+			w.Write([]byte(`[{"id": 19721, "name": "Glob of Ectoplasm"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	saved := client
+	client = gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithAPIKey("key"), gw2api.WithRetries(0))
+	defer func() { client = saved }()
+
+	ctx := context.Background()
+	if _, err := findGuild(ctx, "Other Guild"); err == nil || !strings.Contains(err.Error(), "Bank of Tyria [BANK]") {
+		t.Errorf("finding a guild the account is not in: %v", err)
+	}
+	guild, err := findGuild(ctx, "bank")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	filter := gw2api.GuildLogFilter{
+		Kinds: []gw2api.GuildLogKind{gw2api.GuildLogStash},
+		Since: time.Date(2026, 10, 9, 0, 0, 0, 0, time.UTC),
+	}
+	report, err := guildLogReport(ctx, guild, filter)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Guild != "Bank of Tyria" || len(report.Entries) != 1 || report.Entries[0].ID != 3 {
+		t.Fatalf("report = %+v", report)
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { outputData(report) })
+	if !strings.Contains(out, "Banker.4821 deposited 10 Glob of Ectoplasm") {
+		t.Errorf("table output does not name the item:\n%s", out)
+	}
+	out, _ = captureOutput(t, "json", false, func() { outputData(report) })
+	for _, want := range []string{`"operation": "deposit"`, `"19721": "Glob of Ectoplasm"`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("JSON output does not contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// defaultMaxIDs caps how many IDs one command may request, so piping a whole
+// ID list into a get command doesn't start thousands of API calls by accident
+const defaultMaxIDs = 10000
+
+// idArgsHelp describes the argument syntax accepted by parseIDs
+const idArgsHelp = `IDs can be given as:
+  123            a single ID
+  1,2,3          a comma-separated list
+  100-150        an inclusive range
+  @ids.txt       a file with one ID, list or range per line
+  -              the same, read from stdin
+
+Blank lines and lines starting with # are ignored in files and stdin. Duplicate
+IDs are requested once. At most --max-ids IDs are accepted per command.`
+
+// parseIDs reads the IDs given as command arguments, see idArgsHelp
+func parseIDs(cmd *cobra.Command, args []string) ([]int, error) {
+	return readIDs(args, cmd.InOrStdin(), maxIDs, parseID)
+}
+
+// parseIDsOrChatLinks is parseIDs that also accepts chat links of the given type
+func parseIDsOrChatLinks(cmd *cobra.Command, args []string, want gw2api.ChatLinkType) ([]int, error) {
+	return readIDs(args, cmd.InOrStdin(), maxIDs, func(s string) (int, error) {
+		return gw2api.ParseIDOrChatLink(s, want)
+	})
+}
+
+func parseID(s string) (int, error) {
+	id, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid ID %q", s)
+	}
+	return id, nil
+}
+
+// idList collects IDs in the order given, dropping duplicates and enforcing a limit
+type idList struct {
+	ids   []int
+	seen  map[int]bool
+	limit int
+	parse func(string) (int, error)
+}
+
+// readIDs parses args into a deduplicated list of at most limit IDs. A limit
+// of zero or less means no limit.
+func readIDs(args []string, stdin io.Reader, limit int, parse func(string) (int, error)) ([]int, error) {
+	list := &idList{seen: make(map[int]bool), limit: limit, parse: parse}
+	for _, arg := range args {
+		switch {
+		case arg == "-":
+			if err := list.addLines(stdin, "stdin"); err != nil {
+				return nil, err
+			}
+		case strings.HasPrefix(arg, "@"):
+			path := arg[1:]
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read IDs: %w", err)
+			}
+			err = list.addLines(f, path)
+			f.Close()
+			if err != nil {
+				return nil, err
+			}
+		default:
+			if err := list.addList(arg); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if len(list.ids) == 0 {
+		return nil, fmt.Errorf("no IDs given")
+	}
+	return list.ids, nil
+}
+
+// addLines adds the IDs on each line of r, naming source in errors
+func (l *idList) addLines(r io.Reader, source string) error {
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		if err := l.addList(text); err != nil {
+			return fmt.Errorf("%s line %d: %w", source, line, err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read IDs from %s: %w", source, err)
+	}
+	return nil
+}
+
+// addList adds a comma-separated list of IDs and ranges
+func (l *idList) addList(s string) error {
+	for _, token := range strings.Split(s, ",") {
+		token = strings.TrimSpace(token)
+		if token == "" {
+			continue
+		}
+		if first, last, ok := strings.Cut(token, "-"); ok && first != "" {
+			if err := l.addRange(first, last); err != nil {
+				return err
+			}
+			continue
+		}
+		id, err := l.parse(token)
+		if err != nil {
+			return err
+		}
+		if err := l.add(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addRange adds every ID from first to last inclusive
+func (l *idList) addRange(first, last string) error {
+	lo, err := strconv.Atoi(strings.TrimSpace(first))
+	if err != nil {
+		return fmt.Errorf("invalid range %s-%s", first, last)
+	}
+	hi, err := strconv.Atoi(strings.TrimSpace(last))
+	if err != nil || hi < lo {
+		return fmt.Errorf("invalid range %s-%s", first, last)
+	}
+	// Check before expanding, so a typo like 1-999999999 fails fast
+	if l.limit > 0 && hi-lo+1 > l.limit {
+		return l.tooMany(hi - lo + 1)
+	}
+	for id := lo; id <= hi; id++ {
+		if err := l.add(id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (l *idList) add(id int) error {
+	if l.seen[id] {
+		return nil
+	}
+	if l.limit > 0 && len(l.ids) >= l.limit {
+		return l.tooMany(len(l.ids) + 1)
+	}
+	l.seen[id] = true
+	l.ids = append(l.ids, id)
+	return nil
+}
+
+func (l *idList) tooMany(count int) error {
+	return fmt.Errorf("too many IDs: %d or more given, the limit is %d per command (raise it with --max-ids)", count, l.limit)
+}
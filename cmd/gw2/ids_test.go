@@ -0,0 +1,58 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReadIDs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ids.txt")
+	if err := os.WriteFile(path, []byte("# wanted items\n7\n\n8-9\n2,3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	stdin := strings.NewReader("10\n3\n")
+
+	ids, err := readIDs([]string{"3,1", "4-6", "@" + path, "-", "1"}, stdin, 0, parseID)
+	if err != nil {
+		t.Fatalf("readIDs: %v", err)
+	}
+	// Duplicates keep their first position
+	if expected := []int{3, 1, 4, 5, 6, 7, 8, 9, 2, 10}; !slices.Equal(ids, expected) {
+		t.Errorf("ids = %v, expected %v", ids, expected)
+	}
+}
+
+func TestReadIDsErrors(t *testing.T) {
+	tests := map[string]struct {
+		args     []string
+		stdin    string
+		limit    int
+		expected string
+	}{
+		"bad token":         {args: []string{"1,abc"}, expected: `invalid ID "abc"`},
+		"reversed range":    {args: []string{"9-3"}, expected: "invalid range 9-3"},
+		"bad range":         {args: []string{"3-x"}, expected: "invalid range 3-x"},
+		"bad stdin line":    {args: []string{"-"}, stdin: "1\nnope\n", expected: "stdin line 2"},
+		"missing file":      {args: []string{"@no-such-file"}, expected: "failed to read IDs"},
+		"nothing given":     {args: []string{"-"}, stdin: "# empty\n", expected: "no IDs given"},
+		"over limit":        {args: []string{"1,2,3"}, limit: 2, expected: "limit is 2"},
+		"huge range":        {args: []string{"1-999999999"}, limit: 10, expected: "limit is 10"},
+		"unique over limit": {args: []string{"1,1,1,2,2,3"}, limit: 2, expected: "limit is 2"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := readIDs(tt.args, strings.NewReader(tt.stdin), tt.limit, parseID)
+			if err == nil || !strings.Contains(err.Error(), tt.expected) {
+				t.Errorf("error = %v, expected it to mention %q", err, tt.expected)
+			}
+		})
+	}
+
+	// Duplicates don't count towards the limit
+	if ids, err := readIDs([]string{"1,1,2,2"}, nil, 2, parseID); err != nil || len(ids) != 2 {
+		t.Errorf("readIDs with duplicates at the limit = %v, %v", ids, err)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// itemSources is the --source flag of account watch
+var itemSources = newEnumListFlag("source", gw2api.ItemSources, gw2api.ParseItemSource)
+
+// watchAccountItems prints each change in the account's items until
+// interrupted
+func watchAccountItems(interval time.Duration, sources []gw2api.ItemSource) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	deltas, err := client.WatchAccountItems(ctx, interval, sources)
+	if err != nil {
+		return scopeError(err, "inventories")
+	}
+	if outputFormat == "table" {
+		fmt.Printf("%s Watching items every %s\n", time.Now().Format(time.DateTime), interval)
+	}
+
+	names := make(map[int]string)
+	for delta := range deltas {
+		name, found := names[delta.ItemID]
+		if !found {
+			name = fmt.Sprintf("Item %d", delta.ItemID)
+			if item, err := client.GetItem(ctx, delta.ItemID); err == nil {
+				name = item.Name
+			}
+			names[delta.ItemID] = name
+		}
+		printItemDelta(os.Stdout, delta, name)
+	}
+	return nil
+}
+
+// printItemDelta prints a delta as a line, or as a JSON object with the
+// item's name for --output json
+func printItemDelta(w io.Writer, delta gw2api.ItemDelta, name string) {
+	if outputFormat != "table" {
+		data, _ := json.Marshal(struct {
+			gw2api.ItemDelta
+			Name string `json:"name"`
+		}{delta, name})
+		fmt.Fprintln(w, string(data))
+		return
+	}
+
+	where := string(delta.Source)
+	if delta.Character != "" {
+		where = delta.Character
+	}
+	change := fmt.Sprintf("%+d", delta.Change)
+	fmt.Fprintf(w, "%s %s %s (%s)\n", delta.Time.Format(time.DateTime), colorSign(change, float64(delta.Change)), name, where)
+}
+
+// itemSourceNames lists the item sources for help text
+func itemSourceNames() string {
+	names := make([]string, len(gw2api.ItemSources))
+	for i, source := range gw2api.ItemSources {
+		names[i] = string(source)
+	}
+	return strings.Join(names, ", ")
+}
@@ -3,8 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +18,7 @@ import (
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"j5.nz/gw2/internal/gw2api"
+	"j5.nz/gw2/internal/snapshot"
 )
 
 // Global flags
@@ -20,12 +27,23 @@ var (
 	language     string
 	timeout      int
 	apiKey       string
+	keyName      string
 	verbose      bool
+	dataDir      string
+	noCache      bool
+	rateLimit    float64
+	maxIDs       int
+	failEmpty    bool
+	priceTTL     time.Duration
+	noPriceCache bool
 )
 
 // Global client
 var client *gw2api.Client
 
+// prices is the price cache file the client uses, nil when it is disabled
+var prices *diskPriceCache
+
 func main() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -36,8 +54,39 @@ func main() {
 var rootCmd = &cobra.Command{
 	Use:   "gw2api",
 	Short: "Guild Wars 2 API command-line client",
+	// main prints the error returned by a command
+	SilenceErrors: true,
 	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// Initialize client with global flags
+		// Completion requests run on every tab press, so they skip loading the
+		// data cache; completions needing the API set up their own client
+		if cmd.Name() == cobra.ShellCompRequestCmd || cmd.Name() == cobra.ShellCompNoDescRequestCmd {
+			return
+		}
+
+		// Arguments have been validated, so errors from here on are not usage errors
+		cmd.SilenceUsage = true
+
+		// Merge flags, environment and config file into the globals
+		cfg, err := resolveConfig(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		apiKey = cfg.APIKey
+		language = cfg.Language
+		outputFormat = cfg.Output
+		timeout = cfg.Timeout
+
+		// Catch mistyped keys here rather than as a confusing error from
+		// whichever endpoint the command happens to call first
+		if apiKey != "" {
+			if err := gw2api.ValidateAPIKeyFormat(apiKey); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n%s\n", err, apiKeyHint)
+				os.Exit(1)
+			}
+		}
+
+		// Initialize client with the merged settings
 		var opts []gw2api.ClientOption
 
 		if timeout > 0 {
@@ -48,32 +97,77 @@ var rootCmd = &cobra.Command{
 			opts = append(opts, gw2api.WithAPIKey(apiKey))
 		}
 
+		if len(cfg.APIKeys) > 0 {
+			opts = append(opts, gw2api.WithKeyRing(gw2api.NewKeyRing(cfg.APIKeys)))
+		}
+
+		if cfg.RateLimit > 0 {
+			opts = append(opts, gw2api.WithRateLimit(cfg.RateLimit))
+		}
+
 		if language != "" {
-			switch language {
-			case "en":
-				opts = append(opts, gw2api.WithLanguage(gw2api.LanguageEnglish))
-			case "es":
-				opts = append(opts, gw2api.WithLanguage(gw2api.LanguageSpanish))
-			case "de":
-				opts = append(opts, gw2api.WithLanguage(gw2api.LanguageGerman))
-			case "fr":
-				opts = append(opts, gw2api.WithLanguage(gw2api.LanguageFrench))
-			case "zh":
-				opts = append(opts, gw2api.WithLanguage(gw2api.LanguageChinese))
-			default:
+			lang, ok := gw2api.ParseLanguage(language)
+			if !ok {
 				fmt.Fprintf(os.Stderr, "Unsupported language: %s\n", language)
 				os.Exit(1)
 			}
+			opts = append(opts, gw2api.WithLanguage(lang))
 		}
 
 		opts = append(opts, gw2api.WithUserAgent("gw2api-cli/1.0"))
-		
-		// Enable comprehensive data cache if data directory exists
-		if _, err := os.Stat("data"); err == nil {
-			opts = append(opts, gw2api.WithDataCache("data"))
+
+		// Enable the data cache from the configured directory, falling back to ./data
+		cacheDir := cfg.DataDir
+		if cacheDir != "" {
+			if _, err := os.Stat(cacheDir); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: data directory: %v\n", err)
+				os.Exit(1)
+			}
+		} else if _, err := os.Stat("data"); err == nil {
+			cacheDir = "data"
+		}
+		if noCache {
+			cacheDir = ""
+		}
+		if cacheDir != "" {
+			opts = append(opts, gw2api.WithDataCache(cacheDir))
+		}
+
+		// Reuse recent prices across invocations unless live data was asked for
+		if !noPriceCache && cfg.PriceTTL > 0 {
+			if opened, err := openPriceCacheFile(cfg.PriceTTL); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: price cache: %v\n", err)
+			} else {
+				prices = opened
+				opts = append(opts, gw2api.WithPriceCache(prices, cfg.PriceTTL))
+			}
+		}
+
+		client, err = gw2api.NewClientE(opts...)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+
+		// A key given on the command line is checked with the API up front
+		if cmd.Flags().Changed("api-key") {
+			if err := checkAPIKey(client, time.Duration(timeout)*time.Second); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Language: %s\n", client.Language())
+			logCacheLoad(cacheDir)
+		}
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		if prices == nil {
+			return
+		}
+		if err := prices.save(); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to save price cache: %v\n", err)
 		}
-		
-		client = gw2api.NewClient(opts...)
 	},
 }
 
@@ -83,12 +177,74 @@ func init() {
 	rootCmd.PersistentFlags().StringVarP(&language, "lang", "l", "en", "Language (en, es, de, fr, zh)")
 	rootCmd.PersistentFlags().IntVarP(&timeout, "timeout", "t", 30, "Request timeout in seconds")
 	rootCmd.PersistentFlags().StringVar(&apiKey, "api-key", "", "API key for authenticated endpoints")
+	rootCmd.PersistentFlags().StringVar(&keyName, "key-name", "", "Use the named key from api_keys in the config file")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output")
+	rootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file (default ~/.config/gw2api/config.yaml)")
+	rootCmd.PersistentFlags().StringVar(&dataDir, "data-dir", "", "Directory containing cached game data")
+	rootCmd.PersistentFlags().StringVar(&dataDir, "cache-dir", "", "Alias for --data-dir")
+	rootCmd.PersistentFlags().BoolVar(&noCache, "no-cache", false, "Don't load cached game data, always query the API")
+	rootCmd.PersistentFlags().Float64Var(&rateLimit, "rate-limit", 0, "Maximum requests per second")
+	rootCmd.PersistentFlags().IntVar(&maxIDs, "max-ids", defaultMaxIDs, "Maximum IDs a single command may request (0 for no limit)")
+	rootCmd.PersistentFlags().DurationVar(&priceTTL, "price-ttl", defaultPriceTTL, "How long to reuse trading post prices from earlier commands (0 to disable)")
+	rootCmd.PersistentFlags().BoolVar(&noPriceCache, "no-price-cache", false, "Always fetch live trading post prices")
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Don't color table output, which is only colored on a terminal")
+	rootCmd.PersistentFlags().BoolVar(&failEmpty, "fail-empty", false, "Exit with status 3 when a list, get or search command finds nothing")
 
 	// Command-specific flags
+	buildCmd.Flags().Bool("watch", false, "Keep polling and announce new builds")
+	buildCmd.Flags().Duration("interval", time.Minute, "Time between polls with --watch")
+	buildCmd.Flags().String("exec", "", "Shell command to run for each new build with --watch")
 	itemsSearchCmd.Flags().StringP("name", "n", "", "Search for items containing this name (case-insensitive)")
-	itemsSearchCmd.Flags().StringP("rarity", "r", "", "Filter by rarity (Basic, Fine, Masterwork, Rare, Exotic, Ascended, Legendary)")
+	addEnumListFlag(itemsSearchCmd, searchRarities, "r", "Filter by rarity, comma-separated (Basic, Fine, Masterwork, Rare, Exotic, Ascended, Legendary)")
+	addEnumListFlag(itemsSearchCmd, searchTypes, "", "Filter by item type, comma-separated (Armor, Weapon, Trinket, ...)")
 	itemsSearchCmd.Flags().IntP("limit", "", 50, "Maximum number of results to return (0 = no limit)")
+	itemsSearchCmd.Flags().Bool("tradable", false, "Only show items that can be listed on the trading post")
+	skinsSearchCmd.Flags().StringP("name", "n", "", "Search for skins containing this name (case-insensitive)")
+	skinsSearchCmd.Flags().StringSlice("type", nil, "Filter by skin type or weapon/armor type, comma-separated (Weapon, Armor, Back, Sword, Helm, ...)")
+	skinsSearchCmd.Flags().StringSlice("weight", nil, "Filter armor skins by weight class, comma-separated (Light, Medium, Heavy, Clothing)")
+	addEnumListFlag(skinsSearchCmd, skinRarities, "r", "Filter by rarity, comma-separated (Basic, Fine, Masterwork, Rare, Exotic, Ascended, Legendary)")
+	skinsSearchCmd.Flags().Int("limit", 50, "Maximum number of results to return")
+	configInitCmd.Flags().Bool("force", false, "Overwrite an existing config file")
+	for _, cmd := range []*cobra.Command{achievementsListCmd, currenciesListCmd, itemsListCmd, worldsListCmd, skillsListCmd} {
+		cmd.Flags().Bool("count", false, "Only print how many IDs there are, without downloading the list")
+	}
+	accountNearlyDoneCmd.Flags().Int("limit", 20, "Maximum number of achievements to list (0 for all)")
+	accountDyesMissingCmd.Flags().String("max-price", "", "Leave out dyes costing more than this, such as 5g or 1g 50s")
+	addEnumListFlag(accountDyesMissingCmd, dyeRarities, "r", "Filter by dye rarity, comma-separated (Starter, Common, Uncommon, Rare, Exclusive)")
+	accountRaidsCmd.Flags().Bool("value", false, "Estimate what the encounters not yet cleared this week are worth")
+	accountRaidsCmd.Flags().String("magnetite-value", "", "Value a Magnetite Shard at this much coin, such as 20s")
+	accountRaidsCmd.Flags().String("gaeting-value", "", "Value a Gaeting Crystal at this much coin, such as 20s")
+	accountWatchCmd.Flags().Duration("interval", time.Minute, "Time between polls")
+	addEnumListFlag(accountWatchCmd, itemSources, "", "Places to watch, comma-separated ("+itemSourceNames()+")")
+	addEnumListFlag(guildLogCmd, guildLogKinds, "", "Only show entries of these types, comma-separated (stash, treasury, kick, rank_change, ...)")
+	guildLogCmd.Flags().String("user", "", "Only show entries naming this account, as the member or the one who acted")
+	guildLogCmd.Flags().Int("since-days", 0, "Only show entries from the last this many days (0 for the whole log)")
+	accountSnapshotCmd.Flags().String("out", "", "Snapshot file to write (default snap-YYYY-MM-DD.json)")
+	accountSnapshotCmd.Flags().Int("concurrency", snapshot.DefaultConcurrency, "Maximum concurrent API requests")
+	charactersGearCmd.ValidArgsFunction = completeCharacterName
+	charactersNextCraftsCmd.ValidArgsFunction = completeCharacterName
+	charactersGearCmd.Flags().Int("tab", 0, "Equipment tab to show (default the active tab)")
+	commerceDepthCmd.Flags().IntP("quantity", "q", 250, "Number of items to buy or sell")
+	commerceOrdersCmd.Flags().DurationVar(&staleOrderAge, "stale", 30*24*time.Hour, "Flag orders open at least this long as probably stale (0 to disable)")
+	skillsGetCmd.Flags().IntSlice("traits", nil, "Show facts as changed by these selected trait IDs, comma-separated")
+	recipesSearchCmd.Flags().StringSlice("discipline", nil, "Filter by crafting discipline, comma-separated (case-insensitive)")
+	recipesSearchCmd.Flags().Int("min-rating", 0, "Minimum crafting rating")
+	recipesSearchCmd.Flags().Int("max-rating", 0, "Maximum crafting rating (0 = no maximum)")
+	recipesSearchCmd.Flags().String("item", "", "Only recipes whose output item name contains this text")
+	recipesSearchCmd.Flags().Int("limit", 50, "Maximum number of results to return (0 = no limit)")
+	rawCmd.Flags().StringSlice("ids", nil, "IDs to request, comma-separated")
+	rawCmd.Flags().Int("page", 0, "Page to request, counting from 0")
+	rawCmd.Flags().Int("page-size", 0, "Results per page, up to 200")
+	rawCmd.Flags().StringArray("param", nil, "Extra query parameter as name=value, repeatable")
+
+	// Completions for the global flags; enum flags register their own
+	rootCmd.RegisterFlagCompletionFunc("output", completeValues(outputFormats))
+	rootCmd.RegisterFlagCompletionFunc("lang", completeValues(languages))
+
+	// Commands taking IDs share the same argument syntax
+	for _, cmd := range []*cobra.Command{achievementsGetCmd, currenciesGetCmd, itemsGetCmd, worldsGetCmd, skillsGetCmd, skinsGetCmd, recipesGetCmd, commercePricesCmd} {
+		cmd.Long = cmd.Short + "\n\n" + idArgsHelp
+	}
 
 	// Add all subcommands
 	rootCmd.AddCommand(
@@ -98,8 +254,22 @@ func init() {
 		itemsCmd,
 		worldsCmd,
 		skillsCmd,
+		skinsCmd,
+		recipesCmd,
 		commerceCmd,
+		worldbossesCmd,
+		accountCmd,
+		charactersCmd,
+		pvpCmd,
+		wvwCmd,
+		guildCmd,
+		vaultCmd,
+		cacheCmd,
+		configCmd,
 		versionCmd,
+		completionCmd,
+		docsCmd,
+		rawCmd,
 	)
 
 	// Add subcommands to their parents
@@ -108,7 +278,24 @@ func init() {
 	itemsCmd.AddCommand(itemsListCmd, itemsGetCmd, itemsSearchCmd)
 	worldsCmd.AddCommand(worldsListCmd, worldsGetCmd, worldsAllCmd)
 	skillsCmd.AddCommand(skillsListCmd, skillsGetCmd)
-	commerceCmd.AddCommand(commercePricesCmd)
+	skinsCmd.AddCommand(skinsGetCmd, skinsSearchCmd)
+	recipesCmd.AddCommand(recipesGetCmd, recipesSearchCmd)
+	commerceCmd.AddCommand(commercePricesCmd, commerceDepthCmd, commerceOrdersCmd)
+	worldbossesCmd.AddCommand(worldbossesNextCmd)
+	accountCmd.AddCommand(accountAPCmd, accountMasteriesCmd, accountLegendariesCmd, accountRaidsCmd, accountBankCmd, accountMaterialsCmd, accountNearlyDoneCmd, accountMissingCmd, accountDyesCmd, accountSnapshotCmd, accountDiffCmd, accountWvWCmd, accountWatchCmd)
+	charactersCmd.AddCommand(charactersGearCmd, charactersBirthdaysCmd, charactersNextCraftsCmd)
+	pvpCmd.AddCommand(pvpStatsCmd)
+	wvwCmd.AddCommand(wvwMapCmd)
+	guildCmd.AddCommand(guildLogCmd)
+	vaultCmd.AddCommand(vaultPlanCmd)
+	vaultCmd.AddCommand(vaultClaimableCmd)
+	cacheCmd.AddCommand(cacheStatsCmd, cacheStatusCmd)
+	cacheCmd.AddCommand(cacheCompactCmd)
+	configCmd.AddCommand(configInitCmd, configShowCmd, configKeysCmd)
+	docsCmd.AddCommand(docsManCmd)
+	accountDyesCmd.AddCommand(accountDyesMissingCmd)
+	buildCmd.AddCommand(buildDecodeCmd)
+	accountMissingCmd.AddCommand(accountMissingOutfitsCmd, accountMissingGlidersCmd, accountMissingMountSkinsCmd, accountMissingMinisCmd, accountMissingNoveltiesCmd)
 }
 
 // Version command
@@ -125,7 +312,23 @@ var versionCmd = &cobra.Command{
 var buildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Get current game build",
+	Long: `Get current game build.
+
+With --watch, poll the build until interrupted and announce each new one. The
+last announced build is remembered next to the config file, so a restart only
+announces builds released since. --exec runs a shell command for each new
+build, with the builds in GW2_BUILD and GW2_PREVIOUS_BUILD.`,
 	Run: func(cmd *cobra.Command, args []string) {
+		if watch, _ := cmd.Flags().GetBool("watch"); watch {
+			interval, _ := cmd.Flags().GetDuration("interval")
+			command, _ := cmd.Flags().GetString("exec")
+			if err := watchBuild(interval, command); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
+
 		ctx := context.Background()
 		build, err := client.GetBuild(ctx)
 		if err != nil {
@@ -151,6 +354,10 @@ var achievementsListCmd = &cobra.Command{
 	Short: "List all achievement IDs",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
+		if count, _ := cmd.Flags().GetBool("count"); count {
+			outputCount(ctx, "/v2/achievements")
+			return
+		}
 		ids, err := client.GetAchievementIDs(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -165,28 +372,57 @@ var achievementsGetCmd = &cobra.Command{
 	Use:   "get [id...]",
 	Short: "Get specific achievements",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ids := parseIDs(args)
+		ids, err := parseIDs(cmd, args)
+		if err != nil {
+			return err
+		}
 
 		if len(ids) == 1 {
 			achievement, err := client.GetAchievement(ctx, ids[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			outputData(achievement)
+			outputData(newAchievementRow(ctx, achievement))
 		} else {
 			achievements, err := client.GetAchievements(ctx, ids)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
+			}
+			rows := make([]*AchievementRow, len(achievements))
+			for i, achievement := range achievements {
+				rows[i] = newAchievementRow(ctx, achievement)
 			}
-			outputData(achievements)
+			outputData(rows)
 		}
+		return nil
 	},
 }
 
+// AchievementRow is an achievement with the names of every achievement that
+// must be completed before it, prerequisites first
+type AchievementRow struct {
+	*gw2api.Achievement
+	Requires []string `json:"requires,omitempty"`
+}
+
+func newAchievementRow(ctx context.Context, achievement *gw2api.Achievement) *AchievementRow {
+	row := &AchievementRow{Achievement: achievement}
+	if len(achievement.Prerequisites) == 0 {
+		return row
+	}
+	chain, err := client.GetAchievementChain(ctx, achievement.ID)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get prerequisites of achievement %d: %v\n", achievement.ID, err)
+		return row
+	}
+	for _, prerequisite := range chain {
+		row.Requires = append(row.Requires, prerequisite.Name)
+	}
+	return row
+}
+
 // Placeholder commands
 var currenciesCmd = &cobra.Command{Use: "currencies", Short: "Currency operations"}
 var currenciesListCmd = &cobra.Command{
@@ -194,6 +430,10 @@ var currenciesListCmd = &cobra.Command{
 	Short: "List all currency IDs",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
+		if count, _ := cmd.Flags().GetBool("count"); count {
+			outputCount(ctx, "/v2/currencies")
+			return
+		}
 		ids, err := client.GetCurrencyIDs(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -207,25 +447,27 @@ var currenciesGetCmd = &cobra.Command{
 	Use:   "get [id...]",
 	Short: "Get specific currencies",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ids := parseIDs(args)
+		ids, err := parseIDs(cmd, args)
+		if err != nil {
+			return err
+		}
 
 		if len(ids) == 1 {
 			currency, err := client.GetCurrency(ctx, ids[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 			outputData(currency)
 		} else {
 			currencies, err := client.GetCurrencies(ctx, ids)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 			outputData(currencies)
 		}
+		return nil
 	},
 }
 var currenciesAllCmd = &cobra.Command{
@@ -249,6 +491,10 @@ var itemsListCmd = &cobra.Command{
 	Short: "List item IDs",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
+		if count, _ := cmd.Flags().GetBool("count"); count {
+			outputCount(ctx, "/v2/items")
+			return
+		}
 		ids, err := client.GetItemIDs(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -259,35 +505,61 @@ var itemsListCmd = &cobra.Command{
 }
 
 var itemsGetCmd = &cobra.Command{
-	Use:   "get [id...]",
-	Short: "Get items by ID",
+	Use:   "get [id|chat link...]",
+	Short: "Get items by ID or chat link",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ids := parseIDs(args)
+		ids, err := parseIDsOrChatLinks(cmd, args, gw2api.ChatLinkItem)
+		if err != nil {
+			return err
+		}
 
 		if len(ids) == 1 {
 			item, err := client.GetItem(ctx, ids[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 			outputData(item)
 		} else {
 			items, err := client.GetItems(ctx, ids)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 			outputData(items)
 		}
+		if outputFormat == "table" {
+			outputVendorOffers(ctx, ids)
+		}
+		return nil
 	},
 }
 
+// outputVendorOffers lists the vendors selling each item after the item table.
+// Vendors are only known when the vendors data file is loaded.
+func outputVendorOffers(ctx context.Context, ids []int) {
+	for _, id := range ids {
+		offers, err := client.GetVendorOffers(ctx, id)
+		if err != nil || len(offers) == 0 {
+			continue
+		}
+		fmt.Printf("\nItem %d is available from vendors:\n", id)
+		for _, offer := range offers {
+			fmt.Printf("  for %s\n", offer)
+		}
+	}
+}
+
+// Filters for items search, checked as the flags are parsed
+var (
+	searchRarities = newEnumListFlag("rarity", gw2api.Rarities, gw2api.ParseRarity)
+	searchTypes    = newEnumListFlag("type", gw2api.ItemTypes, gw2api.ParseItemType)
+)
+
 var itemsSearchCmd = &cobra.Command{
 	Use:   "search",
-	Short: "Search items by name and/or rarity",
-	Long: `Search for items with optional filtering by name and rarity.
+	Short: "Search items by name, rarity and type",
+	Long: `Search for items with optional filtering by name, rarity and type.
 	
 Examples:
   # Search for items with "sword" in the name
@@ -298,28 +570,33 @@ Examples:
   
   # Search for exotic swords (combining filters)
   gw2api items search --name sword --rarity exotic
+
+  # Search for ascended or legendary trinkets
+  gw2api items search --rarity ascended,legendary --type trinket
   
+  # Only items that can be sold on the trading post
+  gw2api items search --name "berserker" --tradable
+
   # Limit results to 10 items
   gw2api items search --name "berserker" --limit 10`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
 
 		name, _ := cmd.Flags().GetString("name")
-		rarity, _ := cmd.Flags().GetString("rarity")
 		limit, _ := cmd.Flags().GetInt("limit")
+		tradable, _ := cmd.Flags().GetBool("tradable")
 
-		if name == "" && rarity == "" {
-			fmt.Fprintf(os.Stderr, "Error: At least one search criteria (--name or --rarity) must be provided\n")
+		if name == "" && len(searchRarities.values) == 0 && len(searchTypes.values) == 0 {
+			fmt.Fprintf(os.Stderr, "Error: At least one search criteria (--name, --rarity or --type) must be provided\n")
 			os.Exit(1)
 		}
 
 		options := gw2api.ItemSearchOptions{
-			Name:  name,
-			Limit: limit,
-		}
-
-		if rarity != "" {
-			options.Rarities = []string{rarity}
+			Name:         name,
+			RarityFilter: searchRarities.values,
+			TypeFilter:   searchTypes.values,
+			Limit:        limit,
+			TradableOnly: tradable,
 		}
 
 		items, err := client.SearchItems(ctx, options)
@@ -328,11 +605,6 @@ Examples:
 			os.Exit(1)
 		}
 
-		if len(items) == 0 {
-			fmt.Println("No items found matching the search criteria")
-			return
-		}
-
 		outputData(items)
 	},
 }
@@ -343,6 +615,10 @@ var worldsListCmd = &cobra.Command{
 	Short: "List all world IDs",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
+		if count, _ := cmd.Flags().GetBool("count"); count {
+			outputCount(ctx, "/v2/worlds")
+			return
+		}
 		ids, err := client.GetWorldIDs(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -356,25 +632,27 @@ var worldsGetCmd = &cobra.Command{
 	Use:   "get [id...]",
 	Short: "Get specific worlds",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ids := parseIDs(args)
+		ids, err := parseIDs(cmd, args)
+		if err != nil {
+			return err
+		}
 
 		if len(ids) == 1 {
 			world, err := client.GetWorld(ctx, ids[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 			outputData(world)
 		} else {
 			worlds, err := client.GetWorlds(ctx, ids)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
 			outputData(worlds)
 		}
+		return nil
 	},
 }
 var worldsAllCmd = &cobra.Command{
@@ -398,6 +676,10 @@ var skillsListCmd = &cobra.Command{
 	Short: "List all skill IDs",
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx := context.Background()
+		if count, _ := cmd.Flags().GetBool("count"); count {
+			outputCount(ctx, "/v2/skills")
+			return
+		}
 		ids, err := client.GetSkillIDs(ctx)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
@@ -411,25 +693,100 @@ var skillsGetCmd = &cobra.Command{
 	Use:   "get [id...]",
 	Short: "Get specific skills",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ids := parseIDs(args)
+		ids, err := parseIDs(cmd, args)
+		if err != nil {
+			return err
+		}
 
-		if len(ids) == 1 {
-			skill, err := client.GetSkill(ctx, ids[0])
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-			outputData(skill)
-		} else {
-			skills, err := client.GetSkills(ctx, ids)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
-			}
-			outputData(skills)
+		traits, _ := cmd.Flags().GetIntSlice("traits")
+
+		// Skills are always listed, even one, so JSON output has the same shape
+		// however many IDs are given
+		skills, err := client.GetSkills(ctx, ids)
+		if err != nil {
+			return err
 		}
+		for _, skill := range skills {
+			applyTraits(skill, traits)
+		}
+		outputData(skills)
+		return nil
+	},
+}
+
+// applyTraits replaces a skill's facts with those shown when the traits are
+// selected
+func applyTraits(skill *gw2api.Skill, traits []int) {
+	if len(traits) == 0 {
+		return
+	}
+	skill.Facts = skill.RenderFacts(traits)
+	skill.TraitedFacts = nil
+}
+
+var skinsCmd = &cobra.Command{Use: "skins", Short: "Skin operations"}
+var skinsGetCmd = &cobra.Command{
+	Use:   "get [id...]",
+	Short: "Get specific skins",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ids, err := parseIDs(cmd, args)
+		if err != nil {
+			return err
+		}
+
+		skins, err := client.GetSkins(ctx, ids)
+		if err != nil {
+			return err
+		}
+		outputData(skins)
+		return nil
+	},
+}
+
+// Rarity filter for skins search, checked as the flag is parsed
+var skinRarities = newEnumListFlag("rarity", gw2api.Rarities, gw2api.ParseRarity)
+
+var skinsSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search cached skins by name, type, weight class and rarity",
+	Long: `Search the skins in the data cache with optional filtering by name, type,
+weight class and rarity. Types match either the skin type (Weapon, Armor, Back,
+Gathering) or the weapon or armor type (Sword, Helm, ...).
+
+Examples:
+  # Search for weapon skins with "Bolt" in the name
+  gw2api skins search --name "Bolt" --type Weapon
+
+  # Search for heavy helmets
+  gw2api skins search --type Helm --weight Heavy`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+
+		name, _ := cmd.Flags().GetString("name")
+		types, _ := cmd.Flags().GetStringSlice("type")
+		weights, _ := cmd.Flags().GetStringSlice("weight")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		if name == "" && len(types) == 0 && len(weights) == 0 && len(skinRarities.values) == 0 {
+			return fmt.Errorf("at least one search criteria (--name, --type, --weight or --rarity) must be provided")
+		}
+
+		skins, err := client.SearchSkins(ctx, gw2api.SkinSearchOptions{
+			Name:          name,
+			Types:         types,
+			WeightClasses: weights,
+			Rarities:      skinRarities.values,
+			Limit:         limit,
+		})
+		if err != nil {
+			return err
+		}
+		outputData(skins)
+		return nil
 	},
 }
 
@@ -438,242 +795,2167 @@ var commercePricesCmd = &cobra.Command{
 	Use:   "prices [item_id...]",
 	Short: "Get trading post prices",
 	Args:  cobra.MinimumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		ctx := context.Background()
-		ids := parseIDs(args)
+		ids, err := parseIDs(cmd, args)
+		if err != nil {
+			return err
+		}
 
 		if len(ids) == 1 {
 			price, err := client.GetCommercePrice(ctx, ids[0])
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
 			}
-			outputData(price)
+			outputData(newPriceRow(price))
 		} else {
 			prices, err := client.GetCommercePrices(ctx, ids)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				return err
+			}
+			rows := make([]*PriceRow, len(prices))
+			for i, price := range prices {
+				rows[i] = newPriceRow(price)
 			}
-			outputData(prices)
+			outputData(rows)
 		}
+		return nil
 	},
 }
 
-// Helper functions
-func parseIDs(args []string) []int {
-	var ids []int
-	for _, arg := range args {
-		// Handle comma-separated IDs
-		for _, idStr := range strings.Split(arg, ",") {
-			id, err := strconv.Atoi(strings.TrimSpace(idStr))
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid ID: %s\n", idStr)
-				os.Exit(1)
-			}
-			ids = append(ids, id)
-		}
-	}
-	return ids
+// PriceRow is a trading post price with the figures traders derive from it,
+// so scripts reading the JSON don't have to work them out again
+type PriceRow struct {
+	*gw2api.Price
+	Derived gw2api.PriceMetrics `json:"derived"`
 }
 
-func outputIDs(ids []int) {
-	switch outputFormat {
-	case "json":
-		data, _ := json.MarshalIndent(ids, "", "  ")
-		fmt.Println(string(data))
-	case "table":
-		for _, id := range ids {
-			fmt.Println(id)
-		}
-	default:
-		data, _ := json.MarshalIndent(ids, "", "  ")
-		fmt.Println(string(data))
-	}
+func newPriceRow(price *gw2api.Price) *PriceRow {
+	return &PriceRow{Price: price, Derived: price.Metrics()}
 }
 
-func outputData(data any) {
-	switch outputFormat {
-	case "json":
-		jsonData, _ := json.MarshalIndent(data, "", "  ")
-		fmt.Println(string(jsonData))
-	case "table":
-		outputTable(data)
-	default:
-		jsonData, _ := json.MarshalIndent(data, "", "  ")
-		fmt.Println(string(jsonData))
-	}
+// MarketDepth is what buying or selling a quantity of an item instantly would
+// cost or earn, walking the order book past the best price
+type MarketDepth struct {
+	ItemID   int              `json:"item_id"`
+	Quantity int              `json:"quantity"`
+	Buy      gw2api.OrderFill `json:"buy"`  // Buying from sell listings
+	Sell     gw2api.OrderFill `json:"sell"` // Selling to buy orders, after fees
 }
 
-func outputTable(data any) {
-	switch v := data.(type) {
-	case *gw2api.Item:
-		outputItemTable([]*gw2api.Item{v})
-	case []*gw2api.Item:
-		outputItemTable(v)
-	case *gw2api.Achievement:
-		outputAchievementTable([]*gw2api.Achievement{v})
-	case []*gw2api.Achievement:
-		outputAchievementTable(v)
-	case *gw2api.Currency:
-		outputCurrencyTable([]*gw2api.Currency{v})
-	case []*gw2api.Currency:
-		outputCurrencyTable(v)
-	case *gw2api.World:
-		outputWorldTable([]*gw2api.World{v})
-	case []*gw2api.World:
-		outputWorldTable(v)
-	case *gw2api.Skill:
-		outputSkillTable([]*gw2api.Skill{v})
-	case []*gw2api.Skill:
-		outputSkillTable(v)
-	case *gw2api.Price:
-		outputPriceTable([]*gw2api.Price{v})
-	case []*gw2api.Price:
-		outputPriceTable(v)
-	default:
-		// Fallback to simple printing
-		fmt.Printf("%+v\n", data)
-	}
+var commerceOrdersCmd = &cobra.Command{
+	Use:   "orders",
+	Short: "Summarize your open buy orders and sell listings (requires --api-key)",
+	Long: `Summarize your open trading post buy orders and sell listings by item, sorted
+by the coins committed to them. Orders open for longer than --stale are flagged,
+as they have usually been outbid or undercut.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		orders, err := client.GetOutstandingOrders(ctx)
+		if err != nil {
+			return scopeError(err, "tradingpost")
+		}
+
+		outputData(orders)
+		return nil
+	},
 }
 
-func outputItemTable(items []*gw2api.Item) {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("ID", "Name", "Type", "Rarity", "Level")
+var commerceDepthCmd = &cobra.Command{
+	Use:   "depth <item_id|chat link>",
+	Short: "Estimate the cost of buying or selling a quantity instantly",
+	Long: `Estimate the cost of buying or selling a quantity of an item instantly, walking
+the trading post order book past the best price. Sale values are after the
+listing and exchange fees.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		quantity, _ := cmd.Flags().GetInt("quantity")
+		if quantity <= 0 {
+			return fmt.Errorf("--quantity must be positive")
+		}
+		itemID, err := gw2api.ParseIDOrChatLink(args[0], gw2api.ChatLinkItem)
+		if err != nil {
+			return err
+		}
 
-	for _, item := range items {
-		name := item.Name
-		if len(name) > 50 {
-			name = name[:47] + "..."
+		ctx := context.Background()
+		listing, err := client.GetCommerceListing(ctx, itemID)
+		if err != nil {
+			return err
 		}
-		table.Append(
-			strconv.Itoa(item.ID),
-			name,
-			item.Type,
-			item.Rarity,
-			strconv.Itoa(item.Level),
-		)
-	}
-	table.Render()
+
+		outputData(&MarketDepth{
+			ItemID:   itemID,
+			Quantity: quantity,
+			Buy:      gw2api.EstimateAcquisitionCost(listing, quantity),
+			Sell:     gw2api.EstimateLiquidationValue(listing, quantity),
+		})
+		return nil
+	},
 }
 
-func outputAchievementTable(achievements []*gw2api.Achievement) {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("ID", "Name", "Type", "Points")
+var worldbossesCmd = &cobra.Command{
+	Use:     "worldbosses",
+	Aliases: []string{"worldboss", "wb"},
+	Short:   "World boss operations",
+}
 
-	for _, achievement := range achievements {
-		name := achievement.Name
-		if len(name) > 40 {
-			name = name[:37] + "..."
-		}
+// WorldBossStatus is a world boss's next spawn with today's completion state
+type WorldBossStatus struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	NextSpawn time.Time `json:"next_spawn"`
+	Done      bool      `json:"done"`
+}
 
-		points := "0"
-		if len(achievement.Tiers) > 0 {
+var worldbossesNextCmd = &cobra.Command{
+	Use:   "next",
+	Short: "List world bosses by soonest spawn",
+	Long: `List world bosses ordered by their next spawn time.
+
+When an API key is provided, bosses already defeated since daily reset are marked as done.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+
+		done := make(map[string]bool)
+		if apiKey != "" {
+			defeated, err := client.GetAccountWorldBosses(ctx)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			for _, boss := range defeated {
+				done[string(boss)] = true
+			}
+		}
+
+		var statuses []WorldBossStatus
+		for _, boss := range gw2api.UpcomingWorldBosses(time.Now()) {
+			statuses = append(statuses, WorldBossStatus{
+				ID:        boss.ID,
+				Name:      boss.Name,
+				NextSpawn: boss.NextSpawn,
+				Done:      done[boss.ID],
+			})
+		}
+
+		outputData(statuses)
+	},
+}
+
+var accountCmd = &cobra.Command{
+	Use:   "account",
+	Short: "Account operations (requires --api-key)",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.PersistentPreRun(cmd, args)
+		if apiKey == "" {
+			fmt.Fprintf(os.Stderr, "Error: account commands require --api-key\n")
+			os.Exit(1)
+		}
+	},
+}
+
+var vaultCmd = &cobra.Command{
+	Use:     "vault",
+	Aliases: []string{"wizardsvault"},
+	Short:   "Wizard's Vault operations (requires --api-key)",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.PersistentPreRun(cmd, args)
+		if apiKey == "" {
+			fmt.Fprintf(os.Stderr, "Error: vault commands require --api-key\n")
+			os.Exit(1)
+		}
+	},
+}
+
+var vaultPlanCmd = &cobra.Command{
+	Use:   "plan [priority...]",
+	Short: "Plan what to buy from the Wizard's Vault with the acclaim left",
+	Long: `Plan Wizard's Vault purchases with the Astral Acclaim in the wallet, buying
+rewards for each priority in turn, cheapest first. A priority is "gold",
+"skins" or text to find in reward names. Rewards that no longer fit are listed
+as not affordable. Acclaim is valued in gold through the vault's gold reward.
+
+The default order is "legendary starter kit" skins gold.
+
+Examples:
+  gw2api vault plan
+  gw2api vault plan gold "mystic clover"`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		plan, err := client.GetVaultPurchasePlan(ctx, args)
+		if err != nil {
+			return scopeError(err, "wallet")
+		}
+
+		outputData(plan)
+		return nil
+	},
+}
+
+var vaultClaimableCmd = &cobra.Command{
+	Use:   "claimable",
+	Short: "List the Wizard's Vault rewards the acclaim left can buy now",
+	Long: `List the Wizard's Vault rewards that the Astral Acclaim in the wallet can
+buy and that aren't bought up to their limit this season, with how many can be
+bought. Featured rewards come first, then legacy ones, then the rest, the most
+expensive first within each.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		claimable, err := client.GetClaimableVaultListings(ctx)
+		if err != nil {
+			return scopeError(err, "wallet")
+		}
+
+		outputData(claimable)
+		return nil
+	},
+}
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Local data cache operations",
+}
+
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show what the data cache loaded and how often it was used",
+	Run: func(cmd *cobra.Command, args []string) {
+		cache := client.DataCache()
+		if cache == nil {
+			fmt.Fprintf(os.Stderr, "Error: no data cache loaded (use --data-dir, or remove --no-cache)\n")
+			os.Exit(1)
+		}
+
+		stats := cache.Stats()
+		outputData(&stats)
+	},
+}
+
+var cacheStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Check the data files for missing, truncated or corrupt entries",
+	Long: `Reread every data file in the data directory and report, for each, its
+lines, invalid lines and duplicate IDs, and the entry count updatedb recorded
+in manifest.json. Files listed in the manifest but missing are reported too.
+
+Exits with an error when any file has a problem, so it can guard scripts that
+use the data directory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cache := client.DataCache()
+		if cache == nil || cache.Stats().DataDir == "" {
+			return fmt.Errorf("no data cache loaded (use --data-dir, or remove --no-cache)")
+		}
+
+		diagnostics, err := cache.Validate()
+		if err != nil {
+			return err
+		}
+		outputData(diagnostics)
+
+		problems := 0
+		for _, diagnostic := range diagnostics {
+			if !diagnostic.OK() {
+				problems++
+			}
+		}
+		if problems > 0 {
+			return fmt.Errorf("%d of %d data files have problems (rerun updatedb for them)", problems, len(diagnostics))
+		}
+		return nil
+	},
+}
+
+var cacheCompactCmd = &cobra.Command{
+	Use:   "compact [kind...]",
+	Short: "Rebuild the binary copies of the data files for faster loading",
+	Long: `Rebuild the binary copy (such as items.gob) of every JSONL file in the data
+directory, or only of the given kinds (items, skills, ...). Loading the data
+cache uses a binary copy instead of its JSONL file while the JSONL file is
+unchanged, and rebuilds it otherwise, so this is only needed to prepare a data
+directory ahead of time.`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		for _, arg := range args {
+			if _, err := gw2api.ParseCacheKind(arg); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	ValidArgsFunction: completeCacheKinds,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var kinds []gw2api.CacheKind
+		for _, arg := range args {
+			kind, _ := gw2api.ParseCacheKind(arg)
+			kinds = append(kinds, kind)
+		}
+
+		cache := client.DataCache()
+		if cache == nil || cache.Stats().DataDir == "" {
+			return fmt.Errorf("no data cache loaded (use --data-dir, or remove --no-cache)")
+		}
+
+		dataDir := cache.Stats().DataDir
+		compacted, err := cache.Compact(dataDir, kinds...)
+		for _, kind := range compacted {
+			fmt.Printf("Compacted %s\n", filepath.Join(dataDir, gw2api.BinaryFileName(kind.FileName())))
+		}
+		return err
+	},
+}
+
+var pvpCmd = &cobra.Command{
+	Use:   "pvp",
+	Short: "PvP operations (requires --api-key)",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.PersistentPreRun(cmd, args)
+		if apiKey == "" {
+			fmt.Fprintf(os.Stderr, "Error: pvp commands require --api-key\n")
+			os.Exit(1)
+		}
+	},
+}
+
+var charactersCmd = &cobra.Command{
+	Use:     "characters",
+	Aliases: []string{"character", "char"},
+	Short:   "Character operations (requires --api-key)",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.PersistentPreRun(cmd, args)
+		if apiKey == "" {
+			fmt.Fprintf(os.Stderr, "Error: characters commands require --api-key\n")
+			os.Exit(1)
+		}
+	},
+}
+
+// CharacterGear is an equipment tab with the problems found by a gear check
+type CharacterGear struct {
+	Character string `json:"character"`
+	gw2api.CharacterLoadout
+	AttributeTotals map[string]int       `json:"attribute_totals"`
+	Warnings        []gw2api.GearWarning `json:"warnings"`
+}
+
+var charactersGearCmd = &cobra.Command{
+	Use:   "gear <name>",
+	Short: "Show a character's equipment and check it for gaps",
+	Long: `Show the items, stats, attributes, upgrades and infusions in a character's
+equipment tab and the attribute totals of the gear worn together, followed by
+warnings for empty slots, empty infusion slots on ascended and legendary gear,
+and pieces whose stats differ from the rest of the tab.
+
+The active tab is shown unless --tab is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tab, _ := cmd.Flags().GetInt("tab")
+
+		ctx := context.Background()
+		loadouts, err := client.GetCharacterEquipmentTabsDetailed(ctx, args[0])
+		if err != nil {
+			return scopeError(err, "characters")
+		}
+
+		for _, loadout := range loadouts {
+			if tab == loadout.Tab || tab == 0 && loadout.IsActive {
+				outputData(&CharacterGear{
+					Character:        args[0],
+					CharacterLoadout: loadout,
+					AttributeTotals:  loadout.AttributeTotals(),
+					Warnings:         loadout.GearCheck(),
+				})
+				return nil
+			}
+		}
+		if tab == 0 {
+			return fmt.Errorf("%s has no active equipment tab", args[0])
+		}
+		return fmt.Errorf("%s has no equipment tab %d (found %d tabs)", args[0], tab, len(loadouts))
+	},
+}
+
+// CharacterBirthday is when a character next gets a birthday gift
+type CharacterBirthday struct {
+	Name         string          `json:"name"`
+	Profession   string          `json:"profession"`
+	Level        int             `json:"level"`
+	Created      time.Time       `json:"created"`
+	NextBirthday time.Time       `json:"next_birthday"`
+	Birthday     int             `json:"birthday"` // Which birthday it is, such as 5 for the fifth
+	Playtime     gw2api.Playtime `json:"playtime"`
+}
+
+var charactersBirthdaysCmd = &cobra.Command{
+	Use:   "birthdays",
+	Short: "List characters by their next birthday",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		names, err := client.GetCharacterNames(ctx)
+		if err != nil {
+			return scopeError(err, "characters")
+		}
+
+		now := time.Now()
+		birthdays := make([]CharacterBirthday, 0, len(names))
+		for _, name := range names {
+			core, err := client.GetCharacterCore(ctx, name)
+			if err != nil {
+				return fmt.Errorf("failed to get %s: %w", name, scopeError(err, "characters"))
+			}
+			next := core.NextBirthday(now)
+			birthdays = append(birthdays, CharacterBirthday{
+				Name:         core.Name,
+				Profession:   core.Profession,
+				Level:        core.Level,
+				Created:      core.Created,
+				NextBirthday: next,
+				Birthday:     core.BirthdayNumber(next),
+				Playtime:     core.Age,
+			})
+		}
+		slices.SortStableFunc(birthdays, func(a, b CharacterBirthday) int {
+			return a.NextBirthday.Compare(b.NextBirthday)
+		})
+
+		outputData(birthdays)
+		return nil
+	},
+}
+
+var charactersNextCraftsCmd = &cobra.Command{
+	Use:   "next-crafts <name>",
+	Short: "List recipes a character can discover at their current crafting ratings",
+	Long: `List recipes in the active crafting disciplines of a character that are at or
+below the character's rating and not yet known by the character or account.
+Recipes learned automatically or from recipe sheets are left out.
+
+Needs recipes.jsonl in the data directory (see --data-dir).`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		upgrades, err := client.GetCraftableUpgrades(ctx, args[0])
+		if err != nil {
+			return scopeError(err, "characters")
+		}
+
+		outputData(upgrades)
+		return nil
+	},
+}
+
+var pvpStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show PvP rank and win rates by profession",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		stats, err := client.GetPvPStats(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", scopeError(err, "pvp"))
+			os.Exit(1)
+		}
+
+		outputData(stats)
+	},
+}
+
+var accountAPCmd = &cobra.Command{
+	Use:   "ap",
+	Short: "Show earned achievement points by category",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		summary, err := client.GetAccountAchievementPoints(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputData(summary)
+	},
+}
+
+var accountMasteriesCmd = &cobra.Command{
+	Use:   "masteries",
+	Short: "Show mastery points earned and spent by region",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		summary, err := client.GetAccountMasteryPointSummary(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputData(summary)
+	},
+}
+
+var accountLegendariesCmd = &cobra.Command{
+	Use:   "legendaries",
+	Short: "Show legendary armory progress by slot",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		status, err := client.GetLegendaryArmoryStatus(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputData(status)
+	},
+}
+
+var accountWvWCmd = &cobra.Command{
+	Use:   "wvw",
+	Short: "Show WvW rank, team, guild and the team's current match",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		status, err := client.GetAccountWvWStatus(ctx)
+		if err != nil {
+			return scopeError(err, "account")
+		}
+
+		outputData(status)
+		return nil
+	},
+}
+
+var wvwCmd = &cobra.Command{Use: "wvw", Short: "World vs. World operations"}
+
+var wvwMapCmd = &cobra.Command{
+	Use:   "map [team]",
+	Short: "Show the objectives of a WvW match with their upgrade tiers and claims",
+	Long: `Show who holds each objective of the match a team or world plays in, with
+the points each side gains per tick and how many of its structures are at
+tier 3. The team defaults to the API key's account.
+
+Tiers come from the yaks delivered since the objective was captured, and the
+time to the next tier assumes yaks keep arriving at the same rate.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		var teamID int
+		var err error
+		if len(args) == 1 {
+			if teamID, err = strconv.Atoi(args[0]); err != nil || teamID <= 0 {
+				return fmt.Errorf("invalid team or world ID %q", args[0])
+			}
+		} else if teamID, err = accountTeamID(ctx); err != nil {
+			return err
+		}
+
+		report, err := wvwMapReport(ctx, teamID, time.Now())
+		if err != nil {
+			return err
+		}
+		outputData(report)
+		return nil
+	},
+}
+
+var guildCmd = &cobra.Command{Use: "guild", Short: "Guild operations"}
+
+var guildLogCmd = &cobra.Command{
+	Use:   "log <guild>",
+	Short: "Show a guild's log as an audit trail",
+	Long: `Show the log of one of the account's guilds, newest first, with the items
+of stash and treasury entries named. The guild is given by name, tag or ID.
+The API keeps the last 100 entries, and only gives them to the guild leader.
+
+Examples:
+  gw2api guild log "My Guild" --type stash --since-days 7
+  gw2api guild log TAG --user Player.1234`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filter := gw2api.GuildLogFilter{Kinds: guildLogKinds.values}
+		filter.User, _ = cmd.Flags().GetString("user")
+		days, _ := cmd.Flags().GetInt("since-days")
+		if days < 0 {
+			return fmt.Errorf("invalid --since-days %d", days)
+		} else if days > 0 {
+			filter.Since = time.Now().AddDate(0, 0, -days)
+		}
+
+		ctx := context.Background()
+		guild, err := findGuild(ctx, args[0])
+		if err != nil {
+			return err
+		}
+		report, err := guildLogReport(ctx, guild, filter)
+		if err != nil {
+			return err
+		}
+		outputData(report)
+		return nil
+	},
+}
+
+var accountWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Print the items the account gains and loses, such as loot, until interrupted",
+	Long: `Poll the account's items and print each item gained or lost, with where
+it changed. Items moved between the watched places, such as from a character
+to the bank, are not changes. The API updates inventories every few minutes
+at most, so shorter intervals only cost requests.
+
+The places watched are chosen with --source, comma-separated, from: ` + itemSourceNames() + `.
+All of them are watched by default. Each poll requests every place, and the
+bags of each character separately.
+
+With --output json, each change is printed as a JSON object on its own line.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		return watchAccountItems(interval, itemSources.values)
+	},
+}
+
+var accountRaidsCmd = &cobra.Command{
+	Use:   "raids",
+	Short: "Show raid encounters cleared since weekly reset",
+	Long: `Show raid encounters cleared since weekly reset.
+
+With --value, estimate the coin the encounters not yet cleared this week are
+still worth, per wing, from a built-in table of each encounter's reward.
+Magnetite Shards and Gaeting Crystals can't be sold, so they only count
+towards the value at the rates given with --magnetite-value and --gaeting-value.
+
+Examples:
+  gw2api account raids --value
+  gw2api account raids --value --magnetite-value 20s`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var rates gw2api.ShardRates
+		for _, flag := range []struct {
+			name string
+			rate *gw2api.Coins
+		}{{"magnetite-value", &rates.MagnetiteShard}, {"gaeting-value", &rates.GaetingCrystal}} {
+			if value, _ := cmd.Flags().GetString(flag.name); value != "" {
+				var err error
+				if *flag.rate, err = gw2api.ParseCoins(value); err != nil {
+					return fmt.Errorf("invalid --%s: %w", flag.name, err)
+				}
+			}
+		}
+
+		ctx := context.Background()
+		progress, err := client.GetRaidProgress(ctx, time.Now())
+		if err != nil {
+			return scopeError(err, "progression")
+		}
+
+		if value, _ := cmd.Flags().GetBool("value"); value {
+			outputData(progress.RemainingValue(gw2api.DefaultRaidRewards(), rates))
+			return nil
+		}
+		outputData(progress)
+		return nil
+	},
+}
+
+var accountBankCmd = &cobra.Command{
+	Use:   "bank",
+	Short: "List the items in the account bank",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		bank, err := client.GetAccountBank(ctx)
+		if err != nil {
+			return scopeError(err, "inventories")
+		}
+
+		outputSlots(ctx, gw2api.BankSlotRefs(bank))
+		return nil
+	},
+}
+
+var accountMaterialsCmd = &cobra.Command{
+	Use:   "materials",
+	Short: "List the items in material storage",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		materials, err := client.GetAccountMaterials(ctx)
+		if err != nil {
+			return scopeError(err, "inventories")
+		}
+
+		outputSlots(ctx, gw2api.MaterialSlotRefs(materials))
+		return nil
+	},
+}
+
+// outputSlots prints storage slots with their items. Items that could not be
+// fetched are listed as unknown after a warning.
+func outputSlots(ctx context.Context, slots []gw2api.SlotRef) {
+	resolved, err := gw2api.ResolveSlots(ctx, client, slots)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	outputData(resolved)
+}
+
+var accountNearlyDoneCmd = &cobra.Command{
+	Use:   "nearly-done",
+	Short: "List the unfinished achievements closest to completion",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		ctx := context.Background()
+		achievements, err := client.GetNearlyCompleteAchievements(ctx, limit)
+		if err != nil {
+			return scopeError(err, "progression")
+		}
+
+		outputData(achievements)
+		return nil
+	},
+}
+
+var accountDyesCmd = &cobra.Command{
+	Use:   "dyes",
+	Short: "Dye unlock operations",
+}
+
+var dyeRarities = newEnumListFlag("rarity", gw2api.DyeRarities, func(s string) (string, error) {
+	for _, rarity := range gw2api.DyeRarities {
+		if strings.EqualFold(strings.TrimSpace(s), rarity) {
+			return rarity, nil
+		}
+	}
+	return "", fmt.Errorf("unknown dye rarity %q, expected one of %s", s, strings.Join(gw2api.DyeRarities, ", "))
+})
+
+var accountDyesMissingCmd = &cobra.Command{
+	Use:   "missing",
+	Short: "List missing dyes, cheapest on the trading post first",
+	Long: `List the dyes the account hasn't unlocked. Dyes whose item is for sale on the
+trading post are ranked by their lowest sell listing, followed by those that
+can't be bought there, such as gem store exclusives.
+
+Examples:
+  # The cheapest way to finish the Uncommon dyes
+  gw2api account dyes missing --rarity Uncommon
+
+  # Every missing dye up to 5 gold
+  gw2api account dyes missing --max-price 5g`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var maxPrice gw2api.Coins
+		if value, _ := cmd.Flags().GetString("max-price"); value != "" {
+			var err error
+			if maxPrice, err = gw2api.ParseCoins(value); err != nil {
+				return fmt.Errorf("invalid --max-price: %w", err)
+			}
+		}
+
+		ctx := context.Background()
+		missing, err := client.GetMissingDyesRanked(ctx)
+		if err != nil {
+			return scopeError(err, "unlocks")
+		}
+
+		outputData(missing.Filter(dyeRarities.values, maxPrice))
+		return nil
+	},
+}
+
+var accountSnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save wallet, storage, unlocks and progress to a JSON file",
+	Long: `Save wallet, storage, unlocks and progress to a JSON file for later comparison with "account diff".
+Sections the API key has no scope for are skipped and listed in the snapshot metadata.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		out, _ := cmd.Flags().GetString("out")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+
+		ctx := context.Background()
+		snap, err := snapshot.Take(ctx, client, concurrency)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if out == "" {
+			out = "snap-" + snap.Metadata.TakenAt.Format(time.DateOnly) + ".json"
+		}
+		if err := snap.WriteFile(out); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Saved snapshot of %s to %s\n", snap.Metadata.Account, out)
+		for _, section := range snapshot.Sections {
+			if reason, skipped := snap.Metadata.Skipped[section]; skipped {
+				fmt.Printf("Skipped %s: %s\n", section, reason)
+			}
+		}
+	},
+}
+
+var accountDiffCmd = &cobra.Command{
+	Use:   "diff <old.json> <new.json>",
+	Short: "Show what changed between two account snapshots",
+	Args:  cobra.ExactArgs(2),
+	// Comparing saved snapshots needs no API key
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		rootCmd.PersistentPreRun(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		old, err := snapshot.ReadFile(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		current, err := snapshot.ReadFile(args[1])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputData(snapshot.Compare(old, current))
+	},
+}
+
+var accountMissingCmd = &cobra.Command{
+	Use:   "missing",
+	Short: "Show wardrobe unlocks the account is missing and where to get them",
+}
+
+var accountMissingOutfitsCmd = &cobra.Command{
+	Use:   "outfits",
+	Short: "Show missing outfits",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		all, err := client.GetOutfitIDs(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		unlocked, err := client.GetAccountOutfits(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputMissingUnlocks(ctx, gw2api.UnlockKindOutfit, all, unlocked)
+	},
+}
+
+var accountMissingGlidersCmd = &cobra.Command{
+	Use:   "gliders",
+	Short: "Show missing gliders",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		all, err := client.GetGliderIDs(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		unlocked, err := client.GetAccountGliders(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputMissingUnlocks(ctx, gw2api.UnlockKindGlider, all, unlocked)
+	},
+}
+
+var accountMissingMountSkinsCmd = &cobra.Command{
+	Use:     "mountskins",
+	Aliases: []string{"mount-skins"},
+	Short:   "Show missing mount skins",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		all, err := client.GetMountSkinIDs(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		unlocked, err := client.GetAccountMountSkins(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputMissingUnlocks(ctx, gw2api.UnlockKindMountSkin, all, unlocked)
+	},
+}
+
+var accountMissingMinisCmd = &cobra.Command{
+	Use:   "minis",
+	Short: "Show missing minis",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		all, err := client.GetMiniIDs(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		unlocked, err := client.GetAccountMinis(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputMissingUnlocks(ctx, gw2api.UnlockKindMini, all, unlocked)
+	},
+}
+
+var accountMissingNoveltiesCmd = &cobra.Command{
+	Use:   "novelties",
+	Short: "Show missing novelties",
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := context.Background()
+		all, err := client.GetNoveltyIDs(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		unlocked, err := client.GetAccountNovelties(ctx)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		outputMissingUnlocks(ctx, gw2api.UnlockKindNovelty, all, unlocked)
+	},
+}
+
+// outputMissingUnlocks resolves the sources of every ID in all that isn't unlocked
+func outputMissingUnlocks[T ~int](ctx context.Context, kind gw2api.UnlockKind, all []int, unlocked []T) {
+	missing := gw2api.MissingUnlocks(all, unlocked)
+	if len(missing) == 0 {
+		fmt.Println("Nothing missing")
+		return
+	}
+
+	sources, err := client.GetUnlockSources(ctx, kind, missing)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	outputData(sources)
+}
+
+var recipesCmd = &cobra.Command{Use: "recipes", Short: "Recipe operations"}
+var recipesGetCmd = &cobra.Command{
+	Use:   "get [id|chat link...]",
+	Short: "Get recipes by ID or chat link",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ctx := context.Background()
+		ids, err := parseIDsOrChatLinks(cmd, args, gw2api.ChatLinkRecipe)
+		if err != nil {
+			return err
+		}
+
+		recipes, err := client.GetRecipes(ctx, ids)
+		if err != nil {
+			return err
+		}
+		outputData(recipes)
+		return nil
+	},
+}
+
+var recipesSearchCmd = &cobra.Command{
+	Use:   "search",
+	Short: "Search cached recipes by discipline, rating and output",
+	Long: `Search the recipe data cache with optional filtering by discipline, crafting
+rating and output item name.
+
+Examples:
+  # Tailor recipes up to rating 300
+  gw2api recipes search --discipline Tailor --max-rating 300
+
+  # Recipes making anything called "Insignia"
+  gw2api recipes search --item insignia --limit 0`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		disciplines, _ := cmd.Flags().GetStringSlice("discipline")
+		minRating, _ := cmd.Flags().GetInt("min-rating")
+		maxRating, _ := cmd.Flags().GetInt("max-rating")
+		output, _ := cmd.Flags().GetString("item")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		ctx := context.Background()
+		recipes, err := client.SearchRecipes(ctx, gw2api.RecipeSearchOptions{
+			Disciplines:    disciplines,
+			MinRating:      minRating,
+			MaxRating:      maxRating,
+			OutputItemName: output,
+			Limit:          limit,
+		})
+		if err != nil {
+			return err
+		}
+		outputData(recipes)
+		return nil
+	},
+}
+
+// Helper functions
+// apiKeyHint tells users where to get a working API key
+const apiKeyHint = "API keys look like XXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXXXXXXXXXX-XXXX-XXXX-XXXX-XXXXXXXXXXXX\nand can be created at https://account.arena.net/applications"
+
+// checkAPIKey asks the API whether the client's key is valid, explaining how
+// to fix a rejected one. Other failures are left for the command to report.
+func checkAPIKey(client *gw2api.Client, timeout time.Duration) error {
+	ctx := context.Background()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	if _, err := client.CheckAPIKey(ctx); errors.Is(err, gw2api.ErrInvalidAPIKey) {
+		return fmt.Errorf("the API rejected the key given with --api-key (%v)\nCheck that it was copied in full and still exists at https://account.arena.net/applications", err)
+	}
+	return nil
+}
+
+// logCacheLoad reports which caches were loaded from dir and how long it took
+func logCacheLoad(dir string) {
+	cache := client.DataCache()
+	if cache == nil {
+		fmt.Fprintln(os.Stderr, "Data cache disabled")
+		return
+	}
+
+	stats := cache.Stats()
+	var loaded []string
+	for _, kind := range stats.Kinds {
+		if kind.Records > 0 {
+			loaded = append(loaded, fmt.Sprintf("%d %s", kind.Records, kind.Kind))
+		}
+	}
+	if len(loaded) == 0 {
+		loaded = append(loaded, "nothing")
+	}
+	fmt.Fprintf(os.Stderr, "Loaded data cache from %s in %s: %s\n", dir, stats.LoadTime.Round(time.Millisecond), strings.Join(loaded, ", "))
+}
+
+// scopeError explains a 403 from the API, which means the API key was created
+// without a scope the command needs
+func scopeError(err error, scope string) error {
+	if errors.Is(err, gw2api.ErrMissingScope) {
+		return fmt.Errorf("the API key does not have the %q permission; create a key with it at https://account.arena.net/applications (%w)", scope, err)
+	}
+	return err
+}
+
+func formatCoins(copper int) string {
+	return fmt.Sprintf("%dg %ds %dc", copper/10000, copper/100%100, copper%100)
+}
+
+// exitNoResults is the exit status used with --fail-empty when nothing was found
+const exitNoResults = 3
+
+// exit ends the process, and is replaced in tests
+var exit = os.Exit
+
+// outputCount prints how many entries endpoint lists, for list commands given
+// --count. Only the total is requested, not the IDs.
+func outputCount(ctx context.Context, endpoint string) {
+	total, err := gw2api.CountResults(ctx, client, endpoint)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if outputFormat == "table" {
+		fmt.Printf("Total: %d\n", total)
+		return
+	}
+	data, _ := json.MarshalIndent(map[string]int{"total": total}, "", "  ")
+	fmt.Println(string(data))
+}
+
+func outputIDs(ids []int) {
+	if len(ids) == 0 {
+		outputEmpty()
+		return
+	}
+	switch outputFormat {
+	case "json":
+		data, _ := json.MarshalIndent(ids, "", "  ")
+		fmt.Println(string(data))
+	case "table":
+		for _, id := range ids {
+			fmt.Println(id)
+		}
+	default:
+		data, _ := json.MarshalIndent(ids, "", "  ")
+		fmt.Println(string(data))
+	}
+}
+
+func outputData(data any) {
+	if isEmptyResult(data) {
+		outputEmpty()
+		return
+	}
+	switch outputFormat {
+	case "json":
+		jsonData, _ := json.MarshalIndent(data, "", "  ")
+		fmt.Println(string(jsonData))
+	case "table":
+		outputTable(data)
+	default:
+		jsonData, _ := json.MarshalIndent(data, "", "  ")
+		fmt.Println(string(jsonData))
+	}
+}
+
+// isEmptyResult reports whether data is nil or an empty slice or map
+func isEmptyResult(data any) bool {
+	if data == nil {
+		return true
+	}
+	v := reflect.ValueOf(data)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return v.IsNil()
+	}
+	return false
+}
+
+// outputEmpty reports that a command found nothing. Table output says so,
+// while JSON output stays parseable as an empty list. With --fail-empty the
+// process then exits with exitNoResults.
+func outputEmpty() {
+	if outputFormat == "table" {
+		fmt.Println("No results found")
+	} else {
+		fmt.Println("[]")
+	}
+	if failEmpty {
+		exit(exitNoResults)
+	}
+}
+
+func outputTable(data any) {
+	switch v := data.(type) {
+	case *gw2api.Item:
+		outputItemTable([]*gw2api.Item{v})
+	case []*gw2api.Item:
+		outputItemTable(v)
+	case *gw2api.Achievement:
+		outputAchievementTable([]*AchievementRow{{Achievement: v}})
+	case []*gw2api.Achievement:
+		rows := make([]*AchievementRow, len(v))
+		for i, achievement := range v {
+			rows[i] = &AchievementRow{Achievement: achievement}
+		}
+		outputAchievementTable(rows)
+	case *AchievementRow:
+		outputAchievementTable([]*AchievementRow{v})
+	case []*AchievementRow:
+		outputAchievementTable(v)
+	case *gw2api.Currency:
+		outputCurrencyTable([]*gw2api.Currency{v})
+	case []*gw2api.Currency:
+		outputCurrencyTable(v)
+	case *gw2api.World:
+		outputWorldTable([]*gw2api.World{v})
+	case []*gw2api.World:
+		outputWorldTable(v)
+	case *gw2api.Skill:
+		outputSkillTable([]*gw2api.Skill{v})
+		outputSkillFacts([]*gw2api.Skill{v})
+	case []*gw2api.Skill:
+		outputSkillTable(v)
+		outputSkillFacts(v)
+	case []*gw2api.SkinDetail:
+		outputSkinTable(v)
+	case *PriceRow:
+		outputPriceTable([]*PriceRow{v})
+	case []*PriceRow:
+		outputPriceTable(v)
+	case []WorldBossStatus:
+		outputWorldBossTable(v)
+	case *gw2api.OutstandingOrders:
+		outputOutstandingOrdersTable(v)
+	case *gw2api.RaidProgress:
+		outputRaidProgressTable(v)
+	case *gw2api.WvWStatus:
+		outputWvWStatusTable(v)
+	case *WvWMapReport:
+		outputWvWMapTable(v)
+	case *GuildLogReport:
+		outputGuildLogTable(v)
+	case *gw2api.RaidValue:
+		outputRaidValueTable(v)
+	case *gw2api.ResolvedBuild:
+		outputBuildTemplate(v)
+	case *gw2api.AchievementPointsSummary:
+		outputAchievementPointsTable(v)
+	case *gw2api.MasteryPointSummary:
+		outputMasteryPointsTable(v)
+	case *gw2api.LegendaryArmoryStatus:
+		outputLegendaryArmoryTable(v)
+	case []*gw2api.RecipeDetail:
+		outputRecipeTable(v)
+	case []*gw2api.UnlockSource:
+		outputUnlockSourceTable(v)
+	case *gw2api.PvPStats:
+		outputPvPStatsTable(v)
+	case *snapshot.Diff:
+		outputSnapshotDiffTable(v)
+	case *CharacterGear:
+		outputCharacterGearTable(v)
+	case *MarketDepth:
+		outputMarketDepthTable(v)
+	case *gw2api.DataCacheStats:
+		outputCacheStatsTable(v)
+	case []gw2api.DataFileDiagnostics:
+		outputDataFileDiagnosticsTable(v)
+	case []KeyStatus:
+		outputKeyStatusTable(v)
+	case []CharacterBirthday:
+		outputCharacterBirthdayTable(v)
+	case []gw2api.NearlyDoneAchievement:
+		outputNearlyDoneTable(v)
+	case *gw2api.MissingDyes:
+		outputMissingDyesTable(v)
+	case []gw2api.CraftableUpgrade:
+		outputCraftableUpgradeTable(v)
+	case *gw2api.VaultPurchasePlan:
+		outputVaultPlanTable(v)
+	case *gw2api.ClaimableVaultListings:
+		outputClaimableVaultTable(v)
+	case []gw2api.ResolvedSlot:
+		outputSlotTable(v)
+	default:
+		// Fallback to simple printing
+		fmt.Printf("%+v\n", data)
+	}
+}
+
+func outputItemTable(items []*gw2api.Item) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Type", "Rarity", "Level")
+
+	for _, item := range items {
+		name := truncate(item.Name, 50)
+		table.Append(
+			strconv.Itoa(item.ID),
+			name,
+			item.Type,
+			item.Rarity,
+			strconv.Itoa(item.Level),
+		)
+	}
+	table.Render()
+}
+
+func outputSkinTable(skins []*gw2api.SkinDetail) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Type", "Weight", "Rarity")
+
+	for _, skin := range skins {
+		name := truncate(skin.Name, 50)
+		skinType := skin.Type
+		if skin.Details.Type != "" {
+			skinType += " (" + skin.Details.Type + ")"
+		}
+		table.Append(
+			strconv.Itoa(skin.ID),
+			name,
+			skinType,
+			skin.Details.WeightClass,
+			skin.Rarity,
+		)
+	}
+	table.Render()
+}
+
+func outputAchievementTable(rows []*AchievementRow) {
+	// The Requires column is only shown when something has prerequisites
+	showRequires := slices.ContainsFunc(rows, func(row *AchievementRow) bool { return len(row.Requires) > 0 })
+
+	table := tablewriter.NewWriter(os.Stdout)
+	if showRequires {
+		table.Header("ID", "Name", "Type", "Points", "Requires")
+	} else {
+		table.Header("ID", "Name", "Type", "Points")
+	}
+
+	for _, row := range rows {
+		achievement := row.Achievement
+		name := truncate(achievement.Name, 40)
+
+		points := "0"
+		if len(achievement.Tiers) > 0 {
 			totalPoints := 0
 			for _, tier := range achievement.Tiers {
 				totalPoints += tier.Points
 			}
-			points = strconv.Itoa(totalPoints)
+			points = strconv.Itoa(totalPoints)
+		}
+
+		cells := []string{
+			strconv.Itoa(achievement.ID),
+			name,
+			achievement.Type,
+			points,
+		}
+		if showRequires {
+			cells = append(cells, strings.Join(row.Requires, ", "))
+		}
+		table.Append(cells)
+	}
+	table.Render()
+}
+
+func outputCurrencyTable(currencies []*gw2api.Currency) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Description", "Order")
+
+	for _, currency := range currencies {
+		name := truncate(currency.Name, 20)
+		description := truncate(currency.Description, 50)
+
+		table.Append(
+			strconv.Itoa(currency.ID),
+			name,
+			description,
+			strconv.Itoa(currency.Order),
+		)
+	}
+	table.Render()
+}
+
+func outputWorldTable(worlds []*gw2api.World) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Population")
+
+	for _, world := range worlds {
+		name := truncate(world.Name, 30)
+
+		table.Append(
+			strconv.Itoa(world.ID),
+			name,
+			world.Population,
+		)
+	}
+	table.Render()
+}
+
+func outputSkillTable(skills []*gw2api.Skill) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Type", "Professions")
+
+	for _, skill := range skills {
+		name := truncate(skill.Name, 30)
+
+		skillType := skill.Type
+		if skillType == "" {
+			skillType = "N/A"
+		}
+
+		professions := "N/A"
+		if len(skill.Professions) > 0 {
+			professions = truncate(strings.Join(skill.Professions, ", "), 20)
+		}
+
+		table.Append(
+			strconv.Itoa(skill.ID),
+			name,
+			skillType,
+			professions,
+		)
+	}
+	table.Render()
+}
+
+// outputSkillFacts prints each skill's tooltip facts after the skill table
+func outputSkillFacts(skills []*gw2api.Skill) {
+	for _, skill := range skills {
+		if len(skill.Facts) == 0 {
+			continue
+		}
+		fmt.Printf("\n%s (%d)\n", skill.Name, skill.ID)
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header("Fact", "Value")
+		for _, fact := range skill.Facts {
+			label := fact.Text
+			if label == "" {
+				label = fact.Type
+			}
+			table.Append(label, fact.Summary())
+		}
+		table.Render()
+
+		if len(skill.TraitedFacts) > 0 {
+			fmt.Printf("%d traited facts, see --traits\n", len(skill.TraitedFacts))
+		}
+	}
+}
+
+func outputPriceTable(prices []*PriceRow) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Item ID", "Buy Price", "Buy Qty", "Sell Price", "Sell Qty", "Spread", "Flip Profit", "Demand/Supply")
+
+	for _, price := range prices {
+		spread, profit, ratio := "-", "-", "-"
+		if price.Buys.UnitPrice > 0 && price.Sells.UnitPrice > 0 {
+			spread = fmt.Sprintf("%.1f%%", price.Derived.SpreadPercent)
+			profit = colorSign(strconv.Itoa(price.Derived.FlipProfit), float64(price.Derived.FlipProfit))
+		}
+		if price.Sells.Quantity > 0 {
+			// More demand than supply is the good side for a seller
+			ratio = colorSign(fmt.Sprintf("%.2f", price.Derived.DemandSupplyRatio), price.Derived.DemandSupplyRatio-1)
+		}
+		table.Append(
+			strconv.Itoa(price.ID),
+			strconv.Itoa(price.Buys.UnitPrice),
+			strconv.Itoa(price.Buys.Quantity),
+			strconv.Itoa(price.Sells.UnitPrice),
+			strconv.Itoa(price.Sells.Quantity),
+			spread,
+			profit,
+			ratio,
+		)
+	}
+	table.Render()
+}
+
+func outputWorldBossTable(bosses []WorldBossStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Boss", "Next Spawn", "In", "Done")
+
+	now := time.Now()
+	for _, boss := range bosses {
+		done := ""
+		if boss.Done {
+			done = "yes"
+		}
+
+		table.Append(
+			boss.Name,
+			boss.NextSpawn.Local().Format("15:04"),
+			boss.NextSpawn.Sub(now).Round(time.Minute).String(),
+			done,
+		)
+	}
+	table.Render()
+
+	fmt.Printf("Daily reset in %s\n", gw2api.TimeUntilReset(gw2api.DailyReset, now).Round(time.Minute))
+}
+
+// staleOrderAge is the commerce orders --stale flag
+var staleOrderAge time.Duration
+
+func outputOutstandingOrdersTable(orders *gw2api.OutstandingOrders) {
+	fmt.Println("Buy orders:")
+	outputOrderSummaryTable(orders.Buys, orders.BuyCoins, orders.AsOf)
+	fmt.Println("\nSell listings:")
+	outputOrderSummaryTable(orders.Sells, orders.SellCoins, orders.AsOf)
+	fmt.Printf("\nTied up on the trading post: %s\n", formatCoins(orders.BuyCoins+orders.SellCoins))
+}
+
+func outputOrderSummaryTable(summaries []*gw2api.OrderSummary, total int, now time.Time) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header(append([]string{"Item", "Orders", "Quantity", "Committed", "Oldest"}, gw2api.OrderAgeLabels...))
+
+	for _, summary := range summaries {
+		name := summary.Name
+		if name == "" {
+			name = fmt.Sprintf("Item %d", summary.ItemID)
+		}
+		oldest := formatOrderAge(summary.Age(now))
+		if summary.Stale(now, staleOrderAge) {
+			oldest += " (stale)"
+		}
+
+		row := []string{name, strconv.Itoa(summary.Orders), strconv.Itoa(summary.Quantity), formatCoins(summary.Coins), oldest}
+		for _, count := range summary.AgeBuckets {
+			row = append(row, strconv.Itoa(count))
+		}
+		table.Append(row)
+	}
+	footer := []string{"Total", "", "", formatCoins(total), ""}
+	for range gw2api.OrderAgeLabels {
+		footer = append(footer, "")
+	}
+	table.Footer(footer)
+	table.Render()
+}
+
+// formatTime shows a timestamp in local time with how long ago it was, such as
+// "2026-10-16 09:12:44 (3h ago)", or "-" when unset
+func formatTime(t time.Time) string {
+	if gw2api.IsZeroTimestamp(t) {
+		return "-"
+	}
+	return fmt.Sprintf("%s (%s)", t.Local().Format(time.DateTime), gw2api.RelativeTime(t, time.Now()))
+}
+
+// formatOrderAge shows how long an order has been open in days, or hours for
+// orders under a day old
+func formatOrderAge(age time.Duration) string {
+	if age < 24*time.Hour {
+		return fmt.Sprintf("%dh", int(age/time.Hour))
+	}
+	return fmt.Sprintf("%dd", int(age/(24*time.Hour)))
+}
+
+func outputWvWStatusTable(status *gw2api.WvWStatus) {
+	rank := strconv.Itoa(status.Rank)
+	if status.RankTitle != "" {
+		rank += " (" + status.RankTitle + ")"
+	}
+	fmt.Printf("Rank: %s\n", rank)
+	if status.TeamID == 0 {
+		fmt.Println("Team: not assigned")
+	} else {
+		fmt.Printf("Team: %s (%s %d)\n", status.TeamName, status.Region, status.TeamID)
+	}
+	if status.GuildID != "" {
+		guild := status.GuildID
+		if status.GuildName != "" {
+			guild = fmt.Sprintf("%s [%s]", status.GuildName, status.GuildTag)
+		}
+		fmt.Printf("Guild: %s\n", guild)
+	}
+
+	match := status.Match
+	if match == nil {
+		return
+	}
+	fmt.Printf("Match %s, ends in %s\n", match.ID, time.Until(match.EndTime).Round(time.Minute))
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Side", "Team", "Score", "Victory Points", "Kills", "Deaths")
+	for _, side := range []struct {
+		color                          string
+		team, score, vp, kills, deaths int
+	}{
+		{"red", match.Worlds.Red, match.Scores.Red, match.VictoryPoints.Red, match.Kills.Red, match.Deaths.Red},
+		{"blue", match.Worlds.Blue, match.Scores.Blue, match.VictoryPoints.Blue, match.Kills.Blue, match.Deaths.Blue},
+		{"green", match.Worlds.Green, match.Scores.Green, match.VictoryPoints.Green, match.Kills.Green, match.Deaths.Green},
+	} {
+		color := side.color
+		if color == status.Color {
+			color += " (you)"
+		}
+		table.Append(color, strconv.Itoa(side.team), strconv.Itoa(side.score), strconv.Itoa(side.vp), strconv.Itoa(side.kills), strconv.Itoa(side.deaths))
+	}
+	table.Render()
+}
+
+func outputRaidProgressTable(progress *gw2api.RaidProgress) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Wing", "Encounter", "Type", "Done")
+
+	for _, wing := range progress.Wings {
+		for _, encounter := range wing.Encounters {
+			done := ""
+			if encounter.Done {
+				done = "yes"
+			}
+			table.Append(wing.ID, encounter.ID, encounter.Type, done)
 		}
+	}
+	table.Footer("Total", "", "", fmt.Sprintf("%d/%d", progress.Cleared, progress.Total))
+	table.Render()
 
+	fmt.Printf("Weekly reset in %s\n", time.Until(progress.ResetsAt).Round(time.Minute))
+}
+
+func outputRaidValueTable(value *gw2api.RaidValue) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Wing", "Remaining", "Coins", "Magnetite", "Gaeting", "Value")
+
+	for _, wing := range value.Wings {
 		table.Append(
-			strconv.Itoa(achievement.ID),
-			name,
-			achievement.Type,
-			points,
+			wing.ID,
+			strconv.Itoa(wing.Remaining),
+			formatCoins(int(wing.Coins)),
+			strconv.Itoa(wing.MagnetiteShards),
+			strconv.Itoa(wing.GaetingCrystals),
+			formatCoins(int(wing.Value)),
 		)
 	}
+	table.Footer("Total", "", "", strconv.Itoa(value.MagnetiteShards), strconv.Itoa(value.GaetingCrystals), formatCoins(int(value.Total)))
 	table.Render()
+
+	if len(value.Unknown) > 0 {
+		fmt.Printf("No reward known for %s\n", strings.Join(value.Unknown, ", "))
+	}
 }
 
-func outputCurrencyTable(currencies []*gw2api.Currency) {
+func outputAchievementPointsTable(summary *gw2api.AchievementPointsSummary) {
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("ID", "Name", "Description", "Order")
+	table.Header("Category", "Points")
 
-	for _, currency := range currencies {
-		name := currency.Name
-		if len(name) > 20 {
-			name = name[:17] + "..."
+	for _, category := range summary.ByCategory {
+		table.Append(category.Name, strconv.Itoa(category.Points))
+	}
+	table.Footer("Total", strconv.Itoa(summary.Total))
+	table.Render()
+
+	if summary.Repeated > 0 {
+		fmt.Printf("%d points from repeated achievements\n", summary.Repeated)
+	}
+	if summary.Unknown > 0 {
+		fmt.Printf("%d achievements skipped (no longer in the API)\n", summary.Unknown)
+	}
+}
+
+func outputMasteryPointsTable(summary *gw2api.MasteryPointSummary) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Region", "Earned", "Spent", "Available", "To Complete", "Tracks")
+
+	for _, region := range summary.Regions {
+		table.Append(
+			region.Region,
+			strconv.Itoa(region.Earned),
+			strconv.Itoa(region.Spent),
+			strconv.Itoa(region.Available),
+			strconv.Itoa(region.ToComplete),
+			fmt.Sprintf("%d/%d", region.TracksCompleted, region.Tracks),
+		)
+	}
+	table.Footer("Total", strconv.Itoa(summary.Earned), strconv.Itoa(summary.Spent), strconv.Itoa(summary.Available), "", "")
+	table.Render()
+}
+
+func outputUnlockSourceTable(sources []*gw2api.UnlockSource) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Source", "Details")
+
+	for _, source := range sources {
+		details := ""
+		switch source.Source {
+		case gw2api.UnlockSourceTradable:
+			details = formatCoins(source.Price)
+		case gw2api.UnlockSourceAchievement:
+			details = fmt.Sprintf("Granted by achievement %s (%d AP)", source.AchievementName, source.AchievementPoints)
+		}
+
+		table.Append(
+			strconv.Itoa(source.ID),
+			source.Name,
+			string(source.Source),
+			details,
+		)
+	}
+	table.Render()
+}
+
+func outputSlotTable(slots []gw2api.ResolvedSlot) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Slot", "ID", "Name", "Rarity", "Count", "Binding")
+
+	for _, slot := range slots {
+		binding := slot.Binding
+		if slot.BoundTo != "" {
+			binding += " (" + slot.BoundTo + ")"
 		}
+		table.Append(
+			strconv.Itoa(slot.Index),
+			strconv.Itoa(slot.ItemID),
+			slot.Item.Name,
+			slot.Item.Rarity,
+			strconv.Itoa(slot.Count),
+			binding,
+		)
+	}
+	table.Render()
+}
+
+func outputRecipeTable(recipes []*gw2api.RecipeDetail) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Type", "Output Item", "Count", "Disciplines", "Rating", "Chat Link")
+
+	for _, recipe := range recipes {
+		table.Append(
+			strconv.Itoa(recipe.ID),
+			recipe.Type,
+			strconv.Itoa(recipe.OutputItemID),
+			strconv.Itoa(recipe.OutputItemCount),
+			strings.Join(recipe.Disciplines, ", "),
+			strconv.Itoa(recipe.MinRating),
+			gw2api.ChatLink{Type: gw2api.ChatLinkRecipe, ID: recipe.ID}.String(),
+		)
+	}
+	table.Render()
+}
 
-		description := currency.Description
-		if len(description) > 50 {
-			description = description[:47] + "..."
+func outputLegendaryArmoryTable(status *gw2api.LegendaryArmoryStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Slot", "Legendary", "Owned")
+
+	for _, entry := range status.Items {
+		name := entry.Item.Name
+		if name == "" {
+			name = fmt.Sprintf("Item %d", entry.Item.ID)
 		}
 
 		table.Append(
-			strconv.Itoa(currency.ID),
+			entry.Slot,
 			name,
-			description,
-			strconv.Itoa(currency.Order),
+			fmt.Sprintf("%d/%d", entry.Owned, entry.Max),
 		)
 	}
+	table.Footer("Total", fmt.Sprintf("%.1f%% complete", status.Completion()), fmt.Sprintf("%d/%d", status.Owned, status.Max))
 	table.Render()
 }
 
-func outputWorldTable(worlds []*gw2api.World) {
+func outputPvPStatsTable(stats *gw2api.PvPStats) {
+	fmt.Printf("Rank %d (%d rollovers), %d games, %.1f%% won\n",
+		stats.PvPRank, stats.PvPRankRollovers, stats.Aggregate.Games(), stats.WinRate()*100)
+
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("ID", "Name", "Population")
+	table.Header("Profession", "Games", "Wins", "Losses", "Desertions", "Forfeits", "Byes", "Win Rate")
 
-	for _, world := range worlds {
-		name := world.Name
-		if len(name) > 30 {
-			name = name[:27] + "..."
+	for _, rate := range stats.ProfessionWinRates() {
+		winRate := "-"
+		if rate.Games() > 0 {
+			winRate = fmt.Sprintf("%.1f%%", rate.WinRate*100)
 		}
+		table.Append(
+			rate.Name,
+			strconv.Itoa(rate.Games()),
+			strconv.Itoa(rate.Wins),
+			strconv.Itoa(rate.Losses),
+			strconv.Itoa(rate.Desertions),
+			strconv.Itoa(rate.Forfeits),
+			strconv.Itoa(rate.Byes),
+			winRate,
+		)
+	}
+	table.Render()
+
+	for _, ladder := range stats.LadderWinRates() {
+		fmt.Printf("%s: %d games, %.1f%% won\n", ladder.Name, ladder.Games(), ladder.WinRate*100)
+	}
+}
 
+func outputMarketDepthTable(depth *MarketDepth) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Order", "Filled", "Total", "Average", "Last Unit", "Fees")
+
+	for _, row := range []struct {
+		name string
+		fill gw2api.OrderFill
+	}{{"Buy instantly", depth.Buy}, {"Sell instantly", depth.Sell}} {
+		filled := strconv.Itoa(row.fill.Filled)
+		if !row.fill.Complete() {
+			filled += fmt.Sprintf(" of %d", row.fill.Requested)
+		}
+		fees := "-"
+		if row.fill.Fees > 0 {
+			fees = formatCoins(row.fill.Fees)
+		}
 		table.Append(
-			strconv.Itoa(world.ID),
-			name,
-			world.Population,
+			row.name,
+			filled,
+			formatCoins(row.fill.Total),
+			formatCoins(int(math.Round(row.fill.AveragePrice()))),
+			formatCoins(row.fill.LastPrice),
+			fees,
 		)
 	}
 	table.Render()
 }
 
-func outputSkillTable(skills []*gw2api.Skill) {
+func outputCharacterGearTable(gear *CharacterGear) {
+	name := gear.Name
+	if name == "" {
+		name = fmt.Sprintf("Tab %d", gear.Tab)
+	}
+	fmt.Printf("%s: %s\n", gear.Character, name)
+
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("ID", "Name", "Type", "Professions")
+	table.Header("Slot", "Item", "Rarity", "Stats", "Attributes", "Upgrades", "Infusions")
 
-	for _, skill := range skills {
-		name := skill.Name
-		if len(name) > 30 {
-			name = name[:27] + "..."
+	for _, slot := range gear.Slots {
+		stats := "-"
+		if slot.Stats != nil {
+			stats = slot.Stats.Name
+		}
+		infusions := "-"
+		if slot.InfusionSlots > 0 || len(slot.Infusions) > 0 {
+			infusions = fmt.Sprintf("%d/%d", len(slot.Infusions), slot.InfusionSlots)
 		}
+		table.Append(slot.Slot, itemName(slot.Item), slot.Item.Rarity, stats, formatAttributes(slot.Attributes), itemNames(slot.Upgrades), infusions)
+	}
+	table.Render()
 
-		skillType := skill.Type
-		if skillType == "" {
-			skillType = "N/A"
+	if len(gear.AttributeTotals) > 0 {
+		fmt.Printf("Attribute totals: %s\n", formatAttributes(gear.AttributeTotals))
+	}
+
+	if len(gear.Warnings) == 0 {
+		fmt.Println("No problems found")
+		return
+	}
+	fmt.Println("Warnings:")
+	for _, warning := range gear.Warnings {
+		fmt.Printf("  %s\n", warning)
+	}
+}
+
+// formatAttributes lists attributes from largest to smallest, such as
+// "Power 63, Precision 45"
+func formatAttributes(attributes map[string]int) string {
+	if len(attributes) == 0 {
+		return "-"
+	}
+	names := slices.Collect(maps.Keys(attributes))
+	slices.SortFunc(names, func(a, b string) int {
+		if attributes[a] != attributes[b] {
+			return attributes[b] - attributes[a]
 		}
+		return strings.Compare(a, b)
+	})
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s %d", name, attributes[name])
+	}
+	return strings.Join(parts, ", ")
+}
 
-		professions := "N/A"
-		if len(skill.Professions) > 0 {
-			professions = strings.Join(skill.Professions, ", ")
-			if len(professions) > 20 {
-				professions = professions[:17] + "..."
+// itemName is the item's name, or its ID when the item couldn't be looked up
+func itemName(item *gw2api.Item) string {
+	if item.Name == "" {
+		return fmt.Sprintf("Item %d", item.ID)
+	}
+	return item.Name
+}
+
+func itemNames(items []*gw2api.Item) string {
+	if len(items) == 0 {
+		return "-"
+	}
+	names := make([]string, len(items))
+	for i, item := range items {
+		names[i] = itemName(item)
+	}
+	return strings.Join(names, ", ")
+}
+
+func outputSnapshotDiffTable(diff *snapshot.Diff) {
+	ctx := context.Background()
+	fmt.Printf("Changes from %s to %s\n", formatTime(diff.From), formatTime(diff.To))
+	if diff.Empty() {
+		fmt.Println("Nothing changed")
+	}
+
+	if len(diff.Currencies) > 0 {
+		names := make(map[int]string)
+		if currencies, err := client.GetCurrencies(ctx, changeIDs(diff.Currencies)); err == nil {
+			for _, currency := range currencies {
+				names[currency.ID] = currency.Name
+			}
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header("Currency", "Before", "After", "Change")
+		for _, change := range diff.Currencies {
+			before, after, delta := strconv.Itoa(change.Before), strconv.Itoa(change.After), fmt.Sprintf("%+d", change.Delta())
+			if change.ID == gw2api.CurrencyCoin {
+				before, after, delta = formatCoins(change.Before), formatCoins(change.After), formatCoinDelta(change.Delta())
 			}
+			table.Append(nameOrID(names, "Currency", change.ID), before, after, delta)
+		}
+		table.Render()
+	}
+
+	if len(diff.Items) > 0 {
+		names := make(map[int]string)
+		items, _ := client.GetItems(ctx, changeIDs(diff.Items)) // Items that fail fall back to IDs
+		for _, item := range items {
+			names[item.ID] = item.Name
+		}
+
+		table := tablewriter.NewWriter(os.Stdout)
+		table.Header("Item", "Before", "After", "Change")
+		for _, change := range diff.Items {
+			table.Append(nameOrID(names, "Item", change.ID), strconv.Itoa(change.Before), strconv.Itoa(change.After), fmt.Sprintf("%+d", change.Delta()))
+		}
+		table.Render()
+	}
+
+	for _, kind := range []string{snapshot.UnlockSkins, snapshot.UnlockMinis, snapshot.UnlockOutfits} {
+		if added := diff.NewUnlocks[kind]; len(added) > 0 {
+			fmt.Printf("New %s: %d\n", kind, len(added))
 		}
+	}
+	if diff.AchievementPoints != 0 {
+		fmt.Printf("Achievement points: %+d\n", diff.AchievementPoints)
+	}
+	if diff.MasteryPoints != 0 {
+		fmt.Printf("Mastery points: %+d\n", diff.MasteryPoints)
+	}
+	if len(diff.Incomparable) > 0 {
+		fmt.Printf("Not compared, missing from one snapshot: %s\n", strings.Join(diff.Incomparable, ", "))
+	}
+}
+
+func changeIDs(changes []snapshot.Change) []int {
+	ids := make([]int, len(changes))
+	for i, change := range changes {
+		ids[i] = change.ID
+	}
+	return ids
+}
+
+func nameOrID(names map[int]string, kind string, id int) string {
+	if name := names[id]; name != "" {
+		return name
+	}
+	return fmt.Sprintf("%s %d", kind, id)
+}
 
+func formatCoinDelta(copper int) string {
+	if copper < 0 {
+		return "-" + formatCoins(-copper)
+	}
+	return "+" + formatCoins(copper)
+}
+
+func outputNearlyDoneTable(achievements []gw2api.NearlyDoneAchievement) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("ID", "Name", "Progress", "Complete", "AP Left")
+
+	for _, a := range achievements {
 		table.Append(
-			strconv.Itoa(skill.ID),
-			name,
-			skillType,
-			professions,
+			strconv.Itoa(a.ID),
+			a.Name,
+			fmt.Sprintf("%d/%d", a.Current, a.Max),
+			fmt.Sprintf("%.0f%%", a.Ratio*100),
+			strconv.Itoa(a.Points),
 		)
 	}
 	table.Render()
 }
 
-func outputPriceTable(prices []*gw2api.Price) {
+func outputMissingDyesTable(missing *gw2api.MissingDyes) {
+	fmt.Printf("%d of %d dyes unlocked\n", missing.Unlocked, missing.Total)
+
 	table := tablewriter.NewWriter(os.Stdout)
-	table.Header("Item ID", "Buy Price", "Buy Qty", "Sell Price", "Sell Qty")
+	table.Header("Color", "Name", "Hue", "Material", "Rarity", "Item", "Price")
+	for _, dye := range missing.Ranked {
+		table.Append(
+			strconv.Itoa(dye.ColorID),
+			dye.Name,
+			dye.Hue,
+			dye.Material,
+			dye.Rarity,
+			strconv.Itoa(dye.ItemID),
+			formatCoins(int(dye.Price)),
+		)
+	}
+	table.Render()
+	fmt.Printf("Total: %s for %d dyes\n", formatCoins(int(missing.Cost())), len(missing.Ranked))
 
-	for _, price := range prices {
+	if len(missing.Unavailable) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Not for sale:")
+	table = tablewriter.NewWriter(os.Stdout)
+	table.Header("Color", "Name", "Hue", "Material", "Rarity", "Reason")
+	for _, dye := range missing.Unavailable {
 		table.Append(
-			strconv.Itoa(price.ID),
-			strconv.Itoa(price.Buys.UnitPrice),
-			strconv.Itoa(price.Buys.Quantity),
-			strconv.Itoa(price.Sells.UnitPrice),
-			strconv.Itoa(price.Sells.Quantity),
+			strconv.Itoa(dye.ColorID),
+			dye.Name,
+			dye.Hue,
+			dye.Material,
+			dye.Rarity,
+			dye.Reason,
+		)
+	}
+	table.Render()
+}
+
+func outputCraftableUpgradeTable(upgrades []gw2api.CraftableUpgrade) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Discipline", "Rating", "Recipe ID", "Min Rating", "Output")
+
+	for _, u := range upgrades {
+		output := strconv.Itoa(u.Recipe.OutputItemID)
+		if u.OutputItem != nil && u.OutputItem.Name != "" {
+			output = u.OutputItem.Name
+		}
+		table.Append(
+			u.Discipline,
+			strconv.Itoa(u.Rating),
+			strconv.Itoa(u.Recipe.ID),
+			strconv.Itoa(u.Recipe.MinRating),
+			output,
+		)
+	}
+	table.Render()
+}
+
+func outputVaultPlanTable(plan *gw2api.VaultPurchasePlan) {
+	fmt.Printf("Astral Acclaim: %d", plan.Acclaim)
+	if plan.CoinsPerAcclaim > 0 {
+		fmt.Printf(" (worth %s in gold rewards, %.0fc each)", formatCoins(int(float64(plan.Acclaim)*plan.CoinsPerAcclaim)), plan.CoinsPerAcclaim)
+	}
+	fmt.Println()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Reward", "Priority", "Buy", "Acclaim", "Gold Equivalent", "Acclaim Left")
+	for _, entry := range plan.Plan {
+		left := strconv.Itoa(entry.AcclaimAfter)
+		if !entry.Affordable {
+			left = "can't afford"
+		}
+		table.Append(
+			vaultRewardName(entry.Listing, entry.Item),
+			entry.Priority,
+			strconv.Itoa(entry.Quantity),
+			strconv.Itoa(entry.Cost),
+			formatCoins(entry.GoldEquivalent),
+			left,
+		)
+	}
+	table.Render()
+
+	if len(plan.Purchased) > 0 {
+		var bought []string
+		for _, entry := range plan.Purchased {
+			bought = append(bought, fmt.Sprintf("%s (%d)", vaultRewardName(entry.Listing, entry.Item), entry.Quantity))
+		}
+		fmt.Printf("Already bought: %s\n", strings.Join(bought, ", "))
+	}
+}
+
+// vaultRewardName names a vault listing's reward, falling back to its item ID
+func vaultRewardName(listing gw2api.WizardsVaultListing, item *gw2api.Item) string {
+	name := strconv.Itoa(listing.ItemID)
+	if item != nil && item.Name != "" {
+		name = item.Name
+	}
+	if listing.ItemCount > 1 {
+		name = fmt.Sprintf("%dx %s", listing.ItemCount, name)
+	}
+	return name
+}
+
+func outputClaimableVaultTable(claimable *gw2api.ClaimableVaultListings) {
+	fmt.Printf("Astral Acclaim: %d\n", claimable.Acclaim)
+	if len(claimable.Listings) == 0 {
+		fmt.Println("Nothing is affordable right now")
+		return
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Reward", "Type", "Acclaim", "Bought", "Can Buy")
+	for _, entry := range claimable.Listings {
+		bought := strconv.Itoa(entry.Listing.Purchased)
+		if entry.Listing.PurchaseLimit > 0 {
+			bought += "/" + strconv.Itoa(entry.Listing.PurchaseLimit)
+		}
+		table.Append(
+			vaultRewardName(entry.Listing, entry.Item),
+			entry.Listing.Type,
+			strconv.Itoa(entry.Listing.Cost),
+			bought,
+			strconv.Itoa(entry.Quantity),
+		)
+	}
+	table.Render()
+}
+
+func outputCharacterBirthdayTable(birthdays []CharacterBirthday) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Name", "Profession", "Level", "Created", "Next Birthday", "Birthday", "Played")
+
+	today := time.Now().Truncate(24 * time.Hour)
+	for _, b := range birthdays {
+		next := b.NextBirthday.Format(time.DateOnly)
+		if days := int(b.NextBirthday.Sub(today).Hours() / 24); days < 1 {
+			next += " (today)"
+		} else {
+			next += fmt.Sprintf(" (%d days)", days)
+		}
+		table.Append(
+			b.Name,
+			b.Profession,
+			strconv.Itoa(b.Level),
+			b.Created.Format(time.DateOnly),
+			next,
+			ordinal(b.Birthday),
+			fmt.Sprintf("%dh", b.Playtime.Hours()),
+		)
+	}
+	table.Render()
+}
+
+// ordinal formats 1 as 1st, 2 as 2nd and so on
+func ordinal(n int) string {
+	suffix := "th"
+	switch {
+	case n%100 >= 11 && n%100 <= 13:
+	case n%10 == 1:
+		suffix = "st"
+	case n%10 == 2:
+		suffix = "nd"
+	case n%10 == 3:
+		suffix = "rd"
+	}
+	return strconv.Itoa(n) + suffix
+}
+
+func outputKeyStatusTable(statuses []KeyStatus) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Name", "Account", "Token Name", "Scopes")
+
+	for _, status := range statuses {
+		if status.Error != "" {
+			table.Append(status.Name, "error: "+status.Error, "", "")
+			continue
+		}
+		table.Append(status.Name, status.Account, status.TokenName, strings.Join(status.Permissions, ", "))
+	}
+	table.Render()
+}
+
+func outputCacheStatsTable(stats *gw2api.DataCacheStats) {
+	fmt.Printf("Data directory: %s\n", stats.DataDir)
+	fmt.Printf("Loaded in %s at %s\n", stats.LoadTime.Round(time.Millisecond), formatTime(stats.LastLoadTime))
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Kind", "Records", "Corrupt", "Load Time", "Hits", "Misses")
+	for _, kind := range stats.Kinds {
+		table.Append(
+			kind.Kind,
+			strconv.Itoa(kind.Records),
+			strconv.Itoa(kind.Corrupt),
+			kind.LoadTime.Round(time.Millisecond).String(),
+			strconv.FormatInt(kind.Hits, 10),
+			strconv.FormatInt(kind.Misses, 10),
+		)
+	}
+	table.Footer("Total", "", "", "", strconv.FormatInt(stats.TotalCacheHits, 10), strconv.FormatInt(stats.TotalCacheMisses, 10))
+	table.Render()
+
+	if stats.CustomRecipesLoaded > 0 {
+		fmt.Printf("Recipes include %d custom recipes\n", stats.CustomRecipesLoaded)
+	}
+}
+
+func outputDataFileDiagnosticsTable(diagnostics []gw2api.DataFileDiagnostics) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Kind", "Lines", "Invalid", "Duplicates", "Manifest", "Status")
+	for _, diagnostic := range diagnostics {
+		manifest := "-"
+		if diagnostic.ManifestCount >= 0 {
+			manifest = strconv.Itoa(diagnostic.ManifestCount)
+		}
+		status := "OK"
+		if !diagnostic.OK() {
+			status = strings.Join(diagnostic.Problems, ", ")
+		}
+		table.Append(
+			diagnostic.Kind,
+			strconv.Itoa(diagnostic.Lines),
+			strconv.Itoa(diagnostic.Corrupt),
+			strconv.Itoa(diagnostic.Duplicates),
+			manifest,
+			status,
 		)
 	}
 	table.Render()
@@ -0,0 +1,253 @@
+package main
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// captureOutput runs fn with stdout redirected and returns what it printed,
+// along with the exit status passed to exit, or -1 if it was not called
+func captureOutput(t *testing.T, format string, fail bool, fn func()) (string, int) {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout, savedFormat, savedFail, savedExit := os.Stdout, outputFormat, failEmpty, exit
+	t.Cleanup(func() {
+		os.Stdout, outputFormat, failEmpty, exit = stdout, savedFormat, savedFail, savedExit
+	})
+
+	status := -1
+	os.Stdout, outputFormat, failEmpty = w, format, fail
+	exit = func(code int) { status = code }
+
+	fn()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out), status
+}
+
+func TestOutputEmpty(t *testing.T) {
+	outputs := map[string]func(){
+		"nil ids":     func() { outputIDs(nil) },
+		"empty ids":   func() { outputIDs([]int{}) },
+		"nil data":    func() { outputData(nil) },
+		"nil slice":   func() { outputData([]*gw2api.Item(nil)) },
+		"empty slice": func() { outputData([]*gw2api.Item{}) },
+		"nil pointer": func() { outputData((*gw2api.Item)(nil)) },
+	}
+	expected := map[string]string{
+		"table": "No results found\n",
+		"json":  "[]\n",
+		"yaml":  "[]\n", // Unknown formats fall back to JSON
+	}
+
+	for name, output := range outputs {
+		for format, want := range expected {
+			got, status := captureOutput(t, format, false, output)
+			if got != want {
+				t.Errorf("%s as %s printed %q, expected %q", name, format, got, want)
+			}
+			if status != -1 {
+				t.Errorf("%s as %s exited with %d without --fail-empty", name, format, status)
+			}
+
+			_, status = captureOutput(t, format, true, output)
+			if status != exitNoResults {
+				t.Errorf("%s as %s exited with %d with --fail-empty, expected %d", name, format, status, exitNoResults)
+			}
+		}
+	}
+}
+
+func TestOutputNotEmpty(t *testing.T) {
+	for _, format := range []string{"table", "json"} {
+		got, status := captureOutput(t, format, true, func() { outputIDs([]int{24}) })
+		if !strings.Contains(got, "24") || status != -1 {
+			t.Errorf("IDs as %s printed %q and exited with %d", format, got, status)
+		}
+
+		got, status = captureOutput(t, format, true, func() {
+			outputData([]*gw2api.Item{{ID: 24, Name: "Sealed Package of Snowballs"}})
+		})
+		if !strings.Contains(got, "Sealed Package of Snowballs") || status != -1 {
+			t.Errorf("items as %s printed %q and exited with %d", format, got, status)
+		}
+	}
+}
+
+func TestOutputOutstandingOrders(t *testing.T) {
+	now := time.Now()
+	orders := &gw2api.OutstandingOrders{
+		Buys: []*gw2api.OrderSummary{
+			{ItemID: 19699, Name: "Iron Ore", Orders: 2, Quantity: 500, Coins: 31750, Oldest: now.Add(-45 * 24 * time.Hour), AgeBuckets: []int{0, 1, 0, 1}},
+			{ItemID: 19721, Orders: 1, Quantity: 100, Coins: 5000, Oldest: now.Add(-3 * time.Hour), AgeBuckets: []int{1, 0, 0, 0}},
+		},
+		BuyCoins:  36750,
+		SellCoins: 21250,
+		AsOf:      now,
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { outputData(orders) })
+	for _, want := range []string{"Iron Ore", "45d (stale)", "Item 19721", "3h", "7 - 30 D", "3g 67s 50c", "Tied up on the trading post: 5g 80s 0c"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "3h (stale)") {
+		t.Errorf("a recent order is flagged stale:\n%s", out)
+	}
+}
+
+func TestOutputUnlockSources(t *testing.T) {
+	sources := []*gw2api.UnlockSource{
+		{Kind: gw2api.UnlockKindMini, ID: 2, Name: "Mini Llama", Source: gw2api.UnlockSourceAchievement, AchievementID: 7, AchievementName: "Llama Herder", AchievementPoints: 10},
+		{Kind: gw2api.UnlockKindMini, ID: 3, Name: "Mini Skritt", Source: gw2api.UnlockSourceTradable, ItemID: 300, Price: 12345},
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { outputData(sources) })
+	for _, want := range []string{"Granted by achievement Llama Herder (10 AP)", "1g 23s 45c"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestOutputPriceRows(t *testing.T) {
+	rows := []*PriceRow{
+		newPriceRow(&gw2api.Price{ID: 19721, Buys: gw2api.PriceInfo{Quantity: 3000, UnitPrice: 800}, Sells: gw2api.PriceInfo{Quantity: 1000, UnitPrice: 1000}}),
+		newPriceRow(&gw2api.Price{ID: 19976, Buys: gw2api.PriceInfo{Quantity: 5, UnitPrice: 100}}),
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { outputData(rows) })
+	for _, want := range []string{"20.0%", "50", "3.00"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output does not contain %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "\x1b[") {
+		t.Errorf("output to a pipe is colored:\n%s", out)
+	}
+
+	out, _ = captureOutput(t, "json", false, func() { outputData(rows[0]) })
+	for _, want := range []string{`"derived": {`, `"flip_profit": 50`, `"demand_supply_ratio": 3`, `"unit_price": 800`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("JSON output does not contain %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestOutputCount(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("X-Page", "0")
+		w.Header().Set("X-Page-Total", "61234")
+		w.Header().Set("X-Result-Total", "61234")
+		w.Write([]byte(`[{"id": 24}]`))
+	}))
+	defer server.Close()
+	saved := client
+	client = gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithRetries(0))
+	defer func() { client = saved }()
+
+	out, _ := captureOutput(t, "table", false, func() { outputCount(context.Background(), "/v2/items") })
+	if out != "Total: 61234\n" {
+		t.Errorf("table output = %q", out)
+	}
+	if !strings.Contains(query, "page_size=1") {
+		t.Errorf("query = %q, want a single-entry page", query)
+	}
+
+	out, _ = captureOutput(t, "json", false, func() { outputCount(context.Background(), "/v2/items") })
+	if !strings.Contains(out, `"total": 61234`) {
+		t.Errorf("json output = %q", out)
+	}
+}
+
+func TestPrintItemDelta(t *testing.T) {
+	at := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	deltas := map[string]gw2api.ItemDelta{
+		"2025-06-01 12:30:00 +3 Mithril Ore (Alice)\n": {ItemID: 19700, Change: 3, Source: gw2api.ItemSourceCharacters, Character: "Alice", Time: at},
+		"2025-06-01 12:30:00 -2 Mithril Ore (bank)\n":  {ItemID: 19700, Change: -2, Source: gw2api.ItemSourceBank, Time: at},
+	}
+	for expected, delta := range deltas {
+		out, _ := captureOutput(t, "table", false, func() { printItemDelta(os.Stdout, delta, "Mithril Ore") })
+		if out != expected {
+			t.Errorf("table output = %q, want %q", out, expected)
+		}
+	}
+
+	delta := deltas["2025-06-01 12:30:00 -2 Mithril Ore (bank)\n"]
+	out, _ := captureOutput(t, "json", false, func() { printItemDelta(os.Stdout, delta, "Mithril Ore") })
+	if !strings.Contains(out, `"change":-2`) || !strings.Contains(out, `"name":"Mithril Ore"`) || strings.Count(out, "\n") != 1 {
+		t.Errorf("json output = %q", out)
+	}
+}
+
+func TestAchievementRowOutput(t *testing.T) {
+	row := &AchievementRow{
+		Achievement: &gw2api.Achievement{ID: 13, Name: "Finale", Type: "Default", Prerequisites: []int{12}},
+		Requires:    []string{"Act 1", "Act 2", "Act 3"},
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { outputData(row) })
+	if !strings.Contains(out, "REQUIRES") || !strings.Contains(out, "Act 1, Act 2, Act 3") {
+		t.Errorf("table output = %q", out)
+	}
+	out, _ = captureOutput(t, "table", false, func() { outputData(&gw2api.Achievement{ID: 10, Name: "Act 1"}) })
+	if strings.Contains(out, "REQUIRES") {
+		t.Errorf("table output without prerequisites = %q", out)
+	}
+
+	out, _ = captureOutput(t, "json", false, func() { outputData(row) })
+	if !strings.Contains(out, `"requires": [`) || !strings.Contains(out, `"prerequisites": [`) {
+		t.Errorf("json output = %q", out)
+	}
+}
+
+func TestSkillsGetOutputShape(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var skills []string
+		for id := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+			skills = append(skills, `{"id": `+id+`, "name": "Skill `+id+`", "type": "Weapon", "professions": ["Guardian"]}`)
+		}
+		w.Write([]byte("[" + strings.Join(skills, ",") + "]"))
+	}))
+	defer server.Close()
+	saved := client
+	client = gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithRetries(0))
+	defer func() { client = saved }()
+
+	// One skill or several, JSON output is a list
+	for _, args := range [][]string{{"9102"}, {"9102", "9103"}} {
+		var err error
+		out, _ := captureOutput(t, "json", false, func() { err = skillsGetCmd.RunE(skillsGetCmd, args) })
+		if err != nil {
+			t.Fatalf("skills get %v: %v", args, err)
+		}
+		if !strings.HasPrefix(strings.TrimSpace(out), "[") || strings.Count(out, `"name"`) != len(args) {
+			t.Errorf("skills get %v printed:\n%s", args, out)
+		}
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { skillsGetCmd.RunE(skillsGetCmd, []string{"9102", "9103"}) })
+	for _, want := range []string{"Skill 9102", "Skill 9103", "Guardian"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output does not contain %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// defaultPriceTTL is how long the CLI reuses a trading post price
+const defaultPriceTTL = 5 * time.Minute
+
+// priceCacheSize is the most prices kept in the price cache file
+const priceCacheSize = 20000
+
+// priceCacheLockWait is how long to wait for another invocation to finish
+// with the price cache file
+const priceCacheLockWait = 5 * time.Second
+
+// priceCachePath returns the price cache file, kept next to the config file
+func priceCachePath() (string, error) {
+	path, _, err := resolveConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "prices.json"), nil
+}
+
+// openPriceCacheFile opens the price cache file next to the config file
+func openPriceCacheFile(ttl time.Duration) (*diskPriceCache, error) {
+	path, err := priceCachePath()
+	if err != nil {
+		return nil, err
+	}
+	return openPriceCache(path, ttl)
+}
+
+// diskPriceCache is a price cache loaded from a file and written back once
+// the command finishes, if any prices were fetched
+type diskPriceCache struct {
+	*cache.LRUCache
+	path  string
+	ttl   time.Duration
+	dirty atomic.Bool
+}
+
+// Set caches a price and marks the cache as needing to be saved
+func (d *diskPriceCache) Set(key string, value any, ttl time.Duration) {
+	d.LRUCache.Set(key, value, ttl)
+	d.dirty.Store(true)
+}
+
+// openPriceCache loads the price cache file at path, leaving out prices older
+// than ttl even if they were saved with a longer one. A missing or corrupt
+// file gives an empty cache, which replaces it when saved.
+func openPriceCache(path string, ttl time.Duration) (*diskPriceCache, error) {
+	d := &diskPriceCache{LRUCache: cache.NewLRUCache(priceCacheSize), path: path, ttl: ttl}
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return d, nil
+	}
+	err := withFileLock(path, func() error {
+		if entries, err := readPriceCache(path); err == nil {
+			d.Restore(freshEntries(entries, ttl, time.Now()))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// save merges the cached prices into the file, so prices saved by another
+// invocation in the meantime are kept. Nothing is written if no prices were
+// fetched.
+func (d *diskPriceCache) save() error {
+	if !d.dirty.Load() {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(d.path), 0o755); err != nil {
+		return err
+	}
+	return withFileLock(d.path, func() error {
+		merged := cache.NewLRUCache(priceCacheSize)
+		// A corrupt file is replaced rather than blocking every later save
+		if entries, err := readPriceCache(d.path); err == nil {
+			merged.Restore(freshEntries(entries, d.ttl, time.Now()))
+		}
+		merged.Restore(d.Snapshot())
+
+		tmp, err := os.CreateTemp(filepath.Dir(d.path), filepath.Base(d.path)+".*")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(tmp.Name())
+		if err := cache.WriteSnapshot(tmp, merged.Snapshot()); err != nil {
+			tmp.Close()
+			return err
+		}
+		if err := tmp.Close(); err != nil {
+			return err
+		}
+		return os.Rename(tmp.Name(), d.path)
+	})
+}
+
+// readPriceCache decodes the entries in a price cache file, or none if there
+// is no file
+func readPriceCache(path string) ([]cache.Entry, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return cache.ReadSnapshot(f, func(key string, value json.RawMessage) (any, error) {
+		var price gw2api.Price
+		if err := json.Unmarshal(value, &price); err != nil {
+			return nil, err
+		}
+		return &price, nil
+	})
+}
+
+// freshEntries drops entries stored more than ttl before now
+func freshEntries(entries []cache.Entry, ttl time.Duration, now time.Time) []cache.Entry {
+	var fresh []cache.Entry
+	for _, entry := range entries {
+		if now.Sub(entry.StoredAt) < ttl {
+			fresh = append(fresh, entry)
+		}
+	}
+	return fresh
+}
+
+// withFileLock runs fn while holding an OS lock on path's lock file, so
+// parallel invocations don't read a file while another replaces it or lose
+// each other's writes. The OS drops the lock when its holder exits, so a
+// crashed invocation never leaves it held. The lock file stays in place, as
+// removing it would let two invocations lock different files.
+func withFileLock(path string, fn func() error) error {
+	lockPath := path + ".lock"
+	lock, err := os.OpenFile(lockPath, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to lock %s: %w", path, err)
+	}
+	defer lock.Close()
+
+	deadline := time.Now().Add(priceCacheLockWait)
+	for {
+		locked, err := tryLockFile(lock)
+		if err != nil {
+			return fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			break
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for another gw2api to release %s", lockPath)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	defer unlockFile(lock)
+	return fn()
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestDiskPriceCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "gw2api", "prices.json")
+
+	// A missing file and directory give an empty cache
+	first, err := openPriceCache(path, time.Minute)
+	if err != nil {
+		t.Fatalf("openPriceCache: %v", err)
+	}
+	second, err := openPriceCache(path, time.Minute)
+	if err != nil {
+		t.Fatalf("openPriceCache: %v", err)
+	}
+
+	// Saving without fetching anything leaves no file
+	if err := first.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Fatalf("expected no file after saving an unchanged cache, got %v", err)
+	}
+
+	// Two invocations that ran side by side both keep their prices
+	first.Set(gw2api.PriceCacheKey(1), &gw2api.Price{ID: 1, Sells: gw2api.PriceInfo{UnitPrice: 100}}, time.Minute)
+	second.Set(gw2api.PriceCacheKey(2), &gw2api.Price{ID: 2, Sells: gw2api.PriceInfo{UnitPrice: 200}}, time.Minute)
+	if err := first.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+	if err := second.save(); err != nil {
+		t.Fatalf("save: %v", err)
+	}
+
+	reopened, err := openPriceCache(path, time.Minute)
+	if err != nil {
+		t.Fatalf("openPriceCache: %v", err)
+	}
+	for id, expected := range map[int]int{1: 100, 2: 200} {
+		value, found := reopened.Get(gw2api.PriceCacheKey(id))
+		if price, ok := value.(*gw2api.Price); !found || !ok || price.Sells.UnitPrice != expected {
+			t.Errorf("price %d = %v, %v, expected %d", id, value, found, expected)
+		}
+	}
+
+	// A shorter TTL than the prices were saved with still applies
+	time.Sleep(20 * time.Millisecond)
+	if short, err := openPriceCache(path, 10*time.Millisecond); err != nil {
+		t.Fatalf("openPriceCache: %v", err)
+	} else if _, found := short.Get(gw2api.PriceCacheKey(1)); found {
+		t.Error("expected prices older than the TTL to be dropped")
+	}
+
+	// A corrupt file is treated as empty rather than failing every command
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if corrupt, err := openPriceCache(path, time.Minute); err != nil || corrupt.Stats().Size != 0 {
+		t.Errorf("openPriceCache on a corrupt file = %v, expected an empty cache", err)
+	}
+}
+
+func TestWithFileLockLeftover(t *testing.T) {
+	// A lock file nobody holds, as a crashed invocation leaves, isn't a lock
+	path := filepath.Join(t.TempDir(), "prices.json")
+	if err := os.WriteFile(path+".lock", nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ran := false
+	if err := withFileLock(path, func() error { ran = true; return nil }); err != nil || !ran {
+		t.Errorf("withFileLock = %v, ran %v, expected a leftover lock file to be reused", err, ran)
+	}
+}
+
+func TestWithFileLockExcludes(t *testing.T) {
+	// Each holder reads the count and writes it back one higher, so any two
+	// holding the lock at once lose an increment
+	path := filepath.Join(t.TempDir(), "count")
+	const holders = 8
+	var wg sync.WaitGroup
+	errs := make(chan error, holders)
+	for range holders {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- withFileLock(path, func() error {
+				data, _ := os.ReadFile(path)
+				count, _ := strconv.Atoi(string(data))
+				time.Sleep(5 * time.Millisecond)
+				return os.WriteFile(path, []byte(strconv.Itoa(count+1)), 0o644)
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatalf("withFileLock: %v", err)
+		}
+	}
+	if data, _ := os.ReadFile(path); string(data) != strconv.Itoa(holders) {
+		t.Errorf("count = %s, expected %d", data, holders)
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+var rawCmd = &cobra.Command{
+	Use:   "raw <endpoint>",
+	Short: "Fetch any API endpoint and print its JSON",
+	Long: `Fetch an API endpoint and print the response as JSON, including endpoints
+this CLI has no command for yet. The endpoint may be given with or without the
+leading /v2/. The API key, language, rate limit and retries apply as for every
+other command. With --verbose, the pagination headers are printed to stderr.
+
+Examples:
+  gw2api raw items --ids 19721,24
+  gw2api raw /v2/commerce/listings --page 0 --page-size 5
+  gw2api raw account/wallet --api-key ...
+  gw2api raw wvw/matches/overview --param world=1008`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ids, _ := cmd.Flags().GetStringSlice("ids")
+		params, _ := cmd.Flags().GetStringArray("param")
+
+		var options []gw2api.RequestOption
+		if len(ids) > 0 {
+			// IDs go through as given, since some endpoints use string IDs
+			options = append(options, gw2api.WithParam("ids", strings.Join(ids, ",")))
+		}
+		if cmd.Flags().Changed("page") {
+			page, _ := cmd.Flags().GetInt("page")
+			options = append(options, gw2api.WithPage(page))
+		}
+		if cmd.Flags().Changed("page-size") {
+			pageSize, _ := cmd.Flags().GetInt("page-size")
+			options = append(options, gw2api.WithPageSize(pageSize))
+		}
+		for _, param := range params {
+			name, value, found := strings.Cut(param, "=")
+			if !found || name == "" {
+				return fmt.Errorf("invalid --param %q, expected name=value", param)
+			}
+			options = append(options, gw2api.WithParam(name, value))
+		}
+
+		ctx := context.Background()
+		data, pagination, err := client.GetRaw(ctx, rawEndpoint(args[0]), options...)
+		if err != nil {
+			return err
+		}
+		if verbose && pagination != nil {
+			fmt.Fprintf(os.Stderr, "Page %d of %d, %d per page, %d results in total\n",
+				pagination.Page, pagination.PageTotal, pagination.PageSize, pagination.Total)
+		}
+
+		var indented bytes.Buffer
+		if err := json.Indent(&indented, data, "", "  "); err != nil {
+			// Print what the API sent even when it isn't JSON
+			os.Stdout.Write(data)
+			fmt.Println()
+			return nil
+		}
+		fmt.Println(indented.String())
+		return nil
+	},
+}
+
+// rawEndpoint turns "items", "v2/items" or "/v2/items" into "/v2/items"
+func rawEndpoint(endpoint string) string {
+	endpoint = "/" + strings.TrimPrefix(strings.TrimSpace(endpoint), "/")
+	if endpoint == "/v2" || endpoint == "/v2.json" || strings.HasPrefix(endpoint, "/v2/") {
+		return endpoint
+	}
+	return "/v2" + endpoint
+}
@@ -0,0 +1,20 @@
+package main
+
+import "testing"
+
+func TestRawEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"items":              "/v2/items",
+		"/items":             "/v2/items",
+		"v2/items":           "/v2/items",
+		"/v2/account/wallet": "/v2/account/wallet",
+		" commerce/prices ":  "/v2/commerce/prices",
+		"v2.json":            "/v2.json",
+		"v2":                 "/v2",
+	}
+	for input, expected := range tests {
+		if got := rawEndpoint(input); got != expected {
+			t.Errorf("rawEndpoint(%q) = %q, expected %q", input, got, expected)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package main
+
+import "github.com/mattn/go-runewidth"
+
+// truncate shortens s to at most width terminal columns, ending it with
+// "..." when anything was cut. Widths are measured the way the table writer
+// measures them, so Chinese characters count as two columns, and s is only
+// cut between runes, never inside a multibyte character.
+func truncate(s string, width int) string {
+	if runewidth.StringWidth(s) <= width {
+		return s
+	}
+	return runewidth.Truncate(s, width, "...")
+}
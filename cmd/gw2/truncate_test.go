@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/mattn/go-runewidth"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestTruncate(t *testing.T) {
+	tests := []struct {
+		s        string
+		width    int
+		expected string
+	}{
+		{"Mithril Ore", 20, "Mithril Ore"},
+		{"Gift of Battle and Exploration", 20, "Gift of Battle an..."},
+		{"Épée de l'Éternité incandescente", 20, "Épée de l'Éternit..."},
+		{"Geschliffener Kristall des Äthers", 20, "Geschliffener Kri..."},
+		// Each Chinese character is two columns wide
+		{"秘银矿石", 8, "秘银矿石"},
+		{"传奇武器的赠礼和战斗的馈赠", 20, "传奇武器的赠礼和..."},
+		{"传奇武器的赠礼和战斗的馈赠", 10, "传奇武..."},
+	}
+	for _, test := range tests {
+		got := truncate(test.s, test.width)
+		if got != test.expected {
+			t.Errorf("truncate(%q, %d) = %q, want %q", test.s, test.width, got, test.expected)
+		}
+		if !utf8.ValidString(got) {
+			t.Errorf("truncate(%q, %d) = %q is not valid UTF-8", test.s, test.width, got)
+		}
+		if width := runewidth.StringWidth(got); width > test.width {
+			t.Errorf("truncate(%q, %d) is %d columns wide", test.s, test.width, width)
+		}
+	}
+}
+
+func TestTableOutputMultibyte(t *testing.T) {
+	items := []*gw2api.Item{
+		{ID: 1, Name: "传奇武器的赠礼和战斗的馈赠传奇武器的赠礼和战斗的馈赠传奇武器的赠礼", Type: "Trophy", Rarity: "Legendary"},
+		{ID: 2, Name: "Épée de l'Éternité incandescente, forgée dans les flammes du Dragon", Type: "Weapon", Rarity: "Exotic"},
+	}
+	out, _ := captureOutput(t, "table", false, func() { outputData(items) })
+	if !utf8.ValidString(out) {
+		t.Errorf("table output is not valid UTF-8: %q", out)
+	}
+	if !strings.Contains(out, "...") {
+		t.Errorf("table output = %q, want truncated names", out)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/olekukonko/tablewriter"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// WvWMapReport is the objectives of a match with the totals of each side
+type WvWMapReport struct {
+	Match      string                      `json:"match"`
+	Summary    *gw2api.WvWObjectiveSummary `json:"summary"`
+	Objectives []WvWObjectiveRow           `json:"objectives"`
+}
+
+// WvWObjectiveRow is an objective of a match with its name and upgrade progress
+type WvWObjectiveRow struct {
+	gw2api.WvWObjectiveProgress
+	Name string `json:"name,omitempty"`
+}
+
+// wvwMapReport builds the report for the match a team plays in. Objectives are
+// named when the objective list can be fetched.
+func wvwMapReport(ctx context.Context, teamID int, now time.Time) (*WvWMapReport, error) {
+	match, err := client.GetWvWMatchByWorld(ctx, teamID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the match of team %d: %w", teamID, err)
+	}
+
+	names := make(map[string]string)
+	if objectives, err := client.GetAllWvWObjectives(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to get objective names: %v\n", err)
+	} else {
+		for _, objective := range objectives {
+			names[objective.ID] = objective.Name
+		}
+	}
+
+	report := &WvWMapReport{Match: match.ID, Summary: match.ObjectiveSummary()}
+	for _, progress := range match.ObjectiveProgress(now) {
+		report.Objectives = append(report.Objectives, WvWObjectiveRow{WvWObjectiveProgress: progress, Name: names[progress.ID]})
+	}
+	return report, nil
+}
+
+// accountTeamID returns the WvW team of the account the API key belongs to
+func accountTeamID(ctx context.Context) (int, error) {
+	info, err := client.GetAccountWvW(ctx)
+	if err != nil {
+		return 0, scopeError(err, "account")
+	}
+	if info.TeamID == 0 {
+		return 0, fmt.Errorf("the account has no WvW team; give a team or world ID")
+	}
+	return info.TeamID, nil
+}
+
+func outputWvWMapTable(report *WvWMapReport) {
+	fmt.Printf("Match %s\n", report.Match)
+	summary := tablewriter.NewWriter(os.Stdout)
+	summary.Header("Side", "Objectives", "Points/Tick", "Tier 3", "Claimed")
+	for _, side := range []struct {
+		name string
+		team gw2api.WvWTeamObjectives
+	}{{"red", report.Summary.Red}, {"blue", report.Summary.Blue}, {"green", report.Summary.Green}} {
+		summary.Append(side.name, strconv.Itoa(side.team.Objectives), strconv.Itoa(side.team.PointsPerTick), strconv.Itoa(side.team.Tier3), strconv.Itoa(side.team.Claimed))
+	}
+	summary.Render()
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.Header("Map", "Objective", "Type", "Owner", "Tier", "Yaks", "Points/Tick", "Claimed For", "Next Tier")
+	for _, row := range report.Objectives {
+		name := row.Name
+		if name == "" {
+			name = row.ID
+		}
+		yaks, tier := "-", "-"
+		if row.Type == "Tower" || row.Type == "Keep" || row.Type == "Castle" {
+			yaks, tier = strconv.Itoa(row.YaksDelivered), strconv.Itoa(row.Tier)
+		}
+		table.Append(
+			row.Map,
+			truncate(name, 30),
+			row.Type,
+			row.Owner,
+			tier,
+			yaks,
+			strconv.Itoa(row.PointsTick),
+			formatSeconds(row.ClaimedForSeconds),
+			formatSeconds(row.NextTierSeconds),
+		)
+	}
+	table.Render()
+}
+
+// formatSeconds shows a duration to the minute, such as "2h5m", or "-" for none
+func formatSeconds(seconds int) string {
+	if seconds <= 0 {
+		return "-"
+	}
+	d := (time.Duration(seconds) * time.Second).Round(time.Minute)
+	if d < time.Minute {
+		return "<1m"
+	}
+	s := d.String()
+	return s[:len(s)-2] // Drop the "0s" left by rounding
+}
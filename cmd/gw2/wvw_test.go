@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestWvWMapReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/wvw/matches":
+			w.Write([]byte(`{"id": "1-2", "maps": [{"id": 38, "type": "Center", "objectives": [
+				{"id": "38-9", "type": "Castle", "owner": "Red", "last_flipped": "2025-06-06T06:00:00Z", "claimed_by": "GUILD", "claimed_at": "2025-06-06T06:05:00Z", "points_tick": 12, "yaks_delivered": 150},
+				{"id": "38-3", "type": "Tower", "owner": "Green", "last_flipped": "2025-06-06T09:40:00Z", "points_tick": 4, "yaks_delivered": 10},
+				{"id": "38-15", "type": "Camp", "owner": "Red", "last_flipped": "2025-06-06T08:30:00Z", "points_tick": 2}
+			]}]}`))
+		case "/v2/wvw/objectives":
+			w.Write([]byte(`[{"id": "38-9", "name": "Stonemist Castle"}, {"id": "38-3", "name": "Mendon's Gap"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	saved := client
+	client = gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithRetries(0))
+	defer func() { client = saved }()
+
+	now := time.Date(2025, 6, 6, 11, 0, 0, 0, time.UTC)
+	report, err := wvwMapReport(context.Background(), 11004, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Summary.Red.PointsPerTick != 14 || report.Summary.Red.Tier3 != 1 || report.Summary.Green.PointsPerTick != 4 {
+		t.Errorf("summary = %+v", report.Summary)
+	}
+
+	out, _ := captureOutput(t, "table", false, func() { outputData(report) })
+	for _, want := range []string{"Stonemist Castle", "4h55m", "Mendon's Gap", "1h20m", "38-15"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("table output does not contain %q:\n%s", want, out)
+		}
+	}
+
+	out, _ = captureOutput(t, "json", false, func() { outputData(report) })
+	for _, want := range []string{`"points_per_tick": 14`, `"next_tier_seconds": 4800`, `"claimed_for_seconds": 17700`, `"tier": 3`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("JSON output does not contain %q:\n%s", want, out)
+		}
+	}
+}
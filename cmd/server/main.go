@@ -2,18 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"flag"
 	"fmt"
+	"io/fs"
 	"log"
+	"log/slog"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/exchangehistory"
 	"j5.nz/gw2/internal/gw2api"
+	"j5.nz/gw2/internal/proxy"
 	"j5.nz/gw2/internal/web"
 )
 
@@ -27,6 +34,29 @@ func main() {
 	// Parse command line flags
 	verbose := flag.Bool("verbose", false, "Enable verbose API request logging")
 	addr := flag.String("addr", ":9090", "HTTP server address")
+	dataDir := flag.String("data-dir", os.Getenv("GW2_DATA_DIR"), "Directory containing cached game data (default $GW2_DATA_DIR, then ./data)")
+	templateDir := flag.String("template-dir", "", "Load templates from this directory and reload them on every request (for development)")
+	officialRecipesOnly := flag.Bool("official-recipes-only", false, "Leave Mystic Forge and other recipes from custom_recipes.json out of crafting trees")
+	priceCacheFile := flag.String("price-cache-file", "", "Save trading post prices here on shutdown and load them on startup (default off)")
+	timeGatesFile := flag.String("time-gates", "", "JSON file of extra time-gated crafts to mark in crafting trees, as [{\"item_id\": 46742, \"name\": \"Lump of Mithrillium\", \"per_day\": 1}]")
+	depthThreshold := flag.Float64("depth-threshold", 0.05, "Price large crafting purchases from the order book when it averages this fraction above the best price (0 to disable)")
+	enableProxy := flag.Bool("proxy", false, "Serve a read-only JSON proxy of the GW2 API under /proxy/v2/, using the server's API key")
+	proxyAllow := flag.String("proxy-allow", strings.Join(proxy.DefaultAllowed, ","), "Comma-separated endpoints the proxy forwards, relative to /v2 (each also allows the paths below it)")
+	proxyRate := flag.Float64("proxy-rate", 2, "Proxy requests per second allowed from each client IP (0 for no limit)")
+	proxyBurst := flag.Int("proxy-burst", 20, "Proxy requests a client IP can make at once before -proxy-rate applies")
+	proxyStaticTTL := flag.Duration("proxy-static-ttl", time.Hour, "How long the proxy caches game data responses (0 to disable)")
+	proxyAccountTTL := flag.Duration("proxy-account-ttl", 5*time.Minute, "How long the proxy caches account and character responses (0 to disable)")
+	proxyOrigin := flag.String("proxy-origin", "*", "Access-Control-Allow-Origin sent with proxy responses")
+	exchangeHistory := flag.String("exchange-history", "", "Record gem exchange rates to this CSV file and show them on /exchange (default off)")
+	exchangeInterval := flag.Duration("exchange-interval", 15*time.Minute, "How often to sample gem exchange rates")
+	exchangeRetention := flag.Duration("exchange-retention", exchangehistory.DefaultRetention, "How long to keep gem exchange rate samples")
+	logRequests := flag.Bool("log-requests", true, "Log every request with its route, status and duration")
+	slowRequest := flag.Duration("slow-request", time.Second, "Log requests taking at least this long as warnings (0 to disable)")
+	requireCache := flag.Bool("require-cache", false, "Exit unless the data cache loads cleanly with some data in it")
+	checkUpstream := flag.Bool("ready-check-upstream", false, "Only report ready on /readyz once a request to the API has succeeded")
+	maxResponseSize := flag.Int64("max-response-size", 32<<20, "Fail API responses larger than this many bytes instead of reading them into memory (0 for no limit)")
+	pageRetries := flag.Int("page-retries", 0, "Retries for API calls made while a page loads, overriding the client's")
+	pageTimeout := flag.Duration("page-timeout", 3*time.Second, "Give up on an API call made while a page loads after this long, counting retries (0 for no limit)")
 	flag.Parse()
 
 	// Get API key from environment
@@ -36,9 +66,35 @@ func main() {
 		log.Println("Some features requiring authentication will not work")
 	}
 
-	// Create GW2 API client with optional verbose logging
-	var clientOptions []gw2api.ClientOption
-	clientOptions = append(clientOptions, gw2api.WithDataCache("data"))
+	// Create GW2 API client with optional verbose logging. Health checks share
+	// one recent fetch of the build through the build cache.
+	clientOptions := []gw2api.ClientOption{gw2api.WithBuildCache(gw2api.DefaultBuildCacheTTL)}
+	if *dataDir == "" {
+		if _, err := os.Stat("data"); err == nil {
+			*dataDir = "data"
+		}
+	}
+	if *dataDir != "" {
+		if _, err := os.Stat(*dataDir); err != nil {
+			if *requireCache {
+				log.Fatalf("Refusing to start: data directory: %v", err)
+			}
+			log.Printf("Warning: data directory: %v", err)
+			*dataDir = ""
+		}
+	}
+
+	// The data cache loads in the background once the server is listening, and
+	// /readyz reports when it is done. Until then lookups go to the API.
+	var dataCache *gw2api.DataCache
+	if *dataDir != "" {
+		dataCache = gw2api.NewDataCache()
+		clientOptions = append(clientOptions, gw2api.WithSharedDataCache(dataCache))
+	} else if *requireCache {
+		log.Fatal("No data directory found and -require-cache is set, set -data-dir or GW2_DATA_DIR")
+	} else {
+		log.Println("Warning: no data directory found, set -data-dir or GW2_DATA_DIR")
+	}
 
 	if apiKey != "" {
 		clientOptions = append(clientOptions, gw2api.WithAPIKey(apiKey))
@@ -50,18 +106,101 @@ func main() {
 		log.Println("Verbose API logging enabled")
 	}
 
+	clientOptions = append(clientOptions, gw2api.WithMaxResponseSize(*maxResponseSize))
+
 	client := gw2api.NewClient(clientOptions...)
 
-	// Create cache for trading post prices (3 hour TTL)
-	priceCache := cache.NewLRUCache(10000)
+	// Create cache for trading post prices. The web server stores each price
+	// with its own TTLs, twice: fresh for 3 hours and as history for a day.
+	// Expired prices are swept out so a quiet server doesn't keep them.
+	priceCache := cache.NewLRUCache(20000)
+	stopSweep := priceCache.StartCleanupRoutine(cacheSweepInterval)
+	defer stopSweep()
+	if *priceCacheFile != "" {
+		restored, err := loadPriceCache(priceCache, *priceCacheFile)
+		if err != nil {
+			log.Printf("Warning: failed to load price cache: %v", err)
+		} else {
+			log.Printf("Restored %d prices from %s", restored, *priceCacheFile)
+		}
+	}
 
 	// Create web server
-	server := web.NewServer(client, priceCache)
+	serverOptions := []web.ServerOption{web.WithInteractiveRequests(*pageRetries, *pageTimeout)}
+	if *templateDir != "" {
+		serverOptions = append(serverOptions, web.WithTemplateDir(*templateDir))
+		log.Printf("Reloading templates from %s", *templateDir)
+	}
+	if *officialRecipesOnly {
+		serverOptions = append(serverOptions, web.WithOfficialRecipesOnly())
+	}
+	if *depthThreshold > 0 {
+		serverOptions = append(serverOptions, web.WithDepthPricing(*depthThreshold))
+	}
+	if *timeGatesFile != "" {
+		file, err := os.Open(*timeGatesFile)
+		if err != nil {
+			log.Fatalf("Failed to open time gates: %v", err)
+		}
+		gates, err := gw2api.ReadTimeGates(file)
+		file.Close()
+		if err != nil {
+			log.Fatalf("Failed to read %s: %v", *timeGatesFile, err)
+		}
+		serverOptions = append(serverOptions, web.WithTimeGates(gates))
+		log.Printf("Loaded %d time gates from %s", len(gates), *timeGatesFile)
+	}
+	if *logRequests {
+		serverOptions = append(serverOptions, web.WithRequestLogging(slog.Default(), *slowRequest))
+	}
+	if *checkUpstream {
+		serverOptions = append(serverOptions, web.WithUpstreamReadiness())
+	}
+
+	// Sample exchange rates in the background until shutdown
+	samplerCtx, stopSampler := context.WithCancel(context.Background())
+	defer stopSampler()
+	if *exchangeHistory != "" {
+		store, err := exchangehistory.Open(*exchangeHistory, *exchangeRetention)
+		if err != nil {
+			log.Fatalf("Failed to open exchange history: %v", err)
+		}
+		serverOptions = append(serverOptions, web.WithExchangeHistory(store))
+		go exchangehistory.NewSampler(client, store, *exchangeInterval).Run(samplerCtx)
+		log.Printf("Recording gem exchange rates to %s every %s", *exchangeHistory, *exchangeInterval)
+	}
+
+	server, err := web.NewServer(client, priceCache, serverOptions...)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
+
+	var handler http.Handler = server
+	if *enableProxy {
+		if apiKey == "" {
+			log.Println("Warning: the proxy has no API key, so account endpoints will fail")
+		}
+		mux := http.NewServeMux()
+		proxyCache := cache.NewLRUCache(5000)
+		stopProxySweep := proxyCache.StartCleanupRoutine(cacheSweepInterval)
+		defer stopProxySweep()
+		mux.Handle(proxy.Prefix, proxy.New(client, proxyCache, proxy.Config{
+			Allowed:           splitList(*proxyAllow),
+			RequestsPerSecond: *proxyRate,
+			Burst:             *proxyBurst,
+			StaticTTL:         *proxyStaticTTL,
+			AccountTTL:        *proxyAccountTTL,
+			AllowOrigin:       *proxyOrigin,
+		}))
+		mux.Handle("/", server)
+		handler = mux
+		log.Printf("Serving read-only API proxy under %s", proxy.Prefix)
+	}
 
 	// Setup HTTP server
 	srv := &http.Server{
 		Addr:    *addr,
-		Handler: server,
+		Handler: handler,
 
 		// Good practice timeouts
 		ReadTimeout:  15 * time.Second,
@@ -78,6 +217,9 @@ func main() {
 		defer l.Close()
 
 		fmt.Printf("Starting server on http://%s\n", l.Addr().String())
+		if dataCache != nil {
+			go loadDataCache(dataCache, *dataDir, *requireCache)
+		}
 		if err := srv.Serve(l); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("Server failed to start: %v", err)
 		}
@@ -89,13 +231,107 @@ func main() {
 	<-quit
 
 	fmt.Println("Shutting down server...")
+	stopSampler()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	if err := srv.Shutdown(ctx); err != nil {
-		log.Fatal("Server forced to shutdown:", err)
+	shutdownErr := srv.Shutdown(ctx)
+
+	// Save prices once no handler can add more, even if shutdown timed out
+	if *priceCacheFile != "" {
+		if err := savePriceCache(priceCache, *priceCacheFile); err != nil {
+			log.Printf("Warning: failed to save price cache: %v", err)
+		} else {
+			log.Printf("Saved price cache to %s", *priceCacheFile)
+		}
+	}
+
+	if shutdownErr != nil {
+		log.Fatal("Server forced to shutdown:", shutdownErr)
 	}
 
 	fmt.Println("Server exited")
 }
+
+// loadDataCache loads the data cache, exiting if it doesn't load cleanly and
+// requireCache is set
+func loadDataCache(dataCache *gw2api.DataCache, dataDir string, requireCache bool) {
+	log.Printf("Loading data cache from %s", dataDir)
+	if err := dataCache.LoadFromDirectory(dataDir); err != nil {
+		if requireCache {
+			log.Fatalf("Exiting: %v", err)
+		}
+		log.Printf("WARNING: %v", err)
+		log.Println("WARNING: serving without a complete data cache, so searches are limited and lookups are slower")
+	}
+	if requireCache && !cacheHasData(dataCache) {
+		log.Fatalf("Exiting: the data cache in %s is empty", dataDir)
+	}
+	log.Printf("Data cache loaded in %s", dataCache.Stats().LoadTime.Round(time.Millisecond))
+}
+
+// cacheHasData reports whether any kind of data was loaded into the cache
+func cacheHasData(dataCache *gw2api.DataCache) bool {
+	if dataCache == nil {
+		return false
+	}
+	for _, kind := range dataCache.Stats().Kinds {
+		if kind.Records > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// cacheSweepInterval is how often the in-memory caches drop expired entries
+const cacheSweepInterval = 10 * time.Minute
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var entries []string
+	for entry := range strings.SplitSeq(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// loadPriceCache restores prices saved by savePriceCache, dropping any that
+// have expired since. A missing file is not an error.
+func loadPriceCache(priceCache *cache.LRUCache, path string) (int, error) {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	entries, err := cache.ReadSnapshot(f, web.DecodePriceCacheEntry)
+	if err != nil {
+		return 0, err
+	}
+	return priceCache.Restore(entries), nil
+}
+
+// savePriceCache writes the unexpired prices to path, replacing it only once
+// the whole snapshot is written
+func savePriceCache(priceCache *cache.LRUCache, path string) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := cache.WriteSnapshot(tmp, priceCache.Snapshot()); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
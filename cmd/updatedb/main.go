@@ -6,6 +6,7 @@ import (
 	"flag"
 	"io"
 	"os"
+	"path/filepath"
 	"sync"
 
 	"github.com/schollz/progressbar/v3"
@@ -106,102 +107,190 @@ func genericUpdate[T any](
 	return nil
 }
 
+// writeDataFile streams a data file written by write to filePath, gzipped when
+// it ends in .gz. The file only replaces an existing one once write succeeds,
+// and its entry count is then recorded in the data directory's manifest.
+func writeDataFile(filePath string, write func(io.Writer) error) error {
+	out, err := gw2api.CreateJSONL(filePath)
+	if err != nil {
+		return err
+	}
+	if err := write(out); err != nil {
+		out.Abort()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	return gw2api.RecordManifestCount(filepath.Dir(filePath), filepath.Base(filePath), out.Lines())
+}
+
 func main() {
 	var (
 		kind        = flag.String("kind", "", "Kind of data to fetch (e.g., item, recipe, etc.)")
 		groupSize   = flag.Int("group-size", 200, "Number of items to fetch in each group")
 		limit       = flag.Int("limit", 100000, "Maximum number of items to fetch")
 		concurrency = flag.Int("concurrency", 10, "Number of concurrent requests (max 20)")
+		lang        = flag.String("lang", "", "Fetch in this language (es, de, fr, zh) and write language-suffixed files such as items.fr.json")
+		compress    = flag.Bool("compress", false, "Write gzipped files such as items.json.gz, replacing any uncompressed file")
+		retries     = flag.Int("retries", 8, "Retries for each failed request; one batch failing for good aborts the whole update")
 	)
 
 	flag.Parse()
 
-	client := gw2api.NewClient()
+	var (
+		clientOptions []gw2api.ClientOption
+		language      gw2api.Language
+	)
+	if *lang != "" {
+		var ok bool
+		if language, ok = gw2api.ParseLanguage(*lang); !ok {
+			panic("Unsupported language: " + *lang)
+		}
+		clientOptions = append(clientOptions, gw2api.WithLanguage(language))
+	}
+	client := gw2api.NewClient(clientOptions...)
+	// A long update outlasts brief API outages, so retry harder than the
+	// client's default
+	fetch := []gw2api.RequestOption{gw2api.WithRequestRetries(*retries)}
+
+	// dataFile is the path of a data file, suffixed with the language if one
+	// was given and with .gz when compressing
+	dataFile := func(name string) string {
+		if language != "" {
+			name = gw2api.LocalizedFileName(name, language)
+		}
+		if *compress {
+			name += gw2api.CompressedSuffix
+		}
+		return "data/" + name
+	}
 
 	switch *kind {
 	case "item":
-		out, err := os.Create("data/items.json")
-		if err != nil {
+		if err := writeDataFile(dataFile("items.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetItemIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.Item, error) { return client.GetItems(ctx, ids, fetch...) },
+				"Fetching items")
+		}); err != nil {
 			panic(err)
 		}
-		defer out.Close()
-
-		if err := genericUpdate(out, *limit, *groupSize, *concurrency,
-			func(ctx context.Context) ([]int, error) { return client.GetItemIDs(ctx) },
-			func(ctx context.Context, ids []int) ([]*gw2api.Item, error) { return client.GetItems(ctx, ids) },
-			"Fetching items"); err != nil {
+	case "skills":
+		if err := writeDataFile(dataFile("skills.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetSkillIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.Skill, error) { return client.GetSkills(ctx, ids, fetch...) },
+				"Fetching skills")
+		}); err != nil {
 			panic(err)
 		}
-	case "skills":
-		out, err := os.Create("data/skills.json")
-		if err != nil {
+	case "recipes":
+		if err := writeDataFile(dataFile("recipes.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetRecipeIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.RecipeDetail, error) {
+					return client.GetRecipes(ctx, ids, fetch...)
+				},
+				"Fetching recipes")
+		}); err != nil {
 			panic(err)
 		}
-		defer out.Close()
-
-		if err := genericUpdate(out, *limit, *groupSize, *concurrency,
-			func(ctx context.Context) ([]int, error) { return client.GetSkillIDs(ctx) },
-			func(ctx context.Context, ids []int) ([]*gw2api.Skill, error) { return client.GetSkills(ctx, ids) },
-			"Fetching skills"); err != nil {
+	case "achievements":
+		if err := writeDataFile(dataFile("achievements.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetAchievementIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.Achievement, error) {
+					return client.GetAchievements(ctx, ids, fetch...)
+				},
+				"Fetching achievements")
+		}); err != nil {
 			panic(err)
 		}
-	case "recipes":
-		out, err := os.Create("data/recipes.json")
-		if err != nil {
+	case "achievement-categories":
+		if err := writeDataFile(dataFile("achievement_categories.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetAchievementCategoryIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.AchievementCategory, error) {
+					return client.GetAchievementCategories(ctx, ids, fetch...)
+				},
+				"Fetching achievement categories")
+		}); err != nil {
 			panic(err)
 		}
-		defer out.Close()
-
-		if err := genericUpdate(out, *limit, *groupSize, *concurrency,
-			func(ctx context.Context) ([]int, error) { return client.GetRecipeIDs(ctx) },
-			func(ctx context.Context, ids []int) ([]*gw2api.RecipeDetail, error) {
-				return client.GetRecipes(ctx, ids)
-			},
-			"Fetching recipes"); err != nil {
+	case "skins":
+		if err := writeDataFile(dataFile("skins.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetSkinIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.SkinDetail, error) { return client.GetSkins(ctx, ids, fetch...) },
+				"Fetching skins")
+		}); err != nil {
 			panic(err)
 		}
-	case "achievements":
-		out, err := os.Create("data/achievements.json")
-		if err != nil {
+	case "vendors":
+		if err := writeDataFile(dataFile("vendors.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetVendorIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.Vendor, error) { return client.GetVendors(ctx, ids, fetch...) },
+				"Fetching vendors")
+		}); err != nil {
 			panic(err)
 		}
-		defer out.Close()
-
-		if err := genericUpdate(out, *limit, *groupSize, *concurrency,
-			func(ctx context.Context) ([]int, error) { return client.GetAchievementIDs(ctx) },
-			func(ctx context.Context, ids []int) ([]*gw2api.Achievement, error) {
-				return client.GetAchievements(ctx, ids)
-			},
-			"Fetching achievements"); err != nil {
+	case "materials":
+		if err := writeDataFile(dataFile("materials.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetMaterialIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.Material, error) { return client.GetMaterials(ctx, ids, fetch...) },
+				"Fetching material categories")
+		}); err != nil {
 			panic(err)
 		}
-	case "achievement-categories":
-		out, err := os.Create("data/achievement_categories.json")
-		if err != nil {
+	case "currencies":
+		if err := writeDataFile(dataFile("currencies.json"), func(out io.Writer) error {
+			return genericUpdate(out, *limit, *groupSize, *concurrency,
+				func(ctx context.Context) ([]int, error) { return client.GetCurrencyIDs(ctx, fetch...) },
+				func(ctx context.Context, ids []int) ([]*gw2api.Currency, error) { return client.GetCurrencies(ctx, ids, fetch...) },
+				"Fetching currencies")
+		}); err != nil {
 			panic(err)
 		}
-		defer out.Close()
-
-		if err := genericUpdate(out, *limit, *groupSize, *concurrency,
-			func(ctx context.Context) ([]int, error) { return client.GetAchievementCategoryIDs(ctx) },
-			func(ctx context.Context, ids []int) ([]*gw2api.AchievementCategory, error) {
-				return client.GetAchievementCategories(ctx, ids)
-			},
-			"Fetching achievement categories"); err != nil {
+	case "prices":
+		// A snapshot of the whole trading post. Prices aren't translated, so
+		// --lang doesn't change the file.
+		name := "data/prices.json"
+		if *compress {
+			name += gw2api.CompressedSuffix
+		}
+		if err := writeDataFile(name, func(out io.Writer) error {
+			pb := progressbar.Default(-1, "Fetching prices")
+			defer pb.Finish()
+			encoder := json.NewEncoder(out)
+			return client.StreamCommercePrices(context.Background(), func(batch []*gw2api.Price) error {
+				for _, price := range batch {
+					if err := encoder.Encode(price); err != nil {
+						return err
+					}
+				}
+				pb.Add(len(batch))
+				return nil
+			}, fetch...)
+		}); err != nil {
 			panic(err)
 		}
-	case "skins":
-		out, err := os.Create("data/skins.json")
+	case "custom-recipes":
+		// Seed the supplemental recipe file with the starter Mystic Forge recipes,
+		// never overwriting one that may have been edited by hand
+		out, err := os.OpenFile("data/"+gw2api.CustomRecipesFileName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
 		if err != nil {
 			panic(err)
 		}
 		defer out.Close()
 
-		if err := genericUpdate(out, *limit, *groupSize, *concurrency,
-			func(ctx context.Context) ([]int, error) { return client.GetSkinIDs(ctx) },
-			func(ctx context.Context, ids []int) ([]*gw2api.SkinDetail, error) { return client.GetSkins(ctx, ids) },
-			"Fetching skins"); err != nil {
-			panic(err)
+		encoder := json.NewEncoder(out)
+		for _, recipe := range gw2api.StarterCustomRecipes() {
+			if err := encoder.Encode(recipe); err != nil {
+				panic(err)
+			}
 		}
 	default:
 		panic("Unsupported kind: " + *kind)
@@ -20,6 +20,7 @@ type Stats struct {
 	Hits        int64
 	Misses      int64
 	Evictions   int64
+	Expired     int64 // Entries dropped for being past their TTL, on Get or by a sweep
 	Size        int
 	MaxSize     int
 	HitRate     float64
@@ -32,14 +33,30 @@ type Item struct {
 	Value      interface{}
 	Expiration time.Time
 	AccessTime time.Time
+	StoredAt   time.Time
 	element    *list.Element
 }
 
 // IsExpired checks if the item has expired
 func (i *Item) IsExpired() bool {
-	return time.Now().After(i.Expiration)
+	return i.expiredAt(time.Now())
 }
 
+// expiredAt reports whether the item has expired at now. Items without an
+// expiration never do.
+func (i *Item) expiredAt(now time.Time) bool {
+	return !i.Expiration.IsZero() && now.After(i.Expiration)
+}
+
+// Clock tells a cache the time, so tests can move it forward to expire entries
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time { return time.Now() }
+
 // LRUCache implements an LRU cache with TTL support
 type LRUCache struct {
 	maxSize    int
@@ -49,17 +66,36 @@ type LRUCache struct {
 	hits       int64
 	misses     int64
 	evictions  int64
+	expired    int64
+	ttl        time.Duration // Default for Set calls without one, 0 for none
+	clock      Clock
 }
 
 // NewLRUCache creates a new LRU cache with the specified maximum size
 func NewLRUCache(maxSize int) *LRUCache {
+	return NewLRUCacheWithTTL(maxSize, 0)
+}
+
+// NewLRUCacheWithTTL creates an LRU cache whose entries expire ttl after they
+// are set, unless Set is given a TTL of its own. Expired entries are dropped
+// when they are next looked up, or sooner with StartCleanupRoutine.
+func NewLRUCacheWithTTL(maxSize int, ttl time.Duration) *LRUCache {
 	return &LRUCache{
 		maxSize: maxSize,
 		items:   make(map[string]*Item),
 		lruList: list.New(),
+		ttl:     max(ttl, 0),
+		clock:   systemClock{},
 	}
 }
 
+// SetClock replaces the clock the cache reads the time from
+func (c *LRUCache) SetClock(clock Clock) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.clock = clock
+}
+
 // Get retrieves a value from the cache
 func (c *LRUCache) Get(key string) (interface{}, bool) {
 	c.mutex.Lock()
@@ -72,33 +108,43 @@ func (c *LRUCache) Get(key string) (interface{}, bool) {
 	}
 
 	// Check if item has expired
-	if item.IsExpired() {
+	now := c.clock.Now()
+	if item.expiredAt(now) {
 		c.removeItem(item)
 		c.misses++
+		c.expired++
 		return nil, false
 	}
 
 	// Move to front (most recently used)
 	c.lruList.MoveToFront(item.element)
-	item.AccessTime = time.Now()
+	item.AccessTime = now
 	c.hits++
 
 	return item.Value, true
 }
 
-// Set stores a value in the cache with the specified TTL
+// Set stores a value in the cache with the specified TTL. A TTL of 0 uses the
+// cache's default TTL, and with no default the value doesn't expire.
 func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	now := time.Now()
-	expiration := now.Add(ttl)
+	now := c.clock.Now()
+	if ttl == 0 {
+		ttl = c.ttl
+	}
+	var expiration time.Time
+	if ttl != 0 {
+		expiration = now.Add(ttl)
+	}
 
 	// If item already exists, update it
 	if existingItem, exists := c.items[key]; exists {
 		existingItem.Value = value
 		existingItem.Expiration = expiration
 		existingItem.AccessTime = now
+		existingItem.StoredAt = now
 		c.lruList.MoveToFront(existingItem.element)
 		return
 	}
@@ -109,6 +155,7 @@ func (c *LRUCache) Set(key string, value interface{}, ttl time.Duration) {
 		Value:      value,
 		Expiration: expiration,
 		AccessTime: now,
+		StoredAt:   now,
 	}
 
 	// Add to front of LRU list
@@ -161,6 +208,7 @@ func (c *LRUCache) Stats() Stats {
 		Hits:        c.hits,
 		Misses:      c.misses,
 		Evictions:   c.evictions,
+		Expired:     c.expired,
 		Size:        len(c.items),
 		MaxSize:     c.maxSize,
 		HitRate:     hitRate,
@@ -173,12 +221,12 @@ func (c *LRUCache) CleanupExpired() {
 	c.mutex.Lock()
 	defer c.mutex.Unlock()
 
-	now := time.Now()
+	now := c.clock.Now()
 	var toRemove []*Item
 
 	// Collect expired items
 	for _, item := range c.items {
-		if now.After(item.Expiration) {
+		if item.expiredAt(now) {
 			toRemove = append(toRemove, item)
 		}
 	}
@@ -187,6 +235,7 @@ func (c *LRUCache) CleanupExpired() {
 	for _, item := range toRemove {
 		c.removeItem(item)
 	}
+	c.expired += int64(len(toRemove))
 }
 
 // removeItem removes an item from both the map and LRU list
@@ -226,12 +275,22 @@ func (c *LRUCache) estimateItemSize(item *Item) int64 {
 }
 
 // StartCleanupRoutine starts a background goroutine to clean up expired items
-func (c *LRUCache) StartCleanupRoutine(interval time.Duration) {
+// every interval, so they don't take up room until they are looked up. The
+// returned function stops it.
+func (c *LRUCache) StartCleanupRoutine(interval time.Duration) (stop func()) {
 	ticker := time.NewTicker(interval)
+	done := make(chan struct{})
 	go func() {
 		defer ticker.Stop()
-		for range ticker.C {
-			c.CleanupExpired()
+		for {
+			select {
+			case <-ticker.C:
+				c.CleanupExpired()
+			case <-done:
+				return
+			}
 		}
 	}()
+	var once sync.Once
+	return func() { once.Do(func() { close(done) }) }
 }
\ No newline at end of file
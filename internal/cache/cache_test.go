@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeClock is a Clock that only moves when told to
+type fakeClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestLRUCacheTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewLRUCacheWithTTL(10, time.Hour)
+	c.SetClock(clock)
+
+	c.Set("default", 1, 0)
+	c.Set("short", 2, time.Minute)
+	clock.Advance(30 * time.Minute)
+
+	if _, found := c.Get("short"); found {
+		t.Error("entry past its own TTL was returned")
+	}
+	if value, found := c.Get("default"); !found || value != 1 {
+		t.Errorf("Get(default) = %v, %v, expected 1 within the cache TTL", value, found)
+	}
+
+	clock.Advance(time.Hour)
+	if _, found := c.Get("default"); found {
+		t.Error("entry past the cache TTL was returned")
+	}
+
+	stats := c.Stats()
+	if stats.Hits != 1 || stats.Misses != 2 || stats.Expired != 2 || stats.Size != 0 {
+		t.Errorf("stats = %+v, expected 1 hit, 2 misses, 2 expired and nothing left", stats)
+	}
+	if stats.HitRate != 1.0/3 {
+		t.Errorf("HitRate = %v, expected 1/3", stats.HitRate)
+	}
+}
+
+func TestLRUCacheWithoutTTL(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewLRUCache(10)
+	c.SetClock(clock)
+
+	c.Set("forever", 1, 0)
+	clock.Advance(365 * 24 * time.Hour)
+	if _, found := c.Get("forever"); !found {
+		t.Error("entry without a TTL expired")
+	}
+	if snapshot := c.Snapshot(); len(snapshot) != 1 {
+		t.Errorf("Snapshot = %+v, expected the entry without a TTL", snapshot)
+	}
+}
+
+func TestCleanupExpired(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewLRUCacheWithTTL(10, time.Hour)
+	c.SetClock(clock)
+	for i := range 5 {
+		c.Set(fmt.Sprint(i), i, time.Duration(i+1)*time.Minute)
+	}
+
+	clock.Advance(3*time.Minute + time.Second)
+	c.CleanupExpired()
+	if stats := c.Stats(); stats.Size != 2 || stats.Expired != 3 || stats.Misses != 0 {
+		t.Errorf("stats = %+v, expected 2 left and 3 expired without any lookups", stats)
+	}
+}
+
+func TestStartCleanupRoutine(t *testing.T) {
+	c := NewLRUCache(10)
+	c.Set("gone", 1, time.Millisecond)
+	stop := c.StartCleanupRoutine(time.Millisecond)
+	defer stop()
+
+	deadline := time.Now().Add(time.Second)
+	for c.Stats().Size > 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("the cleanup routine didn't remove the expired entry")
+		}
+		time.Sleep(time.Millisecond)
+	}
+	stop()
+	stop() // Stopping twice is harmless
+}
+
+func TestLRUCacheConcurrent(t *testing.T) {
+	clock := &fakeClock{now: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	c := NewLRUCacheWithTTL(50, time.Minute)
+	c.SetClock(clock)
+
+	var wg sync.WaitGroup
+	for worker := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range 500 {
+				key := fmt.Sprint(i % 100)
+				switch (worker + i) % 5 {
+				case 0:
+					c.Set(key, i, 0)
+				case 1:
+					c.Delete(key)
+				case 2:
+					c.CleanupExpired()
+				case 3:
+					c.Stats()
+				default:
+					c.Get(key)
+				}
+				if i%100 == 0 {
+					clock.Advance(10 * time.Second)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	stats := c.Stats()
+	if stats.Size > 50 {
+		t.Errorf("Size = %d, expected at most the capacity of 50", stats.Size)
+	}
+	if stats.Hits+stats.Misses != 8*100 {
+		t.Errorf("%d hits and %d misses, expected one per Get", stats.Hits, stats.Misses)
+	}
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// SnapshotVersion is the encoding version written by WriteSnapshot
+const SnapshotVersion = 1
+
+// Entry is one cached value as saved by Snapshot
+type Entry struct {
+	Key        string    `json:"key"`
+	Value      any       `json:"value"`
+	StoredAt   time.Time `json:"stored_at"`
+	Expiration time.Time `json:"expiration"`
+}
+
+// snapshotFile is the encoded form of a snapshot. Values stay raw until the
+// caller says what type each key holds.
+type snapshotFile struct {
+	Version int `json:"version"`
+	Entries []struct {
+		Key        string          `json:"key"`
+		Value      json.RawMessage `json:"value"`
+		StoredAt   time.Time       `json:"stored_at"`
+		Expiration time.Time       `json:"expiration"`
+	} `json:"entries"`
+}
+
+// Snapshot returns the unexpired entries, most recently used first
+func (c *LRUCache) Snapshot() []Entry {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	now := c.clock.Now()
+	entries := make([]Entry, 0, len(c.items))
+	for element := c.lruList.Front(); element != nil; element = element.Next() {
+		item := element.Value.(*Item)
+		if item.expiredAt(now) {
+			continue
+		}
+		entries = append(entries, Entry{Key: item.Key, Value: item.Value, StoredAt: item.StoredAt, Expiration: item.Expiration})
+	}
+	return entries
+}
+
+// Restore adds entries from Snapshot back into the cache, keeping their
+// original expiry. Expired entries are dropped, and existing keys are
+// replaced. It returns the number of entries restored.
+func (c *LRUCache) Restore(entries []Entry) int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	now := c.clock.Now()
+	restored := 0
+	// Least recently used first, so the most recent entries end up at the front
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if !entry.Expiration.IsZero() && now.After(entry.Expiration) {
+			continue
+		}
+		if existing, exists := c.items[entry.Key]; exists {
+			c.removeItem(existing)
+		}
+		item := &Item{
+			Key:        entry.Key,
+			Value:      entry.Value,
+			Expiration: entry.Expiration,
+			AccessTime: now,
+			StoredAt:   entry.StoredAt,
+		}
+		item.element = c.lruList.PushFront(item)
+		c.items[entry.Key] = item
+		restored++
+
+		if len(c.items) > c.maxSize {
+			c.evictLRU()
+		}
+	}
+	return restored
+}
+
+// WriteSnapshot encodes entries as versioned JSON. Values must be JSON encodable.
+func WriteSnapshot(w io.Writer, entries []Entry) error {
+	data := struct {
+		Version int     `json:"version"`
+		Entries []Entry `json:"entries"`
+	}{SnapshotVersion, entries}
+	if err := json.NewEncoder(w).Encode(data); err != nil {
+		return fmt.Errorf("failed to encode cache snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadSnapshot decodes entries written by WriteSnapshot. decode turns each
+// value back into the type stored under its key.
+func ReadSnapshot(r io.Reader, decode func(key string, value json.RawMessage) (any, error)) ([]Entry, error) {
+	var file snapshotFile
+	if err := json.NewDecoder(r).Decode(&file); err != nil {
+		return nil, fmt.Errorf("invalid cache snapshot: %w", err)
+	}
+	if file.Version != SnapshotVersion {
+		return nil, fmt.Errorf("cache snapshot has version %d, expected %d", file.Version, SnapshotVersion)
+	}
+
+	entries := make([]Entry, 0, len(file.Entries))
+	for _, raw := range file.Entries {
+		value, err := decode(raw.Key, raw.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid cache entry %s: %w", raw.Key, err)
+		}
+		entries = append(entries, Entry{Key: raw.Key, Value: value, StoredAt: raw.StoredAt, Expiration: raw.Expiration})
+	}
+	return entries, nil
+}
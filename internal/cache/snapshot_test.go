@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testPrice struct {
+	ID    int `json:"id"`
+	Price int `json:"price"`
+}
+
+func decodeTestPrice(key string, value json.RawMessage) (any, error) {
+	var price testPrice
+	err := json.Unmarshal(value, &price)
+	return &price, err
+}
+
+func TestSnapshotRoundTrip(t *testing.T) {
+	original := NewLRUCache(10)
+	original.Set("price_1", &testPrice{ID: 1, Price: 100}, time.Hour)
+	original.Set("price_2", &testPrice{ID: 2, Price: 200}, time.Hour)
+	original.Set("price_3", &testPrice{ID: 3, Price: 300}, time.Hour)
+	original.Get("price_1") // Most recently used
+
+	var buf bytes.Buffer
+	if err := WriteSnapshot(&buf, original.Snapshot()); err != nil {
+		t.Fatalf("WriteSnapshot: %v", err)
+	}
+	entries, err := ReadSnapshot(&buf, decodeTestPrice)
+	if err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+
+	// Two slots, so the least recently used entry doesn't fit
+	restored := NewLRUCache(2)
+	if n := restored.Restore(entries); n != 3 {
+		t.Errorf("Restore = %d, expected 3", n)
+	}
+	value, found := restored.Get("price_1")
+	if price, ok := value.(*testPrice); !found || !ok || *price != (testPrice{ID: 1, Price: 100}) {
+		t.Errorf("price_1 = %#v, %v, expected the original price", value, found)
+	}
+	if _, found := restored.Get("price_3"); !found {
+		t.Error("price_3 was not restored")
+	}
+	if _, found := restored.Get("price_2"); found {
+		t.Error("least recently used price_2 was kept over more recent entries")
+	}
+
+	for _, entry := range entries {
+		if entry.StoredAt.IsZero() || !entry.Expiration.After(entry.StoredAt) {
+			t.Errorf("entry %s stored at %v, expires %v", entry.Key, entry.StoredAt, entry.Expiration)
+		}
+	}
+}
+
+func TestRestoreDropsExpired(t *testing.T) {
+	now := time.Now()
+	entries := []Entry{
+		{Key: "fresh", Value: 1, StoredAt: now.Add(-time.Hour), Expiration: now.Add(time.Hour)},
+		{Key: "stale", Value: 2, StoredAt: now.Add(-4 * time.Hour), Expiration: now.Add(-time.Hour)},
+	}
+
+	c := NewLRUCache(10)
+	if n := c.Restore(entries); n != 1 {
+		t.Errorf("Restore = %d, expected 1", n)
+	}
+	if _, found := c.Get("fresh"); !found {
+		t.Error("fresh entry was dropped")
+	}
+	if _, found := c.Get("stale"); found {
+		t.Error("expired entry was restored")
+	}
+
+	// Expired entries are also left out of snapshots
+	c.Set("short", 3, -time.Second)
+	if snapshot := c.Snapshot(); len(snapshot) != 1 || snapshot[0].Key != "fresh" {
+		t.Errorf("Snapshot = %+v, expected only the fresh entry", snapshot)
+	}
+}
+
+func TestReadSnapshotVersion(t *testing.T) {
+	_, err := ReadSnapshot(strings.NewReader(`{"version":2,"entries":[]}`), decodeTestPrice)
+	if err == nil || !strings.Contains(err.Error(), "version 2") {
+		t.Errorf("ReadSnapshot error = %v, expected a version error", err)
+	}
+}
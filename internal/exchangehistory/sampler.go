@@ -0,0 +1,87 @@
+package exchangehistory
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// Quote sizes used for sampling. Rates depend on the amount exchanged, so the
+// same amounts are used every time to keep samples comparable.
+const (
+	quoteGems  = 100
+	quoteCoins = 100 * 10000 // 100 gold
+)
+
+// MaxBackoff caps the wait between attempts while the API keeps failing
+const MaxBackoff = 6 * time.Hour
+
+// Sampler records the exchange rates into a store at a fixed interval
+type Sampler struct {
+	client   *gw2api.Client
+	store    *Store
+	interval time.Duration
+}
+
+// NewSampler returns a sampler that records every interval
+func NewSampler(client *gw2api.Client, store *Store, interval time.Duration) *Sampler {
+	return &Sampler{client: client, store: store, interval: interval}
+}
+
+// Run samples once straight away and then every interval until ctx is done.
+// After a failure it waits twice as long as before, up to MaxBackoff, so an
+// API outage is not hammered.
+func (s *Sampler) Run(ctx context.Context) {
+	failures := 0
+	for {
+		if err := s.SampleOnce(ctx); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failures++
+			log.Printf("Exchange rate sample failed (%d in a row): %v", failures, err)
+		} else {
+			failures = 0
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(nextDelay(s.interval, failures)):
+		}
+	}
+}
+
+// SampleOnce fetches the current rates and appends them to the store
+func (s *Sampler) SampleOnce(ctx context.Context) error {
+	gems, err := s.client.GetCommerceExchangeGems(ctx, quoteGems)
+	if err != nil {
+		return fmt.Errorf("failed to get gem exchange rate: %w", err)
+	}
+	coins, err := s.client.GetCommerceExchangeCoins(ctx, quoteCoins)
+	if err != nil {
+		return fmt.Errorf("failed to get coin exchange rate: %w", err)
+	}
+
+	return s.store.Append(Sample{
+		Time:        time.Now(),
+		CoinsPerGem: gems.CoinsPerGem,
+		GemsPerGold: float64(coins.Quantity) / (quoteCoins / 10000),
+	})
+}
+
+// nextDelay is the wait before the next sample after the given number of
+// failures in a row
+func nextDelay(interval time.Duration, failures int) time.Duration {
+	delay := interval
+	for range failures {
+		if delay >= MaxBackoff/2 {
+			return max(MaxBackoff, interval)
+		}
+		delay *= 2
+	}
+	return delay
+}
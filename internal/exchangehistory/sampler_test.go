@@ -0,0 +1,59 @@
+package exchangehistory
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestSampleOnce(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/commerce/exchange/gems":
+			w.Write([]byte(`{"coins_per_gem": 2345, "quantity": 234500}`))
+		case "/v2/commerce/exchange/coins":
+			w.Write([]byte(`{"coins_per_gem": 2900, "quantity": 344}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store, err := Open(filepath.Join(t.TempDir(), "exchange.csv"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithRetries(0))
+	if err := NewSampler(client, store, time.Hour).SampleOnce(context.Background()); err != nil {
+		t.Fatalf("SampleOnce: %v", err)
+	}
+
+	latest, ok := store.Latest()
+	if !ok || latest.CoinsPerGem != 2345 || latest.GemsPerGold != 3.44 {
+		t.Errorf("sample = %+v, expected 2345 coins per gem and 3.44 gems per gold", latest)
+	}
+}
+
+func TestNextDelay(t *testing.T) {
+	tests := []struct {
+		interval time.Duration
+		failures int
+		expected time.Duration
+	}{
+		{15 * time.Minute, 0, 15 * time.Minute},
+		{15 * time.Minute, 1, 30 * time.Minute},
+		{15 * time.Minute, 3, 2 * time.Hour},
+		{15 * time.Minute, 20, MaxBackoff},
+		{12 * time.Hour, 2, 12 * time.Hour},
+	}
+	for _, tt := range tests {
+		if got := nextDelay(tt.interval, tt.failures); got != tt.expected {
+			t.Errorf("nextDelay(%s, %d) = %s, expected %s", tt.interval, tt.failures, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+// Package exchangehistory records the gem exchange rates over time in a small
+// CSV file, so recent trends can be shown without a database.
+package exchangehistory
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultRetention is how long samples are kept when no retention is given
+const DefaultRetention = 30 * 24 * time.Hour
+
+// csvHeader is the first line of a history file
+const csvHeader = "time,coins_per_gem,gems_per_gold"
+
+// Sample is the exchange rates at one point in time
+type Sample struct {
+	Time        time.Time `json:"time"`
+	CoinsPerGem int       `json:"coins_per_gem"` // Coins received for each gem when exchanging gems for gold
+	GemsPerGold float64   `json:"gems_per_gold"` // Gems received for each gold when exchanging gold for gems
+}
+
+// Store is an append-only history of samples backed by a CSV file. Samples
+// older than the retention are dropped when the store is opened and as new
+// samples arrive.
+type Store struct {
+	path      string
+	retention time.Duration
+	mutex     sync.RWMutex
+	samples   []Sample // Oldest first
+	pruned    int      // Lines in the file that are not in samples
+}
+
+// Open loads the history in path, creating it on the first Append if it does
+// not exist yet
+func Open(path string, retention time.Duration) (*Store, error) {
+	if retention <= 0 {
+		retention = DefaultRetention
+	}
+	s := &Store{path: path, retention: retention}
+
+	samples, skipped, err := readFile(path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("failed to read exchange history: %w", err)
+	}
+	s.samples = samples
+	s.pruned = skipped
+	// Compacting also drops any partial line a crash left at the end
+	s.pruneLocked(time.Now())
+	if s.pruned > 0 {
+		if err := s.compactLocked(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Append records a sample, writing it to the end of the file
+func (s *Store) Append(sample Sample) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	sample.Time = sample.Time.UTC()
+	if err := s.appendLine(sample); err != nil {
+		return err
+	}
+	s.samples = append(s.samples, sample)
+
+	// Rewrite the file once a good part of it is expired, rather than on
+	// every sample
+	s.pruneLocked(sample.Time)
+	if s.pruned > 0 && s.pruned >= len(s.samples)/4 {
+		return s.compactLocked()
+	}
+	return nil
+}
+
+// Since returns the samples taken at or after t, oldest first
+func (s *Store) Since(t time.Time) []Sample {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	i, _ := slices.BinarySearchFunc(s.samples, t, func(sample Sample, t time.Time) int {
+		return sample.Time.Compare(t)
+	})
+	return slices.Clone(s.samples[i:])
+}
+
+// Latest returns the most recent sample, if there is one
+func (s *Store) Latest() (Sample, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	if len(s.samples) == 0 {
+		return Sample{}, false
+	}
+	return s.samples[len(s.samples)-1], true
+}
+
+// Len returns the number of samples kept
+func (s *Store) Len() int {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+	return len(s.samples)
+}
+
+// pruneLocked drops samples older than the retention
+func (s *Store) pruneLocked(now time.Time) {
+	cutoff := now.Add(-s.retention)
+	i := 0
+	for i < len(s.samples) && s.samples[i].Time.Before(cutoff) {
+		i++
+	}
+	if i > 0 {
+		s.samples = slices.Delete(s.samples, 0, i)
+		s.pruned += i
+	}
+}
+
+// appendLine writes one sample, starting the file with a header if it is new
+func (s *Store) appendLine(sample Sample) error {
+	f, err := os.OpenFile(s.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open exchange history: %w", err)
+	}
+	defer f.Close()
+
+	var line string
+	if info, err := f.Stat(); err == nil && info.Size() == 0 {
+		line = csvHeader + "\n"
+	}
+	line += formatSample(sample) + "\n"
+	if _, err := f.WriteString(line); err != nil {
+		return fmt.Errorf("failed to write exchange history: %w", err)
+	}
+	return nil
+}
+
+// compactLocked rewrites the file with only the kept samples, replacing it
+// only once the new file is complete
+func (s *Store) compactLocked() error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return fmt.Errorf("failed to compact exchange history: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	w.WriteString(csvHeader + "\n")
+	for _, sample := range s.samples {
+		w.WriteString(formatSample(sample) + "\n")
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to compact exchange history: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to compact exchange history: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), s.path); err != nil {
+		return fmt.Errorf("failed to compact exchange history: %w", err)
+	}
+	s.pruned = 0
+	return nil
+}
+
+func formatSample(sample Sample) string {
+	return sample.Time.Format(time.RFC3339) + "," +
+		strconv.Itoa(sample.CoinsPerGem) + "," +
+		strconv.FormatFloat(sample.GemsPerGold, 'f', -1, 64)
+}
+
+// readFile parses a history file, sorting samples by time. Lines that do not
+// parse, such as one cut short by a crash, are skipped and counted so the next
+// compaction drops them.
+func readFile(path string) (samples []Sample, skipped int, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || line == csvHeader {
+			continue
+		}
+		sample, err := parseSample(line)
+		if err != nil {
+			skipped++
+			continue
+		}
+		samples = append(samples, sample)
+	}
+
+	slices.SortStableFunc(samples, func(a, b Sample) int { return a.Time.Compare(b.Time) })
+	return samples, skipped, nil
+}
+
+func parseSample(line string) (Sample, error) {
+	fields := strings.Split(line, ",")
+	if len(fields) != 3 {
+		return Sample{}, fmt.Errorf("expected 3 fields, got %d", len(fields))
+	}
+	t, err := time.Parse(time.RFC3339, fields[0])
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid time: %w", err)
+	}
+	coinsPerGem, err := strconv.Atoi(fields[1])
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid coins_per_gem: %w", err)
+	}
+	gemsPerGold, err := strconv.ParseFloat(fields[2], 64)
+	if err != nil {
+		return Sample{}, fmt.Errorf("invalid gems_per_gold: %w", err)
+	}
+	return Sample{Time: t, CoinsPerGem: coinsPerGem, GemsPerGold: gemsPerGold}, nil
+}
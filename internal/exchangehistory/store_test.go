@@ -0,0 +1,98 @@
+package exchangehistory
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStoreAppendAndReopen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exchange.csv")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	store, err := Open(path, 0)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	for i, rate := range []int{2400, 2500, 2450} {
+		sample := Sample{Time: now.Add(time.Duration(i-2) * time.Hour), CoinsPerGem: rate, GemsPerGold: 10000 / float64(rate) * 0.85}
+		if err := store.Append(sample); err != nil {
+			t.Fatalf("Append: %v", err)
+		}
+	}
+
+	reopened, err := Open(path, 0)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if reopened.Len() != 3 {
+		t.Fatalf("reopened store has %d samples, expected 3", reopened.Len())
+	}
+	if latest, _ := reopened.Latest(); latest.CoinsPerGem != 2450 || !latest.Time.Equal(now) {
+		t.Errorf("latest = %+v, expected 2450 at %s", latest, now)
+	}
+	if got := reopened.Since(now.Add(-90 * time.Minute)); len(got) != 2 {
+		t.Errorf("Since returned %d samples, expected the last 2", len(got))
+	}
+
+	summary := reopened.Summarize(24*time.Hour, now)
+	if summary.Samples != 3 || summary.MinCoinsPerGem != 2400 || summary.MaxCoinsPerGem != 2500 || summary.CoinsPerGemChange() != 50 {
+		t.Errorf("summary = %+v, expected 3 samples from 2400 to 2500, up 50", summary)
+	}
+	if empty := reopened.Summarize(time.Minute, now.Add(time.Hour)); empty.Samples != 0 {
+		t.Errorf("summary of an empty window has %d samples", empty.Samples)
+	}
+}
+
+func TestStoreRetention(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exchange.csv")
+	now := time.Now().UTC().Truncate(time.Second)
+
+	old := "time,coins_per_gem,gems_per_gold\n" +
+		now.Add(-72*time.Hour).Format(time.RFC3339) + ",2000,4.2\n" +
+		now.Add(-1*time.Hour).Format(time.RFC3339) + ",2100,4\n" +
+		now.Format(time.RFC3339) + ",21" // Cut short by a crash
+	if err := os.WriteFile(path, []byte(old), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	store, err := Open(path, 48*time.Hour)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	if store.Len() != 1 {
+		t.Fatalf("store has %d samples, expected the 1 inside the retention", store.Len())
+	}
+
+	// Opening compacts the file, so appending starts on a clean line
+	if err := store.Append(Sample{Time: now, CoinsPerGem: 2200, GemsPerGold: 3.9}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 3 || lines[0] != csvHeader || !strings.HasSuffix(lines[2], ",2200,3.9") {
+		t.Errorf("file after compaction =\n%s", data)
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	samples := []Sample{
+		{Time: start, CoinsPerGem: 100},
+		{Time: start.Add(time.Hour), CoinsPerGem: 300},
+		{Time: start.Add(2 * time.Hour), CoinsPerGem: 200},
+	}
+	coins := func(s Sample) float64 { return float64(s.CoinsPerGem) }
+
+	if got := Sparkline(samples, 100, 20, coins); got != "0.0,20.0 50.0,0.0 100.0,10.0" {
+		t.Errorf("Sparkline = %q", got)
+	}
+	if got := Sparkline(samples[:1], 100, 20, coins); got != "" {
+		t.Errorf("Sparkline of one sample = %q, expected none", got)
+	}
+}
@@ -0,0 +1,76 @@
+package exchangehistory
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Summary describes the rates over a window of time
+type Summary struct {
+	Window  time.Duration `json:"window"`
+	Samples int           `json:"samples"`
+	First   Sample        `json:"first"`
+	Latest  Sample        `json:"latest"`
+
+	MinCoinsPerGem int     `json:"min_coins_per_gem"`
+	MaxCoinsPerGem int     `json:"max_coins_per_gem"`
+	MinGemsPerGold float64 `json:"min_gems_per_gold"`
+	MaxGemsPerGold float64 `json:"max_gems_per_gold"`
+}
+
+// CoinsPerGemChange is how much a gem's value in coins moved over the window
+func (s Summary) CoinsPerGemChange() int {
+	return s.Latest.CoinsPerGem - s.First.CoinsPerGem
+}
+
+// Summarize returns the range of the samples in the window ending at now. The
+// summary has no samples if none fall in the window.
+func (s *Store) Summarize(window time.Duration, now time.Time) Summary {
+	samples := s.Since(now.Add(-window))
+	summary := Summary{Window: window, Samples: len(samples)}
+	if len(samples) == 0 {
+		return summary
+	}
+
+	summary.First, summary.Latest = samples[0], samples[len(samples)-1]
+	summary.MinCoinsPerGem, summary.MaxCoinsPerGem = samples[0].CoinsPerGem, samples[0].CoinsPerGem
+	summary.MinGemsPerGold, summary.MaxGemsPerGold = samples[0].GemsPerGold, samples[0].GemsPerGold
+	for _, sample := range samples[1:] {
+		summary.MinCoinsPerGem = min(summary.MinCoinsPerGem, sample.CoinsPerGem)
+		summary.MaxCoinsPerGem = max(summary.MaxCoinsPerGem, sample.CoinsPerGem)
+		summary.MinGemsPerGold = min(summary.MinGemsPerGold, sample.GemsPerGold)
+		summary.MaxGemsPerGold = max(summary.MaxGemsPerGold, sample.GemsPerGold)
+	}
+	return summary
+}
+
+// Sparkline returns SVG polyline points plotting value over the samples in a
+// width by height box, with the lowest value at the bottom. It returns an
+// empty string for fewer than two samples.
+func Sparkline(samples []Sample, width, height float64, value func(Sample) float64) string {
+	if len(samples) < 2 {
+		return ""
+	}
+
+	low, high := value(samples[0]), value(samples[0])
+	for _, sample := range samples[1:] {
+		low, high = min(low, value(sample)), max(high, value(sample))
+	}
+	start, end := samples[0].Time, samples[len(samples)-1].Time
+	span := end.Sub(start).Seconds()
+
+	points := make([]string, 0, len(samples))
+	for _, sample := range samples {
+		x := 0.0
+		if span > 0 {
+			x = sample.Time.Sub(start).Seconds() / span * width
+		}
+		y := height / 2
+		if high > low {
+			y = height - (value(sample)-low)/(high-low)*height
+		}
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+	return strings.Join(points, " ")
+}
@@ -1,12 +1,16 @@
 package gw2api
 
-import "time"
+import (
+	"context"
+	"errors"
+	"time"
+)
 
 // Account represents basic account information.
 // Wiki: https://wiki.guildwars2.com/wiki/API:2/account
 type Account struct {
 	ID                string         `json:"id"`
-	Age               int            `json:"age"`
+	Age               Playtime       `json:"age"` // Time played, not time since Created
 	Name              string         `json:"name"`
 	World             int            `json:"world"`
 	Guilds            []string       `json:"guilds"`
@@ -33,8 +37,8 @@ type AccountWvW struct {
 type AccountAchievement struct {
 	ID      int           `json:"id"`
 	Bits    []int         `json:"bits,omitempty"`
-	Current []int         `json:"current,omitempty"`
-	Max     []int         `json:"max,omitempty"`
+	Current int           `json:"current,omitempty"`
+	Max     int           `json:"max,omitempty"`
 	Done    bool          `json:"done"`
 	Unlocked bool         `json:"unlocked,omitempty"`
 	Repeated int          `json:"repeated,omitempty"`
@@ -95,11 +99,12 @@ type Dye int
 // Emote represents an unlocked emote
 type Emote string
 
-// Finisher represents an unlocked finisher
-type Finisher struct {
-	ID       int    `json:"id"`
-	Permanent bool  `json:"permanent,omitempty"`
-	Quantity int   `json:"quantity,omitempty"`
+// AccountFinisher represents an unlocked finisher. Finishers that aren't
+// permanent have a limited number of uses left in Quantity.
+type AccountFinisher struct {
+	ID        int  `json:"id"`
+	Permanent bool `json:"permanent"`
+	Quantity  int  `json:"quantity,omitempty"`
 }
 
 // Glider represents an unlocked glider
@@ -189,6 +194,12 @@ type MasteryPoint struct {
 	Earned   int    `json:"earned"`
 }
 
+// AccountMasteryPoints represents the mastery points earned and spent by an account
+type AccountMasteryPoints struct {
+	Totals   []MasteryPoint `json:"totals"`
+	Unlocked []int          `json:"unlocked"`
+}
+
 // MaterialSlot represents materials storage
 type MaterialSlot struct {
 	ID       int `json:"id"`
@@ -241,9 +252,24 @@ type WizardsVaultDaily struct {
 	// Placeholder structure
 }
 
-// WizardsVaultListing represents wizard's vault listings
+// WizardsVaultListing is a Wizard's Vault reward with the account's purchases
+// this season. PurchaseLimit is 0 for rewards that can be bought without limit.
 type WizardsVaultListing struct {
-	// Placeholder structure
+	WizardsVaultListingDetail
+	Purchased     int `json:"purchased,omitempty"`
+	PurchaseLimit int `json:"purchase_limit,omitempty"`
+}
+
+// LimitReached reports whether the account has bought the listing as often
+// as it may this season
+func (l WizardsVaultListing) LimitReached() bool {
+	return l.PurchaseLimit > 0 && l.Purchased >= l.PurchaseLimit
+}
+
+// Affordable reports whether the listing can be bought now with acclaim
+// Astral Acclaim: its limit isn't reached and it costs no more than that
+func (l WizardsVaultListing) Affordable(acclaim int) bool {
+	return !l.LimitReached() && l.Cost <= acclaim
 }
 
 // WizardsVaultSpecial represents special wizard's vault objectives
@@ -259,7 +285,352 @@ type WizardsVaultWeekly struct {
 // WorldBoss represents defeated world bosses
 type WorldBoss string
 
-// WvWInfo represents WvW account information
+// WvWInfo is the account's World vs. World team, guild and rank. Since the
+// World Restructuring, teams are formed each season from the guild each
+// account chooses to play for.
 type WvWInfo struct {
-	// Placeholder structure
-}
\ No newline at end of file
+	TeamID  int    `json:"team_id"`            // 0 before the account is assigned a team
+	Rank    int    `json:"rank,omitempty"`     // WvW rank, titled by WvWRankTitle
+	GuildID string `json:"guild_id,omitempty"` // The chosen WvW guild, if any
+}
+
+// GetAccount returns basic account information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account
+// Scopes: account
+// Optional Scopes: guilds, progression
+func (c *Client) GetAccount(ctx context.Context, options ...RequestOption) (*Account, error) {
+	return GetSingle[Account](ctx, c, "/v2/account", options...)
+}
+
+// GetAccountAchievements returns account's progress towards all achievements.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/achievements
+// Scopes: account, progression
+func (c *Client) GetAccountAchievements(ctx context.Context, options ...RequestOption) ([]AccountAchievement, error) {
+	return GetAll[AccountAchievement](ctx, c, "/v2/account/achievements", options...)
+}
+
+// GetAccountAchievementsByIDs returns account's progress towards specific
+// achievements, in the order of ids. The API leaves out achievements the
+// account has made no progress on, so there may be fewer results than IDs,
+// and none at all is not an error.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/achievements
+// Scopes: account, progression
+func (c *Client) GetAccountAchievementsByIDs(ctx context.Context, ids []int, options ...RequestOption) ([]AccountAchievement, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	progress, err := GetByIDs[AccountAchievement](ctx, c, "/v2/account/achievements", ids, options...)
+	if errors.Is(err, ErrNotFound) {
+		// "all ids provided are invalid": no progress on any of them
+		return nil, nil
+	}
+	return progress, err
+}
+
+// GetAccountBank returns items stored in the account vault.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/bank
+// Scopes: account, inventories
+func (c *Client) GetAccountBank(ctx context.Context, options ...RequestOption) ([]BankSlot, error) {
+	return GetAll[BankSlot](ctx, c, "/v2/account/bank", options...)
+}
+
+// GetAccountBuildStorage returns build templates stored in the account.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/buildstorage
+// Scopes: account
+func (c *Client) GetAccountBuildStorage(ctx context.Context, options ...RequestOption) ([]BuildStorage, error) {
+	return GetAll[BuildStorage](ctx, c, "/v2/account/buildstorage", options...)
+}
+
+// GetAccountDailyCrafting returns daily crafting progress.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/dailycrafting
+// Scopes: account, progression
+func (c *Client) GetAccountDailyCrafting(ctx context.Context, options ...RequestOption) ([]DailyCrafting, error) {
+	return GetAll[DailyCrafting](ctx, c, "/v2/account/dailycrafting", options...)
+}
+
+// GetAccountDungeons returns dungeons completed since daily reset.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/dungeons
+// Scopes: account, progression
+func (c *Client) GetAccountDungeons(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/account/dungeons", options...)
+}
+
+// GetAccountDyes returns unlocked dyes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/dyes
+// Scopes: account, unlocks
+func (c *Client) GetAccountDyes(ctx context.Context, options ...RequestOption) ([]Dye, error) {
+	return GetAll[Dye](ctx, c, "/v2/account/dyes", options...)
+}
+
+// GetAccountEmotes returns unlocked emotes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/emotes
+// Scopes: account, unlocks
+func (c *Client) GetAccountEmotes(ctx context.Context, options ...RequestOption) ([]Emote, error) {
+	return GetAll[Emote](ctx, c, "/v2/account/emotes", options...)
+}
+
+// GetAccountFinishers returns unlocked finishers.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/finishers
+// Scopes: account, unlocks
+func (c *Client) GetAccountFinishers(ctx context.Context, options ...RequestOption) ([]AccountFinisher, error) {
+	return GetAll[AccountFinisher](ctx, c, "/v2/account/finishers", options...)
+}
+
+// GetAccountGliders returns unlocked gliders.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/gliders
+// Scopes: account, unlocks
+func (c *Client) GetAccountGliders(ctx context.Context, options ...RequestOption) ([]Glider, error) {
+	return GetAll[Glider](ctx, c, "/v2/account/gliders", options...)
+}
+
+// GetAccountHome returns home instance information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/home
+// Scopes: None (public endpoint)
+func (c *Client) GetAccountHome(ctx context.Context, options ...RequestOption) (*HomeInfo, error) {
+	return GetSingle[HomeInfo](ctx, c, "/v2/account/home", options...)
+}
+
+// GetAccountHomeCats returns unlocked home instance cats.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/home/cats
+// Scopes: account, progression, unlocks
+func (c *Client) GetAccountHomeCats(ctx context.Context, options ...RequestOption) ([]HomeCat, error) {
+	return GetAll[HomeCat](ctx, c, "/v2/account/home/cats", options...)
+}
+
+// GetAccountHomeNodes returns unlocked home instance nodes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/home/nodes
+// Scopes: account, progression, unlocks
+func (c *Client) GetAccountHomeNodes(ctx context.Context, options ...RequestOption) ([]HomeNode, error) {
+	return GetAll[HomeNode](ctx, c, "/v2/account/home/nodes", options...)
+}
+
+// GetAccountHomestead returns homestead information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/homestead
+// Scopes: None (public endpoint)
+func (c *Client) GetAccountHomestead(ctx context.Context, options ...RequestOption) (*Homestead, error) {
+	return GetSingle[Homestead](ctx, c, "/v2/account/homestead", options...)
+}
+
+// GetAccountHomesteadDecorations returns homestead decorations used by the account.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/homestead/decorations
+// Scopes: account, unlocks
+func (c *Client) GetAccountHomesteadDecorations(ctx context.Context, options ...RequestOption) ([]HomesteadDecoration, error) {
+	return GetAll[HomesteadDecoration](ctx, c, "/v2/account/homestead/decorations", options...)
+}
+
+// GetAccountHomesteadGlyphs returns glyphs stored in homestead collection boxes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/homestead/glyphs
+// Scopes: account, unlocks
+func (c *Client) GetAccountHomesteadGlyphs(ctx context.Context, options ...RequestOption) ([]HomesteadGlyph, error) {
+	return GetAll[HomesteadGlyph](ctx, c, "/v2/account/homestead/glyphs", options...)
+}
+
+// GetAccountInventory returns the shared inventory slots.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/inventory
+// Scopes: account, inventories
+func (c *Client) GetAccountInventory(ctx context.Context, options ...RequestOption) ([]InventorySlot, error) {
+	return GetAll[InventorySlot](ctx, c, "/v2/account/inventory", options...)
+}
+
+// GetAccountJadeBots returns unlocked jade bot skins.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/jadebots
+// Scopes: account, unlocks
+func (c *Client) GetAccountJadeBots(ctx context.Context, options ...RequestOption) ([]JadeBot, error) {
+	return GetAll[JadeBot](ctx, c, "/v2/account/jadebots", options...)
+}
+
+// GetAccountLegendaryArmory returns legendary armory items unlocked for the account.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/legendaryarmory
+// Scopes: account, unlocks, inventories
+func (c *Client) GetAccountLegendaryArmory(ctx context.Context, options ...RequestOption) ([]LegendaryArmory, error) {
+	return GetAll[LegendaryArmory](ctx, c, "/v2/account/legendaryarmory", options...)
+}
+
+// GetAccountLuck returns the total amount of luck consumed on the account.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/luck
+// Scopes: account, progression, unlocks
+func (c *Client) GetAccountLuck(ctx context.Context, options ...RequestOption) ([]Luck, error) {
+	return GetAll[Luck](ctx, c, "/v2/account/luck", options...)
+}
+
+// GetAccountMail returns account mail.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mail
+// Scopes: account
+func (c *Client) GetAccountMail(ctx context.Context, options ...RequestOption) ([]Mail, error) {
+	return GetAll[Mail](ctx, c, "/v2/account/mail", options...)
+}
+
+// GetAccountMailCarriers returns unlocked mail carriers.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mailcarriers
+// Scopes: account, unlocks
+func (c *Client) GetAccountMailCarriers(ctx context.Context, options ...RequestOption) ([]MailCarrier, error) {
+	return GetAll[MailCarrier](ctx, c, "/v2/account/mailcarriers", options...)
+}
+
+// GetAccountMapChests returns Hero's Choice Chests acquired since daily reset.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mapchests
+// Scopes: account, progression
+func (c *Client) GetAccountMapChests(ctx context.Context, options ...RequestOption) ([]MapChest, error) {
+	return GetAll[MapChest](ctx, c, "/v2/account/mapchests", options...)
+}
+
+// GetAccountMasteries returns unlocked masteries for the account.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/masteries
+// Scopes: account, progression
+func (c *Client) GetAccountMasteries(ctx context.Context, options ...RequestOption) ([]AccountMastery, error) {
+	return GetAll[AccountMastery](ctx, c, "/v2/account/masteries", options...)
+}
+
+// GetAccountMasteryPoints returns the total amount of mastery points unlocked.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mastery/points
+// Scopes: account, progression
+func (c *Client) GetAccountMasteryPoints(ctx context.Context, options ...RequestOption) (*AccountMasteryPoints, error) {
+	return GetSingle[AccountMasteryPoints](ctx, c, "/v2/account/mastery/points", options...)
+}
+
+// GetAccountMaterials returns materials stored in the account vault.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/materials
+// Scopes: account, inventories
+func (c *Client) GetAccountMaterials(ctx context.Context, options ...RequestOption) ([]MaterialSlot, error) {
+	return GetAll[MaterialSlot](ctx, c, "/v2/account/materials", options...)
+}
+
+// GetAccountMinis returns unlocked miniatures.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/minis
+// Scopes: account, unlocks
+func (c *Client) GetAccountMinis(ctx context.Context, options ...RequestOption) ([]Mini, error) {
+	return GetAll[Mini](ctx, c, "/v2/account/minis", options...)
+}
+
+// GetAccountMounts returns mount information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mounts
+// Scopes: None (public endpoint)
+func (c *Client) GetAccountMounts(ctx context.Context, options ...RequestOption) (*MountInfo, error) {
+	return GetSingle[MountInfo](ctx, c, "/v2/account/mounts", options...)
+}
+
+// GetAccountMountSkins returns unlocked mount skins.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mounts/skins
+// Scopes: account, unlocks
+func (c *Client) GetAccountMountSkins(ctx context.Context, options ...RequestOption) ([]MountSkin, error) {
+	return GetAll[MountSkin](ctx, c, "/v2/account/mounts/skins", options...)
+}
+
+// GetAccountMountTypes returns unlocked mount types.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/mounts/types
+// Scopes: account, unlocks
+func (c *Client) GetAccountMountTypes(ctx context.Context, options ...RequestOption) ([]MountType, error) {
+	return GetAll[MountType](ctx, c, "/v2/account/mounts/types", options...)
+}
+
+// GetAccountNovelties returns unlocked novelties.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/novelties
+// Scopes: account, unlocks
+func (c *Client) GetAccountNovelties(ctx context.Context, options ...RequestOption) ([]Novelty, error) {
+	return GetAll[Novelty](ctx, c, "/v2/account/novelties", options...)
+}
+
+// GetAccountOutfits returns unlocked outfits.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/outfits
+// Scopes: account, unlocks
+func (c *Client) GetAccountOutfits(ctx context.Context, options ...RequestOption) ([]Outfit, error) {
+	return GetAll[Outfit](ctx, c, "/v2/account/outfits", options...)
+}
+
+// GetAccountProgression returns account-wide progression for Fractals and Luck.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/progression
+// Scopes: progression, unlocks
+func (c *Client) GetAccountProgression(ctx context.Context, options ...RequestOption) ([]Progression, error) {
+	return GetAll[Progression](ctx, c, "/v2/account/progression", options...)
+}
+
+// GetAccountPvPHeroes returns unlocked PvP heroes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/pvp/heroes
+// Scopes: account, unlocks
+func (c *Client) GetAccountPvPHeroes(ctx context.Context, options ...RequestOption) ([]AccountPvPHero, error) {
+	return GetAll[AccountPvPHero](ctx, c, "/v2/account/pvp/heroes", options...)
+}
+
+// GetAccountRaids returns completed raid encounters since weekly reset.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/raids
+// Scopes: account, progression
+func (c *Client) GetAccountRaids(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/account/raids", options...)
+}
+
+// GetAccountRecipes returns unlocked recipes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/recipes
+// Scopes: account, unlocks
+func (c *Client) GetAccountRecipes(ctx context.Context, options ...RequestOption) ([]Recipe, error) {
+	return GetAll[Recipe](ctx, c, "/v2/account/recipes", options...)
+}
+
+// GetAccountSkiffs returns unlocked skiff skins.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/skiffs
+// Scopes: account, unlocks
+func (c *Client) GetAccountSkiffs(ctx context.Context, options ...RequestOption) ([]Skiff, error) {
+	return GetAll[Skiff](ctx, c, "/v2/account/skiffs", options...)
+}
+
+// GetAccountSkins returns unlocked skins.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/skins
+// Scopes: account, unlocks
+func (c *Client) GetAccountSkins(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/account/skins", options...)
+}
+
+// GetAccountTitles returns unlocked titles.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/titles
+// Scopes: account, unlocks
+func (c *Client) GetAccountTitles(ctx context.Context, options ...RequestOption) ([]UnlockedTitle, error) {
+	return GetAll[UnlockedTitle](ctx, c, "/v2/account/titles", options...)
+}
+
+// GetAccountWallet returns the account's currencies.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/wallet
+// Scopes: account, wallet
+func (c *Client) GetAccountWallet(ctx context.Context, options ...RequestOption) ([]WalletCurrency, error) {
+	return GetAll[WalletCurrency](ctx, c, "/v2/account/wallet", options...)
+}
+
+// GetAccountWizardsVaultDaily returns daily Wizard's Vault objectives.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/wizardsvault/daily
+// Scopes: account, progression
+func (c *Client) GetAccountWizardsVaultDaily(ctx context.Context, options ...RequestOption) ([]WizardsVaultDaily, error) {
+	return GetAll[WizardsVaultDaily](ctx, c, "/v2/account/wizardsvault/daily", options...)
+}
+
+// GetAccountWizardsVaultListings returns Wizard's Vault reward listings.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/wizardsvault/listings
+// Scopes: account, progression
+func (c *Client) GetAccountWizardsVaultListings(ctx context.Context, options ...RequestOption) ([]WizardsVaultListing, error) {
+	return GetAll[WizardsVaultListing](ctx, c, "/v2/account/wizardsvault/listings", options...)
+}
+
+// GetAccountWizardsVaultSpecial returns special Wizard's Vault objectives.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/wizardsvault/special
+// Scopes: account, progression
+func (c *Client) GetAccountWizardsVaultSpecial(ctx context.Context, options ...RequestOption) ([]WizardsVaultSpecial, error) {
+	return GetAll[WizardsVaultSpecial](ctx, c, "/v2/account/wizardsvault/special", options...)
+}
+
+// GetAccountWizardsVaultWeekly returns weekly Wizard's Vault objectives.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/wizardsvault/weekly
+// Scopes: account, progression
+func (c *Client) GetAccountWizardsVaultWeekly(ctx context.Context, options ...RequestOption) ([]WizardsVaultWeekly, error) {
+	return GetAll[WizardsVaultWeekly](ctx, c, "/v2/account/wizardsvault/weekly", options...)
+}
+
+// GetAccountWorldBosses returns defeated world bosses since daily reset.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/worldbosses
+// Scopes: account, progression
+func (c *Client) GetAccountWorldBosses(ctx context.Context, options ...RequestOption) ([]WorldBoss, error) {
+	return GetAll[WorldBoss](ctx, c, "/v2/account/worldbosses", options...)
+}
+
+// GetAccountWvW returns WvW account information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/wvw
+// Scopes: account
+func (c *Client) GetAccountWvW(ctx context.Context, options ...RequestOption) (*WvWInfo, error) {
+	return GetSingle[WvWInfo](ctx, c, "/v2/account/wvw", options...)
+}
@@ -0,0 +1,242 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// AchievementPointsSummary is the achievement point total for an account
+type AchievementPointsSummary struct {
+	Total      int              `json:"total"`       // AP earned from account achievements
+	Repeated   int              `json:"repeated"`    // Portion of Total earned from repeat completions
+	Unknown    int              `json:"unknown"`     // Progress entries without an achievement definition
+	ByCategory []CategoryPoints `json:"by_category"` // Per-category breakdown, highest first
+}
+
+// CategoryPoints is the AP earned within one achievement category
+type CategoryPoints struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Points int    `json:"points"`
+}
+
+// MasteryPointSummary combines earned and spent mastery points with track progress
+type MasteryPointSummary struct {
+	Earned    int                    `json:"earned"`
+	Spent     int                    `json:"spent"`
+	Available int                    `json:"available"`
+	Regions   []MasteryRegionSummary `json:"regions"`
+}
+
+// MasteryRegionSummary is the mastery progress for one region
+type MasteryRegionSummary struct {
+	Region          string `json:"region"`
+	Earned          int    `json:"earned"`
+	Spent           int    `json:"spent"`
+	Available       int    `json:"available"`        // Earned but not yet spent
+	ToComplete      int    `json:"to_complete"`      // Points still needed to finish every track
+	Tracks          int    `json:"tracks"`           // Mastery tracks in the region
+	TracksStarted   int    `json:"tracks_started"`   // Tracks with at least one level trained
+	TracksCompleted int    `json:"tracks_completed"` // Tracks with every level trained
+}
+
+// achievementTierPoints returns the AP awarded for completing every tier once
+func achievementTierPoints(achievement *Achievement) int {
+	total := 0
+	for _, tier := range achievement.Tiers {
+		total += tier.Points
+	}
+	return total
+}
+
+// achievementPoints returns the AP an account has earned from a single achievement.
+// Repeatable achievements award their full tier points again on every repeat, up to
+// point_cap; a cap of -1 (or none at all) means the achievement never stops awarding points.
+func achievementPoints(achievement *Achievement, progress AccountAchievement) (earned, repeated int) {
+	for _, tier := range achievement.Tiers {
+		if progress.Done || progress.Current >= tier.Count {
+			earned += tier.Points
+		}
+	}
+
+	if progress.Repeated > 0 && slices.Contains(achievement.Flags, "Repeatable") {
+		repeated = progress.Repeated * achievementTierPoints(achievement)
+		earned += repeated
+
+		if achievement.PointCap > 0 && earned > achievement.PointCap {
+			repeated -= earned - achievement.PointCap
+			earned = achievement.PointCap
+			if repeated < 0 {
+				repeated = 0
+			}
+		}
+	}
+
+	return earned, repeated
+}
+
+// summarizeAchievementPoints joins account progress against achievement definitions
+func summarizeAchievementPoints(progress []AccountAchievement, achievements map[int]*Achievement, categories []*AchievementCategory) *AchievementPointsSummary {
+	summary := &AchievementPointsSummary{}
+
+	earnedByID := make(map[int]int, len(progress))
+	for _, p := range progress {
+		achievement, found := achievements[p.ID]
+		if !found {
+			summary.Unknown++
+			continue
+		}
+
+		earned, repeated := achievementPoints(achievement, p)
+		summary.Total += earned
+		summary.Repeated += repeated
+		earnedByID[p.ID] = earned
+	}
+
+	for _, category := range categories {
+		points := 0
+		for _, id := range category.AchievementIDs() {
+			points += earnedByID[id]
+		}
+		if points > 0 {
+			summary.ByCategory = append(summary.ByCategory, CategoryPoints{
+				ID:     category.ID,
+				Name:   category.Name,
+				Points: points,
+			})
+		}
+	}
+
+	slices.SortStableFunc(summary.ByCategory, func(a, b CategoryPoints) int {
+		return b.Points - a.Points
+	})
+
+	return summary
+}
+
+// GetAccountAchievementPoints computes the account's earned achievement points.
+// Achievement definitions are fetched cache-first. Daily and monthly AP is not
+// included since the API doesn't report it per achievement.
+// Scopes: account, progression
+func (c *Client) GetAccountAchievementPoints(ctx context.Context, options ...RequestOption) (*AchievementPointsSummary, error) {
+	progress, err := c.GetAccountAchievements(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account achievements: %w", err)
+	}
+
+	ids := make([]int, len(progress))
+	for i, p := range progress {
+		ids[i] = p.ID
+	}
+
+	achievements := make(map[int]*Achievement, len(ids))
+	for start := 0; start < len(ids); start += maxIDsPerRequest {
+		end := min(start+maxIDsPerRequest, len(ids))
+		results, err := c.GetAchievements(ctx, ids[start:end], options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get achievements: %w", err)
+		}
+		for _, achievement := range results {
+			if achievement != nil {
+				achievements[achievement.ID] = achievement
+			}
+		}
+	}
+
+	categoryIDs, err := c.GetAchievementCategoryIDs(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievement categories: %w", err)
+	}
+
+	var categories []*AchievementCategory
+	for start := 0; start < len(categoryIDs); start += maxIDsPerRequest {
+		end := min(start+maxIDsPerRequest, len(categoryIDs))
+		results, err := c.GetAchievementCategories(ctx, categoryIDs[start:end], options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get achievement categories: %w", err)
+		}
+		categories = append(categories, results...)
+	}
+
+	return summarizeAchievementPoints(progress, achievements, categories), nil
+}
+
+// summarizeMasteryPoints joins point totals with trained mastery levels per region
+func summarizeMasteryPoints(points *AccountMasteryPoints, trained []AccountMastery, masteries []*Mastery) *MasteryPointSummary {
+	summary := &MasteryPointSummary{}
+
+	regions := make(map[string]*MasteryRegionSummary)
+	var order []string
+	region := func(name string) *MasteryRegionSummary {
+		if r, found := regions[name]; found {
+			return r
+		}
+		r := &MasteryRegionSummary{Region: name}
+		regions[name] = r
+		order = append(order, name)
+		return r
+	}
+
+	for _, total := range points.Totals {
+		r := region(total.Region)
+		r.Earned = total.Earned
+		r.Spent = total.Spent
+		r.Available = total.Earned - total.Spent
+		summary.Earned += total.Earned
+		summary.Spent += total.Spent
+	}
+	summary.Available = summary.Earned - summary.Spent
+
+	// Level is a 0-indexed reference into the track's levels
+	trainedLevel := make(map[int]int, len(trained))
+	for _, mastery := range trained {
+		trainedLevel[mastery.ID] = mastery.Level
+	}
+
+	for _, mastery := range masteries {
+		r := region(mastery.Region)
+		r.Tracks++
+
+		level, started := trainedLevel[mastery.ID]
+		if started {
+			r.TracksStarted++
+			if level >= len(mastery.Levels)-1 {
+				r.TracksCompleted++
+			}
+		}
+
+		for i, l := range mastery.Levels {
+			if !started || i > level {
+				r.ToComplete += l.PointCost
+			}
+		}
+	}
+
+	for _, name := range order {
+		summary.Regions = append(summary.Regions, *regions[name])
+	}
+	return summary
+}
+
+// GetAccountMasteryPointSummary combines the account's mastery point totals with
+// trained mastery levels to show per-region progress.
+// Scopes: account, progression
+func (c *Client) GetAccountMasteryPointSummary(ctx context.Context, options ...RequestOption) (*MasteryPointSummary, error) {
+	points, err := c.GetAccountMasteryPoints(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get mastery points: %w", err)
+	}
+
+	trained, err := c.GetAccountMasteries(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account masteries: %w", err)
+	}
+
+	masteries, err := c.GetAllMasteries(ctx, options...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get masteries: %w", err)
+	}
+
+	return summarizeMasteryPoints(points, trained, masteries), nil
+}
@@ -0,0 +1,157 @@
+package gw2api
+
+import "testing"
+
+func TestAchievementPoints(t *testing.T) {
+	tiered := &Achievement{
+		ID:    1,
+		Tiers: []AchievementTier{{Count: 1, Points: 5}, {Count: 5, Points: 10}, {Count: 10, Points: 15}},
+	}
+	repeatable := &Achievement{
+		ID:       2,
+		Flags:    []string{"Repeatable"},
+		Tiers:    []AchievementTier{{Count: 1, Points: 1}, {Count: 3, Points: 4}},
+		PointCap: 20,
+	}
+	uncapped := &Achievement{
+		ID:       3,
+		Flags:    []string{"Repeatable"},
+		Tiers:    []AchievementTier{{Count: 10, Points: 10}},
+		PointCap: -1,
+	}
+
+	tests := []struct {
+		name         string
+		achievement  *Achievement
+		progress     AccountAchievement
+		wantEarned   int
+		wantRepeated int
+	}{
+		{
+			name:        "no tiers reached",
+			achievement: tiered,
+			progress:    AccountAchievement{ID: 1, Current: 0, Max: 10},
+		},
+		{
+			name:        "partial tiers",
+			achievement: tiered,
+			progress:    AccountAchievement{ID: 1, Current: 7, Max: 10},
+			wantEarned:  15,
+		},
+		{
+			name:        "done awards every tier",
+			achievement: tiered,
+			progress:    AccountAchievement{ID: 1, Current: 10, Max: 10, Done: true},
+			wantEarned:  30,
+		},
+		{
+			name:        "repeated ignored without repeatable flag",
+			achievement: tiered,
+			progress:    AccountAchievement{ID: 1, Current: 10, Max: 10, Done: true, Repeated: 3},
+			wantEarned:  30,
+		},
+		{
+			name:         "repeats under cap",
+			achievement:  repeatable,
+			progress:     AccountAchievement{ID: 2, Current: 1, Max: 3, Repeated: 2},
+			wantEarned:   11,
+			wantRepeated: 10,
+		},
+		{
+			name:         "repeats capped at point_cap",
+			achievement:  repeatable,
+			progress:     AccountAchievement{ID: 2, Current: 3, Max: 3, Done: true, Repeated: 10},
+			wantEarned:   20,
+			wantRepeated: 15,
+		},
+		{
+			name:         "negative cap is uncapped",
+			achievement:  uncapped,
+			progress:     AccountAchievement{ID: 3, Current: 4, Max: 10, Repeated: 50},
+			wantEarned:   500,
+			wantRepeated: 500,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			earned, repeated := achievementPoints(tt.achievement, tt.progress)
+			if earned != tt.wantEarned || repeated != tt.wantRepeated {
+				t.Errorf("achievementPoints() = (%d, %d), expected (%d, %d)", earned, repeated, tt.wantEarned, tt.wantRepeated)
+			}
+		})
+	}
+}
+
+func TestSummarizeAchievementPoints(t *testing.T) {
+	achievements := map[int]*Achievement{
+		1: {ID: 1, Tiers: []AchievementTier{{Count: 1, Points: 10}}},
+		2: {ID: 2, Tiers: []AchievementTier{{Count: 1, Points: 5}}},
+		3: {ID: 3, Flags: []string{"Repeatable"}, Tiers: []AchievementTier{{Count: 1, Points: 2}}, PointCap: 6},
+	}
+	progress := []AccountAchievement{
+		{ID: 1, Current: 1, Max: 1, Done: true},
+		{ID: 2, Current: 1, Max: 1, Done: true},
+		{ID: 3, Current: 1, Max: 1, Done: true, Repeated: 5},
+		{ID: 99, Done: true},
+	}
+	categories := []*AchievementCategory{
+		{ID: 10, Name: "Small", Achievements: []CategoryAchievement{{ID: 2}}},
+		{ID: 11, Name: "Big", Achievements: []CategoryAchievement{{ID: 1}, {ID: 3}}},
+		{ID: 12, Name: "Empty", Achievements: []CategoryAchievement{{ID: 42}}},
+	}
+
+	summary := summarizeAchievementPoints(progress, achievements, categories)
+
+	if summary.Total != 21 {
+		t.Errorf("Total = %d, expected 21", summary.Total)
+	}
+	if summary.Repeated != 4 {
+		t.Errorf("Repeated = %d, expected 4", summary.Repeated)
+	}
+	if summary.Unknown != 1 {
+		t.Errorf("Unknown = %d, expected 1", summary.Unknown)
+	}
+	if len(summary.ByCategory) != 2 {
+		t.Fatalf("got %d categories, expected 2", len(summary.ByCategory))
+	}
+	if summary.ByCategory[0].Name != "Big" || summary.ByCategory[0].Points != 16 {
+		t.Errorf("first category = %+v, expected Big with 16 points", summary.ByCategory[0])
+	}
+}
+
+func TestSummarizeMasteryPoints(t *testing.T) {
+	points := &AccountMasteryPoints{
+		Totals: []MasteryPoint{
+			{Region: "Tyria", Earned: 10, Spent: 6},
+			{Region: "Maguuma", Earned: 20, Spent: 20},
+		},
+	}
+	masteries := []*Mastery{
+		{ID: 1, Region: "Tyria", Levels: []MasteryLevel{{PointCost: 1}, {PointCost: 2}, {PointCost: 3}}},
+		{ID: 2, Region: "Tyria", Levels: []MasteryLevel{{PointCost: 4}}},
+		{ID: 3, Region: "Maguuma", Levels: []MasteryLevel{{PointCost: 5}, {PointCost: 5}}},
+	}
+	trained := []AccountMastery{
+		{ID: 1, Level: 0},
+		{ID: 3, Level: 1},
+	}
+
+	summary := summarizeMasteryPoints(points, trained, masteries)
+
+	if summary.Earned != 30 || summary.Spent != 26 || summary.Available != 4 {
+		t.Errorf("totals = %d/%d/%d, expected 30/26/4", summary.Earned, summary.Spent, summary.Available)
+	}
+	if len(summary.Regions) != 2 {
+		t.Fatalf("got %d regions, expected 2", len(summary.Regions))
+	}
+
+	tyria := summary.Regions[0]
+	if tyria.Region != "Tyria" || tyria.Tracks != 2 || tyria.TracksStarted != 1 || tyria.TracksCompleted != 0 || tyria.ToComplete != 9 {
+		t.Errorf("Tyria = %+v", tyria)
+	}
+	maguuma := summary.Regions[1]
+	if maguuma.TracksCompleted != 1 || maguuma.ToComplete != 0 || maguuma.Available != 0 {
+		t.Errorf("Maguuma = %+v", maguuma)
+	}
+}
@@ -0,0 +1,51 @@
+package gw2api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetAccountAchievementsByIDs(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("ids")
+		requested = append(requested, r.URL.Path+"?ids="+ids)
+		switch ids {
+		case "1,2,3":
+			// The API answers in its own order and leaves out 2, which has no progress
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write([]byte(`[{"id": 3, "current": 1, "max": 5, "done": false}, {"id": 1, "current": 10, "max": 10, "done": true}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "all ids provided are invalid"}`))
+		}
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithRetries(0), WithRateLimit(1000))
+	ctx := context.Background()
+
+	progress, err := client.GetAccountAchievementsByIDs(ctx, []int{1, 2, 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(progress) != 2 || progress[0].ID != 1 || !progress[0].Done || progress[1].ID != 3 || progress[1].Current != 1 {
+		t.Errorf("progress = %+v, expected 1 then 3", progress)
+	}
+	if len(requested) != 1 || requested[0] != "/v2/account/achievements?ids=1,2,3" {
+		t.Errorf("requested %v", requested)
+	}
+
+	// No progress on any of them
+	progress, err = client.GetAccountAchievementsByIDs(ctx, []int{4})
+	if err != nil || len(progress) != 0 {
+		t.Errorf("without progress got %+v, %v", progress, err)
+	}
+
+	// Nothing to ask for
+	requested = nil
+	if progress, err := client.GetAccountAchievementsByIDs(ctx, nil); err != nil || progress != nil || len(requested) != 0 {
+		t.Errorf("without IDs got %+v, %v after %v", progress, err, requested)
+	}
+}
@@ -0,0 +1,100 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+)
+
+// AccountFinisherDetail pairs an unlocked finisher with its definition
+type AccountFinisherDetail struct {
+	AccountFinisher
+	Detail *FinisherDetail `json:"detail,omitempty"` // nil when the finisher has no definition
+}
+
+// unlockIDs converts typed unlock IDs to plain ints
+func unlockIDs[T ~int](unlocks []T) []int {
+	ids := make([]int, len(unlocks))
+	for i, id := range unlocks {
+		ids[i] = int(id)
+	}
+	return ids
+}
+
+// GetAccountFinishersDetailed returns unlocked finishers joined with their definitions.
+// Scopes: account, unlocks
+func (c *Client) GetAccountFinishersDetailed(ctx context.Context) ([]AccountFinisherDetail, error) {
+	unlocked, err := c.GetAccountFinishers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account finishers: %w", err)
+	}
+
+	ids := make([]int, len(unlocked))
+	for i, finisher := range unlocked {
+		ids[i] = finisher.ID
+	}
+	details, err := c.GetFinishers(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get finisher details: %w", err)
+	}
+
+	byID := make(map[int]*FinisherDetail, len(details))
+	for _, detail := range details {
+		byID[detail.ID] = detail
+	}
+
+	results := make([]AccountFinisherDetail, len(unlocked))
+	for i, finisher := range unlocked {
+		results[i] = AccountFinisherDetail{AccountFinisher: finisher, Detail: byID[finisher.ID]}
+	}
+	return results, nil
+}
+
+// GetAccountGlidersDetailed returns the definitions of unlocked gliders.
+// Scopes: account, unlocks
+func (c *Client) GetAccountGlidersDetailed(ctx context.Context) ([]*GliderDetail, error) {
+	unlocked, err := c.GetAccountGliders(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account gliders: %w", err)
+	}
+	return c.GetGliders(ctx, unlockIDs(unlocked))
+}
+
+// GetAccountJadeBotsDetailed returns the definitions of unlocked jade bot skins.
+// Scopes: account, unlocks
+func (c *Client) GetAccountJadeBotsDetailed(ctx context.Context) ([]*JadeBotDetail, error) {
+	unlocked, err := c.GetAccountJadeBots(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account jade bots: %w", err)
+	}
+	return c.GetJadeBots(ctx, unlockIDs(unlocked))
+}
+
+// GetAccountMailCarriersDetailed returns the definitions of unlocked mail carriers.
+// Scopes: account, unlocks
+func (c *Client) GetAccountMailCarriersDetailed(ctx context.Context) ([]*MailCarrierDetail, error) {
+	unlocked, err := c.GetAccountMailCarriers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account mail carriers: %w", err)
+	}
+	return c.GetMailCarriers(ctx, unlockIDs(unlocked))
+}
+
+// GetAccountNoveltiesDetailed returns the definitions of unlocked novelties.
+// Scopes: account, unlocks
+func (c *Client) GetAccountNoveltiesDetailed(ctx context.Context) ([]*NoveltyDetail, error) {
+	unlocked, err := c.GetAccountNovelties(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account novelties: %w", err)
+	}
+	return c.GetNovelties(ctx, unlockIDs(unlocked))
+}
+
+// GetAccountSkiffsDetailed returns the definitions of unlocked skiff skins.
+// Scopes: account, unlocks
+func (c *Client) GetAccountSkiffsDetailed(ctx context.Context) ([]*SkiffDetail, error) {
+	unlocked, err := c.GetAccountSkiffs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account skiffs: %w", err)
+	}
+	return c.GetSkiffs(ctx, unlockIDs(unlocked))
+}
@@ -0,0 +1,117 @@
+package gw2api
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestUnlockFixturesDecodeStrictly(t *testing.T) {
+	tests := []struct {
+		fixture string
+		target  any
+	}{
+		{"account_finishers.json", &[]AccountFinisher{}},
+		{"account_gliders.json", &[]Glider{}},
+		{"account_jadebots.json", &[]JadeBot{}},
+		{"account_mailcarriers.json", &[]MailCarrier{}},
+		{"account_novelties.json", &[]Novelty{}},
+		{"account_skiffs.json", &[]Skiff{}},
+		{"finishers.json", &[]FinisherDetail{}},
+		{"gliders.json", &[]GliderDetail{}},
+		{"jadebots.json", &[]JadeBotDetail{}},
+		{"mailcarriers.json", &[]MailCarrierDetail{}},
+		{"novelties.json", &[]NoveltyDetail{}},
+		{"skiffs.json", &[]SkiffDetail{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			decodeStrict(t, filepath.Join("unlocks", tt.fixture), tt.target)
+		})
+	}
+}
+
+func newUnlockFixtureClient(t *testing.T) *Client {
+	return newFixtureClient(t, "unlocks", map[string]string{
+		"/v2/account/finishers":    "account_finishers.json",
+		"/v2/account/gliders":      "account_gliders.json",
+		"/v2/account/jadebots":     "account_jadebots.json",
+		"/v2/account/mailcarriers": "account_mailcarriers.json",
+		"/v2/account/novelties":    "account_novelties.json",
+		"/v2/account/skiffs":       "account_skiffs.json",
+		"/v2/finishers":            "finishers.json",
+		"/v2/gliders":              "gliders.json",
+		"/v2/jadebots":             "jadebots.json",
+		"/v2/mailcarriers":         "mailcarriers.json",
+		"/v2/novelties":            "novelties.json",
+		"/v2/skiffs":               "skiffs.json",
+	})
+}
+
+func TestAccountFinishersDetailed(t *testing.T) {
+	client := newUnlockFixtureClient(t)
+
+	finishers, err := client.GetAccountFinishersDetailed(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccountFinishersDetailed: %v", err)
+	}
+	if len(finishers) != 2 {
+		t.Fatalf("got %d finishers, expected 2", len(finishers))
+	}
+
+	rabbit, quaggan := finishers[0], finishers[1]
+	if !rabbit.Permanent || rabbit.Detail == nil || rabbit.Detail.Name != "Rabbit Rank Finisher" {
+		t.Errorf("finishers[0] = %+v, expected the permanent rabbit finisher", rabbit)
+	}
+	if quaggan.Permanent || quaggan.Quantity != 8 || quaggan.Detail == nil || !reflect.DeepEqual(quaggan.Detail.UnlockItems, []int{44873}) {
+		t.Errorf("finishers[1] = %+v, expected 8 uses of the quaggan finisher", quaggan)
+	}
+}
+
+func TestAccountUnlocksDetailed(t *testing.T) {
+	ctx := context.Background()
+	client := newUnlockFixtureClient(t)
+
+	gliders, err := client.GetAccountGlidersDetailed(ctx)
+	if err != nil {
+		t.Fatalf("GetAccountGlidersDetailed: %v", err)
+	}
+	// Glider 2 is unlocked but has no definition in the fixture
+	if len(gliders) != 2 || gliders[1].Name != "Crystal Arbiter Glider" {
+		t.Errorf("gliders = %+v", gliders)
+	}
+
+	jadeBots, err := client.GetAccountJadeBotsDetailed(ctx)
+	if err != nil {
+		t.Fatalf("GetAccountJadeBotsDetailed: %v", err)
+	}
+	if len(jadeBots) != 2 || jadeBots[1].UnlockItem != 97285 {
+		t.Errorf("jade bots = %+v", jadeBots)
+	}
+
+	mailCarriers, err := client.GetAccountMailCarriersDetailed(ctx)
+	if err != nil {
+		t.Fatalf("GetAccountMailCarriersDetailed: %v", err)
+	}
+	if len(mailCarriers) != 2 || !reflect.DeepEqual(mailCarriers[0].Flags, []string{"Default"}) {
+		t.Errorf("mail carriers = %+v", mailCarriers)
+	}
+
+	novelties, err := client.GetAccountNoveltiesDetailed(ctx)
+	if err != nil {
+		t.Fatalf("GetAccountNoveltiesDetailed: %v", err)
+	}
+	if len(novelties) != 2 || !reflect.DeepEqual(novelties[1].UnlockItem, []int{38479, 43497}) {
+		t.Errorf("novelties = %+v", novelties)
+	}
+
+	skiffs, err := client.GetAccountSkiffsDetailed(ctx)
+	if err != nil {
+		t.Fatalf("GetAccountSkiffsDetailed: %v", err)
+	}
+	if len(skiffs) != 2 || len(skiffs[0].DyeSlots) != 3 || skiffs[1].DyeSlots[0].ColorID != 473 {
+		t.Errorf("skiffs = %+v", skiffs)
+	}
+}
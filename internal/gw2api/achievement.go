@@ -1,5 +1,11 @@
 package gw2api
 
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+)
+
 // Achievement represents a Guild Wars 2 achievement
 type Achievement struct {
 	ID            int                 `json:"id"`
@@ -38,14 +44,103 @@ type AchievementBit struct {
 	Text string `json:"text,omitempty"`
 }
 
+// AchievementCategorySchema is the schema version the achievement category
+// getters request unless given another with WithSchemaVersion. From it on, a
+// category's achievements are objects with flags and levels rather than IDs,
+// and tomorrow's dailies are listed alongside today's.
+const AchievementCategorySchema = "2022-03-23T19:00:00.000Z"
+
+// categorySchema puts AchievementCategorySchema ahead of options, so a
+// WithSchemaVersion among them still wins
+func categorySchema(options []RequestOption) []RequestOption {
+	return append([]RequestOption{WithSchemaVersion(AchievementCategorySchema)}, options...)
+}
+
 // AchievementCategory represents an achievement category
 type AchievementCategory struct {
-	ID           int    `json:"id"`
-	Name         string `json:"name"`
-	Description  string `json:"description"`
-	Order        int    `json:"order"`
-	Icon         string `json:"icon"`
-	Achievements []int  `json:"achievements"`
+	ID           int                   `json:"id"`
+	Name         string                `json:"name"`
+	Description  string                `json:"description"`
+	Order        int                   `json:"order"`
+	Icon         string                `json:"icon"`
+	Achievements []CategoryAchievement `json:"achievements"`
+	Tomorrow     []CategoryAchievement `json:"tomorrow,omitempty"`
+}
+
+// CategoryAchievement is an achievement listed in a category. Under older
+// schema versions only the ID is set.
+type CategoryAchievement struct {
+	ID             int                `json:"id"`
+	Flags          []string           `json:"flags,omitempty"` // e.g. "PvE", "SpecialEvent"
+	Level          []int              `json:"level,omitempty"` // Min and max level for dailies
+	RequiredAccess *AchievementAccess `json:"required_access,omitempty"`
+}
+
+// AchievementAccess limits an achievement to accounts with or without a product
+type AchievementAccess struct {
+	Product   string `json:"product"`   // e.g. "HeartOfThorns"
+	Condition string `json:"condition"` // "HasAccess" or "NoAccess"
+}
+
+// UnmarshalJSON decodes an achievement object, or a bare ID as sent under
+// schema versions before AchievementCategorySchema
+func (a *CategoryAchievement) UnmarshalJSON(data []byte) error {
+	var id int
+	if err := json.Unmarshal(data, &id); err == nil {
+		*a = CategoryAchievement{ID: id}
+		return nil
+	}
+	type plain CategoryAchievement
+	return json.Unmarshal(data, (*plain)(a))
+}
+
+// AchievementIDs returns the IDs of the category's achievements in order
+func (c *AchievementCategory) AchievementIDs() []int {
+	ids := make([]int, len(c.Achievements))
+	for i, achievement := range c.Achievements {
+		ids[i] = achievement.ID
+	}
+	return ids
+}
+
+// MetaAchievement returns the category's meta achievement, the one completed
+// by finishing the others, such as a collection's mastery. achievements holds
+// the definitions of the category's achievements by ID. An achievement flagged
+// CategoryDisplay is preferred; otherwise it is the one whose bits name the
+// most other achievements in the category, at least two. It returns nil if the
+// category has no meta achievement.
+func (c *AchievementCategory) MetaAchievement(achievements map[int]*Achievement) *Achievement {
+	names := make(map[string]int)
+	for _, entry := range c.Achievements {
+		if achievement := achievements[entry.ID]; achievement != nil {
+			names[strings.ToLower(strings.TrimSpace(achievement.Name))] = entry.ID
+		}
+	}
+
+	var meta *Achievement
+	most := 1
+	for _, entry := range c.Achievements {
+		achievement := achievements[entry.ID]
+		if achievement == nil {
+			continue
+		}
+		if slices.Contains(achievement.Flags, "CategoryDisplay") {
+			return achievement
+		}
+		referenced := make(map[int]bool)
+		for _, bit := range achievement.Bits {
+			if bit.Type != "Text" {
+				continue
+			}
+			if id, found := names[strings.ToLower(strings.TrimSpace(bit.Text))]; found && id != achievement.ID {
+				referenced[id] = true
+			}
+		}
+		if len(referenced) > most {
+			meta, most = achievement, len(referenced)
+		}
+	}
+	return meta
 }
 
 // AchievementGroup represents an achievement group
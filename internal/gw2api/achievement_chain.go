@@ -0,0 +1,90 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// GetAchievementChain returns every achievement that must be completed before
+// the achievement with the given ID, following prerequisites recursively. The
+// list is ordered so each achievement comes after its own prerequisites, and
+// doesn't include the achievement itself. Achievements are read from the data
+// cache when it's loaded. A cycle in the prerequisites, which the API
+// shouldn't have, is broken rather than followed forever, and prerequisites
+// the API doesn't know are left out.
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementChain(ctx context.Context, id int) ([]*Achievement, error) {
+	known, err := c.lookupAchievements(ctx, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	if known[id] == nil {
+		return nil, fmt.Errorf("achievement %d: %w", id, ErrNotFound)
+	}
+
+	// Fetch a level of prerequisites at a time
+	pending := known[id].Prerequisites
+	for len(pending) > 0 {
+		var missing []int
+		for _, prerequisite := range uniqueIDs(pending) {
+			if _, seen := known[prerequisite]; !seen {
+				missing = append(missing, prerequisite)
+			}
+		}
+		if len(missing) == 0 {
+			break
+		}
+		found, err := c.lookupAchievements(ctx, missing)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return nil, fmt.Errorf("failed to get prerequisites: %w", err)
+		}
+		pending = nil
+		for _, prerequisite := range missing {
+			achievement := found[prerequisite]
+			known[prerequisite] = achievement // nil when unknown, so it isn't asked for again
+			if achievement != nil {
+				pending = append(pending, achievement.Prerequisites...)
+			}
+		}
+	}
+
+	// Prerequisites first, by a depth-first walk that visits each achievement once
+	var chain []*Achievement
+	visited := map[int]bool{id: true}
+	var visit func(achievement *Achievement)
+	visit = func(achievement *Achievement) {
+		for _, prerequisite := range achievement.Prerequisites {
+			if visited[prerequisite] {
+				continue
+			}
+			visited[prerequisite] = true
+			if next := known[prerequisite]; next != nil {
+				visit(next)
+				chain = append(chain, next)
+			}
+		}
+	}
+	visit(known[id])
+	return chain, nil
+}
+
+// GetAchievementsUnlockedBy returns the achievements that list the achievement
+// with the given ID as a prerequisite, ordered by ID. Only direct dependents
+// are returned. Needs the achievement cache, as the API can't be asked which
+// achievements require another.
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementsUnlockedBy(ctx context.Context, id int) ([]*Achievement, error) {
+	if c.dataCache == nil || !c.dataCache.GetAchievementCache().IsLoaded() {
+		return nil, errors.New("finding the achievements a prerequisite unlocks requires the achievement data cache to be loaded")
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	achievements := c.dataCache.GetAchievementCache()
+	ids := achievements.AchievementsRequiring(id)
+	slices.Sort(ids)
+	return achievements.GetByIDs(ids), nil
+}
@@ -0,0 +1,96 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newChainFixtureClient returns a client with the prerequisite chain fixture
+// cached. The API knows no achievements, so anything missing from the cache
+// is unknown.
+func newChainFixtureClient(t *testing.T) *Client {
+	t.Helper()
+	client := newFixtureClient(t, "achievements", nil)
+	client.dataCache = NewDataCache()
+	if err := client.dataCache.GetAchievementCache().LoadFromFile(filepath.Join("testdata", "achievements", "chain.jsonl")); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	return client
+}
+
+func achievementIDs(achievements []*Achievement) []int {
+	ids := make([]int, len(achievements))
+	for i, achievement := range achievements {
+		ids[i] = achievement.ID
+	}
+	return ids
+}
+
+func TestGetAchievementChain(t *testing.T) {
+	client := newChainFixtureClient(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		id       int
+		expected []int
+	}{
+		{name: "no prerequisites", id: 10},
+		{name: "one level", id: 11, expected: []int{10}},
+		// Act 1 is required twice, but listed once before both
+		{name: "three levels", id: 13, expected: []int{10, 11, 12, 14}},
+		{name: "unknown prerequisite", id: 15, expected: []int{10}},
+		{name: "cycle", id: 20, expected: []int{22, 21}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			chain, err := client.GetAchievementChain(ctx, test.id)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ids := achievementIDs(chain); !slices.Equal(ids, test.expected) {
+				t.Errorf("chain = %v, want %v", ids, test.expected)
+			}
+		})
+	}
+
+	if _, err := client.GetAchievementChain(ctx, 99); !errors.Is(err, ErrNotFound) {
+		t.Errorf("unknown achievement: err = %v, want ErrNotFound", err)
+	}
+}
+
+func TestGetAchievementsUnlockedBy(t *testing.T) {
+	client := newChainFixtureClient(t)
+	ctx := context.Background()
+
+	unlocked, err := client.GetAchievementsUnlockedBy(ctx, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ids := achievementIDs(unlocked); !slices.Equal(ids, []int{11, 12, 14, 15}) {
+		t.Errorf("unlocked by Act 1 = %v, want 11, 12, 14 and 15", ids)
+	}
+
+	unlocked, err = client.GetAchievementsUnlockedBy(ctx, 13)
+	if err != nil || len(unlocked) != 0 {
+		t.Errorf("unlocked by Finale = %v, %v; want none", achievementIDs(unlocked), err)
+	}
+
+	// Replacing an achievement moves it in the index
+	cache := client.dataCache.GetAchievementCache()
+	cache.replace([]*Achievement{{ID: 14, Name: "Side Story", Prerequisites: []int{13}}})
+	if ids := cache.AchievementsRequiring(10); !slices.Equal(ids, []int{11, 12, 15}) {
+		t.Errorf("after replacing Side Story, requiring Act 1 = %v", ids)
+	}
+	if ids := cache.AchievementsRequiring(13); !slices.Equal(ids, []int{14}) {
+		t.Errorf("after replacing Side Story, requiring Finale = %v", ids)
+	}
+
+	client.dataCache = nil
+	if _, err := client.GetAchievementsUnlockedBy(ctx, 10); err == nil {
+		t.Error("expected an error without the achievement cache")
+	}
+}
@@ -0,0 +1,78 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+)
+
+// achievementScanPages bounds how many pages of achievements are read to find
+// the achievements granting an unlock when no achievement cache is loaded. At
+// 200 a page only the oldest achievements are covered, so newer sources are
+// reported as unknown.
+const achievementScanPages = 5
+
+// achievementGranter looks up the achievements with a bit of the given type
+// referring to id
+type achievementGranter func(bitType string, id int) []*Achievement
+
+// GetAchievementsGranting returns the achievements with a bit of the given type
+// referring to id, such as ("Minipet", 12) for a mini or ("Item", 72016) for an
+// unlock item. The achievement data cache's bit index is used when the cache is
+// loaded; otherwise only the first few pages of achievements are scanned. An
+// empty result means the source is unknown, not that no achievement grants it.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementsGranting(ctx context.Context, bitType string, id int) ([]*Achievement, error) {
+	granting, err := c.achievementGranter(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return granting(bitType, id), nil
+}
+
+// achievementGranter returns a lookup backed by the achievement cache when it
+// is loaded, or by a bounded scan of the API otherwise
+func (c *Client) achievementGranter(ctx context.Context) (achievementGranter, error) {
+	if granting := c.cachedAchievementGranter(); granting != nil {
+		return granting, nil
+	}
+
+	byBit := make(map[AchievementBitKey][]*Achievement)
+	for page := 0; page < achievementScanPages; page++ {
+		achievements, pagination, err := GetPaged[Achievement](ctx, c, "/v2/achievements", WithPage(page), WithPageSize(maxIDsPerRequest))
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan achievements: %w", err)
+		}
+		for i := range achievements {
+			achievement := &achievements[i]
+			for _, bit := range achievement.Bits {
+				if bit.ID != 0 {
+					key := AchievementBitKey{Type: bit.Type, ID: bit.ID}
+					byBit[key] = append(byBit[key], achievement)
+				}
+			}
+		}
+		if pagination == nil || page+1 >= pagination.PageTotal {
+			break
+		}
+	}
+	return func(bitType string, id int) []*Achievement {
+		return byBit[AchievementBitKey{Type: bitType, ID: id}]
+	}, nil
+}
+
+// cachedAchievementGranter returns a lookup backed by the achievement cache's
+// bit index, or nil when the cache isn't loaded
+func (c *Client) cachedAchievementGranter() achievementGranter {
+	if c.dataCache == nil || !c.dataCache.GetAchievementCache().IsLoaded() {
+		return nil
+	}
+	achievements := c.dataCache.GetAchievementCache()
+	return func(bitType string, id int) []*Achievement {
+		ids := achievements.AchievementsForBit(bitType, id)
+		if len(ids) == 0 {
+			return nil
+		}
+		return achievements.GetByIDs(ids)
+	}
+}
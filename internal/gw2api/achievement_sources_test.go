@@ -0,0 +1,68 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestGetAchievementsGrantingScan(t *testing.T) {
+	// Ten pages of one achievement each, with a mini granted on page 2 and
+	// another past the scan bound on page 8
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/achievements" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		w.Header().Set("X-Page", strconv.Itoa(page))
+		w.Header().Set("X-Page-Total", "10")
+		fmt.Fprintf(w, `[{"id": %d, "name": "Achievement %d", "tiers": [{"count": 1, "points": 10}], "bits": [{"type": "Minipet", "id": %d}]}]`, page+1, page+1, 10+page)
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+	ctx := context.Background()
+
+	achievements, err := client.GetAchievementsGranting(ctx, "Minipet", 12)
+	if err != nil {
+		t.Fatalf("GetAchievementsGranting: %v", err)
+	}
+	if len(achievements) != 1 || achievements[0].ID != 3 {
+		t.Errorf("GetAchievementsGranting(Minipet, 12) = %+v, expected achievement 3", achievements)
+	}
+	if got := requests.Load(); got != achievementScanPages {
+		t.Errorf("scan made %d requests, expected %d", got, achievementScanPages)
+	}
+
+	// Sources past the scanned pages are unknown rather than an error
+	achievements, err = client.GetAchievementsGranting(ctx, "Minipet", 18)
+	if err != nil || len(achievements) != 0 {
+		t.Errorf("GetAchievementsGranting(Minipet, 18) = %+v, %v, expected no achievements and no error", achievements, err)
+	}
+}
+
+func TestGetAchievementsGrantingCache(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		"achievements.json": `{"id": 1, "name": "Mini Collector", "tiers": [{"count": 1, "points": 5}], "bits": [{"type": "Minipet", "id": 12}, {"type": "Item", "id": 500}]}` + "\n" +
+			`{"id": 2, "name": "Mini Master", "bits": [{"type": "Minipet", "id": 12}]}` + "\n",
+	})
+	// The cache answers without any requests to the API
+	client := NewClient(WithBaseURL("http://127.0.0.1:1"), WithRetries(0), WithDataCache(dir))
+
+	achievements, err := client.GetAchievementsGranting(context.Background(), "Minipet", 12)
+	if err != nil {
+		t.Fatalf("GetAchievementsGranting: %v", err)
+	}
+	if len(achievements) != 2 || achievements[0].Name != "Mini Collector" || achievements[1].Name != "Mini Master" {
+		t.Errorf("GetAchievementsGranting(Minipet, 12) = %+v, expected both achievements", achievements)
+	}
+	if achievements, err := client.GetAchievementsGranting(context.Background(), "Minipet", 500); err != nil || len(achievements) != 0 {
+		t.Errorf("GetAchievementsGranting(Minipet, 500) = %+v, %v, expected none", achievements, err)
+	}
+}
@@ -0,0 +1,96 @@
+package gw2api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestAchievementCategorySchemas(t *testing.T) {
+	var old, current AchievementCategory
+	decodeStrict(t, "achievements/category_old.json", &old)
+	decodeStrict(t, "achievements/category_new.json", &current)
+
+	want := []int{1840, 910, 2258}
+	if got := old.AchievementIDs(); !slices.Equal(got, want) {
+		t.Errorf("old schema IDs = %v, expected %v", got, want)
+	}
+	if got := current.AchievementIDs(); !slices.Equal(got, want) {
+		t.Errorf("new schema IDs = %v, expected %v", got, want)
+	}
+
+	if old.Achievements[0].Flags != nil || old.Tomorrow != nil {
+		t.Errorf("old schema decoded extra fields: %+v", old)
+	}
+	first := current.Achievements[0]
+	if !slices.Equal(first.Flags, []string{"PvE"}) || !slices.Equal(first.Level, []int{1, 80}) {
+		t.Errorf("first achievement = %+v", first)
+	}
+	if access := current.Achievements[1].RequiredAccess; access == nil || access.Product != "HeartOfThorns" || access.Condition != "HasAccess" {
+		t.Errorf("required access = %+v", access)
+	}
+	if len(current.Tomorrow) != 1 || current.Tomorrow[0].ID != 1936 {
+		t.Errorf("tomorrow = %+v", current.Tomorrow)
+	}
+}
+
+func TestGetAchievementCategorySchema(t *testing.T) {
+	fixture, err := os.ReadFile(filepath.Join("testdata", "achievements", "category_new.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions = append(versions, r.URL.Query().Get("v"))
+		w.Write(fixture)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetries(0), WithRateLimit(1000))
+	client.baseURL = server.URL
+
+	category, err := client.GetAchievementCategory(context.Background(), 97)
+	if err != nil {
+		t.Fatalf("GetAchievementCategory: %v", err)
+	}
+	if len(category.Achievements) != 3 {
+		t.Errorf("got %d achievements, expected 3", len(category.Achievements))
+	}
+	if _, err := client.GetAchievementCategory(context.Background(), 97, WithSchemaVersion("2019-05-16T00:00:00.000Z")); err != nil {
+		t.Fatalf("GetAchievementCategory: %v", err)
+	}
+
+	want := []string{AchievementCategorySchema, "2019-05-16T00:00:00.000Z"}
+	if !slices.Equal(versions, want) {
+		t.Errorf("schema versions = %v, expected %v", versions, want)
+	}
+}
+
+func TestMetaAchievement(t *testing.T) {
+	var list []Achievement
+	decodeStrict(t, "achievements/collection.json", &list)
+	achievements := make(map[int]*Achievement, len(list))
+	for i := range list {
+		achievements[list[i].ID] = &list[i]
+	}
+
+	// The fledgling collection names one other achievement, which isn't enough
+	category := &AchievementCategory{Achievements: []CategoryAchievement{{ID: 3043}, {ID: 3044}, {ID: 3045}, {ID: 3042}}}
+	if meta := category.MetaAchievement(achievements); meta == nil || meta.ID != 3042 {
+		t.Errorf("meta = %+v, expected 3042", meta)
+	}
+
+	achievements[3045].Flags = append(achievements[3045].Flags, "CategoryDisplay")
+	if meta := category.MetaAchievement(achievements); meta == nil || meta.ID != 3045 {
+		t.Errorf("meta = %+v, expected the CategoryDisplay achievement 3045", meta)
+	}
+
+	category = &AchievementCategory{Achievements: []CategoryAchievement{{ID: 3043}, {ID: 3044}}}
+	if meta := category.MetaAchievement(achievements); meta != nil {
+		t.Errorf("meta = %d, expected none", meta.ID)
+	}
+}
@@ -0,0 +1,74 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Playtime is time played, which the API reports as whole seconds in "age"
+// fields. It is not the time since the account or character was created;
+// use Created for that.
+type Playtime time.Duration
+
+// UnmarshalJSON decodes a number of seconds
+func (p *Playtime) UnmarshalJSON(data []byte) error {
+	var seconds int64
+	if err := json.Unmarshal(data, &seconds); err != nil {
+		return err
+	}
+	*p = Playtime(time.Duration(seconds) * time.Second)
+	return nil
+}
+
+// MarshalJSON encodes whole seconds, as the API does
+func (p Playtime) MarshalJSON() ([]byte, error) {
+	return json.Marshal(int64(p.Duration() / time.Second))
+}
+
+// Duration returns the time played as a time.Duration
+func (p Playtime) Duration() time.Duration {
+	return time.Duration(p)
+}
+
+// Hours returns the time played in whole hours, as shown in game
+func (p Playtime) Hours() int {
+	return int(p.Duration() / time.Hour)
+}
+
+func (p Playtime) String() string {
+	return p.Duration().String()
+}
+
+// AccountAge returns how long ago the account was created. Unlike Age, this
+// includes time spent not playing.
+func (a *Account) AccountAge() time.Duration {
+	return time.Since(a.Created)
+}
+
+// NextBirthday returns the first anniversary of the character's creation after
+// now, when the game hands out a birthday gift. Characters created on
+// February 29 have their birthday on March 1 outside leap years.
+func (c *CharacterCore) NextBirthday(now time.Time) time.Time {
+	if c.Created.IsZero() {
+		return time.Time{}
+	}
+	now = now.In(c.Created.Location())
+	for year := max(now.Year(), c.Created.Year()+1); ; year++ {
+		if birthday := anniversary(c.Created, year); birthday.After(now) {
+			return birthday
+		}
+	}
+}
+
+// BirthdayNumber returns which birthday a date from NextBirthday is, such as 5
+// for the character's fifth
+func (c *CharacterCore) BirthdayNumber(birthday time.Time) int {
+	return birthday.In(c.Created.Location()).Year() - c.Created.Year()
+}
+
+// anniversary returns created moved to another year. time.Date rolls
+// February 29 over to March 1 in years without it.
+func anniversary(created time.Time, year int) time.Time {
+	return time.Date(year, created.Month(), created.Day(),
+		created.Hour(), created.Minute(), created.Second(), created.Nanosecond(), created.Location())
+}
@@ -0,0 +1,64 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestCharacterCoreDecodesAge(t *testing.T) {
+	var core CharacterCore
+	data := `{"name":"Test","age":90061,"created":"2016-02-29T13:37:00Z"}`
+	if err := json.Unmarshal([]byte(data), &core); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if core.Age.Duration() != 25*time.Hour+time.Minute+time.Second || core.Age.Hours() != 25 {
+		t.Errorf("Age = %v, expected 25h1m1s", core.Age)
+	}
+	if expected := time.Date(2016, 2, 29, 13, 37, 0, 0, time.UTC); !core.Created.Equal(expected) {
+		t.Errorf("Created = %v, expected %v", core.Created, expected)
+	}
+
+	encoded, err := json.Marshal(core.Age)
+	if err != nil || string(encoded) != "90061" {
+		t.Errorf("Marshal(Age) = %s, %v, expected seconds", encoded, err)
+	}
+}
+
+func TestNextBirthday(t *testing.T) {
+	at := func(year int, month time.Month, day, hour int) time.Time {
+		return time.Date(year, month, day, hour, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name     string
+		created  time.Time
+		now      time.Time
+		expected time.Time
+		number   int
+	}{
+		{"later this year", at(2015, time.August, 28, 12), at(2024, time.June, 1, 0), at(2024, time.August, 28, 12), 9},
+		{"already passed this year", at(2015, time.August, 28, 12), at(2024, time.September, 1, 0), at(2025, time.August, 28, 12), 10},
+		{"earlier today", at(2015, time.August, 28, 12), at(2024, time.August, 28, 13), at(2025, time.August, 28, 12), 10},
+		{"before the first", at(2024, time.March, 5, 0), at(2024, time.April, 1, 0), at(2025, time.March, 5, 0), 1},
+		{"leap day in a leap year", at(2016, time.February, 29, 10), at(2024, time.February, 1, 0), at(2024, time.February, 29, 10), 8},
+		{"leap day outside a leap year", at(2016, time.February, 29, 10), at(2025, time.February, 1, 0), at(2025, time.March, 1, 10), 9},
+		{"leap day rolled over", at(2016, time.February, 29, 10), at(2025, time.March, 1, 11), at(2026, time.March, 1, 10), 10},
+		{"march first outside a leap year", at(2016, time.March, 1, 10), at(2025, time.February, 1, 0), at(2025, time.March, 1, 10), 9},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core := CharacterCore{Created: tt.created}
+			got := core.NextBirthday(tt.now)
+			if !got.Equal(tt.expected) {
+				t.Errorf("NextBirthday = %v, expected %v", got, tt.expected)
+			}
+			if number := core.BirthdayNumber(got); number != tt.number {
+				t.Errorf("BirthdayNumber = %d, expected %d", number, tt.number)
+			}
+		})
+	}
+
+	if got := (&CharacterCore{}).NextBirthday(time.Now()); !got.IsZero() {
+		t.Errorf("NextBirthday without a created date = %v, expected zero", got)
+	}
+}
@@ -0,0 +1,80 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidAPIKey is returned by CheckAPIKey when the API rejects the key,
+// and matches the errors from ValidateAPIKeyFormat
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// apiKeyLength is the length of an API key, such as
+// 564F181A-F0FC-114A-A55D-3C1DCD45F3767AF3848F-AB29-4EBF-9594-F91E6A75E015
+const apiKeyLength = 72
+
+// apiKeyPattern matches API keys, which are two GUIDs run together with the
+// hyphen between them dropped
+var apiKeyPattern = regexp.MustCompile(`^[0-9A-Fa-f]{8}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{20}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{4}-[0-9A-Fa-f]{12}$`)
+
+// subtokenPattern matches subtokens from CreateSubtoken, which are JWTs
+var subtokenPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*$`)
+
+// ValidateAPIKeyFormat checks that key looks like an API key or a subtoken
+// without calling the API, to catch typos and truncated pastes early. A key
+// that passes may still be revoked or unknown; use CheckAPIKey for that. The
+// error matches ErrInvalidAPIKey.
+func ValidateAPIKeyFormat(key string) error {
+	switch {
+	case key == "":
+		return fmt.Errorf("%w: key is empty", ErrInvalidAPIKey)
+	case strings.TrimSpace(key) != key:
+		return fmt.Errorf("%w: key has leading or trailing whitespace", ErrInvalidAPIKey)
+	case apiKeyPattern.MatchString(key), subtokenPattern.MatchString(key):
+		return nil
+	case len(key) != apiKeyLength:
+		return fmt.Errorf("%w: key is %d characters long, expected %d", ErrInvalidAPIKey, len(key), apiKeyLength)
+	}
+	return fmt.Errorf("%w: expected hexadecimal groups of 8-4-4-4-20-4-4-4-12 characters", ErrInvalidAPIKey)
+}
+
+// CheckAPIKey asks the API about the client's API key, returning its name and
+// permissions. A key the API doesn't accept gives an error matching
+// ErrInvalidAPIKey, so callers can tell it apart from network problems.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/tokeninfo
+// Scopes: account
+func (c *Client) CheckAPIKey(ctx context.Context) (*TokenInfo, error) {
+	if c.apiKey == "" {
+		return nil, fmt.Errorf("%w: no API key set", ErrInvalidAPIKey)
+	}
+	info, err := c.GetTokenInfo(ctx)
+	if err != nil {
+		return nil, apiKeyError(err)
+	}
+	return info, nil
+}
+
+// apiKeyError turns the API's rejection of an API key into an error matching
+// ErrInvalidAPIKey, and returns any other error unchanged. Invalid keys get a
+// 401, or a 400 or 403 mentioning the key on older API versions.
+func apiKeyError(err error) error {
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) {
+		return err
+	}
+	message := strings.ToLower(httpErr.Message)
+	switch {
+	case httpErr.StatusCode == http.StatusUnauthorized:
+	case httpErr.StatusCode == http.StatusBadRequest || httpErr.StatusCode == http.StatusForbidden:
+		if !strings.Contains(message, "invalid key") && !strings.Contains(message, "invalid access token") {
+			return err
+		}
+	default:
+		return err
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidAPIKey, httpErr.Message)
+}
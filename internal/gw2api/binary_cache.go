@@ -0,0 +1,275 @@
+package gw2api
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Decoding tens of thousands of JSONL lines dominates startup, so each data
+// file loaded by LoadFromDirectory gets a gob sidecar, such as items.gob next
+// to items.json. The sidecar's header holds a hash of the JSONL it was built
+// from; when the JSONL changes the sidecar is ignored and rebuilt, so the JSONL
+// files stay the source of truth.
+
+// binaryCacheMagic and binaryCacheVersion identify sidecar files. Bump the
+// version when a cached type or the header changes shape.
+const (
+	binaryCacheMagic   = "gw2api-cache"
+	binaryCacheVersion = 2
+)
+
+// binaryCacheHeader starts every sidecar file
+type binaryCacheHeader struct {
+	Magic      string
+	Version    int
+	Kind       string
+	SourceHash [sha256.Size]byte // SHA-256 of the JSONL file
+	Count      int
+	Corrupt    int // Invalid lines skipped in the JSONL file
+}
+
+// BinaryFileName returns the sidecar file name for a JSONL data file, turning
+// items.json or items.json.gz into items.gob
+func BinaryFileName(name string) string {
+	name = strings.TrimSuffix(name, CompressedSuffix)
+	return strings.TrimSuffix(name, filepath.Ext(name)) + ".gob"
+}
+
+// decodeJSONL parses JSONL data, skipping invalid lines and returning how many
+// were skipped. Data with invalid lines and no valid ones is reported as corrupt.
+func decodeJSONL[T any](data []byte, kind, filePath string) ([]*T, int, error) {
+	var values []*T
+	invalid := 0
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+
+		value := new(T)
+		if err := json.Unmarshal(line, value); err != nil {
+			// Skip invalid lines but continue processing
+			invalid++
+			continue
+		}
+		values = append(values, value)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error reading %s file: %w", kind, err)
+	}
+	if invalid > 0 && len(values) == 0 {
+		// A file with no valid lines at all is corrupt rather than out of date
+		return nil, invalid, fmt.Errorf("%w: %s file %s has no valid entries", ErrDataFileCorrupt, kind, filePath)
+	}
+	return values, invalid, nil
+}
+
+// readBinaryCache reads the entries from a sidecar file along with the number
+// of invalid lines in its JSONL, failing if it is missing, corrupt, truncated
+// or was built from different JSONL
+func readBinaryCache[T any](binaryPath, kind string, sourceHash [sha256.Size]byte) ([]*T, int, error) {
+	file, err := os.Open(binaryPath)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	decoder := gob.NewDecoder(bufio.NewReader(file))
+	var header binaryCacheHeader
+	if err := decoder.Decode(&header); err != nil {
+		return nil, 0, fmt.Errorf("invalid %s binary cache header: %w", kind, err)
+	}
+	if header.Magic != binaryCacheMagic || header.Version != binaryCacheVersion || header.Kind != kind {
+		return nil, 0, fmt.Errorf("%s is not a version %d %s binary cache", binaryPath, binaryCacheVersion, kind)
+	}
+	if header.SourceHash != sourceHash {
+		return nil, 0, fmt.Errorf("%s binary cache is out of date", kind)
+	}
+
+	var values []*T
+	if err := decoder.Decode(&values); err != nil {
+		return nil, 0, fmt.Errorf("invalid %s binary cache: %w", kind, err)
+	}
+	if len(values) != header.Count || (len(values) > 0 && values[len(values)-1] == nil) {
+		return nil, 0, fmt.Errorf("%s binary cache has %d entries, expected %d", kind, len(values), header.Count)
+	}
+	return values, header.Corrupt, nil
+}
+
+// writeBinaryCache atomically writes values to a sidecar file for the JSONL
+// data with the given hash, which had corrupt invalid lines
+func writeBinaryCache[T any](binaryPath, kind string, sourceHash [sha256.Size]byte, values []*T, corrupt int) error {
+	tmp, err := os.CreateTemp(filepath.Dir(binaryPath), "."+filepath.Base(binaryPath)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tmpPath := tmp.Name()
+
+	writer := bufio.NewWriter(tmp)
+	encoder := gob.NewEncoder(writer)
+	header := binaryCacheHeader{
+		Magic:      binaryCacheMagic,
+		Version:    binaryCacheVersion,
+		Kind:       kind,
+		SourceHash: sourceHash,
+		Count:      len(values),
+		Corrupt:    corrupt,
+	}
+	err = encoder.Encode(header)
+	if err == nil {
+		err = encoder.Encode(values)
+	}
+	if err == nil {
+		err = writer.Flush()
+	}
+	if err == nil {
+		err = tmp.Chmod(replacedMode(binaryPath))
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to encode %s binary cache: %w", kind, err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to close temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, binaryPath); err != nil {
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to replace %s: %w", binaryPath, err)
+	}
+	return nil
+}
+
+// loadPreferBinary loads a JSONL data file through its sidecar when the sidecar
+// matches it, and otherwise from the JSONL, rebuilding the sidecar afterwards.
+// Failing to write the sidecar, such as in a read-only data directory, only
+// costs the speedup next time. Gzipped files are hashed as stored, so a
+// matching sidecar skips decompressing them too.
+func (c *jsonlCache[T]) loadPreferBinary(filePath string) error {
+	startTime := time.Now()
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s file %s: %w", c.kind, filePath, err)
+	}
+	hash := sha256.Sum256(data)
+	binaryPath := BinaryFileName(filePath)
+
+	values, corrupt, err := readBinaryCache[T](binaryPath, c.kind, hash)
+	if err != nil {
+		if data, err = decompressData(data, filePath); err != nil {
+			return err
+		}
+		values, corrupt, err = decodeJSONL[T](data, c.kind, filePath)
+		if err != nil {
+			return err
+		}
+		writeBinaryCache(binaryPath, c.kind, hash, values, corrupt)
+	}
+
+	c.store(values, corrupt, startTime)
+	return nil
+}
+
+// compactFile rebuilds the sidecar for a JSONL data file
+func compactFile[T any](kind CacheKind, filePath string) error {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s file %s: %w", kind, filePath, err)
+	}
+	decompressed, err := decompressData(data, filePath)
+	if err != nil {
+		return err
+	}
+	values, corrupt, err := decodeJSONL[T](decompressed, string(kind), filePath)
+	if err != nil {
+		return err
+	}
+	return writeBinaryCache(BinaryFileName(filePath), string(kind), sha256.Sum256(data), values, corrupt)
+}
+
+// Compact rebuilds the binary sidecar of every JSONL data file in dataDir, or
+// only those of the given kinds, so the next LoadFromDirectory can skip
+// decoding JSON. It returns the kinds that were compacted; files that don't
+// exist are skipped.
+func (dc *DataCache) Compact(dataDir string, kinds ...CacheKind) ([]CacheKind, error) {
+	compactors := []struct {
+		kind    CacheKind
+		compact func(CacheKind, string) error
+	}{
+		{CacheKindItems, compactFile[Item]},
+		{CacheKindSkills, compactFile[Skill]},
+		{CacheKindAchievements, compactFile[Achievement]},
+		{CacheKindRecipes, compactFile[RecipeDetail]},
+		{CacheKindMaterials, compactFile[Material]},
+		{CacheKindSkins, compactFile[SkinDetail]},
+		{CacheKindVendors, compactFile[Vendor]},
+		{CacheKindCurrencies, compactFile[Currency]},
+	}
+
+	var compacted []CacheKind
+	var errs []error
+	for _, compactor := range compactors {
+		if len(kinds) > 0 && !slices.Contains(kinds, compactor.kind) {
+			continue
+		}
+		filePath, found := findDataFile(dataDir, compactor.kind.FileName())
+		if !found {
+			continue
+		}
+		if err := compactor.compact(compactor.kind, filePath); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", compactor.kind, err))
+			continue
+		}
+		compacted = append(compacted, compactor.kind)
+	}
+	return compacted, errors.Join(errs...)
+}
+
+// Types holding interface values are stored in sidecars through their JSON
+// form, since gob would need every possible concrete type registered
+
+// GobEncode encodes the fact as JSON
+func (f SkillFact) GobEncode() ([]byte, error) {
+	return f.MarshalJSON()
+}
+
+// GobDecode decodes a fact written by GobEncode
+func (f *SkillFact) GobDecode(data []byte) error {
+	return f.UnmarshalJSON(data)
+}
+
+// GobEncode encodes the fact as JSON. Without it, the promoted SkillFact
+// methods would drop the trait fields.
+func (f TraitedFact) GobEncode() ([]byte, error) {
+	return f.MarshalJSON()
+}
+
+// GobDecode decodes a fact written by GobEncode
+func (f *TraitedFact) GobDecode(data []byte) error {
+	return f.UnmarshalJSON(data)
+}
+
+// GobEncode encodes the dye slots as JSON
+func (d SkinDyeSlots) GobEncode() ([]byte, error) {
+	return json.Marshal(d)
+}
+
+// GobDecode decodes dye slots written by GobEncode
+func (d *SkinDyeSlots) GobDecode(data []byte) error {
+	return json.Unmarshal(data, d)
+}
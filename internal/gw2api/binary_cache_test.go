@@ -0,0 +1,198 @@
+package gw2api
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// writeDataDir writes JSONL files into a new data directory
+func writeDataDir(t testing.TB, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+const testSkinJSON = `{"id": 10, "name": "Dye Test", "type": "Armor", "rarity": "Exotic", "details": {"type": "Coat", "weight_class": "Heavy", "dye_slots": {"default": [{"color_id": 1, "material": "cloth"}], "overrides": {"AsuraMale": [null, {"color_id": 2, "material": "metal"}]}}}}`
+
+func TestBinaryCacheRoundTrip(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		"items.json":  `{"id": 1, "name": "Sword", "flags": ["NoSell"], "details": {"min_power": 900}}` + "\n" + `{"id": 2, "name": "Ingot"}` + "\n",
+		"skills.json": strings.Join(strings.Fields(testSkillJSON), " ") + "\n",
+		"skins.json":  testSkinJSON + "\n",
+	})
+
+	fromJSON := NewDataCache()
+	if err := fromJSON.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("first load: %v", err)
+	}
+	for _, name := range []string{"items.gob", "skills.gob", "skins.gob"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("first load did not write %s: %v", name, err)
+		}
+	}
+
+	// The second load reads the sidecars, which must hold what the JSONL did
+	fromBinary := NewDataCache()
+	if err := fromBinary.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("second load: %v", err)
+	}
+	items, _, err := readBinaryCache[Item](filepath.Join(dir, "items.gob"), "items", hashOf(t, filepath.Join(dir, "items.json")))
+	if err != nil || !reflect.DeepEqual(items, fromJSON.GetItemCache().GetAllRef()) {
+		t.Fatalf("readBinaryCache = %+v, %v", items, err)
+	}
+
+	if got, want := fromBinary.GetItemCache().GetAllRef(), fromJSON.GetItemCache().GetAllRef(); !reflect.DeepEqual(got, want) {
+		t.Errorf("items from binary = %+v, expected %+v", got, want)
+	}
+	if got, want := fromBinary.GetSkillCache().GetAllRef(), fromJSON.GetSkillCache().GetAllRef(); !reflect.DeepEqual(got, want) {
+		t.Errorf("skills from binary = %+v, expected %+v", got, want)
+	}
+	skill, _ := fromBinary.GetSkillCache().GetByIDRef(5491)
+	if skill == nil || skill.TraitedFacts[0].RequiresTrait != 100 || skill.TraitedFacts[0].Overrides == nil {
+		t.Errorf("traited facts lost their trait fields: %+v", skill)
+	}
+	if got, want := fromBinary.GetSkinCache().GetAllRef(), fromJSON.GetSkinCache().GetAllRef(); !reflect.DeepEqual(got, want) {
+		t.Errorf("skins from binary = %+v, expected %+v", got, want)
+	}
+}
+
+// hashOf returns the hash a sidecar for the file should carry
+func hashOf(t *testing.T, path string) [32]byte {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return sha256.Sum256(data)
+}
+
+func TestBinaryCacheFallback(t *testing.T) {
+	tests := []struct {
+		name    string
+		corrupt func(t *testing.T, dir string)
+	}{
+		{"truncated", func(t *testing.T, dir string) {
+			data, _ := os.ReadFile(filepath.Join(dir, "items.gob"))
+			os.WriteFile(filepath.Join(dir, "items.gob"), data[:len(data)/2], 0o644)
+		}},
+		{"empty", func(t *testing.T, dir string) {
+			os.WriteFile(filepath.Join(dir, "items.gob"), nil, 0o644)
+		}},
+		{"garbage", func(t *testing.T, dir string) {
+			os.WriteFile(filepath.Join(dir, "items.gob"), []byte("not a gob file"), 0o644)
+		}},
+		{"stale", func(t *testing.T, dir string) {
+			// The JSONL changed after the sidecar was written
+			os.WriteFile(filepath.Join(dir, "items.json"), []byte(`{"id": 1, "name": "Sword"}`+"\n"+`{"id": 3, "name": "Leather"}`+"\n"), 0o644)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeDataDir(t, map[string]string{
+				"items.json": `{"id": 1, "name": "Sword"}` + "\n" + `{"id": 2, "name": "Ingot"}` + "\n",
+			})
+			if err := NewDataCache().LoadFromDirectory(dir); err != nil {
+				t.Fatalf("first load: %v", err)
+			}
+			tt.corrupt(t, dir)
+
+			dc := NewDataCache()
+			if err := dc.LoadFromDirectory(dir); err != nil {
+				t.Fatalf("load with %s sidecar: %v", tt.name, err)
+			}
+			jsonl := NewItemCache()
+			if err := jsonl.LoadFromFile(filepath.Join(dir, "items.json")); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := dc.GetItemCache().GetAllRef(), jsonl.GetAllRef(); !reflect.DeepEqual(got, want) {
+				t.Errorf("items = %+v, expected the JSONL entries %+v", got, want)
+			}
+
+			// The fallback rebuilt the sidecar
+			if _, _, err := readBinaryCache[Item](filepath.Join(dir, "items.gob"), "items", hashOf(t, filepath.Join(dir, "items.json"))); err != nil {
+				t.Errorf("sidecar was not rebuilt: %v", err)
+			}
+		})
+	}
+}
+
+func TestCompact(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		"items.json":     `{"id": 1, "name": "Sword"}` + "\n",
+		"materials.json": `{"id": 5, "name": "Basic Crafting Materials", "items": [19697]}` + "\n",
+		"skins.json":     "not json\n",
+	})
+
+	compacted, err := NewDataCache().Compact(dir)
+	if err == nil || !strings.Contains(err.Error(), "skins") {
+		t.Errorf("Compact error = %v, expected one for skins", err)
+	}
+	if !slices.Equal(compacted, []CacheKind{CacheKindItems, CacheKindMaterials}) {
+		t.Errorf("compacted = %v, expected [items materials]", compacted)
+	}
+	for _, name := range []string{"items.gob", "materials.gob"} {
+		if _, err := os.Stat(filepath.Join(dir, name)); err != nil {
+			t.Errorf("Compact did not write %s: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "skills.gob")); err == nil {
+		t.Error("Compact wrote a sidecar for a missing file")
+	}
+
+	// Naming kinds compacts only those
+	compacted, err = NewDataCache().Compact(dir, CacheKindMaterials)
+	if err != nil || !slices.Equal(compacted, []CacheKind{CacheKindMaterials}) {
+		t.Errorf("Compact(materials) = %v, %v, expected only materials", compacted, err)
+	}
+
+	if name := BinaryFileName("data/items.json"); name != "data/items.gob" {
+		t.Errorf("BinaryFileName = %s, expected data/items.gob", name)
+	}
+}
+
+// benchmarkItemsFile writes a JSONL file of n generated items
+func benchmarkItemsFile(b *testing.B, n int) string {
+	b.Helper()
+	var lines strings.Builder
+	for i := 1; i <= n; i++ {
+		fmt.Fprintf(&lines, `{"id": %d, "name": "Item %d", "description": "A generated item", "type": "Weapon", "rarity": "Exotic", "level": 80, "vendor_value": %d, "flags": ["NoSell", "SoulBindOnUse"], "details": {"type": "Sword", "min_power": 900, "max_power": 1000, "infix_upgrade": {"id": 161, "attributes": [{"attribute": "Power", "modifier": 120}]}}}`+"\n", i, i, i)
+	}
+	return filepath.Join(writeDataDir(b, map[string]string{"items.json": lines.String()}), "items.json")
+}
+
+func BenchmarkLoadItemsJSONL(b *testing.B) {
+	path := benchmarkItemsFile(b, 10000)
+	cache := NewItemCache()
+	b.ResetTimer()
+	for range b.N {
+		if err := cache.LoadFromFile(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkLoadItemsBinary(b *testing.B) {
+	path := benchmarkItemsFile(b, 10000)
+	cache := NewItemCache()
+	if err := cache.loadPreferBinary(path); err != nil {
+		b.Fatal(err)
+	}
+	b.ResetTimer()
+	for range b.N {
+		if err := cache.loadPreferBinary(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
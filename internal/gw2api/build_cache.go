@@ -0,0 +1,115 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// DefaultBuildCacheTTL is a build cache lifetime short enough to notice a new
+// build within a minute of its release
+const DefaultBuildCacheTTL = time.Minute
+
+// WithBuildCache keeps the game build returned by GetBuild for ttl, so the
+// components of a program that check it opportunistically, such as health
+// checks and staleness banners, share one request rather than each calling the
+// API. Once the build is older than ttl it is revalidated with a conditional
+// request. Concurrent calls while the build is fetched wait for that one
+// request. A ttl of zero or less turns the cache off, which is the default.
+func WithBuildCache(ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.buildCache = nil
+			return
+		}
+		c.buildCache = &buildCache{ttl: ttl}
+	}
+}
+
+// HasNewBuild reports whether the current game build is newer than since, and
+// returns the current build. With WithBuildCache, the build is fetched at most
+// once per cache lifetime however often this is called, so it is cheap to poll.
+func (c *Client) HasNewBuild(ctx context.Context, since int) (bool, int, error) {
+	build, err := c.GetBuild(ctx)
+	if err != nil {
+		return false, 0, err
+	}
+	return build.ID > since, build.ID, nil
+}
+
+// buildCache holds the last build fetched by GetBuild
+type buildCache struct {
+	ttl time.Duration
+
+	mutex     sync.Mutex
+	build     int
+	etag      string // Validator for revalidating build, if the API sent one
+	fetchedAt time.Time
+	fetch     *buildFetch // In flight, nil when no fetch is running
+}
+
+// buildFetch is a fetch of the build shared by every caller that needs it
+type buildFetch struct {
+	done  chan struct{} // Closed when build and err are set
+	build int
+	err   error
+}
+
+// get returns the cached build, fetching it first if it is missing or older
+// than the cache lifetime. Callers arriving during a fetch share its result.
+func (b *buildCache) get(ctx context.Context, c *Client) (*Build, error) {
+	b.mutex.Lock()
+	if !b.fetchedAt.IsZero() && time.Since(b.fetchedAt) < b.ttl {
+		build := b.build
+		b.mutex.Unlock()
+		return &Build{ID: build}, nil
+	}
+	if fetch := b.fetch; fetch != nil {
+		b.mutex.Unlock()
+		select {
+		case <-fetch.done:
+			if fetch.err != nil {
+				return nil, fetch.err
+			}
+			return &Build{ID: fetch.build}, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	fetch := &buildFetch{done: make(chan struct{})}
+	b.fetch = fetch
+	cached, etag := b.build, b.etag
+	b.mutex.Unlock()
+
+	// Revalidate with the validator from the last fetch; a 304 keeps the build
+	var header http.Header
+	options := []RequestOption{captureHeader(&header)}
+	if etag != "" && cached != 0 {
+		options = append(options, WithHeader("If-None-Match", etag))
+	}
+	build, err := c.GetBuild(ctx, options...)
+	switch {
+	case err == nil:
+		fetch.build = build.ID
+		etag = header.Get("ETag")
+	case errors.Is(err, ErrNotModified):
+		fetch.build = cached
+	default:
+		fetch.err = err
+	}
+
+	b.mutex.Lock()
+	b.fetch = nil
+	if fetch.err == nil {
+		b.build, b.etag, b.fetchedAt = fetch.build, etag, time.Now()
+	}
+	b.mutex.Unlock()
+	close(fetch.done)
+
+	if fetch.err != nil {
+		return nil, fetch.err
+	}
+	return &Build{ID: fetch.build}, nil
+}
@@ -0,0 +1,109 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBuildCache(t *testing.T) {
+	var requests, revalidated atomic.Int32
+	var build atomic.Int32
+	build.Store(100)
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := requests.Add(1)
+		if n == 1 {
+			// Hold the first request so concurrent callers pile up behind it
+			<-release
+		}
+		etag := fmt.Sprintf(`"%d"`, build.Load())
+		if r.Header.Get("If-None-Match") == etag {
+			revalidated.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprintf(w, `{"id": %d}`, build.Load())
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000), WithBuildCache(time.Hour))
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if build, err := client.GetBuild(ctx); err != nil || build.ID != 100 {
+				t.Errorf("GetBuild = %+v, %v, expected 100", build, err)
+			}
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	if got := requests.Load(); got != 1 {
+		t.Errorf("concurrent calls made %d requests, expected 1", got)
+	}
+
+	// Fresh builds come from the cache, including for HasNewBuild
+	if newer, current, err := client.HasNewBuild(ctx, 99); err != nil || !newer || current != 100 {
+		t.Errorf("HasNewBuild(99) = %v, %d, %v, expected true, 100", newer, current, err)
+	}
+	if newer, _, _ := client.HasNewBuild(ctx, 100); newer {
+		t.Error("HasNewBuild(100) = true, expected false for the current build")
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("cached calls made %d requests, expected 1", got)
+	}
+
+	// An expired build is revalidated, keeping it on 304 and replacing it otherwise
+	client.buildCache.fetchedAt = time.Now().Add(-2 * time.Hour)
+	if build, err := client.GetBuild(ctx); err != nil || build.ID != 100 || revalidated.Load() != 1 {
+		t.Errorf("GetBuild = %+v, %v after %d revalidations, expected 100 from a 304", build, err, revalidated.Load())
+	}
+	build.Store(101)
+	client.buildCache.fetchedAt = time.Time{}
+	if newer, current, err := client.HasNewBuild(ctx, 100); err != nil || !newer || current != 101 {
+		t.Errorf("HasNewBuild(100) = %v, %d, %v, expected true, 101", newer, current, err)
+	}
+
+	// Requests with options skip the cache
+	client.GetBuild(ctx, WithLang(LanguageFrench))
+	if got := requests.Load(); got != 4 {
+		t.Errorf("made %d requests, expected 4", got)
+	}
+}
+
+func TestBuildCacheErrors(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) == 1 {
+			http.Error(w, `{"text": "unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"id": 100}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000), WithBuildCache(time.Hour))
+	// Failures aren't cached
+	if _, err := client.GetBuild(context.Background()); err == nil {
+		t.Fatal("GetBuild succeeded against a failing API")
+	}
+	if build, err := client.GetBuild(context.Background()); err != nil || build.ID != 100 {
+		t.Errorf("GetBuild after a failure = %+v, %v, expected 100", build, err)
+	}
+
+	// A zero lifetime turns the cache off
+	if client.With(WithBuildCache(0)).buildCache != nil {
+		t.Error("WithBuildCache(0) left the cache on")
+	}
+}
@@ -0,0 +1,350 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+// ProfessionSchema is the schema version the profession getters request
+// unless given another with WithSchemaVersion. From it on, professions carry
+// the code and skill palette needed to read build template links.
+const ProfessionSchema = "2019-12-19T00:00:00.000Z"
+
+// professionSchema puts ProfessionSchema ahead of options, so a
+// WithSchemaVersion among them still wins
+func professionSchema(options []RequestOption) []RequestOption {
+	return append([]RequestOption{WithSchemaVersion(ProfessionSchema)}, options...)
+}
+
+// Profession codes with extra data in build template links
+const (
+	professionCodeRanger   = 4
+	professionCodeRevenant = 9
+)
+
+// buildTemplateSize is the length of a build template link without the
+// weapons added to links in 2023
+const buildTemplateSize = 44
+
+// BuildTemplate is a decoded build template chat link such as [&DQ...].
+// Skills are palette IDs, which differ from skill IDs; ResolveBuildTemplate
+// looks them up.
+type BuildTemplate struct {
+	Profession      int                       `json:"profession"` // Profession.Code, e.g. 1 for Guardian
+	Specializations [3]TemplateSpecialization `json:"specializations"`
+	Terrestrial     TemplateSkills            `json:"terrestrial"`
+	Aquatic         TemplateSkills            `json:"aquatic"`
+
+	// Ranger pets by pet ID
+	TerrestrialPets [2]int `json:"terrestrial_pets,omitzero"`
+	AquaticPets     [2]int `json:"aquatic_pets,omitzero"`
+
+	// Revenant legends by legend code, and the utility skills of the inactive
+	// legends as palette IDs
+	TerrestrialLegends           [2]int `json:"terrestrial_legends,omitzero"`
+	AquaticLegends               [2]int `json:"aquatic_legends,omitzero"`
+	InactiveTerrestrialUtilities [3]int `json:"inactive_terrestrial_utilities,omitzero"`
+	InactiveAquaticUtilities     [3]int `json:"inactive_aquatic_utilities,omitzero"`
+
+	// Weapons the build may use and the skill IDs of chosen weapon skill
+	// variants, only in links made since the 2023 weapon mastery update
+	Weapons      []int `json:"weapons,omitempty"`
+	WeaponSkills []int `json:"weapon_skills,omitempty"`
+}
+
+// TemplateSpecialization is a specialization line and its major trait choices
+type TemplateSpecialization struct {
+	ID int `json:"id"`
+
+	// Traits are the adept, master and grandmaster choices: 1 for the top
+	// trait, 2 for the middle and 3 for the bottom, 0 when none is chosen
+	Traits [3]int `json:"traits"`
+}
+
+// TemplateSkills are the palette IDs of the slotted heal, utility and elite skills
+type TemplateSkills struct {
+	Heal      int    `json:"heal"`
+	Utilities [3]int `json:"utilities"`
+	Elite     int    `json:"elite"`
+}
+
+// ParseBuildTemplateLink decodes a build template chat link. Surrounding
+// whitespace is ignored.
+func ParseBuildTemplateLink(s string) (BuildTemplate, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[&") || !strings.HasSuffix(s, "]") {
+		return BuildTemplate{}, fmt.Errorf("invalid build template %q: expected [&...]", s)
+	}
+	data, err := base64.StdEncoding.DecodeString(s[2 : len(s)-1])
+	if err != nil {
+		return BuildTemplate{}, fmt.Errorf("invalid build template %q: %w", s, err)
+	}
+	if len(data) == 0 || ChatLinkType(data[0]) != ChatLinkBuildTemplate {
+		return BuildTemplate{}, fmt.Errorf("invalid build template %q: not a build template link", s)
+	}
+	if len(data) < buildTemplateSize {
+		return BuildTemplate{}, fmt.Errorf("invalid build template %q: %d bytes, expected at least %d", s, len(data), buildTemplateSize)
+	}
+
+	b := BuildTemplate{Profession: int(data[1])}
+	for i := range b.Specializations {
+		line := data[2+i*2:]
+		b.Specializations[i] = TemplateSpecialization{
+			ID:     int(line[0]),
+			Traits: [3]int{int(line[1] & 3), int(line[1] >> 2 & 3), int(line[1] >> 4 & 3)},
+		}
+	}
+
+	// Skills alternate between terrestrial and aquatic
+	palette := func(offset int) int { return int(binary.LittleEndian.Uint16(data[offset:])) }
+	for i, slot := range []struct{ terrestrial, aquatic *int }{
+		{&b.Terrestrial.Heal, &b.Aquatic.Heal},
+		{&b.Terrestrial.Utilities[0], &b.Aquatic.Utilities[0]},
+		{&b.Terrestrial.Utilities[1], &b.Aquatic.Utilities[1]},
+		{&b.Terrestrial.Utilities[2], &b.Aquatic.Utilities[2]},
+		{&b.Terrestrial.Elite, &b.Aquatic.Elite},
+	} {
+		*slot.terrestrial = palette(8 + i*4)
+		*slot.aquatic = palette(10 + i*4)
+	}
+
+	// The last 16 bytes depend on the profession
+	switch b.Profession {
+	case professionCodeRanger:
+		b.TerrestrialPets = [2]int{int(data[28]), int(data[29])}
+		b.AquaticPets = [2]int{int(data[30]), int(data[31])}
+	case professionCodeRevenant:
+		b.TerrestrialLegends = [2]int{int(data[28]), int(data[29])}
+		b.AquaticLegends = [2]int{int(data[30]), int(data[31])}
+		for i := range 3 {
+			b.InactiveTerrestrialUtilities[i] = palette(32 + i*2)
+			b.InactiveAquaticUtilities[i] = palette(38 + i*2)
+		}
+	}
+
+	rest := data[buildTemplateSize:]
+	if len(rest) == 0 {
+		return b, nil
+	}
+	count := int(rest[0])
+	if len(rest) < 1+count*2+1 {
+		return BuildTemplate{}, fmt.Errorf("invalid build template %q: weapons cut short", s)
+	}
+	for i := range count {
+		b.Weapons = append(b.Weapons, int(binary.LittleEndian.Uint16(rest[1+i*2:])))
+	}
+	rest = rest[1+count*2:]
+	count = int(rest[0])
+	if len(rest) != 1+count*4 {
+		return BuildTemplate{}, fmt.Errorf("invalid build template %q: weapon skills don't match their count", s)
+	}
+	for i := range count {
+		b.WeaponSkills = append(b.WeaponSkills, int(binary.LittleEndian.Uint32(rest[1+i*4:])))
+	}
+	return b, nil
+}
+
+// String encodes the build template as a chat link
+func (b BuildTemplate) String() string {
+	data := make([]byte, buildTemplateSize)
+	data[0] = byte(ChatLinkBuildTemplate)
+	data[1] = byte(b.Profession)
+	for i, line := range b.Specializations {
+		data[2+i*2] = byte(line.ID)
+		data[3+i*2] = byte(line.Traits[0]&3 | line.Traits[1]&3<<2 | line.Traits[2]&3<<4)
+	}
+	terrestrial := []int{b.Terrestrial.Heal, b.Terrestrial.Utilities[0], b.Terrestrial.Utilities[1], b.Terrestrial.Utilities[2], b.Terrestrial.Elite}
+	aquatic := []int{b.Aquatic.Heal, b.Aquatic.Utilities[0], b.Aquatic.Utilities[1], b.Aquatic.Utilities[2], b.Aquatic.Elite}
+	for i := range terrestrial {
+		binary.LittleEndian.PutUint16(data[8+i*4:], uint16(terrestrial[i]))
+		binary.LittleEndian.PutUint16(data[10+i*4:], uint16(aquatic[i]))
+	}
+
+	switch b.Profession {
+	case professionCodeRanger:
+		copy(data[28:], []byte{byte(b.TerrestrialPets[0]), byte(b.TerrestrialPets[1]), byte(b.AquaticPets[0]), byte(b.AquaticPets[1])})
+	case professionCodeRevenant:
+		copy(data[28:], []byte{byte(b.TerrestrialLegends[0]), byte(b.TerrestrialLegends[1]), byte(b.AquaticLegends[0]), byte(b.AquaticLegends[1])})
+		for i := range 3 {
+			binary.LittleEndian.PutUint16(data[32+i*2:], uint16(b.InactiveTerrestrialUtilities[i]))
+			binary.LittleEndian.PutUint16(data[38+i*2:], uint16(b.InactiveAquaticUtilities[i]))
+		}
+	}
+
+	if len(b.Weapons) > 0 || len(b.WeaponSkills) > 0 {
+		data = append(data, byte(len(b.Weapons)))
+		for _, weapon := range b.Weapons {
+			data = binary.LittleEndian.AppendUint16(data, uint16(weapon))
+		}
+		data = append(data, byte(len(b.WeaponSkills)))
+		for _, skill := range b.WeaponSkills {
+			data = binary.LittleEndian.AppendUint32(data, uint32(skill))
+		}
+	}
+	return "[&" + base64.StdEncoding.EncodeToString(data) + "]"
+}
+
+// SkillForPalette returns the skill ID for a palette ID from a build template
+func (p *Profession) SkillForPalette(paletteID int) (int, bool) {
+	for _, pair := range p.SkillsByPalette {
+		if pair[0] == paletteID {
+			return pair[1], true
+		}
+	}
+	return 0, false
+}
+
+// ResolvedBuild is a build template with its profession, specializations,
+// traits, skills and pets looked up. Anything the template leaves empty, or
+// the API doesn't know, is nil.
+type ResolvedBuild struct {
+	Template        BuildTemplate            `json:"template"`
+	Profession      *Profession              `json:"profession"`
+	Specializations []ResolvedSpecialization `json:"specializations"`
+	Terrestrial     ResolvedSkills           `json:"terrestrial"`
+	Aquatic         ResolvedSkills           `json:"aquatic"`
+
+	TerrestrialPets [2]*Pet `json:"terrestrial_pets,omitzero"`
+	AquaticPets     [2]*Pet `json:"aquatic_pets,omitzero"`
+}
+
+// ResolvedSpecialization is a specialization line with its chosen traits
+type ResolvedSpecialization struct {
+	Specialization *Specialization `json:"specialization"`
+	Traits         [3]*Trait       `json:"traits"` // Adept, master and grandmaster
+}
+
+// ResolvedSkills are the slotted heal, utility and elite skills
+type ResolvedSkills struct {
+	Heal      *Skill    `json:"heal"`
+	Utilities [3]*Skill `json:"utilities"`
+	Elite     *Skill    `json:"elite"`
+}
+
+// ResolveBuildTemplate looks up everything a build template refers to. Skill
+// palette IDs are converted with the profession's SkillsByPalette, and skills
+// come from the skill cache when one is loaded.
+func (c *Client) ResolveBuildTemplate(ctx context.Context, b BuildTemplate) (*ResolvedBuild, error) {
+	professions, err := c.GetAllProfessions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get professions: %w", err)
+	}
+	resolved := &ResolvedBuild{Template: b}
+	for _, profession := range professions {
+		if profession.Code == b.Profession {
+			resolved.Profession = profession
+		}
+	}
+	if resolved.Profession == nil {
+		return nil, fmt.Errorf("unknown profession code %d", b.Profession)
+	}
+
+	var specIDs []int
+	for _, line := range b.Specializations {
+		if line.ID != 0 {
+			specIDs = append(specIDs, line.ID)
+		}
+	}
+	specs := make(map[int]*Specialization)
+	if len(specIDs) > 0 {
+		results, err := c.GetSpecializations(ctx, specIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get specializations: %w", err)
+		}
+		for _, spec := range results {
+			specs[spec.ID] = spec
+		}
+	}
+
+	// Major traits are listed three per tier, top to bottom
+	traitIDs := make([][3]int, len(b.Specializations))
+	var allTraitIDs []int
+	for i, line := range b.Specializations {
+		spec := specs[line.ID]
+		if spec == nil {
+			continue
+		}
+		for tier, choice := range line.Traits {
+			if index := tier*3 + choice - 1; choice > 0 && index < len(spec.MajorTraits) {
+				traitIDs[i][tier] = spec.MajorTraits[index]
+				allTraitIDs = append(allTraitIDs, spec.MajorTraits[index])
+			}
+		}
+	}
+	traits := make(map[int]*Trait)
+	if len(allTraitIDs) > 0 {
+		results, err := c.GetTraits(ctx, allTraitIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get traits: %w", err)
+		}
+		for _, trait := range results {
+			traits[trait.ID] = trait
+		}
+	}
+	for i, line := range b.Specializations {
+		if spec := specs[line.ID]; spec != nil {
+			resolvedLine := ResolvedSpecialization{Specialization: spec}
+			for tier, id := range traitIDs[i] {
+				resolvedLine.Traits[tier] = traits[id]
+			}
+			resolved.Specializations = append(resolved.Specializations, resolvedLine)
+		}
+	}
+
+	var skillIDs []int
+	for _, slots := range []TemplateSkills{b.Terrestrial, b.Aquatic} {
+		for _, paletteID := range []int{slots.Heal, slots.Utilities[0], slots.Utilities[1], slots.Utilities[2], slots.Elite} {
+			if id, found := resolved.Profession.SkillForPalette(paletteID); found && paletteID != 0 {
+				skillIDs = append(skillIDs, id)
+			}
+		}
+	}
+	skills := make(map[int]*Skill)
+	if len(skillIDs) > 0 {
+		results, err := c.GetSkills(ctx, uniqueIDs(skillIDs))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get skills: %w", err)
+		}
+		for _, skill := range results {
+			skills[skill.ID] = skill
+		}
+	}
+	skill := func(paletteID int) *Skill {
+		if id, found := resolved.Profession.SkillForPalette(paletteID); found && paletteID != 0 {
+			return skills[id]
+		}
+		return nil
+	}
+	for _, slots := range []struct {
+		template TemplateSkills
+		resolved *ResolvedSkills
+	}{{b.Terrestrial, &resolved.Terrestrial}, {b.Aquatic, &resolved.Aquatic}} {
+		slots.resolved.Heal = skill(slots.template.Heal)
+		for i, paletteID := range slots.template.Utilities {
+			slots.resolved.Utilities[i] = skill(paletteID)
+		}
+		slots.resolved.Elite = skill(slots.template.Elite)
+	}
+
+	if b.Profession == professionCodeRanger {
+		petIDs := uniqueIDs(append(b.TerrestrialPets[:], b.AquaticPets[:]...))
+		if len(petIDs) > 0 {
+			results, err := c.GetPets(ctx, petIDs)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get pets: %w", err)
+			}
+			pets := make(map[int]*Pet, len(results))
+			for _, pet := range results {
+				pets[pet.ID] = pet
+			}
+			for i := range 2 {
+				resolved.TerrestrialPets[i] = pets[b.TerrestrialPets[i]]
+				resolved.AquaticPets[i] = pets[b.AquaticPets[i]]
+			}
+		}
+	}
+	return resolved, nil
+}
@@ -0,0 +1,152 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// rangerTemplate is a ranger build laid out byte by byte as documented for
+// build template links
+func rangerTemplate() []byte {
+	data := []byte{
+		0x0D, 4, // Header, ranger
+		8, 1 | 2<<2 | 3<<4, // Marksmanship: top, middle, bottom
+		30, 0, // Skirmishing with no traits chosen
+		55, 2 << 4, // Soulbeast: grandmaster middle only
+	}
+	for _, palette := range []uint16{5503, 5505, 5678, 5678, 5679, 5679, 5680, 5680, 5804, 5804} {
+		data = binary.LittleEndian.AppendUint16(data, palette)
+	}
+	profession := make([]byte, 16)
+	copy(profession, []byte{59, 47, 21, 0}) // Terrestrial pets, then aquatic
+	return append(data, profession...)
+}
+
+func TestParseBuildTemplateLink(t *testing.T) {
+	code := "[&" + base64.StdEncoding.EncodeToString(rangerTemplate()) + "]"
+	b, err := ParseBuildTemplateLink(code)
+	if err != nil {
+		t.Fatalf("ParseBuildTemplateLink: %v", err)
+	}
+
+	expected := BuildTemplate{
+		Profession: 4,
+		Specializations: [3]TemplateSpecialization{
+			{ID: 8, Traits: [3]int{1, 2, 3}},
+			{ID: 30},
+			{ID: 55, Traits: [3]int{0, 0, 2}},
+		},
+		Terrestrial:     TemplateSkills{Heal: 5503, Utilities: [3]int{5678, 5679, 5680}, Elite: 5804},
+		Aquatic:         TemplateSkills{Heal: 5505, Utilities: [3]int{5678, 5679, 5680}, Elite: 5804},
+		TerrestrialPets: [2]int{59, 47},
+		AquaticPets:     [2]int{21, 0},
+	}
+	if !reflect.DeepEqual(b, expected) {
+		t.Errorf("ParseBuildTemplateLink = %+v, expected %+v", b, expected)
+	}
+	if encoded := b.String(); encoded != code {
+		t.Errorf("String() = %s, expected %s", encoded, code)
+	}
+}
+
+func TestParseBuildTemplateLinkWeapons(t *testing.T) {
+	data := rangerTemplate()
+	data = append(data, 2, 0x66, 0, 0x5A, 0) // Two weapons
+	data = binary.LittleEndian.AppendUint32(append(data, 1), 12466)
+	code := "[&" + base64.StdEncoding.EncodeToString(data) + "]"
+
+	b, err := ParseBuildTemplateLink(code)
+	if err != nil {
+		t.Fatalf("ParseBuildTemplateLink: %v", err)
+	}
+	if !reflect.DeepEqual(b.Weapons, []int{0x66, 0x5A}) || !reflect.DeepEqual(b.WeaponSkills, []int{12466}) {
+		t.Errorf("weapons = %v, skills = %v", b.Weapons, b.WeaponSkills)
+	}
+	if encoded := b.String(); encoded != code {
+		t.Errorf("String() = %s, expected %s", encoded, code)
+	}
+
+	for _, bad := range [][]byte{data[:len(data)-1], data[:20], append([]byte{0x02}, data[1:]...)} {
+		code := "[&" + base64.StdEncoding.EncodeToString(bad) + "]"
+		if _, err := ParseBuildTemplateLink(code); err == nil {
+			t.Errorf("ParseBuildTemplateLink(%s) succeeded", code)
+		}
+	}
+}
+
+func TestParseBuildTemplateLinkRevenant(t *testing.T) {
+	data := rangerTemplate()
+	data[1] = 9
+	copy(data[28:], []byte{1, 4, 1, 5})
+	binary.LittleEndian.PutUint16(data[32:], 4564)
+	binary.LittleEndian.PutUint16(data[42:], 4572)
+
+	b, err := ParseBuildTemplateLink("[&" + base64.StdEncoding.EncodeToString(data) + "]")
+	if err != nil {
+		t.Fatalf("ParseBuildTemplateLink: %v", err)
+	}
+	if b.TerrestrialLegends != [2]int{1, 4} || b.AquaticLegends != [2]int{1, 5} || b.TerrestrialPets != [2]int{} {
+		t.Errorf("legends = %v %v, pets = %v", b.TerrestrialLegends, b.AquaticLegends, b.TerrestrialPets)
+	}
+	if b.InactiveTerrestrialUtilities != [3]int{4564, 0, 0} || b.InactiveAquaticUtilities != [3]int{0, 0, 4572} {
+		t.Errorf("inactive utilities = %v %v", b.InactiveTerrestrialUtilities, b.InactiveAquaticUtilities)
+	}
+}
+
+func TestResolveBuildTemplate(t *testing.T) {
+	client := newFixtureClient(t, "builds", map[string]string{
+		"/v2/professions":     "professions.json",
+		"/v2/specializations": "specializations.json",
+		"/v2/traits":          "traits.json",
+		"/v2/skills":          "skills.json",
+		"/v2/pets":            "pets.json",
+	})
+	b, err := ParseBuildTemplateLink("[&" + base64.StdEncoding.EncodeToString(rangerTemplate()) + "]")
+	if err != nil {
+		t.Fatalf("ParseBuildTemplateLink: %v", err)
+	}
+
+	build, err := client.ResolveBuildTemplate(context.Background(), b)
+	if err != nil {
+		t.Fatalf("ResolveBuildTemplate: %v", err)
+	}
+	if build.Profession.Name != "Ranger" {
+		t.Errorf("profession = %s, expected Ranger", build.Profession.Name)
+	}
+
+	// Skirmishing isn't in the fixture, so only two lines resolve
+	if len(build.Specializations) != 2 {
+		t.Fatalf("got %d specializations, expected 2", len(build.Specializations))
+	}
+	var traits []string
+	for _, trait := range build.Specializations[0].Traits {
+		traits = append(traits, trait.Name)
+	}
+	if got := strings.Join(traits, ", "); got != "Steady Focus, Predator's Onslaught, Lead the Wind" {
+		t.Errorf("marksmanship traits = %s", got)
+	}
+	soulbeast := build.Specializations[1].Traits
+	if soulbeast[0] != nil || soulbeast[1] != nil || soulbeast[2] == nil || soulbeast[2].Name != "Twice as Vicious" {
+		t.Errorf("soulbeast traits = %v", soulbeast)
+	}
+
+	skills := build.Terrestrial
+	if skills.Heal == nil || skills.Heal.Name != "We Heal As One!" || skills.Elite == nil || skills.Elite.Name != "Strength of the Pack!" {
+		t.Errorf("terrestrial heal and elite = %v, %v", skills.Heal, skills.Elite)
+	}
+	if skills.Utilities[2] == nil || skills.Utilities[2].Name != "Sharpening Stone" {
+		t.Errorf("third utility = %v", skills.Utilities[2])
+	}
+	// The aquatic heal's palette ID maps to a skill missing from the fixture
+	if build.Aquatic.Heal != nil {
+		t.Errorf("aquatic heal = %v, expected none", build.Aquatic.Heal)
+	}
+
+	if build.TerrestrialPets[0] == nil || build.TerrestrialPets[0].Name != "Juvenile Siamoth" || build.AquaticPets[0] != nil {
+		t.Errorf("pets = %v, %v", build.TerrestrialPets, build.AquaticPets)
+	}
+}
@@ -0,0 +1,287 @@
+package gw2api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"path/filepath"
+)
+
+// CacheKind identifies one of the datasets held by a DataCache
+type CacheKind string
+
+const (
+	CacheKindItems        CacheKind = "items"
+	CacheKindSkills       CacheKind = "skills"
+	CacheKindAchievements CacheKind = "achievements"
+	CacheKindRecipes      CacheKind = "recipes"
+	CacheKindMaterials    CacheKind = "materials"
+	CacheKindSkins        CacheKind = "skins"
+	CacheKindVendors      CacheKind = "vendors"
+	CacheKindCurrencies   CacheKind = "currencies"
+)
+
+// CacheKinds lists every kind of data a DataCache holds
+var CacheKinds = []CacheKind{
+	CacheKindItems, CacheKindSkills, CacheKindAchievements, CacheKindRecipes,
+	CacheKindMaterials, CacheKindSkins, CacheKindVendors, CacheKindCurrencies,
+}
+
+// ParseCacheKind returns the cache kind named by s, ignoring case
+func ParseCacheKind(s string) (CacheKind, error) {
+	return parseEnum("cache kind", CacheKinds, s)
+}
+
+// maxIDsPerRequest is the largest ids= list the API accepts in one request
+const maxIDsPerRequest = 200
+
+// FileName returns the JSONL file name the kind is stored under in a data directory
+func (k CacheKind) FileName() string {
+	return string(k) + ".json"
+}
+
+// SetPersistRefreshes controls whether RefreshIDs rewrites the JSONL file in the
+// data directory after updating the in-memory cache
+func (dc *DataCache) SetPersistRefreshes(enabled bool) {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+	dc.persist = enabled
+}
+
+// RefreshIDs refetches the given IDs from the API and replaces them in the cache.
+// When persistence is enabled and the cache was loaded from a directory, the JSONL
+// file for the kind is rewritten atomically afterwards.
+func (dc *DataCache) RefreshIDs(ctx context.Context, client *Client, kind CacheKind, ids []int) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	dc.mutex.RLock()
+	dataDir, persist := dc.dataDir, dc.persist
+	dc.mutex.RUnlock()
+
+	var write func(string) error
+	switch kind {
+	case CacheKindItems:
+		if !dc.items.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		if persist && dc.items.isLean() {
+			// Rewriting the file would permanently drop the trimmed fields
+			return fmt.Errorf("cannot persist a lean %s cache", kind)
+		}
+		items, err := fetchForRefresh[Item](ctx, client, "/v2/items", ids)
+		if err != nil {
+			return err
+		}
+		dc.items.replace(items)
+		write = dc.items.writeToFile
+	case CacheKindSkills:
+		if !dc.skills.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		skills, err := fetchForRefresh[Skill](ctx, client, "/v2/skills", ids)
+		if err != nil {
+			return err
+		}
+		dc.skills.replace(skills)
+		write = dc.skills.writeToFile
+	case CacheKindAchievements:
+		if !dc.achievements.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		achievements, err := fetchForRefresh[Achievement](ctx, client, "/v2/achievements", ids)
+		if err != nil {
+			return err
+		}
+		dc.achievements.replace(achievements)
+		write = dc.achievements.writeToFile
+	case CacheKindRecipes:
+		if !dc.recipes.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		recipes, err := fetchForRefresh[RecipeDetail](ctx, client, "/v2/recipes", ids)
+		if err != nil {
+			return err
+		}
+		dc.recipes.replace(recipes)
+		write = dc.recipes.writeOfficialToFile
+	case CacheKindMaterials:
+		if !dc.materials.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		materials, err := fetchForRefresh[Material](ctx, client, "/v2/materials", ids)
+		if err != nil {
+			return err
+		}
+		dc.materials.replace(materials)
+		write = dc.materials.writeToFile
+	case CacheKindSkins:
+		if !dc.skins.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		skins, err := fetchForRefresh[SkinDetail](ctx, client, "/v2/skins", ids)
+		if err != nil {
+			return err
+		}
+		dc.skins.replace(skins)
+		write = dc.skins.writeToFile
+	case CacheKindVendors:
+		if !dc.vendors.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		vendors, err := fetchForRefresh[Vendor](ctx, client, "/v2/vendors", ids)
+		if err != nil {
+			return err
+		}
+		dc.vendors.replace(vendors)
+		write = dc.vendors.writeToFile
+	case CacheKindCurrencies:
+		if !dc.currencies.IsLoaded() {
+			return fmt.Errorf("%s cache is not loaded", kind)
+		}
+		currencies, err := fetchForRefresh[Currency](ctx, client, "/v2/currencies", ids)
+		if err != nil {
+			return err
+		}
+		dc.currencies.replace(currencies)
+		write = dc.currencies.writeToFile
+	default:
+		return fmt.Errorf("unknown cache kind: %s", kind)
+	}
+
+	if !persist || dataDir == "" {
+		return nil
+	}
+
+	// Keep the file compressed if it was
+	filePath, found := findDataFile(dataDir, kind.FileName())
+	if !found {
+		filePath = filepath.Join(dataDir, kind.FileName())
+	}
+	if err := write(filePath); err != nil {
+		return fmt.Errorf("failed to persist %s cache: %w", kind, err)
+	}
+	return nil
+}
+
+// fetchForRefresh fetches IDs straight from the API, bypassing the data cache
+func fetchForRefresh[T any](ctx context.Context, c *Client, endpoint string, ids []int) ([]*T, error) {
+	// A refresh replaces what it fetched, so a partial one is a failure
+	results, err := GetByIDs[T](ctx, c, endpoint, ids)
+	if err != nil {
+		return nil, err
+	}
+	ptrs := make([]*T, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// writeJSONLAtomic writes values one per line to a temp file and renames it over
+// filePath, so a crash mid-write never leaves a truncated dataset behind
+func writeJSONLAtomic[T any](filePath string, values []*T) error {
+	writer, err := CreateJSONL(filePath)
+	if err != nil {
+		return err
+	}
+	for _, value := range values {
+		if err := writer.Encode(value); err != nil {
+			writer.Abort()
+			return fmt.Errorf("failed to encode entry: %w", err)
+		}
+	}
+	return writer.Close()
+}
+
+// RefreshCachedItem refetches a single item from the API and updates the data cache
+func (c *Client) RefreshCachedItem(ctx context.Context, id int) (*Item, error) {
+	if c.dataCache == nil {
+		return nil, fmt.Errorf("refreshing items requires data cache to be loaded")
+	}
+
+	if err := c.dataCache.RefreshIDs(ctx, c, CacheKindItems, []int{id}); err != nil {
+		return nil, err
+	}
+
+	item, found := c.dataCache.GetItemCache().GetByID(id)
+	if !found {
+		return nil, fmt.Errorf("item %d not returned by the API", id)
+	}
+	return item, nil
+}
+
+// StalenessReport summarizes how many sampled cached items differ from the live API
+type StalenessReport struct {
+	Sampled    int   // Number of cached items compared
+	Stale      int   // Number of items whose API data differs from the cache
+	Missing    int   // Number of items the API no longer returns
+	StaleIDs   []int // IDs of the stale items, suitable for RefreshIDs
+	MissingIDs []int // IDs of the missing items
+}
+
+// StaleFraction returns the share of sampled items that were stale or missing
+func (r StalenessReport) StaleFraction() float64 {
+	if r.Sampled == 0 {
+		return 0
+	}
+	return float64(r.Stale+r.Missing) / float64(r.Sampled)
+}
+
+// FindStaleCachedItems samples up to sampleSize random cached items, refetches them
+// and reports how many differ. It does not modify the cache.
+func (c *Client) FindStaleCachedItems(ctx context.Context, sampleSize int) (*StalenessReport, error) {
+	if c.dataCache == nil || !c.dataCache.GetItemCache().IsLoaded() {
+		return nil, fmt.Errorf("staleness check requires data cache to be loaded")
+	}
+
+	// Read-only comparison, so skip the deep copies
+	cached := c.dataCache.GetItemCache().GetAllRef()
+	rand.Shuffle(len(cached), func(i, j int) {
+		cached[i], cached[j] = cached[j], cached[i]
+	})
+	if sampleSize > 0 && sampleSize < len(cached) {
+		cached = cached[:sampleSize]
+	}
+
+	ids := make([]int, len(cached))
+	for i, item := range cached {
+		ids[i] = item.ID
+	}
+
+	// The API answers 404 when none of the sample is left, which makes every
+	// sampled item missing rather than the check a failure
+	fresh, err := fetchForRefresh[Item](ctx, c, "/v2/items", ids)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	freshByID := make(map[int]*Item, len(fresh))
+	for _, item := range fresh {
+		freshByID[item.ID] = item
+	}
+
+	report := &StalenessReport{Sampled: len(cached)}
+	for _, item := range cached {
+		current, found := freshByID[item.ID]
+		if !found {
+			report.Missing++
+			report.MissingIDs = append(report.MissingIDs, item.ID)
+			continue
+		}
+
+		// Compare encoded forms so omitempty round-tripping doesn't count as a change
+		cachedJSON, _ := json.Marshal(item)
+		currentJSON, _ := json.Marshal(current)
+		if !bytes.Equal(cachedJSON, currentJSON) {
+			report.Stale++
+			report.StaleIDs = append(report.StaleIDs, item.ID)
+		}
+	}
+
+	return report, nil
+}
@@ -0,0 +1,221 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newRefreshClient returns a client whose /v2/items answers with the given
+// entries by ID, and whose data cache is loaded from dir
+func newRefreshClient(t *testing.T, dir string, upstream map[int]string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/items" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		var found []string
+		for part := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+			id, _ := strconv.Atoi(part)
+			if item, ok := upstream[id]; ok {
+				found = append(found, item)
+			}
+		}
+		if len(found) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "all ids provided are invalid"}`))
+			return
+		}
+		w.Write([]byte("[" + strings.Join(found, ",") + "]"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000), WithDataCache(dir))
+	if err := client.DataCacheError(); err != nil {
+		t.Fatalf("WithDataCache: %v", err)
+	}
+	return client
+}
+
+// writeItemsFile writes an items.json holding the given lines to a new data directory
+func writeItemsFile(t *testing.T, lines ...string) string {
+	t.Helper()
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// checkNoTempFiles fails if a write left a temp file behind in dir
+func checkNoTempFiles(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("temp file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestRefreshIDs(t *testing.T) {
+	dir := writeItemsFile(t,
+		`{"id": 1, "name": "Old 1"}`,
+		`{"id": 2, "name": "Old 2"}`,
+	)
+	client := newRefreshClient(t, dir, map[int]string{
+		1: `{"id": 1, "name": "New 1"}`,
+		2: `{"id": 2, "name": "New 2"}`,
+	})
+	dc := client.DataCache()
+	ctx := context.Background()
+	filePath := filepath.Join(dir, "items.json")
+
+	// Without persistence only the in-memory cache changes
+	if err := dc.RefreshIDs(ctx, client, CacheKindItems, []int{1}); err != nil {
+		t.Fatalf("RefreshIDs: %v", err)
+	}
+	if item, _ := dc.GetItemCache().GetByID(1); item == nil || item.Name != "New 1" {
+		t.Errorf("item 1 = %+v, expected the refreshed name", item)
+	}
+	if item, _ := dc.GetItemCache().GetByID(2); item == nil || item.Name != "Old 2" {
+		t.Errorf("item 2 = %+v, expected it untouched", item)
+	}
+	if data, _ := os.ReadFile(filePath); strings.Contains(string(data), "New 1") {
+		t.Error("the file was rewritten with persistence off")
+	}
+
+	// With it, the file is replaced and loads back the same
+	dc.SetPersistRefreshes(true)
+	if err := dc.RefreshIDs(ctx, client, CacheKindItems, []int{2}); err != nil {
+		t.Fatalf("RefreshIDs with persistence: %v", err)
+	}
+	checkNoTempFiles(t, dir)
+	reloaded := NewDataCache()
+	if err := reloaded.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	var names []string
+	for _, id := range []int{1, 2} {
+		if item, found := reloaded.GetItemCache().GetByID(id); found {
+			names = append(names, item.Name)
+		}
+	}
+	if !slices.Equal(names, []string{"New 1", "New 2"}) {
+		t.Errorf("reloaded names = %v, expected both refreshed", names)
+	}
+
+	// An ID the API doesn't know fails the refresh and leaves the cache as it was
+	if err := dc.RefreshIDs(ctx, client, CacheKindItems, []int{3}); !errors.Is(err, ErrNotFound) {
+		t.Errorf("refreshing an unknown ID: err = %v, expected ErrNotFound", err)
+	}
+	if err := dc.RefreshIDs(ctx, client, CacheKindSkills, []int{1}); err == nil {
+		t.Error("refreshing a kind that isn't loaded should fail")
+	}
+}
+
+func TestRefreshCachedItem(t *testing.T) {
+	dir := writeItemsFile(t, `{"id": 1, "name": "Old 1"}`)
+	client := newRefreshClient(t, dir, map[int]string{1: `{"id": 1, "name": "New 1"}`})
+	ctx := context.Background()
+
+	item, err := client.RefreshCachedItem(ctx, 1)
+	if err != nil {
+		t.Fatalf("RefreshCachedItem: %v", err)
+	}
+	if item.Name != "New 1" {
+		t.Errorf("refreshed item = %+v", item)
+	}
+	if _, err := client.RefreshCachedItem(ctx, 2); err == nil {
+		t.Error("refreshing an unknown item should fail")
+	}
+}
+
+func TestWriteJSONLAtomic(t *testing.T) {
+	dir := t.TempDir()
+	filePath := filepath.Join(dir, "values.json")
+	one, two := 1.0, 2.0
+	if err := writeJSONLAtomic(filePath, []*float64{&one, &two}); err != nil {
+		t.Fatalf("writeJSONLAtomic: %v", err)
+	}
+	if data, _ := os.ReadFile(filePath); string(data) != "1\n2\n" {
+		t.Errorf("file = %q, expected one value per line", data)
+	}
+
+	// A value that can't be encoded leaves the file as it was
+	nan := math.NaN()
+	if err := writeJSONLAtomic(filePath, []*float64{&two, &nan}); err == nil {
+		t.Fatal("encoding NaN should fail")
+	}
+	if data, _ := os.ReadFile(filePath); string(data) != "1\n2\n" {
+		t.Errorf("file after a failed write = %q, expected it untouched", data)
+	}
+	checkNoTempFiles(t, dir)
+}
+
+func TestFindStaleCachedItems(t *testing.T) {
+	dir := writeItemsFile(t,
+		`{"id": 1, "name": "Same"}`,
+		`{"id": 2, "name": "Old 2"}`,
+		`{"id": 3, "name": "Gone"}`,
+	)
+	client := newRefreshClient(t, dir, map[int]string{
+		1: `{"id": 1, "name": "Same"}`,
+		2: `{"id": 2, "name": "New 2"}`,
+	})
+
+	report, err := client.FindStaleCachedItems(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("FindStaleCachedItems: %v", err)
+	}
+	if report.Sampled != 3 || report.Stale != 1 || report.Missing != 1 {
+		t.Errorf("report = %+v, expected 3 sampled, 1 stale and 1 missing", report)
+	}
+	if !slices.Equal(report.StaleIDs, []int{2}) || !slices.Equal(report.MissingIDs, []int{3}) {
+		t.Errorf("stale IDs %v and missing IDs %v, expected [2] and [3]", report.StaleIDs, report.MissingIDs)
+	}
+	if fraction := report.StaleFraction(); math.Abs(fraction-2.0/3) > 1e-9 {
+		t.Errorf("StaleFraction = %v, expected 2/3", fraction)
+	}
+	if item, _ := client.DataCache().GetItemCache().GetByID(2); item.Name != "Old 2" {
+		t.Error("the staleness check changed the cache")
+	}
+
+	// A sample of one is one item
+	if report, err := client.FindStaleCachedItems(context.Background(), 1); err != nil || report.Sampled != 1 {
+		t.Errorf("sample of 1: %+v, %v", report, err)
+	}
+}
+
+func TestFindStaleCachedItemsAllMissing(t *testing.T) {
+	dir := writeItemsFile(t,
+		`{"id": 1, "name": "Gone 1"}`,
+		`{"id": 2, "name": "Gone 2"}`,
+	)
+	client := newRefreshClient(t, dir, nil)
+
+	// The API answers 404 for the whole sample, which is no failure
+	report, err := client.FindStaleCachedItems(context.Background(), 0)
+	if err != nil {
+		t.Fatalf("FindStaleCachedItems: %v", err)
+	}
+	slices.Sort(report.MissingIDs)
+	if report.Sampled != 2 || report.Missing != 2 || !slices.Equal(report.MissingIDs, []int{1, 2}) {
+		t.Errorf("report = %+v, expected both items missing", report)
+	}
+	if report.StaleFraction() != 1 {
+		t.Errorf("StaleFraction = %v, expected 1", report.StaleFraction())
+	}
+}
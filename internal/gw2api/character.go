@@ -1,6 +1,12 @@
 package gw2api
 
-import "time"
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
 
 // Character represents a character name
 type Character string
@@ -41,7 +47,7 @@ type CharacterCore struct {
 	Profession   string    `json:"profession"`
 	Level        int       `json:"level"`
 	Guild        string    `json:"guild,omitempty"`
-	Age          int       `json:"age"`
+	Age          Playtime  `json:"age"` // Time played, not time since Created
 	LastModified time.Time `json:"last_modified,omitempty"`
 	Created      time.Time `json:"created"`
 	Deaths       int       `json:"deaths"`
@@ -99,6 +105,7 @@ type CharacterEquipmentStats struct {
 type CharacterEquipmentStatsAttributes struct {
 	Power              int `json:"Power,omitempty"`
 	Precision          int `json:"Precision,omitempty"`
+	CritDamage         int `json:"CritDamage,omitempty"` // Ferocity
 	Toughness          int `json:"Toughness,omitempty"`
 	Vitality           int `json:"Vitality,omitempty"`
 	ConditionDamage    int `json:"ConditionDamage,omitempty"`
@@ -149,7 +156,7 @@ type CharacterInventorySlot struct {
 	Count     int       `json:"count"`
 	Binding   string    `json:"binding,omitempty"`
 	BoundTo   string    `json:"bound_to,omitempty"`
-	Stats     *ItemStat `json:"stats,omitempty"`
+	Stats     *ItemInstanceStats `json:"stats,omitempty"`
 }
 
 // CharacterQuest represents character quests
@@ -216,4 +223,167 @@ type CharacterTraining struct {
 	ID    int  `json:"id"`
 	Spent int  `json:"spent"`
 	Done  bool `json:"done"`
-}
\ No newline at end of file
+}
+
+// GetCharacterNames returns all character names.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters
+// Scopes: characters
+func (c *Client) GetCharacterNames(ctx context.Context, options ...RequestOption) ([]string, error) {
+	// Custom implementation that doesn't add ids=all
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, _, err := c.get(ctx, "/v2/characters", opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []string
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetCharacters returns all character details.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters
+// Scopes: characters
+func (c *Client) GetCharacters(ctx context.Context, options ...RequestOption) ([]Character, error) {
+	return GetAll[Character](ctx, c, "/v2/characters", options...)
+}
+
+// GetCharacterBackstory returns character backstory.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/backstory
+// Scopes: characters
+func (c *Client) GetCharacterBackstory(ctx context.Context, name string, options ...RequestOption) (*CharacterBackstory, error) {
+	return GetSingle[CharacterBackstory](ctx, c, "/v2/characters/"+name+"/backstory", options...)
+}
+
+// GetCharacterBuildTabs returns character build tabs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/buildtabs
+// Scopes: characters, builds
+func (c *Client) GetCharacterBuildTabs(ctx context.Context, name string, options ...RequestOption) ([]CharacterBuildTab, error) {
+	return GetAll[CharacterBuildTab](ctx, c, "/v2/characters/"+name+"/buildtabs", options...)
+}
+
+// GetCharacterBuildTab returns one build tab, numbered from 1.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/buildtabs
+// Scopes: characters, builds
+func (c *Client) GetCharacterBuildTab(ctx context.Context, name string, tab int, options ...RequestOption) (*CharacterBuildTab, error) {
+	return GetSingle[CharacterBuildTab](ctx, c, "/v2/characters/"+name+"/buildtabs/"+strconv.Itoa(tab), options...)
+}
+
+// GetCharacterBuildTabActive returns active build tab.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/buildtabs/active
+// Scopes: characters, builds
+func (c *Client) GetCharacterBuildTabActive(ctx context.Context, name string, options ...RequestOption) (*CharacterBuildTabActive, error) {
+	return GetSingle[CharacterBuildTabActive](ctx, c, "/v2/characters/"+name+"/buildtabs/active", options...)
+}
+
+// GetCharacterCore returns core character information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/core
+// Scopes: characters
+func (c *Client) GetCharacterCore(ctx context.Context, name string, options ...RequestOption) (*CharacterCore, error) {
+	return GetSingle[CharacterCore](ctx, c, "/v2/characters/"+name+"/core", options...)
+}
+
+// GetCharacterCrafting returns character crafting disciplines.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/crafting
+// Scopes: characters
+func (c *Client) GetCharacterCrafting(ctx context.Context, name string, options ...RequestOption) ([]CharacterCrafting, error) {
+	return GetAll[CharacterCrafting](ctx, c, "/v2/characters/"+name+"/crafting", options...)
+}
+
+// GetCharacterDungeons returns character dungeon progress.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/dungeons
+// Scopes: characters, progression
+func (c *Client) GetCharacterDungeons(ctx context.Context, name string, options ...RequestOption) ([]CharacterDungeon, error) {
+	return GetAll[CharacterDungeon](ctx, c, "/v2/characters/"+name+"/dungeons", options...)
+}
+
+// GetCharacterEquipment returns character equipment.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/equipment
+// Scopes: characters, inventories
+func (c *Client) GetCharacterEquipment(ctx context.Context, name string, options ...RequestOption) ([]CharacterEquipment, error) {
+	return GetAll[CharacterEquipment](ctx, c, "/v2/characters/"+name+"/equipment", options...)
+}
+
+// GetCharacterEquipmentTabs returns character equipment tabs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/equipmenttabs
+// Scopes: characters, inventories
+func (c *Client) GetCharacterEquipmentTabs(ctx context.Context, name string, options ...RequestOption) ([]CharacterEquipmentTab, error) {
+	return GetAll[CharacterEquipmentTab](ctx, c, "/v2/characters/"+name+"/equipmenttabs", options...)
+}
+
+// GetCharacterEquipmentTab returns one equipment tab, numbered from 1.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/equipmenttabs
+// Scopes: characters, inventories
+func (c *Client) GetCharacterEquipmentTab(ctx context.Context, name string, tab int, options ...RequestOption) (*CharacterEquipmentTab, error) {
+	return GetSingle[CharacterEquipmentTab](ctx, c, "/v2/characters/"+name+"/equipmenttabs/"+strconv.Itoa(tab), options...)
+}
+
+// GetCharacterEquipmentTabActive returns active equipment tab.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/equipmenttabs/active
+// Scopes: characters, inventories
+func (c *Client) GetCharacterEquipmentTabActive(ctx context.Context, name string, options ...RequestOption) (*CharacterEquipmentTabActive, error) {
+	return GetSingle[CharacterEquipmentTabActive](ctx, c, "/v2/characters/"+name+"/equipmenttabs/active", options...)
+}
+
+// GetCharacterHeroPoints returns character hero points.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/heropoints
+// Scopes: characters, progression
+func (c *Client) GetCharacterHeroPoints(ctx context.Context, name string, options ...RequestOption) ([]CharacterHeroPoint, error) {
+	return GetAll[CharacterHeroPoint](ctx, c, "/v2/characters/"+name+"/heropoints", options...)
+}
+
+// GetCharacterInventory returns character inventory.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/inventory
+// Scopes: characters, inventories
+func (c *Client) GetCharacterInventory(ctx context.Context, name string, options ...RequestOption) (*CharacterInventory, error) {
+	return GetSingle[CharacterInventory](ctx, c, "/v2/characters/"+name+"/inventory", options...)
+}
+
+// GetCharacterQuests returns character quests.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/quests
+// Scopes: characters, progression
+func (c *Client) GetCharacterQuests(ctx context.Context, name string, options ...RequestOption) ([]CharacterQuest, error) {
+	return GetAll[CharacterQuest](ctx, c, "/v2/characters/"+name+"/quests", options...)
+}
+
+// GetCharacterRecipes returns character recipes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/recipes
+// Scopes: characters, unlocks
+func (c *Client) GetCharacterRecipes(ctx context.Context, name string, options ...RequestOption) (*CharacterRecipe, error) {
+	return GetSingle[CharacterRecipe](ctx, c, "/v2/characters/"+name+"/recipes", options...)
+}
+
+// GetCharacterSAB returns character SAB progress.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/sab
+// Scopes: characters, progression
+func (c *Client) GetCharacterSAB(ctx context.Context, name string, options ...RequestOption) (*CharacterSAB, error) {
+	return GetSingle[CharacterSAB](ctx, c, "/v2/characters/"+name+"/sab", options...)
+}
+
+// GetCharacterSkills returns character skills.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/skills
+// Scopes: characters, builds
+func (c *Client) GetCharacterSkills(ctx context.Context, name string, options ...RequestOption) (*CharacterSkills, error) {
+	return GetSingle[CharacterSkills](ctx, c, "/v2/characters/"+name+"/skills", options...)
+}
+
+// GetCharacterSpecializations returns character specializations.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/specializations
+// Scopes: characters, builds
+func (c *Client) GetCharacterSpecializations(ctx context.Context, name string, options ...RequestOption) ([]CharacterSpecialization, error) {
+	return GetAll[CharacterSpecialization](ctx, c, "/v2/characters/"+name+"/specializations", options...)
+}
+
+// GetCharacterTraining returns character training.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters/(name)/training
+// Scopes: characters, builds
+func (c *Client) GetCharacterTraining(ctx context.Context, name string, options ...RequestOption) ([]CharacterTraining, error) {
+	return GetAll[CharacterTraining](ctx, c, "/v2/characters/"+name+"/training", options...)
+}
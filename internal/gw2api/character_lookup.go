@@ -0,0 +1,102 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrCharacterNotFound matches a *CharacterNotFoundError, so callers can use
+// errors.Is without needing the name
+var ErrCharacterNotFound = errors.New("character not found")
+
+// CharacterNotFoundError reports that the API has no character called Name on
+// the account, usually because it was deleted or renamed since its name was
+// listed. It also matches ErrNotFound.
+type CharacterNotFoundError struct {
+	Name string
+}
+
+func (e *CharacterNotFoundError) Error() string {
+	return fmt.Sprintf("character %q no longer exists on this account", e.Name)
+}
+
+// Is reports whether target is ErrCharacterNotFound or ErrNotFound
+func (e *CharacterNotFoundError) Is(target error) bool {
+	return target == ErrCharacterNotFound || target == ErrNotFound
+}
+
+// characterNotFoundText is the error text the API returns for a character name
+// it doesn't know, see testdata/characters/no_such_character.json
+const characterNotFoundText = "no such character"
+
+// characterError turns the API's 404 for an unknown character under
+// /v2/characters/{name} into a *CharacterNotFoundError, and returns any other
+// error unchanged
+func characterError(endpoint string, err error) error {
+	rest, found := strings.CutPrefix(endpoint, "/v2/characters/")
+	if !found || rest == "" {
+		return err
+	}
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusNotFound ||
+		!strings.EqualFold(strings.TrimSpace(httpErr.Message), characterNotFoundText) {
+		return err
+	}
+	name, _, _ := strings.Cut(rest, "/")
+	return &CharacterNotFoundError{Name: name}
+}
+
+// GetCharactersByNames returns the core details of the named characters in
+// one request, in the order of names. Names the API doesn't know, such as
+// characters deleted since the names were listed, are left out and returned
+// as missing instead of failing the whole batch.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/characters
+// Scopes: characters
+func (c *Client) GetCharactersByNames(ctx context.Context, names []string, options ...RequestOption) ([]CharacterCore, []string, error) {
+	if len(names) == 0 {
+		return nil, nil, nil
+	}
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+	WithParam("ids", strings.Join(names, ","))(opts)
+
+	data, _, err := c.get(ctx, "/v2/characters", opts)
+	if errors.Is(err, ErrNotFound) {
+		// The API answers 404 when none of the names exist
+		return nil, names, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var characters []CharacterCore
+	if err := json.Unmarshal(data, &characters); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	byName := make(map[string]CharacterCore, len(characters))
+	for _, character := range characters {
+		byName[character.Name] = character
+	}
+
+	var found []CharacterCore
+	var missing []string
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		if character, ok := byName[name]; ok {
+			found = append(found, character)
+		} else {
+			missing = append(missing, name)
+		}
+	}
+	return found, missing, nil
+}
@@ -0,0 +1,93 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// newCharactersServer answers like the API for an account with one character,
+// Aldric Stormhand, using the error bodies the API returns for other names
+func newCharactersServer(t *testing.T) *Client {
+	t.Helper()
+	serve := func(w http.ResponseWriter, status int, fixture string) {
+		data, err := os.ReadFile(filepath.Join("testdata", "characters", fixture))
+		if err != nil {
+			t.Errorf("reading fixture: %v", err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write(data)
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/characters":
+			if !strings.Contains(r.URL.Query().Get("ids"), "Aldric Stormhand") {
+				serve(w, http.StatusNotFound, "all_ids_invalid.json")
+			} else if r.URL.Query().Get("ids") == "Aldric Stormhand" {
+				serve(w, http.StatusOK, "characters.json")
+			} else {
+				serve(w, http.StatusPartialContent, "characters.json")
+			}
+		case strings.HasPrefix(r.URL.Path, "/v2/characters/Aldric Stormhand/"):
+			w.Write([]byte(`{"name": "Aldric Stormhand", "level": 80}`))
+		default:
+			serve(w, http.StatusNotFound, "no_such_character.json")
+		}
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithRetries(0), WithRateLimit(1000))
+}
+
+func TestCharacterNotFound(t *testing.T) {
+	client := newCharactersServer(t)
+	ctx := context.Background()
+
+	if _, err := client.GetCharacterCore(ctx, "Aldric Stormhand"); err != nil {
+		t.Fatalf("GetCharacterCore: %v", err)
+	}
+
+	_, err := client.GetCharacterInventory(ctx, "Deleted Alt")
+	var notFound *CharacterNotFoundError
+	if !errors.As(err, &notFound) || notFound.Name != "Deleted Alt" {
+		t.Fatalf("GetCharacterInventory(Deleted Alt) = %v, expected a CharacterNotFoundError", err)
+	}
+	if !errors.Is(err, ErrCharacterNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("%v should match ErrCharacterNotFound and ErrNotFound", err)
+	}
+	if !strings.Contains(err.Error(), `"Deleted Alt" no longer exists`) {
+		t.Errorf("error = %q, expected it to name the character", err)
+	}
+
+	// Other 404s keep their API error
+	if _, err := client.GetItems(ctx, []int{1}); errors.Is(err, ErrCharacterNotFound) || !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetItems = %v, expected a plain not found error", err)
+	}
+}
+
+func TestGetCharactersByNames(t *testing.T) {
+	client := newCharactersServer(t)
+	ctx := context.Background()
+
+	characters, missing, err := client.GetCharactersByNames(ctx, []string{"Deleted Alt", "Aldric Stormhand", "Deleted Alt"})
+	if err != nil {
+		t.Fatalf("GetCharactersByNames: %v", err)
+	}
+	if len(characters) != 1 || characters[0].Name != "Aldric Stormhand" || characters[0].Profession != "Guardian" {
+		t.Errorf("characters = %+v, expected Aldric Stormhand", characters)
+	}
+	if len(missing) != 1 || missing[0] != "Deleted Alt" {
+		t.Errorf("missing = %v, expected [Deleted Alt]", missing)
+	}
+
+	// When every name is gone the API fails the request, which is still reported as missing names
+	characters, missing, err = client.GetCharactersByNames(ctx, []string{"Deleted Alt", "Other Alt"})
+	if err != nil || len(characters) != 0 || len(missing) != 2 {
+		t.Errorf("GetCharactersByNames(deleted) = %+v, %v, %v, expected both names missing", characters, missing, err)
+	}
+}
@@ -0,0 +1,194 @@
+package gw2api
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ChatLinkType is the header byte identifying what a chat link refers to
+type ChatLinkType byte
+
+const (
+	ChatLinkCoin   ChatLinkType = 0x01
+	ChatLinkItem   ChatLinkType = 0x02
+	ChatLinkMap    ChatLinkType = 0x04 // Waypoints, points of interest and vistas
+	ChatLinkSkill  ChatLinkType = 0x06
+	ChatLinkTrait  ChatLinkType = 0x07
+	ChatLinkRecipe ChatLinkType = 0x09
+	ChatLinkSkin   ChatLinkType = 0x0A
+	ChatLinkOutfit ChatLinkType = 0x0B
+
+	ChatLinkBuildTemplate ChatLinkType = 0x0D // Decoded with ParseBuildTemplateLink
+)
+
+// Item link flag bits marking which optional components follow the item ID
+const (
+	chatLinkFlagSkin     = 0x80
+	chatLinkFlagUpgrade1 = 0x40
+	chatLinkFlagUpgrade2 = 0x20
+)
+
+func (t ChatLinkType) String() string {
+	switch t {
+	case ChatLinkCoin:
+		return "coin"
+	case ChatLinkItem:
+		return "item"
+	case ChatLinkMap:
+		return "map"
+	case ChatLinkSkill:
+		return "skill"
+	case ChatLinkTrait:
+		return "trait"
+	case ChatLinkRecipe:
+		return "recipe"
+	case ChatLinkSkin:
+		return "skin"
+	case ChatLinkOutfit:
+		return "outfit"
+	case ChatLinkBuildTemplate:
+		return "build template"
+	default:
+		return fmt.Sprintf("0x%02x", byte(t))
+	}
+}
+
+// ChatLink is a decoded in-game chat code such as [&AgEAWgAA].
+// ID is the item, recipe, skin, map point, ... ID, or the amount in copper for coin links.
+// Quantity, SkinID and the upgrades are only used by item links.
+type ChatLink struct {
+	Type     ChatLinkType
+	ID       int
+	Quantity int
+	SkinID   int
+	Upgrade1 int
+	Upgrade2 int
+}
+
+// ParseChatLink decodes a chat code. Surrounding whitespace is ignored.
+func ParseChatLink(s string) (ChatLink, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[&") || !strings.HasSuffix(s, "]") {
+		return ChatLink{}, fmt.Errorf("invalid chat link %q: expected [&...]", s)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(s[2 : len(s)-1])
+	if err != nil {
+		return ChatLink{}, fmt.Errorf("invalid chat link %q: %w", s, err)
+	}
+	if len(data) == 0 {
+		return ChatLink{}, fmt.Errorf("invalid chat link %q: empty", s)
+	}
+
+	link := ChatLink{Type: ChatLinkType(data[0])}
+	body := data[1:]
+
+	switch link.Type {
+	case ChatLinkItem:
+		// Quantity, 24-bit item ID, flags, then a 32-bit ID per flagged component
+		if len(body) < 5 {
+			return ChatLink{}, fmt.Errorf("invalid chat link %q: item link too short", s)
+		}
+		link.Quantity = int(body[0])
+		link.ID = int(uint24(body[1:4]))
+		flags := body[4]
+		body = body[5:]
+
+		for _, component := range []struct {
+			flag  byte
+			value *int
+		}{
+			{chatLinkFlagSkin, &link.SkinID},
+			{chatLinkFlagUpgrade1, &link.Upgrade1},
+			{chatLinkFlagUpgrade2, &link.Upgrade2},
+		} {
+			if flags&component.flag == 0 {
+				continue
+			}
+			if len(body) < 4 {
+				return ChatLink{}, fmt.Errorf("invalid chat link %q: item link missing flagged component", s)
+			}
+			*component.value = int(binary.LittleEndian.Uint32(body))
+			body = body[4:]
+		}
+	case ChatLinkCoin, ChatLinkMap, ChatLinkSkill, ChatLinkTrait, ChatLinkRecipe, ChatLinkSkin, ChatLinkOutfit:
+		if len(body) < 4 {
+			return ChatLink{}, fmt.Errorf("invalid chat link %q: %s link too short", s, link.Type)
+		}
+		link.ID = int(binary.LittleEndian.Uint32(body))
+		body = body[4:]
+	default:
+		return ChatLink{}, fmt.Errorf("unsupported chat link type %s in %q", link.Type, s)
+	}
+
+	if len(body) != 0 {
+		return ChatLink{}, fmt.Errorf("invalid chat link %q: %d unexpected trailing bytes", s, len(body))
+	}
+	return link, nil
+}
+
+// String encodes the link as a chat code. Item quantities are clamped to 1-255.
+func (l ChatLink) String() string {
+	data := []byte{byte(l.Type)}
+
+	if l.Type == ChatLinkItem {
+		var flags byte
+		var components []byte
+		for _, component := range []struct {
+			flag  byte
+			value int
+		}{
+			{chatLinkFlagSkin, l.SkinID},
+			{chatLinkFlagUpgrade1, l.Upgrade1},
+			{chatLinkFlagUpgrade2, l.Upgrade2},
+		} {
+			if component.value != 0 {
+				flags |= component.flag
+				components = binary.LittleEndian.AppendUint32(components, uint32(component.value))
+			}
+		}
+
+		data = append(data, byte(min(max(l.Quantity, 1), 255)))
+		data = append(data, byte(l.ID), byte(l.ID>>8), byte(l.ID>>16), flags)
+		data = append(data, components...)
+	} else {
+		data = binary.LittleEndian.AppendUint32(data, uint32(l.ID))
+	}
+
+	return "[&" + base64.StdEncoding.EncodeToString(data) + "]"
+}
+
+// ChatLinkFor returns the chat code for a stack of this item
+func (i *Item) ChatLinkFor(quantity int) string {
+	return ChatLink{Type: ChatLinkItem, ID: i.ID, Quantity: quantity}.String()
+}
+
+// ParseIDOrChatLink accepts either a numeric ID or a chat link of the expected
+// type and returns the ID it refers to
+func ParseIDOrChatLink(s string, want ChatLinkType) (int, error) {
+	s = strings.TrimSpace(s)
+	if !strings.HasPrefix(s, "[&") {
+		id, err := strconv.Atoi(s)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ID %q", s)
+		}
+		return id, nil
+	}
+
+	link, err := ParseChatLink(s)
+	if err != nil {
+		return 0, err
+	}
+	if link.Type != want {
+		return 0, fmt.Errorf("chat link %s is a %s link, expected %s", s, link.Type, want)
+	}
+	return link.ID, nil
+}
+
+// uint24 decodes a little-endian 24-bit integer
+func uint24(b []byte) uint32 {
+	return uint32(b[0]) | uint32(b[1])<<8 | uint32(b[2])<<16
+}
@@ -0,0 +1,113 @@
+package gw2api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseChatLink(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected ChatLink
+	}{
+		{"single item", "[&AgH1WQAA]", ChatLink{Type: ChatLinkItem, ID: 23029, Quantity: 1}},
+		{"item stack", "[&AvoJTQAA]", ChatLink{Type: ChatLinkItem, ID: 19721, Quantity: 250}},
+		{"item with skin only", "[&AgEJTQCAfQ4AAA==]", ChatLink{Type: ChatLinkItem, ID: 19721, Quantity: 1, SkinID: 3709}},
+		{
+			"item with skin and both upgrades",
+			"[&AgGqtgDgfQ4AAP9fAAAnYAAA]",
+			ChatLink{Type: ChatLinkItem, ID: 46762, Quantity: 1, SkinID: 3709, Upgrade1: 24575, Upgrade2: 24615},
+		},
+		{"coins", "[&AdsnAAA=]", ChatLink{Type: ChatLinkCoin, ID: 10203}},
+		{"waypoint", "[&BDAEAAA=]", ChatLink{Type: ChatLinkMap, ID: 1072}},
+		{"recipe", "[&CQEAAAA=]", ChatLink{Type: ChatLinkRecipe, ID: 1}},
+		{"skin", "[&CgEAAAA=]", ChatLink{Type: ChatLinkSkin, ID: 1}},
+		{"surrounding whitespace", "  [&CQEAAAA=]\n", ChatLink{Type: ChatLinkRecipe, ID: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			link, err := ParseChatLink(tt.code)
+			if err != nil {
+				t.Fatalf("ParseChatLink(%q): %v", tt.code, err)
+			}
+			if link != tt.expected {
+				t.Errorf("ParseChatLink(%q) = %+v, expected %+v", tt.code, link, tt.expected)
+			}
+
+			// Encoding must reproduce the original code exactly
+			if encoded := link.String(); encoded != strings.TrimSpace(tt.code) {
+				t.Errorf("String() = %q, expected %q", encoded, strings.TrimSpace(tt.code))
+			}
+		})
+	}
+}
+
+func TestParseChatLinkErrors(t *testing.T) {
+	tests := map[string]string{
+		"missing brackets":        "AgH1WQAA",
+		"missing ampersand":       "[AgH1WQAA]",
+		"bad base64":              "[&!!!!]",
+		"empty":                   "[&]",
+		"truncated item":          "[&AgH1WQ==]",
+		"flagged skin missing":    "[&AgEJTQCA]",
+		"truncated recipe":        "[&CQEA]",
+		"trailing bytes":          "[&CQEAAAAA]",
+		"unsupported type (npc)":  "[&AwEAAAA=]",
+		"trailing item component": "[&AgEJTQAAfQ4AAA==]",
+	}
+
+	for name, code := range tests {
+		t.Run(name, func(t *testing.T) {
+			if link, err := ParseChatLink(code); err == nil {
+				t.Errorf("ParseChatLink(%q) = %+v, expected an error", code, link)
+			}
+		})
+	}
+}
+
+func TestChatLinkStringClampsQuantity(t *testing.T) {
+	tests := []struct {
+		quantity int
+		expected int
+	}{
+		{0, 1},
+		{-5, 1},
+		{250, 250},
+		{1000, 255},
+	}
+
+	for _, tt := range tests {
+		code := ChatLink{Type: ChatLinkItem, ID: 19721, Quantity: tt.quantity}.String()
+		link, err := ParseChatLink(code)
+		if err != nil {
+			t.Fatalf("ParseChatLink(%q): %v", code, err)
+		}
+		if link.Quantity != tt.expected {
+			t.Errorf("quantity %d encoded as %d, expected %d", tt.quantity, link.Quantity, tt.expected)
+		}
+	}
+}
+
+func TestItemChatLinkFor(t *testing.T) {
+	item := &Item{ID: 19721}
+	if code := item.ChatLinkFor(250); code != "[&AvoJTQAA]" {
+		t.Errorf("ChatLinkFor(250) = %q, expected [&AvoJTQAA]", code)
+	}
+}
+
+func TestParseIDOrChatLink(t *testing.T) {
+	if id, err := ParseIDOrChatLink("19721", ChatLinkItem); err != nil || id != 19721 {
+		t.Errorf("numeric ID = %d, %v", id, err)
+	}
+	if id, err := ParseIDOrChatLink("[&AvoJTQAA]", ChatLinkItem); err != nil || id != 19721 {
+		t.Errorf("item link = %d, %v", id, err)
+	}
+	if _, err := ParseIDOrChatLink("[&CQEAAAA=]", ChatLinkItem); err == nil {
+		t.Error("expected an error for a recipe link where an item was wanted")
+	}
+	if _, err := ParseIDOrChatLink("12abc", ChatLinkItem); err == nil {
+		t.Error("expected an error for a malformed ID")
+	}
+}
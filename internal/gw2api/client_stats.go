@@ -0,0 +1,79 @@
+package gw2api
+
+import (
+	"errors"
+	"sync/atomic"
+)
+
+// ErrResponseTooLarge is returned for a response body larger than the limit
+// set with WithMaxResponseSize. It is not retried, as the next attempt would
+// get the same response.
+var ErrResponseTooLarge = errors.New("response too large")
+
+// WithMaxResponseSize limits response bodies to maxBytes, failing requests
+// for larger ones with ErrResponseTooLarge instead of reading them into
+// memory. Zero or less leaves responses unlimited, which is the default.
+func WithMaxResponseSize(maxBytes int64) ClientOption {
+	return func(c *Client) {
+		c.maxResponseSize = maxBytes
+	}
+}
+
+// ClientStats counts the HTTP attempts made by a client and the copies made
+// from it with With, WithKey or WithSubtoken
+type ClientStats struct {
+	Requests int64 // HTTP attempts, including retries and failed ones
+	Retries  int64 // Attempts after the first of a request
+	BytesIn  int64 // Response body bytes read
+
+	// NotModified counts attempts answered from a kept response after a 304,
+	// see WithConditionalRequests
+	NotModified int64
+
+	// RecipeSearchHits counts recipe searches answered from earlier ones
+	// without a request, see SearchRecipesByOutput
+	RecipeSearchHits int64
+}
+
+// clientStats is the shared, atomically updated counters behind ClientStats
+type clientStats struct {
+	requests atomic.Int64
+	retries  atomic.Int64
+	bytesIn  atomic.Int64
+
+	notModified atomic.Int64
+
+	recipeSearchHits atomic.Int64
+}
+
+// Stats returns how many requests the client has made and how much it has
+// downloaded, for keeping an eye on API usage
+func (c *Client) Stats() ClientStats {
+	if c.stats == nil {
+		return ClientStats{}
+	}
+	return ClientStats{
+		Requests: c.stats.requests.Load(),
+		Retries:  c.stats.retries.Load(),
+		BytesIn:  c.stats.bytesIn.Load(),
+
+		NotModified: c.stats.notModified.Load(),
+
+		RecipeSearchHits: c.stats.recipeSearchHits.Load(),
+	}
+}
+
+// countRequest adds an attempt to the client's stats
+func (c *Client) countRequest(info RequestInfo) {
+	if c.stats == nil {
+		return
+	}
+	c.stats.requests.Add(1)
+	if info.Attempt > 1 {
+		c.stats.retries.Add(1)
+	}
+	c.stats.bytesIn.Add(int64(info.BodySize))
+	if info.NotModified {
+		c.stats.notModified.Add(1)
+	}
+}
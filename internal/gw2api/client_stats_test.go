@@ -0,0 +1,82 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestMaxResponseSize(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch {
+		case r.URL.Query().Get("ids") == "all":
+			// Streamed in chunks without a Content-Length, like ids=all
+			w.Write([]byte("["))
+			for i := 0; i < 1000; i++ {
+				w.Write([]byte(`{"id": 1, "name": "` + strings.Repeat("x", 100) + `"},`))
+				w.(http.Flusher).Flush()
+			}
+			w.Write([]byte(`{"id": 2}]`))
+		case r.URL.Path == "/v2/build":
+			w.Header().Set("Content-Length", "100000")
+			w.Write([]byte(`{"id": 1` + strings.Repeat(" ", 100000-10) + `}`))
+		default:
+			w.Write([]byte(`{"id": 115267}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithMaxResponseSize(10000), WithRetries(2), WithRateLimit(1000))
+	if _, err := GetAll[Item](context.Background(), client, "/v2/items"); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("GetAll = %v, expected ErrResponseTooLarge", err)
+	}
+	if requests != 1 {
+		t.Errorf("made %d requests, expected no retries", requests)
+	}
+	if _, err := client.GetBuild(context.Background()); !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("GetBuild = %v, expected ErrResponseTooLarge from Content-Length", err)
+	}
+
+	// Small responses are unaffected
+	if _, err := client.GetItem(context.Background(), 1); err != nil {
+		t.Errorf("GetItem = %v", err)
+	}
+
+	// Unlimited by default
+	if _, err := GetAll[Item](context.Background(), NewClient(WithBaseURL(server.URL), WithRateLimit(1000)), "/v2/items"); err != nil {
+		t.Errorf("GetAll without a limit = %v", err)
+	}
+}
+
+func TestClientStats(t *testing.T) {
+	var attempts int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"text":"API not active"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 115267}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRetryConfig(&RetryConfig{MaxRetries: 1, BackoffMultiple: 1}), WithRateLimit(1000))
+	if _, err := client.GetBuild(context.Background()); err != nil {
+		t.Fatalf("GetBuild: %v", err)
+	}
+	// Copies count towards the same stats
+	if _, err := client.With(WithLanguage(LanguageGerman)).GetBuild(context.Background()); err != nil {
+		t.Fatalf("GetBuild: %v", err)
+	}
+
+	expected := ClientStats{Requests: 3, Retries: 1, BytesIn: int64(len(`{"text":"API not active"}`) + 2*len(`{"id": 115267}`))}
+	if stats := client.Stats(); stats != expected {
+		t.Errorf("Stats = %+v, expected %+v", stats, expected)
+	}
+}
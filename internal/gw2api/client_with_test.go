@@ -0,0 +1,89 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestClientAccessors(t *testing.T) {
+	client := NewClient(
+		WithLanguage(LanguageGerman),
+		WithUserAgent("test-agent"),
+		WithBaseURL("https://mirror.example/"),
+		WithRetries(2),
+	)
+	if client.Language() != LanguageGerman || client.UserAgent() != "test-agent" || client.BaseURL() != "https://mirror.example" {
+		t.Errorf("got language %q, user agent %q, base URL %q", client.Language(), client.UserAgent(), client.BaseURL())
+	}
+	if client.HasAPIKey() {
+		t.Error("HasAPIKey is true without a key")
+	}
+	if retry := client.RetryConfig(); retry.MaxRetries != 2 {
+		t.Errorf("MaxRetries = %d, expected 2", retry.MaxRetries)
+	}
+
+	// The returned retry config is a copy
+	retry := client.RetryConfig()
+	retry.MaxRetries = 10
+	if client.RetryConfig().MaxRetries != 2 {
+		t.Error("changing the returned retry config changed the client")
+	}
+}
+
+func TestClientWith(t *testing.T) {
+	client := NewClient(WithTimeout(5*time.Second), WithResponseHook(func(RequestInfo) {}))
+	client.dataCache = NewDataCache()
+
+	derived := client.With(WithAPIKey("key"), WithLanguage(LanguageFrench))
+	if !derived.HasAPIKey() || derived.Language() != LanguageFrench {
+		t.Errorf("derived client has key %v and language %q", derived.HasAPIKey(), derived.Language())
+	}
+	if client.HasAPIKey() || client.Language() != DefaultLang {
+		t.Error("With changed the original client")
+	}
+	if derived.httpClient != client.httpClient || derived.rateLimiter != client.rateLimiter || derived.DataCache() != client.DataCache() {
+		t.Error("derived client doesn't share the HTTP client, rate limiter and data cache")
+	}
+
+	derived.responseHooks = append(derived.responseHooks, func(RequestInfo) {})
+	if len(client.responseHooks) != 1 {
+		t.Errorf("original has %d response hooks after adding one to the copy, expected 1", len(client.responseHooks))
+	}
+
+	// Overridden resources are the copy's own
+	timed := client.With(WithTimeout(time.Second), WithRateLimit(100))
+	if timed.httpClient == client.httpClient || timed.rateLimiter == client.rateLimiter {
+		t.Error("derived client shares resources its options replaced")
+	}
+	if client.httpClient.Timeout != 5*time.Second || timed.httpClient.Timeout != time.Second {
+		t.Errorf("timeouts are %v and %v, expected 5s and 1s", client.httpClient.Timeout, timed.httpClient.Timeout)
+	}
+	if timed.httpClient.Transport != client.httpClient.Transport {
+		t.Error("derived client with its own timeout doesn't share the transport")
+	}
+}
+
+func TestClientWithSharesRateLimit(t *testing.T) {
+	client, requests, _ := newQueuedClient(t)
+	derived := client.With(WithAPIKey("key"))
+
+	// The original spent the only token, so the copy has to wait past the deadline
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	if _, err := derived.GetBuild(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, expected the derived client to wait on the shared limiter", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d requests reached the server, expected none", n)
+	}
+
+	// A copy with its own limit doesn't wait
+	if _, err := client.With(WithRateLimit(100)).GetBuild(ctx); err != nil {
+		t.Fatalf("GetBuild with its own rate limit: %v", err)
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("%d requests reached the server, expected 1", n)
+	}
+}
@@ -0,0 +1,86 @@
+package gw2api
+
+import "reflect"
+
+// deepCopy returns a copy of value that shares no memory with it: nested
+// pointers, slices and maps are copied recursively. Unexported fields, such as
+// those of time.Time, are copied as they are, without recursing. Cached API
+// objects are trees (no cycles), which this relies on.
+func deepCopy[T any](value *T) *T {
+	if value == nil {
+		return nil
+	}
+	result := new(T)
+	copyValue(reflect.ValueOf(result).Elem(), reflect.ValueOf(value).Elem())
+	return result
+}
+
+// copyValue deep copies src into the settable dst of the same type
+func copyValue(dst, src reflect.Value) {
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.New(src.Type().Elem()))
+		copyValue(dst.Elem(), src.Elem())
+
+	case reflect.Struct:
+		// Copy the whole struct first so unexported fields aren't left zero
+		dst.Set(src)
+		for i := range src.NumField() {
+			if dst.Field(i).CanSet() {
+				copyValue(dst.Field(i), src.Field(i))
+			}
+		}
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeSlice(src.Type(), src.Len(), src.Len()))
+		for i := range src.Len() {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Array:
+		for i := range src.Len() {
+			copyValue(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(reflect.MakeMapWithSize(src.Type(), src.Len()))
+		iter := src.MapRange()
+		for iter.Next() {
+			elem := reflect.New(src.Type().Elem()).Elem()
+			copyValue(elem, iter.Value())
+			dst.SetMapIndex(iter.Key(), elem)
+		}
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		elem := reflect.New(src.Elem().Type()).Elem()
+		copyValue(elem, src.Elem())
+		dst.Set(elem)
+
+	default:
+		dst.Set(src)
+	}
+}
+
+// Clone returns a deep copy of the item, including its details
+func (i *Item) Clone() *Item { return deepCopy(i) }
+
+// Clone returns a deep copy of the skill
+func (s *Skill) Clone() *Skill { return deepCopy(s) }
+
+// Clone returns a deep copy of the achievement
+func (a *Achievement) Clone() *Achievement { return deepCopy(a) }
+
+// Clone returns a deep copy of the recipe
+func (r *RecipeDetail) Clone() *RecipeDetail { return deepCopy(r) }
@@ -0,0 +1,104 @@
+package gw2api
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// nestedItem returns an item with every kind of nested value populated
+func nestedItem() *Item {
+	return &Item{
+		ID:    1,
+		Name:  "Berserker's Greatsword",
+		Flags: []string{"SoulBindOnUse"},
+		Details: &ItemDetails{
+			Type:          "Greatsword",
+			InfusionSlots: []InfusionSlot{{Flags: []string{"Infusion"}}},
+			InfixUpgrade: &InfixUpgrade{
+				ID:         161,
+				Attributes: []Attribute{{Attribute: "Power", Modifier: 251}},
+				Buff:       &Buff{SkillID: 1, Description: "+5% Damage"},
+			},
+			StatChoices: []int{161, 155},
+		},
+	}
+}
+
+func TestDeepCopy(t *testing.T) {
+	original := nestedItem()
+	clone := original.Clone()
+
+	if !reflect.DeepEqual(original, clone) {
+		t.Fatalf("clone differs from original:\n%+v\n%+v", original, clone)
+	}
+
+	clone.Flags[0] = "changed"
+	clone.Details.InfusionSlots[0].Flags[0] = "changed"
+	clone.Details.InfixUpgrade.Attributes[0].Modifier = 0
+	clone.Details.InfixUpgrade.Buff.Description = "changed"
+	clone.Details.StatChoices = append(clone.Details.StatChoices[:0], 1)
+
+	if !reflect.DeepEqual(original, nestedItem()) {
+		t.Errorf("modifying the clone changed the original: %+v", original.Details)
+	}
+
+	if (*Item)(nil).Clone() != nil {
+		t.Error("cloning nil should return nil")
+	}
+}
+
+func TestDeepCopyUnexportedFields(t *testing.T) {
+	type entry struct {
+		Time   time.Time
+		Tags   []string
+		hidden int
+	}
+	original := &entry{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC), Tags: []string{"a"}, hidden: 7}
+	clone := deepCopy(original)
+
+	if !clone.Time.Equal(original.Time) || clone.hidden != 7 {
+		t.Errorf("clone = %+v, expected the time and unexported field of %+v", clone, original)
+	}
+	clone.Tags[0] = "changed"
+	if original.Tags[0] != "a" {
+		t.Error("modifying the clone's slice changed the original")
+	}
+}
+
+func TestCacheReturnsCopies(t *testing.T) {
+	ic := NewItemCache()
+	ic.loaded = true
+	ic.replace([]*Item{nestedItem()})
+
+	mutate := func(item *Item) {
+		item.Name = "Truncated..."
+		item.Details.InfixUpgrade.Attributes[0].Modifier = 9999
+	}
+
+	item, _ := ic.GetByID(1)
+	mutate(item)
+	mutate(ic.GetByIDs([]int{1})[0])
+	mutate(ic.GetAll()[0])
+
+	cached, _ := ic.GetByIDRef(1)
+	if !reflect.DeepEqual(cached, nestedItem()) {
+		t.Errorf("mutating returned items corrupted the cache: %+v", cached)
+	}
+
+	// The client's cache-first getter must hand out copies too
+	client := NewClient()
+	client.dataCache = NewDataCache()
+	client.dataCache.items = ic
+
+	items, err := client.GetItems(context.Background(), []int{1})
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	mutate(items[0])
+
+	if cached, _ := ic.GetByIDRef(1); !reflect.DeepEqual(cached, nestedItem()) {
+		t.Errorf("mutating GetItems results corrupted the cache: %+v", cached)
+	}
+}
@@ -0,0 +1,74 @@
+package gw2api
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Coins is an amount of coin in copper. 100 copper make a silver and 100 silver
+// make a gold.
+type Coins int
+
+// String formats the amount the way the game does, such as "1g 5c", leaving out
+// units that are zero. Zero is "0c", and a negative amount starts with "-".
+func (c Coins) String() string {
+	if c < 0 {
+		return "-" + (-c).String()
+	}
+	gold := int(c) / 10000
+	silver := int(c) % 10000 / 100
+	copper := int(c) % 100
+
+	var parts []string
+	if gold > 0 {
+		parts = append(parts, fmt.Sprintf("%dg", gold))
+	}
+	if silver > 0 {
+		parts = append(parts, fmt.Sprintf("%ds", silver))
+	}
+	if copper > 0 || len(parts) == 0 {
+		parts = append(parts, fmt.Sprintf("%dc", copper))
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseCoins parses an amount such as "5g", "1g 50s", "2s30c" or a plain
+// number of copper, as String formats them
+func ParseCoins(s string) (Coins, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if s == "" {
+		return 0, fmt.Errorf("empty coin amount")
+	}
+	if copper, err := strconv.Atoi(s); err == nil {
+		if copper < 0 {
+			return 0, fmt.Errorf("negative coin amount %q", s)
+		}
+		return Coins(copper), nil
+	}
+
+	var total Coins
+	rest := strings.ReplaceAll(s, " ", "")
+	for rest != "" {
+		end := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+		if end <= 0 {
+			return 0, fmt.Errorf("invalid coin amount %q", s)
+		}
+		amount, err := strconv.Atoi(rest[:end])
+		if err != nil {
+			return 0, fmt.Errorf("invalid coin amount %q", s)
+		}
+		switch rest[end] {
+		case 'g':
+			total += Coins(amount * 10000)
+		case 's':
+			total += Coins(amount * 100)
+		case 'c':
+			total += Coins(amount)
+		default:
+			return 0, fmt.Errorf("invalid coin unit %q in %q, expected g, s or c", rest[end], s)
+		}
+		rest = rest[end+1:]
+	}
+	return total, nil
+}
@@ -0,0 +1,39 @@
+package gw2api
+
+import "testing"
+
+func TestParseCoins(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Coins
+	}{
+		{"5g", 50000},
+		{"1g 50s", 15000},
+		{"2s30c", 230},
+		{" 12G 3C ", 120003},
+		{"750", 750},
+		{"0c", 0},
+	}
+	for _, test := range tests {
+		got, err := ParseCoins(test.in)
+		if err != nil || got != test.expected {
+			t.Errorf("ParseCoins(%q) = %v, %v, expected %v", test.in, got, err, test.expected)
+		}
+	}
+
+	for _, in := range []string{"", "g", "5x", "5g 3", "-1", "1.5g"} {
+		if got, err := ParseCoins(in); err == nil {
+			t.Errorf("ParseCoins(%q) = %v, expected an error", in, got)
+		}
+	}
+
+	if got, _ := ParseCoins(Coins(123456).String()); got != 123456 {
+		t.Errorf("ParseCoins(String()) = %d, expected to round trip", got)
+	}
+}
+
+func TestCoinsStringNegative(t *testing.T) {
+	if got := Coins(-15003).String(); got != "-1g 50s 3c" {
+		t.Errorf("Coins(-15003).String() = %q, expected %q", got, "-1g 50s 3c")
+	}
+}
@@ -0,0 +1,223 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// CollectionEntry is one skin, mini or item bit of a collection achievement
+type CollectionEntry struct {
+	Bit   int    `json:"bit"`  // Index among the achievement's bits
+	Type  string `json:"type"` // Skin, Minipet or Item
+	ID    int    `json:"id"`
+	Owned bool   `json:"owned"`
+
+	// ItemID is the unlock item with the lowest sell listing and Price is
+	// that listing. Both are zero for owned and untradable entries.
+	ItemID     int  `json:"item_id,omitempty"`
+	Price      int  `json:"price,omitempty"`
+	Untradable bool `json:"untradable,omitempty"` // No unlock item is listed for sale
+}
+
+// CollectionCost is what completing a collection achievement costs on the
+// trading post
+type CollectionCost struct {
+	AchievementID int               `json:"achievement_id"`
+	Name          string            `json:"name"`
+	Entries       []CollectionEntry `json:"entries"`    // In bit order, without Text bits
+	Remaining     int               `json:"remaining"`  // Entries not owned yet
+	Untradable    int               `json:"untradable"` // Remaining entries Cost leaves out, since nothing unlocking them is for sale
+	Cost          int               `json:"cost"`       // Every other remaining entry at its lowest sell price
+}
+
+// collectionOwnership is what an account is known to have unlocked
+type collectionOwnership struct {
+	skins    map[int]bool
+	minis    map[int]bool
+	progress map[int]AccountAchievement // By achievement ID
+}
+
+// owns reports whether the account has the bit at index of an achievement,
+// either through its progress on the achievement or, for skins and minis,
+// through its unlocks
+func (o *collectionOwnership) owns(achievementID, index int, bit AchievementBit) bool {
+	if progress, found := o.progress[achievementID]; found && (progress.Done || slices.Contains(progress.Bits, index)) {
+		return true
+	}
+	switch bit.Type {
+	case "Skin":
+		return o.skins[bit.ID]
+	case "Minipet":
+		return o.minis[bit.ID]
+	}
+	return false
+}
+
+// costCollection prices the bits of achievement the account doesn't own.
+// unlockItems lists the items unlocking each bit and prices the trading post
+// prices of those that are tradable.
+func costCollection(achievement *Achievement, owned *collectionOwnership, unlockItems map[AchievementBitKey][]int, prices map[int]*Price) *CollectionCost {
+	cost := &CollectionCost{AchievementID: achievement.ID, Name: achievement.Name}
+	for index, bit := range achievement.Bits {
+		if bit.Type != "Skin" && bit.Type != "Minipet" && bit.Type != "Item" {
+			continue
+		}
+		entry := CollectionEntry{Bit: index, Type: bit.Type, ID: bit.ID, Owned: owned.owns(achievement.ID, index, bit)}
+		if !entry.Owned {
+			cost.Remaining++
+			for _, itemID := range unlockItems[AchievementBitKey{Type: bit.Type, ID: bit.ID}] {
+				price, found := prices[itemID]
+				if !found || price.Sells.UnitPrice == 0 {
+					continue
+				}
+				if entry.ItemID == 0 || price.Sells.UnitPrice < entry.Price {
+					entry.ItemID, entry.Price = itemID, price.Sells.UnitPrice
+				}
+			}
+			if entry.ItemID == 0 {
+				entry.Untradable = true
+				cost.Untradable++
+			}
+			cost.Cost += entry.Price
+		}
+		cost.Entries = append(cost.Entries, entry)
+	}
+	return cost
+}
+
+// GetCollectionCosts prices completing each of the given collection
+// achievements, cheapest first. Skin bits are unlocked by any item showing or
+// unlocking the skin, which needs the item data cache; mini bits by the mini's
+// item; and item bits by the item itself. Each bit is priced by the lowest
+// sell listing of those items.
+//
+// With an API key, bits the account has are left out of the cost, going by
+// its unlocked skins and minis and its progress on the achievements. Without
+// one, every bit is counted.
+// Scopes: account, progression, unlocks (optional)
+func (c *Client) GetCollectionCosts(ctx context.Context, achievementIDs []int) ([]*CollectionCost, error) {
+	if c.dataCache == nil || !c.dataCache.GetItemCache().IsLoaded() {
+		return nil, fmt.Errorf("pricing collections requires the item data cache to be loaded")
+	}
+	achievements, err := c.lookupAchievements(ctx, achievementIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collection achievements: %w", err)
+	}
+
+	owned := &collectionOwnership{}
+	if c.HasAPIKey() {
+		if owned, err = c.collectionOwnership(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	unlockItems, err := c.collectionUnlockItems(ctx, achievements)
+	if err != nil {
+		return nil, err
+	}
+	var itemIDs []int
+	for _, ids := range unlockItems {
+		itemIDs = append(itemIDs, ids...)
+	}
+	items, err := c.lookupItems(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unlock items: %w", err)
+	}
+	var tradableIDs []int
+	for id, item := range items {
+		if item.IsTradable() {
+			tradableIDs = append(tradableIDs, id)
+		}
+	}
+	prices, err := c.lookupPrices(ctx, tradableIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unlock item prices: %w", err)
+	}
+
+	var costs []*CollectionCost
+	for _, id := range achievementIDs {
+		if achievement, found := achievements[id]; found {
+			costs = append(costs, costCollection(achievement, owned, unlockItems, prices))
+		}
+	}
+	slices.SortStableFunc(costs, func(a, b *CollectionCost) int {
+		return cmp.Or(cmp.Compare(a.Cost, b.Cost), cmp.Compare(a.AchievementID, b.AchievementID))
+	})
+	return costs, nil
+}
+
+// GetBlackLionCollectionCosts prices completing each Black Lion weapon
+// collection, as GetCollectionCosts does
+// Scopes: account, progression, unlocks (optional)
+func (c *Client) GetBlackLionCollectionCosts(ctx context.Context) ([]*CollectionCost, error) {
+	category, err := c.GetAchievementCategory(ctx, AchievementCategoryBlackLionCollections)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get Black Lion collections: %w", err)
+	}
+	return c.GetCollectionCosts(ctx, category.AchievementIDs())
+}
+
+// collectionOwnership fetches the account's skins, minis and achievement progress
+func (c *Client) collectionOwnership(ctx context.Context) (*collectionOwnership, error) {
+	skins, err := c.GetAccountSkins(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account skins: %w", err)
+	}
+	minis, err := c.GetAccountMinis(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account minis: %w", err)
+	}
+	progress, err := c.GetAccountAchievements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account achievements: %w", err)
+	}
+
+	owned := &collectionOwnership{
+		skins:    make(map[int]bool, len(skins)),
+		minis:    make(map[int]bool, len(minis)),
+		progress: make(map[int]AccountAchievement, len(progress)),
+	}
+	for _, id := range skins {
+		owned.skins[id] = true
+	}
+	for _, id := range minis {
+		owned.minis[int(id)] = true
+	}
+	for _, achievement := range progress {
+		owned.progress[achievement.ID] = achievement
+	}
+	return owned, nil
+}
+
+// collectionUnlockItems lists the items unlocking each skin, mini and item bit
+// of the achievements
+func (c *Client) collectionUnlockItems(ctx context.Context, achievements map[int]*Achievement) (map[AchievementBitKey][]int, error) {
+	unlockItems := make(map[AchievementBitKey][]int)
+	var miniIDs []int
+	for _, achievement := range achievements {
+		for _, bit := range achievement.Bits {
+			key := AchievementBitKey{Type: bit.Type, ID: bit.ID}
+			switch bit.Type {
+			case "Skin":
+				unlockItems[key] = c.dataCache.GetItemCache().ItemsForSkin(bit.ID)
+			case "Minipet":
+				miniIDs = append(miniIDs, bit.ID)
+			case "Item":
+				unlockItems[key] = []int{bit.ID}
+			}
+		}
+	}
+
+	minis, err := c.GetMinis(ctx, uniqueIDs(miniIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get minis: %w", err)
+	}
+	for _, mini := range minis {
+		if mini.ItemID != 0 {
+			unlockItems[AchievementBitKey{Type: "Minipet", ID: mini.ID}] = []int{mini.ItemID}
+		}
+	}
+	return unlockItems, nil
+}
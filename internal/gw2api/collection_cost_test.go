@@ -0,0 +1,123 @@
+package gw2api
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// newCollectionFixtureClient returns a client for three collections: one
+// partly owned with a bit of every kind, one cheap and one finished
+func newCollectionFixtureClient(t *testing.T, withKey bool) *Client {
+	t.Helper()
+	client := newFixtureClient(t, "collections", map[string]string{
+		"/v2/achievements":         "achievements.json",
+		"/v2/minis":                "minis.json",
+		"/v2/commerce/prices":      "prices.json",
+		"/v2/account/skins":        "account_skins.json",
+		"/v2/account/minis":        "account_minis.json",
+		"/v2/account/achievements": "account_achievements.json",
+	})
+	if withKey {
+		client.apiKey = "key"
+	}
+	client.dataCache = NewDataCache()
+	if err := client.dataCache.GetItemCache().LoadFromFile(filepath.Join("testdata", "collections", "items.jsonl")); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	return client
+}
+
+func TestCostCollection(t *testing.T) {
+	var achievements []*Achievement
+	decodeStrict(t, "collections/achievements.json", &achievements)
+	var prices []*Price
+	decodeStrict(t, "collections/prices.json", &prices)
+	byID := make(map[int]*Price)
+	for _, price := range prices {
+		byID[price.ID] = price
+	}
+
+	owned := &collectionOwnership{
+		skins:    map[int]bool{100: true},
+		progress: map[int]AccountAchievement{1001: {ID: 1001, Bits: []int{5}}},
+	}
+	unlockItems := map[AchievementBitKey][]int{
+		{Type: "Skin", ID: 100}:  {9007},
+		{Type: "Skin", ID: 101}:  {9001, 9002},
+		{Type: "Skin", ID: 102}:  {9003},
+		{Type: "Skin", ID: 103}:  {9008},
+		{Type: "Minipet", ID: 7}: {9004},
+		{Type: "Item", ID: 9005}: {9005},
+		{Type: "Skin", ID: 200}:  {9006},
+		{Type: "Skin", ID: 300}:  nil,
+	}
+
+	cost := costCollection(achievements[0], owned, unlockItems, byID)
+	if cost.Remaining != 4 || cost.Untradable != 2 || cost.Cost != 13000 {
+		t.Errorf("remaining %d, untradable %d, cost %d; want 4, 2 and 13000", cost.Remaining, cost.Untradable, cost.Cost)
+	}
+	if len(cost.Entries) != 6 {
+		t.Fatalf("%d entries, want 6 without the Text bit", len(cost.Entries))
+	}
+	expected := []CollectionEntry{
+		{Bit: 0, Type: "Skin", ID: 100, Owned: true},
+		{Bit: 1, Type: "Skin", ID: 101, ItemID: 9002, Price: 3000}, // The cheaper of two unlock items
+		{Bit: 2, Type: "Skin", ID: 102, Untradable: true},          // Account bound, so never priced
+		{Bit: 3, Type: "Skin", ID: 103, Untradable: true},          // Tradable, but nobody is selling
+		{Bit: 4, Type: "Minipet", ID: 7, ItemID: 9004, Price: 10000},
+		{Bit: 5, Type: "Item", ID: 9005, Owned: true}, // From achievement progress
+	}
+	if !slices.Equal(cost.Entries, expected) {
+		t.Errorf("entries = %+v\nwant %+v", cost.Entries, expected)
+	}
+
+	// Without ownership every bit counts
+	cost = costCollection(achievements[0], &collectionOwnership{}, unlockItems, byID)
+	if cost.Remaining != 6 || cost.Cost != 65000 {
+		t.Errorf("unowned: remaining %d, cost %d; want 6 and 65000", cost.Remaining, cost.Cost)
+	}
+
+	// A finished achievement owns every bit, even ones nothing unlocks
+	owned.progress[1003] = AccountAchievement{ID: 1003, Done: true}
+	cost = costCollection(achievements[2], owned, unlockItems, byID)
+	if cost.Remaining != 0 || cost.Untradable != 0 || cost.Cost != 0 {
+		t.Errorf("finished: %+v, want nothing remaining", cost)
+	}
+}
+
+func TestGetCollectionCosts(t *testing.T) {
+	ctx := context.Background()
+
+	costs, err := newCollectionFixtureClient(t, true).GetCollectionCosts(ctx, []int{1001, 1002, 1003})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var order []int
+	for _, cost := range costs {
+		order = append(order, cost.AchievementID)
+	}
+	if !slices.Equal(order, []int{1003, 1002, 1001}) {
+		t.Errorf("order = %v, want cheapest first", order)
+	}
+	if fire := costs[2]; fire.Remaining != 4 || fire.Untradable != 2 || fire.Cost != 13000 {
+		t.Errorf("Fire Collection = %+v", fire)
+	}
+
+	// Without a key nothing is owned
+	costs, err = newCollectionFixtureClient(t, false).GetCollectionCosts(ctx, []int{1001})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(costs) != 1 || costs[0].Remaining != 6 || costs[0].Cost != 65000 {
+		t.Errorf("without a key = %+v", costs)
+	}
+
+	// Skins are found through the item cache
+	client := newCollectionFixtureClient(t, false)
+	client.dataCache = nil
+	if _, err := client.GetCollectionCosts(ctx, []int{1001}); err == nil {
+		t.Error("expected an error without the item cache")
+	}
+}
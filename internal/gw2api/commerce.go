@@ -1,55 +1,219 @@
 package gw2api
 
-import "time"
+import (
+	"context"
+	"strconv"
+	"time"
+)
 
 // Price represents trading post price information for an item
 type Price struct {
 	ID          int       `json:"id"`
-	Whitelisted bool      `json:"whitelisted"`
+	Whitelisted bool      `json:"whitelisted"` // Whether free to play accounts can trade the item
 	Buys        PriceInfo `json:"buys"`
 	Sells       PriceInfo `json:"sells"`
 }
 
-// PriceInfo represents buy/sell price and quantity information
+// PriceInfo represents the best buy/sell price and the total quantity on offer
 type PriceInfo struct {
 	Quantity  int `json:"quantity"`
 	UnitPrice int `json:"unit_price"`
 }
 
-// Listing represents detailed trading post listings for an item
+// Listing represents the full trading post order book for an item
 type Listing struct {
 	ID    int           `json:"id"`
 	Buys  []ListingInfo `json:"buys"`
 	Sells []ListingInfo `json:"sells"`
 }
 
-// ListingInfo represents individual buy/sell listing information
+// ListingInfo represents all orders at a single price point
 type ListingInfo struct {
-	Listings  int `json:"listings"`
+	Listings  int `json:"listings"` // Number of individual orders at this price
 	UnitPrice int `json:"unit_price"`
-	Quantity  int `json:"quantity"`
+	Quantity  int `json:"quantity"` // Total items across those orders
 }
 
-// ExchangeRate represents gem/gold exchange rate information
-type ExchangeRate struct {
+// ExchangeResult represents a gem/gold exchange quote.
+// Quantity is the number of gems (for coins) or coins (for gems) received.
+type ExchangeResult struct {
 	CoinsPerGem int `json:"coins_per_gem"`
 	Quantity    int `json:"quantity"`
 }
 
-// Transaction represents a trading post transaction
+// TransactionPeriod selects open orders or fulfilled orders from the last 90 days
+type TransactionPeriod string
+
+const (
+	TransactionsCurrent TransactionPeriod = "current"
+	TransactionsHistory TransactionPeriod = "history"
+)
+
+// TransactionSide selects buy or sell orders
+type TransactionSide string
+
+const (
+	TransactionBuys  TransactionSide = "buys"
+	TransactionSells TransactionSide = "sells"
+)
+
+// Transaction represents a trading post order placed by the account
 type Transaction struct {
 	ID        int       `json:"id"`
 	ItemID    int       `json:"item_id"`
 	Price     int       `json:"price"`
 	Quantity  int       `json:"quantity"`
 	Created   time.Time `json:"created"`
-	Purchased time.Time `json:"purchased,omitempty"`
+	Purchased time.Time `json:"purchased,omitzero"` // Only set for history transactions
 }
 
-// DeliveryItem represents an item available for pickup
+// Delivery represents the coins and items waiting for pickup at the trading post
+type Delivery struct {
+	Coins int            `json:"coins"`
+	Items []DeliveryItem `json:"items"`
+}
+
+// DeliveryItem represents an item stack available for pickup
 type DeliveryItem struct {
-	ID       int `json:"id"`
-	Count    int `json:"count"`
-	ItemID   int `json:"item_id"`
-	Quantity int `json:"quantity"`
+	ID    int `json:"id"`
+	Count int `json:"count"`
+}
+
+// GetCommercePriceIDs returns all available item IDs with trading post prices.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/prices
+// Scopes: None (public endpoint)
+func (c *Client) GetCommercePriceIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/commerce/prices", options...)
+}
+
+// GetCommercePrice returns trading post price information for a specific item.
+// With WithPriceCache, calls without options use a recently fetched price.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/prices
+// Scopes: None (public endpoint)
+func (c *Client) GetCommercePrice(ctx context.Context, itemID int, options ...RequestOption) (*Price, error) {
+	if c.priceCache == nil || len(options) > 0 {
+		return GetByID[Price](ctx, c, "/v2/commerce/prices", itemID, options...)
+	}
+	if price, found := c.priceCache.get(itemID); found {
+		return price, nil
+	}
+	price, err := GetByID[Price](ctx, c, "/v2/commerce/prices", itemID)
+	if err != nil {
+		return nil, err
+	}
+	c.priceCache.set(price)
+	return price, nil
+}
+
+// GetCommercePrices returns trading post price information for multiple items.
+// With WithPriceCache, calls without options only fetch the uncached prices.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/prices
+// Scopes: None (public endpoint)
+func (c *Client) GetCommercePrices(ctx context.Context, itemIDs []int, options ...RequestOption) ([]*Price, error) {
+	if c.priceCache != nil && len(options) == 0 {
+		return c.cachedPrices(ctx, itemIDs)
+	}
+	results, err := GetByIDs[Price](ctx, c, "/v2/commerce/prices", itemIDs, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Price, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetCommerceListingIDs returns all item IDs with trading post listings.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/listings
+// Scopes: None (public endpoint)
+func (c *Client) GetCommerceListingIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/commerce/listings", options...)
+}
+
+// GetCommerceListing returns the trading post order book for a specific item.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/listings
+// Scopes: None (public endpoint)
+func (c *Client) GetCommerceListing(ctx context.Context, itemID int, options ...RequestOption) (*Listing, error) {
+	return GetByID[Listing](ctx, c, "/v2/commerce/listings", itemID, options...)
+}
+
+// GetCommerceListings returns the trading post order books for multiple items.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/listings
+// Scopes: None (public endpoint)
+func (c *Client) GetCommerceListings(ctx context.Context, itemIDs []int, options ...RequestOption) ([]*Listing, error) {
+	results, err := GetByIDs[Listing](ctx, c, "/v2/commerce/listings", itemIDs, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Listing, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetCommerceExchangeCoins returns how many gems the given amount of coins buys.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/exchange/coins
+// Scopes: None (public endpoint)
+func (c *Client) GetCommerceExchangeCoins(ctx context.Context, coins int, options ...RequestOption) (*ExchangeResult, error) {
+	options = append(options, WithParam("quantity", strconv.Itoa(coins)))
+	return GetSingle[ExchangeResult](ctx, c, "/v2/commerce/exchange/coins", options...)
+}
+
+// GetCommerceExchangeGems returns how many coins the given amount of gems buys.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/exchange/gems
+// Scopes: None (public endpoint)
+func (c *Client) GetCommerceExchangeGems(ctx context.Context, gems int, options ...RequestOption) (*ExchangeResult, error) {
+	options = append(options, WithParam("quantity", strconv.Itoa(gems)))
+	return GetSingle[ExchangeResult](ctx, c, "/v2/commerce/exchange/gems", options...)
+}
+
+// GetCommerceExchangeTypes returns the currencies the gem exchange converts from, "coins" and "gems".
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/exchange
+// Scopes: None (public endpoint)
+func (c *Client) GetCommerceExchangeTypes(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/commerce/exchange", options...)
+}
+
+// GetCommerceDelivery returns coins and items available for pickup from trading post.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/delivery
+// Scopes: account, tradingpost
+func (c *Client) GetCommerceDelivery(ctx context.Context, options ...RequestOption) (*Delivery, error) {
+	return GetSingle[Delivery](ctx, c, "/v2/commerce/delivery", options...)
+}
+
+// GetCommerceTransactions returns a page of current or historical trading post orders.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/transactions
+// Scopes: account, tradingpost
+func (c *Client) GetCommerceTransactions(ctx context.Context, period TransactionPeriod, side TransactionSide, options ...RequestOption) ([]*Transaction, *PaginationResponse, error) {
+	results, pagination, err := GetPaged[Transaction](ctx, c, "/v2/commerce/transactions/"+string(period)+"/"+string(side), options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ptrs := make([]*Transaction, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, pagination, nil
+}
+
+// GetAllCommerceTransactions returns every current or historical trading post order, following pagination.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/transactions
+// Scopes: account, tradingpost
+func (c *Client) GetAllCommerceTransactions(ctx context.Context, period TransactionPeriod, side TransactionSide) ([]*Transaction, error) {
+	var all []*Transaction
+	for page := 0; ; page++ {
+		results, pagination, err := c.GetCommerceTransactions(ctx, period, side, WithPage(page), WithPageSize(maxIDsPerRequest))
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, results...)
+		if pagination == nil || page+1 >= pagination.PageTotal {
+			return all, nil
+		}
+	}
 }
@@ -0,0 +1,114 @@
+package gw2api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCommerceFixturesDecodeStrictly(t *testing.T) {
+	tests := []struct {
+		fixture string
+		target  any
+	}{
+		{"prices.json", &[]Price{}},
+		{"listings.json", &[]Listing{}},
+		{"exchange_coins.json", &ExchangeResult{}},
+		{"exchange_gems.json", &ExchangeResult{}},
+		{"delivery.json", &Delivery{}},
+		{"transactions_current_buys.json", &[]Transaction{}},
+		{"transactions_history_sells.json", &[]Transaction{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fixture, func(t *testing.T) {
+			decodeStrict(t, filepath.Join("commerce", tt.fixture), tt.target)
+		})
+	}
+}
+
+func TestCommerceEndpoints(t *testing.T) {
+	ctx := context.Background()
+	client := newFixtureClient(t, "commerce", map[string]string{
+		"/v2/commerce/prices":                     "prices.json",
+		"/v2/commerce/listings":                   "listings.json",
+		"/v2/commerce/exchange/coins":             "exchange_coins.json",
+		"/v2/commerce/exchange/gems":              "exchange_gems.json",
+		"/v2/commerce/delivery":                   "delivery.json",
+		"/v2/commerce/transactions/current/buys":  "transactions_current_buys.json",
+		"/v2/commerce/transactions/history/sells": "transactions_history_sells.json",
+	})
+
+	prices, err := client.GetCommercePrices(ctx, []int{19684, 19709})
+	if err != nil {
+		t.Fatalf("GetCommercePrices: %v", err)
+	}
+	if len(prices) != 2 || prices[0].Sells.UnitPrice != 7019 || prices[0].Whitelisted || !prices[1].Whitelisted {
+		t.Errorf("prices = %+v, %+v", prices[0], prices[1])
+	}
+
+	listings, err := client.GetCommerceListings(ctx, []int{19684})
+	if err != nil {
+		t.Fatalf("GetCommerceListings: %v", err)
+	}
+	if len(listings) != 1 || len(listings[0].Buys) != 2 || listings[0].Sells[1] != (ListingInfo{Listings: 2, UnitPrice: 7020, Quantity: 440}) {
+		t.Errorf("listings = %+v", listings)
+	}
+
+	var exchangeURL string
+	client.responseHooks = append(client.responseHooks, func(info RequestInfo) { exchangeURL = info.URL })
+	gems, err := client.GetCommerceExchangeCoins(ctx, 100000)
+	if err != nil {
+		t.Fatalf("GetCommerceExchangeCoins: %v", err)
+	}
+	if *gems != (ExchangeResult{CoinsPerGem: 2941, Quantity: 34}) {
+		t.Errorf("exchange coins = %+v", gems)
+	}
+	if !strings.Contains(exchangeURL, "quantity=100000") {
+		t.Errorf("exchange URL %q should pass the coin quantity", exchangeURL)
+	}
+	client.responseHooks = nil
+
+	delivery, err := client.GetCommerceDelivery(ctx)
+	if err != nil {
+		t.Fatalf("GetCommerceDelivery: %v", err)
+	}
+	if delivery.Coins != 35812 || len(delivery.Items) != 2 || delivery.Items[0] != (DeliveryItem{ID: 19721, Count: 250}) {
+		t.Errorf("delivery = %+v", delivery)
+	}
+
+	current, err := client.GetAllCommerceTransactions(ctx, TransactionsCurrent, TransactionBuys)
+	if err != nil {
+		t.Fatalf("GetAllCommerceTransactions(current): %v", err)
+	}
+	if len(current) != 1 || !current[0].Purchased.IsZero() {
+		t.Errorf("current transactions = %+v, expected one without a purchase time", current)
+	}
+
+	history, err := client.GetAllCommerceTransactions(ctx, TransactionsHistory, TransactionSells)
+	if err != nil {
+		t.Fatalf("GetAllCommerceTransactions(history): %v", err)
+	}
+	if len(history) != 2 {
+		t.Fatalf("got %d history transactions, expected 2", len(history))
+	}
+	expectedCreated := time.Date(2024, 5, 1, 9, 12, 3, 0, time.UTC)
+	expectedPurchased := time.Date(2024, 5, 1, 11, 47, 29, 0, time.UTC)
+	if !history[0].Created.Equal(expectedCreated) || !history[0].Purchased.Equal(expectedPurchased) {
+		t.Errorf("history[0] times = %v, %v", history[0].Created, history[0].Purchased)
+	}
+}
+
+func TestTransactionOmitsEmptyPurchased(t *testing.T) {
+	data, err := json.Marshal(Transaction{ID: 1, Created: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC)})
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if bytes.Contains(data, []byte("purchased")) {
+		t.Errorf("current transaction encoded a purchase time: %s", data)
+	}
+}
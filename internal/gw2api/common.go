@@ -1,5 +1,10 @@
 package gw2api
 
+import (
+	"slices"
+	"strings"
+)
+
 // Build represents the current game build
 type Build struct {
 	ID int `json:"id"`
@@ -16,6 +21,15 @@ const (
 	LanguageChinese Language = "zh"
 )
 
+// Languages lists every language the API supports
+var Languages = []Language{LanguageEnglish, LanguageSpanish, LanguageGerman, LanguageFrench, LanguageChinese}
+
+// ParseLanguage returns the language for a code such as "fr"
+func ParseLanguage(code string) (Language, bool) {
+	lang := Language(strings.ToLower(strings.TrimSpace(code)))
+	return lang, slices.Contains(Languages, lang)
+}
+
 // PaginationResponse contains pagination metadata
 type PaginationResponse struct {
 	Page      int `json:"page"`
@@ -37,6 +51,7 @@ func (e APIError) Error() string {
 type Profession struct {
 	ID              string            `json:"id"`
 	Name            string            `json:"name"`
+	Code            int               `json:"code,omitempty"` // Profession byte in build template links
 	Icon            string            `json:"icon"`
 	IconBig         string            `json:"icon_big"`
 	Specializations []int             `json:"specializations"`
@@ -44,6 +59,11 @@ type Profession struct {
 	Flags           []string          `json:"flags"`
 	Skills          []ProfessionSkill `json:"skills"`
 	Training        []TrainingTrack   `json:"training"`
+
+	// SkillsByPalette pairs the palette IDs used in build template links with
+	// skill IDs, as [palette ID, skill ID]. Code and SkillsByPalette are only
+	// sent under ProfessionSchema or later.
+	SkillsByPalette [][2]int `json:"skills_by_palette,omitempty"`
 }
 
 // Weapon represents weapon information for a profession
@@ -140,6 +160,18 @@ type Material struct {
 	Order int    `json:"order"`
 }
 
+// Dungeon path types returned by /v2/dungeons
+const (
+	DungeonPathStory      = "Story"
+	DungeonPathExplorable = "Explorable"
+)
+
+// Raid event types returned by /v2/raids
+const (
+	RaidEventBoss       = "Boss"
+	RaidEventCheckpoint = "Checkpoint"
+)
+
 // Dungeon represents a dungeon
 type Dungeon struct {
 	ID    string        `json:"id"`
@@ -149,7 +181,28 @@ type Dungeon struct {
 // DungeonPath represents a path within a dungeon
 type DungeonPath struct {
 	ID   string `json:"id"`
-	Type string `json:"type"`
+	Type string `json:"type"` // Story or Explorable
+}
+
+// StoryPath returns the dungeon's story path, if it has one
+func (d Dungeon) StoryPath() (DungeonPath, bool) {
+	for _, path := range d.Paths {
+		if path.Type == DungeonPathStory {
+			return path, true
+		}
+	}
+	return DungeonPath{}, false
+}
+
+// ExplorablePaths returns the dungeon's explorable paths in API order
+func (d Dungeon) ExplorablePaths() []DungeonPath {
+	var paths []DungeonPath
+	for _, path := range d.Paths {
+		if path.Type == DungeonPathExplorable {
+			paths = append(paths, path)
+		}
+	}
+	return paths
 }
 
 // Raid represents a raid
@@ -167,7 +220,27 @@ type RaidWing struct {
 // RaidEncounter represents an encounter within a raid wing
 type RaidEncounter struct {
 	ID   string `json:"id"`
-	Type string `json:"type"`
+	Type string `json:"type"` // Boss or Checkpoint
+}
+
+// Bosses returns the boss encounters of the wing in API order
+func (w RaidWing) Bosses() []RaidEncounter {
+	var bosses []RaidEncounter
+	for _, event := range w.Events {
+		if event.Type == RaidEventBoss {
+			bosses = append(bosses, event)
+		}
+	}
+	return bosses
+}
+
+// Encounters returns every encounter across all wings of the raid
+func (r Raid) Encounters() []RaidEncounter {
+	var events []RaidEncounter
+	for _, wing := range r.Wings {
+		events = append(events, wing.Events...)
+	}
+	return events
 }
 
 // Mastery represents a mastery track
@@ -0,0 +1,98 @@
+package gw2api
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+)
+
+// DefaultConditionalEntries is enough responses for a tracker polling a
+// handful of account endpoints for several keys
+const DefaultConditionalEntries = 256
+
+// WithConditionalRequests keeps the last response of up to maxEntries
+// requests that came with a Last-Modified header, which the account endpoints
+// send, and asks for them again with If-Modified-Since. When the API answers
+// 304 Not Modified, the kept response is decoded again instead, so polling an
+// unchanged inventory costs no download. Responses are kept per URL and API
+// key, least recently used first out. A response hook sees these requests
+// with RequestInfo.NotModified set, for callers that want to skip processing
+// unchanged results. Requests made with WithHeader are left alone, since they
+// may be conditional already. Zero or less turns it off, which is the default.
+func WithConditionalRequests(maxEntries int) ClientOption {
+	return func(c *Client) {
+		if maxEntries <= 0 {
+			c.conditional = nil
+			return
+		}
+		c.conditional = &conditionalStore{
+			maxEntries: maxEntries,
+			entries:    make(map[string]*list.Element),
+			order:      list.New(),
+		}
+	}
+}
+
+// conditionalStore is a bounded map of the last response per request, kept
+// for revalidating with If-Modified-Since
+type conditionalStore struct {
+	maxEntries int
+
+	mutex   sync.Mutex
+	entries map[string]*list.Element // Values are *conditionalEntry
+	order   *list.List               // Most recently used at the front
+}
+
+// conditionalEntry is a kept response
+type conditionalEntry struct {
+	key          string
+	lastModified string
+	body         []byte
+	pagination   *PaginationResponse
+}
+
+// conditionalKey identifies a request by API key and URL, so responses for
+// different accounts are never mixed up
+func conditionalKey(apiKey, url string) string {
+	return apiKey + "\x00" + url
+}
+
+// get returns the kept response for key, if any
+func (s *conditionalStore) get(key string) *conditionalEntry {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	element, found := s.entries[key]
+	if !found {
+		return nil
+	}
+	s.order.MoveToFront(element)
+	return element.Value.(*conditionalEntry)
+}
+
+// set keeps a response, dropping the least recently used one when full
+func (s *conditionalStore) set(entry *conditionalEntry) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if element, found := s.entries[entry.key]; found {
+		element.Value = entry
+		s.order.MoveToFront(element)
+		return
+	}
+	s.entries[entry.key] = s.order.PushFront(entry)
+	for s.order.Len() > s.maxEntries {
+		oldest := s.order.Back()
+		s.order.Remove(oldest)
+		delete(s.entries, oldest.Value.(*conditionalEntry).key)
+	}
+}
+
+// response returns a copy of the kept body and pagination, so callers holding
+// a json.RawMessage from GetRaw can't change the kept one
+func (e *conditionalEntry) response() ([]byte, *PaginationResponse) {
+	var pagination *PaginationResponse
+	if e.pagination != nil {
+		clone := *e.pagination
+		pagination = &clone
+	}
+	return slices.Clone(e.body), pagination
+}
@@ -0,0 +1,80 @@
+package gw2api
+
+import (
+	"container/list"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestConditionalRequests(t *testing.T) {
+	const lastModified = "Wed, 13 Mar 2024 12:00:00 GMT"
+	var sent []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since := r.Header.Get("If-Modified-Since")
+		sent = append(sent, r.Header.Get("Authorization")+" "+since)
+		if since == lastModified {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Last-Modified", lastModified)
+		w.Write([]byte(`[{"id": 19721, "count": 250}]`))
+	}))
+	defer server.Close()
+
+	var notModified []bool
+	client := NewClient(WithAPIKey("first"), WithRetries(0), WithRateLimit(1000), WithConditionalRequests(DefaultConditionalEntries),
+		WithResponseHook(func(info RequestInfo) { notModified = append(notModified, info.NotModified) }))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	for range 2 {
+		materials, err := client.GetAccountMaterials(ctx)
+		if err != nil {
+			t.Fatalf("GetAccountMaterials: %v", err)
+		}
+		if len(materials) != 1 || materials[0].ID != 19721 || materials[0].Count != 250 {
+			t.Fatalf("materials = %+v", materials)
+		}
+	}
+
+	// Another key gets its own copy rather than the first account's
+	if _, err := client.With(WithAPIKey("second")).GetAccountMaterials(ctx); err != nil {
+		t.Fatalf("GetAccountMaterials: %v", err)
+	}
+
+	want := []string{"Bearer first ", "Bearer first " + lastModified, "Bearer second "}
+	if len(sent) != len(want) {
+		t.Fatalf("sent %q, expected %q", sent, want)
+	}
+	for i := range want {
+		if sent[i] != want[i] {
+			t.Errorf("request %d sent %q, expected %q", i, sent[i], want[i])
+		}
+	}
+	if len(notModified) != 3 || notModified[0] || !notModified[1] || notModified[2] {
+		t.Errorf("NotModified per request = %v, expected [false true false]", notModified)
+	}
+	if stats := client.Stats(); stats.NotModified != 1 {
+		t.Errorf("Stats().NotModified = %d, expected 1", stats.NotModified)
+	}
+}
+
+func TestConditionalStoreEviction(t *testing.T) {
+	store := &conditionalStore{maxEntries: 2, entries: make(map[string]*list.Element), order: list.New()}
+	for _, key := range []string{"a", "b"} {
+		store.set(&conditionalEntry{key: key, body: []byte(key)})
+	}
+	store.get("a") // b is now the least recently used
+	store.set(&conditionalEntry{key: "c", body: []byte("c")})
+
+	if store.get("b") != nil {
+		t.Error("b should have been evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if store.get(key) == nil {
+			t.Errorf("%s should be kept", key)
+		}
+	}
+}
@@ -0,0 +1,53 @@
+// Code generated by gencurrencies from currencies.json; DO NOT EDIT.
+
+package gw2api
+
+// Currency IDs, as used by the wallet and the currencies endpoint
+const (
+	CurrencyCoin                      = 1  // Coin
+	CurrencyKarma                     = 2  // Karma
+	CurrencyLaurel                    = 3  // Laurel
+	CurrencyGem                       = 4  // Gem
+	CurrencyAscalonianTear            = 5  // Ascalonian Tear
+	CurrencyShardOfZhaitan            = 6  // Shard of Zhaitan
+	CurrencyFractalRelic              = 7  // Fractal Relic
+	CurrencySealOfBeetletun           = 9  // Seal of Beetletun
+	CurrencyManifestoOfTheMoletariate = 10 // Manifesto of the Moletariate
+	CurrencyDeadlyBloom               = 11 // Deadly Bloom
+	CurrencySymbolOfKoda              = 12 // Symbol of Koda
+	CurrencyFlameLegionCharrCarving   = 13 // Flame Legion Charr Carving
+	CurrencyKnowledgeCrystal          = 14 // Knowledge Crystal
+	CurrencyBadgeOfHonor              = 15 // Badge of Honor
+	CurrencyGuildCommendation         = 16 // Guild Commendation
+	CurrencyTransmutationCharge       = 18 // Transmutation Charge
+	CurrencyAirshipPart               = 19 // Airship Part
+	CurrencyLeyLineCrystal            = 20 // Ley Line Crystal
+	CurrencyLumpOfAurillium           = 22 // Lump of Aurillium
+	CurrencySpiritShard               = 23 // Spirit Shard
+	CurrencyPristineFractalRelic      = 24 // Pristine Fractal Relic
+	CurrencyGeode                     = 25 // Geode
+	CurrencyWvWSkirmishClaimTicket    = 26 // WvW Skirmish Claim Ticket
+	CurrencyBanditCrest               = 27 // Bandit Crest
+	CurrencyMagnetiteShard            = 28 // Magnetite Shard
+	CurrencyProvisionerToken          = 29 // Provisioner Token
+	CurrencyPvPLeagueTicket           = 30 // PvP League Ticket
+	CurrencyProofOfHeroics            = 31 // Proof of Heroics
+	CurrencyUnboundMagic              = 32 // Unbound Magic
+	CurrencyAscendedShardsOfGlory     = 33 // Ascended Shards of Glory
+	CurrencyTradeContract             = 34 // Trade Contract
+	CurrencyElegyMosaic               = 35 // Elegy Mosaic
+	CurrencyTestimonyOfDesertHeroics  = 36 // Testimony of Desert Heroics
+	CurrencyExaltedKey                = 37 // Exalted Key
+	CurrencyMachete                   = 38 // Machete
+	CurrencyGaetingCrystal            = 39 // Gaeting Crystal
+	CurrencyBanditSkeletonKey         = 40 // Bandit Skeleton Key
+	CurrencyPactCrowbar               = 41 // Pact Crowbar
+	CurrencyVialOfChakAcid            = 42 // Vial of Chak Acid
+	CurrencyZephyriteLockpick         = 43 // Zephyrite Lockpick
+	CurrencyTradersKey                = 44 // Trader's Key
+	CurrencyVolatileMagic             = 45 // Volatile Magic
+	CurrencyPvPTournamentVoucher      = 46 // PvP Tournament Voucher
+	CurrencyRacingMedallion           = 47 // Racing Medallion
+	CurrencyMistbornKey               = 49 // Mistborn Key
+	CurrencyFestivalToken             = 50 // Festival Token
+)
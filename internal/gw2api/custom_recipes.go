@@ -0,0 +1,159 @@
+package gw2api
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Sources of supplemental recipes, which /v2/recipes does not list
+const (
+	RecipeSourceMysticForge = "mystic_forge"
+	RecipeSourceCustom      = "custom" // Used when a supplemental recipe has no source
+)
+
+// CustomRecipeIDBase is the lowest ID a supplemental recipe may use, leaving
+// room below it for official recipe IDs to grow
+const CustomRecipeIDBase = 1_000_000
+
+// CustomRecipesFileName is the supplemental recipe file in a data directory.
+// Like the other data files it holds one RecipeDetail per line.
+const CustomRecipesFileName = "custom_recipes.json"
+
+//go:embed custom_recipes_starter.json
+var starterCustomRecipes []byte
+
+// IsCustom reports whether the recipe came from a supplemental file rather than the API
+func (r *RecipeDetail) IsCustom() bool {
+	return r.Source != ""
+}
+
+// IsCustomRecipeID reports whether id belongs to the range reserved for supplemental recipes
+func IsCustomRecipeID(id int) bool {
+	return id >= CustomRecipeIDBase
+}
+
+// StarterCustomRecipes returns a small curated set of common Mystic Forge recipes,
+// suitable for seeding custom_recipes.json
+func StarterCustomRecipes() []*RecipeDetail {
+	recipes, err := parseCustomRecipes(bytes.NewReader(starterCustomRecipes))
+	if err != nil {
+		panic("invalid starter custom recipes: " + err.Error())
+	}
+	return recipes
+}
+
+// parseCustomRecipes reads supplemental recipes, one JSON object per line. Unlike
+// the official data files, which skip bad lines, the file is edited by hand, so
+// any invalid line is reported.
+func parseCustomRecipes(r io.Reader) ([]*RecipeDetail, error) {
+	var recipes []*RecipeDetail
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		recipe := new(RecipeDetail)
+		if err := json.Unmarshal(line, recipe); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		if err := prepareCustomRecipe(recipe); err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		recipes = append(recipes, recipe)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return recipes, nil
+}
+
+// prepareCustomRecipe fills in defaults and checks the recipe can be used in crafting trees
+func prepareCustomRecipe(recipe *RecipeDetail) error {
+	if recipe.Source == "" {
+		recipe.Source = RecipeSourceCustom
+	}
+	if recipe.OutputItemCount == 0 {
+		recipe.OutputItemCount = 1
+	}
+
+	switch {
+	case !IsCustomRecipeID(recipe.ID):
+		return fmt.Errorf("recipe %d: custom recipe IDs must be at least %d", recipe.ID, CustomRecipeIDBase)
+	case recipe.OutputItemID <= 0:
+		return fmt.Errorf("recipe %d: missing output_item_id", recipe.ID)
+	case recipe.OutputItemCount < 0:
+		return fmt.Errorf("recipe %d: negative output_item_count", recipe.ID)
+	case len(recipe.Ingredients) == 0:
+		return fmt.Errorf("recipe %d: no ingredients", recipe.ID)
+	}
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.ItemID <= 0 || ingredient.Count <= 0 {
+			return fmt.Errorf("recipe %d: invalid ingredient %+v", recipe.ID, ingredient)
+		}
+	}
+	return nil
+}
+
+// LoadCustomRecipesFromFile adds the supplemental recipes in filePath to the cache
+func (rc *RecipeCache) LoadCustomRecipesFromFile(filePath string) error {
+	file, err := openDataFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open custom recipes file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	recipes, err := parseCustomRecipes(file)
+	if err != nil {
+		return fmt.Errorf("invalid custom recipes file %s: %w", filePath, err)
+	}
+	return rc.AddCustomRecipes(recipes)
+}
+
+// AddCustomRecipes adds supplemental recipes alongside the official ones, so the
+// output and input indexes include them. Nothing is added if any recipe is
+// invalid or reuses an ID. Loading recipes.json again drops them.
+func (rc *RecipeCache) AddCustomRecipes(recipes []*RecipeDetail) error {
+	for _, recipe := range recipes {
+		if err := prepareCustomRecipe(recipe); err != nil {
+			return err
+		}
+	}
+
+	rc.mutex.Lock()
+	defer rc.mutex.Unlock()
+
+	seen := make(map[int]bool, len(recipes))
+	for _, recipe := range recipes {
+		if _, found := rc.byID[recipe.ID]; found || seen[recipe.ID] {
+			return fmt.Errorf("duplicate recipe ID %d", recipe.ID)
+		}
+		seen[recipe.ID] = true
+	}
+
+	for _, recipe := range recipes {
+		rc.add(recipe)
+	}
+	rc.loaded = true
+	return nil
+}
+
+// writeOfficialToFile rewrites recipes.json, leaving out the supplemental recipes
+// that live in their own file
+func (rc *RecipeCache) writeOfficialToFile(filePath string) error {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+
+	official := make([]*RecipeDetail, 0, len(rc.list))
+	for _, recipe := range rc.list {
+		if !recipe.IsCustom() {
+			official = append(official, recipe)
+		}
+	}
+	return writeJSONLAtomic(filePath, official)
+}
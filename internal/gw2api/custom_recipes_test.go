@@ -0,0 +1,107 @@
+package gw2api
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestStarterCustomRecipes(t *testing.T) {
+	recipes := StarterCustomRecipes()
+	if len(recipes) == 0 {
+		t.Fatal("starter dataset is empty")
+	}
+	for _, recipe := range recipes {
+		if recipe.Source != RecipeSourceMysticForge || !IsCustomRecipeID(recipe.ID) {
+			t.Errorf("recipe %d: source %q, expected a Mystic Forge recipe in the custom ID range", recipe.ID, recipe.Source)
+		}
+	}
+}
+
+func TestLoadCustomRecipes(t *testing.T) {
+	dir := t.TempDir()
+	// An official recipe for Gift of Fortune's ingredient, and a forge recipe using it
+	official := `{"id":1,"type":"Refinement","output_item_id":19721,"output_item_count":1,"disciplines":["Tailor"],"ingredients":[{"item_id":19700,"count":2}]}` + "\n"
+	custom := `{"id":1000001,"output_item_id":19626,"ingredients":[{"item_id":19721,"count":250},{"item_id":19675,"count":77}],"source":"mystic_forge"}` + "\n\n" +
+		`{"id":1000002,"output_item_id":19675,"output_item_count":2,"ingredients":[{"item_id":19721,"count":1}]}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "recipes.json"), []byte(official), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, CustomRecipesFileName), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dc := NewDataCache()
+	if err := dc.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	if stats := dc.Stats(); stats.RecipesLoaded != 1 || stats.CustomRecipesLoaded != 2 {
+		t.Errorf("loaded %d official and %d custom recipes, expected 1 and 2", stats.RecipesLoaded, stats.CustomRecipesLoaded)
+	}
+
+	recipes := dc.GetRecipeCache()
+	if ids := recipes.SearchByOutput(19626); !slices.Equal(ids, []int{1000001}) {
+		t.Errorf("SearchByOutput(Gift of Fortune) = %v, expected the forge recipe", ids)
+	}
+	if ids := recipes.SearchByInput(19721); !slices.Equal(ids, []int{1000001, 1000002}) {
+		t.Errorf("SearchByInput(ectoplasm) = %v, expected both custom recipes", ids)
+	}
+
+	forge, found := recipes.GetByIDRef(1000001)
+	if !found || !forge.IsCustom() || forge.Source != RecipeSourceMysticForge || forge.OutputItemCount != 1 {
+		t.Errorf("forge recipe = %+v, expected a Mystic Forge recipe with the default output count", forge)
+	}
+	if untagged, _ := recipes.GetByIDRef(1000002); untagged.Source != RecipeSourceCustom {
+		t.Errorf("untagged recipe source = %q, expected %q", untagged.Source, RecipeSourceCustom)
+	}
+	if officialRecipe, _ := recipes.GetByIDRef(1); officialRecipe.IsCustom() {
+		t.Error("official recipe is reported as custom")
+	}
+
+	// Persisting recipes.json must not copy the custom recipes into it
+	if err := recipes.writeOfficialToFile(filepath.Join(dir, "recipes.json")); err != nil {
+		t.Fatalf("writeOfficialToFile: %v", err)
+	}
+	written, err := os.ReadFile(filepath.Join(dir, "recipes.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(written), "1000001") || !strings.Contains(string(written), `"id":1,`) {
+		t.Errorf("recipes.json = %s, expected only the official recipe", written)
+	}
+}
+
+func TestAddCustomRecipesErrors(t *testing.T) {
+	valid := func(id int) *RecipeDetail {
+		return &RecipeDetail{ID: id, OutputItemID: 19626, Ingredients: []RecipeIngredient{{ItemID: 19721, Count: 1}}}
+	}
+	tests := map[string][]*RecipeDetail{
+		"official ID range":    {valid(1000001), valid(42)},
+		"duplicate ID":         {valid(1000001), valid(1000001)},
+		"missing output":       {{ID: 1000001, Ingredients: []RecipeIngredient{{ItemID: 19721, Count: 1}}}},
+		"no ingredients":       {{ID: 1000001, OutputItemID: 19626}},
+		"zero ingredient":      {{ID: 1000001, OutputItemID: 19626, Ingredients: []RecipeIngredient{{ItemID: 19721}}}},
+		"negative output size": {{ID: 1000001, OutputItemID: 19626, OutputItemCount: -1, Ingredients: []RecipeIngredient{{ItemID: 19721, Count: 1}}}},
+	}
+
+	for name, recipes := range tests {
+		t.Run(name, func(t *testing.T) {
+			rc := NewRecipeCache()
+			if err := rc.AddCustomRecipes(recipes); err == nil {
+				t.Fatal("expected an error")
+			}
+			if rc.Size() != 0 {
+				t.Errorf("cache holds %d recipes after a failed add, expected none", rc.Size())
+			}
+		})
+	}
+}
+
+func TestParseCustomRecipesReportsLine(t *testing.T) {
+	input := `{"id":1000001,"output_item_id":19626,"ingredients":[{"item_id":19721,"count":1}]}` + "\n" + `{"id":`
+	if _, err := parseCustomRecipes(strings.NewReader(input)); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("error = %v, expected it to name line 2", err)
+	}
+}
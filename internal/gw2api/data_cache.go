@@ -1,10 +1,11 @@
 package gw2api
 
 import (
-	"bufio"
-	"encoding/json"
 	"fmt"
+	"log/slog"
 	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -16,19 +17,48 @@ type DataCache struct {
 	skills       *SkillCache
 	achievements *AchievementCache
 	recipes      *RecipeCache
+	materials    *MaterialCache
+	skins        *SkinCache
+	vendors      *VendorCache
+	currencies   *CurrencyCache
+	dataDir      string
+	loadedFrom   string // Cleaned directory of the last completed load
+	loadErr      error  // Result of that load
+	loading      bool
+	persist      bool
+	logger       *slog.Logger // Nil for slog.Default()
 	mutex        sync.RWMutex
+	loadMutex    sync.Mutex // Held for a whole load, so loads run one at a time
 	stats        DataCacheStats
 }
 
 // DataCacheStats tracks overall cache performance
 type DataCacheStats struct {
-	LoadTime           time.Duration
-	LastLoadTime       time.Time
-	TotalCacheHits     int64
-	ItemsLoaded        int
-	SkillsLoaded       int
-	AchievementsLoaded int
-	RecipesLoaded      int
+	DataDir             string
+	LoadTime            time.Duration
+	LastLoadTime        time.Time
+	TotalCacheHits      int64
+	TotalCacheMisses    int64
+	Kinds               []CacheKindStats // One entry per kind of data, in load order
+	ItemsLoaded         int
+	SkillsLoaded        int
+	AchievementsLoaded  int
+	RecipesLoaded       int
+	CustomRecipesLoaded int
+	MaterialsLoaded     int
+	SkinsLoaded         int
+	VendorsLoaded       int
+	CurrenciesLoaded    int
+}
+
+// CacheKindStats is the state of one kind of cached data, such as "items"
+type CacheKindStats struct {
+	Kind     string
+	Records  int
+	Corrupt  int // Invalid lines skipped while loading
+	LoadTime time.Duration
+	Hits     int64
+	Misses   int64
 }
 
 // NewDataCache creates a new comprehensive data cache
@@ -38,67 +68,184 @@ func NewDataCache() *DataCache {
 		skills:       NewSkillCache(),
 		achievements: NewAchievementCache(),
 		recipes:      NewRecipeCache(),
+		materials:    NewMaterialCache(),
+		skins:        NewSkinCache(),
+		vendors:      NewVendorCache(),
+		currencies:   NewCurrencyCache(),
 	}
 }
 
-// LoadFromDirectory loads all data files from the specified directory
+// LoadFromDirectory loads all data files from the specified directory. Each
+// file is read through its binary sidecar when that is up to date, and the
+// sidecar is rebuilt when it isn't.
+//
+// Loading is idempotent: once a directory has loaded, loading it again returns
+// the first result without rereading the files, so clients sharing the cache
+// can all ask for it. Concurrent calls load once and the others wait for it.
+// Loading a different directory, or the same one after Clear, reloads.
+//
+// Lookups and Stats keep working during a load: each kind of data is empty
+// until its file has been read, so clients fall back to the API for it.
 func (dc *DataCache) LoadFromDirectory(dataDir string) error {
+	cleaned := filepath.Clean(dataDir)
+	if loaded, err := dc.loadResult(cleaned); loaded {
+		return err
+	}
+
+	dc.loadMutex.Lock()
+	defer dc.loadMutex.Unlock()
+
+	// Another caller may have loaded the directory while we waited
+	if loaded, err := dc.loadResult(cleaned); loaded {
+		return err
+	}
+
+	// Every file is optional, but a missing directory is almost always a mistake.
+	// It isn't remembered, so the directory can be created and loaded later.
+	if _, err := os.Stat(dataDir); err != nil {
+		return err
+	}
+
+	dc.mutex.Lock()
+	dc.dataDir = dataDir
+	dc.loading = true
+	stats := dc.stats
+	dc.mutex.Unlock()
+
+	err := dc.loadFiles(dataDir, &stats)
+
 	dc.mutex.Lock()
 	defer dc.mutex.Unlock()
+	dc.stats = stats
+	dc.loading = false
+	dc.loadErr = err
+	dc.loadedFrom = cleaned
+	return err
+}
+
+// loadResult returns the result of the last load if it was from dir
+func (dc *DataCache) loadResult(dir string) (bool, error) {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.loadedFrom == dir, dc.loadErr
+}
+
+// Loading reports whether LoadFromDirectory is reading files
+func (dc *DataCache) Loading() bool {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.loading
+}
 
+// Loaded reports whether a LoadFromDirectory call has finished since the
+// cache was created or cleared, even if some files failed to load
+func (dc *DataCache) Loaded() bool {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.loadedFrom != ""
+}
+
+// LoadError returns the error of the last finished LoadFromDirectory call, which
+// may have loaded some files anyway
+func (dc *DataCache) LoadError() error {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.loadErr
+}
+
+// loadFiles loads every data file in dataDir, recording the counts in stats;
+// callers must hold loadMutex. Problems with single files are returned as
+// *DataFileError values, and the other files still load.
+func (dc *DataCache) loadFiles(dataDir string, stats *DataCacheStats) error {
 	startTime := time.Now()
-	var errors []string
+	var errs []error
 
-	// Load items
-	itemsPath := fmt.Sprintf("%s/items.json", dataDir)
-	if _, err := os.Stat(itemsPath); err == nil {
-		if err := dc.items.LoadFromFile(itemsPath); err != nil {
-			errors = append(errors, fmt.Sprintf("items: %v", err))
-		} else {
-			dc.stats.ItemsLoaded = dc.items.Size()
-		}
+	manifest, err := ReadManifest(dataDir)
+	if err != nil {
+		errs = append(errs, &DataFileError{Kind: "manifest", Path: filepath.Join(dataDir, ManifestFileName), Err: err})
 	}
 
-	// Load skills
-	skillsPath := fmt.Sprintf("%s/skills.json", dataDir)
-	if _, err := os.Stat(skillsPath); err == nil {
-		if err := dc.skills.LoadFromFile(skillsPath); err != nil {
-			errors = append(errors, fmt.Sprintf("skills: %v", err))
-		} else {
-			dc.stats.SkillsLoaded = dc.skills.Size()
+	for _, loader := range []struct {
+		kind   CacheKind
+		load   func(string) error
+		stats  func() cacheStats
+		loaded *int
+	}{
+		{CacheKindItems, dc.items.loadPreferBinary, dc.items.snapshot, &stats.ItemsLoaded},
+		{CacheKindSkills, dc.skills.loadPreferBinary, dc.skills.snapshot, &stats.SkillsLoaded},
+		{CacheKindAchievements, dc.achievements.loadPreferBinary, dc.achievements.snapshot, &stats.AchievementsLoaded},
+		{CacheKindRecipes, dc.recipes.loadPreferBinary, dc.recipes.snapshot, &stats.RecipesLoaded},
+		{CacheKindMaterials, dc.materials.loadPreferBinary, dc.materials.snapshot, &stats.MaterialsLoaded},
+		{CacheKindSkins, dc.skins.loadPreferBinary, dc.skins.snapshot, &stats.SkinsLoaded},
+		{CacheKindVendors, dc.vendors.loadPreferBinary, dc.vendors.snapshot, &stats.VendorsLoaded},
+		{CacheKindCurrencies, dc.currencies.loadPreferBinary, dc.currencies.snapshot, &stats.CurrenciesLoaded},
+	} {
+		name := loader.kind.FileName()
+		filePath, found := findDataFile(dataDir, name)
+		if !found {
+			// Every file is optional unless the manifest says it was written
+			if count, listed := manifest.Count(name); listed {
+				errs = append(errs, &DataFileError{
+					Kind: string(loader.kind),
+					Path: filepath.Join(dataDir, name),
+					Err:  fmt.Errorf("%w: manifest lists %d entries", ErrDataFileMissing, count),
+				})
+			}
+			continue
+		}
+		if err := loader.load(filePath); err != nil {
+			errs = append(errs, &DataFileError{Kind: string(loader.kind), Path: filePath, Err: err})
+			continue
+		}
+		loaded := loader.stats()
+		*loader.loaded = loaded.loaded
+		if err := dc.checkLoaded(loader.kind, filePath, loaded, manifest); err != nil {
+			errs = append(errs, err)
 		}
 	}
 
-	// Load achievements
-	achievementsPath := fmt.Sprintf("%s/achievements.json", dataDir)
-	if _, err := os.Stat(achievementsPath); err == nil {
-		if err := dc.achievements.LoadFromFile(achievementsPath); err != nil {
-			errors = append(errors, fmt.Sprintf("achievements: %v", err))
-		} else {
-			dc.stats.AchievementsLoaded = dc.achievements.Size()
+	// Load item names in other languages, such as items.fr.json
+	for _, lang := range Languages {
+		if namesPath, found := findDataFile(dataDir, LocalizedFileName("items.json", lang)); found {
+			if err := dc.items.LoadNamesFromFile(lang, namesPath); err != nil {
+				errs = append(errs, &DataFileError{Kind: fmt.Sprintf("%s item names", lang), Path: namesPath, Err: err})
+			}
 		}
 	}
 
-	// Load recipes
-	recipesPath := fmt.Sprintf("%s/recipes.json", dataDir)
-	if _, err := os.Stat(recipesPath); err == nil {
-		if err := dc.recipes.LoadFromFile(recipesPath); err != nil {
-			errors = append(errors, fmt.Sprintf("recipes: %v", err))
+	// Load supplemental recipes, such as Mystic Forge ones, after the official
+	// recipes so they are added alongside them
+	if customRecipesPath, found := findDataFile(dataDir, CustomRecipesFileName); found {
+		before := dc.recipes.Size()
+		if err := dc.recipes.LoadCustomRecipesFromFile(customRecipesPath); err != nil {
+			errs = append(errs, &DataFileError{Kind: "custom recipes", Path: customRecipesPath, Err: err})
 		} else {
-			dc.stats.RecipesLoaded = dc.recipes.Size()
+			stats.CustomRecipesLoaded = dc.recipes.Size() - before
 		}
 	}
 
-	dc.stats.LoadTime = time.Since(startTime)
-	dc.stats.LastLoadTime = time.Now()
+	stats.LoadTime = time.Since(startTime)
+	stats.LastLoadTime = time.Now()
 
-	if len(errors) > 0 {
-		return fmt.Errorf("cache loading errors: %s", strings.Join(errors, ", "))
+	if len(errs) > 0 {
+		return dataLoadErrors(errs)
 	}
 
 	return nil
 }
 
+// LoadDataCache creates a data cache and loads it from dataDir. Applications
+// can load the data once this way and pass the cache to every client with
+// WithSharedDataCache. The cache keeps whatever did load when there is an
+// error, which is a *CacheLoadError.
+func LoadDataCache(dataDir string) (*DataCache, error) {
+	dc := NewDataCache()
+	if err := dc.LoadFromDirectory(dataDir); err != nil {
+		return dc, &CacheLoadError{Path: dataDir, Err: err}
+	}
+	return dc, nil
+}
+
 // GetItemCache returns the item cache
 func (dc *DataCache) GetItemCache() *ItemCache {
 	dc.mutex.RLock()
@@ -127,22 +274,74 @@ func (dc *DataCache) GetRecipeCache() *RecipeCache {
 	return dc.recipes
 }
 
+// GetMaterialCache returns the material category cache
+func (dc *DataCache) GetMaterialCache() *MaterialCache {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.materials
+}
+
+// GetSkinCache returns the skin cache
+func (dc *DataCache) GetSkinCache() *SkinCache {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.skins
+}
+
+// GetVendorCache returns the vendor cache
+func (dc *DataCache) GetVendorCache() *VendorCache {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.vendors
+}
+
+// GetCurrencyCache returns the currency cache
+func (dc *DataCache) GetCurrencyCache() *CurrencyCache {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	return dc.currencies
+}
+
 // Stats returns overall cache statistics
 func (dc *DataCache) Stats() DataCacheStats {
 	dc.mutex.RLock()
 	defer dc.mutex.RUnlock()
 
-	// Aggregate cache hits from all sub-caches
-	dc.stats.TotalCacheHits = dc.items.stats.CacheHits +
-		dc.skills.stats.CacheHits +
-		dc.achievements.stats.CacheHits +
-		dc.recipes.stats.CacheHits
+	stats := dc.stats
+	stats.DataDir = dc.dataDir
+	stats.TotalCacheHits, stats.TotalCacheMisses = 0, 0
+
+	// Aggregate counters from all sub-caches
+	for _, s := range []cacheStats{
+		dc.items.snapshot(),
+		dc.skills.snapshot(),
+		dc.achievements.snapshot(),
+		dc.recipes.snapshot(),
+		dc.materials.snapshot(),
+		dc.skins.snapshot(),
+		dc.vendors.snapshot(),
+		dc.currencies.snapshot(),
+	} {
+		stats.Kinds = append(stats.Kinds, CacheKindStats{
+			Kind:     s.kind,
+			Records:  s.loaded,
+			Corrupt:  s.corrupt,
+			LoadTime: s.loadTime,
+			Hits:     s.hits,
+			Misses:   s.misses,
+		})
+		stats.TotalCacheHits += s.hits
+		stats.TotalCacheMisses += s.misses
+	}
 
-	return dc.stats
+	return stats
 }
 
 // Clear clears all caches
 func (dc *DataCache) Clear() {
+	// Wait for a load in progress rather than clearing under it
+	dc.loadMutex.Lock()
+	defer dc.loadMutex.Unlock()
 	dc.mutex.Lock()
 	defer dc.mutex.Unlock()
 
@@ -150,16 +349,17 @@ func (dc *DataCache) Clear() {
 	dc.skills.Clear()
 	dc.achievements.Clear()
 	dc.recipes.Clear()
+	dc.materials.Clear()
+	dc.skins.Clear()
+	dc.vendors.Clear()
+	dc.currencies.Clear()
 	dc.stats = DataCacheStats{}
+	dc.loadedFrom, dc.loadErr = "", nil
 }
 
 // SkillCache provides in-memory caching of skills
 type SkillCache struct {
-	skills     map[int]*Skill // ID -> Skill mapping
-	skillsList []*Skill       // All skills as slice
-	loaded     bool
-	mutex      sync.RWMutex
-	stats      SkillCacheStats
+	jsonlCache[Skill]
 }
 
 // SkillCacheStats tracks skill cache performance
@@ -174,192 +374,54 @@ type SkillCacheStats struct {
 // NewSkillCache creates a new skill cache
 func NewSkillCache() *SkillCache {
 	return &SkillCache{
-		skills:     make(map[int]*Skill),
-		skillsList: make([]*Skill, 0),
-		loaded:     false,
-	}
-}
-
-// LoadFromFile loads all skills from a JSONL file
-func (sc *SkillCache) LoadFromFile(filePath string) error {
-	sc.mutex.Lock()
-	defer sc.mutex.Unlock()
-
-	startTime := time.Now()
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open skills file %s: %w", filePath, err)
-	}
-	defer file.Close()
-
-	// Clear existing data
-	sc.skills = make(map[int]*Skill)
-	sc.skillsList = make([]*Skill, 0)
-
-	scanner := bufio.NewScanner(file)
-	skillCount := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
-		}
-
-		var skill Skill
-		if err := json.Unmarshal([]byte(line), &skill); err != nil {
-			// Skip invalid lines but continue processing
-			continue
-		}
-
-		sc.skills[skill.ID] = &skill
-		sc.skillsList = append(sc.skillsList, &skill)
-		skillCount++
-	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading skills file: %w", err)
-	}
-
-	sc.loaded = true
-	sc.stats.LoadedSkills = skillCount
-	sc.stats.LoadTime = time.Since(startTime)
-	sc.stats.LastLoadTime = time.Now()
-
-	return nil
-}
-
-// GetByID retrieves a skill by its ID
-func (sc *SkillCache) GetByID(id int) (*Skill, bool) {
-	sc.mutex.RLock()
-	defer sc.mutex.RUnlock()
-
-	if !sc.loaded {
-		sc.stats.CacheMisses++
-		return nil, false
-	}
-
-	skill, found := sc.skills[id]
-	if found {
-		sc.stats.CacheHits++
-	} else {
-		sc.stats.CacheMisses++
+		jsonlCache: newJSONLCache("skills", func(skill *Skill) int { return skill.ID }),
 	}
-
-	return skill, found
-}
-
-// GetByIDs retrieves multiple skills by their IDs
-func (sc *SkillCache) GetByIDs(ids []int) []*Skill {
-	sc.mutex.RLock()
-	defer sc.mutex.RUnlock()
-
-	if !sc.loaded {
-		sc.stats.CacheMisses += int64(len(ids))
-		return nil
-	}
-
-	results := make([]*Skill, 0, len(ids))
-	for _, id := range ids {
-		if skill, found := sc.skills[id]; found {
-			results = append(results, skill)
-			sc.stats.CacheHits++
-		} else {
-			sc.stats.CacheMisses++
-		}
-	}
-
-	return results
 }
 
 // SearchSkills performs in-memory search on cached skills
 func (sc *SkillCache) SearchSkills(query string, profession string, skillType string, limit int) []*Skill {
-	sc.mutex.RLock()
-	defer sc.mutex.RUnlock()
-
-	if !sc.loaded {
-		return nil
-	}
-
-	var results []*Skill
-	count := 0
-
-	if limit == 0 {
-		limit = 50
-	}
-
-	for _, skill := range sc.skillsList {
-		match := true
-
+	query = strings.ToLower(query)
+	return sc.search(limit, func(skill *Skill) bool {
 		// Check name match
-		if query != "" && !strings.Contains(strings.ToLower(skill.Name), strings.ToLower(query)) {
-			match = false
+		if query != "" && !strings.Contains(strings.ToLower(skill.Name), query) {
+			return false
 		}
 
 		// Check profession filter
-		if profession != "" && match {
-			professionMatch := false
-			for _, prof := range skill.Professions {
-				if strings.EqualFold(prof, profession) {
-					professionMatch = true
-					break
-				}
-			}
-			if !professionMatch {
-				match = false
-			}
+		if profession != "" && !slices.ContainsFunc(skill.Professions, func(prof string) bool {
+			return strings.EqualFold(prof, profession)
+		}) {
+			return false
 		}
 
 		// Check skill type filter
-		if skillType != "" && match && !strings.EqualFold(skill.Type, skillType) {
-			match = false
-		}
-
-		if match {
-			results = append(results, skill)
-			count++
-			if count >= limit {
-				break
-			}
-		}
-	}
-
-	sc.stats.CacheHits++
-	return results
-}
-
-// IsLoaded returns whether the cache has been loaded
-func (sc *SkillCache) IsLoaded() bool {
-	sc.mutex.RLock()
-	defer sc.mutex.RUnlock()
-	return sc.loaded
+		return skillType == "" || strings.EqualFold(skill.Type, skillType)
+	})
 }
 
-// Size returns the number of skills in the cache
-func (sc *SkillCache) Size() int {
-	sc.mutex.RLock()
-	defer sc.mutex.RUnlock()
-	return len(sc.skillsList)
-}
-
-// Clear clears the cache
-func (sc *SkillCache) Clear() {
-	sc.mutex.Lock()
-	defer sc.mutex.Unlock()
-
-	sc.skills = make(map[int]*Skill)
-	sc.skillsList = make([]*Skill, 0)
-	sc.loaded = false
-	sc.stats = SkillCacheStats{}
+// Stats returns cache statistics
+func (sc *SkillCache) Stats() SkillCacheStats {
+	s := sc.snapshot()
+	return SkillCacheStats{
+		LoadedSkills: s.loaded,
+		LoadTime:     s.loadTime,
+		CacheHits:    s.hits,
+		CacheMisses:  s.misses,
+		LastLoadTime: s.lastLoadTime,
+	}
 }
 
 // AchievementCache provides in-memory caching of achievements
 type AchievementCache struct {
-	achievements     map[int]*Achievement // ID -> Achievement mapping
-	achievementsList []*Achievement       // All achievements as slice
-	loaded           bool
-	mutex            sync.RWMutex
-	stats            AchievementCacheStats
+	jsonlCache[Achievement]
+	achievementsByBit map[AchievementBitKey][]int // Bit target -> achievement IDs with that bit
+	requiredBy        map[int][]int               // Prerequisite ID -> IDs of achievements requiring it
+}
+
+// AchievementBitKey identifies the item, skin or minipet an achievement bit refers to
+type AchievementBitKey struct {
+	Type string // Bit type, e.g. "Item", "Skin", "Minipet"
+	ID   int
 }
 
 // AchievementCacheStats tracks achievement cache performance
@@ -373,166 +435,87 @@ type AchievementCacheStats struct {
 
 // NewAchievementCache creates a new achievement cache
 func NewAchievementCache() *AchievementCache {
-	return &AchievementCache{
-		achievements:     make(map[int]*Achievement),
-		achievementsList: make([]*Achievement, 0),
-		loaded:           false,
+	ac := &AchievementCache{
+		jsonlCache:        newJSONLCache("achievements", func(achievement *Achievement) int { return achievement.ID }),
+		achievementsByBit: make(map[AchievementBitKey][]int),
+		requiredBy:        make(map[int][]int),
 	}
-}
-
-// LoadFromFile loads all achievements from a JSONL file
-func (ac *AchievementCache) LoadFromFile(filePath string) error {
-	ac.mutex.Lock()
-	defer ac.mutex.Unlock()
-
-	startTime := time.Now()
-
-	file, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open achievements file %s: %w", filePath, err)
+	ac.resetIndex = func() {
+		ac.achievementsByBit = make(map[AchievementBitKey][]int)
+		ac.requiredBy = make(map[int][]int)
 	}
-	defer file.Close()
-
-	// Clear existing data
-	ac.achievements = make(map[int]*Achievement)
-	ac.achievementsList = make([]*Achievement, 0)
-
-	scanner := bufio.NewScanner(file)
-	achievementCount := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
+	ac.index = func(achievement *Achievement) {
+		for _, id := range achievement.Prerequisites {
+			ac.requiredBy[id] = append(ac.requiredBy[id], achievement.ID)
 		}
-
-		var achievement Achievement
-		if err := json.Unmarshal([]byte(line), &achievement); err != nil {
-			// Skip invalid lines but continue processing
-			continue
+		for _, bit := range achievement.Bits {
+			// Text bits have no ID to index
+			if bit.ID != 0 {
+				key := AchievementBitKey{Type: bit.Type, ID: bit.ID}
+				ac.achievementsByBit[key] = append(ac.achievementsByBit[key], achievement.ID)
+			}
 		}
-
-		ac.achievements[achievement.ID] = &achievement
-		ac.achievementsList = append(ac.achievementsList, &achievement)
-		achievementCount++
 	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading achievements file: %w", err)
+	ac.unindex = func(achievement *Achievement) {
+		for _, id := range achievement.Prerequisites {
+			ac.requiredBy[id] = removeID(ac.requiredBy[id], achievement.ID)
+		}
+		for _, bit := range achievement.Bits {
+			key := AchievementBitKey{Type: bit.Type, ID: bit.ID}
+			ac.achievementsByBit[key] = removeID(ac.achievementsByBit[key], achievement.ID)
+		}
 	}
-
-	ac.loaded = true
-	ac.stats.LoadedAchievements = achievementCount
-	ac.stats.LoadTime = time.Since(startTime)
-	ac.stats.LastLoadTime = time.Now()
-
-	return nil
+	return ac
 }
 
-// GetByID retrieves an achievement by its ID
-func (ac *AchievementCache) GetByID(id int) (*Achievement, bool) {
+// AchievementsForBit returns the IDs of achievements with a bit of the given type
+// referring to id, e.g. AchievementsForBit("Item", 12345)
+func (ac *AchievementCache) AchievementsForBit(bitType string, id int) []int {
 	ac.mutex.RLock()
 	defer ac.mutex.RUnlock()
 
-	if !ac.loaded {
-		ac.stats.CacheMisses++
-		return nil, false
-	}
-
-	achievement, found := ac.achievements[id]
-	if found {
-		ac.stats.CacheHits++
-	} else {
-		ac.stats.CacheMisses++
+	ids := ac.achievementsByBit[AchievementBitKey{Type: bitType, ID: id}]
+	if len(ids) == 0 {
+		ac.misses.Add(1)
+		return nil
 	}
 
-	return achievement, found
+	ac.hits.Add(1)
+	return slices.Clone(ids)
 }
 
-// GetByIDs retrieves multiple achievements by their IDs
-func (ac *AchievementCache) GetByIDs(ids []int) []*Achievement {
+// AchievementsRequiring returns the IDs of achievements that list id among
+// their prerequisites
+func (ac *AchievementCache) AchievementsRequiring(id int) []int {
 	ac.mutex.RLock()
 	defer ac.mutex.RUnlock()
 
-	if !ac.loaded {
-		ac.stats.CacheMisses += int64(len(ids))
+	ids := ac.requiredBy[id]
+	if len(ids) == 0 {
+		ac.misses.Add(1)
 		return nil
 	}
 
-	results := make([]*Achievement, 0, len(ids))
-	for _, id := range ids {
-		if achievement, found := ac.achievements[id]; found {
-			results = append(results, achievement)
-			ac.stats.CacheHits++
-		} else {
-			ac.stats.CacheMisses++
-		}
-	}
-
-	return results
+	ac.hits.Add(1)
+	return slices.Clone(ids)
 }
 
 // SearchAchievements performs in-memory search on cached achievements
 func (ac *AchievementCache) SearchAchievements(query string, limit int) []*Achievement {
-	ac.mutex.RLock()
-	defer ac.mutex.RUnlock()
-
-	if !ac.loaded {
-		return nil
-	}
-
-	var results []*Achievement
-	count := 0
-
-	if limit == 0 {
-		limit = 50
-	}
-
-	for _, achievement := range ac.achievementsList {
-		match := true
-
-		// Check name match
-		if query != "" && !strings.Contains(strings.ToLower(achievement.Name), strings.ToLower(query)) {
-			match = false
-		}
-
-		// Check category filter (if Achievement has Category field)
-		// Note: Achievement structure may vary, adapt as needed
-
-		if match {
-			results = append(results, achievement)
-			count++
-			if count >= limit {
-				break
-			}
-		}
-	}
-
-	ac.stats.CacheHits++
-	return results
-}
-
-// IsLoaded returns whether the cache has been loaded
-func (ac *AchievementCache) IsLoaded() bool {
-	ac.mutex.RLock()
-	defer ac.mutex.RUnlock()
-	return ac.loaded
+	query = strings.ToLower(query)
+	return ac.search(limit, func(achievement *Achievement) bool {
+		return query == "" || strings.Contains(strings.ToLower(achievement.Name), query)
+	})
 }
 
-// Size returns the number of achievements in the cache
-func (ac *AchievementCache) Size() int {
-	ac.mutex.RLock()
-	defer ac.mutex.RUnlock()
-	return len(ac.achievementsList)
-}
-
-// Clear clears the cache
-func (ac *AchievementCache) Clear() {
-	ac.mutex.Lock()
-	defer ac.mutex.Unlock()
-
-	ac.achievements = make(map[int]*Achievement)
-	ac.achievementsList = make([]*Achievement, 0)
-	ac.loaded = false
-	ac.stats = AchievementCacheStats{}
+// Stats returns cache statistics
+func (ac *AchievementCache) Stats() AchievementCacheStats {
+	s := ac.snapshot()
+	return AchievementCacheStats{
+		LoadedAchievements: s.loaded,
+		LoadTime:           s.loadTime,
+		CacheHits:          s.hits,
+		CacheMisses:        s.misses,
+		LastLoadTime:       s.lastLoadTime,
+	}
 }
@@ -0,0 +1,94 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ManifestFileName is the file in a data directory recording how many entries
+// each data file was written with, so loading can tell a complete file from one
+// cut short
+const ManifestFileName = "manifest.json"
+
+// DataManifest lists the data files written to a data directory
+type DataManifest struct {
+	Files map[string]ManifestEntry `json:"files"` // Keyed by name without CompressedSuffix, such as items.json
+}
+
+// ManifestEntry records one written data file
+type ManifestEntry struct {
+	Count     int       `json:"count"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReadManifest reads the manifest of dataDir. A directory without one, such as
+// one written before manifests existed, has an empty manifest.
+func ReadManifest(dataDir string) (*DataManifest, error) {
+	manifest := &DataManifest{Files: make(map[string]ManifestEntry)}
+	data, err := os.ReadFile(filepath.Join(dataDir, ManifestFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFileName, err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = make(map[string]ManifestEntry)
+	}
+	return manifest, nil
+}
+
+// Count returns the number of entries the named data file was written with,
+// and false if the manifest doesn't list it. Compressed and uncompressed names
+// share an entry.
+func (m *DataManifest) Count(name string) (int, bool) {
+	if m == nil {
+		return 0, false
+	}
+	entry, found := m.Files[strings.TrimSuffix(name, CompressedSuffix)]
+	return entry.Count, found
+}
+
+// RecordManifestCount records in the manifest of dataDir that the named data
+// file has just been written with count entries. The manifest is replaced
+// atomically, like the data files.
+func RecordManifestCount(dataDir, name string, count int) error {
+	manifest, err := ReadManifest(dataDir)
+	if err != nil {
+		return err
+	}
+	manifest.Files[strings.TrimSuffix(name, CompressedSuffix)] = ManifestEntry{Count: count, UpdatedAt: time.Now().UTC()}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	manifestPath := filepath.Join(dataDir, ManifestFileName)
+	tmp, err := os.CreateTemp(dataDir, "."+ManifestFileName+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	_, err = tmp.Write(append(data, '\n'))
+	if err == nil {
+		err = tmp.Chmod(replacedMode(manifestPath))
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), manifestPath)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write %s: %w", manifestPath, err)
+	}
+	return nil
+}
@@ -0,0 +1,252 @@
+package gw2api
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"strings"
+)
+
+// CorruptLineThreshold is the share of invalid lines in a data file above which
+// loading it logs a warning and reports a *DataFileError. A line or two can be
+// a known bad API record; more usually means the file was cut short.
+const CorruptLineThreshold = 0.001
+
+// ErrDataFileMissing matches a *DataFileError for a data file the manifest
+// lists but the data directory doesn't have
+var ErrDataFileMissing = errors.New("data file missing")
+
+// ErrDataFileCorrupt matches a *DataFileError for a data file with too many
+// invalid lines, or fewer entries than the manifest lists
+var ErrDataFileCorrupt = errors.New("data file corrupt")
+
+// DataFileError reports a problem with one data file found by LoadFromDirectory.
+// It is a soft error: the other files load as usual, and a corrupt file keeps
+// whatever entries were valid. Use errors.Is with ErrDataFileMissing or
+// ErrDataFileCorrupt to tell the problems apart, and DataFileErrors to list
+// them from a load error.
+type DataFileError struct {
+	Kind string // Kind of data, such as "items" or "fr item names"
+	Path string
+	Err  error
+}
+
+func (e *DataFileError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Kind, e.Err)
+}
+
+func (e *DataFileError) Unwrap() error {
+	return e.Err
+}
+
+// dataLoadErrors is the error from loading a directory with problem files
+type dataLoadErrors []error
+
+func (e dataLoadErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return "cache loading errors: " + strings.Join(messages, ", ")
+}
+
+func (e dataLoadErrors) Unwrap() []error {
+	return e
+}
+
+// DataFileErrors returns every *DataFileError within err, such as the error
+// from LoadFromDirectory, LoadDataCache or Client.DataCacheError
+func DataFileErrors(err error) []*DataFileError {
+	var found []*DataFileError
+	var walk func(error)
+	walk = func(err error) {
+		switch err := err.(type) {
+		case nil:
+		case *DataFileError:
+			found = append(found, err)
+		case interface{ Unwrap() []error }:
+			for _, inner := range err.Unwrap() {
+				walk(inner)
+			}
+		default:
+			walk(errors.Unwrap(err))
+		}
+	}
+	walk(err)
+	return found
+}
+
+// SetLogger sets the logger warnings about corrupt data files go to, which is
+// slog.Default() unless set
+func (dc *DataCache) SetLogger(logger *slog.Logger) {
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+	dc.logger = logger
+}
+
+// log returns the logger for warnings
+func (dc *DataCache) log() *slog.Logger {
+	dc.mutex.RLock()
+	defer dc.mutex.RUnlock()
+	if dc.logger == nil {
+		return slog.Default()
+	}
+	return dc.logger
+}
+
+// checkLoaded reports a data file that loaded with more invalid lines than
+// CorruptLineThreshold allows, or with fewer entries than the manifest lists
+func (dc *DataCache) checkLoaded(kind CacheKind, filePath string, loaded cacheStats, manifest *DataManifest) error {
+	var problems []string
+	if lines := loaded.loaded + loaded.corrupt; loaded.corrupt > 0 && float64(loaded.corrupt)/float64(lines) > CorruptLineThreshold {
+		problems = append(problems, fmt.Sprintf("%d of %d lines are invalid", loaded.corrupt, lines))
+	}
+	if count, listed := manifest.Count(kind.FileName()); listed && loaded.loaded < count {
+		problems = append(problems, fmt.Sprintf("%d entries loaded, manifest lists %d", loaded.loaded, count))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+
+	dc.log().Warn("data file is incomplete or corrupt",
+		slog.String("kind", string(kind)),
+		slog.String("path", filePath),
+		slog.Int("loaded", loaded.loaded),
+		slog.Int("corrupt_lines", loaded.corrupt))
+	return &DataFileError{
+		Kind: string(kind),
+		Path: filePath,
+		Err:  fmt.Errorf("%w: %s", ErrDataFileCorrupt, strings.Join(problems, ", ")),
+	}
+}
+
+// DataFileDiagnostics describes the state of one data file on disk
+type DataFileDiagnostics struct {
+	Kind          string
+	Path          string
+	Missing       bool     // Listed in the manifest but not in the data directory
+	Lines         int      // Non-empty lines
+	Corrupt       int      // Lines that aren't a valid entry
+	Duplicates    int      // Valid lines repeating the ID of an earlier one
+	ManifestCount int      // Entries the manifest lists, or -1 if it doesn't list the file
+	Problems      []string // Empty when the file looks complete
+}
+
+// OK reports whether no problems were found
+func (d DataFileDiagnostics) OK() bool {
+	return len(d.Problems) == 0
+}
+
+// Validate rereads the data files of the loaded directory and checks each for
+// invalid lines, duplicate IDs and entry counts differing from the manifest.
+// Files that are absent and not in the manifest are left out.
+func (dc *DataCache) Validate() ([]DataFileDiagnostics, error) {
+	dataDir := dc.Stats().DataDir
+	if dataDir == "" {
+		return nil, fmt.Errorf("no data directory loaded")
+	}
+	manifest, err := ReadManifest(dataDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var diagnostics []DataFileDiagnostics
+	for _, validator := range []struct {
+		kind CacheKind
+		scan func(string) (fileScan, error)
+	}{
+		{CacheKindItems, dc.items.scanFile},
+		{CacheKindSkills, dc.skills.scanFile},
+		{CacheKindAchievements, dc.achievements.scanFile},
+		{CacheKindRecipes, dc.recipes.scanFile},
+		{CacheKindMaterials, dc.materials.scanFile},
+		{CacheKindSkins, dc.skins.scanFile},
+		{CacheKindVendors, dc.vendors.scanFile},
+		{CacheKindCurrencies, dc.currencies.scanFile},
+	} {
+		name := validator.kind.FileName()
+		diagnostic := DataFileDiagnostics{Kind: string(validator.kind), ManifestCount: -1}
+		if count, listed := manifest.Count(name); listed {
+			diagnostic.ManifestCount = count
+		}
+
+		filePath, found := findDataFile(dataDir, name)
+		if !found {
+			if diagnostic.ManifestCount < 0 {
+				continue
+			}
+			diagnostic.Path = filepath.Join(dataDir, name)
+			diagnostic.Missing = true
+			diagnostic.Problems = append(diagnostic.Problems, "file is missing")
+			diagnostics = append(diagnostics, diagnostic)
+			continue
+		}
+		diagnostic.Path = filePath
+
+		scan, err := validator.scan(filePath)
+		if err != nil {
+			diagnostic.Problems = append(diagnostic.Problems, err.Error())
+			diagnostics = append(diagnostics, diagnostic)
+			continue
+		}
+		diagnostic.Lines, diagnostic.Corrupt, diagnostic.Duplicates = scan.lines, scan.corrupt, scan.duplicates
+
+		if diagnostic.Corrupt > 0 {
+			diagnostic.Problems = append(diagnostic.Problems, fmt.Sprintf("%d invalid lines", diagnostic.Corrupt))
+		}
+		if diagnostic.Duplicates > 0 {
+			diagnostic.Problems = append(diagnostic.Problems, fmt.Sprintf("%d duplicate IDs", diagnostic.Duplicates))
+		}
+		if diagnostic.ManifestCount >= 0 && diagnostic.Lines != diagnostic.ManifestCount {
+			diagnostic.Problems = append(diagnostic.Problems, fmt.Sprintf("%d lines, manifest lists %d", diagnostic.Lines, diagnostic.ManifestCount))
+		}
+		diagnostics = append(diagnostics, diagnostic)
+	}
+	return diagnostics, nil
+}
+
+// fileScan counts the lines of a data file
+type fileScan struct {
+	lines      int
+	corrupt    int
+	duplicates int
+}
+
+// scanFile counts the lines, invalid lines and duplicate IDs of a JSONL data
+// file without loading it into the cache
+func (c *jsonlCache[T]) scanFile(filePath string) (fileScan, error) {
+	var scan fileScan
+	file, err := openDataFile(filePath)
+	if err != nil {
+		return scan, err
+	}
+	defer file.Close()
+
+	seen := make(map[int]bool)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		scan.lines++
+
+		value := new(T)
+		if err := json.Unmarshal(line, value); err != nil {
+			scan.corrupt++
+			continue
+		}
+		id := c.idOf(value)
+		if seen[id] {
+			scan.duplicates++
+		}
+		seen[id] = true
+	}
+	if err := scanner.Err(); err != nil {
+		return scan, fmt.Errorf("error reading %s file: %w", c.kind, err)
+	}
+	return scan, nil
+}
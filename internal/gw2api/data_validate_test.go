@@ -0,0 +1,145 @@
+package gw2api
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLoadCorruptDataFiles(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		// Cut short mid-line, as by an interrupted write
+		"items.json":     `{"id": 1, "name": "Sword"}` + "\n" + `{"id": 2, "name": "Ingot"}` + "\n" + `{"id": 3, "na`,
+		"materials.json": `{"id": 5, "name": "Basic Crafting Materials", "items": [19697]}` + "\n",
+		ManifestFileName: `{"files": {"items.json": {"count": 3}, "skills.json": {"count": 2}, "materials.json": {"count": 1}}}`,
+	})
+
+	for _, load := range []string{"from JSONL", "from sidecars"} {
+		var logs bytes.Buffer
+		dc := NewDataCache()
+		dc.SetLogger(slog.New(slog.NewTextHandler(&logs, nil)))
+		err := dc.LoadFromDirectory(dir)
+
+		fileErrs := DataFileErrors(err)
+		if len(fileErrs) != 2 {
+			t.Fatalf("%s: DataFileErrors(%v) = %v, expected items and skills", load, err, fileErrs)
+		}
+		if fileErrs[0].Kind != "items" || !errors.Is(fileErrs[0], ErrDataFileCorrupt) || errors.Is(fileErrs[0], ErrDataFileMissing) {
+			t.Errorf("%s: first error = %v, expected corrupt items", load, fileErrs[0])
+		}
+		if fileErrs[1].Kind != "skills" || !errors.Is(fileErrs[1], ErrDataFileMissing) {
+			t.Errorf("%s: second error = %v, expected missing skills", load, fileErrs[1])
+		}
+		if !strings.Contains(logs.String(), "kind=items") || !strings.Contains(logs.String(), "corrupt_lines=1") {
+			t.Errorf("%s: logged %q, expected a warning for items", load, logs.String())
+		}
+
+		// Valid entries still load and the skipped line is counted
+		if size := dc.GetItemCache().Size(); size != 2 {
+			t.Errorf("%s: loaded %d items, expected 2", load, size)
+		}
+		for _, kind := range dc.Stats().Kinds {
+			if kind.Kind == "items" && kind.Corrupt != 1 {
+				t.Errorf("%s: items stats = %+v, expected 1 corrupt line", load, kind)
+			}
+		}
+	}
+
+	// The load error keeps the file errors when wrapped
+	_, err := LoadDataCache(dir)
+	if fileErrs := DataFileErrors(err); len(fileErrs) != 2 {
+		t.Errorf("DataFileErrors(LoadDataCache) = %v, expected 2", fileErrs)
+	}
+}
+
+func TestLoadFewCorruptLines(t *testing.T) {
+	lines := slices.Repeat([]string{`{"id": 1, "name": "Sword"}`}, 2000)
+	lines = append(lines, "not json")
+	dir := writeDataDir(t, map[string]string{"items.json": strings.Join(lines, "\n") + "\n"})
+
+	// One bad line in 2001 is under the threshold
+	dc := NewDataCache()
+	if err := dc.LoadFromDirectory(dir); err != nil {
+		t.Errorf("LoadFromDirectory = %v, expected no error under the threshold", err)
+	}
+	if corrupt := dc.GetItemCache().CorruptLines(); corrupt != 1 {
+		t.Errorf("CorruptLines = %d, expected 1", corrupt)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		"items.json":     `{"id": 1}` + "\n" + `{"id": 1}` + "\n" + `{"id": 2}` + "\n" + "{\n",
+		"materials.json": `{"id": 5, "items": [19697]}` + "\n",
+		ManifestFileName: `{"files": {"items.json": {"count": 4}, "skills.json": {"count": 2}, "materials.json": {"count": 1}}}`,
+	})
+	dc := NewDataCache()
+	dc.SetLogger(slog.New(slog.DiscardHandler))
+	dc.LoadFromDirectory(dir)
+
+	diagnostics, err := dc.Validate()
+	if err != nil {
+		t.Fatalf("Validate: %v", err)
+	}
+	if len(diagnostics) != 3 {
+		t.Fatalf("diagnostics = %+v, expected items, skills and materials", diagnostics)
+	}
+
+	items := diagnostics[0]
+	if items.Kind != "items" || items.Lines != 4 || items.Corrupt != 1 || items.Duplicates != 1 || items.ManifestCount != 4 || items.OK() {
+		t.Errorf("items = %+v, expected 4 lines, 1 corrupt, 1 duplicate", items)
+	}
+	if skills := diagnostics[1]; skills.Kind != "skills" || !skills.Missing || skills.OK() {
+		t.Errorf("skills = %+v, expected missing", skills)
+	}
+	if materials := diagnostics[2]; materials.Kind != "materials" || !materials.OK() {
+		t.Errorf("materials = %+v, expected OK", materials)
+	}
+
+	if _, err := NewDataCache().Validate(); err == nil {
+		t.Error("Validate succeeded without a loaded directory")
+	}
+}
+
+func TestRecordManifestCount(t *testing.T) {
+	dir := t.TempDir()
+	writer, err := CreateJSONL(filepath.Join(dir, "items.json.gz"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	writer.Encode(map[string]int{"id": 1})
+	writer.Write([]byte(`{"id": 2}` + "\n" + `{"id": 3}` + "\n"))
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if writer.Lines() != 3 {
+		t.Errorf("Lines = %d, expected 3", writer.Lines())
+	}
+
+	if err := RecordManifestCount(dir, "items.json.gz", writer.Lines()); err != nil {
+		t.Fatalf("RecordManifestCount: %v", err)
+	}
+	if err := RecordManifestCount(dir, "skills.json", 7); err != nil {
+		t.Fatalf("RecordManifestCount: %v", err)
+	}
+
+	manifest, err := ReadManifest(dir)
+	if err != nil {
+		t.Fatalf("ReadManifest: %v", err)
+	}
+	if count, found := manifest.Count("items.json"); !found || count != 3 {
+		t.Errorf("items count = %d, %v, expected 3 under the uncompressed name", count, found)
+	}
+	if count, _ := manifest.Count("skills.json"); count != 7 {
+		t.Errorf("skills count = %d, expected 7", count)
+	}
+
+	// Directories without a manifest have an empty one
+	if manifest, err := ReadManifest(t.TempDir()); err != nil || len(manifest.Files) != 0 {
+		t.Errorf("ReadManifest(empty) = %+v, %v", manifest, err)
+	}
+}
@@ -0,0 +1,89 @@
+package gw2api
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// Trading post fees, as a percentage of the sale price. Both are charged when
+// selling, and each is at least 1 copper.
+const (
+	ListingFeePercent  = 5
+	ExchangeFeePercent = 10
+)
+
+// TradingPostFees returns the listing and exchange fees for selling items for
+// a total of gross coins
+func TradingPostFees(gross int) int {
+	if gross <= 0 {
+		return 0
+	}
+	return tradingPostFee(gross, ListingFeePercent) + tradingPostFee(gross, ExchangeFeePercent)
+}
+
+func tradingPostFee(gross, percent int) int {
+	return max(1, int(math.Round(float64(gross)*float64(percent)/100)))
+}
+
+// OrderFill estimates an order filled against the trading post order book.
+// Filled is less than Requested when the book doesn't hold enough.
+type OrderFill struct {
+	Requested int `json:"requested"`
+	Filled    int `json:"filled"`
+	Total     int `json:"total"`      // Coins paid, or received after fees when selling
+	Fees      int `json:"fees"`       // Trading post fees included in Total, only when selling
+	LastPrice int `json:"last_price"` // Unit price of the last unit filled
+}
+
+// Complete reports whether the book holds enough to fill the whole order
+func (f OrderFill) Complete() bool {
+	return f.Filled >= f.Requested
+}
+
+// AveragePrice is Total spread over the units filled
+func (f OrderFill) AveragePrice() float64 {
+	if f.Filled == 0 {
+		return 0
+	}
+	return float64(f.Total) / float64(f.Filled)
+}
+
+// EstimateAcquisitionCost walks the sell listings from the cheapest up and
+// returns what buying quantity units instantly would cost
+func EstimateAcquisitionCost(listing *Listing, quantity int) OrderFill {
+	sells := slices.SortedFunc(slices.Values(listing.Sells), func(a, b ListingInfo) int {
+		return cmp.Compare(a.UnitPrice, b.UnitPrice)
+	})
+	return fillOrder(sells, quantity)
+}
+
+// EstimateLiquidationValue walks the buy orders from the highest down and
+// returns what selling quantity units instantly would earn after fees
+func EstimateLiquidationValue(listing *Listing, quantity int) OrderFill {
+	buys := slices.SortedFunc(slices.Values(listing.Buys), func(a, b ListingInfo) int {
+		return cmp.Compare(b.UnitPrice, a.UnitPrice)
+	})
+	fill := fillOrder(buys, quantity)
+	fill.Fees = TradingPostFees(fill.Total)
+	fill.Total -= fill.Fees
+	return fill
+}
+
+// fillOrder takes quantity units from the ladder in order
+func fillOrder(ladder []ListingInfo, quantity int) OrderFill {
+	fill := OrderFill{Requested: quantity}
+	for _, level := range ladder {
+		if fill.Filled >= quantity {
+			break
+		}
+		take := min(level.Quantity, quantity-fill.Filled)
+		if take <= 0 {
+			continue
+		}
+		fill.Filled += take
+		fill.Total += take * level.UnitPrice
+		fill.LastPrice = level.UnitPrice
+	}
+	return fill
+}
@@ -0,0 +1,56 @@
+package gw2api
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestEstimateAcquisitionCost(t *testing.T) {
+	var listings []Listing
+	decodeStrict(t, filepath.Join("commerce", "listings.json"), &listings)
+	ecto := &listings[0]
+
+	// 126 at 7019, then the rest from the 7020 level
+	fill := EstimateAcquisitionCost(ecto, 250)
+	if !fill.Complete() || fill.Total != 126*7019+124*7020 || fill.LastPrice != 7020 {
+		t.Errorf("fill = %+v, expected both price levels", fill)
+	}
+	if average := fill.AveragePrice(); average <= 7019 || average >= 7020 {
+		t.Errorf("average = %f, expected between the two levels", average)
+	}
+
+	if fill := EstimateAcquisitionCost(ecto, 100); fill.Total != 100*7019 || fill.LastPrice != 7019 {
+		t.Errorf("small fill = %+v, expected only the top of the book", fill)
+	}
+
+	fill = EstimateAcquisitionCost(ecto, 1000)
+	if fill.Complete() || fill.Filled != 566 {
+		t.Errorf("oversized fill = %+v, expected the 566 listed", fill)
+	}
+}
+
+func TestEstimateLiquidationValue(t *testing.T) {
+	var listings []Listing
+	decodeStrict(t, filepath.Join("commerce", "listings.json"), &listings)
+
+	fill := EstimateLiquidationValue(&listings[0], 300)
+	gross := 250*7018 + 50*7017
+	if fill.Fees != TradingPostFees(gross) || fill.Total != gross-fill.Fees || fill.LastPrice != 7017 {
+		t.Errorf("fill = %+v, expected %d less fees", fill, gross)
+	}
+}
+
+func TestTradingPostFees(t *testing.T) {
+	tests := map[int]int{
+		0:     0,
+		1:     2, // Each fee is at least 1 copper
+		100:   15,
+		10000: 1500,
+		7019:  351 + 702,
+	}
+	for gross, expected := range tests {
+		if fees := TradingPostFees(gross); fees != expected {
+			t.Errorf("TradingPostFees(%d) = %d, expected %d", gross, fees, expected)
+		}
+	}
+}
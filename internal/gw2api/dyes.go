@@ -0,0 +1,184 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Dye rarities, as listed in a color's categories
+const (
+	DyeRarityStarter   = "Starter"
+	DyeRarityCommon    = "Common"
+	DyeRarityUncommon  = "Uncommon"
+	DyeRarityRare      = "Rare"
+	DyeRarityExclusive = "Exclusive"
+)
+
+// DyeRarities lists the dye rarities from most to least common
+var DyeRarities = []string{DyeRarityStarter, DyeRarityCommon, DyeRarityUncommon, DyeRarityRare, DyeRarityExclusive}
+
+// dyeHues and dyeMaterials are the values of the other kinds of color category
+var (
+	dyeHues      = []string{"Gray", "Brown", "Red", "Orange", "Yellow", "Green", "Blue", "Purple"}
+	dyeMaterials = []string{"Vibrant", "Leather", "Metal"}
+)
+
+// MissingDye is a dye the account hasn't unlocked, with the item that teaches it
+type MissingDye struct {
+	ColorID  int    `json:"color_id"`
+	Name     string `json:"name"`
+	ItemID   int    `json:"item_id,omitempty"` // Zero if no item teaches the dye
+	Hue      string `json:"hue,omitempty"`
+	Material string `json:"material,omitempty"`
+	Rarity   string `json:"rarity,omitempty"`
+	BaseRGB  []int  `json:"base_rgb,omitempty"`
+
+	// Price is the lowest trading post sell listing for the item, and zero
+	// when it isn't for sale
+	Price Coins `json:"price,omitempty"`
+
+	// GemStore is set for exclusive dyes, which come from the gem store's dye kits
+	GemStore bool `json:"gem_store,omitempty"`
+
+	// Reason says why a dye can't be bought, for dyes in MissingDyes.Unavailable
+	Reason string `json:"reason,omitempty"`
+}
+
+// MissingDyes is the dyes an account is missing, split by whether they can be
+// bought on the trading post
+type MissingDyes struct {
+	Unlocked int `json:"unlocked"` // Dyes the account has
+	Total    int `json:"total"`    // Dyes in the game
+
+	// Ranked is the dyes for sale on the trading post, cheapest first
+	Ranked []MissingDye `json:"ranked"`
+
+	// Unavailable is the dyes without an item, and those whose item is not
+	// for sale, such as exclusives only found in gem store dye kits
+	Unavailable []MissingDye `json:"unavailable"`
+}
+
+// Cost returns the total price of the ranked dyes
+func (d *MissingDyes) Cost() Coins {
+	var total Coins
+	for _, dye := range d.Ranked {
+		total += dye.Price
+	}
+	return total
+}
+
+// Filter returns the missing dyes of the rarities, or all of them if none are
+// given, leaving out ranked dyes costing more than maxPrice unless it is zero
+func (d *MissingDyes) Filter(rarities []string, maxPrice Coins) *MissingDyes {
+	keep := func(dye MissingDye) bool {
+		return len(rarities) == 0 || slices.ContainsFunc(rarities, func(r string) bool { return strings.EqualFold(r, dye.Rarity) })
+	}
+	filtered := &MissingDyes{Unlocked: d.Unlocked, Total: d.Total}
+	for _, dye := range d.Ranked {
+		if keep(dye) && (maxPrice <= 0 || dye.Price <= maxPrice) {
+			filtered.Ranked = append(filtered.Ranked, dye)
+		}
+	}
+	for _, dye := range d.Unavailable {
+		if keep(dye) {
+			filtered.Unavailable = append(filtered.Unavailable, dye)
+		}
+	}
+	return filtered
+}
+
+// newMissingDye sorts a color's categories into its hue, material and rarity
+func newMissingDye(color Color) MissingDye {
+	dye := MissingDye{ColorID: color.ID, Name: color.Name, ItemID: color.Item, BaseRGB: color.BaseRGB}
+	for _, category := range color.Categories {
+		switch {
+		case slices.Contains(dyeHues, category):
+			dye.Hue = category
+		case slices.Contains(dyeMaterials, category):
+			dye.Material = category
+		case slices.Contains(DyeRarities, category):
+			dye.Rarity = category
+		}
+	}
+	dye.GemStore = dye.Rarity == DyeRarityExclusive
+	return dye
+}
+
+// RankMissingDyes joins the colors the account hasn't unlocked to the trading
+// post prices of the items that teach them. Dyes for sale are ranked by price,
+// then name; the rest are listed by name with the reason they can't be bought.
+func RankMissingDyes(colors []Color, unlocked []Dye, prices map[int]*Price) *MissingDyes {
+	owned := make(map[int]bool, len(unlocked))
+	for _, id := range unlocked {
+		owned[int(id)] = true
+	}
+
+	result := &MissingDyes{Total: len(colors)}
+	for _, color := range colors {
+		if owned[color.ID] {
+			result.Unlocked++
+			continue
+		}
+		dye := newMissingDye(color)
+		price := prices[color.Item]
+		switch {
+		case color.Item == 0:
+			dye.Reason = "no item teaches this dye"
+		case price != nil && price.Sells.UnitPrice > 0:
+			dye.Price = Coins(price.Sells.UnitPrice)
+			result.Ranked = append(result.Ranked, dye)
+			continue
+		case dye.GemStore:
+			dye.Reason = "gem store exclusive"
+		case price != nil:
+			dye.Reason = "no sell listings"
+		default:
+			dye.Reason = "not on the trading post"
+		}
+		result.Unavailable = append(result.Unavailable, dye)
+	}
+
+	slices.SortStableFunc(result.Ranked, func(a, b MissingDye) int {
+		if a.Price != b.Price {
+			return int(a.Price - b.Price)
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	slices.SortStableFunc(result.Unavailable, func(a, b MissingDye) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return result
+}
+
+// GetMissingDyesRanked returns the dyes the account hasn't unlocked, with
+// those whose dye item is for sale ranked by trading post price
+// Scopes: account, unlocks
+func (c *Client) GetMissingDyesRanked(ctx context.Context) (*MissingDyes, error) {
+	unlocked, err := c.GetAccountDyes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account dyes: %w", err)
+	}
+	colors, err := GetAll[Color](ctx, c, "/v2/colors")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get colors: %w", err)
+	}
+
+	owned := make(map[int]bool, len(unlocked))
+	for _, id := range unlocked {
+		owned[int(id)] = true
+	}
+	var itemIDs []int
+	for _, color := range colors {
+		if !owned[color.ID] && color.Item != 0 {
+			itemIDs = append(itemIDs, color.Item)
+		}
+	}
+	prices, err := c.lookupPrices(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dye prices: %w", err)
+	}
+
+	return RankMissingDyes(colors, unlocked, prices), nil
+}
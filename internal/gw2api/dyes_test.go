@@ -0,0 +1,86 @@
+package gw2api
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+// dyeNames lists the names of the dyes in order
+func dyeNames(dyes []MissingDye) []string {
+	names := make([]string, len(dyes))
+	for i, dye := range dyes {
+		names[i] = dye.Name
+	}
+	return names
+}
+
+func TestRankMissingDyes(t *testing.T) {
+	var colors []Color
+	var unlocked []Dye
+	var priceList []*Price
+	decodeStrict(t, "dyes/colors.json", &colors)
+	decodeStrict(t, "dyes/account_dyes.json", &unlocked)
+	decodeStrict(t, "dyes/prices.json", &priceList)
+	prices := make(map[int]*Price, len(priceList))
+	for _, price := range priceList {
+		prices[price.ID] = price
+	}
+
+	missing := RankMissingDyes(colors, unlocked, prices)
+	if missing.Unlocked != 3 || missing.Total != 10 {
+		t.Errorf("unlocked %d of %d, expected 3 of 10", missing.Unlocked, missing.Total)
+	}
+
+	// Equal prices are ranked by name
+	if names, expected := dyeNames(missing.Ranked), []string{"Ember", "Frost", "Celestial", "Abyss"}; !slices.Equal(names, expected) {
+		t.Errorf("ranked = %v, expected %v", names, expected)
+	}
+	if abyss := missing.Ranked[3]; abyss.Price != 215000 || abyss.ItemID != 20372 || abyss.Hue != "Gray" || abyss.Material != "Leather" || abyss.Rarity != DyeRarityRare {
+		t.Errorf("Abyss = %+v, expected the lowest sell listing and its categories", abyss)
+	}
+	if cost := missing.Cost(); cost != 262690 {
+		t.Errorf("Cost() = %v, expected 26g 26s 90c", cost)
+	}
+
+	reasons := make(map[string]string)
+	for _, dye := range missing.Unavailable {
+		reasons[dye.Name] = dye.Reason
+	}
+	if names, expected := dyeNames(missing.Unavailable), []string{"Midnight Ice", "Permafrost", "Toxic"}; !slices.Equal(names, expected) {
+		t.Errorf("unavailable = %v, expected %v", names, expected)
+	}
+	if reasons["Toxic"] != "gem store exclusive" || !missing.Unavailable[2].GemStore {
+		t.Errorf("Toxic = %+v, expected a gem store exclusive", missing.Unavailable[2])
+	}
+	if reasons["Permafrost"] != "no sell listings" || reasons["Midnight Ice"] != "not on the trading post" {
+		t.Errorf("reasons = %v", reasons)
+	}
+
+	uncommon := missing.Filter([]string{"uncommon"}, 0)
+	if names, expected := dyeNames(uncommon.Ranked), []string{"Ember", "Frost"}; !slices.Equal(names, expected) {
+		t.Errorf("uncommon ranked = %v, expected %v", names, expected)
+	}
+	if names, expected := dyeNames(uncommon.Unavailable), []string{"Midnight Ice", "Permafrost"}; !slices.Equal(names, expected) {
+		t.Errorf("uncommon unavailable = %v, expected %v", names, expected)
+	}
+	if cheap := missing.Filter(nil, 5*10000); !slices.Equal(dyeNames(cheap.Ranked), []string{"Ember", "Frost", "Celestial"}) || len(cheap.Unavailable) != 3 {
+		t.Errorf("under 5g = %v and %d unavailable, expected Abyss left out", dyeNames(cheap.Ranked), len(cheap.Unavailable))
+	}
+}
+
+func TestGetMissingDyesRanked(t *testing.T) {
+	client := newFixtureClient(t, "dyes", map[string]string{
+		"/v2/account/dyes":    "account_dyes.json",
+		"/v2/colors":          "colors.json",
+		"/v2/commerce/prices": "prices.json",
+	})
+
+	missing, err := client.GetMissingDyesRanked(context.Background())
+	if err != nil {
+		t.Fatalf("GetMissingDyesRanked: %v", err)
+	}
+	if len(missing.Ranked) != 4 || len(missing.Unavailable) != 3 {
+		t.Errorf("got %d ranked and %d unavailable, expected 4 and 3", len(missing.Ranked), len(missing.Unavailable))
+	}
+}
@@ -0,0 +1,34 @@
+// Package embeddata is a small sample of the game data, embedded so the gw2api
+// package can be tried, tested and demonstrated without running updatedb or
+// calling the API for static data. Load it with DataCache.LoadEmbedded, or
+// serve it as a fake API with package gw2apitest.
+//
+// The sample is a few connected crafting trees, such as iron ore to ingots to
+// weapons, with every item and recipe in them, plus a spread of other items
+// and some skills, achievements, material categories and currencies. Regenerate
+// it from a full data directory with go generate; see gen for the options.
+//
+// The files checked in now are a hand-written placeholder of 64 items
+// in the shape of the API's, not output of gen. IDs and names follow the game,
+// but other fields may not, so don't rely on them matching the live API until
+// the sample is regenerated from an updatedb dump.
+package embeddata
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:generate go run ./gen -from ../../../data -out data
+
+//go:embed data/*.json
+var files embed.FS
+
+// FS returns the data files, named as in a data directory, such as items.json
+func FS() fs.FS {
+	data, err := fs.Sub(files, "data")
+	if err != nil {
+		panic("embeddata: " + err.Error())
+	}
+	return data
+}
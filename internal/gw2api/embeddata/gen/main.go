@@ -0,0 +1,212 @@
+// Command gen writes the embedded sample data of package embeddata from a full
+// data directory, as written by updatedb.
+//
+// It starts from seed items, by default iron ore, and follows the recipes that
+// use them up to -depth crafting steps. Each recipe found is then completed
+// downwards, so every ingredient and the recipes making it are included and
+// the crafting trees stay intact. The sample is topped up with items spread
+// evenly across the item IDs, and a spread of skills and achievements.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// defaultSeeds are iron ore and wood, whose recipes lead to weapons, armor
+// and the common refined materials
+const defaultSeeds = "19699,19723"
+
+func main() {
+	var (
+		from         = flag.String("from", "data", "Full data directory to sample")
+		out          = flag.String("out", "data", "Directory to write the sample to")
+		seeds        = flag.String("seeds", defaultSeeds, "Item IDs whose recipe trees are included, comma-separated")
+		depth        = flag.Int("depth", 3, "Crafting steps to follow up from the seeds")
+		usesPerItem  = flag.Int("uses", 4, "Recipes to follow per item at each step, lowest IDs first")
+		items        = flag.Int("items", 300, "Total items to include, counting the crafting trees")
+		skills       = flag.Int("skills", 40, "Skills to include")
+		achievements = flag.Int("achievements", 40, "Achievements to include")
+		maxSize      = flag.Int("max-size", 500<<10, "Fail if the sample is larger than this many bytes")
+	)
+	flag.Parse()
+
+	if err := run(*from, *out, *seeds, *depth, *usesPerItem, *items, *skills, *achievements, *maxSize); err != nil {
+		fmt.Fprintf(os.Stderr, "gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(from, out, seedList string, depth, usesPerItem, itemCount, skillCount, achievementCount, maxSize int) error {
+	var seeds []int
+	for _, field := range strings.Split(seedList, ",") {
+		id, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return fmt.Errorf("invalid seed %q", field)
+		}
+		seeds = append(seeds, id)
+	}
+
+	dc, err := gw2api.LoadDataCache(from)
+	var loadErr *gw2api.CacheLoadError
+	if err != nil && !errors.As(err, &loadErr) {
+		return err
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gen: warning: %v\n", err)
+	}
+	recipeCache := dc.GetRecipeCache()
+	itemCache := dc.GetItemCache()
+
+	// Follow recipes up from the seeds, then complete each one downwards
+	recipes := make(map[int]*gw2api.RecipeDetail)
+	var complete func(recipe *gw2api.RecipeDetail)
+	complete = func(recipe *gw2api.RecipeDetail) {
+		if _, found := recipes[recipe.ID]; found {
+			return
+		}
+		recipes[recipe.ID] = recipe
+		for _, ingredient := range recipe.Ingredients {
+			if ids := recipeCache.SearchByOutput(ingredient.ItemID); len(ids) > 0 {
+				if made, found := recipeCache.GetByIDRef(slices.Min(ids)); found {
+					complete(made)
+				}
+			}
+		}
+	}
+	frontier := seeds
+	for range depth {
+		var next []int
+		for _, itemID := range frontier {
+			uses := recipeCache.SearchByInput(itemID)
+			slices.Sort(uses)
+			for _, id := range uses[:min(len(uses), usesPerItem)] {
+				if recipe, found := recipeCache.GetByIDRef(id); found {
+					complete(recipe)
+					next = append(next, recipe.OutputItemID)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	itemIDs := make(map[int]bool)
+	for _, id := range seeds {
+		itemIDs[id] = true
+	}
+	for _, recipe := range recipes {
+		itemIDs[recipe.OutputItemID] = true
+		for _, ingredient := range recipe.Ingredients {
+			itemIDs[ingredient.ItemID] = true
+		}
+	}
+	allItems := sortedByID(itemCache.GetAllRef(), func(item *gw2api.Item) int { return item.ID })
+	for _, item := range spread(allItems, itemCount-len(itemIDs)) {
+		itemIDs[item.ID] = true
+	}
+
+	var sampleItems []*gw2api.Item
+	for _, item := range allItems {
+		if itemIDs[item.ID] {
+			sampleItems = append(sampleItems, item)
+		}
+	}
+	sampleRecipes := sortedByID(mapValues(recipes), func(recipe *gw2api.RecipeDetail) int { return recipe.ID })
+	sampleSkills := spread(sortedByID(dc.GetSkillCache().GetAllRef(), func(skill *gw2api.Skill) int { return skill.ID }), skillCount)
+	sampleAchievements := spread(sortedByID(dc.GetAchievementCache().GetAllRef(), func(a *gw2api.Achievement) int { return a.ID }), achievementCount)
+
+	if err := os.MkdirAll(out, 0o755); err != nil {
+		return err
+	}
+	total := 0
+	for _, file := range []struct {
+		kind   gw2api.CacheKind
+		values []any
+	}{
+		{gw2api.CacheKindItems, anySlice(sampleItems)},
+		{gw2api.CacheKindRecipes, anySlice(sampleRecipes)},
+		{gw2api.CacheKindSkills, anySlice(sampleSkills)},
+		{gw2api.CacheKindAchievements, anySlice(sampleAchievements)},
+		{gw2api.CacheKindMaterials, anySlice(dc.GetMaterialCache().GetAllRef())},
+		{gw2api.CacheKindCurrencies, anySlice(dc.GetCurrencyCache().GetAllRef())},
+	} {
+		path := filepath.Join(out, file.kind.FileName())
+		size, err := writeJSONL(path, file.values)
+		if err != nil {
+			return err
+		}
+		total += size
+		fmt.Printf("%s: %d entries, %d bytes\n", path, len(file.values), size)
+	}
+	if total > maxSize {
+		return fmt.Errorf("sample is %d bytes, over the %d byte limit; lower -items or -depth", total, maxSize)
+	}
+	return nil
+}
+
+// writeJSONL writes values to path a line each, returning the file size
+func writeJSONL(path string, values []any) (int, error) {
+	writer, err := gw2api.CreateJSONL(path)
+	if err != nil {
+		return 0, err
+	}
+	for _, value := range values {
+		if err := writer.Encode(value); err != nil {
+			writer.Abort()
+			return 0, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return 0, err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return 0, err
+	}
+	return int(info.Size()), nil
+}
+
+// spread returns up to n values evenly spaced through values
+func spread[T any](values []T, n int) []T {
+	if n <= 0 {
+		return nil
+	}
+	if n >= len(values) {
+		return values
+	}
+	picked := make([]T, 0, n)
+	for i := range n {
+		picked = append(picked, values[i*len(values)/n])
+	}
+	return picked
+}
+
+func sortedByID[T any](values []*T, id func(*T) int) []*T {
+	sorted := slices.Clone(values)
+	slices.SortFunc(sorted, func(a, b *T) int { return id(a) - id(b) })
+	return sorted
+}
+
+func mapValues[T any](m map[int]*T) []*T {
+	values := make([]*T, 0, len(m))
+	for _, value := range m {
+		values = append(values, value)
+	}
+	return values
+}
+
+func anySlice[T any](values []T) []any {
+	converted := make([]any, len(values))
+	for i, value := range values {
+		converted[i] = value
+	}
+	return converted
+}
@@ -0,0 +1,109 @@
+package gw2api
+
+import (
+	"fmt"
+	"io/fs"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api/embeddata"
+)
+
+// embeddedSource is what loadedFrom holds after LoadEmbedded, which no cleaned
+// directory path can be
+const embeddedSource = "<embedded>"
+
+// LoadEmbedded loads the small sample of game data in package embeddata, for
+// tests, examples and demos that have no data directory. The sample has a few
+// complete crafting trees, such as iron ore to iron ingots to iron swords, and
+// a few dozen other items, skills and achievements. Until embeddata is
+// regenerated from real data, the sample is hand-written; see its docs.
+//
+// Like LoadFromDirectory, loading is idempotent. Since there is no data
+// directory, refreshed data isn't written anywhere.
+func (dc *DataCache) LoadEmbedded() error {
+	if loaded, err := dc.loadResult(embeddedSource); loaded {
+		return err
+	}
+
+	dc.loadMutex.Lock()
+	defer dc.loadMutex.Unlock()
+
+	if loaded, err := dc.loadResult(embeddedSource); loaded {
+		return err
+	}
+
+	dc.mutex.Lock()
+	dc.dataDir = ""
+	dc.loading = true
+	stats := dc.stats
+	dc.mutex.Unlock()
+
+	err := dc.loadFS(embeddata.FS(), &stats)
+
+	dc.mutex.Lock()
+	defer dc.mutex.Unlock()
+	dc.stats = stats
+	dc.loading = false
+	dc.loadErr = err
+	dc.loadedFrom = embeddedSource
+	return err
+}
+
+// loadFS loads the data files in fsys, recording the counts in stats; callers
+// must hold loadMutex. Missing files are skipped.
+func (dc *DataCache) loadFS(fsys fs.FS, stats *DataCacheStats) error {
+	startTime := time.Now()
+	var errs []error
+
+	for _, loader := range []struct {
+		kind   CacheKind
+		load   func(fs.FS, string) error
+		stats  func() cacheStats
+		loaded *int
+	}{
+		{CacheKindItems, dc.items.loadFromFS, dc.items.snapshot, &stats.ItemsLoaded},
+		{CacheKindSkills, dc.skills.loadFromFS, dc.skills.snapshot, &stats.SkillsLoaded},
+		{CacheKindAchievements, dc.achievements.loadFromFS, dc.achievements.snapshot, &stats.AchievementsLoaded},
+		{CacheKindRecipes, dc.recipes.loadFromFS, dc.recipes.snapshot, &stats.RecipesLoaded},
+		{CacheKindMaterials, dc.materials.loadFromFS, dc.materials.snapshot, &stats.MaterialsLoaded},
+		{CacheKindSkins, dc.skins.loadFromFS, dc.skins.snapshot, &stats.SkinsLoaded},
+		{CacheKindVendors, dc.vendors.loadFromFS, dc.vendors.snapshot, &stats.VendorsLoaded},
+		{CacheKindCurrencies, dc.currencies.loadFromFS, dc.currencies.snapshot, &stats.CurrenciesLoaded},
+	} {
+		name := loader.kind.FileName()
+		if _, err := fs.Stat(fsys, name); err != nil {
+			continue
+		}
+		if err := loader.load(fsys, name); err != nil {
+			errs = append(errs, &DataFileError{Kind: string(loader.kind), Path: name, Err: err})
+			continue
+		}
+		*loader.loaded = loader.stats().loaded
+	}
+
+	stats.LoadTime = time.Since(startTime)
+	stats.LastLoadTime = time.Now()
+
+	if len(errs) > 0 {
+		return dataLoadErrors(errs)
+	}
+	return nil
+}
+
+// loadFromFS loads all entries from the JSONL file name in fsys, replacing any
+// cached data
+func (c *jsonlCache[T]) loadFromFS(fsys fs.FS, name string) error {
+	startTime := time.Now()
+
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return fmt.Errorf("failed to open %s file %s: %w", c.kind, name, err)
+	}
+	values, corrupt, err := decodeJSONL[T](data, c.kind, name)
+	if err != nil {
+		return err
+	}
+
+	c.store(values, corrupt, startTime)
+	return nil
+}
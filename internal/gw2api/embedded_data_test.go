@@ -0,0 +1,101 @@
+package gw2api
+
+import (
+	"fmt"
+	"io/fs"
+	"slices"
+	"testing"
+
+	"j5.nz/gw2/internal/gw2api/embeddata"
+)
+
+func TestLoadEmbedded(t *testing.T) {
+	dc := NewDataCache()
+	if err := dc.LoadEmbedded(); err != nil {
+		t.Fatal(err)
+	}
+	if !dc.Loaded() {
+		t.Error("Loaded() = false after LoadEmbedded")
+	}
+	stats := dc.Stats()
+	if stats.ItemsLoaded == 0 || stats.RecipesLoaded == 0 || stats.SkillsLoaded == 0 || stats.AchievementsLoaded == 0 {
+		t.Errorf("stats = %+v, want items, recipes, skills and achievements", stats)
+	}
+	if stats.DataDir != "" {
+		t.Errorf("DataDir = %q, want none", stats.DataDir)
+	}
+
+	// Loading again is a no-op
+	if err := dc.LoadEmbedded(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Every recipe's output and ingredients are in the sample, and so is a
+	// recipe for every ingredient that can be crafted
+	items, recipes := dc.GetItemCache(), dc.GetRecipeCache()
+	for _, recipe := range recipes.GetAllRef() {
+		if _, found := items.GetByIDRef(recipe.OutputItemID); !found {
+			t.Errorf("recipe %d: output %d is missing", recipe.ID, recipe.OutputItemID)
+		}
+		for _, ingredient := range recipe.Ingredients {
+			if _, found := items.GetByIDRef(ingredient.ItemID); !found {
+				t.Errorf("recipe %d: ingredient %d is missing", recipe.ID, ingredient.ItemID)
+			}
+		}
+	}
+
+	// Iron ore makes iron ingots, which make iron swords
+	const ironOre, ironIngot = 19699, 19683
+	ingotRecipes := recipes.SearchByOutput(ironIngot)
+	if len(ingotRecipes) == 0 {
+		t.Fatal("no recipe for iron ingots")
+	}
+	ingotRecipe, _ := recipes.GetByIDRef(ingotRecipes[0])
+	if !slices.ContainsFunc(ingotRecipe.Ingredients, func(i RecipeIngredient) bool { return i.ItemID == ironOre }) {
+		t.Errorf("iron ingot recipe %d doesn't use iron ore", ingotRecipe.ID)
+	}
+	swords := 0
+	for _, component := range recipes.SearchByInput(ironIngot) {
+		recipe, _ := recipes.GetByIDRef(component)
+		for _, id := range recipes.SearchByInput(recipe.OutputItemID) {
+			if weapon, _ := recipes.GetByIDRef(id); weapon.Type == "Sword" {
+				swords++
+			}
+		}
+	}
+	if swords == 0 {
+		t.Error("no sword is made from iron ingots")
+	}
+}
+
+func TestEmbeddedDataSize(t *testing.T) {
+	total := int64(0)
+	err := fs.WalkDir(embeddata.FS(), ".", func(path string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() {
+			return err
+		}
+		info, err := entry.Info()
+		total += info.Size()
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total > 500<<10 {
+		t.Errorf("embedded data is %d bytes, want at most 500 KiB", total)
+	}
+}
+
+func ExampleDataCache_LoadEmbedded() {
+	dc := NewDataCache()
+	if err := dc.LoadEmbedded(); err != nil {
+		panic(err)
+	}
+	items, recipes := dc.GetItemCache(), dc.GetRecipeCache()
+	for _, id := range recipes.SearchByInput(19699) {
+		recipe, _ := recipes.GetByIDRef(id)
+		output, _ := items.GetByIDRef(recipe.OutputItemID)
+		fmt.Println("Iron Ore makes", output.Name)
+	}
+	// Output: Iron Ore makes Iron Ingot
+}
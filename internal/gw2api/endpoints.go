@@ -0,0 +1,103 @@
+package gw2api
+
+import (
+	"slices"
+	"strings"
+)
+
+// The Endpoints registry in endpoints_gen.go is built from the endpoint paths in
+// the Client methods, with the scopes from their Scopes doc lines and the kind
+// of IDs from the request helpers they call. Run go generate after adding or
+// changing one.
+//go:generate go run ./genendpoints -out endpoints_gen.go
+
+// IDKind is the kind of ID an endpoint lists or looks entries up by
+type IDKind string
+
+const (
+	NoIDs     IDKind = ""       // The endpoint isn't a list of entries
+	IntIDs    IDKind = "int"    // Numeric IDs, requested with ?ids=
+	StringIDs IDKind = "string" // Named IDs, requested as a path part
+)
+
+// Endpoint is an API path the client requests. Path parameters, such as a
+// character name, are written :id as in the API's route list at /v2.
+type Endpoint struct {
+	Path    string
+	Methods []string // Client methods requesting the path
+	// Scopes the API key needs, from the Scopes line of the methods' docs.
+	// Scopes a method only uses optionally aren't included, and neither are
+	// scopes only some of the methods need. Public endpoints have none.
+	Scopes []string
+	IDs    IDKind
+}
+
+// Covers reports whether the endpoint serves an API route, such as
+// "/v2/characters/:id/core". A path that only adds parameters to the route,
+// like /v2/emotes/:id for /v2/emotes, covers it too.
+func (e Endpoint) Covers(route string) bool {
+	if !strings.HasPrefix(e.Path, route) {
+		return false
+	}
+	rest := e.Path[len(route):]
+	for rest != "" {
+		if !strings.HasPrefix(rest, "/:id") {
+			return false
+		}
+		rest = rest[len("/:id"):]
+	}
+	return true
+}
+
+// EndpointCovering returns the registered endpoint serving an API route, if any
+func EndpointCovering(route string) (Endpoint, bool) {
+	for _, endpoint := range Endpoints {
+		if endpoint.Covers(route) {
+			return endpoint, true
+		}
+	}
+	return Endpoint{}, false
+}
+
+// EndpointFor returns the registered endpoint a request path, such as
+// "/v2/characters/Name/core", is sent to. Any query string is ignored. When
+// several endpoints match, the one with the fewest path parameters wins, so
+// /v2/account/home is preferred over a route like /v2/account/:id.
+func EndpointFor(path string) (Endpoint, bool) {
+	path, _, _ = strings.Cut(path, "?")
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+
+	var best Endpoint
+	bestParams := -1
+	for _, endpoint := range Endpoints {
+		pattern := strings.Split(endpoint.Path, "/")
+		if len(pattern) != len(parts) {
+			continue
+		}
+		params := 0
+		for i, part := range pattern {
+			if part == ":id" {
+				params++
+			} else if part != parts[i] {
+				params = -1
+				break
+			}
+		}
+		if params >= 0 && (bestParams < 0 || params < bestParams) {
+			best, bestParams = endpoint, params
+		}
+	}
+	return best, bestParams >= 0
+}
+
+// MissingScopes returns the scopes the endpoint needs that aren't among
+// permissions, such as the Permissions of a TokenInfo
+func (e Endpoint) MissingScopes(permissions []string) []string {
+	var missing []string
+	for _, scope := range e.Scopes {
+		if !slices.Contains(permissions, scope) {
+			missing = append(missing, scope)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,204 @@
+// Code generated by genendpoints; DO NOT EDIT.
+
+package gw2api
+
+// Endpoints lists every API path the client requests, sorted by path
+var Endpoints = []Endpoint{
+	{Path: "/v2/account", Methods: []string{"GetAccount"}, Scopes: []string{"account"}},
+	{Path: "/v2/account/achievements", Methods: []string{"GetAccountAchievements", "GetAccountAchievementsByIDs"}, Scopes: []string{"account", "progression"}, IDs: IntIDs},
+	{Path: "/v2/account/bank", Methods: []string{"GetAccountBank"}, Scopes: []string{"account", "inventories"}},
+	{Path: "/v2/account/buildstorage", Methods: []string{"GetAccountBuildStorage"}, Scopes: []string{"account"}},
+	{Path: "/v2/account/dailycrafting", Methods: []string{"GetAccountDailyCrafting"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/dungeons", Methods: []string{"GetAccountDungeons"}, Scopes: []string{"account", "progression"}, IDs: StringIDs},
+	{Path: "/v2/account/dyes", Methods: []string{"GetAccountDyes"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/emotes", Methods: []string{"GetAccountEmotes"}, Scopes: []string{"account", "unlocks"}, IDs: StringIDs},
+	{Path: "/v2/account/finishers", Methods: []string{"GetAccountFinishers"}, Scopes: []string{"account", "unlocks"}},
+	{Path: "/v2/account/gliders", Methods: []string{"GetAccountGliders"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/home", Methods: []string{"GetAccountHome"}},
+	{Path: "/v2/account/home/cats", Methods: []string{"GetAccountHomeCats"}, Scopes: []string{"account", "progression", "unlocks"}},
+	{Path: "/v2/account/home/nodes", Methods: []string{"GetAccountHomeNodes"}, Scopes: []string{"account", "progression", "unlocks"}, IDs: StringIDs},
+	{Path: "/v2/account/homestead", Methods: []string{"GetAccountHomestead"}},
+	{Path: "/v2/account/homestead/decorations", Methods: []string{"GetAccountHomesteadDecorations"}, Scopes: []string{"account", "unlocks"}},
+	{Path: "/v2/account/homestead/glyphs", Methods: []string{"GetAccountHomesteadGlyphs"}, Scopes: []string{"account", "unlocks"}},
+	{Path: "/v2/account/inventory", Methods: []string{"GetAccountInventory"}, Scopes: []string{"account", "inventories"}},
+	{Path: "/v2/account/jadebots", Methods: []string{"GetAccountJadeBots"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/legendaryarmory", Methods: []string{"GetAccountLegendaryArmory"}, Scopes: []string{"account", "unlocks", "inventories"}},
+	{Path: "/v2/account/luck", Methods: []string{"GetAccountLuck"}, Scopes: []string{"account", "progression", "unlocks"}},
+	{Path: "/v2/account/mail", Methods: []string{"GetAccountMail"}, Scopes: []string{"account"}},
+	{Path: "/v2/account/mailcarriers", Methods: []string{"GetAccountMailCarriers"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/mapchests", Methods: []string{"GetAccountMapChests"}, Scopes: []string{"account", "progression"}, IDs: StringIDs},
+	{Path: "/v2/account/masteries", Methods: []string{"GetAccountMasteries"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/mastery/points", Methods: []string{"GetAccountMasteryPoints"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/materials", Methods: []string{"GetAccountMaterials"}, Scopes: []string{"account", "inventories"}},
+	{Path: "/v2/account/minis", Methods: []string{"GetAccountMinis"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/mounts", Methods: []string{"GetAccountMounts"}},
+	{Path: "/v2/account/mounts/skins", Methods: []string{"GetAccountMountSkins"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/mounts/types", Methods: []string{"GetAccountMountTypes"}, Scopes: []string{"account", "unlocks"}, IDs: StringIDs},
+	{Path: "/v2/account/novelties", Methods: []string{"GetAccountNovelties"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/outfits", Methods: []string{"GetAccountOutfits"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/progression", Methods: []string{"GetAccountProgression"}, Scopes: []string{"progression", "unlocks"}, IDs: StringIDs},
+	{Path: "/v2/account/pvp/heroes", Methods: []string{"GetAccountPvPHeroes"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/raids", Methods: []string{"GetAccountRaids"}, Scopes: []string{"account", "progression"}, IDs: StringIDs},
+	{Path: "/v2/account/recipes", Methods: []string{"GetAccountRecipes"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/skiffs", Methods: []string{"GetAccountSkiffs"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/skins", Methods: []string{"GetAccountSkins"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/titles", Methods: []string{"GetAccountTitles"}, Scopes: []string{"account", "unlocks"}, IDs: IntIDs},
+	{Path: "/v2/account/wallet", Methods: []string{"GetAccountWallet"}, Scopes: []string{"account", "wallet"}},
+	{Path: "/v2/account/wizardsvault/daily", Methods: []string{"GetAccountWizardsVaultDaily"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/wizardsvault/listings", Methods: []string{"GetAccountWizardsVaultListings"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/wizardsvault/special", Methods: []string{"GetAccountWizardsVaultSpecial"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/wizardsvault/weekly", Methods: []string{"GetAccountWizardsVaultWeekly"}, Scopes: []string{"account", "progression"}},
+	{Path: "/v2/account/worldbosses", Methods: []string{"GetAccountWorldBosses"}, Scopes: []string{"account", "progression"}, IDs: StringIDs},
+	{Path: "/v2/account/wvw", Methods: []string{"GetAccountWvW"}, Scopes: []string{"account"}},
+	{Path: "/v2/achievements", Methods: []string{"GetAchievement", "GetAchievementIDs", "GetAchievements", "GetAllAchievements"}, IDs: IntIDs},
+	{Path: "/v2/achievements/categories", Methods: []string{"GetAchievementCategories", "GetAchievementCategory", "GetAchievementCategoryIDs"}, IDs: IntIDs},
+	{Path: "/v2/achievements/daily", Methods: []string{"GetDailyAchievements"}},
+	{Path: "/v2/achievements/daily/tomorrow", Methods: []string{"GetDailyAchievementsTomorrow"}},
+	{Path: "/v2/achievements/groups", Methods: []string{"GetAchievementGroupIDs"}, IDs: StringIDs},
+	{Path: "/v2/achievements/groups/:id", Methods: []string{"GetAchievementGroup"}},
+	{Path: "/v2/backstory/answers", Methods: []string{"GetBackstoryAnswerIDs", "GetBackstoryAnswers"}, IDs: StringIDs},
+	{Path: "/v2/backstory/answers/:id", Methods: []string{"GetBackstoryAnswer"}},
+	{Path: "/v2/backstory/questions", Methods: []string{"GetBackstoryQuestionIDs", "GetBackstoryQuestions"}, IDs: StringIDs},
+	{Path: "/v2/backstory/questions/:id", Methods: []string{"GetBackstoryQuestion"}},
+	{Path: "/v2/build", Methods: []string{"GetBuild"}},
+	{Path: "/v2/characters", Methods: []string{"GetCharacterNames", "GetCharacters", "GetCharactersByNames"}, Scopes: []string{"characters"}, IDs: StringIDs},
+	{Path: "/v2/characters/:id/backstory", Methods: []string{"GetCharacterBackstory"}, Scopes: []string{"characters"}},
+	{Path: "/v2/characters/:id/buildtabs", Methods: []string{"GetCharacterBuildTabs"}, Scopes: []string{"characters", "builds"}},
+	{Path: "/v2/characters/:id/buildtabs/:id", Methods: []string{"GetCharacterBuildTab"}, Scopes: []string{"characters", "builds"}},
+	{Path: "/v2/characters/:id/buildtabs/active", Methods: []string{"GetCharacterBuildTabActive"}, Scopes: []string{"characters", "builds"}},
+	{Path: "/v2/characters/:id/core", Methods: []string{"GetCharacterCore"}, Scopes: []string{"characters"}},
+	{Path: "/v2/characters/:id/crafting", Methods: []string{"GetCharacterCrafting"}, Scopes: []string{"characters"}},
+	{Path: "/v2/characters/:id/dungeons", Methods: []string{"GetCharacterDungeons"}, Scopes: []string{"characters", "progression"}},
+	{Path: "/v2/characters/:id/equipment", Methods: []string{"GetCharacterEquipment"}, Scopes: []string{"characters", "inventories"}},
+	{Path: "/v2/characters/:id/equipmenttabs", Methods: []string{"GetCharacterEquipmentTabs"}, Scopes: []string{"characters", "inventories"}},
+	{Path: "/v2/characters/:id/equipmenttabs/:id", Methods: []string{"GetCharacterEquipmentTab"}, Scopes: []string{"characters", "inventories"}},
+	{Path: "/v2/characters/:id/equipmenttabs/active", Methods: []string{"GetCharacterEquipmentTabActive"}, Scopes: []string{"characters", "inventories"}},
+	{Path: "/v2/characters/:id/heropoints", Methods: []string{"GetCharacterHeroPoints"}, Scopes: []string{"characters", "progression"}, IDs: StringIDs},
+	{Path: "/v2/characters/:id/inventory", Methods: []string{"GetCharacterInventory"}, Scopes: []string{"characters", "inventories"}},
+	{Path: "/v2/characters/:id/quests", Methods: []string{"GetCharacterQuests"}, Scopes: []string{"characters", "progression"}},
+	{Path: "/v2/characters/:id/recipes", Methods: []string{"GetCharacterRecipes"}, Scopes: []string{"characters", "unlocks"}},
+	{Path: "/v2/characters/:id/sab", Methods: []string{"GetCharacterSAB"}, Scopes: []string{"characters", "progression"}},
+	{Path: "/v2/characters/:id/skills", Methods: []string{"GetCharacterSkills"}, Scopes: []string{"characters", "builds"}},
+	{Path: "/v2/characters/:id/specializations", Methods: []string{"GetCharacterSpecializations"}, Scopes: []string{"characters", "builds"}},
+	{Path: "/v2/characters/:id/training", Methods: []string{"GetCharacterTraining"}, Scopes: []string{"characters", "builds"}},
+	{Path: "/v2/colors", Methods: []string{"GetColor", "GetColorIDs", "GetColors", "GetMissingDyesRanked"}, IDs: IntIDs},
+	{Path: "/v2/commerce/delivery", Methods: []string{"GetCommerceDelivery"}, Scopes: []string{"account", "tradingpost"}},
+	{Path: "/v2/commerce/exchange", Methods: []string{"GetCommerceExchangeTypes"}, IDs: StringIDs},
+	{Path: "/v2/commerce/exchange/coins", Methods: []string{"GetCommerceExchangeCoins"}},
+	{Path: "/v2/commerce/exchange/gems", Methods: []string{"GetCommerceExchangeGems"}},
+	{Path: "/v2/commerce/listings", Methods: []string{"GetCommerceListing", "GetCommerceListingIDs", "GetCommerceListings"}, IDs: IntIDs},
+	{Path: "/v2/commerce/prices", Methods: []string{"GetCommercePrice", "GetCommercePriceIDs", "GetCommercePrices", "StreamCommercePrices"}, IDs: IntIDs},
+	{Path: "/v2/commerce/transactions/:id/:id", Methods: []string{"GetCommerceTransactions"}, Scopes: []string{"account", "tradingpost"}},
+	{Path: "/v2/continents", Methods: []string{"GetContinent", "GetContinentIDs"}, IDs: IntIDs},
+	{Path: "/v2/createsubtoken", Methods: []string{"GetCreateSubtoken"}, Scopes: []string{"account"}},
+	{Path: "/v2/currencies", Methods: []string{"GetAllCurrencies", "GetCurrencies", "GetCurrency", "GetCurrencyIDs"}, IDs: IntIDs},
+	{Path: "/v2/dailycrafting", Methods: []string{"GetDailyCrafting"}},
+	{Path: "/v2/dungeons", Methods: []string{"GetAllDungeons", "GetDungeonIDs"}, IDs: StringIDs},
+	{Path: "/v2/dungeons/:id", Methods: []string{"GetDungeon"}},
+	{Path: "/v2/emblem", Methods: []string{"GetEmblem"}},
+	{Path: "/v2/emblem/backgrounds", Methods: []string{"GetEmblemBackgroundIDs", "GetEmblemBackgrounds"}, IDs: IntIDs},
+	{Path: "/v2/emblem/foregrounds", Methods: []string{"GetEmblemForegroundIDs", "GetEmblemForegrounds"}, IDs: IntIDs},
+	{Path: "/v2/emotes", Methods: []string{"GetEmoteIDs"}, IDs: StringIDs},
+	{Path: "/v2/emotes/:id", Methods: []string{"GetEmoteDetail"}},
+	{Path: "/v2/events", Methods: []string{"GetEventIDs"}, IDs: StringIDs},
+	{Path: "/v2/events/:id", Methods: []string{"GetEvent"}},
+	{Path: "/v2/files", Methods: []string{"GetFileIDs"}, IDs: StringIDs},
+	{Path: "/v2/files/:id", Methods: []string{"GetFileDetail"}},
+	{Path: "/v2/finishers", Methods: []string{"GetFinisher", "GetFinisherIDs", "GetFinishers"}, IDs: IntIDs},
+	{Path: "/v2/gliders", Methods: []string{"GetGlider", "GetGliderIDs", "GetGliders"}, IDs: IntIDs},
+	{Path: "/v2/guild/:id", Methods: []string{"GetGuild"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/log", Methods: []string{"GetGuildLog", "GetGuildLogSince"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/members", Methods: []string{"GetGuildMembers"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/ranks", Methods: []string{"GetGuildRanks"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/stash", Methods: []string{"GetGuildStash"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/storage", Methods: []string{"GetGuildStorage"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/teams", Methods: []string{"GetGuildTeams"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/treasury", Methods: []string{"GetGuildTreasury"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/:id/upgrades", Methods: []string{"GetGuildUpgrades"}, Scopes: []string{"guilds"}},
+	{Path: "/v2/guild/permissions", Methods: []string{"GetGuildPermissionIDs"}, IDs: StringIDs},
+	{Path: "/v2/guild/permissions/:id", Methods: []string{"GetGuildPermission"}},
+	{Path: "/v2/guild/search", Methods: []string{"GetGuildSearch"}},
+	{Path: "/v2/guild/upgrades", Methods: []string{"GetGuildUpgradeDetail", "GetGuildUpgradeDetailIDs", "GetGuildUpgradeDetails"}, IDs: IntIDs},
+	{Path: "/v2/home", Methods: []string{"GetHome"}},
+	{Path: "/v2/home/cats", Methods: []string{"GetHomeCats"}},
+	{Path: "/v2/home/nodes", Methods: []string{"GetHomeNodes"}, IDs: StringIDs},
+	{Path: "/v2/homestead", Methods: []string{"GetHomestead"}},
+	{Path: "/v2/homestead/decorations", Methods: []string{"GetHomesteadDecoration", "GetHomesteadDecorationIDs"}, IDs: IntIDs},
+	{Path: "/v2/homestead/decorations/categories", Methods: []string{"GetHomesteadDecorationCategory", "GetHomesteadDecorationCategoryIDs"}, IDs: IntIDs},
+	{Path: "/v2/homestead/glyphs", Methods: []string{"GetHomesteadGlyph", "GetHomesteadGlyphIDs"}, IDs: IntIDs},
+	{Path: "/v2/items", Methods: []string{"FindStaleCachedItems", "GetItem", "GetItemIDs", "GetItems"}, IDs: IntIDs},
+	{Path: "/v2/itemstats", Methods: []string{"GetItemStat", "GetItemStatIDs", "GetItemStats"}, IDs: IntIDs},
+	{Path: "/v2/jadebots", Methods: []string{"GetJadeBot", "GetJadeBotIDs", "GetJadeBots"}, IDs: IntIDs},
+	{Path: "/v2/legendaryarmory", Methods: []string{"GetLegendaryArmory", "GetLegendaryArmoryIDs", "GetLegendaryArmoryItems"}, IDs: IntIDs},
+	{Path: "/v2/legends", Methods: []string{"GetLegendIDs"}, IDs: StringIDs},
+	{Path: "/v2/legends/:id", Methods: []string{"GetLegend"}},
+	{Path: "/v2/logos", Methods: []string{"GetLogos"}},
+	{Path: "/v2/mailcarriers", Methods: []string{"GetMailCarrier", "GetMailCarrierIDs", "GetMailCarriers"}, IDs: IntIDs},
+	{Path: "/v2/mapchests", Methods: []string{"GetMapChests"}, IDs: StringIDs},
+	{Path: "/v2/maps", Methods: []string{"GetMap", "GetMapIDs", "GetMaps"}, IDs: IntIDs},
+	{Path: "/v2/masteries", Methods: []string{"GetAllMasteries", "GetMastery", "GetMasteryIDs"}, IDs: IntIDs},
+	{Path: "/v2/materials", Methods: []string{"GetAllMaterials", "GetMaterial", "GetMaterialIDs", "GetMaterials"}, IDs: IntIDs},
+	{Path: "/v2/minis", Methods: []string{"GetMini", "GetMiniIDs", "GetMinis"}, IDs: IntIDs},
+	{Path: "/v2/mounts", Methods: []string{"GetMounts"}},
+	{Path: "/v2/mounts/skins", Methods: []string{"GetMountSkin", "GetMountSkinIDs", "GetMountSkins"}, IDs: IntIDs},
+	{Path: "/v2/mounts/types", Methods: []string{"GetMountTypeIDs"}, IDs: StringIDs},
+	{Path: "/v2/mounts/types/:id", Methods: []string{"GetMountType"}},
+	{Path: "/v2/novelties", Methods: []string{"GetNovelties", "GetNovelty", "GetNoveltyIDs"}, IDs: IntIDs},
+	{Path: "/v2/outfits", Methods: []string{"GetOutfit", "GetOutfitIDs", "GetOutfits"}, IDs: IntIDs},
+	{Path: "/v2/pets", Methods: []string{"GetPet", "GetPetIDs", "GetPets"}, IDs: IntIDs},
+	{Path: "/v2/professions", Methods: []string{"GetAllProfessions", "GetProfessionIDs"}, IDs: StringIDs},
+	{Path: "/v2/professions/:id", Methods: []string{"GetProfession"}},
+	{Path: "/v2/pvp", Methods: []string{"GetPvP"}, Scopes: []string{"pvp"}},
+	{Path: "/v2/pvp/amulets", Methods: []string{"GetPvPAmulet", "GetPvPAmuletIDs"}, IDs: IntIDs},
+	{Path: "/v2/pvp/games", Methods: []string{"GetPvPGames"}, Scopes: []string{"pvp"}},
+	{Path: "/v2/pvp/heroes", Methods: []string{"GetPvPHeroIDs"}, IDs: StringIDs},
+	{Path: "/v2/pvp/heroes/:id", Methods: []string{"GetPvPHero"}},
+	{Path: "/v2/pvp/ranks", Methods: []string{"GetPvPRank", "GetPvPRankIDs"}, IDs: IntIDs},
+	{Path: "/v2/pvp/rewardtracks", Methods: []string{"GetPvPRewardTrack", "GetPvPRewardTrackIDs"}, IDs: IntIDs},
+	{Path: "/v2/pvp/runes", Methods: []string{"GetPvPRune", "GetPvPRuneIDs"}, IDs: IntIDs},
+	{Path: "/v2/pvp/seasons", Methods: []string{"GetPvPSeasonIDs"}, IDs: StringIDs},
+	{Path: "/v2/pvp/seasons/:id", Methods: []string{"GetPvPSeason"}},
+	{Path: "/v2/pvp/seasons/:id/leaderboards", Methods: []string{"GetPvPSeasonLeaderboards"}},
+	{Path: "/v2/pvp/seasons/:id/leaderboards/:id/:id", Methods: []string{"GetPvPSeasonLeaderboard"}},
+	{Path: "/v2/pvp/sigils", Methods: []string{"GetPvPSigil", "GetPvPSigilIDs"}, IDs: IntIDs},
+	{Path: "/v2/pvp/standings", Methods: []string{"GetPvPStandings"}, Scopes: []string{"pvp"}},
+	{Path: "/v2/pvp/stats", Methods: []string{"GetPvPStats"}, Scopes: []string{"pvp"}},
+	{Path: "/v2/quaggans", Methods: []string{"GetQuagganIDs"}, IDs: StringIDs},
+	{Path: "/v2/quaggans/:id", Methods: []string{"GetQuaggan"}},
+	{Path: "/v2/quests", Methods: []string{"GetQuest", "GetQuestIDs"}, IDs: IntIDs},
+	{Path: "/v2/races", Methods: []string{"GetRaceIDs"}, IDs: StringIDs},
+	{Path: "/v2/races/:id", Methods: []string{"GetRace"}},
+	{Path: "/v2/raids", Methods: []string{"GetAllRaids", "GetRaidIDs"}, IDs: StringIDs},
+	{Path: "/v2/raids/:id", Methods: []string{"GetRaid"}},
+	{Path: "/v2/recipes", Methods: []string{"GetRecipeIDs", "GetRecipes"}, IDs: IntIDs},
+	{Path: "/v2/recipes/search", Methods: []string{"GetRecipeSearch"}},
+	{Path: "/v2/skiffs", Methods: []string{"GetSkiff", "GetSkiffIDs", "GetSkiffs"}, IDs: IntIDs},
+	{Path: "/v2/skills", Methods: []string{"GetSkill", "GetSkillIDs", "GetSkills"}, IDs: IntIDs},
+	{Path: "/v2/skins", Methods: []string{"GetAllSkins", "GetSkin", "GetSkinIDs", "GetSkins"}, IDs: IntIDs},
+	{Path: "/v2/specializations", Methods: []string{"GetSpecialization", "GetSpecializationIDs", "GetSpecializations"}, IDs: IntIDs},
+	{Path: "/v2/stories", Methods: []string{"GetStory", "GetStoryIDs"}, IDs: IntIDs},
+	{Path: "/v2/stories/seasons", Methods: []string{"GetStorySeasonIDs"}, IDs: StringIDs},
+	{Path: "/v2/stories/seasons/:id", Methods: []string{"GetStorySeason"}},
+	{Path: "/v2/titles", Methods: []string{"GetTitle", "GetTitleIDs"}, IDs: IntIDs},
+	{Path: "/v2/tokeninfo", Methods: []string{"GetTokenInfo"}, Scopes: []string{"account"}},
+	{Path: "/v2/traits", Methods: []string{"GetTrait", "GetTraitIDs", "GetTraits"}, IDs: IntIDs},
+	{Path: "/v2/vendors", Methods: []string{"GetVendor", "GetVendorIDs", "GetVendors"}, IDs: IntIDs},
+	{Path: "/v2/wizardsvault", Methods: []string{"GetWizardsVaultSeason"}},
+	{Path: "/v2/wizardsvault/listings", Methods: []string{"GetWizardsVaultListing", "GetWizardsVaultListingIDs"}, IDs: IntIDs},
+	{Path: "/v2/wizardsvault/objectives", Methods: []string{"GetWizardsVaultObjective", "GetWizardsVaultObjectiveIDs"}, IDs: IntIDs},
+	{Path: "/v2/worldbosses", Methods: []string{"GetWorldBosses"}},
+	{Path: "/v2/worlds", Methods: []string{"GetAllWorlds", "GetWorld", "GetWorldIDs", "GetWorlds", "GetWorldsPage"}, IDs: IntIDs},
+	{Path: "/v2/wvw/abilities", Methods: []string{"GetWvWAbility", "GetWvWAbilityIDs"}, IDs: IntIDs},
+	{Path: "/v2/wvw/guilds", Methods: []string{"GetWvWGuilds"}},
+	{Path: "/v2/wvw/matches", Methods: []string{"GetWvWMatchByWorld", "GetWvWMatches"}},
+	{Path: "/v2/wvw/matches/:id/stats/teams", Methods: []string{"GetWvWMatchStatsTeams"}},
+	{Path: "/v2/wvw/matches/overview", Methods: []string{"GetWvWMatchOverview"}},
+	{Path: "/v2/wvw/matches/scores", Methods: []string{"GetWvWMatchScores"}},
+	{Path: "/v2/wvw/matches/stats", Methods: []string{"GetWvWMatchStats"}},
+	{Path: "/v2/wvw/objectives", Methods: []string{"GetAllWvWObjectives", "GetWvWObjectiveIDs"}, IDs: StringIDs},
+	{Path: "/v2/wvw/objectives/:id", Methods: []string{"GetWvWObjective"}},
+	{Path: "/v2/wvw/ranks", Methods: []string{"GetAllWvWRanks", "GetWvWRank", "GetWvWRankIDs"}, IDs: IntIDs},
+	{Path: "/v2/wvw/rewardtracks", Methods: []string{"GetWvWRewardTrack", "GetWvWRewardTrackIDs"}, IDs: IntIDs},
+	{Path: "/v2/wvw/timers", Methods: []string{"GetWvWTimers"}},
+	{Path: "/v2/wvw/upgrades", Methods: []string{"GetWvWUpgrade", "GetWvWUpgradeIDs"}, IDs: IntIDs},
+}
@@ -0,0 +1,252 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEndpointCovers(t *testing.T) {
+	tests := []struct {
+		path   string
+		route  string
+		covers bool
+	}{
+		{"/v2/emotes", "/v2/emotes", true},
+		{"/v2/emotes/:id", "/v2/emotes", true},
+		{"/v2/emotes/:id", "/v2/emotes/:id", true},
+		{"/v2/characters/:id/core", "/v2/characters/:id/core", true},
+		{"/v2/pvp/seasons/:id/leaderboards/:id/:id", "/v2/pvp/seasons/:id/leaderboards/:id/:id", true},
+		{"/v2/emotes", "/v2/emotes/:id", false},
+		{"/v2/emotesx", "/v2/emotes", false},
+		{"/v2/characters/:id/core", "/v2/characters", false},
+		{"/v2/emblem/backgrounds", "/v2/emblem", false},
+	}
+
+	for _, tt := range tests {
+		if got := (Endpoint{Path: tt.path}).Covers(tt.route); got != tt.covers {
+			t.Errorf("Endpoint{%q}.Covers(%q) = %v, expected %v", tt.path, tt.route, got, tt.covers)
+		}
+	}
+}
+
+func TestEndpointsRegistry(t *testing.T) {
+	if !slices.IsSortedFunc(Endpoints, func(a, b Endpoint) int {
+		return strings.Compare(a.Path, b.Path)
+	}) {
+		t.Error("Endpoints is not sorted by path")
+	}
+
+	tests := map[string]string{
+		"/v2/commerce/exchange":                    "GetCommerceExchangeTypes",
+		"/v2/emblem/backgrounds":                   "GetEmblemBackgrounds",
+		"/v2/emblem/foregrounds":                   "GetEmblemForegrounds",
+		"/v2/pvp/seasons/:id/leaderboards/:id/:id": "GetPvPSeasonLeaderboard",
+		"/v2/characters/:id/buildtabs/:id":         "GetCharacterBuildTab",
+		"/v2/characters/:id/equipmenttabs/:id":     "GetCharacterEquipmentTab",
+		"/v2/wizardsvault":                         "GetWizardsVaultSeason",
+	}
+	for route, method := range tests {
+		endpoint, found := EndpointCovering(route)
+		if !found {
+			t.Errorf("no endpoint covers %s", route)
+			continue
+		}
+		if !slices.Contains(endpoint.Methods, method) {
+			t.Errorf("endpoint %s has methods %v, expected %s among them", endpoint.Path, endpoint.Methods, method)
+		}
+	}
+
+	if endpoint, found := EndpointCovering("/v2/characters/:id/core"); !found || endpoint.Path != "/v2/characters/:id/core" {
+		t.Errorf("EndpointCovering(/v2/characters/:id/core) = %v, %v", endpoint, found)
+	}
+}
+
+func TestEndpointsRegistryScopesAndIDs(t *testing.T) {
+	tests := []struct {
+		path   string
+		scopes []string
+		ids    IDKind
+	}{
+		{"/v2/items", nil, IntIDs},
+		{"/v2/emotes", nil, StringIDs},
+		{"/v2/build", nil, NoIDs},
+		{"/v2/account/gliders", []string{"account", "unlocks"}, IntIDs},
+		{"/v2/account/wallet", []string{"account", "wallet"}, NoIDs},
+		{"/v2/characters/:id/core", []string{"characters"}, NoIDs},
+		{"/v2/guild/:id/log", []string{"guilds"}, NoIDs},
+	}
+	for _, tt := range tests {
+		endpoint, found := EndpointFor(strings.ReplaceAll(tt.path, ":id", "x"))
+		if !found || endpoint.Path != tt.path {
+			t.Errorf("EndpointFor(%s) = %v, %v", tt.path, endpoint, found)
+			continue
+		}
+		if !slices.Equal(endpoint.Scopes, tt.scopes) || endpoint.IDs != tt.ids {
+			t.Errorf("%s has scopes %v and IDs %q, expected %v and %q", tt.path, endpoint.Scopes, endpoint.IDs, tt.scopes, tt.ids)
+		}
+	}
+}
+
+func TestEndpointFor(t *testing.T) {
+	tests := map[string]string{
+		"/v2/characters/Some%20Name/core":        "/v2/characters/:id/core",
+		"/v2/characters/Tester/buildtabs/active": "/v2/characters/:id/buildtabs/active",
+		"/v2/characters/Tester/buildtabs/2":      "/v2/characters/:id/buildtabs/:id",
+		"/v2/backstory/answers?ids=7-54":         "/v2/backstory/answers",
+		"/v2/account/home/":                      "/v2/account/home",
+	}
+	for path, expected := range tests {
+		if endpoint, found := EndpointFor(path); !found || endpoint.Path != expected {
+			t.Errorf("EndpointFor(%s) = %s, %v; expected %s", path, endpoint.Path, found, expected)
+		}
+	}
+	if endpoint, found := EndpointFor("/v2/nonexistent"); found {
+		t.Errorf("EndpointFor(/v2/nonexistent) = %v", endpoint)
+	}
+
+	wallet := Endpoint{Scopes: []string{"account", "wallet"}}
+	if missing := wallet.MissingScopes([]string{"account", "unlocks"}); !slices.Equal(missing, []string{"wallet"}) {
+		t.Errorf("MissingScopes = %v, expected [wallet]", missing)
+	}
+}
+
+func TestWithPermissions(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`[1, 2]`))
+	}))
+	t.Cleanup(server.Close)
+	ctx := context.Background()
+
+	client := NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithRetries(0), WithRateLimit(1000), WithPermissions("account", "unlocks"))
+	if _, err := client.GetAccountGliders(ctx); err != nil {
+		t.Fatalf("GetAccountGliders: %v", err)
+	}
+	if _, err := client.GetItemIDs(ctx); err != nil {
+		t.Fatalf("GetItemIDs: %v", err)
+	}
+	_, err := client.GetAccountWallet(ctx)
+	if !errors.Is(err, ErrMissingScope) || !strings.Contains(err.Error(), "wallet") {
+		t.Errorf("GetAccountWallet: err = %v, expected a missing wallet scope", err)
+	}
+	if n := requests.Load(); n != 2 {
+		t.Errorf("%d requests sent, expected 2 with the wallet request refused", n)
+	}
+
+	// A new key may have other scopes, so its requests are sent
+	client.With(WithAPIKey("other")).GetAccountWallet(ctx)
+	if n := requests.Load(); n != 3 {
+		t.Errorf("%d requests sent, expected the wallet request with another key to be sent", n)
+	}
+}
+
+func newEndpointsFixtureClient(t *testing.T) *Client {
+	return newFixtureClient(t, "endpoints", map[string]string{
+		"/v2/wizardsvault":                          "wizardsvault.json",
+		"/v2/emblem/backgrounds":                    "backgrounds.json",
+		"/v2/emblem/foregrounds":                    "foregrounds.json",
+		"/v2/commerce/exchange":                     "exchange.json",
+		"/v2/pvp/seasons/S1/leaderboards/ladder/eu": "leaderboard.json",
+		"/v2/characters/Tester/buildtabs/2":         "buildtab.json",
+		"/v2/characters/Tester/equipmenttabs/2":     "equipmenttab.json",
+	})
+}
+
+func TestGetWizardsVaultSeason(t *testing.T) {
+	client := newEndpointsFixtureClient(t)
+
+	season, err := client.GetWizardsVaultSeason(context.Background())
+	if err != nil {
+		t.Fatalf("GetWizardsVaultSeason: %v", err)
+	}
+	if season.Title != "Season 1" || !season.Start.Equal(time.Date(2023, 8, 22, 17, 0, 0, 0, time.UTC)) || !season.End.Equal(time.Date(2023, 11, 21, 17, 0, 0, 0, time.UTC)) {
+		t.Errorf("season = %+v", season)
+	}
+	if !slices.Equal(season.Listings, []int{1, 2, 3}) || !slices.Equal(season.Objectives, []int{10, 11}) {
+		t.Errorf("Listings = %v, Objectives = %v", season.Listings, season.Objectives)
+	}
+}
+
+func TestGetEmblemLayers(t *testing.T) {
+	client := newEndpointsFixtureClient(t)
+
+	backgrounds, err := client.GetEmblemBackgrounds(context.Background(), []int{2, 27})
+	if err != nil {
+		t.Fatalf("GetEmblemBackgrounds: %v", err)
+	}
+	if len(backgrounds) != 2 || backgrounds[0].ID != 2 || backgrounds[1].ID != 27 {
+		t.Fatalf("backgrounds = %+v, expected IDs 2 and 27 in request order", backgrounds)
+	}
+	if len(backgrounds[0].Layers) != 2 {
+		t.Errorf("background 2 has %d layers, expected 2", len(backgrounds[0].Layers))
+	}
+
+	foregrounds, err := client.GetEmblemForegrounds(context.Background(), []int{1})
+	if err != nil {
+		t.Fatalf("GetEmblemForegrounds: %v", err)
+	}
+	if len(foregrounds) != 1 || len(foregrounds[0].Layers) != 3 {
+		t.Errorf("foregrounds = %+v, expected one with 3 layers", foregrounds)
+	}
+}
+
+func TestGetCommerceExchangeTypes(t *testing.T) {
+	client := newEndpointsFixtureClient(t)
+
+	types, err := client.GetCommerceExchangeTypes(context.Background())
+	if err != nil {
+		t.Fatalf("GetCommerceExchangeTypes: %v", err)
+	}
+	if !slices.Equal(types, []string{"coins", "gems"}) {
+		t.Errorf("types = %v, expected [coins gems]", types)
+	}
+}
+
+func TestGetPvPSeasonLeaderboard(t *testing.T) {
+	client := newEndpointsFixtureClient(t)
+
+	entries, pagination, err := client.GetPvPSeasonLeaderboard(context.Background(), "S1", "ladder", "eu")
+	if err != nil {
+		t.Fatalf("GetPvPSeasonLeaderboard: %v", err)
+	}
+	if len(entries) != 2 || entries[0].Name != "Player.1234" || entries[0].Rank != 1 {
+		t.Fatalf("entries = %+v", entries)
+	}
+	if len(entries[1].Scores) != 1 || entries[1].Scores[0].Value != 1820 {
+		t.Errorf("second entry scores = %+v, expected one of 1820", entries[1].Scores)
+	}
+	if pagination == nil || pagination.PageTotal != 1 {
+		t.Errorf("pagination = %+v, expected one page", pagination)
+	}
+}
+
+func TestGetCharacterTabs(t *testing.T) {
+	client := newEndpointsFixtureClient(t)
+
+	build, err := client.GetCharacterBuildTab(context.Background(), "Tester", 2)
+	if err != nil {
+		t.Fatalf("GetCharacterBuildTab: %v", err)
+	}
+	if build.Tab != 2 || build.Build.Name != "Condi" || build.Build.Skills.Heal != 10527 {
+		t.Errorf("build tab = %+v", build)
+	}
+
+	equipment, err := client.GetCharacterEquipmentTab(context.Background(), "Tester", 2)
+	if err != nil {
+		t.Fatalf("GetCharacterEquipmentTab: %v", err)
+	}
+	if equipment.Tab != 2 || equipment.Name != "Open World" || !equipment.IsActive {
+		t.Errorf("equipment tab = %+v", equipment)
+	}
+	if len(equipment.Equipment) != 1 || equipment.Equipment[0].ID != 100 {
+		t.Errorf("equipment = %+v, expected item 100", equipment.Equipment)
+	}
+}
@@ -0,0 +1,52 @@
+package gw2api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// decodeStrict decodes a fixture under testdata, failing on any field the struct doesn't declare
+func decodeStrict(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", path))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+}
+
+// newFixtureClient returns a client whose requests are answered with fixtures
+// from testdata/dir, keyed by endpoint path. Paged endpoints under
+// /v2/commerce/transactions report a single page.
+func newFixtureClient(t *testing.T, dir string, routes map[string]string) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fixture, found := routes[r.URL.Path]
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "not found"}`))
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/v2/commerce/transactions/") || strings.Contains(r.URL.Path, "/leaderboards/") {
+			w.Header().Set("X-Page", "0")
+			w.Header().Set("X-Page-Total", "1")
+		}
+		http.ServeFile(w, r, filepath.Join("testdata", dir, fixture))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithRetries(0), WithRateLimit(1000))
+	client.baseURL = server.URL
+	return client
+}
@@ -0,0 +1,312 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Equipment slots checked by GearCheck, in the order they are shown in game
+var gearSlots = []string{
+	"Helm", "Shoulders", "Coat", "Gloves", "Leggings", "Boots",
+	"Backpack", "Accessory1", "Accessory2", "Amulet", "Ring1", "Ring2",
+	"WeaponA1", "WeaponA2", "WeaponB1", "WeaponB2",
+}
+
+// Other slots an equipment tab can hold, listed after the gear slots
+var otherSlots = []string{
+	"HelmAquatic", "WeaponAquaticA", "WeaponAquaticB", "Relic",
+	"Sickle", "Axe", "Pick", "FishingRod", "FishingBait", "FishingLure",
+	"PowerCore", "SensoryArray", "ServiceChip",
+}
+
+// twoHandedWeapons are weapon types that leave the off-hand slot empty
+var twoHandedWeapons = []string{"Greatsword", "Hammer", "LongBow", "Rifle", "ShortBow", "Staff", "Spear"}
+
+// CharacterLoadout is one equipment tab with every slot resolved to its item,
+// stats, upgrades and infusions
+type CharacterLoadout struct {
+	Tab      int           `json:"tab"`
+	Name     string        `json:"name"`
+	IsActive bool          `json:"is_active"`
+	Slots    []LoadoutSlot `json:"slots"`
+}
+
+// LoadoutSlot is one equipped item
+type LoadoutSlot struct {
+	Slot          string         `json:"slot"`
+	Item          *Item          `json:"item"`
+	Stats         *ItemStat      `json:"stats,omitempty"`      // Selected stats, or the item's fixed stats
+	Attributes    map[string]int `json:"attributes,omitempty"` // Attributes the stats give on this item
+	Upgrades      []*Item        `json:"upgrades,omitempty"`
+	Infusions     []*Item        `json:"infusions,omitempty"`
+	InfusionSlots int            `json:"infusion_slots"` // Infusion slots the item has, not counting enrichments
+	Binding       string         `json:"binding,omitempty"`
+}
+
+// Slot returns the item equipped in the named slot, or nil if it is empty
+func (l *CharacterLoadout) Slot(name string) *LoadoutSlot {
+	for i := range l.Slots {
+		if l.Slots[i].Slot == name {
+			return &l.Slots[i]
+		}
+	}
+	return nil
+}
+
+// GetCharacterEquipmentTabsDetailed returns every equipment tab of a character
+// with items, stats and upgrades resolved.
+// Scopes: characters, inventories
+func (c *Client) GetCharacterEquipmentTabsDetailed(ctx context.Context, name string) ([]CharacterLoadout, error) {
+	tabs, err := c.GetCharacterEquipmentTabs(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	var itemIDs []int
+	for _, tab := range tabs {
+		for _, equipment := range tab.Equipment {
+			itemIDs = append(itemIDs, equipment.ID)
+			itemIDs = append(itemIDs, equipment.Upgrades...)
+			itemIDs = append(itemIDs, equipment.Infusions...)
+		}
+	}
+	items, err := c.lookupItems(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up equipment: %w", err)
+	}
+
+	// Selectable stats are on the equipment, fixed stats on the item
+	var statIDs []int
+	for _, tab := range tabs {
+		for _, equipment := range tab.Equipment {
+			statIDs = append(statIDs, equipmentStatID(equipment, items[equipment.ID]))
+		}
+	}
+	stats, err := c.lookupItemStats(ctx, statIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up item stats: %w", err)
+	}
+
+	loadouts := make([]CharacterLoadout, 0, len(tabs))
+	for _, tab := range tabs {
+		loadout := CharacterLoadout{Tab: tab.Tab, Name: tab.Name, IsActive: tab.IsActive}
+		for _, equipment := range tab.Equipment {
+			item := items[equipment.ID]
+			if item == nil {
+				item = &Item{ID: equipment.ID}
+			}
+			stat := stats[equipmentStatID(equipment, item)]
+			loadout.Slots = append(loadout.Slots, LoadoutSlot{
+				Slot:          equipment.Slot,
+				Item:          item,
+				Stats:         stat,
+				Attributes:    slotAttributes(equipment, item, stat),
+				Upgrades:      resolveItems(items, equipment.Upgrades),
+				Infusions:     resolveItems(items, equipment.Infusions),
+				InfusionSlots: infusionSlots(item),
+				Binding:       equipment.Binding,
+			})
+		}
+		slices.SortStableFunc(loadout.Slots, func(a, b LoadoutSlot) int {
+			return cmp.Compare(slotOrder(a.Slot), slotOrder(b.Slot))
+		})
+		loadouts = append(loadouts, loadout)
+	}
+	return loadouts, nil
+}
+
+// equipmentStatID returns the ID of the stats an equipped item has, or 0
+func equipmentStatID(equipment CharacterEquipment, item *Item) int {
+	if equipment.Stats != nil {
+		return equipment.Stats.ID
+	}
+	if item != nil && item.Details != nil && item.Details.InfixUpgrade != nil {
+		return item.Details.InfixUpgrade.ID
+	}
+	return 0
+}
+
+// slotAttributes returns the attributes of an equipped item. The API lists
+// fixed stats already resolved on the item, while selected stats are scaled by
+// the item's attribute adjustment.
+func slotAttributes(equipment CharacterEquipment, item *Item, stat *ItemStat) map[string]int {
+	if equipment.Stats == nil {
+		if attributes := fixedAttributes(item); attributes != nil {
+			return attributes
+		}
+	}
+	return ResolveAttributes(item, stat)
+}
+
+// AttributeTotals sums the attributes of the gear worn together: armor,
+// trinkets and the first weapon set. The second weapon set and aquatic gear
+// replace those pieces rather than adding to them, so they are left out.
+func (l *CharacterLoadout) AttributeTotals() map[string]int {
+	totals := make(map[string]int)
+	for _, slot := range l.Slots {
+		if !slices.Contains(gearSlots, slot.Slot) || slot.Slot == "WeaponB1" || slot.Slot == "WeaponB2" {
+			continue
+		}
+		for attribute, value := range slot.Attributes {
+			totals[attribute] += value
+		}
+	}
+	return totals
+}
+
+// resolveItems maps IDs to items, keeping unknown IDs as bare items
+func resolveItems(items map[int]*Item, ids []int) []*Item {
+	var resolved []*Item
+	for _, id := range ids {
+		if item := items[id]; item != nil {
+			resolved = append(resolved, item)
+		} else {
+			resolved = append(resolved, &Item{ID: id})
+		}
+	}
+	return resolved
+}
+
+// infusionSlots counts the infusion slots on an item. Enrichment slots on
+// amulets take a different upgrade, so they are not counted.
+func infusionSlots(item *Item) int {
+	if item.Details == nil {
+		return 0
+	}
+	count := 0
+	for _, slot := range item.Details.InfusionSlots {
+		if slices.Contains(slot.Flags, "Infusion") {
+			count++
+		}
+	}
+	return count
+}
+
+// slotOrder sorts gear slots first, then other known slots, then anything new
+func slotOrder(slot string) int {
+	if i := slices.Index(gearSlots, slot); i >= 0 {
+		return i
+	}
+	if i := slices.Index(otherSlots, slot); i >= 0 {
+		return len(gearSlots) + i
+	}
+	return len(gearSlots) + len(otherSlots)
+}
+
+// Kinds of problem reported by GearCheck
+const (
+	GearEmptySlot       = "empty_slot"
+	GearMissingInfusion = "missing_infusion"
+	GearStatMismatch    = "stat_mismatch"
+)
+
+// GearWarning is a problem GearCheck found with a loadout
+type GearWarning struct {
+	Kind    string `json:"kind"`
+	Slot    string `json:"slot"`
+	Message string `json:"message"`
+}
+
+func (w GearWarning) String() string {
+	return w.Slot + ": " + w.Message
+}
+
+// GearCheck looks for empty gear slots, empty infusion slots on ascended and
+// legendary gear, and gear whose stats differ from the rest of the tab.
+// Mixed stats can be deliberate, so they are reported against the most
+// common stats rather than as errors.
+func (l *CharacterLoadout) GearCheck() []GearWarning {
+	var warnings []GearWarning
+
+	for _, slot := range gearSlots {
+		if l.Slot(slot) == nil && l.expectsSlot(slot) {
+			warnings = append(warnings, GearWarning{Kind: GearEmptySlot, Slot: slot, Message: "nothing equipped"})
+		}
+	}
+
+	for _, slot := range l.Slots {
+		if !slices.Contains(gearSlots, slot.Slot) {
+			continue
+		}
+		if rarity := slot.Item.Rarity; rarity != "Ascended" && rarity != "Legendary" {
+			continue
+		}
+		if missing := slot.InfusionSlots - len(slot.Infusions); missing > 0 {
+			warnings = append(warnings, GearWarning{
+				Kind:    GearMissingInfusion,
+				Slot:    slot.Slot,
+				Message: fmt.Sprintf("%d of %d infusion slots empty", missing, slot.InfusionSlots),
+			})
+		}
+	}
+
+	return append(warnings, l.statMismatches()...)
+}
+
+// expectsSlot reports whether an empty slot is worth a warning. The off-hand
+// is empty with a two-handed weapon, and the second weapon set is optional.
+func (l *CharacterLoadout) expectsSlot(slot string) bool {
+	switch slot {
+	case "WeaponA2":
+		return !l.twoHanded("WeaponA1")
+	case "WeaponB1":
+		return l.Slot("WeaponB2") != nil
+	case "WeaponB2":
+		return l.Slot("WeaponB1") != nil && !l.twoHanded("WeaponB1")
+	}
+	return true
+}
+
+func (l *CharacterLoadout) twoHanded(slot string) bool {
+	weapon := l.Slot(slot)
+	return weapon != nil && weapon.Item.Details != nil && slices.Contains(twoHandedWeapons, weapon.Item.Details.Type)
+}
+
+// statMismatches reports gear whose stats differ from the most common stats in
+// the tab. Stats are compared by name, since the same combination has
+// different IDs at different levels.
+func (l *CharacterLoadout) statMismatches() []GearWarning {
+	counts := make(map[string]int)
+	for _, slot := range l.Slots {
+		if slot.Stats != nil && slices.Contains(gearSlots, slot.Slot) {
+			counts[statName(slot.Stats)]++
+		}
+	}
+	if len(counts) < 2 {
+		return nil
+	}
+
+	// Ties go to the alphabetically first stats, so the result is stable
+	var common string
+	for name, count := range counts {
+		if count > counts[common] || count == counts[common] && name < common {
+			common = name
+		}
+	}
+
+	var warnings []GearWarning
+	for _, slot := range l.Slots {
+		if slot.Stats == nil || !slices.Contains(gearSlots, slot.Slot) {
+			continue
+		}
+		if name := statName(slot.Stats); name != common {
+			warnings = append(warnings, GearWarning{
+				Kind:    GearStatMismatch,
+				Slot:    slot.Slot,
+				Message: fmt.Sprintf("%s stats, while %d other pieces are %s", name, counts[common], common),
+			})
+		}
+	}
+	return warnings
+}
+
+// statName is the stat combination's name, or a placeholder for unnamed stats
+func statName(stat *ItemStat) string {
+	if name := strings.TrimSpace(stat.Name); name != "" {
+		return name
+	}
+	return fmt.Sprintf("stats %d", stat.ID)
+}
@@ -0,0 +1,155 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+)
+
+func newGearFixtureClient(t *testing.T) *Client {
+	return newFixtureClient(t, "gear", map[string]string{
+		"/v2/characters/Tester/equipmenttabs": "equipmenttabs.json",
+		"/v2/items":                           "items.json",
+		"/v2/itemstats":                       "itemstats.json",
+	})
+}
+
+func TestGetCharacterEquipmentTabsDetailed(t *testing.T) {
+	client := newGearFixtureClient(t)
+
+	loadouts, err := client.GetCharacterEquipmentTabsDetailed(context.Background(), "Tester")
+	if err != nil {
+		t.Fatalf("GetCharacterEquipmentTabsDetailed: %v", err)
+	}
+	if len(loadouts) != 2 {
+		t.Fatalf("got %d loadouts, expected 2", len(loadouts))
+	}
+
+	power := loadouts[0]
+	var slots []string
+	for _, slot := range power.Slots {
+		slots = append(slots, slot.Slot)
+	}
+	expectedSlots := []string{"Helm", "Shoulders", "Coat", "Gloves", "Leggings", "Boots", "WeaponA1", "Sickle"}
+	if !slices.Equal(slots, expectedSlots) {
+		t.Errorf("slots = %v, expected gear order %v", slots, expectedSlots)
+	}
+
+	helm := power.Slot("Helm")
+	if helm.Item.Name != "Perfected Envoy Helmet" || helm.Stats == nil || helm.Stats.Name != "Berserker's" {
+		t.Errorf("helm = %+v, expected the selected Berserker's stats", helm)
+	}
+	if len(helm.Upgrades) != 1 || helm.Upgrades[0].Name != "Superior Rune of the Scholar" || helm.InfusionSlots != 1 {
+		t.Errorf("helm upgrades = %+v with %d infusion slots", helm.Upgrades, helm.InfusionSlots)
+	}
+	if shoulders := power.Slot("Shoulders"); shoulders.Stats == nil || shoulders.Stats.ID != 161 {
+		t.Errorf("shoulders stats = %+v, expected the item's fixed stats", shoulders.Stats)
+	}
+	if weapon := power.Slot("WeaponA1"); len(weapon.Infusions) != 1 || weapon.Infusions[0].Name != "+9 Agony Infusion" {
+		t.Errorf("weapon infusions = %+v", weapon.Infusions)
+	}
+	if sickle := power.Slot("Sickle"); sickle.Stats != nil {
+		t.Errorf("sickle stats = %+v, expected none", sickle.Stats)
+	}
+}
+
+func TestGearCheck(t *testing.T) {
+	client := newGearFixtureClient(t)
+	loadouts, err := client.GetCharacterEquipmentTabsDetailed(context.Background(), "Tester")
+	if err != nil {
+		t.Fatalf("GetCharacterEquipmentTabsDetailed: %v", err)
+	}
+
+	found := make(map[string][]string)
+	for _, warning := range loadouts[0].GearCheck() {
+		found[warning.Kind] = append(found[warning.Kind], warning.Slot)
+	}
+
+	// The greatsword is two-handed and the second weapon set is unused
+	if expected := []string{"Backpack", "Accessory1", "Accessory2", "Amulet", "Ring1", "Ring2"}; !slices.Equal(found[GearEmptySlot], expected) {
+		t.Errorf("empty slots = %v, expected %v", found[GearEmptySlot], expected)
+	}
+	if expected := []string{"Helm", "WeaponA1"}; !slices.Equal(found[GearMissingInfusion], expected) {
+		t.Errorf("missing infusions = %v, expected %v", found[GearMissingInfusion], expected)
+	}
+	if expected := []string{"Boots"}; !slices.Equal(found[GearStatMismatch], expected) {
+		t.Errorf("stat mismatches = %v, expected %v", found[GearStatMismatch], expected)
+	}
+
+	// An empty tab misses every slot except the optional second weapon set
+	if warnings := loadouts[1].GearCheck(); len(warnings) != 14 {
+		t.Errorf("empty tab has %d warnings, expected 14: %v", len(warnings), warnings)
+	}
+}
+
+func TestGearCheckOffHand(t *testing.T) {
+	sword := &Item{Details: &ItemDetails{Type: "Sword"}}
+	loadout := CharacterLoadout{Slots: []LoadoutSlot{
+		{Slot: "WeaponA1", Item: sword},
+		{Slot: "WeaponB1", Item: sword},
+	}}
+
+	var empty []string
+	for _, warning := range loadout.GearCheck() {
+		if warning.Kind == GearEmptySlot && slices.Contains([]string{"WeaponA2", "WeaponB1", "WeaponB2"}, warning.Slot) {
+			empty = append(empty, warning.Slot)
+		}
+	}
+	if !slices.Equal(empty, []string{"WeaponA2", "WeaponB2"}) {
+		t.Errorf("empty weapon slots = %v, expected both off-hands", empty)
+	}
+}
+
+func TestLoadoutAttributes(t *testing.T) {
+	client := newGearFixtureClient(t)
+	loadouts, err := client.GetCharacterEquipmentTabsDetailed(context.Background(), "Tester")
+	if err != nil {
+		t.Fatalf("GetCharacterEquipmentTabsDetailed: %v", err)
+	}
+	power := loadouts[0]
+
+	// Selected stats resolve to what the API reports for the equipment
+	tabs, err := client.GetCharacterEquipmentTabs(context.Background(), "Tester")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, equipment := range tabs[0].Equipment {
+		if equipment.Stats == nil {
+			continue
+		}
+		reported := map[string]int{}
+		json.Unmarshal(mustMarshal(t, equipment.Stats.Attributes), &reported)
+		if got := power.Slot(equipment.Slot).Attributes; !maps.Equal(got, reported) {
+			t.Errorf("%s attributes = %v, expected the reported %v", equipment.Slot, got, reported)
+		}
+	}
+
+	if got := power.Slot("Shoulders").Attributes; !maps.Equal(got, map[string]int{"Power": 47}) {
+		t.Errorf("shoulders attributes = %v, expected the item's fixed Power 47", got)
+	}
+	if got := power.Slot("Sickle").Attributes; got != nil {
+		t.Errorf("sickle attributes = %v, expected none", got)
+	}
+
+	expected := map[string]int{"Power": 48 + 63 + 4*47 + 251, "Precision": 45 + 179, "CritDamage": 45 + 179, "Healing": 67, "Toughness": 48}
+	if got := power.AttributeTotals(); !maps.Equal(got, expected) {
+		t.Errorf("AttributeTotals = %v, expected %v", got, expected)
+	}
+
+	// The second weapon set is an alternative to the first, so it isn't added
+	power.Slots = append(power.Slots, LoadoutSlot{Slot: "WeaponB1", Attributes: map[string]int{"Power": 251}})
+	if got := power.AttributeTotals()["Power"]; got != expected["Power"] {
+		t.Errorf("Power total with a second weapon set = %d, expected %d", got, expected["Power"])
+	}
+}
+
+func mustMarshal(t *testing.T, v any) []byte {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
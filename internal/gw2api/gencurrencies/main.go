@@ -0,0 +1,116 @@
+// Command gencurrencies writes the currency ID constants of package gw2api from
+// a currencies.json data file, as written by `updatedb -kind currencies`.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"unicode"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func main() {
+	var (
+		in  = flag.String("in", "data/currencies.json", "JSONL file of currencies")
+		out = flag.String("out", "currencies_gen.go", "Go file to write")
+		pkg = flag.String("package", "gw2api", "Package of the generated file")
+	)
+	flag.Parse()
+
+	currencies, err := readCurrencies(*in)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gencurrencies: %v\n", err)
+		os.Exit(1)
+	}
+
+	source, err := generate(*pkg, filepath.Base(*in), currencies)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gencurrencies: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "gencurrencies: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// readCurrencies reads every currency from a JSONL file, sorted by ID
+func readCurrencies(path string) ([]gw2api.Currency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var currencies []gw2api.Currency
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var currency gw2api.Currency
+		if err := json.Unmarshal(line, &currency); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		currencies = append(currencies, currency)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(currencies, func(a, b gw2api.Currency) int { return a.ID - b.ID })
+	return currencies, nil
+}
+
+// generate returns the formatted source of the constants
+func generate(pkg, source string, currencies []gw2api.Currency) ([]byte, error) {
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gencurrencies from %s; DO NOT EDIT.\n\n", source)
+	fmt.Fprintf(&buf, "package %s\n\n", pkg)
+	buf.WriteString("// Currency IDs, as used by the wallet and the currencies endpoint\n")
+	buf.WriteString("const (\n")
+
+	seen := make(map[string]bool)
+	for _, currency := range currencies {
+		name := constName(currency.Name)
+		if name == "Currency" || seen[name] {
+			// Unnamed or ambiguous currencies get their ID appended
+			name = fmt.Sprintf("%s%d", name, currency.ID)
+		}
+		seen[name] = true
+		fmt.Fprintf(&buf, "\t%s = %d // %s\n", name, currency.ID, currency.Name)
+	}
+	buf.WriteString(")\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// constName turns a currency name such as "Trader's Key" into CurrencyTradersKey
+func constName(name string) string {
+	var b strings.Builder
+	b.WriteString("Currency")
+	for word := range strings.FieldsFuncSeq(name, func(r rune) bool { return unicode.IsSpace(r) || r == '-' }) {
+		first := true
+		for _, r := range word {
+			if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+				continue
+			}
+			if first {
+				r = unicode.ToUpper(r)
+				first = false
+			}
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,363 @@
+// Command genendpoints writes the Endpoints registry of package gw2api by
+// scanning the Client methods for the API paths they request, the scopes
+// their docs list and the kind of IDs the request helpers they call take. Run
+// it from the package directory.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	var (
+		dir = flag.String("dir", ".", "Directory of package gw2api")
+		out = flag.String("out", "endpoints_gen.go", "Go file to write")
+	)
+	flag.Parse()
+
+	endpoints, err := scan(*dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genendpoints: %v\n", err)
+		os.Exit(1)
+	}
+
+	source, err := generate(endpoints)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "genendpoints: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(*out, source, 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "genendpoints: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// endpoint is what the scan learned about a path
+type endpoint struct {
+	methods []string
+	scopes  [][]string // The required scopes of each method with a Scopes line
+	ids     string     // Name of the gw2api.IDKind constant
+}
+
+// idHelpers are the request helpers that take or list IDs, with the argument
+// holding the path and the kind of ID. An empty kind means the helper lists
+// IDs when its result is a list of ints or strings, as for GetAll[string].
+var idHelpers = map[string]struct {
+	pathArg int
+	kind    string
+}{
+	"GetIDs":         {2, "IntIDs"},
+	"GetByID":        {2, "IntIDs"},
+	"GetByIDs":       {2, "IntIDs"},
+	"getByIDsCached": {3, "IntIDs"},
+	"GetAll":         {2, ""},
+	"GetSingle":      {2, ""},
+}
+
+// scan returns what each method requesting a path says about it, keyed by path
+func scan(dir string) (map[string]*endpoint, error) {
+	fset := token.NewFileSet()
+	skip := func(info os.FileInfo) bool {
+		name := info.Name()
+		return !strings.HasSuffix(name, "_test.go") && !strings.HasSuffix(name, "_gen.go")
+	}
+	packages, err := parser.ParseDir(fset, dir, skip, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	pkg, found := packages["gw2api"]
+	if !found {
+		return nil, fmt.Errorf("no gw2api package in %s", dir)
+	}
+
+	basic := basicTypes(pkg)
+	endpoints := make(map[string]*endpoint)
+	get := func(path string) *endpoint {
+		if endpoints[path] == nil {
+			endpoints[path] = &endpoint{}
+		}
+		return endpoints[path]
+	}
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Body == nil || !fn.Name.IsExported() || !isClientMethod(fn) {
+				continue
+			}
+			scopes, documented := docScopes(fn.Doc)
+			ast.Inspect(fn.Body, func(node ast.Node) bool {
+				if call, ok := node.(*ast.CallExpr); ok {
+					if path, kind, ok := idHelperCall(call, basic); ok && get(path).ids == "" {
+						get(path).ids = kind
+					}
+					return true
+				}
+				expr, ok := node.(ast.Expr)
+				if !ok {
+					return true
+				}
+				path, ok := endpointPath(expr)
+				if !ok {
+					return true
+				}
+				e := get(path)
+				if !slices.Contains(e.methods, fn.Name.Name) {
+					e.methods = append(e.methods, fn.Name.Name)
+					if documented {
+						e.scopes = append(e.scopes, scopes)
+					}
+				}
+				// The parts of a path aren't paths of their own
+				return false
+			})
+		}
+	}
+	return endpoints, nil
+}
+
+// docScopes returns the required scopes from the "Scopes:" line of a doc
+// comment. Scopes followed by a note in parentheses, such as "(optional)",
+// aren't required.
+func docScopes(doc *ast.CommentGroup) ([]string, bool) {
+	if doc == nil {
+		return nil, false
+	}
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		list, found := strings.CutPrefix(line, "Scopes:")
+		if !found {
+			continue
+		}
+		var scopes []string
+		for _, scope := range strings.Split(list, ",") {
+			scope = strings.TrimSpace(scope)
+			if scope == "" || strings.Contains(scope, "(") || strings.EqualFold(scope, "none") {
+				continue
+			}
+			scopes = append(scopes, scope)
+		}
+		return scopes, true
+	}
+	return nil, false
+}
+
+// basicTypes returns the underlying type of each type in the package declared
+// as an int or a string, such as Glider
+func basicTypes(pkg *ast.Package) map[string]string {
+	basic := map[string]string{"int": "int", "string": "string"}
+	for _, file := range pkg.Files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				spec := spec.(*ast.TypeSpec)
+				if ident, ok := spec.Type.(*ast.Ident); ok && (ident.Name == "int" || ident.Name == "string") {
+					basic[spec.Name.Name] = ident.Name
+				}
+			}
+		}
+	}
+	return basic
+}
+
+// idHelperCall returns the path and kind of ID of a call to one of the
+// idHelpers, such as GetIDs[int](ctx, c, "/v2/items")
+func idHelperCall(call *ast.CallExpr, basic map[string]string) (string, string, bool) {
+	fun := call.Fun
+	var typeArg ast.Expr
+	if index, ok := fun.(*ast.IndexExpr); ok {
+		fun, typeArg = index.X, index.Index
+	}
+	ident, ok := fun.(*ast.Ident)
+	if !ok {
+		return "", "", false
+	}
+	helper, found := idHelpers[ident.Name]
+	if !found || len(call.Args) <= helper.pathArg {
+		return "", "", false
+	}
+	kind := helper.kind
+	if kind == "" {
+		kind = listedIDs(ident.Name, typeArg, basic)
+		if kind == "" {
+			return "", "", false
+		}
+	}
+	path, ok := endpointPath(call.Args[helper.pathArg])
+	if !ok {
+		return "", "", false
+	}
+	return path, kind, true
+}
+
+// listedIDs returns the kind of ID a GetAll or GetSingle call lists, if it
+// lists IDs: GetAll[T] or GetSingle[[]T] with T an int or string type
+func listedIDs(helper string, typeArg ast.Expr, basic map[string]string) string {
+	if helper == "GetSingle" {
+		array, ok := typeArg.(*ast.ArrayType)
+		if !ok || array.Len != nil {
+			return ""
+		}
+		typeArg = array.Elt
+	}
+	ident, ok := typeArg.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	switch basic[ident.Name] {
+	case "int":
+		return "IntIDs"
+	case "string":
+		return "StringIDs"
+	}
+	return ""
+}
+
+// required returns the scopes every documented method needs
+func (e *endpoint) required() []string {
+	if len(e.scopes) == 0 {
+		return nil
+	}
+	required := slices.Clone(e.scopes[0])
+	for _, scopes := range e.scopes[1:] {
+		required = slices.DeleteFunc(required, func(scope string) bool {
+			return !slices.Contains(scopes, scope)
+		})
+	}
+	return required
+}
+
+// isClientMethod reports whether fn is a method on *Client
+func isClientMethod(fn *ast.FuncDecl) bool {
+	if fn.Recv == nil || len(fn.Recv.List) != 1 {
+		return false
+	}
+	star, ok := fn.Recv.List[0].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	ident, ok := star.X.(*ast.Ident)
+	return ok && ident.Name == "Client"
+}
+
+// formatVerb matches the verbs of a fmt.Sprintf format
+var formatVerb = regexp.MustCompile(`%[-+# 0-9.]*[a-zA-Z]`)
+
+// endpointPath returns the path an expression builds, with :id for each
+// part that isn't a constant, if the expression is an endpoint path. Paths are
+// string literals starting with /v2/, concatenations starting with one, or
+// fmt.Sprintf calls with one as the format.
+func endpointPath(expr ast.Expr) (string, bool) {
+	var path string
+	switch expr := expr.(type) {
+	case *ast.BasicLit:
+		literal, ok := stringLiteral(expr)
+		if !ok {
+			return "", false
+		}
+		path = literal
+	case *ast.BinaryExpr:
+		if expr.Op != token.ADD {
+			return "", false
+		}
+		var parts []string
+		for _, operand := range flatten(expr) {
+			if literal, ok := stringLiteral(operand); ok {
+				parts = append(parts, literal)
+			} else {
+				parts = append(parts, ":id")
+			}
+		}
+		path = strings.Join(parts, "")
+	case *ast.CallExpr:
+		selector, ok := expr.Fun.(*ast.SelectorExpr)
+		if !ok || selector.Sel.Name != "Sprintf" || len(expr.Args) == 0 {
+			return "", false
+		}
+		if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != "fmt" {
+			return "", false
+		}
+		format, ok := stringLiteral(expr.Args[0])
+		if !ok {
+			return "", false
+		}
+		path = formatVerb.ReplaceAllString(format, ":id")
+	default:
+		return "", false
+	}
+
+	if !strings.HasPrefix(path, "/v2/") {
+		return "", false
+	}
+	// Query strings, such as ?ids= built by hand, aren't part of the path
+	path, _, _ = strings.Cut(path, "?")
+	return strings.TrimSuffix(path, "/"), true
+}
+
+// flatten returns the operands of a chain of + in order
+func flatten(expr ast.Expr) []ast.Expr {
+	if binary, ok := expr.(*ast.BinaryExpr); ok && binary.Op == token.ADD {
+		return append(flatten(binary.X), flatten(binary.Y)...)
+	}
+	return []ast.Expr{expr}
+}
+
+// stringLiteral returns the value of a string literal
+func stringLiteral(expr ast.Expr) (string, bool) {
+	literal, ok := expr.(*ast.BasicLit)
+	if !ok || literal.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(literal.Value)
+	return value, err == nil
+}
+
+// generate returns the formatted source of the registry
+func generate(endpoints map[string]*endpoint) ([]byte, error) {
+	paths := make([]string, 0, len(endpoints))
+	for path := range endpoints {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by genendpoints; DO NOT EDIT.\n\n")
+	buf.WriteString("package gw2api\n\n")
+	buf.WriteString("// Endpoints lists every API path the client requests, sorted by path\n")
+	buf.WriteString("var Endpoints = []Endpoint{\n")
+	for _, path := range paths {
+		e := endpoints[path]
+		fmt.Fprintf(&buf, "\t{Path: %q, Methods: %s", path, stringSlice(slices.Sorted(slices.Values(e.methods))))
+		if scopes := e.required(); len(scopes) > 0 {
+			fmt.Fprintf(&buf, ", Scopes: %s", stringSlice(scopes))
+		}
+		if e.ids != "" {
+			fmt.Fprintf(&buf, ", IDs: %s", e.ids)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// stringSlice returns the source of a []string literal
+func stringSlice(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = strconv.Quote(value)
+	}
+	return "[]string{" + strings.Join(quoted, ", ") + "}"
+}
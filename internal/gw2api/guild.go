@@ -1,6 +1,10 @@
 package gw2api
 
-import "time"
+import (
+	"context"
+	"strconv"
+	"time"
+)
 
 // Guild represents basic guild information
 type Guild struct {
@@ -29,21 +33,6 @@ type GuildEmblemLayer struct {
 	Colors []int `json:"colors"`
 }
 
-// GuildLog represents a guild log entry
-type GuildLog struct {
-	ID        int       `json:"id"`
-	Time      time.Time `json:"time"`
-	User      string    `json:"user,omitempty"`
-	Type      string    `json:"type"`
-	Invited   string    `json:"invited,omitempty"`
-	Kicked    string    `json:"kicked,omitempty"`
-	ChangedTo string    `json:"changed_to,omitempty"`
-	Operation string    `json:"operation,omitempty"`
-	Activity  string    `json:"activity,omitempty"`
-	TotalParticipants int `json:"total_participants,omitempty"`
-	Participants []string `json:"participants,omitempty"`
-}
-
 // GuildMember represents a guild member
 type GuildMember struct {
 	Name   string    `json:"name"`
@@ -74,7 +63,7 @@ type GuildStashSlot struct {
 	Count    int       `json:"count"`
 	Binding  string    `json:"binding,omitempty"`
 	BoundTo  string    `json:"bound_to,omitempty"`
-	Stats    *ItemStat `json:"stats,omitempty"`
+	Stats    *ItemInstanceStats `json:"stats,omitempty"`
 }
 
 // GuildStorage represents guild storage
@@ -182,4 +171,120 @@ type GuildUpgradeCost struct {
 	Name     string `json:"name,omitempty"`
 	Count    int    `json:"count"`
 	ItemID   int    `json:"item_id,omitempty"`
-}
\ No newline at end of file
+}
+
+// GetGuild returns guild information by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)
+// Scopes: guilds
+func (c *Client) GetGuild(ctx context.Context, id string, options ...RequestOption) (*Guild, error) {
+	return GetSingle[Guild](ctx, c, "/v2/guild/"+id, options...)
+}
+
+// GetGuildLog returns guild log.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/log
+// Scopes: guilds
+func (c *Client) GetGuildLog(ctx context.Context, id string, options ...RequestOption) ([]GuildLog, error) {
+	return GetAll[GuildLog](ctx, c, "/v2/guild/"+id+"/log", options...)
+}
+
+// GetGuildLogSince returns the guild log entries newer than the entry
+// sinceID, for fetching only what was logged since the last call. The API
+// keeps the last 100 entries, so a gap between sinceID and the oldest entry
+// returned means some were missed.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/log
+// Scopes: guilds
+func (c *Client) GetGuildLogSince(ctx context.Context, guildID string, sinceID int, options ...RequestOption) ([]GuildLog, error) {
+	options = append(options, WithParam("since", strconv.Itoa(sinceID)))
+	return GetAll[GuildLog](ctx, c, "/v2/guild/"+guildID+"/log", options...)
+}
+
+// GetGuildMembers returns guild members.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/members
+// Scopes: guilds
+func (c *Client) GetGuildMembers(ctx context.Context, id string, options ...RequestOption) ([]GuildMember, error) {
+	return GetAll[GuildMember](ctx, c, "/v2/guild/"+id+"/members", options...)
+}
+
+// GetGuildRanks returns guild ranks.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/ranks
+// Scopes: guilds
+func (c *Client) GetGuildRanks(ctx context.Context, id string, options ...RequestOption) ([]GuildRank, error) {
+	return GetAll[GuildRank](ctx, c, "/v2/guild/"+id+"/ranks", options...)
+}
+
+// GetGuildStash returns guild stash.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/stash
+// Scopes: guilds
+func (c *Client) GetGuildStash(ctx context.Context, id string, options ...RequestOption) ([]GuildStash, error) {
+	return GetAll[GuildStash](ctx, c, "/v2/guild/"+id+"/stash", options...)
+}
+
+// GetGuildStorage returns guild storage.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/storage
+// Scopes: guilds
+func (c *Client) GetGuildStorage(ctx context.Context, id string, options ...RequestOption) ([]GuildStorage, error) {
+	return GetAll[GuildStorage](ctx, c, "/v2/guild/"+id+"/storage", options...)
+}
+
+// GetGuildTeams returns guild teams.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/teams
+// Scopes: guilds
+func (c *Client) GetGuildTeams(ctx context.Context, id string, options ...RequestOption) ([]GuildTeam, error) {
+	return GetAll[GuildTeam](ctx, c, "/v2/guild/"+id+"/teams", options...)
+}
+
+// GetGuildTreasury returns guild treasury.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/treasury
+// Scopes: guilds
+func (c *Client) GetGuildTreasury(ctx context.Context, id string, options ...RequestOption) ([]GuildTreasury, error) {
+	return GetAll[GuildTreasury](ctx, c, "/v2/guild/"+id+"/treasury", options...)
+}
+
+// GetGuildUpgrades returns guild upgrades.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/(id)/upgrades
+// Scopes: guilds
+func (c *Client) GetGuildUpgrades(ctx context.Context, id string, options ...RequestOption) ([]GuildUpgrade, error) {
+	return GetAll[GuildUpgrade](ctx, c, "/v2/guild/"+id+"/upgrades", options...)
+}
+
+// GetGuildPermissionIDs returns all guild permission IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/permissions
+// Scopes: None (public endpoint)
+func (c *Client) GetGuildPermissionIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/guild/permissions", options...)
+}
+
+// GetGuildPermission returns a specific guild permission by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/permissions
+// Scopes: None (public endpoint)
+func (c *Client) GetGuildPermission(ctx context.Context, id string, options ...RequestOption) (*GuildPermission, error) {
+	return GetSingle[GuildPermission](ctx, c, "/v2/guild/permissions/"+id, options...)
+}
+
+// GetGuildSearch returns guild search functionality.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/search
+// Scopes: None (public endpoint)
+func (c *Client) GetGuildSearch(ctx context.Context, options ...RequestOption) (*GuildSearch, error) {
+	return GetSingle[GuildSearch](ctx, c, "/v2/guild/search", options...)
+}
+
+// GetGuildUpgradeDetailIDs returns all guild upgrade detail IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/upgrades
+// Scopes: None (public endpoint)
+func (c *Client) GetGuildUpgradeDetailIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/guild/upgrades", options...)
+}
+
+// GetGuildUpgradeDetail returns a specific guild upgrade detail by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/upgrades
+// Scopes: None (public endpoint)
+func (c *Client) GetGuildUpgradeDetail(ctx context.Context, id int, options ...RequestOption) (*GuildUpgradeDetail, error) {
+	return GetByID[GuildUpgradeDetail](ctx, c, "/v2/guild/upgrades", id, options...)
+}
+
+// GetGuildUpgradeDetails returns multiple guild upgrade details by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/guild/upgrades
+// Scopes: None (public endpoint)
+func (c *Client) GetGuildUpgradeDetails(ctx context.Context, ids []int, options ...RequestOption) ([]GuildUpgradeDetail, error) {
+	return GetByIDs[GuildUpgradeDetail](ctx, c, "/v2/guild/upgrades", ids, options...)
+}
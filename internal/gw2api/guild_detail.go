@@ -0,0 +1,269 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// GuildLeaderError is returned when an endpoint only answers for the guild
+// leader and the API key belongs to another member. It matches ErrMissingScope.
+type GuildLeaderError struct {
+	GuildID string
+	Err     error
+}
+
+func (e *GuildLeaderError) Error() string {
+	return fmt.Sprintf("guild %s: only the guild leader's API key can read this: %v", e.GuildID, e.Err)
+}
+
+func (e *GuildLeaderError) Unwrap() error {
+	return e.Err
+}
+
+// guildLeaderError wraps a 403 for a leader-only endpoint in a GuildLeaderError.
+// A 403 for a missing scope is returned as is.
+func guildLeaderError(guildID string, err error) error {
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) && errors.Is(err, ErrMissingScope) && strings.Contains(strings.ToLower(httpErr.Message), "leader") {
+		return &GuildLeaderError{GuildID: guildID, Err: err}
+	}
+	return err
+}
+
+// GuildStashDetail is a guild's stash with every slot resolved to its item and value
+type GuildStashDetail struct {
+	Tabs      []GuildStashTabDetail `json:"tabs"`
+	Coins     int                   `json:"coins"`
+	ItemValue int                   `json:"item_value"` // Trading post value of the items in every tab
+}
+
+// GuildStashTabDetail is one stash tab, such as the Guild Vault or Treasure Trove
+type GuildStashTabDetail struct {
+	UpgradeID int                    `json:"upgrade_id"`
+	Name      string                 `json:"name"` // Name of the upgrade that adds the tab
+	Size      int                    `json:"size"`
+	Coins     int                    `json:"coins"`
+	Note      string                 `json:"note"`
+	Slots     []GuildStashSlotDetail `json:"slots"` // Empty slots are left out
+	ItemValue int                    `json:"item_value"`
+}
+
+// GuildStashSlotDetail is a stack of items in a stash tab
+type GuildStashSlotDetail struct {
+	Slot      int   `json:"slot"` // Position in the tab, from 0
+	Item      *Item `json:"item"` // Only the ID is set for items missing from the API
+	Count     int   `json:"count"`
+	UnitPrice int   `json:"unit_price"` // 0 when the item is not on the trading post
+	Value     int   `json:"value"`
+}
+
+// Value returns the stash's coins plus the value of its items
+func (d *GuildStashDetail) Value() int {
+	return d.Coins + d.ItemValue
+}
+
+// Value returns the tab's coins plus the value of its items
+func (t *GuildStashTabDetail) Value() int {
+	return t.Coins + t.ItemValue
+}
+
+// summarizeGuildStash joins stash tabs with item details, prices and upgrade names
+func summarizeGuildStash(stash []GuildStash, items map[int]*Item, prices map[int]*Price, upgrades map[int]string) *GuildStashDetail {
+	detail := &GuildStashDetail{Tabs: make([]GuildStashTabDetail, 0, len(stash))}
+	for _, tab := range stash {
+		tabDetail := GuildStashTabDetail{
+			UpgradeID: tab.UpgradeID,
+			Name:      upgrades[tab.UpgradeID],
+			Size:      tab.Size,
+			Coins:     tab.Coins,
+			Note:      tab.Note,
+		}
+		for i, slot := range tab.Inventory {
+			if slot.ID == 0 {
+				continue // Empty slot
+			}
+			item, found := items[slot.ID]
+			if !found {
+				item = &Item{ID: slot.ID}
+			}
+
+			slotDetail := GuildStashSlotDetail{Slot: i, Item: item, Count: slot.Count}
+			if price, found := prices[slot.ID]; found {
+				slotDetail.UnitPrice = unitPrice(price)
+				slotDetail.Value = slotDetail.UnitPrice * slot.Count
+			}
+			tabDetail.Slots = append(tabDetail.Slots, slotDetail)
+			tabDetail.ItemValue += slotDetail.Value
+		}
+
+		detail.Tabs = append(detail.Tabs, tabDetail)
+		detail.Coins += tab.Coins
+		detail.ItemValue += tabDetail.ItemValue
+	}
+	return detail
+}
+
+// GetGuildStashDetailed returns the guild's stash with item details and
+// trading post values for every slot, tab and the stash as a whole.
+// Requires the guild leader's API key; other keys get a *GuildLeaderError.
+// Scopes: guilds
+func (c *Client) GetGuildStashDetailed(ctx context.Context, guildID string) (*GuildStashDetail, error) {
+	stash, err := c.GetGuildStash(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild stash: %w", guildLeaderError(guildID, err))
+	}
+
+	var itemIDs, upgradeIDs []int
+	for _, tab := range stash {
+		upgradeIDs = append(upgradeIDs, tab.UpgradeID)
+		for _, slot := range tab.Inventory {
+			itemIDs = append(itemIDs, slot.ID)
+		}
+	}
+
+	items, err := c.lookupItems(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stash items: %w", err)
+	}
+
+	// Bound items can't be sold, so only the rest are priced
+	var tradableIDs []int
+	for id, item := range items {
+		if item.IsTradable() {
+			tradableIDs = append(tradableIDs, id)
+		}
+	}
+	prices, err := c.lookupPrices(ctx, tradableIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get stash item prices: %w", err)
+	}
+
+	upgrades, err := c.lookupGuildUpgradeNames(ctx, upgradeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeGuildStash(stash, items, prices, upgrades), nil
+}
+
+// GuildTreasuryDetail is a guild's treasury joined with the items deposited and
+// the upgrades waiting for them
+type GuildTreasuryDetail struct {
+	Entries []GuildTreasuryEntryDetail `json:"entries"` // Least complete first
+	Count   int                        `json:"count"`   // Items deposited, capped at what is needed
+	Needed  int                        `json:"needed"`
+}
+
+// GuildTreasuryEntryDetail is one item in the treasury
+type GuildTreasuryEntryDetail struct {
+	Item     *Item                      `json:"item"` // Only the ID is set for items missing from the API
+	Count    int                        `json:"count"`
+	Needed   int                        `json:"needed"` // Total needed by every upgrade
+	NeededBy []GuildTreasuryUpgradeNeed `json:"needed_by"`
+}
+
+// GuildTreasuryUpgradeNeed is how many of an item one upgrade still needs
+type GuildTreasuryUpgradeNeed struct {
+	UpgradeID int    `json:"upgrade_id"`
+	Name      string `json:"name"` // Empty for upgrades missing from the API
+	Count     int    `json:"count"`
+}
+
+// Completion returns the percentage of the needed items that have been deposited
+func (e *GuildTreasuryEntryDetail) Completion() float64 {
+	return completion(e.Count, e.Needed)
+}
+
+// Completion returns the percentage of every needed item that has been deposited
+func (d *GuildTreasuryDetail) Completion() float64 {
+	return completion(d.Count, d.Needed)
+}
+
+func completion(count, needed int) float64 {
+	if needed == 0 {
+		return 100
+	}
+	return float64(min(count, needed)) / float64(needed) * 100
+}
+
+// summarizeGuildTreasury joins treasury entries with item details and upgrade names
+func summarizeGuildTreasury(treasury []GuildTreasury, items map[int]*Item, upgrades map[int]string) *GuildTreasuryDetail {
+	detail := &GuildTreasuryDetail{Entries: make([]GuildTreasuryEntryDetail, 0, len(treasury))}
+	for _, entry := range treasury {
+		item, found := items[entry.ItemID]
+		if !found {
+			item = &Item{ID: entry.ItemID}
+		}
+
+		entryDetail := GuildTreasuryEntryDetail{Item: item, Count: entry.Count}
+		for _, need := range entry.NeededBy {
+			entryDetail.NeededBy = append(entryDetail.NeededBy, GuildTreasuryUpgradeNeed{
+				UpgradeID: need.UpgradeID,
+				Name:      upgrades[need.UpgradeID],
+				Count:     need.Count,
+			})
+			entryDetail.Needed += need.Count
+		}
+
+		detail.Entries = append(detail.Entries, entryDetail)
+		detail.Count += min(entryDetail.Count, entryDetail.Needed)
+		detail.Needed += entryDetail.Needed
+	}
+
+	slices.SortFunc(detail.Entries, func(a, b GuildTreasuryEntryDetail) int {
+		return cmp.Or(
+			cmp.Compare(a.Completion(), b.Completion()),
+			cmp.Compare(a.Item.Name, b.Item.Name),
+			cmp.Compare(a.Item.ID, b.Item.ID),
+		)
+	})
+	return detail
+}
+
+// GetGuildTreasuryDetailed returns the guild's treasury with item details and
+// the names of the upgrades that need each item.
+// Requires the guild leader's API key; other keys get a *GuildLeaderError.
+// Scopes: guilds
+func (c *Client) GetGuildTreasuryDetailed(ctx context.Context, guildID string) (*GuildTreasuryDetail, error) {
+	treasury, err := c.GetGuildTreasury(ctx, guildID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get guild treasury: %w", guildLeaderError(guildID, err))
+	}
+
+	var itemIDs, upgradeIDs []int
+	for _, entry := range treasury {
+		itemIDs = append(itemIDs, entry.ItemID)
+		for _, need := range entry.NeededBy {
+			upgradeIDs = append(upgradeIDs, need.UpgradeID)
+		}
+	}
+
+	items, err := c.lookupItems(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get treasury items: %w", err)
+	}
+	upgrades, err := c.lookupGuildUpgradeNames(ctx, upgradeIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarizeGuildTreasury(treasury, items, upgrades), nil
+}
+
+// lookupGuildUpgradeNames fetches guild upgrade names, keyed by ID
+func (c *Client) lookupGuildUpgradeNames(ctx context.Context, ids []int) (map[int]string, error) {
+	upgrades, err := c.GetGuildUpgradeDetails(ctx, uniqueIDs(ids))
+	// Upgrades removed from the API keep an empty name
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to get guild upgrades: %w", err)
+	}
+	names := make(map[int]string, len(upgrades))
+	for _, upgrade := range upgrades {
+		names[upgrade.ID] = upgrade.Name
+	}
+	return names, nil
+}
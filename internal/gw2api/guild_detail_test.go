@@ -0,0 +1,116 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const testGuildID = "4BBB52AA-D768-4FC6-8EDE-C299F2822F0F"
+
+func newGuildFixtureClient(t *testing.T) *Client {
+	return newFixtureClient(t, "guild", map[string]string{
+		"/v2/guild/" + testGuildID + "/stash":    "stash.json",
+		"/v2/guild/" + testGuildID + "/treasury": "treasury.json",
+		"/v2/items":                              "items.json",
+		"/v2/commerce/prices":                    "prices.json",
+		"/v2/guild/upgrades":                     "upgrades.json",
+	})
+}
+
+func TestGetGuildStashDetailed(t *testing.T) {
+	stash, err := newGuildFixtureClient(t).GetGuildStashDetailed(context.Background(), testGuildID)
+	if err != nil {
+		t.Fatalf("GetGuildStashDetailed: %v", err)
+	}
+	if len(stash.Tabs) != 2 {
+		t.Fatalf("got %d tabs, expected 2", len(stash.Tabs))
+	}
+
+	vault := stash.Tabs[0]
+	if vault.Name != "Guild Vault" || len(vault.Slots) != 3 {
+		t.Fatalf("vault = %+v, expected the named tab with three filled slots", vault)
+	}
+	ecto, banner, coins := vault.Slots[0], vault.Slots[1], vault.Slots[2]
+	if ecto.Item.Name != "Glob of Ectoplasm" || ecto.UnitPrice != 2100 || ecto.Value != 21000 {
+		t.Errorf("ectoplasm = %+v, expected 10 at the 2100 sell price", ecto)
+	}
+	if banner.Slot != 2 || banner.Value != 0 {
+		t.Errorf("banner = %+v, expected slot 2 with no value since it is account bound", banner)
+	}
+	if coins.UnitPrice != 9000 {
+		t.Errorf("mystic coin unit price = %d, expected the buy order without sell listings", coins.UnitPrice)
+	}
+
+	expectedItems := 21000 + 250*9000
+	if vault.ItemValue != expectedItems || vault.Value() != expectedItems+12345 {
+		t.Errorf("vault value = %d items, %d total", vault.ItemValue, vault.Value())
+	}
+	if trove := stash.Tabs[1]; trove.Name != "Guild Treasure Trove" || len(trove.Slots) != 0 || trove.Value() != 0 {
+		t.Errorf("empty tab = %+v", trove)
+	}
+	if stash.Coins != 12345 || stash.Value() != expectedItems+12345 {
+		t.Errorf("stash coins %d, value %d", stash.Coins, stash.Value())
+	}
+}
+
+func TestGetGuildTreasuryDetailed(t *testing.T) {
+	treasury, err := newGuildFixtureClient(t).GetGuildTreasuryDetailed(context.Background(), testGuildID)
+	if err != nil {
+		t.Fatalf("GetGuildTreasuryDetailed: %v", err)
+	}
+	if len(treasury.Entries) != 3 {
+		t.Fatalf("got %d entries, expected 3", len(treasury.Entries))
+	}
+
+	// Least complete first: the unknown item, then ectoplasm, then the finished coins
+	unknown, ecto, coins := treasury.Entries[0], treasury.Entries[1], treasury.Entries[2]
+	if unknown.Item.ID != 99999999 || unknown.Item.Name != "" || unknown.NeededBy[0].Name != "" {
+		t.Errorf("unknown entry = %+v, expected only IDs", unknown)
+	}
+	if ecto.Needed != 150 || math.Abs(ecto.Completion()-100.0/3) > 1e-9 {
+		t.Errorf("ectoplasm needs %d at %.1f%%, expected 150 at 33.3%%", ecto.Needed, ecto.Completion())
+	}
+	if ecto.NeededBy[0].Name != "Guild Vault" || ecto.NeededBy[1].Name != "Guild Treasure Trove" {
+		t.Errorf("ectoplasm needed by %+v", ecto.NeededBy)
+	}
+	if coins.Completion() != 100 {
+		t.Errorf("mystic coin completion = %.1f, expected a surplus to count as 100%%", coins.Completion())
+	}
+
+	// 50 ecto and 200 of the 300 coins count toward the 360 needed
+	if treasury.Count != 250 || treasury.Needed != 360 {
+		t.Errorf("treasury has %d of %d, expected 250 of 360", treasury.Count, treasury.Needed)
+	}
+}
+
+func TestGuildLeaderError(t *testing.T) {
+	tests := []struct {
+		message  string
+		isLeader bool
+	}{
+		{"access restricted to guild leaders", true},
+		{"requires scope guilds", false},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"text":"` + tt.message + `"}`))
+		}))
+		client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+		_, err := client.GetGuildTreasuryDetailed(context.Background(), testGuildID)
+		server.Close()
+
+		var leaderErr *GuildLeaderError
+		if got := errors.As(err, &leaderErr); got != tt.isLeader {
+			t.Errorf("%q: errors.As(GuildLeaderError) = %v, expected %v (%v)", tt.message, got, tt.isLeader, err)
+		}
+		if !errors.Is(err, ErrMissingScope) {
+			t.Errorf("%q: error should still match ErrMissingScope", tt.message)
+		}
+	}
+}
@@ -0,0 +1,437 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// GuildLogKind is the type of a guild log entry
+type GuildLogKind string
+
+// Kinds of guild log entries
+const (
+	GuildLogJoined         GuildLogKind = "joined"
+	GuildLogInvited        GuildLogKind = "invited"
+	GuildLogInviteDeclined GuildLogKind = "invite_declined"
+	GuildLogKick           GuildLogKind = "kick"
+	GuildLogRankChange     GuildLogKind = "rank_change"
+	GuildLogTreasury       GuildLogKind = "treasury"
+	GuildLogStash          GuildLogKind = "stash"
+	GuildLogMotd           GuildLogKind = "motd"
+	GuildLogUpgrade        GuildLogKind = "upgrade"
+	GuildLogInfluence      GuildLogKind = "influence" // No longer logged, but kept by old guilds
+	GuildLogMission        GuildLogKind = "mission"   // No longer logged, but kept by old guilds
+)
+
+// GuildLogKinds lists the kinds of entry the API logs, in the order the wiki
+// documents them
+var GuildLogKinds = []GuildLogKind{
+	GuildLogJoined, GuildLogInvited, GuildLogInviteDeclined, GuildLogKick, GuildLogRankChange,
+	GuildLogTreasury, GuildLogStash, GuildLogMotd, GuildLogUpgrade, GuildLogInfluence, GuildLogMission,
+}
+
+// ParseGuildLogKind parses a guild log entry type such as "stash", ignoring case
+func ParseGuildLogKind(s string) (GuildLogKind, error) {
+	return parseEnum("guild log type", GuildLogKinds, s)
+}
+
+// GuildLog is a guild log entry. User is the account the entry is about,
+// which isn't always the one that acted: an invited entry's User is the
+// account invited. The fields that depend on Type are decoded into Detail.
+type GuildLog struct {
+	ID     int            `json:"id"`
+	Time   time.Time      `json:"time"`
+	User   string         `json:"user,omitempty"`
+	Type   string         `json:"type"`
+	Detail GuildLogDetail `json:"-"`
+}
+
+// GuildLogDetail holds the type-specific fields of a log entry. It is one of
+// the *Log types below, or UnknownLog for types this package does not know.
+type GuildLogDetail interface {
+	// Summary describes the entry as a sentence about user, such as
+	// "Player.1234 deposited 10 Glob of Ectoplasm". Items are named from
+	// itemNames, which may be nil, or else by ID.
+	Summary(user string, itemNames map[int]string) string
+}
+
+// JoinedLog is an account joining the guild
+type JoinedLog struct{}
+
+func (JoinedLog) Summary(user string, itemNames map[int]string) string {
+	return user + " joined the guild"
+}
+
+// InvitedLog is an account being invited to the guild
+type InvitedLog struct {
+	InvitedBy string `json:"invited_by"`
+}
+
+func (l InvitedLog) Summary(user string, itemNames map[int]string) string {
+	return fmt.Sprintf("%s was invited by %s", user, l.InvitedBy)
+}
+
+// InviteDeclinedLog is an invited account declining, or the invite being
+// withdrawn by DeclinedBy
+type InviteDeclinedLog struct {
+	DeclinedBy string `json:"declined_by"`
+}
+
+func (l InviteDeclinedLog) Summary(user string, itemNames map[int]string) string {
+	if l.DeclinedBy == "" || l.DeclinedBy == user {
+		return user + " declined an invite"
+	}
+	return fmt.Sprintf("%s's invite was withdrawn by %s", user, l.DeclinedBy)
+}
+
+// KickLog is an account leaving the guild, kicked by KickedBy. Accounts that
+// leave on their own kick themselves.
+type KickLog struct {
+	KickedBy string `json:"kicked_by"`
+}
+
+func (l KickLog) Summary(user string, itemNames map[int]string) string {
+	if l.KickedBy == "" || l.KickedBy == user {
+		return user + " left the guild"
+	}
+	return fmt.Sprintf("%s was kicked by %s", user, l.KickedBy)
+}
+
+// RankChangeLog is an account's rank changing. ChangedBy is empty for
+// changes the game made, such as when the guild was created.
+type RankChangeLog struct {
+	ChangedBy string `json:"changed_by,omitempty"`
+	OldRank   string `json:"old_rank"`
+	NewRank   string `json:"new_rank"`
+}
+
+func (l RankChangeLog) Summary(user string, itemNames map[int]string) string {
+	summary := fmt.Sprintf("%s was moved from %s to %s", user, l.OldRank, l.NewRank)
+	if l.ChangedBy != "" && l.ChangedBy != user {
+		summary += " by " + l.ChangedBy
+	}
+	return summary
+}
+
+// TreasuryLog is an account depositing items towards guild upgrades
+type TreasuryLog struct {
+	ItemID int `json:"item_id"`
+	Count  int `json:"count"`
+}
+
+func (l TreasuryLog) Summary(user string, itemNames map[int]string) string {
+	return fmt.Sprintf("%s deposited %s in the treasury", user, guildLogItems(l.Count, l.ItemID, itemNames))
+}
+
+// Stash operations
+const (
+	StashDeposit  = "deposit"
+	StashWithdraw = "withdraw"
+	StashMove     = "move"
+)
+
+// StashLog is an account moving coins or items in or out of a stash tab.
+// ItemID is 0 when only coins moved.
+type StashLog struct {
+	Operation string `json:"operation"` // StashDeposit, StashWithdraw or StashMove
+	ItemID    int    `json:"item_id"`
+	Count     int    `json:"count"`
+	Coins     int    `json:"coins"`
+}
+
+func (l StashLog) Summary(user string, itemNames map[int]string) string {
+	var what []string
+	if l.ItemID != 0 {
+		what = append(what, guildLogItems(l.Count, l.ItemID, itemNames))
+	}
+	if l.Coins != 0 {
+		what = append(what, Coins(l.Coins).String())
+	}
+	thing := strings.Join(what, " and ")
+	switch l.Operation {
+	case StashDeposit:
+		return fmt.Sprintf("%s deposited %s", user, thing)
+	case StashWithdraw:
+		return fmt.Sprintf("%s withdrew %s", user, thing)
+	case StashMove:
+		return fmt.Sprintf("%s moved %s", user, thing)
+	}
+	return fmt.Sprintf("%s: %s %s", user, l.Operation, thing)
+}
+
+// MotdLog is an account changing the guild's message of the day
+type MotdLog struct {
+	Motd string `json:"motd"`
+}
+
+func (l MotdLog) Summary(user string, itemNames map[int]string) string {
+	return fmt.Sprintf("%s set the message of the day: %q", user, l.Motd)
+}
+
+// UpgradeLog is a guild upgrade being queued, cancelled, sped up or finished.
+// RecipeID is set for upgrades crafted at a scribing station, and ItemID and
+// Count for items spent to speed one up.
+type UpgradeLog struct {
+	Action    string `json:"action"` // "queued", "cancelled", "completed" or "sped_up"
+	UpgradeID int    `json:"upgrade_id"`
+	RecipeID  int    `json:"recipe_id,omitempty"`
+	ItemID    int    `json:"item_id,omitempty"`
+	Count     int    `json:"count,omitempty"`
+}
+
+func (l UpgradeLog) Summary(user string, itemNames map[int]string) string {
+	action := strings.ReplaceAll(l.Action, "_", " ")
+	summary := fmt.Sprintf("upgrade %d %s", l.UpgradeID, action)
+	if user != "" {
+		summary = fmt.Sprintf("%s %s upgrade %d", user, action, l.UpgradeID)
+	}
+	if l.ItemID != 0 {
+		summary += " with " + guildLogItems(l.Count, l.ItemID, itemNames)
+	}
+	return summary
+}
+
+// InfluenceLog is influence gained from members logging in or gifting it
+type InfluenceLog struct {
+	Activity          string   `json:"activity"` // "daily_login" or "gifted"
+	TotalParticipants int      `json:"total_participants"`
+	Participants      []string `json:"participants"`
+}
+
+func (l InfluenceLog) Summary(user string, itemNames map[int]string) string {
+	return fmt.Sprintf("influence from %s by %d members", strings.ReplaceAll(l.Activity, "_", " "), l.TotalParticipants)
+}
+
+// MissionLog is a guild mission starting, succeeding or failing
+type MissionLog struct {
+	State     string `json:"state"` // "start", "success" or "fail"
+	Influence int    `json:"influence"`
+}
+
+func (l MissionLog) Summary(user string, itemNames map[int]string) string {
+	summary := "guild mission " + l.State
+	if l.Influence > 0 {
+		summary += fmt.Sprintf(", %d influence", l.Influence)
+	}
+	return summary
+}
+
+// UnknownLog keeps the fields of an entry type this package does not know, so
+// it survives being decoded and encoded again
+type UnknownLog struct {
+	Fields map[string]json.RawMessage
+}
+
+func (l UnknownLog) Summary(user string, itemNames map[int]string) string {
+	return user
+}
+
+// guildLogItems formats a stack of items, named from itemNames when it has them
+func guildLogItems(count, itemID int, itemNames map[int]string) string {
+	name := itemNames[itemID]
+	if name == "" {
+		name = fmt.Sprintf("item %d", itemID)
+	}
+	if count == 1 {
+		return name
+	}
+	return fmt.Sprintf("%d %s", count, name)
+}
+
+// newGuildLogDetail returns an empty detail for the entry type, or nil if unknown
+func newGuildLogDetail(kind GuildLogKind) GuildLogDetail {
+	switch kind {
+	case GuildLogJoined:
+		return &JoinedLog{}
+	case GuildLogInvited:
+		return &InvitedLog{}
+	case GuildLogInviteDeclined:
+		return &InviteDeclinedLog{}
+	case GuildLogKick:
+		return &KickLog{}
+	case GuildLogRankChange:
+		return &RankChangeLog{}
+	case GuildLogTreasury:
+		return &TreasuryLog{}
+	case GuildLogStash:
+		return &StashLog{}
+	case GuildLogMotd:
+		return &MotdLog{}
+	case GuildLogUpgrade:
+		return &UpgradeLog{}
+	case GuildLogInfluence:
+		return &InfluenceLog{}
+	case GuildLogMission:
+		return &MissionLog{}
+	}
+	return nil
+}
+
+// Kind returns the entry's type
+func (l GuildLog) Kind() GuildLogKind {
+	return GuildLogKind(l.Type)
+}
+
+// Summary describes the entry, naming items from itemNames, which may be nil
+func (l GuildLog) Summary(itemNames map[int]string) string {
+	if l.Detail == nil {
+		return l.User
+	}
+	return l.Detail.Summary(l.User, itemNames)
+}
+
+// ItemID returns the item a treasury, stash or upgrade entry involves, or 0
+func (l GuildLog) ItemID() int {
+	switch detail := l.Detail.(type) {
+	case TreasuryLog:
+		return detail.ItemID
+	case StashLog:
+		return detail.ItemID
+	case UpgradeLog:
+		return detail.ItemID
+	}
+	return 0
+}
+
+// Accounts returns the accounts the entry names: User, then the account that
+// invited, kicked or changed the rank of User, if it is another one
+func (l GuildLog) Accounts() []string {
+	var accounts []string
+	if l.User != "" {
+		accounts = append(accounts, l.User)
+	}
+	var other string
+	switch detail := l.Detail.(type) {
+	case InvitedLog:
+		other = detail.InvitedBy
+	case InviteDeclinedLog:
+		other = detail.DeclinedBy
+	case KickLog:
+		other = detail.KickedBy
+	case RankChangeLog:
+		other = detail.ChangedBy
+	}
+	if other != "" && other != l.User {
+		accounts = append(accounts, other)
+	}
+	return accounts
+}
+
+// GuildLogItemIDs returns the items the entries involve, sorted and without
+// duplicates, for naming them in summaries
+func GuildLogItemIDs(entries []GuildLog) []int {
+	ids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		ids = append(ids, entry.ItemID())
+	}
+	return uniqueIDs(ids)
+}
+
+// guildLogHeader holds the fields every entry has
+type guildLogHeader struct {
+	ID   int       `json:"id"`
+	Time timestamp `json:"time"`
+	User string    `json:"user,omitempty"`
+	Type string    `json:"type"`
+}
+
+// UnmarshalJSON decodes the common fields, accepting the timestamps
+// ParseTimestamp does, then the rest into the Detail type matching the
+// entry's type
+func (l *GuildLog) UnmarshalJSON(data []byte) error {
+	var header guildLogHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	*l = GuildLog{ID: header.ID, Time: time.Time(header.Time), User: header.User, Type: header.Type}
+	detail := newGuildLogDetail(GuildLogKind(header.Type))
+	if detail == nil {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		for _, name := range []string{"id", "time", "user", "type"} {
+			delete(fields, name)
+		}
+		l.Detail = UnknownLog{Fields: fields}
+		return nil
+	}
+	if err := json.Unmarshal(data, detail); err != nil {
+		return fmt.Errorf("invalid %s log entry %d: %w", header.Type, header.ID, err)
+	}
+	// Details are stored as values, so copies of an entry never share one
+	l.Detail = reflect.ValueOf(detail).Elem().Interface().(GuildLogDetail)
+	return nil
+}
+
+// MarshalJSON writes the entry in the API's flat format
+func (l GuildLog) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	switch detail := l.Detail.(type) {
+	case nil:
+	case UnknownLog:
+		maps.Copy(fields, detail.Fields)
+	default:
+		data, err := json.Marshal(detail)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+	header, err := json.Marshal(struct {
+		ID   int       `json:"id"`
+		Time time.Time `json:"time"`
+		User string    `json:"user,omitempty"`
+		Type string    `json:"type"`
+	}{l.ID, l.Time, l.User, l.Type})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// GuildLogFilter picks guild log entries. Zero fields match every entry.
+type GuildLogFilter struct {
+	Kinds []GuildLogKind
+	User  string    // Account named by the entry, as User or the account that acted on User, ignoring case
+	Since time.Time // Entries at or after
+	Until time.Time // Entries before
+}
+
+// Matches reports whether an entry passes the filter
+func (f GuildLogFilter) Matches(entry GuildLog) bool {
+	if len(f.Kinds) > 0 && !slices.Contains(f.Kinds, entry.Kind()) {
+		return false
+	}
+	if f.User != "" && !slices.ContainsFunc(entry.Accounts(), func(account string) bool { return strings.EqualFold(account, f.User) }) {
+		return false
+	}
+	if !f.Since.IsZero() && entry.Time.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !entry.Time.Before(f.Until) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the entries that pass the filter, in their order
+func (f GuildLogFilter) Apply(entries []GuildLog) []GuildLog {
+	var matched []GuildLog
+	for _, entry := range entries {
+		if f.Matches(entry) {
+			matched = append(matched, entry)
+		}
+	}
+	return matched
+}
@@ -0,0 +1,137 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGuildLogDecode(t *testing.T) {
+	var log []GuildLog
+	decodeFixture(t, "guild/log.json", &log)
+
+	names := map[int]string{19721: "Glob of Ectoplasm", 70701: "Guild Build Boost"}
+	for _, tt := range []struct {
+		id      int
+		kind    GuildLogKind
+		detail  GuildLogDetail
+		summary string
+	}{
+		{1912, GuildLogStash, StashLog{Operation: StashDeposit, ItemID: 19721, Count: 10}, "Banker.4821 deposited 10 Glob of Ectoplasm"},
+		{1911, GuildLogStash, StashLog{Operation: StashWithdraw, Coins: 25000}, "Banker.4821 withdrew 2g 50s"},
+		{1910, GuildLogStash, StashLog{Operation: StashMove, ItemID: 19976, Count: 1}, "Officer.1357 moved item 19976"},
+		{1909, GuildLogTreasury, TreasuryLog{ItemID: 19721, Count: 50}, "Banker.4821 deposited 50 Glob of Ectoplasm in the treasury"},
+		{1908, GuildLogUpgrade, UpgradeLog{Action: "completed", UpgradeID: 38}, "upgrade 38 completed"},
+		{1907, GuildLogUpgrade, UpgradeLog{Action: "sped_up", UpgradeID: 38, ItemID: 70701, Count: 2}, "Officer.1357 sped up upgrade 38 with 2 Guild Build Boost"},
+		{1906, GuildLogUpgrade, UpgradeLog{Action: "queued", UpgradeID: 38, RecipeID: 9437}, "Officer.1357 queued upgrade 38"},
+		{1905, GuildLogMotd, MotdLog{Motd: "Raid night is Thursday"}, `Officer.1357 set the message of the day: "Raid night is Thursday"`},
+		{1904, GuildLogRankChange, RankChangeLog{ChangedBy: "Officer.1357", OldRank: "Recruit", NewRank: "Member"}, "Recruit.2468 was moved from Recruit to Member by Officer.1357"},
+		{1903, GuildLogKick, KickLog{KickedBy: "Quitter.9753"}, "Quitter.9753 left the guild"},
+		{1902, GuildLogKick, KickLog{KickedBy: "Officer.1357"}, "Troll.8642 was kicked by Officer.1357"},
+		{1901, GuildLogJoined, JoinedLog{}, "Recruit.2468 joined the guild"},
+		{1900, GuildLogInvited, InvitedLog{InvitedBy: "Officer.1357"}, "Recruit.2468 was invited by Officer.1357"},
+		{1899, GuildLogInviteDeclined, InviteDeclinedLog{DeclinedBy: "Shy.1122"}, "Shy.1122 declined an invite"},
+		{1898, GuildLogInfluence, InfluenceLog{Activity: "daily_login", TotalParticipants: 2, Participants: []string{"Officer.1357", "Banker.4821"}}, "influence from daily login by 2 members"},
+		{1897, GuildLogMission, MissionLog{State: "success", Influence: 450}, "guild mission success, 450 influence"},
+	} {
+		i := slices.IndexFunc(log, func(entry GuildLog) bool { return entry.ID == tt.id })
+		if i < 0 {
+			t.Errorf("entry %d not decoded", tt.id)
+			continue
+		}
+		entry := log[i]
+		if entry.Kind() != tt.kind || !reflect.DeepEqual(entry.Detail, tt.detail) {
+			t.Errorf("entry %d = %s %#v, expected %s %#v", tt.id, entry.Kind(), entry.Detail, tt.kind, tt.detail)
+		}
+		if summary := entry.Summary(names); summary != tt.summary {
+			t.Errorf("entry %d summary = %q, expected %q", tt.id, summary, tt.summary)
+		}
+	}
+
+	// Types this package doesn't know keep their fields
+	unknown := log[len(log)-1]
+	detail, ok := unknown.Detail.(UnknownLog)
+	if !ok || string(detail.Fields["decoration_id"]) != "12" || unknown.User != "Officer.1357" {
+		t.Errorf("unknown entry = %#v", unknown)
+	}
+
+	if ids := GuildLogItemIDs(log); !reflect.DeepEqual(ids, []int{19721, 19976, 70701}) {
+		t.Errorf("item IDs = %v", ids)
+	}
+	if accounts := log[10].Accounts(); !reflect.DeepEqual(accounts, []string{"Troll.8642", "Officer.1357"}) {
+		t.Errorf("kick accounts = %v", accounts)
+	}
+	if accounts := log[9].Accounts(); !reflect.DeepEqual(accounts, []string{"Quitter.9753"}) {
+		t.Errorf("leave accounts = %v", accounts)
+	}
+}
+
+func TestGuildLogRoundTrip(t *testing.T) {
+	var log []GuildLog
+	decodeFixture(t, "guild/log.json", &log)
+	data, err := json.Marshal(log)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var again []GuildLog
+	if err := json.Unmarshal(data, &again); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(log, again) {
+		t.Errorf("entries changed when encoded and decoded again:\n%+v\n%+v", log, again)
+	}
+}
+
+func TestGuildLogFilter(t *testing.T) {
+	var log []GuildLog
+	decodeFixture(t, "guild/log.json", &log)
+	ids := func(entries []GuildLog) []int {
+		var ids []int
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+		return ids
+	}
+
+	for _, tt := range []struct {
+		name     string
+		filter   GuildLogFilter
+		expected []int
+	}{
+		{"stash", GuildLogFilter{Kinds: []GuildLogKind{GuildLogStash}}, []int{1912, 1911, 1910}},
+		{"stash and treasury by user", GuildLogFilter{Kinds: []GuildLogKind{GuildLogStash, GuildLogTreasury}, User: "banker.4821"}, []int{1912, 1911, 1909}},
+		{"acting user", GuildLogFilter{Kinds: []GuildLogKind{GuildLogKick, GuildLogInvited}, User: "Officer.1357"}, []int{1902, 1900}},
+		{"time range", GuildLogFilter{Since: time.Date(2026, 10, 14, 12, 15, 0, 0, time.UTC), Until: time.Date(2026, 10, 15, 19, 2, 44, 0, time.UTC)}, []int{1909, 1908, 1907}},
+		{"none", GuildLogFilter{User: "Nobody.0000"}, nil},
+	} {
+		if got := ids(tt.filter.Apply(log)); !reflect.DeepEqual(got, tt.expected) {
+			t.Errorf("%s: got %v, expected %v", tt.name, got, tt.expected)
+		}
+	}
+	if got := len(GuildLogFilter{}.Apply(log)); got != len(log) {
+		t.Errorf("empty filter kept %d of %d entries", got, len(log))
+	}
+}
+
+func TestGetGuildLogSince(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.Query().Get("since")
+		w.Write([]byte(`[{"id": 1913, "time": "2026-10-16T08:00:00Z", "user": "Banker.4821", "type": "joined"}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithAPIKey("key"), WithRetries(0), WithRateLimit(1000))
+	log, err := client.GetGuildLogSince(context.Background(), testGuildID, 1912)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if query != "1912" || len(log) != 1 || log[0].Kind() != GuildLogJoined {
+		t.Errorf("since=%s gave %+v", query, log)
+	}
+}
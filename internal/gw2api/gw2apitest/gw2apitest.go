@@ -0,0 +1,160 @@
+// Package gw2apitest serves the sample data of package embeddata as a fake
+// Guild Wars 2 API, so code that calls the API through a gw2api.Client can be
+// tested without the network. Point a client at it with gw2api.WithBaseURL.
+//
+// Only the bulk endpoints of the sample are served: /v2/items, /v2/recipes,
+// /v2/skills, /v2/achievements, /v2/materials and /v2/currencies. They answer
+// like the real ones: without parameters with the list of IDs, to id= with
+// one entry, and to ids= with the entries found in request order, or a 404
+// when none are.
+//
+// The answers are only as faithful as the sample, which for now is a
+// hand-written placeholder rather than a copy of API data; see embeddata.
+package gw2apitest
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+
+	"j5.nz/gw2/internal/gw2api/embeddata"
+)
+
+// endpointFiles maps each endpoint served to its file in the sample
+var endpointFiles = map[string]string{
+	"/v2/items":        "items.json",
+	"/v2/recipes":      "recipes.json",
+	"/v2/skills":       "skills.json",
+	"/v2/achievements": "achievements.json",
+	"/v2/materials":    "materials.json",
+	"/v2/currencies":   "currencies.json",
+}
+
+// endpoint is the entries of one endpoint, as the raw JSON of each by ID
+type endpoint struct {
+	ids     []int
+	entries map[int]json.RawMessage
+}
+
+// Handler answers API requests from the embedded sample data
+type Handler struct {
+	endpoints map[string]*endpoint
+}
+
+// NewHandler loads the embedded sample data into a Handler
+func NewHandler() (*Handler, error) {
+	h := &Handler{endpoints: make(map[string]*endpoint, len(endpointFiles))}
+	for path, name := range endpointFiles {
+		e, err := loadEndpoint(embeddata.FS(), name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load %s: %w", name, err)
+		}
+		h.endpoints[path] = e
+	}
+	return h, nil
+}
+
+// NewServer starts a server answering from the embedded sample data. The
+// caller should Close it when done.
+func NewServer() *httptest.Server {
+	h, err := NewHandler()
+	if err != nil {
+		// The sample is embedded, so this is a broken build rather than a runtime failure
+		panic("gw2apitest: " + err.Error())
+	}
+	return httptest.NewServer(h)
+}
+
+// loadEndpoint reads a JSONL file from the sample, keyed by each entry's ID
+func loadEndpoint(fsys fs.FS, name string) (*endpoint, error) {
+	f, err := fsys.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	e := &endpoint{entries: make(map[int]json.RawMessage)}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		var header struct {
+			ID int `json:"id"`
+		}
+		if err := json.Unmarshal(line, &header); err != nil {
+			return nil, err
+		}
+		e.ids = append(e.ids, header.ID)
+		e.entries[header.ID] = slices.Clone(line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	slices.Sort(e.ids)
+	return e, nil
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	e, found := h.endpoints[r.URL.Path]
+	if !found {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	query := r.URL.Query()
+	switch {
+	case query.Has("id"):
+		id, _ := strconv.Atoi(query.Get("id"))
+		entry, found := e.entries[id]
+		if !found {
+			writeError(w, http.StatusNotFound, "no such id")
+			return
+		}
+		writeJSON(w, entry)
+	case query.Has("ids"):
+		ids := e.ids
+		if query.Get("ids") != "all" {
+			ids = nil
+			for field := range strings.SplitSeq(query.Get("ids"), ",") {
+				id, err := strconv.Atoi(field)
+				if err == nil && !slices.Contains(ids, id) {
+					ids = append(ids, id)
+				}
+			}
+		}
+		var found []json.RawMessage
+		for _, id := range ids {
+			if entry, ok := e.entries[id]; ok {
+				found = append(found, entry)
+			}
+		}
+		if len(found) == 0 {
+			writeError(w, http.StatusNotFound, "all ids provided are invalid")
+			return
+		}
+		writeJSON(w, found)
+	default:
+		writeJSON(w, e.ids)
+	}
+}
+
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(value)
+}
+
+func writeError(w http.ResponseWriter, status int, text string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"text": text})
+}
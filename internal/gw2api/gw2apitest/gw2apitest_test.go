@@ -0,0 +1,66 @@
+package gw2apitest
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func newTestClient(t *testing.T) *gw2api.Client {
+	t.Helper()
+	server := NewServer()
+	t.Cleanup(server.Close)
+	return gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+}
+
+func TestServer(t *testing.T) {
+	client := newTestClient(t)
+	ctx := context.Background()
+
+	// Bulk requests come back in request order, without the unknown IDs
+	const ironOre, ironIngot, ironSwordBlade = 19699, 19683, 13010
+	items, err := client.GetItems(ctx, []int{ironIngot, 999999, ironOre})
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	if len(items) != 2 || items[0].ID != ironIngot || items[1].ID != ironOre || items[1].Name != "Iron Ore" {
+		t.Errorf("GetItems = %+v, expected iron ingots then iron ore", items)
+	}
+
+	ids, err := client.GetItemIDs(ctx)
+	if err != nil || !slices.Contains(ids, ironOre) || !slices.IsSorted(ids) {
+		t.Errorf("GetItemIDs = %v, %v", ids, err)
+	}
+
+	if _, err := client.GetItem(ctx, 999999); !errors.Is(err, gw2api.ErrNotFound) {
+		t.Errorf("GetItem of an unknown ID: err = %v, expected ErrNotFound", err)
+	}
+	if _, err := client.GetItems(ctx, []int{999998, 999999}); !errors.Is(err, gw2api.ErrNotFound) {
+		t.Errorf("GetItems of unknown IDs: err = %v, expected ErrNotFound", err)
+	}
+	if _, err := client.GetOutfits(ctx, []int{1}); err == nil {
+		t.Error("an endpoint outside the sample should fail")
+	}
+
+	// Iron ore makes iron ingots, which make iron sword blades
+	recipeIDs, err := client.GetRecipeIDs(ctx)
+	if err != nil {
+		t.Fatalf("GetRecipeIDs: %v", err)
+	}
+	recipes, err := client.GetRecipes(ctx, recipeIDs)
+	if err != nil {
+		t.Fatalf("GetRecipes: %v", err)
+	}
+	makes := make(map[int][]int) // Output item IDs by ingredient
+	for _, recipe := range recipes {
+		for _, ingredient := range recipe.Ingredients {
+			makes[ingredient.ItemID] = append(makes[ingredient.ItemID], recipe.OutputItemID)
+		}
+	}
+	if !slices.Contains(makes[ironOre], ironIngot) || !slices.Contains(makes[ironIngot], ironSwordBlade) {
+		t.Errorf("no iron ore to ingot to sword blade chain in %v", makes)
+	}
+}
@@ -0,0 +1,29 @@
+package gw2api
+
+// Currency constants live in currencies_gen.go. To refresh them, fetch the
+// currencies with `updatedb -kind currencies` and run go generate.
+//go:generate go run ./gencurrencies -in ../../data/currencies.json -out currencies_gen.go
+
+// Item IDs of commonly used materials
+const (
+	ItemMysticCoin             = 19976
+	ItemGlobOfEctoplasm        = 19721
+	ItemMysticClover           = 19675
+	ItemObsidianShard          = 19925
+	ItemPhilosophersStone      = 20796
+	ItemAmalgamatedGemstone    = 68063
+	ItemGiftOfExploration      = 19677
+	ItemGiftOfBattle           = 19678
+	ItemBloodstoneDust         = 46731
+	ItemDragoniteOre           = 46733
+	ItemEmpyrealFragment       = 46735
+	ItemOrichalcumOre          = 19701
+	ItemAncientWoodLog         = 19725
+	ItemGossamerScrap          = 19745
+	ItemHardenedLeatherSection = 19732
+)
+
+// Achievement category IDs
+const (
+	AchievementCategoryBlackLionCollections = 76
+)
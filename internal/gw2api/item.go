@@ -1,5 +1,7 @@
 package gw2api
 
+import "context"
+
 // Item represents a Guild Wars 2 item
 type Item struct {
 	ID           int           `json:"id"`
@@ -18,6 +20,10 @@ type Item struct {
 	UpgradesInto []ItemUpgrade `json:"upgrades_into,omitempty"`
 	UpgradesFrom []ItemUpgrade `json:"upgrades_from,omitempty"`
 	Details      *ItemDetails  `json:"details,omitempty"`
+
+	// LocalizedName is set on search results when searching in a language other
+	// than the cache's default, and is never returned by the API
+	LocalizedName string `json:"localized_name,omitempty"`
 }
 
 // ItemUpgrade represents upgrade information
@@ -112,7 +118,177 @@ type Buff struct {
 
 // ItemStat represents item stat combinations
 type ItemStat struct {
-	ID         int         `json:"id"`
-	Name       string      `json:"name"`
-	Attributes []Attribute `json:"attributes"`
+	ID         int                 `json:"id"`
+	Name       string              `json:"name"`
+	Attributes []ItemStatAttribute `json:"attributes"`
+}
+
+// ItemStatAttribute is how much of one attribute a stat combination gives,
+// scaled by the item's attribute adjustment; see ResolveAttributes
+type ItemStatAttribute struct {
+	Attribute  string  `json:"attribute"`
+	Multiplier float64 `json:"multiplier"`
+	Value      int     `json:"value"`
+}
+
+// GetItemIDs returns all available item IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/items
+// Scopes: None (public endpoint)
+func (c *Client) GetItemIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/items", options...)
+}
+
+// GetItem returns details for a specific item.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/items
+// Scopes: None (public endpoint)
+func (c *Client) GetItem(ctx context.Context, id int, options ...RequestOption) (*Item, error) {
+	return GetByID[Item](ctx, c, "/v2/items", id, options...)
+}
+
+// GetItems returns multiple items by IDs.
+// Supports WithFields. Cached items are returned as copies, so callers may modify them.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/items
+// Scopes: None (public endpoint)
+func (c *Client) GetItems(ctx context.Context, ids []int, options ...RequestOption) ([]*Item, error) {
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	// Try cache first if available
+	if c.dataCache != nil && c.dataCache.GetItemCache().IsLoaded() {
+		lookup := c.dataCache.GetItemCache().GetByIDs
+		if len(opts.Fields) > 0 {
+			// Projections copy the entries anyway
+			lookup = c.dataCache.GetItemCache().GetByIDsRef
+		}
+		items, err := getByIDsCached(ctx, c, lookup, "/v2/items", ids, options...)
+		if err != nil && len(items) == 0 {
+			return nil, err
+		}
+		return c.shapeItems(items, opts), err
+	}
+
+	// Fallback to API only
+	results, err := GetByIDs[Item](ctx, c, "/v2/items", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Item, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return c.shapeItems(ptrs, opts), err
+}
+
+// GetItemStatIDs returns all item stat IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/itemstats
+// Scopes: None (public endpoint)
+func (c *Client) GetItemStatIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/itemstats", options...)
+}
+
+// GetItemStat returns a specific item stat by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/itemstats
+// Scopes: None (public endpoint)
+func (c *Client) GetItemStat(ctx context.Context, id int, options ...RequestOption) (*ItemStat, error) {
+	return GetByID[ItemStat](ctx, c, "/v2/itemstats", id, options...)
+}
+
+// GetItemStats returns multiple item stats by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/itemstats
+// Scopes: None (public endpoint)
+func (c *Client) GetItemStats(ctx context.Context, ids []int, options ...RequestOption) ([]ItemStat, error) {
+	return GetByIDs[ItemStat](ctx, c, "/v2/itemstats", ids, options...)
+}
+
+// GetMaterialIDs returns all material category IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/materials
+// Scopes: None (public endpoint)
+func (c *Client) GetMaterialIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/materials", options...)
+}
+
+// GetMaterial returns a specific material category by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/materials
+// Scopes: None (public endpoint)
+func (c *Client) GetMaterial(ctx context.Context, id int, options ...RequestOption) (*Material, error) {
+	return GetByID[Material](ctx, c, "/v2/materials", id, options...)
+}
+
+// GetMaterials returns multiple material categories by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/materials
+// Scopes: None (public endpoint)
+func (c *Client) GetMaterials(ctx context.Context, ids []int, options ...RequestOption) ([]*Material, error) {
+	results, err := GetByIDs[Material](ctx, c, "/v2/materials", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Material, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetAllMaterials returns all material storage categories, from the data cache when loaded.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/materials
+// Scopes: None (public endpoint)
+func (c *Client) GetAllMaterials(ctx context.Context, options ...RequestOption) ([]*Material, error) {
+	if c.dataCache != nil && c.dataCache.GetMaterialCache().IsLoaded() {
+		return c.dataCache.GetMaterialCache().GetAll(), nil
+	}
+
+	results, err := GetAll[Material](ctx, c, "/v2/materials", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Material, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetRecipeIDs returns all recipe IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/recipes
+// Scopes: None (public endpoint)
+func (c *Client) GetRecipeIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/recipes", options...)
+}
+
+// GetRecipes returns a specific recipe by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/recipes
+// Scopes: None (public endpoint)
+func (c *Client) GetRecipes(ctx context.Context, ids []int, options ...RequestOption) ([]*RecipeDetail, error) {
+	// Try cache first if available
+	if c.dataCache != nil && c.dataCache.GetRecipeCache().IsLoaded() {
+		recipes, err := getByIDsCached(ctx, c, c.dataCache.GetRecipeCache().GetByIDs, "/v2/recipes", ids, options...)
+		return recipes, err
+	}
+
+	// Fallback to API only
+	results, err := GetByIDs[RecipeDetail](ctx, c, "/v2/recipes", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*RecipeDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetRecipeSearch returns recipe search functionality.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/recipes/search
+// Scopes: None (public endpoint)
+//
+// Deprecated: the search returns recipe IDs, which this can't decode; use
+// SearchRecipesByOutput or SearchRecipesByInput.
+func (c *Client) GetRecipeSearch(ctx context.Context, options ...RequestOption) (*RecipeSearch, error) {
+	return GetSingle[RecipeSearch](ctx, c, "/v2/recipes/search", options...)
 }
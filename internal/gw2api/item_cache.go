@@ -4,18 +4,16 @@ import (
 	"bufio"
 	"encoding/json"
 	"fmt"
-	"os"
-	"sync"
+	"slices"
+	"strings"
 	"time"
 )
 
 // ItemCache provides in-memory caching of items loaded from a local JSON file
 type ItemCache struct {
-	items     map[int]*Item // ID -> Item mapping for fast lookups
-	itemsList []*Item       // All items as slice for iteration
-	loaded    bool
-	mutex     sync.RWMutex
-	stats     ItemCacheStats
+	jsonlCache[Item]
+	names       map[Language]map[int]string // Item names in other languages, guarded by the cache mutex
+	itemsBySkin map[int][]int               // Skin ID -> IDs of items that show or unlock it
 }
 
 // ItemCacheStats tracks cache performance
@@ -29,185 +27,184 @@ type ItemCacheStats struct {
 
 // NewItemCache creates a new item cache
 func NewItemCache() *ItemCache {
-	return &ItemCache{
-		items:     make(map[int]*Item),
-		itemsList: make([]*Item, 0),
-		loaded:    false,
+	ic := &ItemCache{
+		jsonlCache:  newJSONLCache("items", func(item *Item) int { return item.ID }),
+		itemsBySkin: make(map[int][]int),
 	}
-}
-
-// LoadFromFile loads all items from a JSONL file (one JSON object per line)
-func (ic *ItemCache) LoadFromFile(filePath string) error {
-	ic.mutex.Lock()
-	defer ic.mutex.Unlock()
-
-	startTime := time.Now()
-	
-	file, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open items file %s: %w", filePath, err)
-	}
-	defer file.Close()
-
-	// Clear existing data
-	ic.items = make(map[int]*Item)
-	ic.itemsList = make([]*Item, 0)
-
-	scanner := bufio.NewScanner(file)
-	itemCount := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
+	ic.resetIndex = func() { ic.itemsBySkin = make(map[int][]int) }
+	ic.index = func(item *Item) {
+		for _, skinID := range itemSkins(item) {
+			ic.itemsBySkin[skinID] = append(ic.itemsBySkin[skinID], item.ID)
 		}
-
-		var item Item
-		if err := json.Unmarshal([]byte(line), &item); err != nil {
-			// Skip invalid lines but continue processing
-			continue
-		}
-
-		// Store in both map and slice
-		ic.items[item.ID] = &item
-		ic.itemsList = append(ic.itemsList, &item)
-		itemCount++
 	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading items file: %w", err)
+	ic.unindex = func(item *Item) {
+		for _, skinID := range itemSkins(item) {
+			ic.itemsBySkin[skinID] = removeID(ic.itemsBySkin[skinID], item.ID)
+		}
 	}
-
-	ic.loaded = true
-	ic.stats.LoadedItems = itemCount
-	ic.stats.LoadTime = time.Since(startTime)
-	ic.stats.LastLoadTime = time.Now()
-
-	return nil
+	return ic
 }
 
-// GetByID retrieves an item by its ID from the cache
-func (ic *ItemCache) GetByID(id int) (*Item, bool) {
-	ic.mutex.RLock()
-	defer ic.mutex.RUnlock()
-
-	if !ic.loaded {
-		ic.stats.CacheMisses++
-		return nil, false
+// itemSkins returns the skin an item shows followed by the skins it unlocks
+func itemSkins(item *Item) []int {
+	var skins []int
+	if item.DefaultSkin != 0 {
+		skins = append(skins, item.DefaultSkin)
 	}
-
-	item, found := ic.items[id]
-	if found {
-		ic.stats.CacheHits++
-	} else {
-		ic.stats.CacheMisses++
+	if item.Details != nil {
+		for _, skinID := range item.Details.Skins {
+			if skinID != item.DefaultSkin {
+				skins = append(skins, skinID)
+			}
+		}
 	}
-	
-	return item, found
+	return skins
 }
 
-// GetByIDs retrieves multiple items by their IDs
-func (ic *ItemCache) GetByIDs(ids []int) []*Item {
+// ItemsForSkin returns the IDs of items that show a skin by default or unlock it
+func (ic *ItemCache) ItemsForSkin(skinID int) []int {
 	ic.mutex.RLock()
 	defer ic.mutex.RUnlock()
 
-	if !ic.loaded {
-		ic.stats.CacheMisses += int64(len(ids))
+	ids := ic.itemsBySkin[skinID]
+	if len(ids) == 0 {
+		ic.misses.Add(1)
 		return nil
 	}
 
-	results := make([]*Item, 0, len(ids))
-	for _, id := range ids {
-		if item, found := ic.items[id]; found {
-			results = append(results, item)
-			ic.stats.CacheHits++
-		} else {
-			ic.stats.CacheMisses++
-		}
-	}
-
-	return results
+	ic.hits.Add(1)
+	return slices.Clone(ids)
 }
 
-// SearchItems performs in-memory search on cached items
+// SearchItems performs in-memory search on cached items. When options.Language
+// names a language loaded with LoadNamesFromFile, names are matched in that
+// language and each result has LocalizedName set; otherwise the default names
+// are searched. Rarities and types are matched ignoring case.
 func (ic *ItemCache) SearchItems(options ItemSearchOptions) []*Item {
-	ic.mutex.RLock()
-	defer ic.mutex.RUnlock()
-
-	if !ic.loaded {
-		return nil
+	options, err := options.normalized()
+	if err != nil {
+		return nil // An unknown rarity or type matches nothing
 	}
 
-	var results []*Item
-	count := 0
+	ic.mutex.RLock()
+	names := ic.names[options.Language]
+	ic.mutex.RUnlock()
+	if names == nil {
+		return ic.search(options.Limit, func(item *Item) bool {
+			return matchesSearchCriteria(item, options)
+		})
+	}
 
-	// Set default limit if not specified
-	limit := options.Limit
-	if limit == 0 {
-		limit = 50
+	localized := strings.ToLower(options.Name)
+	options.Name = ""
+	results := ic.search(options.Limit, func(item *Item) bool {
+		return strings.Contains(strings.ToLower(names[item.ID]), localized) && matchesSearchCriteria(item, options)
+	})
+	for _, item := range results {
+		item.LocalizedName = names[item.ID]
 	}
+	return results
+}
 
-	for _, item := range ic.itemsList {
-		if matchesSearchCriteria(item, options) {
-			results = append(results, item)
-			count++
+// LoadNamesFromFile indexes the item names in a language from a JSONL items
+// file fetched in that language, such as items.fr.json. Only the names are
+// kept; the items themselves come from the default file.
+func (ic *ItemCache) LoadNamesFromFile(lang Language, filePath string) error {
+	file, err := openDataFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s item names %s: %w", lang, filePath, err)
+	}
+	defer file.Close()
 
-			// Check if we've reached the limit
-			if count >= limit {
-				break
-			}
+	names := make(map[int]string)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil || entry.Name == "" {
+			continue // Skip invalid lines, like LoadFromFile
 		}
+		names[entry.ID] = entry.Name
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("error reading %s item names: %w", lang, err)
 	}
 
-	ic.stats.CacheHits++
-	return results
+	ic.mutex.Lock()
+	defer ic.mutex.Unlock()
+	if ic.names == nil {
+		ic.names = make(map[Language]map[int]string)
+	}
+	ic.names[lang] = names
+	return nil
 }
 
-// GetAll returns all cached items (use with caution for large datasets)
-func (ic *ItemCache) GetAll() []*Item {
+// LocalizedName returns an item's name in a language loaded with LoadNamesFromFile
+func (ic *ItemCache) LocalizedName(id int, lang Language) (string, bool) {
 	ic.mutex.RLock()
 	defer ic.mutex.RUnlock()
-
-	if !ic.loaded {
-		return nil
-	}
-
-	// Return a copy to prevent external modification
-	result := make([]*Item, len(ic.itemsList))
-	copy(result, ic.itemsList)
-	
-	ic.stats.CacheHits++
-	return result
+	name, found := ic.names[lang][id]
+	return name, found
 }
 
-// IsLoaded returns whether the cache has been loaded
-func (ic *ItemCache) IsLoaded() bool {
+// Languages returns the languages with item names loaded, in API order
+func (ic *ItemCache) Languages() []Language {
 	ic.mutex.RLock()
 	defer ic.mutex.RUnlock()
-	return ic.loaded
+	var loaded []Language
+	for _, lang := range Languages {
+		if ic.names[lang] != nil {
+			loaded = append(loaded, lang)
+		}
+	}
+	return loaded
+}
+
+// LocalizedFileName inserts the language before the extension, turning
+// items.json into items.fr.json
+func LocalizedFileName(name string, lang Language) string {
+	base, ext, _ := strings.Cut(name, ".")
+	return base + "." + string(lang) + "." + ext
 }
 
 // Stats returns cache statistics
 func (ic *ItemCache) Stats() ItemCacheStats {
-	ic.mutex.RLock()
-	defer ic.mutex.RUnlock()
-	return ic.stats
+	s := ic.snapshot()
+	return ItemCacheStats{
+		LoadedItems:  s.loaded,
+		LoadTime:     s.loadTime,
+		CacheHits:    s.hits,
+		CacheMisses:  s.misses,
+		LastLoadTime: s.lastLoadTime,
+	}
+}
+
+// heavyItemFields are the JSON fields dropped from items in lean mode
+var heavyItemFields = []string{"description", "chat_link"}
+
+// trimItem drops the heavy text fields from an item in place
+func trimItem(item *Item) {
+	item.Description = ""
+	item.ChatLink = ""
 }
 
-// Clear clears the cache
-func (ic *ItemCache) Clear() {
+// setLean makes the cache drop heavy text fields from every stored item,
+// including the ones already loaded
+func (ic *ItemCache) setLean() {
 	ic.mutex.Lock()
 	defer ic.mutex.Unlock()
 
-	ic.items = make(map[int]*Item)
-	ic.itemsList = make([]*Item, 0)
-	ic.loaded = false
-	ic.stats = ItemCacheStats{}
+	ic.prepare = trimItem
+	for _, item := range ic.list {
+		trimItem(item)
+	}
 }
 
-// Size returns the number of items in the cache
-func (ic *ItemCache) Size() int {
+// isLean reports whether the cache trims heavy fields
+func (ic *ItemCache) isLean() bool {
 	ic.mutex.RLock()
 	defer ic.mutex.RUnlock()
-	return len(ic.itemsList)
-}
\ No newline at end of file
+	return ic.prepare != nil
+}
@@ -0,0 +1,86 @@
+package gw2api
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Rarity is an item rarity, as in Item.Rarity
+type Rarity string
+
+const (
+	RarityJunk       Rarity = "Junk"
+	RarityBasic      Rarity = "Basic"
+	RarityFine       Rarity = "Fine"
+	RarityMasterwork Rarity = "Masterwork"
+	RarityRare       Rarity = "Rare"
+	RarityExotic     Rarity = "Exotic"
+	RarityAscended   Rarity = "Ascended"
+	RarityLegendary  Rarity = "Legendary"
+)
+
+// Rarities lists every rarity from lowest to highest
+var Rarities = []Rarity{
+	RarityJunk, RarityBasic, RarityFine, RarityMasterwork,
+	RarityRare, RarityExotic, RarityAscended, RarityLegendary,
+}
+
+// ItemType is an item type, as in Item.Type
+type ItemType string
+
+const (
+	ItemTypeArmor            ItemType = "Armor"
+	ItemTypeBack             ItemType = "Back"
+	ItemTypeBag              ItemType = "Bag"
+	ItemTypeConsumable       ItemType = "Consumable"
+	ItemTypeContainer        ItemType = "Container"
+	ItemTypeCraftingMaterial ItemType = "CraftingMaterial"
+	ItemTypeGathering        ItemType = "Gathering"
+	ItemTypeGizmo            ItemType = "Gizmo"
+	ItemTypeJadeTechModule   ItemType = "JadeTechModule"
+	ItemTypeKey              ItemType = "Key"
+	ItemTypeMiniPet          ItemType = "MiniPet"
+	ItemTypePowerCore        ItemType = "PowerCore"
+	ItemTypeRelic            ItemType = "Relic"
+	ItemTypeTool             ItemType = "Tool"
+	ItemTypeTrait            ItemType = "Trait"
+	ItemTypeTrinket          ItemType = "Trinket"
+	ItemTypeTrophy           ItemType = "Trophy"
+	ItemTypeUpgradeComponent ItemType = "UpgradeComponent"
+	ItemTypeWeapon           ItemType = "Weapon"
+)
+
+// ItemTypes lists every item type in alphabetical order
+var ItemTypes = []ItemType{
+	ItemTypeArmor, ItemTypeBack, ItemTypeBag, ItemTypeConsumable, ItemTypeContainer,
+	ItemTypeCraftingMaterial, ItemTypeGathering, ItemTypeGizmo, ItemTypeJadeTechModule,
+	ItemTypeKey, ItemTypeMiniPet, ItemTypePowerCore, ItemTypeRelic, ItemTypeTool,
+	ItemTypeTrait, ItemTypeTrinket, ItemTypeTrophy, ItemTypeUpgradeComponent, ItemTypeWeapon,
+}
+
+// ParseRarity returns the rarity named by s, ignoring case, so "exotic"
+// becomes RarityExotic
+func ParseRarity(s string) (Rarity, error) {
+	return parseEnum("rarity", Rarities, s)
+}
+
+// ParseItemType returns the item type named by s, ignoring case, so
+// "craftingmaterial" becomes ItemTypeCraftingMaterial
+func ParseItemType(s string) (ItemType, error) {
+	return parseEnum("item type", ItemTypes, s)
+}
+
+// parseEnum finds s in values ignoring case, or returns an error listing them
+func parseEnum[T ~string](kind string, values []T, s string) (T, error) {
+	s = strings.TrimSpace(s)
+	for _, value := range values {
+		if strings.EqualFold(string(value), s) {
+			return value, nil
+		}
+	}
+	valid := make([]string, len(values))
+	for i, value := range values {
+		valid[i] = string(value)
+	}
+	return "", fmt.Errorf("unknown %s %q (valid: %s)", kind, s, strings.Join(valid, ", "))
+}
@@ -0,0 +1,50 @@
+package gw2api
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestParseRarityAndItemType(t *testing.T) {
+	if rarity, err := ParseRarity(" exotic "); err != nil || rarity != RarityExotic {
+		t.Errorf("ParseRarity = %q, %v, expected Exotic", rarity, err)
+	}
+	if itemType, err := ParseItemType("CRAFTINGMATERIAL"); err != nil || itemType != ItemTypeCraftingMaterial {
+		t.Errorf("ParseItemType = %q, %v, expected CraftingMaterial", itemType, err)
+	}
+
+	_, err := ParseRarity("exotci")
+	if err == nil || !strings.Contains(err.Error(), "Masterwork, Rare, Exotic") {
+		t.Errorf("ParseRarity error = %v, expected the valid rarities", err)
+	}
+}
+
+func TestSearchItemsNormalizesFilters(t *testing.T) {
+	path := writeJSONLFixture(t,
+		`{"id": 1, "name": "Zojja's Breastplate", "type": "Armor", "rarity": "Ascended"}`,
+		`{"id": 2, "name": "Berserker's Armor", "type": "Armor", "rarity": "Exotic"}`,
+	)
+	client := NewClient(WithItemCache(path))
+
+	items, err := client.SearchItems(context.Background(), ItemSearchOptions{Rarities: []string{"ascended"}, Types: []string{"armor"}})
+	if err != nil || len(items) != 1 || items[0].ID != 1 {
+		t.Errorf("SearchItems = %v, %v, expected Zojja's Breastplate", items, err)
+	}
+
+	// The typed filters add to the string ones
+	items, err = client.SearchItems(context.Background(), ItemSearchOptions{Rarities: []string{"Exotic"}, RarityFilter: []Rarity{"ascended"}, TypeFilter: []ItemType{ItemTypeArmor}})
+	if err != nil || len(items) != 2 {
+		t.Errorf("SearchItems with typed filters = %v, %v, expected both items", items, err)
+	}
+
+	if _, err := client.SearchItems(context.Background(), ItemSearchOptions{Types: []string{"Armour"}}); err == nil {
+		t.Error("SearchItems with an unknown type succeeded")
+	}
+	if _, err := client.SearchItems(context.Background(), ItemSearchOptions{TypeFilter: []ItemType{"Armour"}}); err == nil {
+		t.Error("SearchItems with an unknown typed type succeeded")
+	}
+	if items := client.DataCache().GetItemCache().SearchItems(ItemSearchOptions{Rarities: []string{"Shiny"}}); len(items) != 0 {
+		t.Errorf("cache search with an unknown rarity returned %d items", len(items))
+	}
+}
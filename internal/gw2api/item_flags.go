@@ -0,0 +1,42 @@
+package gw2api
+
+import "slices"
+
+// Item flags, as in Item.Flags
+const (
+	ItemFlagAccountBound      = "AccountBound"
+	ItemFlagAccountBindOnUse  = "AccountBindOnUse"
+	ItemFlagSoulbindOnAcquire = "SoulbindOnAcquire"
+	ItemFlagSoulBindOnUse     = "SoulBindOnUse"
+	ItemFlagNoSell            = "NoSell"
+	ItemFlagNoSalvage         = "NoSalvage"
+)
+
+// HasFlag reports whether the item has the given flag
+func (item *Item) HasFlag(flag string) bool {
+	return slices.Contains(item.Flags, flag)
+}
+
+// IsAccountBound reports whether the item is bound to the account as soon as it
+// is acquired. Items that only bind once used are not.
+func (item *Item) IsAccountBound() bool {
+	return item.HasFlag(ItemFlagAccountBound)
+}
+
+// IsSoulbound reports whether the item is bound to a character as soon as it is
+// acquired. Items that only bind once used are not.
+func (item *Item) IsSoulbound() bool {
+	return item.HasFlag(ItemFlagSoulbindOnAcquire)
+}
+
+// IsTradable reports whether the item can be listed on the trading post. The API
+// has no flag for this, so it is inferred from the binding flags: items bound on
+// acquire can't be traded, while items that bind on use can until they are used.
+func (item *Item) IsTradable() bool {
+	return !item.IsAccountBound() && !item.IsSoulbound()
+}
+
+// CanSellToVendor reports whether a merchant buys the item for its vendor value
+func (item *Item) CanSellToVendor() bool {
+	return !item.HasFlag(ItemFlagNoSell) && item.VendorValue > 0
+}
@@ -0,0 +1,71 @@
+package gw2api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestItemFlagHelpers(t *testing.T) {
+	tests := []struct {
+		name         string
+		flags        []string
+		vendorValue  int
+		accountBound bool
+		soulbound    bool
+		tradable     bool
+		vendorSell   bool
+	}{
+		{"no flags", nil, 10, false, false, true, true},
+		{"unrelated flags", []string{"NoSalvage", "NoMysticForge"}, 10, false, false, true, true},
+		{"account bound", []string{"AccountBound"}, 10, true, false, false, true},
+		{"soulbound on acquire", []string{"SoulbindOnAcquire"}, 10, false, true, false, true},
+		{"account bound and soulbound", []string{"AccountBound", "SoulbindOnAcquire"}, 10, true, true, false, true},
+		{"account binds on use", []string{"AccountBindOnUse"}, 10, false, false, true, true},
+		{"soul binds on use", []string{"SoulBindOnUse"}, 10, false, false, true, true},
+		{"no sell", []string{"NoSell"}, 10, false, false, true, false},
+		{"no sell and account bound", []string{"AccountBound", "NoSell"}, 10, true, false, false, false},
+		{"no vendor value", nil, 0, false, false, true, false},
+		{"flags are case sensitive", []string{"accountbound"}, 10, false, false, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{Flags: tt.flags, VendorValue: tt.vendorValue}
+			if got := item.IsAccountBound(); got != tt.accountBound {
+				t.Errorf("IsAccountBound() = %v, expected %v", got, tt.accountBound)
+			}
+			if got := item.IsSoulbound(); got != tt.soulbound {
+				t.Errorf("IsSoulbound() = %v, expected %v", got, tt.soulbound)
+			}
+			if got := item.IsTradable(); got != tt.tradable {
+				t.Errorf("IsTradable() = %v, expected %v", got, tt.tradable)
+			}
+			if got := item.CanSellToVendor(); got != tt.vendorSell {
+				t.Errorf("CanSellToVendor() = %v, expected %v", got, tt.vendorSell)
+			}
+		})
+	}
+}
+
+func TestSearchItemsTradableOnly(t *testing.T) {
+	path := writeJSONLFixture(t,
+		`{"id": 1, "name": "Mystic Coin", "flags": []}`,
+		`{"id": 2, "name": "Mystic Clover", "flags": ["AccountBound", "NoSell"]}`,
+		`{"id": 3, "name": "Mystic Forge Stone", "flags": ["SoulbindOnAcquire"]}`,
+		`{"id": 4, "name": "Mystic Salvage Kit", "flags": ["AccountBindOnUse"]}`,
+	)
+	client := NewClient(WithItemCache(path))
+
+	items, err := client.SearchItems(context.Background(), ItemSearchOptions{Name: "mystic", TradableOnly: true})
+	if err != nil {
+		t.Fatalf("SearchItems: %v", err)
+	}
+	if ids := itemIDsOf(items); len(ids) != 2 || ids[0] != 1 || ids[1] != 4 {
+		t.Errorf("tradable items = %v, expected [1 4]", ids)
+	}
+
+	items, _ = client.SearchItems(context.Background(), ItemSearchOptions{Name: "mystic"})
+	if len(items) != 4 {
+		t.Errorf("search without TradableOnly returned %d items, expected 4", len(items))
+	}
+}
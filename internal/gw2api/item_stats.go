@@ -0,0 +1,35 @@
+package gw2api
+
+import "math"
+
+// ResolveAttributes returns the attributes an item has with the given stats,
+// keyed by attribute name. Each is the stat's value plus its multiplier times
+// the item's attribute adjustment, rounded to the nearest whole number with
+// halves rounded up, which matches the game's tooltips. Items without an
+// attribute adjustment, such as most upgrade components, give nil.
+func ResolveAttributes(item *Item, stat *ItemStat) map[string]int {
+	if item == nil || stat == nil || item.Details == nil || item.Details.AttributeAdjustment == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]int, len(stat.Attributes))
+	for _, attribute := range stat.Attributes {
+		value := float64(attribute.Value) + attribute.Multiplier*item.Details.AttributeAdjustment
+		attributes[attribute.Attribute] += int(math.Round(value))
+	}
+	return attributes
+}
+
+// fixedAttributes returns the attributes of an item's own stats, which the API
+// lists already resolved, or nil for items without them
+func fixedAttributes(item *Item) map[string]int {
+	if item == nil || item.Details == nil || item.Details.InfixUpgrade == nil || len(item.Details.InfixUpgrade.Attributes) == 0 {
+		return nil
+	}
+
+	attributes := make(map[string]int, len(item.Details.InfixUpgrade.Attributes))
+	for _, attribute := range item.Details.InfixUpgrade.Attributes {
+		attributes[attribute.Attribute] += attribute.Modifier
+	}
+	return attributes
+}
@@ -0,0 +1,47 @@
+package gw2api
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestResolveAttributes(t *testing.T) {
+	berserkers := &ItemStat{ID: 161, Name: "Berserker's", Attributes: []ItemStatAttribute{
+		{Attribute: "Power", Multiplier: 0.35},
+		{Attribute: "Precision", Multiplier: 0.25},
+		{Attribute: "CritDamage", Multiplier: 0.25},
+	}}
+
+	tests := []struct {
+		name       string
+		adjustment float64
+		stat       *ItemStat
+		expected   map[string]int
+	}{
+		// In-game tooltips of ascended Berserker's pieces. 0.25 * 179.4 is
+		// 44.85, so these also check the minor attributes round up.
+		{"ascended helm", 179.4, berserkers, map[string]int{"Power": 63, "Precision": 45, "CritDamage": 45}},
+		{"ascended greatsword", 717.6, berserkers, map[string]int{"Power": 251, "Precision": 179, "CritDamage": 179}},
+		{"halves round up", 10, &ItemStat{Attributes: []ItemStatAttribute{{Attribute: "Power", Multiplier: 0.25}}}, map[string]int{"Power": 3}},
+		{"flat values are added", 100, &ItemStat{Attributes: []ItemStatAttribute{{Attribute: "Vitality", Multiplier: 0.1, Value: 5}}}, map[string]int{"Vitality": 15}},
+		{"repeated attributes are summed", 100, &ItemStat{Attributes: []ItemStatAttribute{
+			{Attribute: "Power", Multiplier: 0.1}, {Attribute: "Power", Value: 2},
+		}}, map[string]int{"Power": 12}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := &Item{Details: &ItemDetails{AttributeAdjustment: tt.adjustment}}
+			if got := ResolveAttributes(item, tt.stat); !maps.Equal(got, tt.expected) {
+				t.Errorf("ResolveAttributes = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+
+	if got := ResolveAttributes(&Item{}, berserkers); got != nil {
+		t.Errorf("item without details = %v, expected nil", got)
+	}
+	if got := ResolveAttributes(&Item{Details: &ItemDetails{AttributeAdjustment: 179.4}}, nil); got != nil {
+		t.Errorf("nil stats = %v, expected nil", got)
+	}
+}
@@ -0,0 +1,285 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// ItemSource is a place WatchAccountItems counts items in
+type ItemSource string
+
+const (
+	ItemSourceCharacters ItemSource = "characters" // The bags of every character
+	ItemSourceBank       ItemSource = "bank"
+	ItemSourceMaterials  ItemSource = "materials"
+	ItemSourceShared     ItemSource = "shared" // Shared inventory slots
+)
+
+// ItemSources lists every item source
+var ItemSources = []ItemSource{ItemSourceCharacters, ItemSourceBank, ItemSourceMaterials, ItemSourceShared}
+
+// ParseItemSource returns the item source named by s, ignoring case
+func ParseItemSource(s string) (ItemSource, error) {
+	return parseEnum("item source", ItemSources, s)
+}
+
+// ItemDelta is a change in how many of an item the account has. Items moved
+// between the watched sources aren't changes.
+type ItemDelta struct {
+	ItemID    int        `json:"item_id"`
+	Change    int        `json:"change"`              // Gained, or negative when lost
+	Source    ItemSource `json:"source"`              // Where the count went up, or down for a loss
+	Character string     `json:"character,omitempty"` // Whose bags, when Source is ItemSourceCharacters
+	Time      time.Time  `json:"time"`
+}
+
+// itemLocation is one source, or one character's bags
+type itemLocation struct {
+	source    ItemSource
+	character string
+}
+
+// itemSnapshot counts the items in each location, by item ID
+type itemSnapshot map[itemLocation]map[int]int
+
+// add counts count of the item in location
+func (s itemSnapshot) add(location itemLocation, id, count int) {
+	if id == 0 || count == 0 {
+		return // An empty slot
+	}
+	if s[location] == nil {
+		s[location] = make(map[int]int)
+	}
+	s[location][id] += count
+}
+
+// diffItemSnapshots returns what the account gained and lost from old to
+// current. Changes are netted across locations, so an item moved from the
+// bank to a character isn't a change, and when some of an item was moved
+// and more was looted, only what was looted is a gain. A net gain is placed
+// in the locations whose counts went up the most, and a net loss in those
+// whose counts went down the most.
+func diffItemSnapshots(old, current itemSnapshot, now time.Time) []ItemDelta {
+	changes := make(map[int]map[itemLocation]int)
+	record := func(snapshot itemSnapshot, sign int) {
+		for location, counts := range snapshot {
+			for id, count := range counts {
+				if changes[id] == nil {
+					changes[id] = make(map[itemLocation]int)
+				}
+				changes[id][location] += sign * count
+			}
+		}
+	}
+	record(old, -1)
+	record(current, 1)
+
+	var deltas []ItemDelta
+	for id, byLocation := range changes {
+		net := 0
+		for _, change := range byLocation {
+			net += change
+		}
+		if net == 0 {
+			continue
+		}
+
+		// The locations that moved the same way as the total, most first
+		type locationChange struct {
+			location itemLocation
+			change   int
+		}
+		var candidates []locationChange
+		for location, change := range byLocation {
+			if change*net > 0 {
+				candidates = append(candidates, locationChange{location, change})
+			}
+		}
+		slices.SortFunc(candidates, func(a, b locationChange) int {
+			return cmp.Or(
+				cmp.Compare(abs(b.change), abs(a.change)),
+				cmp.Compare(a.location.source, b.location.source),
+				cmp.Compare(a.location.character, b.location.character),
+			)
+		})
+
+		remaining := abs(net)
+		for _, candidate := range candidates {
+			if remaining == 0 {
+				break
+			}
+			amount := min(remaining, abs(candidate.change))
+			remaining -= amount
+			if net < 0 {
+				amount = -amount
+			}
+			deltas = append(deltas, ItemDelta{
+				ItemID:    id,
+				Change:    amount,
+				Source:    candidate.location.source,
+				Character: candidate.location.character,
+				Time:      now,
+			})
+		}
+	}
+
+	slices.SortFunc(deltas, func(a, b ItemDelta) int {
+		return cmp.Or(
+			cmp.Compare(a.ItemID, b.ItemID),
+			cmp.Compare(a.Source, b.Source),
+			cmp.Compare(a.Character, b.Character),
+		)
+	})
+	return deltas
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+// WatchAccountItems polls the given item sources every interval until ctx is
+// done, sending a delta for each change in the account's items, such as loot.
+// All sources are watched if none are given. The sources are counted once
+// before it returns, so a missing scope or a bad key fails straight away.
+//
+// Each poll makes a request per source, and one per character for their
+// bags, spread over the first half of the interval so the polls don't burst.
+// A poll where any request fails is skipped, rather than reporting what it
+// couldn't see as lost. The channel is closed when ctx is done.
+// Scopes: account, inventories, characters (for ItemSourceCharacters)
+func (c *Client) WatchAccountItems(ctx context.Context, interval time.Duration, sources []ItemSource) (<-chan ItemDelta, error) {
+	if interval <= 0 {
+		return nil, errors.New("watch interval must be positive")
+	}
+	if len(sources) == 0 {
+		sources = ItemSources
+	}
+	for _, source := range sources {
+		if !slices.Contains(ItemSources, source) {
+			return nil, fmt.Errorf("unknown item source %q", source)
+		}
+	}
+
+	previous, err := c.snapshotItems(ctx, sources, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	deltas := make(chan ItemDelta, 16)
+	go func() {
+		defer close(deltas)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jitter(interval)):
+			}
+
+			current, err := c.snapshotItems(ctx, sources, interval/2)
+			if err != nil {
+				continue
+			}
+			changes := diffItemSnapshots(previous, current, time.Now())
+			previous = current
+			for _, delta := range changes {
+				select {
+				case <-ctx.Done():
+					return
+				case deltas <- delta:
+				}
+			}
+		}
+	}()
+	return deltas, nil
+}
+
+// snapshotItems counts the items in sources, spacing the requests evenly over
+// spread
+func (c *Client) snapshotItems(ctx context.Context, sources []ItemSource, spread time.Duration) (itemSnapshot, error) {
+	var characters []string
+	if slices.Contains(sources, ItemSourceCharacters) {
+		names, err := c.GetCharacterNames(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list characters: %w", err)
+		}
+		characters = names
+	}
+
+	snapshot := make(itemSnapshot)
+	var requests []func() error
+	for _, source := range sources {
+		location := itemLocation{source: source}
+		switch source {
+		case ItemSourceCharacters:
+			for _, name := range characters {
+				location := itemLocation{source: source, character: name}
+				requests = append(requests, func() error {
+					inventory, err := c.GetCharacterInventory(ctx, name)
+					if err != nil {
+						return fmt.Errorf("failed to get %s's inventory: %w", name, err)
+					}
+					for _, bag := range inventory.Bags {
+						for _, slot := range bag.Inventory {
+							snapshot.add(location, slot.ID, slot.Count)
+						}
+					}
+					return nil
+				})
+			}
+		case ItemSourceBank:
+			requests = append(requests, func() error {
+				slots, err := c.GetAccountBank(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get bank: %w", err)
+				}
+				for _, slot := range slots {
+					snapshot.add(location, slot.ID, slot.Count)
+				}
+				return nil
+			})
+		case ItemSourceMaterials:
+			requests = append(requests, func() error {
+				slots, err := c.GetAccountMaterials(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get material storage: %w", err)
+				}
+				for _, slot := range slots {
+					snapshot.add(location, slot.ID, slot.Count)
+				}
+				return nil
+			})
+		case ItemSourceShared:
+			requests = append(requests, func() error {
+				slots, err := c.GetAccountInventory(ctx)
+				if err != nil {
+					return fmt.Errorf("failed to get shared inventory: %w", err)
+				}
+				for _, slot := range slots {
+					snapshot.add(location, slot.ID, slot.Count)
+				}
+				return nil
+			})
+		}
+	}
+
+	for i, request := range requests {
+		if i > 0 && spread > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(spread / time.Duration(len(requests))):
+			}
+		}
+		if err := request(); err != nil {
+			return nil, err
+		}
+	}
+	return snapshot, nil
+}
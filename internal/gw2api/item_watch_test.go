@@ -0,0 +1,153 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiffItemSnapshots(t *testing.T) {
+	var (
+		bank      = itemLocation{source: ItemSourceBank}
+		materials = itemLocation{source: ItemSourceMaterials}
+		shared    = itemLocation{source: ItemSourceShared}
+		alice     = itemLocation{source: ItemSourceCharacters, character: "Alice"}
+		bob       = itemLocation{source: ItemSourceCharacters, character: "Bob"}
+	)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	delta := func(id, change int, location itemLocation) ItemDelta {
+		return ItemDelta{ItemID: id, Change: change, Source: location.source, Character: location.character, Time: now}
+	}
+
+	tests := []struct {
+		name     string
+		old, new itemSnapshot
+		expected []ItemDelta
+	}{
+		{
+			name:     "looted",
+			old:      itemSnapshot{alice: {10: 2}},
+			new:      itemSnapshot{alice: {10: 5}, bob: {11: 1}},
+			expected: []ItemDelta{delta(10, 3, alice), delta(11, 1, bob)},
+		},
+		{
+			name: "moved",
+			old:  itemSnapshot{bank: {10: 5}, alice: {12: 1}},
+			new:  itemSnapshot{alice: {10: 5}, shared: {12: 1}},
+		},
+		{
+			name:     "moved and looted",
+			old:      itemSnapshot{bank: {10: 5}, alice: {10: 1}},
+			new:      itemSnapshot{alice: {10: 9}},
+			expected: []ItemDelta{delta(10, 3, alice)},
+		},
+		{
+			name:     "deposited and used",
+			old:      itemSnapshot{alice: {10: 10}, materials: {10: 100}},
+			new:      itemSnapshot{materials: {10: 107}},
+			expected: []ItemDelta{delta(10, -3, alice)},
+		},
+		{
+			name:     "lost in two places",
+			old:      itemSnapshot{alice: {10: 2}, bob: {10: 3}},
+			new:      itemSnapshot{},
+			expected: []ItemDelta{delta(10, -2, alice), delta(10, -3, bob)},
+		},
+		{
+			// The gain goes to the biggest increase first
+			name:     "looted in two places",
+			old:      itemSnapshot{bank: {10: 4}},
+			new:      itemSnapshot{alice: {10: 3}, bob: {10: 5}},
+			expected: []ItemDelta{delta(10, 4, bob)},
+		},
+		{
+			name: "unchanged",
+			old:  itemSnapshot{bank: {10: 4}},
+			new:  itemSnapshot{bank: {10: 4}},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			deltas := diffItemSnapshots(test.old, test.new, now)
+			if !slices.Equal(deltas, test.expected) {
+				t.Errorf("deltas = %+v\nwant %+v", deltas, test.expected)
+			}
+		})
+	}
+}
+
+func TestItemSnapshotAdd(t *testing.T) {
+	snapshot := make(itemSnapshot)
+	bank := itemLocation{source: ItemSourceBank}
+	snapshot.add(bank, 10, 2)
+	snapshot.add(bank, 10, 3)
+	snapshot.add(bank, 0, 0) // An empty slot
+	if len(snapshot[bank]) != 1 || snapshot[bank][10] != 5 {
+		t.Errorf("bank = %v, want 5 of item 10", snapshot[bank])
+	}
+}
+
+func TestWatchAccountItems(t *testing.T) {
+	// The bank gains an item on every poll after the first, while a stack
+	// moves from Alice to the shared slots
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/characters":
+			w.Write([]byte(`["Alice"]`))
+		case "/v2/characters/Alice/inventory":
+			if polls.Load() == 0 {
+				w.Write([]byte(`{"bags": [{"id": 1, "size": 2, "inventory": [{"id": 30, "count": 4}, null]}]}`))
+			} else {
+				w.Write([]byte(`{"bags": [{"id": 1, "size": 2, "inventory": [null, null]}]}`))
+			}
+		case "/v2/account/inventory":
+			if polls.Load() == 0 {
+				w.Write([]byte(`[null]`))
+			} else {
+				w.Write([]byte(`[{"id": 30, "count": 4}]`))
+			}
+		case "/v2/account/bank":
+			// The bank is requested last in each poll
+			fmt.Fprintf(w, `[{"id": 20, "count": %d}, null]`, polls.Add(1))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000), WithAPIKey("key"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	if _, err := client.WatchAccountItems(ctx, 0, nil); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+	if _, err := client.WatchAccountItems(ctx, time.Second, []ItemSource{"wallet"}); err == nil {
+		t.Error("expected an error for an unknown source")
+	}
+
+	deltas, err := client.WatchAccountItems(ctx, 20*time.Millisecond, []ItemSource{ItemSourceCharacters, ItemSourceShared, ItemSourceBank})
+	if err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case delta := <-deltas:
+		if delta.ItemID != 20 || delta.Change != 1 || delta.Source != ItemSourceBank {
+			t.Errorf("first delta = %+v, want 1 of item 20 in the bank", delta)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("no delta")
+	}
+
+	cancel()
+	for delta := range deltas {
+		if delta.ItemID != 20 {
+			t.Errorf("unexpected delta %+v", delta)
+		}
+	}
+}
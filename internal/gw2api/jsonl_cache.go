@@ -0,0 +1,322 @@
+package gw2api
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// jsonlCache is an in-memory cache of API objects loaded from a JSONL file
+// (one JSON object per line). The typed caches embed it and add their own
+// search methods and secondary indexes on top.
+type jsonlCache[T any] struct {
+	kind   string       // Plural name used in error messages, e.g. "items"
+	idOf   func(*T) int // Extracts the API ID from an entry
+	byID   map[int]*T   // ID -> entry mapping for fast lookups
+	list   []*T         // All entries in load order for iteration
+	loaded bool
+	mutex  sync.RWMutex
+
+	// Optional hooks, always called with the write lock held. prepare runs on every
+	// entry before it is stored; the others maintain secondary indexes.
+	prepare    func(*T)
+	resetIndex func()
+	index      func(*T)
+	unindex    func(*T)
+
+	hits         atomic.Int64
+	misses       atomic.Int64
+	corrupt      int // Invalid lines skipped by the last load
+	loadTime     time.Duration
+	lastLoadTime time.Time
+}
+
+// newJSONLCache creates an empty cache for the given kind
+func newJSONLCache[T any](kind string, idOf func(*T) int) jsonlCache[T] {
+	return jsonlCache[T]{
+		kind: kind,
+		idOf: idOf,
+		byID: make(map[int]*T),
+		list: make([]*T, 0),
+	}
+}
+
+// reset empties the cache; callers must hold the write lock
+func (c *jsonlCache[T]) reset() {
+	c.byID = make(map[int]*T)
+	c.list = make([]*T, 0)
+	if c.resetIndex != nil {
+		c.resetIndex()
+	}
+}
+
+// add stores an entry in the map, list and indexes; callers must hold the write lock
+func (c *jsonlCache[T]) add(value *T) {
+	if c.prepare != nil {
+		c.prepare(value)
+	}
+	c.byID[c.idOf(value)] = value
+	c.list = append(c.list, value)
+	if c.index != nil {
+		c.index(value)
+	}
+}
+
+// LoadFromFile loads all entries from a JSONL file, which may be gzipped,
+// replacing any cached data
+func (c *jsonlCache[T]) LoadFromFile(filePath string) error {
+	startTime := time.Now()
+
+	data, err := readDataFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open %s file %s: %w", c.kind, filePath, err)
+	}
+	values, corrupt, err := decodeJSONL[T](data, c.kind, filePath)
+	if err != nil {
+		return err
+	}
+
+	c.store(values, corrupt, startTime)
+	return nil
+}
+
+// store replaces the cached data with freshly loaded entries, read from a file
+// with corrupt invalid lines
+func (c *jsonlCache[T]) store(values []*T, corrupt int, startTime time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	// Clear existing data
+	c.reset()
+	for _, value := range values {
+		c.add(value)
+	}
+
+	c.loaded = true
+	c.corrupt = corrupt
+	c.loadTime = time.Since(startTime)
+	c.lastLoadTime = time.Now()
+}
+
+// GetByID retrieves a deep copy of an entry by its ID from the cache
+func (c *jsonlCache[T]) GetByID(id int) (*T, bool) {
+	value, found := c.GetByIDRef(id)
+	return deepCopy(value), found
+}
+
+// GetByIDRef retrieves an entry by its ID without copying it. The returned
+// pointer is shared with every other caller and must not be modified.
+func (c *jsonlCache[T]) GetByIDRef(id int) (*T, bool) {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		c.misses.Add(1)
+		return nil, false
+	}
+
+	value, found := c.byID[id]
+	if found {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+
+	return value, found
+}
+
+// GetByIDs retrieves deep copies of the cached entries for ids, skipping any that are missing
+func (c *jsonlCache[T]) GetByIDs(ids []int) []*T {
+	return copyAll(c.GetByIDsRef(ids))
+}
+
+// GetByIDsRef retrieves the cached entries for ids without copying them.
+// The returned pointers are shared with every other caller and must not be modified.
+func (c *jsonlCache[T]) GetByIDsRef(ids []int) []*T {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		c.misses.Add(int64(len(ids)))
+		return nil
+	}
+
+	results := make([]*T, 0, len(ids))
+	for _, id := range ids {
+		if value, found := c.byID[id]; found {
+			results = append(results, value)
+			c.hits.Add(1)
+		} else {
+			c.misses.Add(1)
+		}
+	}
+
+	return results
+}
+
+// GetAll returns deep copies of all cached entries (use with caution for large datasets)
+func (c *jsonlCache[T]) GetAll() []*T {
+	return copyAll(c.GetAllRef())
+}
+
+// GetAllRef returns all cached entries without copying them.
+// The returned pointers are shared with every other caller and must not be modified.
+func (c *jsonlCache[T]) GetAllRef() []*T {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		return nil
+	}
+
+	// Copy the slice so later loads and refreshes don't change it underneath the caller
+	result := make([]*T, len(c.list))
+	copy(result, c.list)
+
+	c.hits.Add(1)
+	return result
+}
+
+// copyAll deep copies every entry of values
+func copyAll[T any](values []*T) []*T {
+	if values == nil {
+		return nil
+	}
+	result := make([]*T, len(values))
+	for i, value := range values {
+		result[i] = deepCopy(value)
+	}
+	return result
+}
+
+// search returns deep copies of up to limit entries matching the predicate, in
+// load order. A limit of 0 defaults to 50.
+func (c *jsonlCache[T]) search(limit int, match func(*T) bool) []*T {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+
+	if !c.loaded {
+		return nil
+	}
+
+	if limit == 0 {
+		limit = 50
+	}
+
+	var results []*T
+	for _, value := range c.list {
+		if match(value) {
+			results = append(results, value)
+			if len(results) >= limit {
+				break
+			}
+		}
+	}
+
+	c.hits.Add(1)
+	return copyAll(results)
+}
+
+// IsLoaded returns whether the cache has been loaded
+func (c *jsonlCache[T]) IsLoaded() bool {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.loaded
+}
+
+// Size returns the number of entries in the cache
+func (c *jsonlCache[T]) Size() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return len(c.list)
+}
+
+// CorruptLines returns the number of invalid lines skipped when the cache was
+// loaded, such as a line truncated by an interrupted write
+func (c *jsonlCache[T]) CorruptLines() int {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return c.corrupt
+}
+
+// Clear clears the cache
+func (c *jsonlCache[T]) Clear() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.reset()
+	c.loaded = false
+	c.corrupt = 0
+	c.hits.Store(0)
+	c.misses.Store(0)
+	c.loadTime = 0
+	c.lastLoadTime = time.Time{}
+}
+
+// replace inserts or overwrites the given entries, keeping the original load order
+func (c *jsonlCache[T]) replace(values []*T) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	positions := make(map[int]int, len(c.list))
+	for i, value := range c.list {
+		positions[c.idOf(value)] = i
+	}
+
+	for _, value := range values {
+		if c.prepare != nil {
+			c.prepare(value)
+		}
+		id := c.idOf(value)
+		if old, found := c.byID[id]; found && c.unindex != nil {
+			// Drop the stale index entries before re-indexing
+			c.unindex(old)
+		}
+
+		if pos, found := positions[id]; found {
+			c.list[pos] = value
+		} else {
+			positions[id] = len(c.list)
+			c.list = append(c.list, value)
+		}
+		c.byID[id] = value
+
+		if c.index != nil {
+			c.index(value)
+		}
+	}
+}
+
+// writeToFile atomically rewrites the JSONL file backing the cache
+func (c *jsonlCache[T]) writeToFile(filePath string) error {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return writeJSONLAtomic(filePath, c.list)
+}
+
+// cacheStats is a snapshot of the counters shared by every typed cache
+type cacheStats struct {
+	kind         string
+	loaded       int
+	corrupt      int
+	loadTime     time.Duration
+	hits         int64
+	misses       int64
+	lastLoadTime time.Time
+}
+
+// snapshot returns the current counters
+func (c *jsonlCache[T]) snapshot() cacheStats {
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	return cacheStats{
+		kind:         c.kind,
+		loaded:       len(c.list),
+		corrupt:      c.corrupt,
+		loadTime:     c.loadTime,
+		hits:         c.hits.Load(),
+		misses:       c.misses.Load(),
+		lastLoadTime: c.lastLoadTime,
+	}
+}
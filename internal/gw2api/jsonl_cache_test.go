@@ -0,0 +1,375 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// writeJSONLFixture writes lines to a temp file and returns its path
+func writeJSONLFixture(t *testing.T, lines ...string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fixture.json")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatalf("failed to write fixture: %v", err)
+	}
+	return path
+}
+
+// testJSONLCache runs the shared cache behaviour against one typed cache
+func testJSONLCache[T any](t *testing.T, cache *jsonlCache[T], newValue func(id int) *T) {
+	if cache.IsLoaded() {
+		t.Fatal("new cache reports loaded")
+	}
+	if _, found := cache.GetByID(1); found {
+		t.Error("GetByID found an entry before loading")
+	}
+
+	path := writeJSONLFixture(t,
+		`{"id": 1, "name": "one"}`,
+		``,
+		`not json`,
+		`{"id": 2, "name": "two"}`,
+		`{"id": 3, "name": "three"}`,
+	)
+	if err := cache.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	if !cache.IsLoaded() {
+		t.Error("cache not loaded after LoadFromFile")
+	}
+	if cache.Size() != 3 {
+		t.Errorf("Size() = %d, expected 3 (invalid lines skipped)", cache.Size())
+	}
+
+	if value, found := cache.GetByID(2); !found || cache.idOf(value) != 2 {
+		t.Errorf("GetByID(2) = %v, %v", value, found)
+	}
+	if _, found := cache.GetByID(42); found {
+		t.Error("GetByID(42) found a missing entry")
+	}
+
+	got := cache.GetByIDs([]int{3, 42, 1})
+	if len(got) != 2 || cache.idOf(got[0]) != 3 || cache.idOf(got[1]) != 1 {
+		t.Errorf("GetByIDs returned %d entries in the wrong order", len(got))
+	}
+
+	stats := cache.snapshot()
+	if stats.loaded != 3 || stats.hits != 3 || stats.misses != 3 {
+		t.Errorf("stats = %+v, expected 3 loaded, 3 hits, 3 misses", stats)
+	}
+
+	// Replacing keeps load order and appends new IDs at the end
+	cache.replace([]*T{newValue(4), newValue(2)})
+	var ids []int
+	for _, value := range cache.GetAll() {
+		ids = append(ids, cache.idOf(value))
+	}
+	if !slices.Equal(ids, []int{1, 2, 3, 4}) {
+		t.Errorf("IDs after replace = %v, expected [1 2 3 4]", ids)
+	}
+
+	// Round trip through the atomic writer
+	out := filepath.Join(t.TempDir(), "out.json")
+	if err := cache.writeToFile(out); err != nil {
+		t.Fatalf("writeToFile: %v", err)
+	}
+	if err := cache.LoadFromFile(out); err != nil {
+		t.Fatalf("LoadFromFile after write: %v", err)
+	}
+	if cache.Size() != 4 {
+		t.Errorf("Size() after round trip = %d, expected 4", cache.Size())
+	}
+
+	cache.Clear()
+	if cache.IsLoaded() || cache.Size() != 0 {
+		t.Error("cache not empty after Clear")
+	}
+	if stats := cache.snapshot(); stats.hits != 0 || stats.misses != 0 {
+		t.Errorf("stats not reset after Clear: %+v", stats)
+	}
+
+	if err := cache.LoadFromFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadFromFile on a missing file should fail")
+	}
+}
+
+func TestJSONLCacheKinds(t *testing.T) {
+	t.Run("items", func(t *testing.T) {
+		testJSONLCache(t, &NewItemCache().jsonlCache, func(id int) *Item { return &Item{ID: id} })
+	})
+	t.Run("skills", func(t *testing.T) {
+		testJSONLCache(t, &NewSkillCache().jsonlCache, func(id int) *Skill { return &Skill{ID: id} })
+	})
+	t.Run("achievements", func(t *testing.T) {
+		testJSONLCache(t, &NewAchievementCache().jsonlCache, func(id int) *Achievement { return &Achievement{ID: id} })
+	})
+	t.Run("recipes", func(t *testing.T) {
+		testJSONLCache(t, &NewRecipeCache().jsonlCache, func(id int) *RecipeDetail { return &RecipeDetail{ID: id} })
+	})
+	t.Run("materials", func(t *testing.T) {
+		testJSONLCache(t, &NewMaterialCache().jsonlCache, func(id int) *Material { return &Material{ID: id} })
+	})
+	t.Run("skins", func(t *testing.T) {
+		testJSONLCache(t, &NewSkinCache().jsonlCache, func(id int) *SkinDetail { return &SkinDetail{ID: id} })
+	})
+}
+
+func TestRecipeCacheIndexes(t *testing.T) {
+	rc := NewRecipeCache()
+	path := writeJSONLFixture(t,
+		`{"id": 1, "output_item_id": 100, "ingredients": [{"item_id": 10, "count": 1}, {"item_id": 11, "count": 2}]}`,
+		`{"id": 2, "output_item_id": 100, "ingredients": [{"item_id": 10, "count": 5}]}`,
+	)
+	if err := rc.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	if got := rc.SearchByOutput(100); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("SearchByOutput(100) = %v, expected [1 2]", got)
+	}
+	if got := rc.SearchByInput(10); !slices.Equal(got, []int{1, 2}) {
+		t.Errorf("SearchByInput(10) = %v, expected [1 2]", got)
+	}
+
+	// Replacing a recipe must move its index entries rather than duplicate them
+	rc.replace([]*RecipeDetail{{ID: 1, OutputItemID: 200, Ingredients: []RecipeIngredient{{ItemID: 12, Count: 1}}}})
+
+	if got := rc.SearchByOutput(100); !slices.Equal(got, []int{2}) {
+		t.Errorf("SearchByOutput(100) after replace = %v, expected [2]", got)
+	}
+	if got := rc.SearchByOutput(200); !slices.Equal(got, []int{1}) {
+		t.Errorf("SearchByOutput(200) after replace = %v, expected [1]", got)
+	}
+	if got := rc.SearchByInput(11); len(got) != 0 {
+		t.Errorf("SearchByInput(11) after replace = %v, expected none", got)
+	}
+
+	rc.Clear()
+	if got := rc.SearchByOutput(200); len(got) != 0 {
+		t.Errorf("SearchByOutput after Clear = %v, expected none", got)
+	}
+}
+
+func TestCacheSearchWrappers(t *testing.T) {
+	sc := NewSkillCache()
+	path := writeJSONLFixture(t,
+		`{"id": 1, "name": "Fireball", "type": "Weapon", "professions": ["Elementalist"]}`,
+		`{"id": 2, "name": "Fire Shield", "type": "Utility", "professions": ["Elementalist"]}`,
+		`{"id": 3, "name": "Fire at Will", "type": "Weapon", "professions": ["Ranger"]}`,
+	)
+	if err := sc.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	if got := sc.SearchSkills("fire", "elementalist", "", 0); len(got) != 2 {
+		t.Errorf("SearchSkills by profession returned %d, expected 2", len(got))
+	}
+	if got := sc.SearchSkills("FIRE", "", "weapon", 0); len(got) != 2 {
+		t.Errorf("SearchSkills by type returned %d, expected 2", len(got))
+	}
+	if got := sc.SearchSkills("fire", "", "", 1); len(got) != 1 {
+		t.Errorf("SearchSkills with limit returned %d, expected 1", len(got))
+	}
+}
+
+func TestDataCacheStatsKinds(t *testing.T) {
+	dir := t.TempDir()
+	items := `{"id":1,"name":"One"}` + "\n" + `{"id":2,"name":"Two"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(items), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dc := NewDataCache()
+	if err := dc.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	dc.GetItemCache().GetByID(1)
+	dc.GetItemCache().GetByID(3)
+
+	stats := dc.Stats()
+	if stats.DataDir != dir || len(stats.Kinds) != 8 {
+		t.Fatalf("stats = %+v, expected the directory and eight kinds", stats)
+	}
+	if items := stats.Kinds[0]; items.Kind != "items" || items.Records != 2 || items.Hits != 1 || items.Misses != 1 {
+		t.Errorf("items = %+v, expected 2 records, 1 hit and 1 miss", items)
+	}
+	if stats.TotalCacheHits != 1 || stats.TotalCacheMisses != 1 {
+		t.Errorf("totals = %d hits, %d misses, expected 1 and 1", stats.TotalCacheHits, stats.TotalCacheMisses)
+	}
+}
+
+func TestItemCacheLocalizedSearch(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"items.json":    `{"id":1,"name":"Iron Ore","type":"CraftingMaterial"}` + "\n" + `{"id":2,"name":"Copper Ore","type":"CraftingMaterial"}` + "\n",
+		"items.fr.json": `{"id":1,"name":"Minerai de fer"}` + "\n" + `{"id":2,"name":"Minerai de cuivre"}` + "\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	dc := NewDataCache()
+	if err := dc.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	ic := dc.GetItemCache()
+
+	if got := ic.Languages(); len(got) != 1 || got[0] != LanguageFrench {
+		t.Errorf("Languages = %v, expected [fr]", got)
+	}
+
+	got := ic.SearchItems(ItemSearchOptions{Name: "FER", Language: LanguageFrench})
+	if len(got) != 1 || got[0].ID != 1 || got[0].LocalizedName != "Minerai de fer" || got[0].Name != "Iron Ore" {
+		t.Fatalf("French search = %+v, expected Iron Ore with its French name", got)
+	}
+	if got := ic.SearchItems(ItemSearchOptions{Name: "minerai", Types: []string{"CraftingMaterial"}, Language: LanguageFrench}); len(got) != 2 {
+		t.Errorf("French search with type returned %d, expected 2", len(got))
+	}
+
+	// Default names are still searched without a language, or with one that isn't loaded
+	for _, lang := range []Language{"", LanguageGerman} {
+		got := ic.SearchItems(ItemSearchOptions{Name: "iron", Language: lang})
+		if len(got) != 1 || got[0].LocalizedName != "" {
+			t.Errorf("search with language %q = %+v, expected Iron Ore without a localized name", lang, got)
+		}
+	}
+	if name, _ := ic.LocalizedName(2, LanguageFrench); name != "Minerai de cuivre" {
+		t.Errorf("LocalizedName = %q, expected Minerai de cuivre", name)
+	}
+
+	if name := LocalizedFileName("items.json", LanguageFrench); name != "items.fr.json" {
+		t.Errorf("LocalizedFileName = %q, expected items.fr.json", name)
+	}
+}
+
+func TestNewClientECacheErrors(t *testing.T) {
+	valid := t.TempDir()
+	if err := os.WriteFile(filepath.Join(valid, "items.json"), []byte(`{"id":1,"name":"One"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	corrupt := t.TempDir()
+	if err := os.WriteFile(filepath.Join(corrupt, "items.json"), []byte("<html>Bad Gateway</html>\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := NewClientE(WithDataCache(valid))
+	if err != nil || client.DataCacheError() != nil {
+		t.Fatalf("NewClientE with a valid cache = %v, expected no error", err)
+	}
+	if client.DataCache().GetItemCache().Size() != 1 {
+		t.Error("valid cache was not loaded")
+	}
+
+	for name, dir := range map[string]string{"corrupt": corrupt, "missing": filepath.Join(valid, "missing")} {
+		client, err := NewClientE(WithDataCache(dir))
+		var loadErr *CacheLoadError
+		if !errors.As(err, &loadErr) || loadErr.Path != dir {
+			t.Errorf("%s cache: NewClientE error = %v, expected a CacheLoadError for %s", name, err, dir)
+		}
+		if client == nil || client.DataCacheError() != err {
+			t.Errorf("%s cache: client = %v, expected a client reporting the same error", name, client)
+		}
+	}
+}
+
+func TestLoadFromDirectoryOnce(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{"items.json": `{"id": 1, "name": "One"}` + "\n"})
+
+	dc := NewDataCache()
+	if dc.Loaded() || dc.Loading() {
+		t.Fatal("new cache reports a load")
+	}
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := dc.LoadFromDirectory(dir); err != nil {
+				t.Errorf("LoadFromDirectory: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+	loadedAt := dc.Stats().LastLoadTime
+	if !dc.Loaded() || dc.Loading() || dc.LoadError() != nil {
+		t.Errorf("after loading, Loaded = %v, Loading = %v, LoadError = %v", dc.Loaded(), dc.Loading(), dc.LoadError())
+	}
+
+	// Loading the directory again, even spelled differently, keeps the first load
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(`{"id": 2, "name": "Two"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := dc.LoadFromDirectory(dir + "/"); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	if got := dc.Stats().LastLoadTime; !got.Equal(loadedAt) {
+		t.Errorf("second load reread the directory at %v", got)
+	}
+	if _, found := dc.GetItemCache().GetByID(1); !found {
+		t.Error("second load replaced the cached items")
+	}
+
+	// Clear forgets the load
+	dc.Clear()
+	if dc.Loaded() {
+		t.Error("Loaded after Clear")
+	}
+	if err := dc.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory after Clear: %v", err)
+	}
+	if _, found := dc.GetItemCache().GetByID(2); !found {
+		t.Error("load after Clear did not reread the directory")
+	}
+}
+
+func TestLoadFromDirectoryMissing(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "data")
+
+	dc, err := LoadDataCache(dir)
+	var loadErr *CacheLoadError
+	if !errors.As(err, &loadErr) || loadErr.Path != dir || dc == nil {
+		t.Fatalf("LoadDataCache of a missing directory = %v, %v", dc, err)
+	}
+
+	// The directory can still be loaded once it exists
+	if err := os.Mkdir(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(`{"id": 1, "name": "One"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := dc.LoadFromDirectory(dir); err != nil || dc.GetItemCache().Size() != 1 {
+		t.Errorf("LoadFromDirectory once the directory exists: %v, %d items", err, dc.GetItemCache().Size())
+	}
+}
+
+func TestSharedDataCache(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{"items.json": `{"id": 1, "name": "One"}` + "\n"})
+	dc, err := LoadDataCache(dir)
+	if err != nil {
+		t.Fatalf("LoadDataCache: %v", err)
+	}
+
+	first := NewClient(WithSharedDataCache(dc), WithAPIKey("first"))
+	second := NewClient(WithSharedDataCache(dc), WithAPIKey("second"))
+	if first.DataCache() != dc || second.DataCache() != dc {
+		t.Fatal("clients do not use the shared cache")
+	}
+	if first.DataCacheError() != nil {
+		t.Errorf("DataCacheError = %v, expected nil", first.DataCacheError())
+	}
+
+	items, err := second.GetItems(context.Background(), []int{1})
+	if err != nil || len(items) != 1 || items[0].Name != "One" {
+		t.Errorf("GetItems from the shared cache = %v, %v", items, err)
+	}
+}
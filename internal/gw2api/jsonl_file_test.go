@@ -0,0 +1,192 @@
+package gw2api
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+// gzipFixture returns content gzipped
+func gzipFixture(t testing.TB, content string) string {
+	t.Helper()
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	writer.Write([]byte(content))
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.String()
+}
+
+func TestCreateJSONL(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "items.json")
+	if err := os.WriteFile(plain, []byte(`{"id": 1, "name": "Stale"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// An aborted write leaves the existing file alone
+	writer, err := CreateJSONL(plain + CompressedSuffix)
+	if err != nil {
+		t.Fatalf("CreateJSONL: %v", err)
+	}
+	writer.Encode(Item{ID: 2, Name: "Partial"})
+	writer.Abort()
+	if _, err := os.Stat(plain + CompressedSuffix); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("aborted write created %s", plain+CompressedSuffix)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 1 {
+		t.Errorf("aborted write left %d files behind, expected only items.json", len(entries))
+	}
+
+	writer, err = CreateJSONL(plain + CompressedSuffix)
+	if err != nil {
+		t.Fatalf("CreateJSONL: %v", err)
+	}
+	for _, item := range []Item{{ID: 1, Name: "Sword"}, {ID: 2, Name: "Ingot"}} {
+		if err := writer.Encode(item); err != nil {
+			t.Fatalf("Encode: %v", err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+
+	data, err := os.ReadFile(plain + CompressedSuffix)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.HasPrefix(data, gzipMagic) {
+		t.Error("items.json.gz is not gzipped")
+	}
+	if _, err := os.Stat(plain); !errors.Is(err, os.ErrNotExist) {
+		t.Error("writing items.json.gz did not remove the stale items.json")
+	}
+
+	cache := NewItemCache()
+	if err := cache.LoadFromFile(plain + CompressedSuffix); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	if item, found := cache.GetByID(2); cache.Size() != 2 || !found || item.Name != "Ingot" {
+		t.Errorf("loaded %d items, item 2 = %+v, expected Sword and Ingot", cache.Size(), item)
+	}
+}
+
+func TestCreateJSONLKeepsMode(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Windows has no Unix permissions")
+	}
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "items.json")
+	write := func(path string) os.FileMode {
+		t.Helper()
+		writer, err := CreateJSONL(path)
+		if err != nil {
+			t.Fatalf("CreateJSONL: %v", err)
+		}
+		writer.Encode(Item{ID: 1, Name: "Sword"})
+		if err := writer.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return info.Mode().Perm()
+	}
+
+	// A new file is readable by everyone, as os.WriteFile would make it
+	if mode := write(plain); mode != 0o644 {
+		t.Errorf("new file mode = %v, expected 0644", mode)
+	}
+
+	// Rewrites keep the mode of the file they replace, even when compressing it
+	if err := os.Chmod(plain, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if mode := write(plain); mode != 0o640 {
+		t.Errorf("rewritten file mode = %v, expected 0640", mode)
+	}
+	if mode := write(plain + CompressedSuffix); mode != 0o640 {
+		t.Errorf("compressed file mode = %v, expected 0640 from items.json", mode)
+	}
+
+	// So does the manifest
+	if err := RecordManifestCount(dir, "items.json", 1); err != nil {
+		t.Fatal(err)
+	}
+	manifestPath := filepath.Join(dir, ManifestFileName)
+	if err := os.Chmod(manifestPath, 0o664); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordManifestCount(dir, "skills.json", 1); err != nil {
+		t.Fatal(err)
+	}
+	info, err := os.Stat(manifestPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mode := info.Mode().Perm(); mode != 0o664 {
+		t.Errorf("manifest mode = %v, expected 0664", mode)
+	}
+}
+
+func TestLoadFromDirectoryGzip(t *testing.T) {
+	dir := writeDataDir(t, map[string]string{
+		// The compressed file is preferred when both exist
+		"items.json":    `{"id": 1, "name": "Uncompressed"}` + "\n",
+		"items.json.gz": gzipFixture(t, `{"id": 1, "name": "Compressed"}`+"\n"),
+		// Gzip is detected by its magic bytes whatever the file is called
+		"skills.json":      gzipFixture(t, `{"id": 5, "name": "Fireball"}`+"\n"),
+		"items.fr.json.gz": gzipFixture(t, `{"id": 1, "name": "Compressé"}`+"\n"),
+	})
+
+	dc := NewDataCache()
+	if err := dc.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	if item, _ := dc.GetItemCache().GetByID(1); item == nil || item.Name != "Compressed" {
+		t.Errorf("item 1 = %+v, expected the compressed file's", item)
+	}
+	if skill, found := dc.GetSkillCache().GetByID(5); !found || skill.Name != "Fireball" {
+		t.Errorf("skill 5 = %+v, expected Fireball", skill)
+	}
+	if name, _ := dc.GetItemCache().LocalizedName(1, LanguageFrench); name != "Compressé" {
+		t.Errorf("French name of item 1 = %q, expected Compressé", name)
+	}
+
+	// The sidecar built from the compressed file loads the same data
+	if _, err := os.Stat(filepath.Join(dir, "items.gob")); err != nil {
+		t.Fatalf("no sidecar for items.json.gz: %v", err)
+	}
+	reloaded := NewDataCache()
+	if err := reloaded.LoadFromDirectory(dir); err != nil {
+		t.Fatalf("LoadFromDirectory: %v", err)
+	}
+	if item, _ := reloaded.GetItemCache().GetByID(1); item == nil || item.Name != "Compressed" {
+		t.Errorf("item 1 from the sidecar = %+v, expected the compressed file's", item)
+	}
+}
+
+func BenchmarkLoadItemsGzip(b *testing.B) {
+	path := benchmarkItemsFile(b, 10000)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		b.Fatal(err)
+	}
+	compressed := path + CompressedSuffix
+	if err := os.WriteFile(compressed, []byte(gzipFixture(b, string(data))), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	cache := NewItemCache()
+	b.ResetTimer()
+	for range b.N {
+		if err := cache.LoadFromFile(compressed); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// KeyRing holds named API keys, such as one per account, so a single client
+// can switch between them with WithKey. Token info is cached per key.
+type KeyRing struct {
+	mutex  sync.RWMutex
+	keys   map[string]string
+	tokens map[string]*TokenInfo // Keyed by API key
+}
+
+// NewKeyRing returns a key ring holding the given name to key mapping
+func NewKeyRing(keys map[string]string) *KeyRing {
+	r := &KeyRing{keys: make(map[string]string, len(keys)), tokens: make(map[string]*TokenInfo)}
+	for name, key := range keys {
+		r.keys[name] = key
+	}
+	return r
+}
+
+// Add stores a key under a name, replacing any key already using it
+func (r *KeyRing) Add(name, key string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.keys[name] = key
+}
+
+// Key returns the key stored under a name
+func (r *KeyRing) Key(name string) (string, bool) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	key, found := r.keys[name]
+	return key, found
+}
+
+// Names returns the key names in alphabetical order
+func (r *KeyRing) Names() []string {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+	names := make([]string, 0, len(r.keys))
+	for name := range r.keys {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// WithKeyRing gives the client named keys to switch between with WithKey
+func WithKeyRing(ring *KeyRing) ClientOption {
+	return func(c *Client) {
+		c.keyRing = ring
+	}
+}
+
+// KeyRing returns the client's key ring (if available)
+func (c *Client) KeyRing() *KeyRing {
+	return c.keyRing
+}
+
+// WithKey returns a copy of the client that authenticates with the named key
+// from its key ring. The copy shares the rate limiter, data cache and HTTP
+// client with the original.
+func (c *Client) WithKey(name string) (*Client, error) {
+	if c.keyRing == nil {
+		return nil, fmt.Errorf("no API key named %q: client has no key ring", name)
+	}
+	key, found := c.keyRing.Key(name)
+	if !found {
+		return nil, fmt.Errorf("no API key named %q (have %s)", name, strings.Join(c.keyRing.Names(), ", "))
+	}
+	return c.withAPIKey(key), nil
+}
+
+// withAPIKey returns a shallow copy of the client using a different API key
+func (c *Client) withAPIKey(key string) *Client {
+	return c.With(WithAPIKey(key))
+}
+
+// CachedTokenInfo returns the token info for the client's API key, fetching it
+// only once per key when the client has a key ring.
+// Scopes: account
+func (c *Client) CachedTokenInfo(ctx context.Context) (*TokenInfo, error) {
+	if c.keyRing == nil {
+		return c.GetTokenInfo(ctx)
+	}
+
+	c.keyRing.mutex.RLock()
+	info := c.keyRing.tokens[c.apiKey]
+	c.keyRing.mutex.RUnlock()
+	if info != nil {
+		return info, nil
+	}
+
+	info, err := c.GetTokenInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.keyRing.mutex.Lock()
+	c.keyRing.tokens[c.apiKey] = info
+	c.keyRing.mutex.Unlock()
+	return info, nil
+}
@@ -0,0 +1,63 @@
+package gw2api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+)
+
+func TestClientWithKey(t *testing.T) {
+	var tokenInfoCalls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Header.Get("Authorization") + " " + r.URL.Path {
+		case "Bearer main-key /v2/tokeninfo":
+			tokenInfoCalls.Add(1)
+			w.Write([]byte(`{"id":"main-key","name":"Main","permissions":["account"]}`))
+		case "Bearer guild-key /v2/tokeninfo":
+			tokenInfoCalls.Add(1)
+			w.Write([]byte(`{"id":"guild-key","name":"Guild","permissions":["account","guilds"]}`))
+		default:
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"text":"invalid key"}`))
+		}
+	}))
+	defer server.Close()
+
+	ring := NewKeyRing(map[string]string{"main": "main-key", "guild": "guild-key"})
+	client := NewClient(WithBaseURL(server.URL), WithKeyRing(ring), WithRetries(0), WithRateLimit(1000))
+	if names := ring.Names(); !slices.Equal(names, []string{"guild", "main"}) {
+		t.Errorf("Names = %v, expected guild and main", names)
+	}
+
+	guild, err := client.WithKey("guild")
+	if err != nil {
+		t.Fatalf("WithKey: %v", err)
+	}
+	if guild.DataCache() != client.DataCache() || guild.rateLimiter != client.rateLimiter || guild.KeyRing() != ring {
+		t.Error("derived client doesn't share the original's cache, rate limiter and key ring")
+	}
+	for range 2 {
+		info, err := guild.CachedTokenInfo(context.Background())
+		if err != nil || info.Name != "Guild" {
+			t.Fatalf("CachedTokenInfo = %+v, %v", info, err)
+		}
+	}
+
+	main, _ := client.WithKey("main")
+	if info, err := main.CachedTokenInfo(context.Background()); err != nil || info.Name != "Main" {
+		t.Errorf("CachedTokenInfo for main = %+v, %v", info, err)
+	}
+	if calls := tokenInfoCalls.Load(); calls != 2 {
+		t.Errorf("tokeninfo requested %d times, expected once per key", calls)
+	}
+
+	if _, err := client.WithKey("alt"); err == nil {
+		t.Error("WithKey with an unknown name succeeded")
+	}
+	if client.apiKey != "" {
+		t.Errorf("original client key changed to %q", client.apiKey)
+	}
+}
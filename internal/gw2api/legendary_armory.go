@@ -0,0 +1,124 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Legendary armory slot groups, in display order
+const (
+	LegendarySlotWeapon  = "Weapon"
+	LegendarySlotArmor   = "Armor"
+	LegendarySlotTrinket = "Trinket"
+	LegendarySlotUpgrade = "Upgrade"
+	LegendarySlotOther   = "Other"
+)
+
+var legendarySlotOrder = []string{LegendarySlotWeapon, LegendarySlotArmor, LegendarySlotTrinket, LegendarySlotUpgrade, LegendarySlotOther}
+
+// LegendaryArmoryStatus is the account's legendary armory progress
+type LegendaryArmoryStatus struct {
+	Items []LegendaryArmoryItemStatus `json:"items"` // Grouped by slot, then sorted by name
+	Owned int                         `json:"owned"` // Copies stored, capped at each item's max
+	Max   int                         `json:"max"`   // Copies needed to fill the armory
+}
+
+// LegendaryArmoryItemStatus is how many copies of one legendary the account has stored
+type LegendaryArmoryItemStatus struct {
+	Item  *Item  `json:"item"`
+	Slot  string `json:"slot"`
+	Owned int    `json:"owned"`
+	Max   int    `json:"max"`
+}
+
+// Completion returns the percentage of armory slots filled
+func (s *LegendaryArmoryStatus) Completion() float64 {
+	if s.Max == 0 {
+		return 0
+	}
+	return float64(s.Owned) / float64(s.Max) * 100
+}
+
+// legendarySlot groups an item by where it is equipped
+func legendarySlot(item *Item) string {
+	switch item.Type {
+	case "Weapon":
+		return LegendarySlotWeapon
+	case "Armor":
+		return LegendarySlotArmor
+	case "Trinket", "Back":
+		return LegendarySlotTrinket
+	case "UpgradeComponent":
+		return LegendarySlotUpgrade
+	default:
+		return LegendarySlotOther
+	}
+}
+
+// summarizeLegendaryArmory joins armory definitions with account counts and item details.
+// Items missing from the item lookup are kept with only their ID filled in.
+func summarizeLegendaryArmory(armory []*LegendaryArmoryDetail, owned []LegendaryArmory, items map[int]*Item) *LegendaryArmoryStatus {
+	counts := make(map[int]int, len(owned))
+	for _, entry := range owned {
+		counts[entry.ID] = entry.Count
+	}
+
+	status := &LegendaryArmoryStatus{}
+	for _, entry := range armory {
+		item, found := items[entry.ID]
+		if !found {
+			item = &Item{ID: entry.ID}
+		}
+
+		count := min(counts[entry.ID], entry.MaxCount)
+		status.Items = append(status.Items, LegendaryArmoryItemStatus{
+			Item:  item,
+			Slot:  legendarySlot(item),
+			Owned: count,
+			Max:   entry.MaxCount,
+		})
+		status.Owned += count
+		status.Max += entry.MaxCount
+	}
+
+	slices.SortFunc(status.Items, func(a, b LegendaryArmoryItemStatus) int {
+		return cmp.Or(
+			cmp.Compare(slices.Index(legendarySlotOrder, a.Slot), slices.Index(legendarySlotOrder, b.Slot)),
+			cmp.Compare(a.Item.Name, b.Item.Name),
+			cmp.Compare(a.Item.ID, b.Item.ID),
+		)
+	})
+	return status
+}
+
+// GetLegendaryArmoryStatus returns how many copies of every legendary the account
+// has stored in the armory, against the most it can hold.
+// Scopes: account, unlocks, inventories
+func (c *Client) GetLegendaryArmoryStatus(ctx context.Context) (*LegendaryArmoryStatus, error) {
+	owned, err := c.GetAccountLegendaryArmory(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account legendary armory: %w", err)
+	}
+
+	ids, err := c.GetLegendaryArmoryIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legendary armory IDs: %w", err)
+	}
+
+	armory, err := c.GetLegendaryArmoryItems(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legendary armory details: %w", err)
+	}
+	results, err := c.GetItems(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get legendary armory details: %w", err)
+	}
+	items := make(map[int]*Item, len(results))
+	for _, item := range results {
+		items[item.ID] = item
+	}
+
+	return summarizeLegendaryArmory(armory, owned, items), nil
+}
@@ -0,0 +1,62 @@
+package gw2api
+
+import "testing"
+
+func TestSummarizeLegendaryArmory(t *testing.T) {
+	armory := []*LegendaryArmoryDetail{
+		{ID: 30684, MaxCount: 2}, // Frostfang
+		{ID: 30704, MaxCount: 2}, // Twilight
+		{ID: 80248, MaxCount: 1}, // Perfected Envoy Helmet
+		{ID: 81908, MaxCount: 2}, // Aurora
+		{ID: 91234, MaxCount: 1}, // Not in the item lookup
+	}
+	owned := []LegendaryArmory{
+		{ID: 30704, Count: 2},
+		{ID: 30684, Count: 1},
+		{ID: 81908, Count: 5}, // More than the armory holds
+	}
+	items := map[int]*Item{
+		30684: {ID: 30684, Name: "Frostfang", Type: "Weapon"},
+		30704: {ID: 30704, Name: "Twilight", Type: "Weapon"},
+		80248: {ID: 80248, Name: "Perfected Envoy Helmet", Type: "Armor"},
+		81908: {ID: 81908, Name: "Aurora", Type: "Trinket"},
+	}
+
+	status := summarizeLegendaryArmory(armory, owned, items)
+
+	expected := []struct {
+		id    int
+		slot  string
+		owned int
+		max   int
+	}{
+		{30684, LegendarySlotWeapon, 1, 2},
+		{30704, LegendarySlotWeapon, 2, 2},
+		{80248, LegendarySlotArmor, 0, 1},
+		{81908, LegendarySlotTrinket, 2, 2},
+		{91234, LegendarySlotOther, 0, 1},
+	}
+	if len(status.Items) != len(expected) {
+		t.Fatalf("got %d items, expected %d", len(status.Items), len(expected))
+	}
+	for i, want := range expected {
+		got := status.Items[i]
+		if got.Item.ID != want.id || got.Slot != want.slot || got.Owned != want.owned || got.Max != want.max {
+			t.Errorf("items[%d] = {%d %s %d/%d}, expected {%d %s %d/%d}",
+				i, got.Item.ID, got.Slot, got.Owned, got.Max, want.id, want.slot, want.owned, want.max)
+		}
+	}
+
+	if status.Owned != 5 || status.Max != 8 {
+		t.Errorf("totals = %d/%d, expected 5/8", status.Owned, status.Max)
+	}
+	if completion := status.Completion(); completion != 62.5 {
+		t.Errorf("Completion() = %v, expected 62.5", completion)
+	}
+}
+
+func TestLegendaryArmoryCompletionEmpty(t *testing.T) {
+	if completion := (&LegendaryArmoryStatus{}).Completion(); completion != 0 {
+		t.Errorf("Completion() of an empty armory = %v, expected 0", completion)
+	}
+}
@@ -0,0 +1,83 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+)
+
+// lookupItems fetches the items with the given IDs, keyed by ID. Duplicate
+// IDs are fetched once and unknown IDs are left out of the map.
+func (c *Client) lookupItems(ctx context.Context, ids []int) (map[int]*Item, error) {
+	results, err := c.GetItems(ctx, uniqueIDs(ids))
+	items := make(map[int]*Item, len(results))
+	for _, item := range results {
+		if item != nil {
+			items[item.ID] = item
+		}
+	}
+	return items, err
+}
+
+// lookupPrices fetches trading post prices, keyed by item ID. Items that are
+// not listed are left out of the map.
+func (c *Client) lookupPrices(ctx context.Context, ids []int) (map[int]*Price, error) {
+	results, err := c.GetCommercePrices(ctx, uniqueIDs(ids))
+	var httpErr HTTPError
+	if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+		// None of the items are listed on the trading post
+		err = nil
+	}
+	prices := make(map[int]*Price, len(results))
+	for _, price := range results {
+		prices[price.ID] = price
+	}
+	return prices, err
+}
+
+// lookupItemStats fetches stat combinations, keyed by ID. Unknown IDs are
+// left out of the map.
+func (c *Client) lookupItemStats(ctx context.Context, ids []int) (map[int]*ItemStat, error) {
+	results, err := c.GetItemStats(ctx, uniqueIDs(ids))
+	if errors.Is(err, ErrNotFound) {
+		err = nil
+	}
+	stats := make(map[int]*ItemStat, len(results))
+	for i := range results {
+		stats[results[i].ID] = &results[i]
+	}
+	return stats, err
+}
+
+// uniqueIDs returns the non-zero IDs sorted and without duplicates
+func uniqueIDs(ids []int) []int {
+	unique := slices.DeleteFunc(slices.Clone(ids), func(id int) bool { return id == 0 })
+	slices.Sort(unique)
+	return slices.Compact(unique)
+}
+
+// unitPrice is what buying one of the item costs now: the lowest sell listing,
+// or the highest buy order when nobody is selling
+func unitPrice(price *Price) int {
+	if price.Sells.Quantity == 0 {
+		return price.Buys.UnitPrice
+	}
+	return price.Sells.UnitPrice
+}
+
+// lookupAchievements fetches achievements, keyed by ID. Unknown IDs are left
+// out of the map.
+func (c *Client) lookupAchievements(ctx context.Context, ids []int) (map[int]*Achievement, error) {
+	results, err := c.GetAchievements(ctx, uniqueIDs(ids))
+	if errors.Is(err, ErrNotFound) {
+		err = nil
+	}
+	achievements := make(map[int]*Achievement, len(results))
+	for _, achievement := range results {
+		if achievement != nil {
+			achievements[achievement.ID] = achievement
+		}
+	}
+	return achievements, err
+}
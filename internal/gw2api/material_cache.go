@@ -0,0 +1,193 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// MaterialCache provides in-memory caching of material storage categories with a
+// reverse index from item ID to the category that stores it
+type MaterialCache struct {
+	jsonlCache[Material]
+	categoryByItem map[int]int // ItemID -> material category ID
+}
+
+// MaterialCacheStats tracks material cache performance
+type MaterialCacheStats struct {
+	LoadedMaterials int
+	LoadTime        time.Duration
+	CacheHits       int64
+	CacheMisses     int64
+	LastLoadTime    time.Time
+}
+
+// NewMaterialCache creates a new material category cache
+func NewMaterialCache() *MaterialCache {
+	mc := &MaterialCache{
+		jsonlCache:     newJSONLCache("materials", func(material *Material) int { return material.ID }),
+		categoryByItem: make(map[int]int),
+	}
+	mc.resetIndex = func() { mc.categoryByItem = make(map[int]int) }
+	mc.index = func(material *Material) {
+		for _, itemID := range material.Items {
+			mc.categoryByItem[itemID] = material.ID
+		}
+	}
+	mc.unindex = func(material *Material) {
+		for _, itemID := range material.Items {
+			delete(mc.categoryByItem, itemID)
+		}
+	}
+	return mc
+}
+
+// CategoryForItem returns the material storage category an item is deposited in.
+// Most items are not materials, in which case found is false.
+func (mc *MaterialCache) CategoryForItem(itemID int) (*Material, bool) {
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+
+	categoryID, found := mc.categoryByItem[itemID]
+	if !found {
+		mc.misses.Add(1)
+		return nil, false
+	}
+
+	mc.hits.Add(1)
+	return deepCopy(mc.byID[categoryID]), true
+}
+
+// Stats returns cache statistics
+func (mc *MaterialCache) Stats() MaterialCacheStats {
+	s := mc.snapshot()
+	return MaterialCacheStats{
+		LoadedMaterials: s.loaded,
+		LoadTime:        s.loadTime,
+		CacheHits:       s.hits,
+		CacheMisses:     s.misses,
+		LastLoadTime:    s.lastLoadTime,
+	}
+}
+
+// ItemCount is a quantity of a single item
+type ItemCount struct {
+	ItemID int `json:"item_id"`
+	Count  int `json:"count"`
+}
+
+// MaterialGroup is a set of items that belong to one material storage category
+type MaterialGroup struct {
+	CategoryID int         `json:"category_id"`
+	Name       string      `json:"name"`
+	Order      int         `json:"order"`
+	Items      []ItemCount `json:"items"`
+}
+
+// GroupByCategory groups item counts by material storage category, ordered the
+// way the game shows them: categories by their order, items by their position
+// within the category. Items that aren't materials are returned separately,
+// sorted by item ID.
+func (mc *MaterialCache) GroupByCategory(counts map[int]int) (groups []MaterialGroup, uncategorized []ItemCount) {
+	mc.mutex.RLock()
+	defer mc.mutex.RUnlock()
+
+	byCategory := make(map[int]*MaterialGroup)
+	for itemID, count := range counts {
+		if count <= 0 {
+			continue
+		}
+
+		categoryID, found := mc.categoryByItem[itemID]
+		if !found {
+			uncategorized = append(uncategorized, ItemCount{ItemID: itemID, Count: count})
+			continue
+		}
+
+		group, ok := byCategory[categoryID]
+		if !ok {
+			material := mc.byID[categoryID]
+			group = &MaterialGroup{CategoryID: material.ID, Name: material.Name, Order: material.Order}
+			byCategory[categoryID] = group
+		}
+		group.Items = append(group.Items, ItemCount{ItemID: itemID, Count: count})
+	}
+
+	for _, group := range byCategory {
+		positions := mc.byID[group.CategoryID].Items
+		slices.SortFunc(group.Items, func(a, b ItemCount) int {
+			return slices.Index(positions, a.ItemID) - slices.Index(positions, b.ItemID)
+		})
+		groups = append(groups, *group)
+	}
+
+	slices.SortFunc(groups, func(a, b MaterialGroup) int { return a.Order - b.Order })
+	slices.SortFunc(uncategorized, func(a, b ItemCount) int { return a.ItemID - b.ItemID })
+	return groups, uncategorized
+}
+
+// materialCache returns the loaded material cache, or builds a temporary one from the API
+func (c *Client) materialCache(ctx context.Context) (*MaterialCache, error) {
+	if c.dataCache != nil && c.dataCache.GetMaterialCache().IsLoaded() {
+		return c.dataCache.GetMaterialCache(), nil
+	}
+
+	materials, err := c.GetAllMaterials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get material categories: %w", err)
+	}
+
+	mc := NewMaterialCache()
+	mc.replace(materials)
+	mc.loaded = true
+	return mc, nil
+}
+
+// GetAccountMaterialsByCategory returns the account's material storage grouped by category.
+// Scopes: account, inventories
+func (c *Client) GetAccountMaterialsByCategory(ctx context.Context) ([]MaterialGroup, error) {
+	slots, err := c.GetAccountMaterials(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account materials: %w", err)
+	}
+
+	mc, err := c.materialCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int, len(slots))
+	for _, slot := range slots {
+		counts[slot.ID] += slot.Count
+	}
+
+	groups, _ := mc.GroupByCategory(counts)
+	return groups, nil
+}
+
+// GetBankMaterialDeposits returns the materials sitting in the account bank that
+// could be deposited into material storage, grouped by category.
+// Scopes: account, inventories
+func (c *Client) GetBankMaterialDeposits(ctx context.Context) ([]MaterialGroup, error) {
+	bank, err := c.GetAccountBank(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account bank: %w", err)
+	}
+
+	mc, err := c.materialCache(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[int]int)
+	for _, slot := range bank {
+		// Empty bank slots come back as null
+		if slot.ID != 0 {
+			counts[slot.ID] += slot.Count
+		}
+	}
+
+	groups, _ := mc.GroupByCategory(counts)
+	return groups, nil
+}
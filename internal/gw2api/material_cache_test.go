@@ -0,0 +1,63 @@
+package gw2api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func loadMaterialFixture(t *testing.T) *MaterialCache {
+	t.Helper()
+	mc := NewMaterialCache()
+	path := writeJSONLFixture(t,
+		`{"id": 5, "name": "Cooking Materials", "items": [12134, 12238, 12147], "order": 2}`,
+		`{"id": 6, "name": "Basic Crafting Materials", "items": [19697, 19699, 19703], "order": 0}`,
+	)
+	if err := mc.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	return mc
+}
+
+func TestMaterialCacheCategoryForItem(t *testing.T) {
+	mc := loadMaterialFixture(t)
+
+	material, found := mc.CategoryForItem(19699)
+	if !found || material.ID != 6 {
+		t.Errorf("CategoryForItem(19699) = %+v, %v, expected category 6", material, found)
+	}
+
+	// Most items are not materials
+	if material, found := mc.CategoryForItem(30684); found {
+		t.Errorf("CategoryForItem(30684) = %+v, expected no category", material)
+	}
+
+	// Moving an item between categories updates the reverse index
+	mc.replace([]*Material{{ID: 5, Name: "Cooking Materials", Items: []int{12134}, Order: 2}})
+	if _, found := mc.CategoryForItem(12238); found {
+		t.Error("item removed from a category is still indexed")
+	}
+}
+
+func TestMaterialCacheGroupByCategory(t *testing.T) {
+	mc := loadMaterialFixture(t)
+
+	groups, uncategorized := mc.GroupByCategory(map[int]int{
+		12147: 3,
+		19703: 250,
+		12134: 10,
+		19697: 1,
+		30684: 1, // Not a material
+		19699: 0, // Empty stacks are ignored
+	})
+
+	expected := []MaterialGroup{
+		{CategoryID: 6, Name: "Basic Crafting Materials", Order: 0, Items: []ItemCount{{19697, 1}, {19703, 250}}},
+		{CategoryID: 5, Name: "Cooking Materials", Order: 2, Items: []ItemCount{{12134, 10}, {12147, 3}}},
+	}
+	if !reflect.DeepEqual(groups, expected) {
+		t.Errorf("groups = %+v\nexpected %+v", groups, expected)
+	}
+	if !reflect.DeepEqual(uncategorized, []ItemCount{{30684, 1}}) {
+		t.Errorf("uncategorized = %+v, expected only item 30684", uncategorized)
+	}
+}
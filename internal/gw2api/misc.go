@@ -1,5 +1,7 @@
 package gw2api
 
+import "time"
+
 // Adventure represents an adventure
 type Adventure struct {
 	ID          string `json:"id"`
@@ -54,6 +56,27 @@ type Emblem struct {
 	Layers []string `json:"layers"`
 }
 
+// FinisherDetail represents finisher details
+type FinisherDetail struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	Icon          string `json:"icon"`
+	Order         int    `json:"order"`
+	UnlockDetails string `json:"unlock_details"`
+	UnlockItems   []int  `json:"unlock_items"`
+}
+
+// GliderDetail represents glider details
+type GliderDetail struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Icon        string `json:"icon"`
+	Order       int    `json:"order"`
+	DefaultDyes []int  `json:"default_dyes"`
+	UnlockItems []int  `json:"unlock_items,omitempty"`
+}
+
 // Emote represents an emote
 type EmoteDetail struct {
 	ID          string   `json:"id"`
@@ -142,7 +165,7 @@ type HomesteadGlyphDetail struct {
 	Icon        string `json:"icon"`
 }
 
-// LegendaryArmoryDetail represents legendary armory item details
+// LegendaryArmoryDetail is a legendary the armory can hold and how many copies it stores
 // Wiki: https://wiki.guildwars2.com/wiki/API:2/legendaryarmory
 type LegendaryArmoryDetail struct {
 	ID       int `json:"id"`
@@ -188,6 +211,34 @@ type MountInfo struct {
 	Skins []string `json:"skins"`
 }
 
+// JadeBotDetail represents jade bot skin details
+type JadeBotDetail struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	UnlockItem  int    `json:"unlock_item"`
+}
+
+// MailCarrierDetail represents mail carrier details
+type MailCarrierDetail struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Icon        string   `json:"icon"`
+	Order       int      `json:"order"`
+	UnlockItems []int    `json:"unlock_items"`
+	Flags       []string `json:"flags"`
+}
+
+// MiniDetail represents miniature details
+type MiniDetail struct {
+	ID     int    `json:"id"`
+	Name   string `json:"name"`
+	Unlock string `json:"unlock,omitempty"` // Description of how to unlock, if any
+	Icon   string `json:"icon"`
+	Order  int    `json:"order"`
+	ItemID int    `json:"item_id"`
+}
+
 // MountSkinDetail represents mount skin details
 type MountSkinDetail struct {
 	ID       int            `json:"id"`
@@ -225,6 +276,7 @@ type NoveltyDetail struct {
 	Description string `json:"description"`
 	Icon        string `json:"icon"`
 	Slot        string `json:"slot"`
+	UnlockItem  []int  `json:"unlock_item"` // A list despite the singular name
 }
 
 // OutfitDetail represents outfit details
@@ -269,6 +321,7 @@ type RecipeDetail struct {
 	Ingredients      []RecipeIngredient `json:"ingredients"`
 	GuildIngredients []RecipeIngredient `json:"guild_ingredients,omitempty"`
 	OutputUpgradeID  int                `json:"output_upgrade_id,omitempty"`
+	Source           string             `json:"source,omitempty"` // Empty for official recipes, see RecipeSourceMysticForge
 }
 
 // RecipeIngredient represents a recipe ingredient
@@ -287,9 +340,10 @@ type RecipeSearch struct {
 
 // SkiffDetail represents skiff details
 type SkiffDetail struct {
-	ID   int    `json:"id"`
-	Name string `json:"name"`
-	Icon string `json:"icon"`
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Icon     string         `json:"icon"`
+	DyeSlots []MountDyeSlot `json:"dye_slots"`
 }
 
 // SkinDetail represents skin details
@@ -346,10 +400,22 @@ type VendorItem struct {
 	Cost   []VendorCost `json:"cost"`
 }
 
-// VendorCost represents the cost of a vendor item
+// VendorCost represents the cost of a vendor item, paid either in a wallet
+// currency or in items
 type VendorCost struct {
-	ItemID   int `json:"item_id"`
-	Quantity int `json:"quantity"`
+	ItemID     int `json:"item_id,omitempty"`
+	CurrencyID int `json:"currency_id,omitempty"`
+	Quantity   int `json:"quantity"`
+}
+
+// WizardsVaultSeason represents the current Wizard's Vault season
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wizardsvault
+type WizardsVaultSeason struct {
+	Title      string    `json:"title"`
+	Start      time.Time `json:"start"`
+	End        time.Time `json:"end"`
+	Listings   []int     `json:"listings"`
+	Objectives []int     `json:"objectives"`
 }
 
 // WizardsVaultListingDetail represents wizard's vault listing details
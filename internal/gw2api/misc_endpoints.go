@@ -0,0 +1,1368 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GetBuild returns the current game build ID. With WithBuildCache, calls
+// without options share a recently fetched build; see build_cache.go.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/build
+// Scopes: None (public endpoint)
+func (c *Client) GetBuild(ctx context.Context, options ...RequestOption) (*Build, error) {
+	if c.buildCache != nil && len(options) == 0 {
+		return c.buildCache.get(ctx, c)
+	}
+	return GetSingle[Build](ctx, c, "/v2/build", options...)
+}
+
+// GetBackstoryAnswerIDs returns all available backstory answer IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/backstory/answers
+// Scopes: None (public endpoint)
+func (c *Client) GetBackstoryAnswerIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/backstory/answers", options...)
+}
+
+// GetBackstoryAnswer returns details for a specific backstory answer.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/backstory/answers
+// Scopes: None (public endpoint)
+func (c *Client) GetBackstoryAnswer(ctx context.Context, id string, options ...RequestOption) (*BackstoryAnswer, error) {
+	return GetSingle[BackstoryAnswer](ctx, c, "/v2/backstory/answers/"+id, options...)
+}
+
+// GetBackstoryQuestionIDs returns all available backstory question IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/backstory/questions
+// Scopes: None (public endpoint)
+func (c *Client) GetBackstoryQuestionIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/backstory/questions", options...)
+}
+
+// GetBackstoryQuestion returns details for a specific backstory question.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/backstory/questions
+// Scopes: None (public endpoint)
+func (c *Client) GetBackstoryQuestion(ctx context.Context, id string, options ...RequestOption) (*BackstoryQuestion, error) {
+	return GetSingle[BackstoryQuestion](ctx, c, "/v2/backstory/questions/"+id, options...)
+}
+
+// GetBackstoryAnswers returns multiple backstory answers by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/backstory/answers
+// Scopes: None (public endpoint)
+func (c *Client) GetBackstoryAnswers(ctx context.Context, ids []string, options ...RequestOption) ([]*BackstoryAnswer, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no IDs provided")
+	}
+
+	idsStr := strings.Join(ids, ",")
+	endpoint := "/v2/backstory/answers?ids=" + idsStr
+
+	results, err := GetAll[BackstoryAnswer](ctx, c, endpoint, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*BackstoryAnswer, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetBackstoryQuestions returns multiple backstory questions by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/backstory/questions
+// Scopes: None (public endpoint)
+func (c *Client) GetBackstoryQuestions(ctx context.Context, ids []string, options ...RequestOption) ([]*BackstoryQuestion, error) {
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no IDs provided")
+	}
+
+	idsStr := strings.Join(ids, ",")
+	endpoint := "/v2/backstory/questions?ids=" + idsStr
+
+	results, err := GetAll[BackstoryQuestion](ctx, c, endpoint, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*BackstoryQuestion, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetAchievementIDs returns all available achievement IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/achievements", options...)
+}
+
+// GetAchievement returns details for a specific achievement.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievement(ctx context.Context, id int, options ...RequestOption) (*Achievement, error) {
+	return GetByID[Achievement](ctx, c, "/v2/achievements", id, options...)
+}
+
+// GetAchievements returns multiple achievements by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievements(ctx context.Context, ids []int, options ...RequestOption) ([]*Achievement, error) {
+	// Try cache first if available
+	if c.dataCache != nil && c.dataCache.GetAchievementCache().IsLoaded() {
+		achievements, err := getByIDsCached(ctx, c, c.dataCache.GetAchievementCache().GetByIDs, "/v2/achievements", ids, options...)
+		return achievements, err
+	}
+
+	// No cache available, fetch directly from API
+	results, err := GetByIDs[Achievement](ctx, c, "/v2/achievements", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Achievement, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetAllAchievements returns every achievement, from the achievement cache
+// when it is loaded. Otherwise the endpoint is paged through, as there are too
+// many achievements to request with ids=all.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements
+// Scopes: None (public endpoint)
+func (c *Client) GetAllAchievements(ctx context.Context, options ...RequestOption) ([]*Achievement, error) {
+	if c.dataCache != nil && c.dataCache.GetAchievementCache().IsLoaded() && len(options) == 0 {
+		return c.dataCache.GetAchievementCache().GetAll(), nil
+	}
+
+	results, _, err := GetAllPaged[Achievement](ctx, c, "/v2/achievements", maxIDsPerRequest, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Achievement, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetCurrencyIDs returns all available currency IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/currencies
+// Scopes: None (public endpoint)
+func (c *Client) GetCurrencyIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/currencies", options...)
+}
+
+// GetCurrency returns details for a specific currency.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/currencies
+// Scopes: None (public endpoint)
+func (c *Client) GetCurrency(ctx context.Context, id int, options ...RequestOption) (*Currency, error) {
+	return GetByID[Currency](ctx, c, "/v2/currencies", id, options...)
+}
+
+// GetCurrencies returns multiple currencies by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/currencies
+// Scopes: None (public endpoint)
+func (c *Client) GetCurrencies(ctx context.Context, ids []int, options ...RequestOption) ([]*Currency, error) {
+	// Try cache first if available
+	if c.dataCache != nil && c.dataCache.GetCurrencyCache().IsLoaded() {
+		currencies, err := getByIDsCached(ctx, c, c.dataCache.GetCurrencyCache().GetByIDs, "/v2/currencies", ids, options...)
+		return currencies, err
+	}
+
+	// Fallback to API only
+	results, err := GetByIDs[Currency](ctx, c, "/v2/currencies", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Currency, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetWorldIDs returns all available world IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/worlds
+// Scopes: None (public endpoint)
+func (c *Client) GetWorldIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/worlds", options...)
+}
+
+// GetWorld returns a specific world by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/worlds
+// Scopes: None (public endpoint)
+func (c *Client) GetWorld(ctx context.Context, id int, options ...RequestOption) (*World, error) {
+	return GetByID[World](ctx, c, "/v2/worlds", id, options...)
+}
+
+// GetWorlds returns multiple worlds by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/worlds
+// Scopes: None (public endpoint)
+func (c *Client) GetWorlds(ctx context.Context, ids []int, options ...RequestOption) ([]*World, error) {
+	results, err := GetByIDs[World](ctx, c, "/v2/worlds", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*World, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetWorldsPage returns a page of worlds.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/worlds
+// Scopes: None (public endpoint)
+func (c *Client) GetWorldsPage(ctx context.Context, options ...RequestOption) ([]*World, *PaginationResponse, error) {
+	results, pagination, err := GetPaged[World](ctx, c, "/v2/worlds", options...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ptrs := make([]*World, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, pagination, nil
+}
+
+// GetSkillIDs returns all available skill IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skills
+// Scopes: None (public endpoint)
+func (c *Client) GetSkillIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/skills", options...)
+}
+
+// GetSkill returns a specific skill by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skills
+// Scopes: None (public endpoint)
+func (c *Client) GetSkill(ctx context.Context, id int, options ...RequestOption) (*Skill, error) {
+	return GetByID[Skill](ctx, c, "/v2/skills", id, options...)
+}
+
+// GetSkills returns multiple skills by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skills
+// Scopes: None (public endpoint)
+func (c *Client) GetSkills(ctx context.Context, ids []int, options ...RequestOption) ([]*Skill, error) {
+	// Try cache first if available
+	if c.dataCache != nil && c.dataCache.GetSkillCache().IsLoaded() {
+		skills, err := getByIDsCached(ctx, c, c.dataCache.GetSkillCache().GetByIDs, "/v2/skills", ids, options...)
+		return skills, err
+	}
+
+	// Fallback to API only
+	results, err := GetByIDs[Skill](ctx, c, "/v2/skills", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Skill, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetAllCurrencies returns all currencies.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/currencies
+// Scopes: None (public endpoint)
+func (c *Client) GetAllCurrencies(ctx context.Context, options ...RequestOption) ([]*Currency, error) {
+	results, err := GetAll[Currency](ctx, c, "/v2/currencies", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Currency, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetAllWorlds returns all worlds.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/worlds
+// Scopes: None (public endpoint)
+func (c *Client) GetAllWorlds(ctx context.Context, options ...RequestOption) ([]*World, error) {
+	results, err := GetAll[World](ctx, c, "/v2/worlds", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*World, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetAchievementCategoryIDs returns all achievement category IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/categories
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementCategoryIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/achievements/categories", options...)
+}
+
+// GetAchievementCategory returns details for a specific achievement category.
+// AchievementCategorySchema is requested unless WithSchemaVersion is given.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/categories
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementCategory(ctx context.Context, id int, options ...RequestOption) (*AchievementCategory, error) {
+	return GetByID[AchievementCategory](ctx, c, "/v2/achievements/categories", id, categorySchema(options)...)
+}
+
+// GetAchievementCategories returns multiple achievement categories by IDs.
+// AchievementCategorySchema is requested unless WithSchemaVersion is given.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/categories
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementCategories(ctx context.Context, ids []int, options ...RequestOption) ([]*AchievementCategory, error) {
+	results, err := GetByIDs[AchievementCategory](ctx, c, "/v2/achievements/categories", ids, categorySchema(options)...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*AchievementCategory, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetAchievementGroupIDs returns all achievement group IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/groups
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementGroupIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/achievements/groups", options...)
+}
+
+// GetAchievementGroup returns details for a specific achievement group.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/groups
+// Scopes: None (public endpoint)
+func (c *Client) GetAchievementGroup(ctx context.Context, id string, options ...RequestOption) (*AchievementGroup, error) {
+	// Note: This would need a string-based GetByID variant
+	return GetSingle[AchievementGroup](ctx, c, "/v2/achievements/groups/"+id, options...)
+}
+
+// GetDailyAchievements returns today's daily achievements.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/daily
+// Scopes: None (public endpoint)
+func (c *Client) GetDailyAchievements(ctx context.Context, options ...RequestOption) (*DailyAchievements, error) {
+	return GetSingle[DailyAchievements](ctx, c, "/v2/achievements/daily", options...)
+}
+
+// GetDailyAchievementsTomorrow returns tomorrow's daily achievements.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/achievements/daily/tomorrow
+// Scopes: None (public endpoint)
+func (c *Client) GetDailyAchievementsTomorrow(ctx context.Context, options ...RequestOption) (*DailyAchievements, error) {
+	return GetSingle[DailyAchievements](ctx, c, "/v2/achievements/daily/tomorrow", options...)
+}
+
+// GetColorIDs returns all available color IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/colors
+// Scopes: None (public endpoint)
+func (c *Client) GetColorIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/colors", options...)
+}
+
+// GetColor returns details for a specific color.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/colors
+// Scopes: None (public endpoint)
+func (c *Client) GetColor(ctx context.Context, id int, options ...RequestOption) (*Color, error) {
+	return GetByID[Color](ctx, c, "/v2/colors", id, options...)
+}
+
+// GetColors returns multiple colors by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/colors
+// Scopes: None (public endpoint)
+func (c *Client) GetColors(ctx context.Context, ids []int, options ...RequestOption) ([]*Color, error) {
+	results, err := GetByIDs[Color](ctx, c, "/v2/colors", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Color, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetContinentIDs returns all continent IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/continents
+// Scopes: None (public endpoint)
+func (c *Client) GetContinentIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/continents", options...)
+}
+
+// GetContinent returns a specific continent by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/continents
+// Scopes: None (public endpoint)
+func (c *Client) GetContinent(ctx context.Context, id int, options ...RequestOption) (*Continent, error) {
+	return GetByID[Continent](ctx, c, "/v2/continents", id, options...)
+}
+
+// GetCreateSubtoken creates a subtoken. CreateSubtoken takes the parameters as arguments.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/createsubtoken
+// Scopes: account
+func (c *Client) GetCreateSubtoken(ctx context.Context, options ...RequestOption) (*CreateSubtoken, error) {
+	return GetSingle[CreateSubtoken](ctx, c, "/v2/createsubtoken", options...)
+}
+
+// GetDailyCrafting returns daily crafting items.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/dailycrafting
+// Scopes: None (public endpoint)
+func (c *Client) GetDailyCrafting(ctx context.Context, options ...RequestOption) ([]DailyCraftingItem, error) {
+	return GetAll[DailyCraftingItem](ctx, c, "/v2/dailycrafting", options...)
+}
+
+// GetDungeonIDs returns all dungeon IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/dungeons
+// Scopes: None (public endpoint)
+func (c *Client) GetDungeonIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	ids, err := GetSingle[[]string](ctx, c, "/v2/dungeons", options...)
+	if err != nil {
+		return nil, err
+	}
+	return *ids, nil
+}
+
+// GetAllDungeons returns all dungeons with their paths.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/dungeons
+// Scopes: None (public endpoint)
+func (c *Client) GetAllDungeons(ctx context.Context, options ...RequestOption) ([]*Dungeon, error) {
+	results, err := GetAll[Dungeon](ctx, c, "/v2/dungeons", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Dungeon, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetDungeon returns a specific dungeon by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/dungeons
+// Scopes: None (public endpoint)
+func (c *Client) GetDungeon(ctx context.Context, id string, options ...RequestOption) (*Dungeon, error) {
+	return GetSingle[Dungeon](ctx, c, "/v2/dungeons/"+id, options...)
+}
+
+// GetEmblem returns emblem information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emblem
+// Scopes: None (public endpoint)
+func (c *Client) GetEmblem(ctx context.Context, options ...RequestOption) (*Emblem, error) {
+	return GetSingle[Emblem](ctx, c, "/v2/emblem", options...)
+}
+
+// GetEmblemBackgroundIDs returns all guild emblem background IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emblem
+// Scopes: None (public endpoint)
+func (c *Client) GetEmblemBackgroundIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/emblem/backgrounds", options...)
+}
+
+// GetEmblemBackgrounds returns guild emblem backgrounds by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emblem
+// Scopes: None (public endpoint)
+func (c *Client) GetEmblemBackgrounds(ctx context.Context, ids []int, options ...RequestOption) ([]Emblem, error) {
+	return GetByIDs[Emblem](ctx, c, "/v2/emblem/backgrounds", ids, options...)
+}
+
+// GetEmblemForegroundIDs returns all guild emblem foreground IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emblem
+// Scopes: None (public endpoint)
+func (c *Client) GetEmblemForegroundIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/emblem/foregrounds", options...)
+}
+
+// GetEmblemForegrounds returns guild emblem foregrounds by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emblem
+// Scopes: None (public endpoint)
+func (c *Client) GetEmblemForegrounds(ctx context.Context, ids []int, options ...RequestOption) ([]Emblem, error) {
+	return GetByIDs[Emblem](ctx, c, "/v2/emblem/foregrounds", ids, options...)
+}
+
+// GetEmoteIDs returns all emote IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emotes
+// Scopes: None (public endpoint)
+func (c *Client) GetEmoteIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/emotes", options...)
+}
+
+// GetEmoteDetail returns a specific emote by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/emotes
+// Scopes: None (public endpoint)
+func (c *Client) GetEmoteDetail(ctx context.Context, id string, options ...RequestOption) (*EmoteDetail, error) {
+	return GetSingle[EmoteDetail](ctx, c, "/v2/emotes/"+id, options...)
+}
+
+// GetEventIDs returns all event IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/events
+// Scopes: None (public endpoint)
+func (c *Client) GetEventIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/events", options...)
+}
+
+// GetEvent returns a specific event by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/events
+// Scopes: None (public endpoint)
+func (c *Client) GetEvent(ctx context.Context, id string, options ...RequestOption) (*Event, error) {
+	return GetSingle[Event](ctx, c, "/v2/events/"+id, options...)
+}
+
+// GetFileIDs returns all file IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/files
+// Scopes: None (public endpoint)
+func (c *Client) GetFileIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/files", options...)
+}
+
+// GetFileDetail returns a specific file by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/files
+// Scopes: None (public endpoint)
+func (c *Client) GetFileDetail(ctx context.Context, id string, options ...RequestOption) (*FileDetail, error) {
+	return GetSingle[FileDetail](ctx, c, "/v2/files/"+id, options...)
+}
+
+// GetFinisherIDs returns all finisher IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/finishers
+// Scopes: None (public endpoint)
+func (c *Client) GetFinisherIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/finishers", options...)
+}
+
+// GetFinisher returns a specific finisher by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/finishers
+// Scopes: None (public endpoint)
+func (c *Client) GetFinisher(ctx context.Context, id int, options ...RequestOption) (*FinisherDetail, error) {
+	return GetByID[FinisherDetail](ctx, c, "/v2/finishers", id, options...)
+}
+
+// GetFinishers returns multiple finishers by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/finishers
+// Scopes: None (public endpoint)
+func (c *Client) GetFinishers(ctx context.Context, ids []int, options ...RequestOption) ([]*FinisherDetail, error) {
+	results, err := GetByIDs[FinisherDetail](ctx, c, "/v2/finishers", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*FinisherDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetGliderIDs returns all glider IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/gliders
+// Scopes: None (public endpoint)
+func (c *Client) GetGliderIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/gliders", options...)
+}
+
+// GetGlider returns a specific glider by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/gliders
+// Scopes: None (public endpoint)
+func (c *Client) GetGlider(ctx context.Context, id int, options ...RequestOption) (*GliderDetail, error) {
+	return GetByID[GliderDetail](ctx, c, "/v2/gliders", id, options...)
+}
+
+// GetGliders returns multiple gliders by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/gliders
+// Scopes: None (public endpoint)
+func (c *Client) GetGliders(ctx context.Context, ids []int, options ...RequestOption) ([]*GliderDetail, error) {
+	results, err := GetByIDs[GliderDetail](ctx, c, "/v2/gliders", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*GliderDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetHome returns home instance information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/home
+// Scopes: None (public endpoint)
+func (c *Client) GetHome(ctx context.Context, options ...RequestOption) (*HomeInfo, error) {
+	return GetSingle[HomeInfo](ctx, c, "/v2/home", options...)
+}
+
+// GetHomeCats returns home instance cats.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/home/cats
+// Scopes: None (public endpoint)
+func (c *Client) GetHomeCats(ctx context.Context, options ...RequestOption) ([]HomeCat, error) {
+	return GetAll[HomeCat](ctx, c, "/v2/home/cats", options...)
+}
+
+// GetHomeNodes returns home instance nodes.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/home/nodes
+// Scopes: None (public endpoint)
+func (c *Client) GetHomeNodes(ctx context.Context, options ...RequestOption) ([]HomeNode, error) {
+	return GetAll[HomeNode](ctx, c, "/v2/home/nodes", options...)
+}
+
+// GetHomestead returns homestead information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead
+// Scopes: None (public endpoint)
+func (c *Client) GetHomestead(ctx context.Context, options ...RequestOption) (*HomesteadInfo, error) {
+	return GetSingle[HomesteadInfo](ctx, c, "/v2/homestead", options...)
+}
+
+// GetHomesteadDecorationIDs returns all homestead decoration IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead/decorations
+// Scopes: None (public endpoint)
+func (c *Client) GetHomesteadDecorationIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/homestead/decorations", options...)
+}
+
+// GetHomesteadDecoration returns a specific homestead decoration by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead/decorations
+// Scopes: None (public endpoint)
+func (c *Client) GetHomesteadDecoration(ctx context.Context, id int, options ...RequestOption) (*HomesteadDecorationDetail, error) {
+	return GetByID[HomesteadDecorationDetail](ctx, c, "/v2/homestead/decorations", id, options...)
+}
+
+// GetHomesteadDecorationCategoryIDs returns all decoration category IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead/decorations/categories
+// Scopes: None (public endpoint)
+func (c *Client) GetHomesteadDecorationCategoryIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/homestead/decorations/categories", options...)
+}
+
+// GetHomesteadDecorationCategory returns a specific decoration category by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead/decorations/categories
+// Scopes: None (public endpoint)
+func (c *Client) GetHomesteadDecorationCategory(ctx context.Context, id int, options ...RequestOption) (*HomesteadDecorationCategory, error) {
+	return GetByID[HomesteadDecorationCategory](ctx, c, "/v2/homestead/decorations/categories", id, options...)
+}
+
+// GetHomesteadGlyphIDs returns all homestead glyph IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead/glyphs
+// Scopes: None (public endpoint)
+func (c *Client) GetHomesteadGlyphIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/homestead/glyphs", options...)
+}
+
+// GetHomesteadGlyph returns a specific homestead glyph by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/homestead/glyphs
+// Scopes: None (public endpoint)
+func (c *Client) GetHomesteadGlyph(ctx context.Context, id int, options ...RequestOption) (*HomesteadGlyphDetail, error) {
+	return GetByID[HomesteadGlyphDetail](ctx, c, "/v2/homestead/glyphs", id, options...)
+}
+
+// GetJadeBotIDs returns all jade bot IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/jadebots
+// Scopes: None (public endpoint)
+func (c *Client) GetJadeBotIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/jadebots", options...)
+}
+
+// GetJadeBot returns a specific jade bot by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/jadebots
+// Scopes: None (public endpoint)
+func (c *Client) GetJadeBot(ctx context.Context, id int, options ...RequestOption) (*JadeBotDetail, error) {
+	return GetByID[JadeBotDetail](ctx, c, "/v2/jadebots", id, options...)
+}
+
+// GetJadeBots returns multiple jade bot skins by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/jadebots
+// Scopes: None (public endpoint)
+func (c *Client) GetJadeBots(ctx context.Context, ids []int, options ...RequestOption) ([]*JadeBotDetail, error) {
+	results, err := GetByIDs[JadeBotDetail](ctx, c, "/v2/jadebots", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*JadeBotDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetLegendaryArmoryIDs returns the item IDs of all legendaries the armory can hold.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/legendaryarmory
+// Scopes: None (public endpoint)
+func (c *Client) GetLegendaryArmoryIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/legendaryarmory", options...)
+}
+
+// GetLegendaryArmory returns the armory entry for a specific legendary item ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/legendaryarmory
+// Scopes: None (public endpoint)
+func (c *Client) GetLegendaryArmory(ctx context.Context, id int, options ...RequestOption) (*LegendaryArmoryDetail, error) {
+	return GetByID[LegendaryArmoryDetail](ctx, c, "/v2/legendaryarmory", id, options...)
+}
+
+// GetLegendaryArmoryItems returns the armory entries for multiple legendary item IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/legendaryarmory
+// Scopes: None (public endpoint)
+func (c *Client) GetLegendaryArmoryItems(ctx context.Context, ids []int, options ...RequestOption) ([]*LegendaryArmoryDetail, error) {
+	results, err := GetByIDs[LegendaryArmoryDetail](ctx, c, "/v2/legendaryarmory", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*LegendaryArmoryDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetLegendIDs returns all legend IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/legends
+// Scopes: None (public endpoint)
+func (c *Client) GetLegendIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/legends", options...)
+}
+
+// GetLegend returns a specific legend by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/legends
+// Scopes: None (public endpoint)
+func (c *Client) GetLegend(ctx context.Context, id string, options ...RequestOption) (*Legend, error) {
+	return GetSingle[Legend](ctx, c, "/v2/legends/"+id, options...)
+}
+
+// GetLogos returns logo information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/logos
+// Scopes: None (public endpoint)
+func (c *Client) GetLogos(ctx context.Context, options ...RequestOption) (*LogoDetail, error) {
+	return GetSingle[LogoDetail](ctx, c, "/v2/logos", options...)
+}
+
+// GetMailCarrierIDs returns all mail carrier IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mailcarriers
+// Scopes: None (public endpoint)
+func (c *Client) GetMailCarrierIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/mailcarriers", options...)
+}
+
+// GetMailCarrier returns a specific mail carrier by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mailcarriers
+// Scopes: None (public endpoint)
+func (c *Client) GetMailCarrier(ctx context.Context, id int, options ...RequestOption) (*MailCarrierDetail, error) {
+	return GetByID[MailCarrierDetail](ctx, c, "/v2/mailcarriers", id, options...)
+}
+
+// GetMailCarriers returns multiple mail carriers by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mailcarriers
+// Scopes: None (public endpoint)
+func (c *Client) GetMailCarriers(ctx context.Context, ids []int, options ...RequestOption) ([]*MailCarrierDetail, error) {
+	results, err := GetByIDs[MailCarrierDetail](ctx, c, "/v2/mailcarriers", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*MailCarrierDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetMapChests returns map chest information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mapchests
+// Scopes: None (public endpoint)
+func (c *Client) GetMapChests(ctx context.Context, options ...RequestOption) ([]MapChest, error) {
+	return GetAll[MapChest](ctx, c, "/v2/mapchests", options...)
+}
+
+// GetMapIDs returns all map IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/maps
+// Scopes: None (public endpoint)
+func (c *Client) GetMapIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/maps", options...)
+}
+
+// GetMap returns a specific map by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/maps
+// Scopes: None (public endpoint)
+func (c *Client) GetMap(ctx context.Context, id int, options ...RequestOption) (*MapDetail, error) {
+	return GetByID[MapDetail](ctx, c, "/v2/maps", id, options...)
+}
+
+// GetMaps returns multiple maps by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/maps
+// Scopes: None (public endpoint)
+func (c *Client) GetMaps(ctx context.Context, ids []int, options ...RequestOption) ([]*MapDetail, error) {
+	results, err := GetByIDs[MapDetail](ctx, c, "/v2/maps", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*MapDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetMasteryIDs returns all mastery IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/masteries
+// Scopes: None (public endpoint)
+func (c *Client) GetMasteryIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/masteries", options...)
+}
+
+// GetMastery returns a specific mastery by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/masteries
+// Scopes: None (public endpoint)
+func (c *Client) GetMastery(ctx context.Context, id int, options ...RequestOption) (*Mastery, error) {
+	return GetByID[Mastery](ctx, c, "/v2/masteries", id, options...)
+}
+
+// GetAllMasteries returns all mastery tracks.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/masteries
+// Scopes: None (public endpoint)
+func (c *Client) GetAllMasteries(ctx context.Context, options ...RequestOption) ([]*Mastery, error) {
+	results, err := GetAll[Mastery](ctx, c, "/v2/masteries", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Mastery, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetMiniIDs returns all mini IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/minis
+// Scopes: None (public endpoint)
+func (c *Client) GetMiniIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/minis", options...)
+}
+
+// GetMini returns a specific mini by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/minis
+// Scopes: None (public endpoint)
+func (c *Client) GetMini(ctx context.Context, id int, options ...RequestOption) (*MiniDetail, error) {
+	return GetByID[MiniDetail](ctx, c, "/v2/minis", id, options...)
+}
+
+// GetMinis returns multiple minis by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/minis
+// Scopes: None (public endpoint)
+func (c *Client) GetMinis(ctx context.Context, ids []int, options ...RequestOption) ([]*MiniDetail, error) {
+	results, err := GetByIDs[MiniDetail](ctx, c, "/v2/minis", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*MiniDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetMounts returns mount information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mounts
+// Scopes: None (public endpoint)
+func (c *Client) GetMounts(ctx context.Context, options ...RequestOption) (*MountInfo, error) {
+	return GetSingle[MountInfo](ctx, c, "/v2/mounts", options...)
+}
+
+// GetMountSkinIDs returns all mount skin IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mounts/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetMountSkinIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/mounts/skins", options...)
+}
+
+// GetMountSkin returns a specific mount skin by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mounts/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetMountSkin(ctx context.Context, id int, options ...RequestOption) (*MountSkinDetail, error) {
+	return GetByID[MountSkinDetail](ctx, c, "/v2/mounts/skins", id, options...)
+}
+
+// GetMountSkins returns multiple mount skins by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mounts/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetMountSkins(ctx context.Context, ids []int, options ...RequestOption) ([]*MountSkinDetail, error) {
+	results, err := GetByIDs[MountSkinDetail](ctx, c, "/v2/mounts/skins", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*MountSkinDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetMountTypeIDs returns all mount type IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mounts/types
+// Scopes: None (public endpoint)
+func (c *Client) GetMountTypeIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/mounts/types", options...)
+}
+
+// GetMountType returns a specific mount type by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/mounts/types
+// Scopes: None (public endpoint)
+func (c *Client) GetMountType(ctx context.Context, id string, options ...RequestOption) (*MountTypeDetail, error) {
+	return GetSingle[MountTypeDetail](ctx, c, "/v2/mounts/types/"+id, options...)
+}
+
+// GetNoveltyIDs returns all novelty IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/novelties
+// Scopes: None (public endpoint)
+func (c *Client) GetNoveltyIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/novelties", options...)
+}
+
+// GetNovelty returns a specific novelty by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/novelties
+// Scopes: None (public endpoint)
+func (c *Client) GetNovelty(ctx context.Context, id int, options ...RequestOption) (*NoveltyDetail, error) {
+	return GetByID[NoveltyDetail](ctx, c, "/v2/novelties", id, options...)
+}
+
+// GetNovelties returns multiple novelties by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/novelties
+// Scopes: None (public endpoint)
+func (c *Client) GetNovelties(ctx context.Context, ids []int, options ...RequestOption) ([]*NoveltyDetail, error) {
+	results, err := GetByIDs[NoveltyDetail](ctx, c, "/v2/novelties", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*NoveltyDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetOutfitIDs returns all outfit IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/outfits
+// Scopes: None (public endpoint)
+func (c *Client) GetOutfitIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/outfits", options...)
+}
+
+// GetOutfit returns a specific outfit by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/outfits
+// Scopes: None (public endpoint)
+func (c *Client) GetOutfit(ctx context.Context, id int, options ...RequestOption) (*OutfitDetail, error) {
+	return GetByID[OutfitDetail](ctx, c, "/v2/outfits", id, options...)
+}
+
+// GetOutfits returns multiple outfits by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/outfits
+// Scopes: None (public endpoint)
+func (c *Client) GetOutfits(ctx context.Context, ids []int, options ...RequestOption) ([]*OutfitDetail, error) {
+	results, err := GetByIDs[OutfitDetail](ctx, c, "/v2/outfits", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*OutfitDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetPetIDs returns all pet IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pets
+// Scopes: None (public endpoint)
+func (c *Client) GetPetIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/pets", options...)
+}
+
+// GetPet returns a specific pet by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pets
+// Scopes: None (public endpoint)
+func (c *Client) GetPet(ctx context.Context, id int, options ...RequestOption) (*Pet, error) {
+	return GetByID[Pet](ctx, c, "/v2/pets", id, options...)
+}
+
+// GetPets returns multiple pets by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pets
+// Scopes: None (public endpoint)
+func (c *Client) GetPets(ctx context.Context, ids []int, options ...RequestOption) ([]*Pet, error) {
+	results, err := GetByIDs[Pet](ctx, c, "/v2/pets", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Pet, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetProfessionIDs returns all profession IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/professions
+// Scopes: None (public endpoint)
+func (c *Client) GetProfessionIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/professions", options...)
+}
+
+// GetProfession returns a specific profession by ID.
+// ProfessionSchema is requested unless WithSchemaVersion is given.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/professions
+// Scopes: None (public endpoint)
+func (c *Client) GetProfession(ctx context.Context, id string, options ...RequestOption) (*Profession, error) {
+	return GetSingle[Profession](ctx, c, "/v2/professions/"+id, professionSchema(options)...)
+}
+
+// GetAllProfessions returns every profession.
+// ProfessionSchema is requested unless WithSchemaVersion is given.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/professions
+// Scopes: None (public endpoint)
+func (c *Client) GetAllProfessions(ctx context.Context, options ...RequestOption) ([]*Profession, error) {
+	results, err := GetAll[Profession](ctx, c, "/v2/professions", professionSchema(options)...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Profession, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetQuagganIDs returns all quaggan IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/quaggans
+// Scopes: None (public endpoint)
+func (c *Client) GetQuagganIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/quaggans", options...)
+}
+
+// GetQuaggan returns a specific quaggan by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/quaggans
+// Scopes: None (public endpoint)
+func (c *Client) GetQuaggan(ctx context.Context, id string, options ...RequestOption) (*Quaggan, error) {
+	return GetSingle[Quaggan](ctx, c, "/v2/quaggans/"+id, options...)
+}
+
+// GetQuestIDs returns all quest IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/quests
+// Scopes: None (public endpoint)
+func (c *Client) GetQuestIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/quests", options...)
+}
+
+// GetQuest returns a specific quest by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/quests
+// Scopes: None (public endpoint)
+func (c *Client) GetQuest(ctx context.Context, id int, options ...RequestOption) (*Quest, error) {
+	return GetByID[Quest](ctx, c, "/v2/quests", id, options...)
+}
+
+// GetRaceIDs returns all race IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/races
+// Scopes: None (public endpoint)
+func (c *Client) GetRaceIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/races", options...)
+}
+
+// GetRace returns a specific race by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/races
+// Scopes: None (public endpoint)
+func (c *Client) GetRace(ctx context.Context, id string, options ...RequestOption) (*Race, error) {
+	return GetSingle[Race](ctx, c, "/v2/races/"+id, options...)
+}
+
+// GetRaidIDs returns all raid IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/raids
+// Scopes: None (public endpoint)
+func (c *Client) GetRaidIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	ids, err := GetSingle[[]string](ctx, c, "/v2/raids", options...)
+	if err != nil {
+		return nil, err
+	}
+	return *ids, nil
+}
+
+// GetAllRaids returns all raids with their wings and encounters.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/raids
+// Scopes: None (public endpoint)
+func (c *Client) GetAllRaids(ctx context.Context, options ...RequestOption) ([]*Raid, error) {
+	results, err := GetAll[Raid](ctx, c, "/v2/raids", options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*Raid, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetRaid returns a specific raid by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/raids
+// Scopes: None (public endpoint)
+func (c *Client) GetRaid(ctx context.Context, id string, options ...RequestOption) (*Raid, error) {
+	return GetSingle[Raid](ctx, c, "/v2/raids/"+id, options...)
+}
+
+// GetSkiffIDs returns all skiff IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skiffs
+// Scopes: None (public endpoint)
+func (c *Client) GetSkiffIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/skiffs", options...)
+}
+
+// GetSkiff returns a specific skiff by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skiffs
+// Scopes: None (public endpoint)
+func (c *Client) GetSkiff(ctx context.Context, id int, options ...RequestOption) (*SkiffDetail, error) {
+	return GetByID[SkiffDetail](ctx, c, "/v2/skiffs", id, options...)
+}
+
+// GetSkiffs returns multiple skiffs by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skiffs
+// Scopes: None (public endpoint)
+func (c *Client) GetSkiffs(ctx context.Context, ids []int, options ...RequestOption) ([]*SkiffDetail, error) {
+	results, err := GetByIDs[SkiffDetail](ctx, c, "/v2/skiffs", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*SkiffDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetSkinIDs returns all skin IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetSkinIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/skins", options...)
+}
+
+// GetSkins returns multiple skins by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetSkins(ctx context.Context, ids []int, options ...RequestOption) ([]*SkinDetail, error) {
+	// Try cache first if available
+	if c.dataCache != nil && c.dataCache.GetSkinCache().IsLoaded() {
+		skins, err := getByIDsCached(ctx, c, c.dataCache.GetSkinCache().GetByIDs, "/v2/skins", ids, options...)
+		return skins, err
+	}
+
+	// Fallback to API only
+	results, err := GetByIDs[SkinDetail](ctx, c, "/v2/skins", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*SkinDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetSkin returns a specific skin by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetSkin(ctx context.Context, id int, options ...RequestOption) (*SkinDetail, error) {
+	return GetByID[SkinDetail](ctx, c, "/v2/skins", id, options...)
+}
+
+// GetSpecializationIDs returns all specialization IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/specializations
+// Scopes: None (public endpoint)
+func (c *Client) GetSpecializationIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/specializations", options...)
+}
+
+// GetSpecialization returns a specific specialization by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/specializations
+// Scopes: None (public endpoint)
+func (c *Client) GetSpecialization(ctx context.Context, id int, options ...RequestOption) (*Specialization, error) {
+	return GetByID[Specialization](ctx, c, "/v2/specializations", id, options...)
+}
+
+// GetSpecializations returns multiple specializations by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/specializations
+// Scopes: None (public endpoint)
+func (c *Client) GetSpecializations(ctx context.Context, ids []int, options ...RequestOption) ([]*Specialization, error) {
+	results, err := GetByIDs[Specialization](ctx, c, "/v2/specializations", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Specialization, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetStoryIDs returns all story IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/stories
+// Scopes: None (public endpoint)
+func (c *Client) GetStoryIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/stories", options...)
+}
+
+// GetStory returns a specific story by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/stories
+// Scopes: None (public endpoint)
+func (c *Client) GetStory(ctx context.Context, id int, options ...RequestOption) (*Story, error) {
+	return GetByID[Story](ctx, c, "/v2/stories", id, options...)
+}
+
+// GetStorySeasonIDs returns all story season IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/stories/seasons
+// Scopes: None (public endpoint)
+func (c *Client) GetStorySeasonIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/stories/seasons", options...)
+}
+
+// GetStorySeason returns a specific story season by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/stories/seasons
+// Scopes: None (public endpoint)
+func (c *Client) GetStorySeason(ctx context.Context, id string, options ...RequestOption) (*StorySeason, error) {
+	return GetSingle[StorySeason](ctx, c, "/v2/stories/seasons/"+id, options...)
+}
+
+// GetTitleIDs returns all title IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/titles
+// Scopes: None (public endpoint)
+func (c *Client) GetTitleIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/titles", options...)
+}
+
+// GetTitle returns a specific title by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/titles
+// Scopes: None (public endpoint)
+func (c *Client) GetTitle(ctx context.Context, id int, options ...RequestOption) (*Title, error) {
+	return GetByID[Title](ctx, c, "/v2/titles", id, options...)
+}
+
+// GetTokenInfo returns API token information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/tokeninfo
+// Scopes: account
+func (c *Client) GetTokenInfo(ctx context.Context, options ...RequestOption) (*TokenInfo, error) {
+	return GetSingle[TokenInfo](ctx, c, "/v2/tokeninfo", options...)
+}
+
+// GetTraitIDs returns all trait IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/traits
+// Scopes: None (public endpoint)
+func (c *Client) GetTraitIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/traits", options...)
+}
+
+// GetTrait returns a specific trait by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/traits
+// Scopes: None (public endpoint)
+func (c *Client) GetTrait(ctx context.Context, id int, options ...RequestOption) (*Trait, error) {
+	return GetByID[Trait](ctx, c, "/v2/traits", id, options...)
+}
+
+// GetTraits returns multiple traits by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/traits
+// Scopes: None (public endpoint)
+func (c *Client) GetTraits(ctx context.Context, ids []int, options ...RequestOption) ([]*Trait, error) {
+	results, err := GetByIDs[Trait](ctx, c, "/v2/traits", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Trait, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetVendorIDs returns all vendor IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/vendors
+// Scopes: None (public endpoint)
+func (c *Client) GetVendorIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/vendors", options...)
+}
+
+// GetVendor returns a specific vendor by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/vendors
+// Scopes: None (public endpoint)
+func (c *Client) GetVendor(ctx context.Context, id int, options ...RequestOption) (*Vendor, error) {
+	return GetByID[Vendor](ctx, c, "/v2/vendors", id, options...)
+}
+
+// GetVendors returns multiple vendors by IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/vendors
+// Scopes: None (public endpoint)
+func (c *Client) GetVendors(ctx context.Context, ids []int, options ...RequestOption) ([]*Vendor, error) {
+	results, err := GetByIDs[Vendor](ctx, c, "/v2/vendors", ids, options...)
+	if err != nil && len(results) == 0 {
+		return nil, err
+	}
+
+	ptrs := make([]*Vendor, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, err
+}
+
+// GetWizardsVaultSeason returns the current Wizard's Vault season.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wizardsvault
+// Scopes: None (public endpoint)
+func (c *Client) GetWizardsVaultSeason(ctx context.Context, options ...RequestOption) (*WizardsVaultSeason, error) {
+	return GetSingle[WizardsVaultSeason](ctx, c, "/v2/wizardsvault", options...)
+}
+
+// GetWizardsVaultListingIDs returns all wizard's vault listing IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wizardsvault/listings
+// Scopes: None (public endpoint)
+func (c *Client) GetWizardsVaultListingIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/wizardsvault/listings", options...)
+}
+
+// GetWizardsVaultListing returns a specific wizard's vault listing by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wizardsvault/listings
+// Scopes: None (public endpoint)
+func (c *Client) GetWizardsVaultListing(ctx context.Context, id int, options ...RequestOption) (*WizardsVaultListingDetail, error) {
+	return GetByID[WizardsVaultListingDetail](ctx, c, "/v2/wizardsvault/listings", id, options...)
+}
+
+// GetWizardsVaultObjectiveIDs returns all wizard's vault objective IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wizardsvault/objectives
+// Scopes: None (public endpoint)
+func (c *Client) GetWizardsVaultObjectiveIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/wizardsvault/objectives", options...)
+}
+
+// GetWizardsVaultObjective returns a specific wizard's vault objective by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wizardsvault/objectives
+// Scopes: None (public endpoint)
+func (c *Client) GetWizardsVaultObjective(ctx context.Context, id int, options ...RequestOption) (*WizardsVaultObjective, error) {
+	return GetByID[WizardsVaultObjective](ctx, c, "/v2/wizardsvault/objectives", id, options...)
+}
+
+// GetWorldBosses returns world boss information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/worldbosses
+// Scopes: None (public endpoint)
+func (c *Client) GetWorldBosses(ctx context.Context, options ...RequestOption) (*WorldBossDetail, error) {
+	return GetSingle[WorldBossDetail](ctx, c, "/v2/worldbosses", options...)
+}
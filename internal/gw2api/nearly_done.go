@@ -0,0 +1,114 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// Achievement flags that keep an achievement out of GetNearlyCompleteAchievements.
+// Daily, weekly and monthly achievements reset before they are worth chasing,
+// and the game itself hides IgnoreNearlyComplete ones from its own list.
+var nearlyDoneExcludedFlags = []string{"Daily", "Weekly", "Monthly", "IgnoreNearlyComplete"}
+
+// NearlyDoneAchievement is an unfinished achievement and how far along it is
+type NearlyDoneAchievement struct {
+	ID      int     `json:"id"`
+	Name    string  `json:"name"`
+	Current int     `json:"current"`
+	Max     int     `json:"max"`
+	Ratio   float64 `json:"ratio"`  // Current over Max, from 0 to 1
+	Points  int     `json:"points"` // AP still to earn from unfinished tiers
+}
+
+// GetNearlyCompleteAchievements returns the account's unfinished achievements
+// closest to completion, most complete first, up to limit (0 for all).
+// Achievement definitions are fetched cache-first.
+// Scopes: account, progression
+func (c *Client) GetNearlyCompleteAchievements(ctx context.Context, limit int) ([]NearlyDoneAchievement, error) {
+	progress, err := c.GetAccountAchievements(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account achievements: %w", err)
+	}
+
+	var ids []int
+	for _, p := range progress {
+		if !p.Done {
+			ids = append(ids, p.ID)
+		}
+	}
+	achievements, err := c.lookupAchievements(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get achievements: %w", err)
+	}
+
+	return nearlyDoneAchievements(progress, achievements, limit), nil
+}
+
+// nearlyDoneAchievements ranks unfinished achievements by completion, then by
+// the AP left to earn. Achievements without a definition are skipped, since
+// there is no way to tell what they are or whether they repeat.
+func nearlyDoneAchievements(progress []AccountAchievement, achievements map[int]*Achievement, limit int) []NearlyDoneAchievement {
+	var result []NearlyDoneAchievement
+	for _, p := range progress {
+		achievement := achievements[p.ID]
+		if p.Done || achievement == nil || slices.ContainsFunc(achievement.Flags, func(flag string) bool {
+			return slices.Contains(nearlyDoneExcludedFlags, flag)
+		}) {
+			continue
+		}
+
+		current, maximum := achievementProgress(achievement, p)
+		if current <= 0 || maximum <= 0 {
+			continue
+		}
+
+		points := 0
+		for _, tier := range achievement.Tiers {
+			if current < tier.Count {
+				points += tier.Points
+			}
+		}
+		result = append(result, NearlyDoneAchievement{
+			ID:      p.ID,
+			Name:    achievement.Name,
+			Current: current,
+			Max:     maximum,
+			Ratio:   min(float64(current)/float64(maximum), 1),
+			Points:  points,
+		})
+	}
+
+	slices.SortFunc(result, func(a, b NearlyDoneAchievement) int {
+		if c := cmp.Compare(b.Ratio, a.Ratio); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Points, a.Points); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	if limit > 0 && len(result) > limit {
+		result = result[:limit]
+	}
+	return result
+}
+
+// achievementProgress returns how far the account is through an achievement.
+// The API leaves max out for some collections, so the definition's bits or
+// final tier stand in for it, and completed bits for a missing current.
+func achievementProgress(achievement *Achievement, progress AccountAchievement) (current, maximum int) {
+	current, maximum = progress.Current, progress.Max
+	if maximum == 0 {
+		if len(achievement.Bits) > 0 {
+			maximum = len(achievement.Bits)
+		} else if len(achievement.Tiers) > 0 {
+			maximum = achievement.Tiers[len(achievement.Tiers)-1].Count
+		}
+	}
+	if current == 0 {
+		current = len(progress.Bits)
+	}
+	return current, maximum
+}
@@ -0,0 +1,65 @@
+package gw2api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNearlyDoneAchievements(t *testing.T) {
+	tier := func(counts ...int) []AchievementTier {
+		tiers := make([]AchievementTier, len(counts))
+		for i, count := range counts {
+			tiers[i] = AchievementTier{Count: count, Points: 5}
+		}
+		return tiers
+	}
+	achievements := map[int]*Achievement{
+		1: {ID: 1, Name: "Half way", Tiers: tier(10)},
+		2: {ID: 2, Name: "Almost, fewer points", Tiers: tier(5, 10)},
+		3: {ID: 3, Name: "Almost", Tiers: tier(10, 10)},
+		4: {ID: 4, Name: "Daily", Flags: []string{"Daily"}, Tiers: tier(4)},
+		5: {ID: 5, Name: "Finished", Tiers: tier(1)},
+		6: {ID: 6, Name: "Collection", Bits: make([]AchievementBit, 4), Tiers: tier(4)},
+		7: {ID: 7, Name: "Hidden from the game's list", Flags: []string{"IgnoreNearlyComplete"}, Tiers: tier(2)},
+		8: {ID: 8, Name: "Not started", Tiers: tier(10)},
+		9: {ID: 9, Name: "No maximum", Tiers: tier(20)},
+	}
+	progress := []AccountAchievement{
+		{ID: 1, Current: 5, Max: 10},
+		{ID: 2, Current: 9, Max: 10},
+		{ID: 3, Current: 9, Max: 10},
+		{ID: 4, Current: 3, Max: 4},
+		{ID: 5, Current: 1, Max: 1, Done: true},
+		{ID: 6, Bits: []int{0, 1, 3}}, // No current or max, as for some collections
+		{ID: 7, Current: 1, Max: 2},
+		{ID: 8, Current: 0, Max: 10},
+		{ID: 9, Current: 4},
+		{ID: 10, Current: 9, Max: 10}, // No definition
+	}
+
+	got := nearlyDoneAchievements(progress, achievements, 0)
+	var ids []int
+	for _, a := range got {
+		ids = append(ids, a.ID)
+	}
+	if expected := []int{3, 2, 6, 1, 9}; !slices.Equal(ids, expected) {
+		t.Fatalf("IDs = %v, expected %v", ids, expected)
+	}
+
+	if almost := got[0]; almost.Ratio != 0.9 || almost.Points != 10 || almost.Name != "Almost" {
+		t.Errorf("Almost = %+v, expected 90%% with both tiers' 10 AP left", almost)
+	}
+	if fewer := got[1]; fewer.Points != 5 {
+		t.Errorf("fewer points = %+v, expected only the last tier's 5 AP left", fewer)
+	}
+	if collection := got[2]; collection.Current != 3 || collection.Max != 4 {
+		t.Errorf("collection = %+v, expected 3 of 4 bits", collection)
+	}
+	if noMax := got[4]; noMax.Max != 20 || noMax.Ratio != 0.2 {
+		t.Errorf("achievement without max = %+v, expected its final tier's count", noMax)
+	}
+
+	if limited := nearlyDoneAchievements(progress, achievements, 2); len(limited) != 2 || limited[1].ID != 2 {
+		t.Errorf("limit 2 = %+v, expected the first two", limited)
+	}
+}
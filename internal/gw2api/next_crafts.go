@@ -0,0 +1,96 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// CraftableUpgrade is a recipe a character can discover at its current
+// crafting rating but doesn't know yet
+type CraftableUpgrade struct {
+	Discipline string        `json:"discipline"`
+	Rating     int           `json:"rating"` // The character's rating in Discipline
+	Recipe     *RecipeDetail `json:"recipe"`
+	OutputItem *Item         `json:"output_item,omitempty"`
+}
+
+// GetCraftableUpgrades lists the recipes a character could discover in its
+// active crafting disciplines, highest required rating first. Recipes learned
+// automatically or from recipe sheets are left out, as are recipes the
+// character or account already knows. Needs the recipe cache.
+// Scopes: account, characters, unlocks
+func (c *Client) GetCraftableUpgrades(ctx context.Context, characterName string) ([]CraftableUpgrade, error) {
+	if c.dataCache == nil || !c.dataCache.GetRecipeCache().IsLoaded() {
+		return nil, fmt.Errorf("finding craftable recipes requires data cache to be loaded")
+	}
+
+	crafting, err := c.GetCharacterCrafting(ctx, characterName)
+	if err != nil {
+		return nil, err
+	}
+	characterRecipes, err := c.GetCharacterRecipes(ctx, characterName)
+	if err != nil {
+		return nil, err
+	}
+	accountRecipes, err := c.GetAccountRecipes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[int]bool, len(characterRecipes.Recipes)+len(accountRecipes))
+	for _, id := range characterRecipes.Recipes {
+		known[id] = true
+	}
+	for _, id := range accountRecipes {
+		known[int(id)] = true
+	}
+
+	// A recipe shared by two active disciplines is listed once, under the first
+	var upgrades []CraftableUpgrade
+	seen := make(map[int]bool)
+	for _, discipline := range crafting {
+		if !discipline.Active {
+			continue
+		}
+		recipes := c.dataCache.GetRecipeCache().SearchRecipes(RecipeSearchOptions{
+			Disciplines: []string{discipline.Discipline},
+			MaxRating:   discipline.Rating,
+		})
+		for _, recipe := range recipes {
+			if discoverable(recipe) && !known[recipe.ID] && !seen[recipe.ID] {
+				seen[recipe.ID] = true
+				upgrades = append(upgrades, CraftableUpgrade{Discipline: discipline.Discipline, Rating: discipline.Rating, Recipe: recipe})
+			}
+		}
+	}
+
+	outputIDs := make([]int, len(upgrades))
+	for i, upgrade := range upgrades {
+		outputIDs[i] = upgrade.Recipe.OutputItemID
+	}
+	items, err := c.lookupItems(ctx, outputIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to look up crafted items: %w", err)
+	}
+	for i := range upgrades {
+		upgrades[i].OutputItem = items[upgrades[i].Recipe.OutputItemID]
+	}
+
+	slices.SortStableFunc(upgrades, func(a, b CraftableUpgrade) int {
+		if c := cmp.Compare(b.Recipe.MinRating, a.Recipe.MinRating); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Recipe.ID, b.Recipe.ID)
+	})
+	return upgrades, nil
+}
+
+// discoverable reports whether a recipe is learned by discovery, rather than
+// automatically, from a recipe sheet or from outside the crafting stations
+func discoverable(recipe *RecipeDetail) bool {
+	return !recipe.IsCustom() &&
+		!slices.Contains(recipe.Flags, "AutoLearned") &&
+		!slices.Contains(recipe.Flags, "LearnedFromItem")
+}
@@ -0,0 +1,81 @@
+package gw2api
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func newCraftingFixtureClient(t *testing.T) *Client {
+	client := newFixtureClient(t, "crafting", map[string]string{
+		"/v2/characters/Tester/crafting": "crafting.json",
+		"/v2/characters/Tester/recipes":  "character_recipes.json",
+		"/v2/account/recipes":            "account_recipes.json",
+		"/v2/items":                      "items.json",
+	})
+	client.dataCache = NewDataCache()
+	if err := client.dataCache.GetRecipeCache().LoadFromFile(filepath.Join("testdata", "crafting", "recipes.jsonl")); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+	return client
+}
+
+func TestSearchRecipes(t *testing.T) {
+	client := newCraftingFixtureClient(t)
+	cache := client.DataCache().GetRecipeCache()
+
+	ids := func(recipes []*RecipeDetail) []int {
+		var result []int
+		for _, recipe := range recipes {
+			result = append(result, recipe.ID)
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		options  RecipeSearchOptions
+		expected []int
+	}{
+		{"discipline ignoring case", RecipeSearchOptions{Disciplines: []string{"tailor"}}, []int{1, 2, 3, 4, 5, 6, 8, 9}},
+		{"rating range", RecipeSearchOptions{Disciplines: []string{"Tailor"}, MinRating: 150, MaxRating: 275}, []int{2, 3, 6, 8, 9}},
+		{"several disciplines", RecipeSearchOptions{Disciplines: []string{"Chef", "Armorsmith"}}, []int{5, 7, 8}},
+		{"no discipline", RecipeSearchOptions{MaxRating: 50}, []int{1, 7}},
+		{"output items", RecipeSearchOptions{OutputItemIDs: []int{103, 108}}, []int{3, 8}},
+		{"limit", RecipeSearchOptions{Disciplines: []string{"Tailor"}, Limit: 2}, []int{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ids(cache.SearchRecipes(tt.options)); !slices.Equal(got, tt.expected) {
+				t.Errorf("SearchRecipes = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetCraftableUpgrades(t *testing.T) {
+	client := newCraftingFixtureClient(t)
+
+	upgrades, err := client.GetCraftableUpgrades(context.Background(), "Tester")
+	if err != nil {
+		t.Fatalf("GetCraftableUpgrades: %v", err)
+	}
+
+	var got []int
+	for _, upgrade := range upgrades {
+		got = append(got, upgrade.Recipe.ID)
+	}
+	// 1 and 9 are known, 4 is out of reach, 5 and 6 aren't discovered and Chef is inactive
+	if expected := []int{3, 8, 2}; !slices.Equal(got, expected) {
+		t.Fatalf("recipes = %v, expected %v", got, expected)
+	}
+
+	bag := upgrades[1]
+	if bag.Discipline != "Tailor" || bag.Rating != 275 {
+		t.Errorf("shared recipe listed under %s %d, expected the first active discipline", bag.Discipline, bag.Rating)
+	}
+	if bag.OutputItem == nil || bag.OutputItem.Name != "Craftsman's Bag" {
+		t.Errorf("output item = %+v, expected Craftsman's Bag", bag.OutputItem)
+	}
+}
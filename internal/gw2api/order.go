@@ -0,0 +1,93 @@
+package gw2api
+
+import (
+	"context"
+	"reflect"
+)
+
+// Bulk getters such as GetItems and GetByIDs return entries in the order of
+// the requested IDs, whether they come from the API, the data cache or both.
+// Each ID appears once, at its first position in the request, and IDs that the
+// API doesn't know are left out, so a result can be shorter than the request
+// but never holds nil entries. Requests for more IDs than the API takes at
+// once are split, and when only some parts fail, the entries fetched are
+// returned along with the error.
+
+// idOf returns a function that reads the int ID field of T, or nil if T has none
+func idOf[T any]() func(*T) int {
+	t := reflect.TypeFor[T]()
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	field, found := t.FieldByName("ID")
+	if !found || field.Type.Kind() != reflect.Int || len(field.Index) != 1 {
+		return nil
+	}
+	return func(entry *T) int {
+		return int(reflect.ValueOf(entry).Elem().Field(field.Index[0]).Int())
+	}
+}
+
+// orderByIDs arranges entries in the order of ids, following the contract above
+func orderByIDs[T any](entries []T, ids []int, id func(*T) int) []T {
+	index := make(map[int]int, len(entries))
+	for i := range entries {
+		if _, duplicate := index[id(&entries[i])]; !duplicate {
+			index[id(&entries[i])] = i
+		}
+	}
+
+	ordered := make([]T, 0, len(entries))
+	for _, requested := range ids {
+		if i, found := index[requested]; found {
+			ordered = append(ordered, entries[i])
+			delete(index, requested)
+		}
+	}
+	return ordered
+}
+
+// pickByIDs returns the entries for ids from byID, following the contract above
+func pickByIDs[T any](byID map[int]*T, ids []int) []*T {
+	picked := make([]*T, 0, len(byID))
+	seen := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if entry, found := byID[id]; found && !seen[id] {
+			seen[id] = true
+			picked = append(picked, entry)
+		}
+	}
+	return picked
+}
+
+// getByIDsCached looks up ids with lookup and fetches those it doesn't find from
+// endpoint. It returns the entries in request order. When the API fails, the
+// cached entries and any that were fetched are still returned, along with the
+// error.
+func getByIDsCached[T any](ctx context.Context, c *Client, lookup func([]int) []*T, endpoint string, ids []int, options ...RequestOption) ([]*T, error) {
+	id := idOf[T]()
+	byID := make(map[int]*T, len(ids))
+	for _, entry := range lookup(ids) {
+		byID[id(entry)] = entry
+	}
+
+	var missingIDs []int
+	for _, requested := range ids {
+		if _, found := byID[requested]; !found {
+			missingIDs = append(missingIDs, requested)
+		}
+	}
+	if len(missingIDs) == 0 {
+		return pickByIDs(byID, ids), nil
+	}
+
+	fetched, err := GetByIDs[T](ctx, c, endpoint, missingIDs, options...)
+	for i := range fetched {
+		byID[id(&fetched[i])] = &fetched[i]
+	}
+	if err != nil && len(byID) == 0 {
+		return nil, err
+	}
+	// Return cached entries even if the API fails
+	return pickByIDs(byID, ids), err
+}
@@ -0,0 +1,235 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newOrderClient returns a client whose API answers item and skill requests
+// with fixed entries in descending ID order, whatever was asked for
+func newOrderClient(t *testing.T) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/items":
+			w.Write([]byte(`[{"id": 40, "name": "API 40"}, {"id": 20, "name": "API 20"}]`))
+		case "/v2/skills":
+			w.Write([]byte(`[{"id": 7, "name": "API 7"}, {"id": 5, "name": "API 5"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+	client.dataCache = NewDataCache()
+	return client
+}
+
+func itemIDsOf(items []*Item) []int {
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func TestGetItemsOrder(t *testing.T) {
+	client := newOrderClient(t)
+	ctx := context.Background()
+
+	// API only: the response order is replaced by the request order
+	items, err := client.GetItems(ctx, []int{20, 99, 40, 20})
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	if ids := itemIDsOf(items); !slices.Equal(ids, []int{20, 40}) {
+		t.Errorf("API-only IDs = %v, expected [20 40]", ids)
+	}
+
+	// Cached and fetched entries are interleaved in request order
+	if err := client.dataCache.GetItemCache().LoadFromFile(writeJSONLFixture(t,
+		`{"id": 30, "name": "Cached 30"}`,
+		`{"id": 10, "name": "Cached 10"}`,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	requests := [][]int{
+		{40, 10, 99, 20, 30},
+		{30, 20, 10, 40},
+		{10, 30, 10}, // Cache only, with a duplicate
+	}
+	expected := [][]int{
+		{40, 10, 20, 30},
+		{30, 20, 10, 40},
+		{10, 30},
+	}
+	for i, ids := range requests {
+		items, err := client.GetItems(ctx, ids)
+		if err != nil {
+			t.Fatalf("GetItems(%v): %v", ids, err)
+		}
+		if got := itemIDsOf(items); !slices.Equal(got, expected[i]) {
+			t.Errorf("GetItems(%v) = %v, expected %v", ids, got, expected[i])
+		}
+		if slices.Contains(items, nil) {
+			t.Errorf("GetItems(%v) returned nil entries", ids)
+		}
+	}
+
+	// Projections keep the order too
+	items, err = client.GetItems(ctx, []int{20, 30}, WithFields("name"))
+	if err != nil {
+		t.Fatalf("GetItems with fields: %v", err)
+	}
+	if got := itemIDsOf(items); !slices.Equal(got, []int{20, 30}) || items[0].Name != "API 20" {
+		t.Errorf("projected items = %+v, expected 20 then 30", items)
+	}
+}
+
+func TestGetSkillsOrder(t *testing.T) {
+	client := newOrderClient(t)
+	if err := client.dataCache.GetSkillCache().LoadFromFile(writeJSONLFixture(t, `{"id": 6, "name": "Cached 6"}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	skills, err := client.GetSkills(context.Background(), []int{5, 6, 7})
+	if err != nil {
+		t.Fatalf("GetSkills: %v", err)
+	}
+	var ids []int
+	for _, skill := range skills {
+		ids = append(ids, skill.ID)
+	}
+	if !slices.Equal(ids, []int{5, 6, 7}) {
+		t.Errorf("GetSkills IDs = %v, expected [5 6 7]", ids)
+	}
+}
+
+func TestOrderByIDsWithoutIDField(t *testing.T) {
+	if idOf[int]() != nil || idOf[struct{ ID string }]() != nil {
+		t.Error("idOf found an int ID field where there is none")
+	}
+	if id := idOf[Price](); id == nil || id(&Price{ID: 19721}) != 19721 {
+		t.Error("idOf did not read the ID of a price")
+	}
+}
+
+func TestGetByIDsChunks(t *testing.T) {
+	// IDs 1000 and up are unknown, and a request containing 666 fails
+	var requests [][]int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ids []int
+		for part := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+			id, _ := strconv.Atoi(part)
+			ids = append(ids, id)
+		}
+		requests = append(requests, ids)
+		if slices.Contains(ids, 666) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(`{"text": "internal error"}`))
+			return
+		}
+		var items []string
+		for _, id := range slices.Backward(ids) {
+			if id < 1000 {
+				items = append(items, fmt.Sprintf(`{"id": %d, "name": "Item %d"}`, id, id))
+			}
+		}
+		if len(items) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "all ids provided are invalid"}`))
+			return
+		}
+		w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+	ctx := context.Background()
+
+	checkRequests := func(name string, expected int) {
+		t.Helper()
+		if len(requests) != expected {
+			t.Errorf("%s: %d requests, expected %d", name, len(requests), expected)
+		}
+		for _, ids := range requests {
+			if len(ids) > maxIDsPerRequest {
+				t.Errorf("%s: a request has %d IDs", name, len(ids))
+			}
+		}
+		requests = nil
+	}
+
+	// 450 IDs take three requests, and come back in the order asked for
+	var ids []int
+	for id := 450; id > 0; id-- {
+		ids = append(ids, id)
+	}
+	items, err := GetByIDs[Item](ctx, client, "/v2/items", ids)
+	checkRequests("450 IDs", 3)
+	if err != nil || len(items) != 450 || items[0].ID != 450 || items[449].ID != 1 {
+		t.Fatalf("got %d items, %v", len(items), err)
+	}
+
+	// No IDs make no request
+	items, err = GetByIDs[Item](ctx, client, "/v2/items", nil)
+	checkRequests("no IDs", 0)
+	if err != nil || items != nil {
+		t.Errorf("with no IDs got %v, %v", items, err)
+	}
+
+	// A part with only unknown IDs is no failure
+	unknown := slices.Clone(ids[:10])
+	for id := 1000; len(unknown) < 2*maxIDsPerRequest+10; id++ {
+		unknown = append(unknown, id)
+	}
+	items, err = GetByIDs[Item](ctx, client, "/v2/items", unknown)
+	checkRequests("unknown part", 3)
+	if err != nil || len(items) != 10 {
+		t.Errorf("with a part of unknown IDs got %d items, %v", len(items), err)
+	}
+
+	// With all of them unknown, it is
+	_, err = GetByIDs[Item](ctx, client, "/v2/items", unknown[10:])
+	checkRequests("all unknown", 2)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("with only unknown IDs got %v", err)
+	}
+
+	// A failed part leaves the others, which GetItems passes on
+	failing := slices.Clone(ids)
+	failing[300] = 666
+	fetched, err := client.GetItems(ctx, failing)
+	checkRequests("failed part", 3)
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("with a failed part got %v", err)
+	}
+	if len(fetched) != 250 || fetched[0].ID != 450 || fetched[len(fetched)-1].ID != 1 {
+		t.Errorf("with a failed part got %d items", len(fetched))
+	}
+	// With the cache loaded, the cached entries and the parts that were
+	// fetched come back along with the error
+	client.dataCache = NewDataCache()
+	if err := client.dataCache.GetItemCache().LoadFromFile(writeJSONLFixture(t,
+		`{"id": 2000, "name": "Cached 2000"}`,
+	)); err != nil {
+		t.Fatal(err)
+	}
+	fetched, err = client.GetItems(ctx, append([]int{2000}, failing...))
+	checkRequests("failed part with cache", 3)
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("with a failed part and a cache got %v", err)
+	}
+	if len(fetched) != 251 || fetched[0].ID != 2000 || fetched[1].ID != 450 || fetched[len(fetched)-1].ID != 1 {
+		t.Errorf("with a failed part and a cache got %d items", len(fetched))
+	}
+}
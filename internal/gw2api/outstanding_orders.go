@@ -0,0 +1,128 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// OrderAgeBuckets are the upper bounds of the age buckets orders are counted
+// in, in ascending order. Orders older than the last bound fall in one more
+// bucket, so OrderSummary.AgeBuckets has one entry more than this.
+var OrderAgeBuckets = []time.Duration{24 * time.Hour, 7 * 24 * time.Hour, 30 * 24 * time.Hour}
+
+// OrderAgeLabels name the age buckets, in the same order as AgeBuckets
+var OrderAgeLabels = []string{"<1d", "1-7d", "7-30d", ">30d"}
+
+// OutstandingOrders is a summary of an account's open trading post orders
+type OutstandingOrders struct {
+	Buys  []*OrderSummary `json:"buys"`  // Sorted by committed coins, highest first
+	Sells []*OrderSummary `json:"sells"` // Sorted by committed coins, highest first
+	// BuyCoins is the coins held in buy orders, SellCoins the value of the
+	// listings at their asking prices before fees
+	BuyCoins  int       `json:"buy_coins"`
+	SellCoins int       `json:"sell_coins"`
+	AsOf      time.Time `json:"as_of"` // When the ages were measured
+}
+
+// OrderSummary is the open orders for one item on one side of the trading post
+type OrderSummary struct {
+	ItemID   int       `json:"item_id"`
+	Name     string    `json:"name,omitempty"` // Empty if the item could not be looked up
+	Orders   int       `json:"orders"`
+	Quantity int       `json:"quantity"`
+	Coins    int       `json:"coins"`  // Price times quantity over every order
+	Oldest   time.Time `json:"oldest"` // When the oldest order was placed
+	// AgeBuckets counts the orders in each of OrderAgeBuckets by age
+	AgeBuckets []int `json:"age_buckets"`
+}
+
+// Age returns how long the oldest order has been open at now
+func (s *OrderSummary) Age(now time.Time) time.Duration {
+	return now.Sub(s.Oldest)
+}
+
+// Stale reports whether the oldest order has been open for at least threshold,
+// which usually means it is priced out of the market
+func (s *OrderSummary) Stale(now time.Time, threshold time.Duration) bool {
+	return threshold > 0 && s.Age(now) >= threshold
+}
+
+// GetOutstandingOrders summarizes the account's open buy and sell orders by
+// item, following pagination. Item names come from the data cache or the API;
+// items that can't be looked up are summarized without a name.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/transactions
+// Scopes: account, tradingpost
+func (c *Client) GetOutstandingOrders(ctx context.Context) (*OutstandingOrders, error) {
+	buys, err := c.GetAllCommerceTransactions(ctx, TransactionsCurrent, TransactionBuys)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get buy orders: %w", err)
+	}
+	sells, err := c.GetAllCommerceTransactions(ctx, TransactionsCurrent, TransactionSells)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sell orders: %w", err)
+	}
+
+	var ids []int
+	for _, transaction := range slices.Concat(buys, sells) {
+		ids = append(ids, transaction.ItemID)
+	}
+	// Names are only for display, so a failed lookup leaves them out
+	items, _ := c.lookupItems(ctx, ids)
+
+	orders := &OutstandingOrders{AsOf: time.Now()}
+	orders.Buys, orders.BuyCoins = summarizeOrders(buys, items, orders.AsOf)
+	orders.Sells, orders.SellCoins = summarizeOrders(sells, items, orders.AsOf)
+	return orders, nil
+}
+
+// summarizeOrders groups transactions by item, sorted by committed coins, and
+// returns the coins committed in total
+func summarizeOrders(transactions []*Transaction, items map[int]*Item, now time.Time) ([]*OrderSummary, int) {
+	byItem := make(map[int]*OrderSummary)
+	var summaries []*OrderSummary
+	total := 0
+	for _, transaction := range transactions {
+		summary, found := byItem[transaction.ItemID]
+		if !found {
+			summary = &OrderSummary{
+				ItemID:     transaction.ItemID,
+				Oldest:     transaction.Created,
+				AgeBuckets: make([]int, len(OrderAgeBuckets)+1),
+			}
+			if item, found := items[transaction.ItemID]; found {
+				summary.Name = item.Name
+			}
+			byItem[transaction.ItemID] = summary
+			summaries = append(summaries, summary)
+		}
+
+		coins := transaction.Price * transaction.Quantity
+		summary.Orders++
+		summary.Quantity += transaction.Quantity
+		summary.Coins += coins
+		total += coins
+		if transaction.Created.Before(summary.Oldest) {
+			summary.Oldest = transaction.Created
+		}
+		summary.AgeBuckets[orderAgeBucket(now.Sub(transaction.Created))]++
+	}
+
+	slices.SortFunc(summaries, func(a, b *OrderSummary) int {
+		return cmp.Or(cmp.Compare(b.Coins, a.Coins), cmp.Compare(a.ItemID, b.ItemID))
+	})
+	return summaries, total
+}
+
+// orderAgeBucket returns the index of the age bucket an order of the given age
+// falls in
+func orderAgeBucket(age time.Duration) int {
+	for i, bound := range OrderAgeBuckets {
+		if age < bound {
+			return i
+		}
+	}
+	return len(OrderAgeBuckets)
+}
@@ -0,0 +1,69 @@
+package gw2api
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGetOutstandingOrders(t *testing.T) {
+	client := newFixtureClient(t, "orders", map[string]string{
+		"/v2/commerce/transactions/current/buys":  "current_buys.json",
+		"/v2/commerce/transactions/current/sells": "current_sells.json",
+		"/v2/items": "items.json",
+	})
+
+	orders, err := client.GetOutstandingOrders(context.Background())
+	if err != nil {
+		t.Fatalf("GetOutstandingOrders: %v", err)
+	}
+	if orders.BuyCoins != 250*63+250*64+100*50 || orders.SellCoins != 21250 {
+		t.Errorf("committed %d in buys and %d in sells", orders.BuyCoins, orders.SellCoins)
+	}
+
+	if len(orders.Buys) != 2 {
+		t.Fatalf("got %d buy summaries, expected 2", len(orders.Buys))
+	}
+	ore := orders.Buys[0]
+	if ore.ItemID != 19699 || ore.Name != "Iron Ore" || ore.Orders != 2 || ore.Quantity != 500 || ore.Coins != 250*63+250*64 {
+		t.Errorf("first buy summary = %+v", ore)
+	}
+	if !ore.Oldest.Equal(time.Date(2024, 3, 20, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("oldest ore order = %s", ore.Oldest)
+	}
+	// The unknown item is still summarized
+	if unknown := orders.Buys[1]; unknown.ItemID != 19721 || unknown.Name != "" || unknown.Coins != 5000 {
+		t.Errorf("second buy summary = %+v", unknown)
+	}
+	if len(orders.Sells) != 1 || orders.Sells[0].Name != "Vial of Powerful Blood" {
+		t.Errorf("sell summaries = %+v", orders.Sells)
+	}
+}
+
+func TestSummarizeOrderAges(t *testing.T) {
+	now := time.Date(2024, 5, 10, 12, 0, 0, 0, time.UTC)
+	transactions := []*Transaction{
+		{ItemID: 1, Price: 10, Quantity: 1, Created: now.Add(-time.Hour)},
+		{ItemID: 1, Price: 10, Quantity: 1, Created: now.Add(-24 * time.Hour)},
+		{ItemID: 1, Price: 10, Quantity: 1, Created: now.Add(-6 * 24 * time.Hour)},
+		{ItemID: 1, Price: 10, Quantity: 1, Created: now.Add(-30 * 24 * time.Hour)},
+		{ItemID: 2, Price: 100, Quantity: 1, Created: now.Add(-2 * time.Hour)},
+	}
+
+	summaries, total := summarizeOrders(transactions, nil, now)
+	if total != 140 || len(summaries) != 2 || summaries[0].ItemID != 2 {
+		t.Fatalf("total %d, summaries %+v", total, summaries)
+	}
+	if buckets := summaries[1].AgeBuckets; !slices.Equal(buckets, []int{1, 2, 0, 1}) {
+		t.Errorf("age buckets = %v, expected [1 2 0 1]", buckets)
+	}
+	if len(OrderAgeLabels) != len(OrderAgeBuckets)+1 {
+		t.Errorf("%d labels for %d buckets", len(OrderAgeLabels), len(OrderAgeBuckets)+1)
+	}
+
+	oldest := summaries[1]
+	if oldest.Age(now) != 30*24*time.Hour || !oldest.Stale(now, 14*24*time.Hour) || oldest.Stale(now, 0) || summaries[0].Stale(now, 14*24*time.Hour) {
+		t.Errorf("age %s, stale flags wrong", oldest.Age(now))
+	}
+}
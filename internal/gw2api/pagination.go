@@ -0,0 +1,70 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+)
+
+// ForEachPage requests endpoint a page at a time, pageSize entries per page,
+// and calls fn with each page in order until the last page or until fn
+// returns an error, which ForEachPage then returns. A pageSize of zero or less
+// requests the API's maximum of 200. Cancelling ctx stops it between pages
+// with the context's error. Endpoints that aren't paged are one page.
+func ForEachPage[T any](ctx context.Context, c *Client, endpoint string, pageSize int, fn func(page []T, p *PaginationResponse) error, options ...RequestOption) error {
+	if pageSize <= 0 {
+		pageSize = maxIDsPerRequest
+	}
+	for page := 0; ; page++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		results, pagination, err := GetPaged[T](ctx, c, endpoint, append(options, WithPage(page), WithPageSize(pageSize))...)
+		if err != nil {
+			return err
+		}
+		if err := fn(results, pagination); err != nil {
+			return err
+		}
+		if pagination == nil || page+1 >= pagination.PageTotal {
+			return nil
+		}
+	}
+}
+
+// GetAllPaged requests every page of endpoint, pageSize entries per page as
+// for ForEachPage, and returns the entries of all of them in order, along with
+// the pagination of the last page, whose Total is the number of entries the
+// API reported. The pagination is nil for endpoints that aren't paged.
+func GetAllPaged[T any](ctx context.Context, c *Client, endpoint string, pageSize int, options ...RequestOption) ([]T, *PaginationResponse, error) {
+	var (
+		all  []T
+		last *PaginationResponse
+	)
+	err := ForEachPage(ctx, c, endpoint, pageSize, func(page []T, p *PaginationResponse) error {
+		all = append(all, page...)
+		last = p
+		return nil
+	}, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return all, last, nil
+}
+
+// CountResults returns how many entries endpoint lists, read from the
+// X-Result-Total header of a single-entry page, without downloading the list
+func CountResults(ctx context.Context, c *Client, endpoint string, options ...RequestOption) (int, error) {
+	opts := &RequestOptions{}
+	for _, opt := range append(options, WithPage(0), WithPageSize(1)) {
+		opt(opts)
+	}
+
+	_, pagination, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return 0, err
+	}
+	if pagination == nil {
+		return 0, fmt.Errorf("%s isn't paged, so it doesn't report a total", endpoint)
+	}
+	return pagination.Total, nil
+}
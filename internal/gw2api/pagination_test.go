@@ -0,0 +1,151 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newPagedClient returns a client for a fake API listing total entries at
+// /v2/things, and the number of requests it has answered
+func newPagedClient(t *testing.T, total int) (*Client, *atomic.Int32) {
+	t.Helper()
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/things" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "not found"}`))
+			return
+		}
+		requests.Add(1)
+		query := r.URL.Query()
+		page, _ := strconv.Atoi(query.Get("page"))
+		size, _ := strconv.Atoi(query.Get("page_size"))
+		things := []Color{}
+		for id := page*size + 1; id <= min((page+1)*size, total); id++ {
+			things = append(things, Color{ID: id})
+		}
+		w.Header().Set("X-Page", strconv.Itoa(page))
+		w.Header().Set("X-Page-Size", strconv.Itoa(size))
+		w.Header().Set("X-Page-Total", strconv.Itoa(max((total+size-1)/size, 1)))
+		w.Header().Set("X-Result-Total", strconv.Itoa(total))
+		json.NewEncoder(w).Encode(things)
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000)), &requests
+}
+
+func TestForEachPage(t *testing.T) {
+	client, requests := newPagedClient(t, 25)
+
+	var ids, pages []int
+	err := ForEachPage(context.Background(), client, "/v2/things", 10, func(page []Color, p *PaginationResponse) error {
+		pages = append(pages, p.Page)
+		for _, color := range page {
+			ids = append(ids, color.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 25 || ids[0] != 1 || ids[24] != 25 {
+		t.Errorf("got %d entries, want 25 in order", len(ids))
+	}
+	if len(pages) != 3 || pages[2] != 2 || requests.Load() != 3 {
+		t.Errorf("pages = %v in %d requests, want 0 to 2 in 3", pages, requests.Load())
+	}
+}
+
+func TestForEachPageStops(t *testing.T) {
+	client, requests := newPagedClient(t, 25)
+
+	stop := errors.New("stop")
+	err := ForEachPage(context.Background(), client, "/v2/things", 10, func(page []Color, p *PaginationResponse) error {
+		return stop
+	})
+	if !errors.Is(err, stop) || requests.Load() != 1 {
+		t.Errorf("err = %v after %d requests, want fn's error after 1", err, requests.Load())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	requests.Store(0)
+	err = ForEachPage(ctx, client, "/v2/things", 10, func(page []Color, p *PaginationResponse) error {
+		cancel()
+		return nil
+	})
+	if !errors.Is(err, context.Canceled) || requests.Load() != 1 {
+		t.Errorf("err = %v after %d requests, want context.Canceled after 1", err, requests.Load())
+	}
+}
+
+func TestGetAllPaged(t *testing.T) {
+	client, requests := newPagedClient(t, 25)
+
+	things, pagination, err := GetAllPaged[Color](context.Background(), client, "/v2/things", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(things) != 25 || things[0].ID != 1 || things[24].ID != 25 || requests.Load() != 3 {
+		t.Errorf("got %d entries in %d requests, want 25 in order in 3", len(things), requests.Load())
+	}
+	if pagination == nil || pagination.Page != 2 || pagination.Total != 25 {
+		t.Errorf("pagination = %+v, want the last page with the total", pagination)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	requests.Store(0)
+	if things, _, err := GetAllPaged[Color](ctx, client, "/v2/things", 10); !errors.Is(err, context.Canceled) || things != nil || requests.Load() != 0 {
+		t.Errorf("cancelled: %d entries, %v after %d requests", len(things), err, requests.Load())
+	}
+}
+
+func TestGetAllAchievements(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pages = append(pages, query.Get("page")+"/"+query.Get("page_size"))
+		page, _ := strconv.Atoi(query.Get("page"))
+		achievements := []Achievement{{ID: page*2 + 1}}
+		if page == 0 {
+			achievements = append(achievements, Achievement{ID: 2})
+		}
+		w.Header().Set("X-Page", strconv.Itoa(page))
+		w.Header().Set("X-Page-Total", "2")
+		w.Header().Set("X-Result-Total", "3")
+		json.NewEncoder(w).Encode(achievements)
+	}))
+	defer server.Close()
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+
+	achievements, err := client.GetAllAchievements(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(achievements) != 3 || achievements[2].ID != 3 || len(pages) != 2 || pages[0] != "/200" || pages[1] != "1/200" {
+		t.Errorf("got %d achievements from pages %v", len(achievements), pages)
+	}
+}
+
+func TestCountResults(t *testing.T) {
+	client, requests := newPagedClient(t, 25)
+
+	total, err := CountResults(context.Background(), client, "/v2/things")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 25 || requests.Load() != 1 {
+		t.Errorf("CountResults = %d in %d requests, want 25 in 1", total, requests.Load())
+	}
+
+	if _, err := CountResults(context.Background(), client, "/v2/missing"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("CountResults(/v2/missing) error = %v, want ErrNotFound", err)
+	}
+}
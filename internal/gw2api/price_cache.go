@@ -0,0 +1,110 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+// PriceCache stores trading post prices for WithPriceCache. The LRU cache in
+// internal/cache satisfies it.
+type PriceCache interface {
+	Get(key string) (any, bool)
+	Set(key string, value any, ttl time.Duration)
+}
+
+// WithPriceCache keeps trading post prices from GetCommercePrice and
+// GetCommercePrices in cache for ttl, so repeated lookups within a short time
+// only request the prices not already held. Like WithBuildCache, only calls
+// without options are cached. A nil cache or a ttl of zero or less turns the
+// cache off, which is the default.
+func WithPriceCache(cache PriceCache, ttl time.Duration) ClientOption {
+	return func(c *Client) {
+		if cache == nil || ttl <= 0 {
+			c.priceCache = nil
+			return
+		}
+		c.priceCache = &priceCache{cache: cache, ttl: ttl}
+	}
+}
+
+// PriceCacheKey is the key a price is stored under in a PriceCache, for
+// programs that persist the cache and need to decode its values as *Price
+func PriceCacheKey(itemID int) string {
+	return "price:" + strconv.Itoa(itemID)
+}
+
+// priceCache is the cache set with WithPriceCache
+type priceCache struct {
+	cache PriceCache
+	ttl   time.Duration
+}
+
+// get returns a copy of the cached price for an item, so callers can't change
+// the cached one
+func (p *priceCache) get(itemID int) (*Price, bool) {
+	value, found := p.cache.Get(PriceCacheKey(itemID))
+	if !found {
+		return nil, false
+	}
+	price, ok := value.(*Price)
+	if !ok || price == nil {
+		return nil, false
+	}
+	clone := *price
+	return &clone, true
+}
+
+// set caches a copy of price
+func (p *priceCache) set(price *Price) {
+	clone := *price
+	p.cache.Set(PriceCacheKey(price.ID), &clone, p.ttl)
+}
+
+// cachedPrices returns the prices for itemIDs in their order, fetching only
+// those not in the cache. Like GetCommercePrices, unknown IDs are left out,
+// and it fails with ErrNotFound only when none of the IDs have a price. When
+// only some requests fail, the prices fetched are cached and returned with
+// the error.
+func (c *Client) cachedPrices(ctx context.Context, itemIDs []int) ([]*Price, error) {
+	byID := make(map[int]*Price, len(itemIDs))
+	var (
+		missing []int
+		partial error
+	)
+	for _, id := range uniqueIDs(itemIDs) {
+		if price, found := c.priceCache.get(id); found {
+			byID[id] = price
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		fetched, err := GetByIDs[Price](ctx, c, "/v2/commerce/prices", missing)
+		switch {
+		case errors.Is(err, ErrNotFound) && len(byID) > 0:
+			// The uncached IDs are unlisted, but the cached ones are still known
+		case err != nil && len(fetched) == 0:
+			return nil, err
+		case err != nil:
+			partial = err
+		}
+		for i := range fetched {
+			price := &fetched[i]
+			c.priceCache.set(price)
+			byID[price.ID] = price
+		}
+	}
+
+	prices := make([]*Price, 0, len(byID))
+	seen := make(map[int]bool, len(byID))
+	for _, id := range itemIDs {
+		if price, found := byID[id]; found && !seen[id] {
+			seen[id] = true
+			prices = append(prices, price)
+		}
+	}
+	return prices, partial
+}
@@ -0,0 +1,103 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mapPriceCache is a PriceCache that ignores expiry
+type mapPriceCache struct {
+	mutex  sync.Mutex
+	values map[string]any
+}
+
+func (m *mapPriceCache) Get(key string) (any, bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	value, found := m.values[key]
+	return value, found
+}
+
+func (m *mapPriceCache) Set(key string, value any, ttl time.Duration) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.values[key] = value
+}
+
+func TestPriceCache(t *testing.T) {
+	var requested []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("ids")
+		if ids == "" {
+			ids = r.URL.Query().Get("id")
+		}
+		requested = append(requested, ids)
+
+		var prices []string
+		for id := range strings.SplitSeq(ids, ",") {
+			if id != "3" { // Not listed
+				prices = append(prices, fmt.Sprintf(`{"id": %s, "sells": {"quantity": 1, "unit_price": %s00}}`, id, id))
+			}
+		}
+		if len(prices) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "all ids provided are invalid"}`))
+			return
+		}
+		if r.URL.Query().Has("id") {
+			w.Write([]byte(prices[0]))
+			return
+		}
+		w.Write([]byte("[" + strings.Join(prices, ",") + "]"))
+	}))
+	defer server.Close()
+
+	cache := &mapPriceCache{values: make(map[string]any)}
+	client := NewClient(WithRetries(0), WithRateLimit(1000), WithPriceCache(cache, time.Minute))
+	client.baseURL = server.URL
+	ctx := context.Background()
+
+	if _, err := client.GetCommercePrices(ctx, []int{1, 2}); err != nil {
+		t.Fatalf("GetCommercePrices: %v", err)
+	}
+	prices, err := client.GetCommercePrices(ctx, []int{2, 3, 1, 2})
+	if err != nil {
+		t.Fatalf("GetCommercePrices with cached prices: %v", err)
+	}
+	var ids []int
+	for _, price := range prices {
+		ids = append(ids, price.ID)
+	}
+	if !slices.Equal(ids, []int{2, 1}) {
+		t.Errorf("IDs = %v, expected the requested order without duplicates or the unlisted item", ids)
+	}
+	if price, err := client.GetCommercePrice(ctx, 1); err != nil || price.Sells.UnitPrice != 100 {
+		t.Errorf("GetCommercePrice(1) = %+v, %v, expected the cached price", price, err)
+	}
+
+	// Only the first call and the uncached, unlisted item reached the API
+	if expected := []string{"1,2", "3"}; !slices.Equal(requested, expected) {
+		t.Errorf("requested %q, expected %q", requested, expected)
+	}
+
+	// Callers get copies, so changing one doesn't change the cache
+	prices[0].Sells.UnitPrice = 1
+	if price, _ := client.GetCommercePrice(ctx, 2); price.Sells.UnitPrice != 200 {
+		t.Errorf("cached price changed to %d", price.Sells.UnitPrice)
+	}
+
+	// Options bypass the cache
+	if _, err := client.GetCommercePrices(ctx, []int{1}, WithRequestRetries(0)); err != nil {
+		t.Fatalf("GetCommercePrices with options: %v", err)
+	}
+	if len(requested) != 3 {
+		t.Errorf("made %d requests, expected a call with options to skip the cache", len(requested))
+	}
+}
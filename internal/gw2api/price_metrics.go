@@ -0,0 +1,31 @@
+package gw2api
+
+// PriceMetrics are the figures traders read off an item's best prices
+type PriceMetrics struct {
+	Spread        int     `json:"spread"`         // Sell price less buy price
+	SpreadPercent float64 `json:"spread_percent"` // Spread as a percentage of the sell price
+
+	// FlipProfit is what buying one unit with a buy order at the buy price and
+	// listing it at the sell price earns after trading post fees. It is zero
+	// when either side of the book is empty.
+	FlipProfit int `json:"flip_profit"`
+
+	// DemandSupplyRatio is the units wanted by buy orders per unit listed for
+	// sale, or zero when nothing is listed
+	DemandSupplyRatio float64 `json:"demand_supply_ratio"`
+}
+
+// Metrics returns the spread, flip profit and demand/supply ratio of a price
+func (p *Price) Metrics() PriceMetrics {
+	var metrics PriceMetrics
+	buy, sell := p.Buys.UnitPrice, p.Sells.UnitPrice
+	if buy > 0 && sell > 0 {
+		metrics.Spread = sell - buy
+		metrics.FlipProfit = sell - TradingPostFees(sell) - buy
+		metrics.SpreadPercent = float64(metrics.Spread) / float64(sell) * 100
+	}
+	if p.Sells.Quantity > 0 {
+		metrics.DemandSupplyRatio = float64(p.Buys.Quantity) / float64(p.Sells.Quantity)
+	}
+	return metrics
+}
@@ -0,0 +1,34 @@
+package gw2api
+
+import (
+	"math"
+	"testing"
+)
+
+func TestPriceMetrics(t *testing.T) {
+	price := &Price{
+		ID:    19721,
+		Buys:  PriceInfo{Quantity: 3000, UnitPrice: 800},
+		Sells: PriceInfo{Quantity: 1000, UnitPrice: 1000},
+	}
+	metrics := price.Metrics()
+	// Selling at 1000 costs 50 + 100 in fees, leaving 850 for an 800 buy
+	if metrics.Spread != 200 || metrics.FlipProfit != 50 {
+		t.Errorf("spread and flip profit = %d, %d, expected 200, 50", metrics.Spread, metrics.FlipProfit)
+	}
+	if math.Abs(metrics.SpreadPercent-20) > 1e-9 || math.Abs(metrics.DemandSupplyRatio-3) > 1e-9 {
+		t.Errorf("spread percent and ratio = %v, %v, expected 20, 3", metrics.SpreadPercent, metrics.DemandSupplyRatio)
+	}
+
+	// A narrow spread loses money to the fees
+	price.Buys.UnitPrice = 950
+	if profit := price.Metrics().FlipProfit; profit != -100 {
+		t.Errorf("flip profit = %d, expected -100", profit)
+	}
+
+	// With nothing listed there is no spread or ratio
+	empty := &Price{ID: 1, Buys: PriceInfo{Quantity: 10, UnitPrice: 5}}
+	if metrics := empty.Metrics(); metrics != (PriceMetrics{}) {
+		t.Errorf("metrics = %+v, expected none", metrics)
+	}
+}
@@ -0,0 +1,105 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// priceSnapshotWorkers is how many price requests StreamCommercePrices keeps
+// in flight. The client's rate limiter still decides how fast they are sent;
+// this only keeps slow responses from holding up the next ones.
+const priceSnapshotWorkers = 4
+
+// StreamCommercePrices fetches the price of every item on the trading post,
+// 200 at a time, and calls fn with each batch as it arrives. Batches arrive
+// in no particular order, but fn is never called for two at once. An error
+// from fn or from a request stops the fetch and is returned; the batches
+// already passed to fn stay delivered. Items that leave the trading post
+// between listing the IDs and fetching their prices are left out.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/prices
+// Scopes: None (public endpoint)
+func (c *Client) StreamCommercePrices(ctx context.Context, fn func(batch []*Price) error, options ...RequestOption) error {
+	ids, err := c.GetCommercePriceIDs(ctx, options...)
+	if err != nil {
+		return fmt.Errorf("failed to list the items with prices: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		prices []Price
+		err    error
+	}
+	jobs := make(chan []int)
+	results := make(chan result)
+	var wg sync.WaitGroup
+	for range min(priceSnapshotWorkers, (len(ids)+maxIDsPerRequest-1)/maxIDsPerRequest) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chunk := range jobs {
+				prices, err := GetByIDs[Price](ctx, c, "/v2/commerce/prices", chunk, options...)
+				if errors.Is(err, ErrNotFound) {
+					err = nil // Every item in the batch has left the trading post
+				}
+				select {
+				case results <- result{prices, err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		defer close(jobs)
+		for chunk := range slices.Chunk(ids, maxIDsPerRequest) {
+			select {
+			case jobs <- chunk:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	for result := range results {
+		if result.err != nil {
+			return fmt.Errorf("failed to get prices: %w", result.err)
+		}
+		batch := make([]*Price, len(result.prices))
+		for i := range result.prices {
+			batch[i] = &result.prices[i]
+		}
+		if err := fn(batch); err != nil {
+			return err
+		}
+	}
+	// The workers also stop when ctx is cancelled by the caller
+	return ctx.Err()
+}
+
+// GetAllCommercePrices returns the price of every item on the trading post,
+// sorted by item ID. It holds all of them at once; StreamCommercePrices can
+// handle them a batch at a time instead.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/commerce/prices
+// Scopes: None (public endpoint)
+func (c *Client) GetAllCommercePrices(ctx context.Context, options ...RequestOption) ([]*Price, error) {
+	var prices []*Price
+	err := c.StreamCommercePrices(ctx, func(batch []*Price) error {
+		prices = append(prices, batch...)
+		return nil
+	}, options...)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(prices, func(a, b *Price) int { return cmp.Compare(a.ID, b.ID) })
+	return prices, nil
+}
@@ -0,0 +1,106 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// newPriceSnapshotClient returns a client for a fake API listing prices for
+// items 1 to total, with no price for item 13 and a failure for any request
+// including item fail
+func newPriceSnapshotClient(t *testing.T, total, fail int) (*Client, func() (requests, maxIDs, maxInFlight int)) {
+	t.Helper()
+	var (
+		mutex                                 sync.Mutex
+		requests, maxIDs, inFlight, maxFlight int
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests++
+		inFlight++
+		maxFlight = max(maxFlight, inFlight)
+		mutex.Unlock()
+		defer func() {
+			mutex.Lock()
+			inFlight--
+			mutex.Unlock()
+		}()
+
+		query := r.URL.Query().Get("ids")
+		if query == "" {
+			ids := make([]string, total)
+			for i := range ids {
+				ids[i] = strconv.Itoa(i + 1)
+			}
+			w.Write([]byte("[" + strings.Join(ids, ",") + "]"))
+			return
+		}
+		var prices []string
+		parts := strings.Split(query, ",")
+		mutex.Lock()
+		maxIDs = max(maxIDs, len(parts))
+		mutex.Unlock()
+		for _, part := range parts {
+			id, _ := strconv.Atoi(part)
+			if id == fail {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"text": "bad request"}`))
+				return
+			}
+			if id != 13 {
+				prices = append(prices, fmt.Sprintf(`{"id": %d, "buys": {"unit_price": %d}, "sells": {"unit_price": %d}}`, id, id, 2*id))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(prices, ",") + "]"))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+	return client, func() (int, int, int) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return requests, maxIDs, maxFlight
+	}
+}
+
+func TestGetAllCommercePrices(t *testing.T) {
+	client, stats := newPriceSnapshotClient(t, 1050, 0)
+
+	prices, err := client.GetAllCommercePrices(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prices) != 1049 || prices[0].ID != 1 || prices[12].ID != 14 || prices[1048].ID != 1050 || prices[1048].Sells.UnitPrice != 2100 {
+		t.Errorf("got %d prices from %d to %d", len(prices), prices[0].ID, prices[len(prices)-1].ID)
+	}
+	requests, maxIDs, maxInFlight := stats()
+	if requests != 7 || maxIDs != maxIDsPerRequest || maxInFlight > priceSnapshotWorkers {
+		t.Errorf("%d requests of up to %d IDs, %d at once; expected the list and 6 of up to 200, at most %d at once", requests, maxIDs, maxInFlight, priceSnapshotWorkers)
+	}
+}
+
+func TestStreamCommercePricesStops(t *testing.T) {
+	client, _ := newPriceSnapshotClient(t, 1050, 0)
+	stop := errors.New("disk full")
+	batches := 0
+	err := client.StreamCommercePrices(context.Background(), func(batch []*Price) error {
+		batches++
+		return stop
+	})
+	if !errors.Is(err, stop) || batches != 1 {
+		t.Errorf("err = %v after %d batches, expected fn's error after 1", err, batches)
+	}
+
+	client, _ = newPriceSnapshotClient(t, 1050, 700)
+	var httpErr HTTPError
+	if _, err := client.GetAllCommercePrices(context.Background()); !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadRequest {
+		t.Errorf("with a failing batch got %v", err)
+	}
+}
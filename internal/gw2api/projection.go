@@ -0,0 +1,92 @@
+package gw2api
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// jsonFieldIndexes caches the JSON name -> struct field index mapping per type
+var jsonFieldIndexes sync.Map // reflect.Type -> map[string]int
+
+// fieldIndexes returns the JSON name -> field index mapping for a struct type
+func fieldIndexes(t reflect.Type) map[string]int {
+	if cached, ok := jsonFieldIndexes.Load(t); ok {
+		return cached.(map[string]int)
+	}
+
+	indexes := make(map[string]int, t.NumField())
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" || !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		indexes[name] = i
+	}
+
+	jsonFieldIndexes.Store(t, indexes)
+	return indexes
+}
+
+// project returns a shallow copy of value containing only the named JSON fields.
+// The "id" field is always kept. Unknown field names are ignored. Slices and
+// pointers in the copy still share memory with the original.
+func project[T any](value *T, fields []string) *T {
+	if value == nil {
+		return nil
+	}
+
+	result := new(T)
+	src := reflect.ValueOf(value).Elem()
+	dst := reflect.ValueOf(result).Elem()
+	indexes := fieldIndexes(src.Type())
+
+	if i, ok := indexes["id"]; ok {
+		dst.Field(i).Set(src.Field(i))
+	}
+	for _, name := range fields {
+		if i, ok := indexes[name]; ok {
+			dst.Field(i).Set(src.Field(i))
+		}
+	}
+
+	return result
+}
+
+// wantsAnyField reports whether a WithFields projection keeps any of names.
+// An empty projection keeps every field.
+func wantsAnyField(fields []string, names ...string) bool {
+	if len(fields) == 0 {
+		return true
+	}
+	for _, name := range names {
+		if slices.Contains(fields, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// shapeItems applies lean trimming or WithFields projections to items, which
+// must be copies when there is no projection, as they are trimmed in place
+func (c *Client) shapeItems(items []*Item, opts *RequestOptions) []*Item {
+	if len(opts.Fields) == 0 {
+		if c.leanItems {
+			for _, item := range items {
+				trimItem(item)
+			}
+		}
+		return items
+	}
+
+	projected := make([]*Item, len(items))
+	for i, item := range items {
+		projected[i] = project(item, opts.Fields)
+	}
+	return projected
+}
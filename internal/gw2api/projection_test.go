@@ -0,0 +1,228 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestProject(t *testing.T) {
+	item := &Item{
+		ID:          42,
+		Name:        "Mithril Ore",
+		Description: "Refine into ingots",
+		ChatLink:    "[&AgH/TQAA]",
+		Flags:       []string{"NoSell"},
+	}
+
+	got := project(item, []string{"name", "flags", "not_a_field"})
+	if got == item {
+		t.Fatal("project returned the original pointer")
+	}
+	if got.ID != 42 || got.Name != "Mithril Ore" || len(got.Flags) != 1 {
+		t.Errorf("projected fields missing: %+v", got)
+	}
+	if got.Description != "" || got.ChatLink != "" {
+		t.Errorf("unrequested fields kept: %+v", got)
+	}
+
+	got.Name = "changed"
+	if item.Name != "Mithril Ore" {
+		t.Error("modifying the projection changed the original")
+	}
+
+	if project[Item](nil, []string{"name"}) != nil {
+		t.Error("project(nil) should return nil")
+	}
+}
+
+// writeItemsDataDir writes n generated items to an items.json in a temp data directory
+func writeItemsDataDir(tb testing.TB, n int) string {
+	tb.Helper()
+	dir := tb.TempDir()
+	file, err := os.Create(filepath.Join(dir, CacheKindItems.FileName()))
+	if err != nil {
+		tb.Fatalf("failed to create items file: %v", err)
+	}
+	defer file.Close()
+
+	description := strings.Repeat("A long flavour text description. ", 8)
+	encoder := json.NewEncoder(file)
+	for id := 1; id <= n; id++ {
+		item := Item{
+			ID:          id,
+			Name:        fmt.Sprintf("Item %d", id),
+			ChatLink:    fmt.Sprintf("[&AgH%08d]", id),
+			Description: description,
+			Type:        "CraftingMaterial",
+			Rarity:      "Basic",
+			Flags:       []string{"NoSell"},
+		}
+		if err := encoder.Encode(&item); err != nil {
+			tb.Fatalf("failed to encode item: %v", err)
+		}
+	}
+	return dir
+}
+
+func TestGetItemsWithFieldsFromCache(t *testing.T) {
+	client := NewClient(WithDataCache(writeItemsDataDir(t, 10)))
+	ctx := context.Background()
+
+	items, err := client.GetItems(ctx, []int{1, 2, 3}, WithFields("name"))
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	if len(items) != 3 || items[0].Name != "Item 1" || items[0].Description != "" {
+		t.Fatalf("unexpected projection: %+v", items[0])
+	}
+
+	items[0].Name = "changed"
+	cached, _ := client.DataCache().GetItemCache().GetByID(1)
+	if cached.Name != "Item 1" {
+		t.Error("modifying a projected item changed the cache entry")
+	}
+}
+
+func TestLeanItems(t *testing.T) {
+	// Lean mode applies regardless of option order
+	client := NewClient(WithLeanItems(), WithDataCache(writeItemsDataDir(t, 10)))
+
+	items, err := client.GetItems(context.Background(), []int{1, 2})
+	if err != nil {
+		t.Fatalf("GetItems: %v", err)
+	}
+	for _, item := range items {
+		if item.Description != "" || item.ChatLink != "" {
+			t.Errorf("item %d kept heavy fields in lean mode", item.ID)
+		}
+		if item.Name == "" {
+			t.Errorf("item %d lost its name in lean mode", item.ID)
+		}
+	}
+
+	dc := client.DataCache()
+	dc.SetPersistRefreshes(true)
+	if err := dc.RefreshIDs(context.Background(), client, CacheKindItems, []int{1}); err == nil {
+		t.Error("persisting a lean item cache should be refused")
+	}
+}
+
+func TestLeanItemsSharedCache(t *testing.T) {
+	dc, err := LoadDataCache(writeItemsDataDir(t, 10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	full := NewClient(WithSharedDataCache(dc))
+	lean := NewClient(WithSharedDataCache(dc), WithLeanItems())
+	derived := full.With(WithLeanItems())
+	ctx := context.Background()
+
+	// Only the lean clients trim, and the shared items are left whole
+	var wg sync.WaitGroup
+	for _, client := range []*Client{full, lean, derived, full} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := client.GetItems(ctx, []int{1, 2})
+			if err != nil {
+				t.Errorf("GetItems: %v", err)
+				return
+			}
+			for _, item := range items {
+				if trimmed := item.Description == "" && item.ChatLink == ""; trimmed != client.leanItems {
+					t.Errorf("item %d: trimmed = %v with lean = %v", item.ID, trimmed, client.leanItems)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	if stored, _ := dc.GetItemCache().GetByIDRef(1); stored.Description == "" || stored.ChatLink == "" {
+		t.Errorf("a lean client trimmed the shared item: %+v", stored)
+	}
+
+	// A lean client can still ask for the heavy fields
+	items, err := lean.GetItems(ctx, []int{1}, WithFields("description"))
+	if err != nil || len(items) != 1 || items[0].Description == "" {
+		t.Errorf("lean GetItems with description = %+v, %v", items, err)
+	}
+}
+
+const benchmarkItemCount = 50000
+
+// liveHeap returns the live heap size after a full collection
+func liveHeap() uint64 {
+	var stats runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&stats)
+	return stats.HeapAlloc
+}
+
+// BenchmarkLoadItemCache reports the heap freed when the last loaded client is
+// released, which is the memory a client and its cache hold and where lean
+// mode saves; allocations during decoding are the same.
+func BenchmarkLoadItemCache(b *testing.B) {
+	dir := writeItemsDataDir(b, benchmarkItemCount)
+
+	for _, bench := range []struct {
+		name    string
+		options []ClientOption
+	}{
+		{"full", []ClientOption{WithDataCache(dir)}},
+		{"lean", []ClientOption{WithDataCache(dir), WithLeanItems()}},
+	} {
+		b.Run(bench.name, func(b *testing.B) {
+			b.ReportAllocs()
+			var client *Client
+			for b.Loop() {
+				client = NewClient(bench.options...)
+			}
+
+			held := liveHeap()
+			runtime.KeepAlive(client)
+			released := liveHeap()
+			// The heap can grow between the samples, so subtract as floats
+			b.ReportMetric((float64(held)-float64(released))/(1<<20), "client-MB")
+		})
+	}
+}
+
+func BenchmarkGetItemsCached(b *testing.B) {
+	client := NewClient(WithDataCache(writeItemsDataDir(b, benchmarkItemCount)))
+	ctx := context.Background()
+
+	ids := make([]int, 200)
+	for i := range ids {
+		ids[i] = i*(benchmarkItemCount/len(ids)) + 1
+	}
+
+	b.Run("full", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := client.GetItems(ctx, ids); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("ref", func(b *testing.B) {
+		// Shared pointers, the cost floor that copying trades against
+		b.ReportAllocs()
+		for b.Loop() {
+			client.DataCache().GetItemCache().GetByIDsRef(ids)
+		}
+	})
+	b.Run("projected", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			if _, err := client.GetItems(ctx, ids, WithFields("name", "icon", "rarity")); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -1,6 +1,12 @@
 package gw2api
 
-import "time"
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+	"time"
+)
 
 // PvPAmulet represents a PvP amulet
 type PvPAmulet struct {
@@ -241,29 +247,84 @@ type PvPStats struct {
 
 // PvPStatsAggregate represents aggregate PvP stats
 type PvPStatsAggregate struct {
-	Wins   int `json:"wins"`
-	Losses int `json:"losses"`
+	Wins       int `json:"wins"`
+	Losses     int `json:"losses"`
 	Desertions int `json:"desertions"`
-	Byes   int `json:"byes"`
-	Forfeits int `json:"forfeits"`
+	Byes       int `json:"byes"`
+	Forfeits   int `json:"forfeits"`
 }
 
 // PvPStatsProfession represents profession-specific PvP stats
-type PvPStatsProfession struct {
-	Wins   int `json:"wins"`
-	Losses int `json:"losses"`
-	Desertions int `json:"desertions"`
-	Byes   int `json:"byes"`
-	Forfeits int `json:"forfeits"`
-}
+type PvPStatsProfession = PvPStatsAggregate
 
 // PvPStatsLadder represents ladder-specific PvP stats
-type PvPStatsLadder struct {
-	Wins   int `json:"wins"`
-	Losses int `json:"losses"`
-	Desertions int `json:"desertions"`
-	Byes   int `json:"byes"`
-	Forfeits int `json:"forfeits"`
+type PvPStatsLadder = PvPStatsAggregate
+
+// Games returns the number of matches played. Desertions and forfeits count as
+// lost games, while byes are not games at all.
+func (s PvPStatsAggregate) Games() int {
+	return s.Wins + s.Losses + s.Desertions + s.Forfeits
+}
+
+// WinRate returns the fraction of games won, from 0 to 1, or 0 if no games were played
+func (s PvPStatsAggregate) WinRate() float64 {
+	games := s.Games()
+	if games == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(games)
+}
+
+// PvPWinRate is the record for one profession or ladder, named by its key in PvPStats
+type PvPWinRate struct {
+	Name string `json:"name"`
+	PvPStatsAggregate
+	WinRate float64 `json:"win_rate"`
+}
+
+// WinRate returns the account's overall win rate
+func (s *PvPStats) WinRate() float64 {
+	return s.Aggregate.WinRate()
+}
+
+// ProfessionWinRates returns the record for each profession, highest win rate
+// first. Ties are broken by games played, then by name.
+func (s *PvPStats) ProfessionWinRates() []PvPWinRate {
+	return sortedWinRates(s.Professions)
+}
+
+// LadderWinRates returns the record for each ladder, such as "ranked" and
+// "unranked", highest win rate first
+func (s *PvPStats) LadderWinRates() []PvPWinRate {
+	return sortedWinRates(s.Ladders)
+}
+
+// TopProfession returns the profession with the best win rate. Professions that
+// have never played a game are skipped; found is false if none have.
+func (s *PvPStats) TopProfession() (top PvPWinRate, found bool) {
+	for _, rate := range s.ProfessionWinRates() {
+		if rate.Games() > 0 {
+			return rate, true
+		}
+	}
+	return PvPWinRate{}, false
+}
+
+func sortedWinRates(stats map[string]PvPStatsAggregate) []PvPWinRate {
+	rates := make([]PvPWinRate, 0, len(stats))
+	for name, record := range stats {
+		rates = append(rates, PvPWinRate{Name: name, PvPStatsAggregate: record, WinRate: record.WinRate()})
+	}
+	slices.SortFunc(rates, func(a, b PvPWinRate) int {
+		if c := cmp.Compare(b.WinRate, a.WinRate); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(b.Games(), a.Games()); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+	return rates
 }
 
 // PvPSeasonLeaderboardEntries represents the actual leaderboard data
@@ -279,7 +340,7 @@ type PvPLeaderboardEntry struct {
 	Team        string                `json:"team,omitempty"`
 	TeamID      int                   `json:"team_id,omitempty"`
 	Rank        int                   `json:"rank"`
-	Date        string                `json:"date"`
+	Date        time.Time             `json:"date"`
 	Scores      []PvPLeaderboardScore `json:"scores"`
 }
 
@@ -287,4 +348,145 @@ type PvPLeaderboardEntry struct {
 type PvPLeaderboardScore struct {
 	ID    string `json:"id"`
 	Value int    `json:"value"`
-}
\ No newline at end of file
+}
+
+// GetPvP returns PvP information.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp
+// Scopes: pvp
+func (c *Client) GetPvP(ctx context.Context, options ...RequestOption) (*PvPStats, error) {
+	return GetSingle[PvPStats](ctx, c, "/v2/pvp", options...)
+}
+
+// GetPvPAmuletIDs returns all PvP amulet IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/amulets
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPAmuletIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/pvp/amulets", options...)
+}
+
+// GetPvPAmulet returns a specific PvP amulet by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/amulets
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPAmulet(ctx context.Context, id int, options ...RequestOption) (*PvPAmulet, error) {
+	return GetByID[PvPAmulet](ctx, c, "/v2/pvp/amulets", id, options...)
+}
+
+// GetPvPGames returns PvP games.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/games
+// Scopes: pvp
+func (c *Client) GetPvPGames(ctx context.Context, options ...RequestOption) ([]PvPGame, error) {
+	return GetAll[PvPGame](ctx, c, "/v2/pvp/games", options...)
+}
+
+// GetPvPHeroIDs returns all PvP hero IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/heroes
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPHeroIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/pvp/heroes", options...)
+}
+
+// GetPvPHero returns a specific PvP hero by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/heroes
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPHero(ctx context.Context, id string, options ...RequestOption) (*PvPHero, error) {
+	return GetSingle[PvPHero](ctx, c, "/v2/pvp/heroes/"+id, options...)
+}
+
+// GetPvPRankIDs returns all PvP rank IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/ranks
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPRankIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/pvp/ranks", options...)
+}
+
+// GetPvPRank returns a specific PvP rank by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/ranks
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPRank(ctx context.Context, id int, options ...RequestOption) (*PvPRank, error) {
+	return GetByID[PvPRank](ctx, c, "/v2/pvp/ranks", id, options...)
+}
+
+// GetPvPRewardTrackIDs returns all PvP reward track IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/rewardtracks
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPRewardTrackIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/pvp/rewardtracks", options...)
+}
+
+// GetPvPRewardTrack returns a specific PvP reward track by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/rewardtracks
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPRewardTrack(ctx context.Context, id int, options ...RequestOption) (*PvPRewardTrack, error) {
+	return GetByID[PvPRewardTrack](ctx, c, "/v2/pvp/rewardtracks", id, options...)
+}
+
+// GetPvPRuneIDs returns all PvP rune IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/runes
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPRuneIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/pvp/runes", options...)
+}
+
+// GetPvPRune returns a specific PvP rune by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/runes
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPRune(ctx context.Context, id int, options ...RequestOption) (*PvPRune, error) {
+	return GetByID[PvPRune](ctx, c, "/v2/pvp/runes", id, options...)
+}
+
+// GetPvPSeasonIDs returns all PvP season IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/seasons
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPSeasonIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/pvp/seasons", options...)
+}
+
+// GetPvPSeason returns a specific PvP season by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/seasons
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPSeason(ctx context.Context, id string, options ...RequestOption) (*PvPSeason, error) {
+	return GetSingle[PvPSeason](ctx, c, "/v2/pvp/seasons/"+id, options...)
+}
+
+// GetPvPSeasonLeaderboards returns PvP season leaderboard data.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/seasons/leaderboards
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPSeasonLeaderboards(ctx context.Context, seasonID string, options ...RequestOption) (*PvPSeasonLeaderboardEntries, error) {
+	return GetSingle[PvPSeasonLeaderboardEntries](ctx, c, "/v2/pvp/seasons/"+seasonID+"/leaderboards", options...)
+}
+
+// GetPvPSeasonLeaderboard returns a page of one leaderboard of a PvP season, such
+// as the "ladder" board for region "eu".
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/seasons/leaderboards
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPSeasonLeaderboard(ctx context.Context, seasonID, board, region string, options ...RequestOption) ([]PvPLeaderboardEntry, *PaginationResponse, error) {
+	return GetPaged[PvPLeaderboardEntry](ctx, c, "/v2/pvp/seasons/"+seasonID+"/leaderboards/"+board+"/"+region, options...)
+}
+
+// GetPvPSigilIDs returns all PvP sigil IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/sigils
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPSigilIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/pvp/sigils", options...)
+}
+
+// GetPvPSigil returns a specific PvP sigil by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/sigils
+// Scopes: None (public endpoint)
+func (c *Client) GetPvPSigil(ctx context.Context, id int, options ...RequestOption) (*PvPSigil, error) {
+	return GetByID[PvPSigil](ctx, c, "/v2/pvp/sigils", id, options...)
+}
+
+// GetPvPStandings returns PvP standings.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/standings
+// Scopes: pvp
+func (c *Client) GetPvPStandings(ctx context.Context, options ...RequestOption) (*PvPStandings, error) {
+	return GetSingle[PvPStandings](ctx, c, "/v2/pvp/standings", options...)
+}
+
+// GetPvPStats returns PvP statistics.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/pvp/stats
+// Scopes: pvp
+func (c *Client) GetPvPStats(ctx context.Context, options ...RequestOption) (*PvPStats, error) {
+	return GetSingle[PvPStats](ctx, c, "/v2/pvp/stats", options...)
+}
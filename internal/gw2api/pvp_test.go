@@ -0,0 +1,84 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func decodeTestPvPStats(t *testing.T) *PvPStats {
+	t.Helper()
+	var stats PvPStats
+	decodeStrict(t, "pvp/stats.json", &stats)
+	return &stats
+}
+
+func TestPvPStatsDecode(t *testing.T) {
+	stats := decodeTestPvPStats(t)
+	necromancer := stats.Professions["necromancer"]
+	if necromancer != (PvPStatsProfession{Wins: 3, Losses: 4, Desertions: 2, Byes: 2, Forfeits: 1}) {
+		t.Errorf("necromancer = %+v", necromancer)
+	}
+	if unranked := stats.Ladders["unranked"]; unranked.Byes != 4 || unranked.Forfeits != 1 {
+		t.Errorf("unranked = %+v", unranked)
+	}
+}
+
+func TestPvPWinRateAccounting(t *testing.T) {
+	stats := decodeTestPvPStats(t)
+
+	// Desertions and forfeits are losses, byes are not games
+	if games := stats.Aggregate.Games(); games != 20 {
+		t.Errorf("aggregate games = %d, expected 20", games)
+	}
+	if rate := stats.WinRate(); rate != 12.0/20 {
+		t.Errorf("WinRate() = %v, expected 0.6", rate)
+	}
+	if rate := stats.Ladders["unranked"].WinRate(); rate != 0.4 {
+		t.Errorf("unranked win rate = %v, expected 0.4", rate)
+	}
+
+	var empty PvPStats
+	if rate := empty.WinRate(); rate != 0 {
+		t.Errorf("WinRate() without games = %v, expected 0", rate)
+	}
+}
+
+func TestPvPProfessionWinRates(t *testing.T) {
+	stats := decodeTestPvPStats(t)
+
+	rates := stats.ProfessionWinRates()
+	var names []string
+	for _, rate := range rates {
+		names = append(names, rate.Name)
+	}
+	if len(names) != 3 || names[0] != "guardian" || names[1] != "necromancer" || names[2] != "thief" {
+		t.Fatalf("professions = %v, expected guardian, necromancer, thief", names)
+	}
+	if rates[0].WinRate != 0.9 || rates[1].WinRate != 0.3 {
+		t.Errorf("win rates = %v, %v, expected 0.9 and 0.3", rates[0].WinRate, rates[1].WinRate)
+	}
+	if rates[2].WinRate != 0 || rates[2].Games() != 0 {
+		t.Errorf("thief = %+v, expected no games and a zero win rate", rates[2])
+	}
+
+	top, found := stats.TopProfession()
+	if !found || top.Name != "guardian" {
+		t.Errorf("TopProfession() = %+v, %v, expected guardian", top, found)
+	}
+
+	// Encoded rates keep the record fields alongside the computed rate
+	encoded, err := json.Marshal(rates[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(encoded) != `{"name":"guardian","wins":9,"losses":1,"desertions":0,"byes":2,"forfeits":0,"win_rate":0.9}` {
+		t.Errorf("encoded = %s", encoded)
+	}
+}
+
+func TestPvPTopProfessionWithoutGames(t *testing.T) {
+	stats := PvPStats{Professions: map[string]PvPStatsProfession{"thief": {}, "mesmer": {Byes: 3}}}
+	if top, found := stats.TopProfession(); found {
+		t.Errorf("TopProfession() = %+v, expected none without games", top)
+	}
+}
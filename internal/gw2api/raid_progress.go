@@ -0,0 +1,65 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RaidProgress is an account's raid encounters cleared this week
+type RaidProgress struct {
+	Wings   []RaidWingProgress `json:"wings"`
+	Cleared int                `json:"cleared"`
+	Total   int                `json:"total"`
+	// ResetsAt is the next weekly reset, when every encounter counts again
+	ResetsAt time.Time `json:"resets_at"`
+}
+
+// RaidWingProgress is the encounters of one raid wing
+type RaidWingProgress struct {
+	Raid       string                  `json:"raid"`
+	ID         string                  `json:"id"`
+	Encounters []RaidEncounterProgress `json:"encounters"`
+}
+
+// RaidEncounterProgress is a raid encounter and whether it was cleared since
+// the weekly reset
+type RaidEncounterProgress struct {
+	RaidEncounter
+	Done bool `json:"done"`
+}
+
+// GetRaidProgress returns every raid encounter in API order with the ones the
+// account cleared since the weekly reset marked done
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/account/raids
+// Scopes: account, progression
+func (c *Client) GetRaidProgress(ctx context.Context, now time.Time) (*RaidProgress, error) {
+	raids, err := c.GetAllRaids(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get raids: %w", err)
+	}
+	cleared, err := c.GetAccountRaids(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account raids: %w", err)
+	}
+	done := make(map[string]bool, len(cleared))
+	for _, id := range cleared {
+		done[id] = true
+	}
+
+	progress := &RaidProgress{ResetsAt: NextWeeklyReset(now)}
+	for _, raid := range raids {
+		for _, wing := range raid.Wings {
+			wingProgress := RaidWingProgress{Raid: raid.ID, ID: wing.ID}
+			for _, event := range wing.Events {
+				wingProgress.Encounters = append(wingProgress.Encounters, RaidEncounterProgress{RaidEncounter: event, Done: done[event.ID]})
+				progress.Total++
+				if done[event.ID] {
+					progress.Cleared++
+				}
+			}
+			progress.Wings = append(progress.Wings, wingProgress)
+		}
+	}
+	return progress, nil
+}
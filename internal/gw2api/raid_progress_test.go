@@ -0,0 +1,38 @@
+package gw2api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestGetRaidProgress(t *testing.T) {
+	client := newFixtureClient(t, "raids", map[string]string{
+		"/v2/raids":         "raids.json",
+		"/v2/account/raids": "account_raids.json",
+	})
+
+	progress, err := client.GetRaidProgress(context.Background(), utc("2024-03-13T12:00:00Z"))
+	if err != nil {
+		t.Fatalf("GetRaidProgress: %v", err)
+	}
+	if progress.Cleared != 4 || progress.Total != 7 || len(progress.Wings) != 2 {
+		t.Fatalf("cleared %d of %d in %d wings, expected 4 of 7 in 2", progress.Cleared, progress.Total, len(progress.Wings))
+	}
+	if !progress.ResetsAt.Equal(utc("2024-03-18T07:30:00Z")) {
+		t.Errorf("ResetsAt = %s, expected the next Monday 07:30 UTC", progress.ResetsAt)
+	}
+
+	wing := progress.Wings[1]
+	if wing.Raid != "forsaken_thicket" || wing.ID != "salvation_pass" {
+		t.Errorf("second wing = %s/%s", wing.Raid, wing.ID)
+	}
+	var done []string
+	for _, encounter := range wing.Encounters {
+		if encounter.Done {
+			done = append(done, encounter.ID)
+		}
+	}
+	if len(done) != 1 || done[0] != "slothasor" {
+		t.Errorf("done in %s = %v, expected [slothasor]", wing.ID, done)
+	}
+}
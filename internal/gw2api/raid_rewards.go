@@ -0,0 +1,134 @@
+package gw2api
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// RaidRewards is a table of what the first clear of each raid encounter in a
+// week is worth
+type RaidRewards struct {
+	Source     string                `json:"source"` // Where the values come from
+	Encounters []RaidEncounterReward `json:"encounters"`
+}
+
+// RaidEncounterReward is the reward for the weekly clear of one encounter
+type RaidEncounterReward struct {
+	ID              string `json:"id"`    // Encounter ID as in /v2/raids, e.g. "vale_guardian"
+	Coins           Coins  `json:"coins"` // Coin, plus the vendor value of the chest's other drops
+	MagnetiteShards int    `json:"magnetite_shards,omitempty"`
+	GaetingCrystals int    `json:"gaeting_crystals,omitempty"`
+}
+
+// ShardRates values the currencies raids award, which can't be sold, in coin
+// per shard or crystal. A zero rate leaves the currency out of the value.
+type ShardRates struct {
+	MagnetiteShard Coins `json:"magnetite_shard"`
+	GaetingCrystal Coins `json:"gaeting_crystal"`
+}
+
+// Value returns the reward in coin, with shards and crystals at rates
+func (r RaidEncounterReward) Value(rates ShardRates) Coins {
+	return r.Coins + Coins(r.MagnetiteShards)*rates.MagnetiteShard + Coins(r.GaetingCrystals)*rates.GaetingCrystal
+}
+
+//go:embed raid_rewards.json
+var defaultRaidRewards []byte
+
+// DefaultRaidRewards returns an estimate of each raid encounter's weekly
+// reward, keyed by encounter ID. Replace it with ReadRaidRewards to use other
+// values.
+func DefaultRaidRewards() map[string]RaidEncounterReward {
+	rewards, err := ReadRaidRewards(bytes.NewReader(defaultRaidRewards))
+	if err != nil {
+		panic("invalid default raid rewards: " + err.Error())
+	}
+	byID := make(map[string]RaidEncounterReward, len(rewards.Encounters))
+	for _, reward := range rewards.Encounters {
+		byID[reward.ID] = reward
+	}
+	return byID
+}
+
+// ReadRaidRewards reads a reward table in the format of DefaultRaidRewards: a
+// JSON object with a "source" string and an "encounters" array, each entry
+// having the encounter "id", its "coins" in copper and optionally the
+// "magnetite_shards" and "gaeting_crystals" it awards. Each encounter must
+// have an ID and be listed once.
+func ReadRaidRewards(r io.Reader) (*RaidRewards, error) {
+	var rewards RaidRewards
+	if err := json.NewDecoder(r).Decode(&rewards); err != nil {
+		return nil, fmt.Errorf("invalid raid rewards: %w", err)
+	}
+	seen := make(map[string]bool, len(rewards.Encounters))
+	for i, reward := range rewards.Encounters {
+		if reward.ID == "" {
+			return nil, fmt.Errorf("raid reward %d: needs an id", i+1)
+		}
+		if seen[reward.ID] {
+			return nil, fmt.Errorf("raid reward %d: %s is listed twice", i+1, reward.ID)
+		}
+		if reward.Coins < 0 || reward.MagnetiteShards < 0 || reward.GaetingCrystals < 0 {
+			return nil, fmt.Errorf("raid reward %d: %s has a negative reward", i+1, reward.ID)
+		}
+		seen[reward.ID] = true
+	}
+	return &rewards, nil
+}
+
+// RaidValue is what the encounters an account hasn't cleared this week are
+// still worth
+type RaidValue struct {
+	Wings []RaidWingValue `json:"wings"`
+	Total Coins           `json:"total"`
+
+	MagnetiteShards int `json:"magnetite_shards"`
+	GaetingCrystals int `json:"gaeting_crystals"`
+
+	// Unknown lists the uncleared encounters missing from the reward table
+	Unknown []string `json:"unknown,omitempty"`
+}
+
+// RaidWingValue is the remaining value of one raid wing
+type RaidWingValue struct {
+	Raid            string `json:"raid"`
+	ID              string `json:"id"`
+	Remaining       int    `json:"remaining"` // Encounters not cleared this week
+	Coins           Coins  `json:"coins"`
+	MagnetiteShards int    `json:"magnetite_shards"`
+	GaetingCrystals int    `json:"gaeting_crystals"`
+	Value           Coins  `json:"value"` // Coins plus shards and crystals at the rates
+}
+
+// RemainingValue estimates what the encounters not cleared this week are
+// worth from a reward table such as DefaultRaidRewards, valuing shards and
+// crystals at rates
+func (p *RaidProgress) RemainingValue(rewards map[string]RaidEncounterReward, rates ShardRates) *RaidValue {
+	value := &RaidValue{}
+	for _, wing := range p.Wings {
+		wingValue := RaidWingValue{Raid: wing.Raid, ID: wing.ID}
+		for _, encounter := range wing.Encounters {
+			if encounter.Done {
+				continue
+			}
+			wingValue.Remaining++
+			reward, found := rewards[encounter.ID]
+			if !found {
+				value.Unknown = append(value.Unknown, encounter.ID)
+				continue
+			}
+			wingValue.Coins += reward.Coins
+			wingValue.MagnetiteShards += reward.MagnetiteShards
+			wingValue.GaetingCrystals += reward.GaetingCrystals
+			wingValue.Value += reward.Value(rates)
+		}
+		value.Wings = append(value.Wings, wingValue)
+		value.Total += wingValue.Value
+		value.MagnetiteShards += wingValue.MagnetiteShards
+		value.GaetingCrystals += wingValue.GaetingCrystals
+	}
+	return value
+}
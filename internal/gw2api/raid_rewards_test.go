@@ -0,0 +1,94 @@
+package gw2api
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRaidRewardsMatchRaids(t *testing.T) {
+	var raids []Raid
+	decodeStrict(t, "raids/all_raids.json", &raids)
+	encounters := make(map[string]bool)
+	for _, raid := range raids {
+		for _, wing := range raid.Wings {
+			for _, event := range wing.Events {
+				encounters[event.ID] = true
+			}
+		}
+	}
+
+	rewards := DefaultRaidRewards()
+	for id, reward := range rewards {
+		if !encounters[id] {
+			t.Errorf("reward table lists %s, which isn't a raid encounter", id)
+		}
+		if reward.Coins <= 0 {
+			t.Errorf("%s has no coin reward", id)
+		}
+	}
+	for id := range encounters {
+		if _, found := rewards[id]; !found {
+			t.Errorf("reward table is missing %s", id)
+		}
+	}
+}
+
+func TestReadRaidRewards(t *testing.T) {
+	rewards, err := ReadRaidRewards(strings.NewReader(`{"source": "test", "encounters": [{"id": "cairn", "coins": 100, "magnetite_shards": 2}]}`))
+	if err != nil || len(rewards.Encounters) != 1 || rewards.Encounters[0].MagnetiteShards != 2 {
+		t.Errorf("ReadRaidRewards = %+v, %v", rewards, err)
+	}
+	for _, input := range []string{
+		`{"encounters": [{"coins": 1}]}`,
+		`{"encounters": [{"id": "cairn"}, {"id": "cairn"}]}`,
+		`{"encounters": [{"id": "cairn", "coins": -1}]}`,
+		`{`,
+	} {
+		if _, err := ReadRaidRewards(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadRaidRewards(%s) succeeded", input)
+		}
+	}
+}
+
+func TestRaidRemainingValue(t *testing.T) {
+	client := newFixtureClient(t, "raids", map[string]string{
+		"/v2/raids":         "raids.json",
+		"/v2/account/raids": "account_raids.json",
+	})
+	progress, err := client.GetRaidProgress(context.Background(), utc("2024-03-13T12:00:00Z"))
+	if err != nil {
+		t.Fatalf("GetRaidProgress: %v", err)
+	}
+
+	rewards := map[string]RaidEncounterReward{
+		"sabetha":     {ID: "sabetha", Coins: 10000, MagnetiteShards: 3},
+		"bandit_trio": {ID: "bandit_trio", Coins: 5000, GaetingCrystals: 2},
+		"matthias":    {ID: "matthias", Coins: 10000, MagnetiteShards: 3},
+	}
+	value := progress.RemainingValue(rewards, ShardRates{MagnetiteShard: 100, GaetingCrystal: 50})
+
+	if len(value.Wings) != 2 {
+		t.Fatalf("got %d wings, expected 2", len(value.Wings))
+	}
+	vale, pass := value.Wings[0], value.Wings[1]
+	if vale.Remaining != 1 || vale.Coins != 10000 || vale.Value != 10300 {
+		t.Errorf("spirit vale = %+v, expected sabetha's 1g and 3 shards", vale)
+	}
+	// Slothasor is done, so bandit trio and matthias remain
+	if pass.Remaining != 2 || pass.Coins != 15000 || pass.MagnetiteShards != 3 || pass.GaetingCrystals != 2 || pass.Value != 15400 {
+		t.Errorf("salvation pass = %+v", pass)
+	}
+	if value.Total != 25700 || value.MagnetiteShards != 6 || value.GaetingCrystals != 2 {
+		t.Errorf("total = %s with %d shards and %d crystals", value.Total, value.MagnetiteShards, value.GaetingCrystals)
+	}
+	if len(value.Unknown) != 0 {
+		t.Errorf("unknown = %v, expected none", value.Unknown)
+	}
+
+	delete(rewards, "matthias")
+	if unknown := progress.RemainingValue(rewards, ShardRates{}).Unknown; !slices.Equal(unknown, []string{"matthias"}) {
+		t.Errorf("unknown = %v, expected [matthias]", unknown)
+	}
+}
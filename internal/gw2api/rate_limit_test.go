@@ -0,0 +1,95 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newQueuedClient returns a client whose rate limiter has no tokens left, so
+// the next request waits about an hour, and a counter of requests that reached
+// the server
+func newQueuedClient(t *testing.T) (*Client, *atomic.Int32, *[]RequestInfo) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.Write([]byte(`{"id": 115267}`))
+	}))
+	t.Cleanup(server.Close)
+
+	var infos []RequestInfo
+	client := NewClient(
+		WithRateLimit(1.0/3600),
+		WithRetryConfig(&RetryConfig{MaxRetries: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffMultiple: 1}),
+		WithResponseHook(func(info RequestInfo) { infos = append(infos, info) }),
+	)
+	client.baseURL = server.URL
+	client.rateLimiter.Allow() // Spend the only token
+	return client, &requests, &infos
+}
+
+func TestRateLimitWaitCancelled(t *testing.T) {
+	client, requests, infos := newQueuedClient(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := client.GetBuild(ctx)
+	if err != context.Canceled {
+		t.Fatalf("err = %v, expected context.Canceled unwrapped", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d requests reached the server, expected none", n)
+	}
+	if len(*infos) != 1 {
+		t.Fatalf("got %d attempts, expected 1 without retries", len(*infos))
+	}
+	if info := (*infos)[0]; info.RateLimitWait < 10*time.Millisecond || info.URL != "" {
+		t.Errorf("attempt = %+v, expected a limiter wait and no request built", info)
+	}
+}
+
+func TestRateLimitWaitPastDeadline(t *testing.T) {
+	client, requests, infos := newQueuedClient(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// The limiter gives up at once when the wait would outlast the deadline
+	_, err := client.GetBuild(ctx)
+	if err != context.DeadlineExceeded {
+		t.Fatalf("err = %v, expected context.DeadlineExceeded unwrapped", err)
+	}
+	if n := requests.Load(); n != 0 {
+		t.Errorf("%d requests reached the server, expected none", n)
+	}
+	if len(*infos) != 1 {
+		t.Errorf("got %d attempts, expected 1 without retries", len(*infos))
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	tests := []struct {
+		err       error
+		retryable bool
+	}{
+		{HTTPError{StatusCode: http.StatusServiceUnavailable}, true},
+		{HTTPError{StatusCode: http.StatusTooManyRequests}, true},
+		{HTTPError{StatusCode: http.StatusNotFound}, false},
+		{errors.New("connection reset"), true},
+		{fmt.Errorf("request failed: %v", "Client.Timeout exceeded"), true},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, false},
+		{fmt.Errorf("request failed: %w", context.Canceled), false},
+	}
+	for _, tt := range tests {
+		if got := isRetryableError(tt.err); got != tt.retryable {
+			t.Errorf("isRetryableError(%v) = %v, expected %v", tt.err, got, tt.retryable)
+		}
+	}
+}
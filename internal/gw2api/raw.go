@@ -0,0 +1,77 @@
+package gw2api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidEndpoint is returned by GetRaw for an endpoint outside /v2/
+var ErrInvalidEndpoint = errors.New("invalid endpoint")
+
+// GetRaw fetches an endpoint, such as "/v2/items", and returns the response
+// body undecoded, for endpoints this package has no types for yet and for
+// callers that pass responses on as they are. Requests go through the usual
+// authentication, language, rate limiting and retries, and IDs, pages and
+// other options apply as for the typed methods. Query parameters are set with
+// WithParam and override the client's defaults, so a "lang" parameter
+// replaces the client's language. Decode turns the result into a struct.
+//
+// Endpoints must start with /v2/, apart from the /v2.json endpoint list, and
+// can't carry their own query string; anything else fails with
+// ErrInvalidEndpoint before a request is made.
+//
+// The client's API key is replaced with REDACTED wherever the response or an
+// error message echoes it back.
+func (c *Client) GetRaw(ctx context.Context, endpoint string, options ...RequestOption) (json.RawMessage, *PaginationResponse, error) {
+	if err := validateRawEndpoint(endpoint); err != nil {
+		return nil, nil, err
+	}
+
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, pagination, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		var httpErr HTTPError
+		if c.apiKey != "" && errors.As(err, &httpErr) {
+			httpErr.Message = strings.ReplaceAll(httpErr.Message, c.apiKey, "REDACTED")
+			return nil, nil, httpErr
+		}
+		return nil, nil, err
+	}
+
+	if c.apiKey != "" {
+		data = bytes.ReplaceAll(data, []byte(c.apiKey), []byte("REDACTED"))
+	}
+	return data, pagination, nil
+}
+
+// validateRawEndpoint checks an endpoint passed to GetRaw
+func validateRawEndpoint(endpoint string) error {
+	switch {
+	case endpoint == "/v2.json":
+	case !strings.HasPrefix(endpoint, "/v2/") || endpoint == "/v2/":
+		return fmt.Errorf("%w %q: must start with /v2/", ErrInvalidEndpoint, endpoint)
+	case strings.ContainsAny(endpoint, "?#"):
+		return fmt.Errorf("%w %q: set query parameters with WithParam, WithIDs or WithPage", ErrInvalidEndpoint, endpoint)
+	case strings.Contains(endpoint, ".."):
+		return fmt.Errorf("%w %q: must not contain ..", ErrInvalidEndpoint, endpoint)
+	}
+	return nil
+}
+
+// Decode decodes a response from GetRaw into T, for pairing GetRaw with
+// callers' own types for endpoints this package doesn't cover
+func Decode[T any](data json.RawMessage) (T, error) {
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result, nil
+}
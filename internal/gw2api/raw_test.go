@@ -0,0 +1,62 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGetRaw(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		w.Header().Set("X-Page", "1")
+		w.Header().Set("X-Page-Total", "3")
+		w.Header().Set("X-Result-Total", "5")
+		w.Write([]byte(`[{"id": 1, "name": "New Thing", "shiny": true}]`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRetries(0), WithRateLimit(1000), WithLanguage(LanguageFrench))
+	client.baseURL = server.URL
+
+	data, pagination, err := client.GetRaw(context.Background(), "/v2/newthings", WithPage(1), WithPageSize(2))
+	if err != nil {
+		t.Fatalf("GetRaw: %v", err)
+	}
+	if query != "lang=fr&page=1&page_size=2" {
+		t.Errorf("query = %q, expected the language and page", query)
+	}
+	if pagination == nil || pagination.PageTotal != 3 {
+		t.Errorf("pagination = %+v, expected 3 pages", pagination)
+	}
+
+	type newThing struct {
+		ID    int    `json:"id"`
+		Name  string `json:"name"`
+		Shiny bool   `json:"shiny"`
+	}
+	things, err := Decode[[]newThing](data)
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(things) != 1 || things[0] != (newThing{ID: 1, Name: "New Thing", Shiny: true}) {
+		t.Errorf("things = %+v", things)
+	}
+	if _, err := Decode[newThing](data); err == nil {
+		t.Error("expected an error decoding an array into a struct")
+	}
+}
+
+func TestGetRawInvalidEndpoint(t *testing.T) {
+	client := NewClient(WithRetries(0))
+	client.baseURL = "http://127.0.0.1:0" // Unreachable, so any request would fail differently
+
+	for _, endpoint := range []string{"", "/v2/", "v2/items", "/v1/items", "/v2/items?ids=1", "/v2/../v1/build", "https://example.com/v2/items"} {
+		if _, _, err := client.GetRaw(context.Background(), endpoint); !errors.Is(err, ErrInvalidEndpoint) {
+			t.Errorf("GetRaw(%q) error = %v, expected ErrInvalidEndpoint", endpoint, err)
+		}
+	}
+}
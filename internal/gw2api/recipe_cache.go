@@ -1,23 +1,18 @@
 package gw2api
 
 import (
-	"bufio"
-	"encoding/json"
-	"fmt"
-	"os"
-	"sync"
+	"slices"
+	"strings"
 	"time"
 )
 
 // RecipeCache provides in-memory caching of recipes loaded from a local JSON file
 type RecipeCache struct {
-	recipes         map[int]*RecipeDetail // ID -> Recipe mapping for fast lookups
-	recipesByOutput map[int][]int         // OutputItemID -> []RecipeID mapping for recipe search
-	recipesByInput  map[int][]int         // IngredientItemID -> []RecipeID mapping for recipe search
-	recipesList     []*RecipeDetail       // All recipes as slice for iteration
-	loaded          bool
-	mutex           sync.RWMutex
-	stats           RecipeCacheStats
+	jsonlCache[RecipeDetail]
+	recipesByOutput map[int][]int // OutputItemID -> []RecipeID mapping for recipe search
+	recipesByInput  map[int][]int // IngredientItemID -> []RecipeID mapping for recipe search
+
+	recipesByDiscipline map[string][]int // Lowercase discipline -> []RecipeID mapping for SearchRecipes
 }
 
 // RecipeCacheStats tracks cache performance
@@ -31,212 +26,153 @@ type RecipeCacheStats struct {
 
 // NewRecipeCache creates a new recipe cache
 func NewRecipeCache() *RecipeCache {
-	return &RecipeCache{
-		recipes:         make(map[int]*RecipeDetail),
+	rc := &RecipeCache{
+		jsonlCache:      newJSONLCache("recipes", func(recipe *RecipeDetail) int { return recipe.ID }),
 		recipesByOutput: make(map[int][]int),
 		recipesByInput:  make(map[int][]int),
-		recipesList:     make([]*RecipeDetail, 0),
-		loaded:          false,
-	}
-}
 
-// LoadFromFile loads all recipes from a JSONL file (one JSON object per line)
-func (rc *RecipeCache) LoadFromFile(filePath string) error {
-	rc.mutex.Lock()
-	defer rc.mutex.Unlock()
-
-	startTime := time.Now()
-	
-	file, err := os.Open(filePath)
-	if err != nil {
-		return fmt.Errorf("failed to open recipes file %s: %w", filePath, err)
+		recipesByDiscipline: make(map[string][]int),
 	}
-	defer file.Close()
+	rc.resetIndex = rc.resetRecipeIndexes
+	rc.index = rc.indexRecipe
+	rc.unindex = rc.unindexRecipe
+	return rc
+}
 
-	// Clear existing data
-	rc.recipes = make(map[int]*RecipeDetail)
+// resetRecipeIndexes empties the output, input and discipline indexes
+func (rc *RecipeCache) resetRecipeIndexes() {
 	rc.recipesByOutput = make(map[int][]int)
 	rc.recipesByInput = make(map[int][]int)
-	rc.recipesList = make([]*RecipeDetail, 0)
-
-	scanner := bufio.NewScanner(file)
-	recipeCount := 0
-
-	for scanner.Scan() {
-		line := scanner.Text()
-		if len(line) == 0 {
-			continue
-		}
-
-		var recipe RecipeDetail
-		if err := json.Unmarshal([]byte(line), &recipe); err != nil {
-			// Skip invalid lines but continue processing
-			continue
-		}
+	rc.recipesByDiscipline = make(map[string][]int)
+}
 
-		// Store in map and slice
-		rc.recipes[recipe.ID] = &recipe
-		rc.recipesList = append(rc.recipesList, &recipe)
-		
-		// Build output item mapping for recipe search
-		if recipe.OutputItemID > 0 {
-			rc.recipesByOutput[recipe.OutputItemID] = append(rc.recipesByOutput[recipe.OutputItemID], recipe.ID)
-		}
-		
-		// Build input item mapping for recipe search
-		for _, ingredient := range recipe.Ingredients {
-			if ingredient.ItemID > 0 {
-				rc.recipesByInput[ingredient.ItemID] = append(rc.recipesByInput[ingredient.ItemID], recipe.ID)
-			}
+// indexRecipe adds a recipe to the output, input and discipline indexes
+func (rc *RecipeCache) indexRecipe(recipe *RecipeDetail) {
+	if recipe.OutputItemID > 0 {
+		rc.recipesByOutput[recipe.OutputItemID] = append(rc.recipesByOutput[recipe.OutputItemID], recipe.ID)
+	}
+	for _, ingredient := range recipe.Ingredients {
+		if ingredient.ItemID > 0 {
+			rc.recipesByInput[ingredient.ItemID] = append(rc.recipesByInput[ingredient.ItemID], recipe.ID)
 		}
-		
-		recipeCount++
 	}
-
-	if err := scanner.Err(); err != nil {
-		return fmt.Errorf("error reading recipes file: %w", err)
+	for _, discipline := range recipe.Disciplines {
+		key := strings.ToLower(discipline)
+		rc.recipesByDiscipline[key] = append(rc.recipesByDiscipline[key], recipe.ID)
 	}
-
-	rc.loaded = true
-	rc.stats.LoadedRecipes = recipeCount
-	rc.stats.LoadTime = time.Since(startTime)
-	rc.stats.LastLoadTime = time.Now()
-
-	return nil
 }
 
-// GetByID retrieves a recipe by its ID from the cache
-func (rc *RecipeCache) GetByID(id int) (*RecipeDetail, bool) {
-	rc.mutex.RLock()
-	defer rc.mutex.RUnlock()
-
-	if !rc.loaded {
-		rc.stats.CacheMisses++
-		return nil, false
+// unindexRecipe removes a recipe from the output, input and discipline indexes
+func (rc *RecipeCache) unindexRecipe(recipe *RecipeDetail) {
+	rc.recipesByOutput[recipe.OutputItemID] = removeID(rc.recipesByOutput[recipe.OutputItemID], recipe.ID)
+	for _, ingredient := range recipe.Ingredients {
+		rc.recipesByInput[ingredient.ItemID] = removeID(rc.recipesByInput[ingredient.ItemID], recipe.ID)
 	}
-
-	recipe, found := rc.recipes[id]
-	if found {
-		rc.stats.CacheHits++
-	} else {
-		rc.stats.CacheMisses++
+	for _, discipline := range recipe.Disciplines {
+		key := strings.ToLower(discipline)
+		rc.recipesByDiscipline[key] = removeID(rc.recipesByDiscipline[key], recipe.ID)
 	}
-	
-	return recipe, found
 }
 
-// GetByIDs retrieves multiple recipes by their IDs
-func (rc *RecipeCache) GetByIDs(ids []int) []*RecipeDetail {
+// SearchByOutput finds recipes that create a specific item
+func (rc *RecipeCache) SearchByOutput(itemID int) []int {
 	rc.mutex.RLock()
 	defer rc.mutex.RUnlock()
+	return rc.lookupIndex(rc.recipesByOutput, itemID)
+}
 
-	if !rc.loaded {
-		rc.stats.CacheMisses += int64(len(ids))
-		return make([]*RecipeDetail, 0)
-	}
-
-	results := make([]*RecipeDetail, 0, len(ids))
-	for _, id := range ids {
-		if recipe, found := rc.recipes[id]; found {
-			results = append(results, recipe)
-			rc.stats.CacheHits++
-		} else {
-			rc.stats.CacheMisses++
-		}
-	}
-
-	return results
+// SearchByInput finds recipes that use a specific item as ingredient
+func (rc *RecipeCache) SearchByInput(itemID int) []int {
+	rc.mutex.RLock()
+	defer rc.mutex.RUnlock()
+	return rc.lookupIndex(rc.recipesByInput, itemID)
 }
 
-// SearchByOutput finds recipes that create a specific item
-func (rc *RecipeCache) SearchByOutput(itemID int) []int {
+// SearchRecipes returns copies of the recipes matching options, ordered by ID.
+// Disciplines are looked up in an index, so searches by discipline don't scan
+// every recipe. OutputItemName is ignored here; Client.SearchRecipes resolves
+// it to OutputItemIDs.
+func (rc *RecipeCache) SearchRecipes(options RecipeSearchOptions) []*RecipeDetail {
 	rc.mutex.RLock()
 	defer rc.mutex.RUnlock()
 
 	if !rc.loaded {
-		rc.stats.CacheMisses++
-		return []int{}
+		rc.misses.Add(1)
+		return nil
 	}
 
-	if recipeIDs, found := rc.recipesByOutput[itemID]; found {
-		rc.stats.CacheHits++
-		// Return a copy to avoid race conditions
-		result := make([]int, len(recipeIDs))
-		copy(result, recipeIDs)
-		return result
+	var candidates []*RecipeDetail
+	if len(options.Disciplines) > 0 {
+		var ids []int
+		for _, discipline := range options.Disciplines {
+			ids = append(ids, rc.recipesByDiscipline[strings.ToLower(discipline)]...)
+		}
+		for _, id := range uniqueIDs(ids) {
+			candidates = append(candidates, rc.byID[id])
+		}
+	} else {
+		candidates = slices.SortedFunc(slices.Values(rc.list), func(a, b *RecipeDetail) int { return a.ID - b.ID })
 	}
 
-	rc.stats.CacheMisses++
-	return []int{}
-}
+	var results []*RecipeDetail
+	for _, recipe := range candidates {
+		if options.MinRating > 0 && recipe.MinRating < options.MinRating {
+			continue
+		}
+		if options.MaxRating > 0 && recipe.MinRating > options.MaxRating {
+			continue
+		}
+		if len(options.OutputItemIDs) > 0 && !slices.Contains(options.OutputItemIDs, recipe.OutputItemID) {
+			continue
+		}
+		results = append(results, recipe)
+		if options.Limit > 0 && len(results) >= options.Limit {
+			break
+		}
+	}
 
-// SearchByInput finds recipes that use a specific item as ingredient
-func (rc *RecipeCache) SearchByInput(itemID int) []int {
-	rc.mutex.RLock()
-	defer rc.mutex.RUnlock()
+	rc.hits.Add(1)
+	return copyAll(results)
+}
 
+// lookupIndex returns a copy of the recipe IDs stored under itemID; callers must hold the read lock
+func (rc *RecipeCache) lookupIndex(index map[int][]int, itemID int) []int {
 	if !rc.loaded {
-		rc.stats.CacheMisses++
+		rc.misses.Add(1)
 		return []int{}
 	}
 
-	if recipeIDs, found := rc.recipesByInput[itemID]; found {
-		rc.stats.CacheHits++
+	if recipeIDs, found := index[itemID]; found && len(recipeIDs) > 0 {
+		rc.hits.Add(1)
 		// Return a copy to avoid race conditions
 		result := make([]int, len(recipeIDs))
 		copy(result, recipeIDs)
 		return result
 	}
 
-	rc.stats.CacheMisses++
+	rc.misses.Add(1)
 	return []int{}
 }
 
-// IsLoaded returns true if the cache has been loaded with data
-func (rc *RecipeCache) IsLoaded() bool {
-	rc.mutex.RLock()
-	defer rc.mutex.RUnlock()
-	return rc.loaded
-}
-
-// Size returns the number of recipes in the cache
-func (rc *RecipeCache) Size() int {
-	rc.mutex.RLock()
-	defer rc.mutex.RUnlock()
-	return len(rc.recipes)
-}
-
-// Clear clears the cache
-func (rc *RecipeCache) Clear() {
-	rc.mutex.Lock()
-	defer rc.mutex.Unlock()
-	
-	rc.recipes = make(map[int]*RecipeDetail)
-	rc.recipesByOutput = make(map[int][]int)
-	rc.recipesByInput = make(map[int][]int)
-	rc.recipesList = make([]*RecipeDetail, 0)
-	rc.loaded = false
-	rc.stats = RecipeCacheStats{}
-}
-
 // GetStats returns cache performance statistics
 func (rc *RecipeCache) GetStats() RecipeCacheStats {
-	rc.mutex.RLock()
-	defer rc.mutex.RUnlock()
-	return rc.stats
+	s := rc.snapshot()
+	return RecipeCacheStats{
+		LoadedRecipes: s.loaded,
+		LoadTime:      s.loadTime,
+		CacheHits:     s.hits,
+		CacheMisses:   s.misses,
+		LastLoadTime:  s.lastLoadTime,
+	}
 }
 
-// GetAll returns all cached recipes (use with caution for large datasets)
-func (rc *RecipeCache) GetAll() []*RecipeDetail {
-	rc.mutex.RLock()
-	defer rc.mutex.RUnlock()
-	
-	if !rc.loaded {
-		return []*RecipeDetail{}
+// removeID returns ids without any occurrence of id
+func removeID(ids []int, id int) []int {
+	result := ids[:0]
+	for _, existing := range ids {
+		if existing != id {
+			result = append(result, existing)
+		}
 	}
-	
-	// Return a copy to avoid race conditions
-	result := make([]*RecipeDetail, len(rc.recipesList))
-	copy(result, rc.recipesList)
 	return result
-}
\ No newline at end of file
+}
@@ -0,0 +1,120 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// recipeSearchMissTTL is how long a search that found no recipes is
+// remembered. Most items have none, so crafting trees ask about the same
+// leaves over and over, but an item can gain a recipe in a game update, so
+// the answer is asked for again after a while. Searches that found recipes
+// are remembered for the life of the client.
+const recipeSearchMissTTL = time.Hour
+
+// recipeSearchKey is one search: recipes creating the item, or using it
+type recipeSearchKey struct {
+	param  string // "output" or "input"
+	itemID int
+}
+
+type recipeSearchResult struct {
+	recipeIDs []int
+	expires   time.Time // Zero for a result kept for the session
+}
+
+// recipeSearchCache remembers the API's answers to recipe searches. It is
+// shared with copies of the client made by With.
+type recipeSearchCache struct {
+	mutex   sync.Mutex
+	results map[recipeSearchKey]recipeSearchResult
+}
+
+func newRecipeSearchCache() *recipeSearchCache {
+	return &recipeSearchCache{results: make(map[recipeSearchKey]recipeSearchResult)}
+}
+
+// get returns a copy of the remembered result of a search, if it hasn't expired
+func (r *recipeSearchCache) get(key recipeSearchKey, now time.Time) ([]int, bool) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	result, found := r.results[key]
+	if !found {
+		return nil, false
+	}
+	if !result.expires.IsZero() && !now.Before(result.expires) {
+		delete(r.results, key)
+		return nil, false
+	}
+	return slices.Clone(result.recipeIDs), true
+}
+
+// set remembers the result of a search, only until recipeSearchMissTTL when
+// it found nothing
+func (r *recipeSearchCache) set(key recipeSearchKey, recipeIDs []int, now time.Time) {
+	result := recipeSearchResult{recipeIDs: slices.Clone(recipeIDs)}
+	if len(recipeIDs) == 0 {
+		result.expires = now.Add(recipeSearchMissTTL)
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.results[key] = result
+}
+
+// SearchRecipesByOutput returns the IDs of the recipes that create an item.
+// A loaded recipe cache answers on its own. Otherwise the API is searched,
+// and the answer is remembered: recipes found are kept for the life of the
+// client, and finding none is kept for an hour, so repeated searches for
+// items without recipes, the common case, don't each cost a request.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/recipes/search
+// Scopes: None (public endpoint)
+func (c *Client) SearchRecipesByOutput(ctx context.Context, itemID int, options ...RequestOption) ([]int, error) {
+	return c.searchRecipeIDs(ctx, "output", itemID, options)
+}
+
+// SearchRecipesByInput returns the IDs of the recipes that use an item as an
+// ingredient, answered and remembered like SearchRecipesByOutput.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/recipes/search
+// Scopes: None (public endpoint)
+func (c *Client) SearchRecipesByInput(ctx context.Context, itemID int, options ...RequestOption) ([]int, error) {
+	return c.searchRecipeIDs(ctx, "input", itemID, options)
+}
+
+// searchRecipeIDs searches for recipes by output or input item
+func (c *Client) searchRecipeIDs(ctx context.Context, param string, itemID int, options []RequestOption) ([]int, error) {
+	if c.dataCache != nil && c.dataCache.GetRecipeCache().IsLoaded() {
+		recipes := c.dataCache.GetRecipeCache()
+		if param == "input" {
+			return recipes.SearchByInput(itemID), nil
+		}
+		return recipes.SearchByOutput(itemID), nil
+	}
+
+	key := recipeSearchKey{param: param, itemID: itemID}
+	if c.recipeSearches != nil {
+		if recipeIDs, found := c.recipeSearches.get(key, time.Now()); found {
+			if c.stats != nil {
+				c.stats.recipeSearchHits.Add(1)
+			}
+			return recipeIDs, nil
+		}
+	}
+
+	options = append(options, WithParam(param, strconv.Itoa(itemID)))
+	recipeIDs, err := GetIDs[int](ctx, c, "/v2/recipes/search", options...)
+	if errors.Is(err, ErrNotFound) {
+		// An unknown item has no recipes
+		recipeIDs, err = []int{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if c.recipeSearches != nil {
+		c.recipeSearches.set(key, recipeIDs, time.Now())
+	}
+	return recipeIDs, nil
+}
@@ -0,0 +1,84 @@
+package gw2api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSearchRecipesRemembered(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		switch r.URL.RawQuery {
+		case "output=19684":
+			w.Write([]byte(`[1, 2]`))
+		case "input=19684":
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "no such id"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000), WithLanguage(""))
+	ctx := context.Background()
+
+	for range 2 {
+		recipeIDs, err := client.SearchRecipesByOutput(ctx, 19684)
+		if err != nil || !slices.Equal(recipeIDs, []int{1, 2}) {
+			t.Errorf("SearchRecipesByOutput = %v, %v; want recipes 1 and 2", recipeIDs, err)
+		}
+		recipeIDs, err = client.SearchRecipesByInput(ctx, 19684)
+		if err != nil || len(recipeIDs) != 0 {
+			t.Errorf("SearchRecipesByInput = %v, %v; want none", recipeIDs, err)
+		}
+		// An unknown item has no recipes rather than an error
+		recipeIDs, err = client.SearchRecipesByOutput(ctx, 1)
+		if err != nil || len(recipeIDs) != 0 {
+			t.Errorf("unknown item = %v, %v; want none", recipeIDs, err)
+		}
+	}
+
+	// Copies of the client share what it remembers
+	if recipeIDs, _ := client.With(WithRetries(1)).SearchRecipesByOutput(ctx, 19684); !slices.Equal(recipeIDs, []int{1, 2}) {
+		t.Errorf("copy = %v", recipeIDs)
+	}
+	if n := requests.Load(); n != 3 {
+		t.Errorf("%d requests, want one per search", n)
+	}
+	if hits := client.Stats().RecipeSearchHits; hits != 4 {
+		t.Errorf("RecipeSearchHits = %d, want 4", hits)
+	}
+
+	// Callers can't change what's remembered
+	recipeIDs, _ := client.SearchRecipesByOutput(ctx, 19684)
+	recipeIDs[0] = 99
+	if again, _ := client.SearchRecipesByOutput(ctx, 19684); again[0] != 1 {
+		t.Errorf("remembered result changed to %v", again)
+	}
+}
+
+func TestRecipeSearchCacheExpiry(t *testing.T) {
+	cache := newRecipeSearchCache()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	found := recipeSearchKey{param: "output", itemID: 1}
+	missing := recipeSearchKey{param: "output", itemID: 2}
+	cache.set(found, []int{10}, now)
+	cache.set(missing, nil, now)
+
+	later := now.Add(recipeSearchMissTTL)
+	if recipeIDs, ok := cache.get(found, later); !ok || !slices.Equal(recipeIDs, []int{10}) {
+		t.Errorf("found = %v, %v; want it kept", recipeIDs, ok)
+	}
+	if _, ok := cache.get(missing, later.Add(-time.Second)); !ok {
+		t.Error("no recipes expired early")
+	}
+	if _, ok := cache.get(missing, later); ok {
+		t.Error("no recipes kept past its lifetime")
+	}
+}
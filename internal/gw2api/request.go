@@ -0,0 +1,621 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// isRetryableError determines if an error should trigger a retry
+func isRetryableError(err error) bool {
+	if httpErr, ok := err.(HTTPError); ok {
+		// Retry server errors (5xx) and rate limiting (429)
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == 429
+	}
+	
+	// The caller gave up, so another attempt would be cancelled too
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	// The same response would come back
+	if errors.Is(err, ErrResponseTooLarge) {
+		return false
+	}
+
+	// Retry network errors, timeouts, etc.
+	return true
+}
+
+// calculateBackoffDelay calculates the delay for exponential backoff
+func (c *Client) calculateBackoffDelay(attempt int) time.Duration {
+	if c.retryConfig == nil {
+		return 0
+	}
+	
+	delay := time.Duration(float64(c.retryConfig.BaseDelay) * math.Pow(c.retryConfig.BackoffMultiple, float64(attempt)))
+	if delay > c.retryConfig.MaxDelay {
+		delay = c.retryConfig.MaxDelay
+	}
+	return delay
+}
+
+// RequestOptions configures individual API requests
+type RequestOptions struct {
+	Language      Language
+	IDs           []int
+	Page          int
+	PageSize      int
+	All           bool
+	SchemaVersion string
+	Fields        []string
+	Params        map[string]string // Extra query parameters, e.g. "quantity"
+	Headers       map[string]string // Extra request headers, e.g. "If-None-Match"
+
+	responseHeader *http.Header  // Receives the response headers, if set
+	retries        *int          // Overrides the client's maximum retries, if set
+	timeout        time.Duration // Deadline for the call including retries, if set
+}
+
+// RequestOption configures a request
+type RequestOption func(*RequestOptions)
+
+// WithLang overrides the default language for this request
+func WithLang(lang Language) RequestOption {
+	return func(o *RequestOptions) {
+		o.Language = lang
+	}
+}
+
+// WithIDs requests specific IDs
+func WithIDs(ids ...int) RequestOption {
+	return func(o *RequestOptions) {
+		o.IDs = ids
+	}
+}
+
+// WithPage requests a specific page
+func WithPage(page int) RequestOption {
+	return func(o *RequestOptions) {
+		o.Page = page
+	}
+}
+
+// WithPageSize sets the page size
+func WithPageSize(size int) RequestOption {
+	return func(o *RequestOptions) {
+		o.PageSize = size
+	}
+}
+
+// WithAll requests all available items
+func WithAll() RequestOption {
+	return func(o *RequestOptions) {
+		o.All = true
+	}
+}
+
+// WithSchemaVersion sets the schema version
+func WithSchemaVersion(version string) RequestOption {
+	return func(o *RequestOptions) {
+		o.SchemaVersion = version
+	}
+}
+
+// WithFields limits returned objects to the named JSON fields (plus "id").
+// The API has no server-side field selection, so this is applied client-side and
+// only by methods that document support for it. Projected results are copies,
+// so modifying them never changes cached data.
+func WithFields(fields ...string) RequestOption {
+	return func(o *RequestOptions) {
+		o.Fields = fields
+	}
+}
+
+// WithParam sets an extra query parameter for endpoints that take one
+func WithParam(key, value string) RequestOption {
+	return func(o *RequestOptions) {
+		if o.Params == nil {
+			o.Params = make(map[string]string)
+		}
+		o.Params[key] = value
+	}
+}
+
+// WithHeader sets an extra request header, such as If-None-Match for a
+// conditional request that fails with ErrNotModified when nothing changed
+func WithHeader(key, value string) RequestOption {
+	return func(o *RequestOptions) {
+		if o.Headers == nil {
+			o.Headers = make(map[string]string)
+		}
+		o.Headers[key] = value
+	}
+}
+
+// WithRequestRetries overrides the client's maximum number of retries for this
+// request. Zero means no retries, for interactive calls with a tight latency
+// budget; background jobs can pass more than the client's default.
+func WithRequestRetries(maxRetries int) RequestOption {
+	// Clamped once, as an option may be shared by requests on many goroutines
+	maxRetries = max(maxRetries, 0)
+	return func(o *RequestOptions) {
+		o.retries = &maxRetries
+	}
+}
+
+// WithRequestTimeout gives up on the request after d, counting every attempt
+// and the backoff between them. Zero or less leaves only the context's deadline.
+func WithRequestTimeout(d time.Duration) RequestOption {
+	return func(o *RequestOptions) {
+		o.timeout = d
+	}
+}
+
+// captureHeader stores the response headers of a successful or failed request in header
+func captureHeader(header *http.Header) RequestOption {
+	return func(o *RequestOptions) {
+		o.responseHeader = header
+	}
+}
+
+// get performs a GET request to the API
+func (c *Client) get(ctx context.Context, endpoint string, opts *RequestOptions) ([]byte, *PaginationResponse, error) {
+	maxRetries := 0
+	if c.retryConfig != nil {
+		maxRetries = c.retryConfig.MaxRetries
+	}
+	if opts.retries != nil {
+		maxRetries = *opts.retries
+	}
+	if c.permissions != nil {
+		if err := c.checkScopes(endpoint); err != nil {
+			return nil, nil, err
+		}
+	}
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+
+	requestID := newRequestID()
+	var lastErr error
+	var delay time.Duration
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, nil, ctx.Err()
+			case <-time.After(delay):
+			}
+		}
+
+		info := RequestInfo{RequestID: requestID, Endpoint: endpoint, Attempt: attempt + 1, Context: ctx}
+		body, pagination, err := c.makeRequest(ctx, endpoint, opts, &info)
+		info.Err = err
+
+		// Success case
+		if err == nil {
+			c.reportRequest(info)
+			return body, pagination, nil
+		}
+
+		lastErr = err
+
+		// Check if error is retryable
+		if !isRetryableError(err) {
+			c.reportRequest(info)
+			return nil, nil, characterError(endpoint, err)
+		}
+
+		if attempt < maxRetries {
+			delay = c.calculateBackoffDelay(attempt)
+			info.Backoff = delay
+		}
+		c.reportRequest(info)
+	}
+
+	if maxRetries == 0 {
+		return nil, nil, lastErr
+	}
+	return nil, nil, fmt.Errorf("request failed after %d retries: %w", maxRetries, lastErr)
+}
+
+// checkScopes returns an error matching ErrMissingScope when the registry
+// lists a scope for the endpoint that the client's permissions lack. Paths
+// the registry doesn't know are left for the API to judge.
+func (c *Client) checkScopes(endpoint string) error {
+	registered, found := EndpointFor(endpoint)
+	if !found {
+		return nil
+	}
+	if missing := registered.MissingScopes(c.permissions); len(missing) > 0 {
+		return fmt.Errorf("%w: %s needs the %s scope", ErrMissingScope, registered.Path, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
+// makeRequest performs a single HTTP attempt, recording timing details in info
+func (c *Client) makeRequest(ctx context.Context, endpoint string, opts *RequestOptions, info *RequestInfo) ([]byte, *PaginationResponse, error) {
+	// Wait for the rate limiter before building the request, so a request
+	// queued behind others is only created once it can be sent
+	if c.rateLimiter != nil {
+		waitStart := time.Now()
+		err := c.rateLimiter.Wait(ctx)
+		info.RateLimitWait = time.Since(waitStart)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, nil, ctxErr
+			}
+			if _, hasDeadline := ctx.Deadline(); hasDeadline {
+				// The limiter gives up early when the wait would outlast the deadline
+				return nil, nil, context.DeadlineExceeded
+			}
+			return nil, nil, fmt.Errorf("rate limiting failed: %w", err)
+		}
+	}
+
+	u, err := url.Parse(c.baseURL + endpoint)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid endpoint: %w", err)
+	}
+
+	q := u.Query()
+
+	// Add language parameter
+	lang := c.language
+	if opts != nil && opts.Language != "" {
+		lang = opts.Language
+	}
+	if lang != "" {
+		q.Set("lang", string(lang))
+	}
+
+	// Add authentication, sent as a header below unless query auth was requested
+	if c.apiKey != "" && c.queryAuth {
+		q.Set("access_token", c.apiKey)
+	}
+
+	// Add schema version
+	if opts != nil && opts.SchemaVersion != "" {
+		q.Set("v", opts.SchemaVersion)
+	}
+
+	// Add bulk expansion parameters
+	if opts != nil {
+		if opts.All {
+			q.Set("ids", "all")
+		} else if len(opts.IDs) > 0 {
+			ids := make([]string, len(opts.IDs))
+			for i, id := range opts.IDs {
+				ids[i] = strconv.Itoa(id)
+			}
+			q.Set("ids", strings.Join(ids, ","))
+		}
+
+		// Add pagination parameters
+		if opts.Page > 0 {
+			q.Set("page", strconv.Itoa(opts.Page))
+		}
+		if opts.PageSize > 0 {
+			q.Set("page_size", strconv.Itoa(opts.PageSize))
+		}
+
+		for key, value := range opts.Params {
+			q.Set(key, value)
+		}
+	}
+
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", u.String(), nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Each attempt builds a new request, so retries never repeat headers
+	req.Header.Set("User-Agent", c.userAgent)
+	if c.apiKey != "" && !c.queryAuth {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+	if opts != nil {
+		for key, value := range opts.Headers {
+			req.Header.Set(key, value)
+		}
+	}
+	info.URL = redactURL(u)
+
+	// Revalidate a kept response rather than downloading it again
+	var conditionalKeyName string
+	var kept *conditionalEntry
+	if c.conditional != nil && (opts == nil || len(opts.Headers) == 0) {
+		conditionalKeyName = conditionalKey(c.apiKey, info.URL)
+		if kept = c.conditional.get(conditionalKeyName); kept != nil {
+			req.Header.Set("If-Modified-Since", kept.lastModified)
+		}
+	}
+
+	requestStart := time.Now()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		info.Duration = time.Since(requestStart)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, nil, ctxErr
+		}
+		if errors.Is(err, context.DeadlineExceeded) {
+			// The HTTP client's own timeout only limits this attempt, so it
+			// stays retryable rather than matching the caller's deadline
+			return nil, nil, fmt.Errorf("request failed: %v", err)
+		}
+		return nil, nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if opts != nil && opts.responseHeader != nil {
+		*opts.responseHeader = resp.Header
+	}
+
+	// Read one byte past the limit to tell a body of exactly the limit from a larger one
+	var reader io.Reader = resp.Body
+	if c.maxResponseSize > 0 {
+		if resp.ContentLength > c.maxResponseSize {
+			info.Duration = time.Since(requestStart)
+			info.StatusCode = resp.StatusCode
+			return nil, nil, fmt.Errorf("%w: %d bytes, limit is %d", ErrResponseTooLarge, resp.ContentLength, c.maxResponseSize)
+		}
+		reader = io.LimitReader(resp.Body, c.maxResponseSize+1)
+	}
+	body, err := io.ReadAll(reader)
+	info.Duration = time.Since(requestStart)
+	info.StatusCode = resp.StatusCode
+	info.BodySize = len(body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if c.maxResponseSize > 0 && int64(len(body)) > c.maxResponseSize {
+		return nil, nil, fmt.Errorf("%w: over %d bytes", ErrResponseTooLarge, c.maxResponseSize)
+	}
+
+	if resp.StatusCode == http.StatusNotModified && kept != nil {
+		info.NotModified = true
+		body, pagination := kept.response()
+		return body, pagination, nil
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		var apiErr APIError
+		if err := json.Unmarshal(body, &apiErr); err == nil && apiErr.Text != "" {
+			// For known API errors, wrap with status code for retry logic
+			return nil, nil, HTTPError{
+				StatusCode: resp.StatusCode,
+				Message:    apiErr.Text,
+			}
+		}
+		return nil, nil, HTTPError{
+			StatusCode: resp.StatusCode,
+			Message:    string(body),
+		}
+	}
+
+	// Parse pagination headers if present
+	var pagination *PaginationResponse
+	if page := resp.Header.Get("X-Page"); page != "" {
+		pagination = &PaginationResponse{}
+		if p, err := strconv.Atoi(page); err == nil {
+			pagination.Page = p
+		}
+		if ps := resp.Header.Get("X-Page-Size"); ps != "" {
+			if p, err := strconv.Atoi(ps); err == nil {
+				pagination.PageSize = p
+			}
+		}
+		if pt := resp.Header.Get("X-Page-Total"); pt != "" {
+			if p, err := strconv.Atoi(pt); err == nil {
+				pagination.PageTotal = p
+			}
+		}
+		if t := resp.Header.Get("X-Result-Total"); t != "" {
+			if p, err := strconv.Atoi(t); err == nil {
+				pagination.Total = p
+			}
+		}
+	}
+
+	if conditionalKeyName != "" && resp.StatusCode == http.StatusOK {
+		if lastModified := resp.Header.Get("Last-Modified"); lastModified != "" {
+			c.conditional.set(&conditionalEntry{key: conditionalKeyName, lastModified: lastModified, body: slices.Clone(body), pagination: pagination})
+		}
+	}
+
+	return body, pagination, nil
+}
+
+// GetIDs is a generic function to get a list of IDs from an endpoint
+func GetIDs[T ~int](ctx context.Context, c *Client, endpoint string, options ...RequestOption) ([]T, error) {
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, _, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []T
+	if err := json.Unmarshal(data, &ids); err != nil {
+		return nil, fmt.Errorf("failed to parse IDs: %w", err)
+	}
+
+	return ids, nil
+}
+
+// GetByID is a generic function to get a single item by ID
+func GetByID[T any](ctx context.Context, c *Client, endpoint string, id int, options ...RequestOption) (*T, error) {
+	opts := &RequestOptions{IDs: []int{id}}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, _, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Try to unmarshal as array first (bulk expansion response)
+	var results []T
+	if err := json.Unmarshal(data, &results); err == nil && len(results) > 0 {
+		return &results[0], nil
+	}
+
+	// If that fails, try to unmarshal as single object
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
+
+// GetByIDs is a generic function to get multiple items by IDs. Entries with
+// an int ID field are returned in the order of ids, each once, with unknown
+// IDs left out. The Client getters built on it keep that order, also when
+// they answer from the data cache; order.go has the details.
+//
+// Lists longer than the API's limit of 200 IDs are split into requests of
+// at most 200, made one after another through the rate limiter. If some of
+// them fail, the entries from the others are returned along with the failures
+// joined into one error, so callers can use what was fetched. A request whose
+// IDs are all unknown isn't a failure unless every request is one. No IDs
+// make no request.
+func GetByIDs[T any](ctx context.Context, c *Client, endpoint string, ids []int, options ...RequestOption) ([]T, error) {
+	if len(ids) == 0 {
+		// Without ids= the endpoint would answer with its list of IDs
+		return nil, nil
+	}
+	if len(ids) <= maxIDsPerRequest {
+		return getByIDsOnce[T](ctx, c, endpoint, ids, options...)
+	}
+
+	var (
+		results  []T
+		errs     []error
+		notFound error
+	)
+	for chunk := range slices.Chunk(ids, maxIDsPerRequest) {
+		if err := ctx.Err(); err != nil {
+			errs = append(errs, err)
+			break
+		}
+		found, err := getByIDsOnce[T](ctx, c, endpoint, chunk, options...)
+		switch {
+		case errors.Is(err, ErrNotFound):
+			notFound = err
+		case err != nil:
+			errs = append(errs, fmt.Errorf("%d IDs from %d: %w", len(chunk), chunk[0], err))
+		}
+		results = append(results, found...)
+	}
+	if len(results) == 0 && len(errs) == 0 {
+		return nil, notFound
+	}
+
+	if id := idOf[T](); id != nil {
+		results = orderByIDs(results, ids, id)
+	}
+	return results, errors.Join(errs...)
+}
+
+// getByIDsOnce gets entries by IDs in one request
+func getByIDsOnce[T any](ctx context.Context, c *Client, endpoint string, ids []int, options ...RequestOption) ([]T, error) {
+	opts := &RequestOptions{IDs: ids}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, _, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []T
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	// The API answers in its own order, see the contract in order.go
+	if id := idOf[T](); id != nil {
+		results = orderByIDs(results, ids, id)
+	}
+	return results, nil
+}
+
+// GetAll is a generic function to get all items from an endpoint
+func GetAll[T any](ctx context.Context, c *Client, endpoint string, options ...RequestOption) ([]T, error) {
+	opts := &RequestOptions{All: true}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, _, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []T
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return results, nil
+}
+
+// GetPaged is a generic function to get a page of items
+func GetPaged[T any](ctx context.Context, c *Client, endpoint string, options ...RequestOption) ([]T, *PaginationResponse, error) {
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, pagination, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var results []T
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return results, pagination, nil
+}
+
+// GetSingle is a generic function to get a single item (not by ID)
+func GetSingle[T any](ctx context.Context, c *Client, endpoint string, options ...RequestOption) (*T, error) {
+	opts := &RequestOptions{}
+	for _, opt := range options {
+		opt(opts)
+	}
+
+	data, _, err := c.get(ctx, endpoint, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var result T
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	return &result, nil
+}
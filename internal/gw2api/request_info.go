@@ -0,0 +1,83 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes a single HTTP attempt made by the client
+type RequestInfo struct {
+	RequestID     string        // Short ID shared by every attempt of one logical request
+	Endpoint      string        // API path without query, e.g. "/v2/items"
+	URL           string        // Full request URL with the access token redacted
+	Attempt       int           // 1 for the first try, 2 for the first retry, ...
+	RateLimitWait time.Duration // Time spent waiting for the client's rate limiter
+	Duration      time.Duration // HTTP round trip including reading the body
+	StatusCode    int           // 0 when no response was received
+	BodySize      int
+	Backoff       time.Duration // Delay before the next attempt, 0 when not retrying
+	Err           error
+	NotModified   bool            // The API answered 304 and a kept response was used, see WithConditionalRequests
+	Context       context.Context // Context the request was made with, for per-caller bookkeeping
+}
+
+// ResponseHook is called after every HTTP attempt, including failed ones
+type ResponseHook func(RequestInfo)
+
+// WithResponseHook registers a hook that receives timing and retry details for
+// every request. Hooks run synchronously on the requesting goroutine.
+func WithResponseHook(hook ResponseHook) ClientOption {
+	return func(c *Client) {
+		c.responseHooks = append(c.responseHooks, hook)
+	}
+}
+
+// newRequestID returns a short random ID for correlating log lines
+func newRequestID() string {
+	return fmt.Sprintf("%08x", rand.Uint32())
+}
+
+// redactURL hides the access token in a request URL
+func redactURL(u *url.URL) string {
+	q := u.Query()
+	if q.Has("access_token") {
+		q.Set("access_token", "REDACTED")
+		redacted := *u
+		redacted.RawQuery = q.Encode()
+		return redacted.String()
+	}
+	return u.String()
+}
+
+// reportRequest counts an attempt in the client's stats, logs it when verbose
+// and passes it to the response hooks
+func (c *Client) reportRequest(info RequestInfo) {
+	c.countRequest(info)
+	if c.verbose {
+		var b strings.Builder
+		fmt.Fprintf(&b, "[API] req=%s attempt=%d GET %s", info.RequestID, info.Attempt, info.URL)
+		if info.StatusCode != 0 {
+			fmt.Fprintf(&b, " status=%d bytes=%d", info.StatusCode, info.BodySize)
+		}
+		if info.NotModified {
+			b.WriteString(" not_modified")
+		}
+		fmt.Fprintf(&b, " wait=%s took=%s", info.RateLimitWait.Round(time.Millisecond), info.Duration.Round(time.Millisecond))
+		if info.Err != nil {
+			fmt.Fprintf(&b, " error=%q", info.Err)
+		}
+		if info.Backoff > 0 {
+			fmt.Fprintf(&b, " retry_in=%s", info.Backoff)
+		}
+		log.Print(b.String())
+	}
+
+	for _, hook := range c.responseHooks {
+		hook(info)
+	}
+}
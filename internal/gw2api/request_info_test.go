@@ -0,0 +1,207 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestResponseHookRetryContext(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"text": "API not active"}`))
+			return
+		}
+		w.Write([]byte(`{"id": 115267}`))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := NewClient(
+		WithAPIKey("secret-key"),
+		WithQueryParamAuth(),
+		WithRetryConfig(&RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffMultiple: 1}),
+		WithResponseHook(func(info RequestInfo) { infos = append(infos, info) }),
+	)
+	client.baseURL = server.URL
+
+	if _, err := client.GetBuild(context.Background()); err != nil {
+		t.Fatalf("GetBuild: %v", err)
+	}
+
+	if len(infos) != 2 {
+		t.Fatalf("got %d hook calls, expected 2", len(infos))
+	}
+
+	first, second := infos[0], infos[1]
+	if first.RequestID == "" || first.RequestID != second.RequestID {
+		t.Errorf("request IDs %q and %q should match", first.RequestID, second.RequestID)
+	}
+	if first.Attempt != 1 || second.Attempt != 2 {
+		t.Errorf("attempts = %d, %d, expected 1, 2", first.Attempt, second.Attempt)
+	}
+	if first.StatusCode != http.StatusServiceUnavailable || first.Err == nil || first.Backoff != time.Millisecond {
+		t.Errorf("first attempt = %+v, expected 503 with an error and 1ms backoff", first)
+	}
+	if second.StatusCode != http.StatusOK || second.Err != nil || second.Backoff != 0 || second.BodySize == 0 {
+		t.Errorf("second attempt = %+v, expected 200 without backoff", second)
+	}
+	if second.Endpoint != "/v2/build" {
+		t.Errorf("Endpoint = %q, expected /v2/build", second.Endpoint)
+	}
+	if strings.Contains(second.URL, "secret-key") || !strings.Contains(second.URL, "access_token=REDACTED") {
+		t.Errorf("URL %q should have the access token redacted", second.URL)
+	}
+}
+
+func TestResponseHookWithoutRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"text": "no such id"}`))
+	}))
+	defer server.Close()
+
+	var infos []RequestInfo
+	client := NewClient(WithRetries(0), WithResponseHook(func(info RequestInfo) { infos = append(infos, info) }))
+	client.baseURL = server.URL
+
+	_, err := client.GetBuild(context.Background())
+	if _, ok := err.(HTTPError); !ok {
+		t.Fatalf("expected an unwrapped HTTPError without retries, got %v", err)
+	}
+	if len(infos) != 1 || infos[0].StatusCode != http.StatusNotFound || infos[0].Backoff != 0 {
+		t.Errorf("hook calls = %+v, expected a single 404 without backoff", infos)
+	}
+}
+
+func TestErrNotFound(t *testing.T) {
+	tests := []struct {
+		status   int
+		expected bool
+	}{
+		{http.StatusNotFound, true},
+		{http.StatusServiceUnavailable, false},
+		{http.StatusBadRequest, false},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			w.Write([]byte(`{"text":"all ids provided are invalid"}`))
+		}))
+
+		// Retries wrap the final error, which must still match
+		client := NewClient(
+			WithBaseURL(server.URL),
+			WithRetryConfig(&RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffMultiple: 1}),
+			WithRateLimit(1000),
+		)
+		_, err := client.GetItems(context.Background(), []int{99999999})
+		server.Close()
+
+		if err == nil {
+			t.Fatalf("HTTP %d: expected an error", tt.status)
+		}
+		if got := errors.Is(err, ErrNotFound); got != tt.expected {
+			t.Errorf("HTTP %d: errors.Is(err, ErrNotFound) = %v, expected %v (%v)", tt.status, got, tt.expected, err)
+		}
+	}
+}
+
+func TestErrMissingScope(t *testing.T) {
+	err := fmt.Errorf("request failed: %w", HTTPError{StatusCode: http.StatusForbidden, Message: "requires scope pvp"})
+	if !errors.Is(err, ErrMissingScope) || errors.Is(err, ErrNotFound) {
+		t.Errorf("HTTP 403 should only match ErrMissingScope")
+	}
+	if errors.Is(HTTPError{StatusCode: http.StatusNotFound}, ErrMissingScope) {
+		t.Errorf("HTTP 404 matched ErrMissingScope")
+	}
+}
+
+func TestWithRequestRetries(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"text": "API not active"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithRateLimit(1000), WithRetryConfig(&RetryConfig{MaxRetries: 1, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffMultiple: 1}))
+	client.baseURL = server.URL
+
+	for _, tt := range []struct {
+		options  []RequestOption
+		expected int32
+	}{
+		{nil, 2},
+		{[]RequestOption{WithRequestRetries(0)}, 1},
+		{[]RequestOption{WithRequestRetries(-1)}, 1},
+		{[]RequestOption{WithRequestRetries(3)}, 4},
+	} {
+		calls.Store(0)
+		if _, err := client.GetItems(context.Background(), []int{1}, tt.options...); err == nil {
+			t.Fatal("expected an error from the failing API")
+		}
+		if got := calls.Load(); got != tt.expected {
+			t.Errorf("%d options: made %d attempts, expected %d", len(tt.options), got, tt.expected)
+		}
+	}
+}
+
+// TestSharedRequestOptions runs requests on many goroutines with the same
+// options, as the web server does, for go test -race to check
+func TestSharedRequestOptions(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"text": "API not active"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithRateLimit(1000))
+	shared := []RequestOption{WithRequestRetries(-1), WithRequestTimeout(time.Second), WithParam("lang", "en")}
+	var wg sync.WaitGroup
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.GetItems(context.Background(), []int{1}, shared...)
+		}()
+	}
+	wg.Wait()
+	if got := calls.Load(); got != 16 {
+		t.Errorf("made %d attempts, expected one for each of 16 requests", got)
+	}
+}
+
+func TestWithRequestTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"text": "API not active"}`))
+	}))
+	defer server.Close()
+
+	// Without the timeout, the retries would back off for 10 seconds
+	client := NewClient(WithRetryConfig(&RetryConfig{MaxRetries: 10, BaseDelay: time.Second, MaxDelay: time.Second, BackoffMultiple: 1}))
+	client.baseURL = server.URL
+
+	start := time.Now()
+	_, err := client.GetItems(context.Background(), []int{1}, WithRequestTimeout(50*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("err = %v, expected the deadline to be exceeded", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("took %v, expected the timeout to cut the retries short", elapsed)
+	}
+}
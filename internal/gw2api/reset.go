@@ -0,0 +1,70 @@
+package gw2api
+
+import "time"
+
+// ResetPeriod is how often an account's progress on something resets, such as
+// world bosses each day and raid encounters each week
+type ResetPeriod int
+
+const (
+	DailyReset  ResetPeriod = iota // Every day at 00:00 UTC
+	WeeklyReset                    // Every Monday at 07:30 UTC
+)
+
+// weeklyResetOffset is when the weekly reset happens on Mondays, in UTC
+const weeklyResetOffset = 7*time.Hour + 30*time.Minute
+
+// LastDailyReset returns the latest daily reset at or before now, in UTC
+func LastDailyReset(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// NextDailyReset returns the first daily reset after now, in UTC
+func NextDailyReset(now time.Time) time.Time {
+	return LastDailyReset(now).AddDate(0, 0, 1)
+}
+
+// LastWeeklyReset returns the latest weekly reset at or before now, in UTC
+func LastWeeklyReset(now time.Time) time.Time {
+	// Days back to this week's Monday, counting Monday as the start of the week
+	monday := LastDailyReset(now).AddDate(0, 0, -(int(now.UTC().Weekday())+6)%7)
+	reset := monday.Add(weeklyResetOffset)
+	if reset.After(now) {
+		// Early on Monday, before this week's reset
+		reset = reset.AddDate(0, 0, -7)
+	}
+	return reset
+}
+
+// NextWeeklyReset returns the first weekly reset after now, in UTC
+func NextWeeklyReset(now time.Time) time.Time {
+	return LastWeeklyReset(now).AddDate(0, 0, 7)
+}
+
+// LastReset returns the latest reset of the period at or before now, in UTC
+func LastReset(period ResetPeriod, now time.Time) time.Time {
+	if period == WeeklyReset {
+		return LastWeeklyReset(now)
+	}
+	return LastDailyReset(now)
+}
+
+// NextReset returns the first reset of the period after now, in UTC
+func NextReset(period ResetPeriod, now time.Time) time.Time {
+	if period == WeeklyReset {
+		return NextWeeklyReset(now)
+	}
+	return NextDailyReset(now)
+}
+
+// TimeUntilReset returns how long after now the period next resets
+func TimeUntilReset(period ResetPeriod, now time.Time) time.Duration {
+	return NextReset(period, now).Sub(now)
+}
+
+// CompletedSinceReset reports whether something completed at the given time
+// still counts as done at now, because the period hasn't reset in between
+func CompletedSinceReset(period ResetPeriod, completed, now time.Time) bool {
+	return !completed.Before(LastReset(period, now))
+}
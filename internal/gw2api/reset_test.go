@@ -0,0 +1,101 @@
+package gw2api
+
+import (
+	"testing"
+	"time"
+)
+
+func utc(value string) time.Time {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestDailyReset(t *testing.T) {
+	tests := []struct {
+		now, last, next string
+	}{
+		{"2024-03-13T12:00:00Z", "2024-03-13T00:00:00Z", "2024-03-14T00:00:00Z"},
+		{"2024-03-13T00:00:00Z", "2024-03-13T00:00:00Z", "2024-03-14T00:00:00Z"},
+		{"2024-03-12T23:59:59Z", "2024-03-12T00:00:00Z", "2024-03-13T00:00:00Z"},
+		// Month, leap day and year boundaries
+		{"2024-02-29T18:00:00Z", "2024-02-29T00:00:00Z", "2024-03-01T00:00:00Z"},
+		{"2024-12-31T23:00:00Z", "2024-12-31T00:00:00Z", "2025-01-01T00:00:00Z"},
+		// Local times count from the UTC day they fall in
+		{"2024-03-13T20:00:00-08:00", "2024-03-14T00:00:00Z", "2024-03-15T00:00:00Z"},
+		{"2024-03-14T01:00:00+05:00", "2024-03-13T00:00:00Z", "2024-03-14T00:00:00Z"},
+	}
+	for _, tt := range tests {
+		now := utc(tt.now)
+		if last := LastDailyReset(now); !last.Equal(utc(tt.last)) || last.Location() != time.UTC {
+			t.Errorf("LastDailyReset(%s) = %s, expected %s", tt.now, last, tt.last)
+		}
+		if next := NextDailyReset(now); !next.Equal(utc(tt.next)) {
+			t.Errorf("NextDailyReset(%s) = %s, expected %s", tt.now, next, tt.next)
+		}
+	}
+}
+
+func TestWeeklyReset(t *testing.T) {
+	// 2024-03-11 is a Monday
+	tests := []struct {
+		now, last, next string
+	}{
+		{"2024-03-13T12:00:00Z", "2024-03-11T07:30:00Z", "2024-03-18T07:30:00Z"},
+		// Monday before, at and after the reset
+		{"2024-03-11T07:29:59Z", "2024-03-04T07:30:00Z", "2024-03-11T07:30:00Z"},
+		{"2024-03-11T07:30:00Z", "2024-03-11T07:30:00Z", "2024-03-18T07:30:00Z"},
+		{"2024-03-11T00:00:00Z", "2024-03-04T07:30:00Z", "2024-03-11T07:30:00Z"},
+		// Sunday is the end of the week, not the start
+		{"2024-03-17T23:59:59Z", "2024-03-11T07:30:00Z", "2024-03-18T07:30:00Z"},
+		{"2024-03-10T08:00:00Z", "2024-03-04T07:30:00Z", "2024-03-11T07:30:00Z"},
+		// Weeks spanning a month and a year
+		{"2024-03-01T09:00:00Z", "2024-02-26T07:30:00Z", "2024-03-04T07:30:00Z"},
+		{"2025-01-01T00:00:00Z", "2024-12-30T07:30:00Z", "2025-01-06T07:30:00Z"},
+		// Late Sunday evening in the US is Monday morning in UTC, either side
+		// of the reset
+		{"2024-03-10T23:15:00-08:00", "2024-03-04T07:30:00Z", "2024-03-11T07:30:00Z"},
+		{"2024-03-10T23:45:00-08:00", "2024-03-11T07:30:00Z", "2024-03-18T07:30:00Z"},
+	}
+	for _, tt := range tests {
+		now := utc(tt.now)
+		if last := LastWeeklyReset(now); !last.Equal(utc(tt.last)) || last.Location() != time.UTC {
+			t.Errorf("LastWeeklyReset(%s) = %s, expected %s", tt.now, last, tt.last)
+		}
+		if next := NextWeeklyReset(now); !next.Equal(utc(tt.next)) {
+			t.Errorf("NextWeeklyReset(%s) = %s, expected %s", tt.now, next, tt.next)
+		}
+	}
+}
+
+func TestTimeUntilReset(t *testing.T) {
+	now := utc("2024-03-11T06:00:00Z")
+	if got := TimeUntilReset(DailyReset, now); got != 18*time.Hour {
+		t.Errorf("TimeUntilReset(DailyReset) = %s, expected 18h", got)
+	}
+	if got := TimeUntilReset(WeeklyReset, now); got != 90*time.Minute {
+		t.Errorf("TimeUntilReset(WeeklyReset) = %s, expected 1h30m", got)
+	}
+}
+
+func TestCompletedSinceReset(t *testing.T) {
+	now := utc("2024-03-11T08:00:00Z")
+	tests := []struct {
+		period    ResetPeriod
+		completed string
+		expected  bool
+	}{
+		{DailyReset, "2024-03-11T00:00:00Z", true},
+		{DailyReset, "2024-03-10T23:59:59Z", false},
+		{WeeklyReset, "2024-03-11T07:30:00Z", true},
+		{WeeklyReset, "2024-03-11T07:29:59Z", false},
+		{WeeklyReset, "2024-03-11T07:45:00+01:00", false},
+	}
+	for _, tt := range tests {
+		if got := CompletedSinceReset(tt.period, utc(tt.completed), now); got != tt.expected {
+			t.Errorf("CompletedSinceReset(%d, %s) = %v, expected %v", tt.period, tt.completed, got, tt.expected)
+		}
+	}
+}
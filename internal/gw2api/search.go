@@ -3,24 +3,34 @@ package gw2api
 import (
 	"context"
 	"fmt"
+	"math"
 	"slices"
 	"strings"
 )
 
 // ItemSearchOptions represents search options for items
 type ItemSearchOptions struct {
-	Name        string   // Partial name to search for
-	Rarities    []string // Filter by rarity (e.g., "Basic", "Fine", "Masterwork", "Rare", "Exotic", "Ascended", "Legendary")
-	Types       []string // Filter by type (e.g., "Armor", "Weapon", "Trinket", "Consumable", etc.)
-	MinLevel    int      // Minimum level requirement
-	MaxLevel    int      // Maximum level requirement
-	Limit       int      // Maximum number of results to return (0 = no limit)
-	UnlocksSkin int      // Filter items that unlock a specific skin
+	Name         string     // Partial name to search for
+	Rarities     []string   // Filter by rarity (e.g., "Basic", "Fine", "Masterwork", "Rare", "Exotic", "Ascended", "Legendary")
+	Types        []string   // Filter by type (e.g., "Armor", "Weapon", "Trinket", "Consumable", etc.)
+	RarityFilter []Rarity   // Filter by rarity, along with Rarities
+	TypeFilter   []ItemType // Filter by type, along with Types
+	MinLevel     int        // Minimum level requirement
+	MaxLevel     int        // Maximum level requirement
+	Limit        int        // Maximum number of results to return (0 = no limit)
+	UnlocksSkin  int        // Filter items that unlock a specific skin
+	Language     Language   // Match Name in this language, if the data cache has its names loaded
+	TradableOnly bool       // Only items that can be listed on the trading post
 }
 
 // SearchItems searches for items based on the provided criteria
 // This function uses cached data if available, otherwise falls back to API
 func (c *Client) SearchItems(ctx context.Context, options ItemSearchOptions) ([]*Item, error) {
+	options, err := options.normalized()
+	if err != nil {
+		return nil, err
+	}
+
 	// Try cache first if available
 	if c.dataCache != nil && c.dataCache.GetItemCache().IsLoaded() {
 		return c.dataCache.GetItemCache().SearchItems(options), nil
@@ -29,7 +39,46 @@ func (c *Client) SearchItems(ctx context.Context, options ItemSearchOptions) ([]
 	return nil, fmt.Errorf("item search requires data cache to be loaded")
 }
 
-// matchesSearchCriteria checks if an item matches the search criteria
+// normalized returns the options with the string and typed filters merged
+// into RarityFilter and TypeFilter in their canonical case, or an error naming
+// the first rarity or type that isn't known
+func (options ItemSearchOptions) normalized() (ItemSearchOptions, error) {
+	rarities, err := parseFilter(ParseRarity, options.Rarities, options.RarityFilter)
+	if err != nil {
+		return options, err
+	}
+	types, err := parseFilter(ParseItemType, options.Types, options.TypeFilter)
+	if err != nil {
+		return options, err
+	}
+	options.Rarities, options.Types = nil, nil
+	options.RarityFilter, options.TypeFilter = rarities, types
+	return options, nil
+}
+
+// parseFilter parses the string values of a filter followed by its typed ones
+func parseFilter[T ~string](parse func(string) (T, error), names []string, values []T) ([]T, error) {
+	parsed := make([]T, 0, len(names)+len(values))
+	for _, name := range names {
+		value, err := parse(name)
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, value)
+	}
+	for _, value := range values {
+		value, err := parse(string(value))
+		if err != nil {
+			return nil, err
+		}
+		parsed = append(parsed, value)
+	}
+	return parsed, nil
+}
+
+// matchesSearchCriteria checks if an item matches the search criteria.
+// Rarities and Types are matched ignoring case, and RarityFilter and
+// TypeFilter exactly, as normalized leaves them.
 func matchesSearchCriteria(item *Item, options ItemSearchOptions) bool {
 	// Check name match (case-insensitive partial match)
 	if options.Name != "" {
@@ -38,32 +87,18 @@ func matchesSearchCriteria(item *Item, options ItemSearchOptions) bool {
 		}
 	}
 
-	// Check rarity filter
-	if len(options.Rarities) > 0 {
-		found := false
-		for _, rarity := range options.Rarities {
-			if strings.EqualFold(item.Rarity, rarity) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+	// Check rarity and type filters
+	if len(options.Rarities) > 0 && !containsFold(options.Rarities, item.Rarity) {
+		return false
 	}
-
-	// Check type filter
-	if len(options.Types) > 0 {
-		found := false
-		for _, itemType := range options.Types {
-			if strings.EqualFold(item.Type, itemType) {
-				found = true
-				break
-			}
-		}
-		if !found {
-			return false
-		}
+	if len(options.Types) > 0 && !containsFold(options.Types, item.Type) {
+		return false
+	}
+	if len(options.RarityFilter) > 0 && !slices.Contains(options.RarityFilter, Rarity(item.Rarity)) {
+		return false
+	}
+	if len(options.TypeFilter) > 0 && !slices.Contains(options.TypeFilter, ItemType(item.Type)) {
+		return false
 	}
 
 	// Check level range
@@ -79,6 +114,10 @@ func matchesSearchCriteria(item *Item, options ItemSearchOptions) bool {
 		return false // Item does not unlock the specified skin
 	}
 
+	if options.TradableOnly && !item.IsTradable() {
+		return false
+	}
+
 	return true
 }
 
@@ -90,7 +129,7 @@ func (c *Client) GetItemsByName(ctx context.Context, name string, limit int) ([]
 	})
 }
 
-// GetItemsByRarity finds items by rarity
+// GetItemsByRarity finds items by rarity, which is parsed with ParseRarity
 func (c *Client) GetItemsByRarity(ctx context.Context, rarity string, limit int) ([]*Item, error) {
 	return c.SearchItems(ctx, ItemSearchOptions{
 		Rarities: []string{rarity},
@@ -144,3 +183,40 @@ func (c *Client) GetSkillsByProfession(ctx context.Context, profession string, l
 		Limit:      limit,
 	})
 }
+
+// RecipeSearchOptions represents search options for recipes
+type RecipeSearchOptions struct {
+	Disciplines    []string // Filter by crafting discipline (e.g., "Tailor", "Chef"), ignoring case
+	MinRating      int      // Lowest required rating to include
+	MaxRating      int      // Highest required rating to include (0 = no maximum)
+	OutputItemName string   // Partial name of the crafted item; needs the item cache
+	OutputItemIDs  []int    // Filter by crafted item
+	Limit          int      // Maximum number of results to return (0 = no limit)
+}
+
+// SearchRecipes searches the recipe cache. OutputItemName is matched against
+// the item cache and narrows OutputItemIDs to the items it finds.
+func (c *Client) SearchRecipes(ctx context.Context, options RecipeSearchOptions) ([]*RecipeDetail, error) {
+	if c.dataCache == nil || !c.dataCache.GetRecipeCache().IsLoaded() {
+		return nil, fmt.Errorf("recipe search requires data cache to be loaded")
+	}
+
+	if options.OutputItemName != "" {
+		items, err := c.SearchItems(ctx, ItemSearchOptions{Name: options.OutputItemName, Limit: math.MaxInt})
+		if err != nil {
+			return nil, err
+		}
+		var ids []int
+		for _, item := range items {
+			if len(options.OutputItemIDs) == 0 || slices.Contains(options.OutputItemIDs, item.ID) {
+				ids = append(ids, item.ID)
+			}
+		}
+		if len(ids) == 0 {
+			return nil, nil
+		}
+		options.OutputItemIDs = ids
+	}
+
+	return c.dataCache.GetRecipeCache().SearchRecipes(options), nil
+}
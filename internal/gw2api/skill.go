@@ -27,33 +27,6 @@ type Skill struct {
 	Flags           []string      `json:"flags,omitempty"`
 }
 
-// SkillFact represents a fact about a skill
-type SkillFact struct {
-	Text          string  `json:"text,omitempty"`
-	Type          string  `json:"type"`
-	Icon          string  `json:"icon,omitempty"`
-	Value         any     `json:"value,omitempty"`
-	Target        string  `json:"target,omitempty"`
-	Status        string  `json:"status,omitempty"`
-	Description   string  `json:"description,omitempty"`
-	ApplyCount    int     `json:"apply_count,omitempty"`
-	Duration      int     `json:"duration,omitempty"`
-	FieldType     string  `json:"field_type,omitempty"`
-	FinisherType  string  `json:"finisher_type,omitempty"`
-	Percent       float64 `json:"percent,omitempty"`
-	HitCount      int     `json:"hit_count,omitempty"`
-	DmgMultiplier float64 `json:"dmg_multiplier,omitempty"`
-	Distance      int     `json:"distance,omitempty"`
-	Prefix        any     `json:"prefix,omitempty"`
-}
-
-// TraitedFact represents a skill fact that is modified by traits
-type TraitedFact struct {
-	SkillFact
-	RequiresTrait int `json:"requires_trait"`
-	Overrides     int `json:"overrides,omitempty"`
-}
-
 // Specialization represents a trait line/specialization
 type Specialization struct {
 	ID          int    `json:"id"`
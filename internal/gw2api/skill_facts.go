@@ -0,0 +1,495 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Fact types used by skills and traits
+const (
+	FactAttributeAdjust = "AttributeAdjust"
+	FactBuff            = "Buff"
+	FactBuffConversion  = "BuffConversion"
+	FactComboField      = "ComboField"
+	FactComboFinisher   = "ComboFinisher"
+	FactDamage          = "Damage"
+	FactDistance        = "Distance"
+	FactDuration        = "Duration"
+	FactHeal            = "Heal"
+	FactHealingAdjust   = "HealingAdjust"
+	FactNoData          = "NoData"
+	FactNumber          = "Number"
+	FactPercent         = "Percent"
+	FactPrefixedBuff    = "PrefixedBuff"
+	FactRadius          = "Radius"
+	FactRange           = "Range"
+	FactRecharge        = "Recharge"
+	FactStunBreak       = "StunBreak"
+	FactTime            = "Time"
+	FactUnblockable     = "Unblockable"
+)
+
+// SkillFact is one line of a skill or trait tooltip, such as its damage,
+// recharge or a boon it applies. The fields that depend on Type are decoded
+// into Detail.
+type SkillFact struct {
+	Type   string     `json:"type"`
+	Text   string     `json:"text,omitempty"`
+	Icon   string     `json:"icon,omitempty"`
+	Detail FactDetail `json:"-"`
+}
+
+// FactDetail holds the type-specific fields of a fact. It is one of the *Fact
+// types below, or UnknownFact for types this package does not know.
+type FactDetail interface {
+	// Summary is the fact's value as shown in a tooltip, such as "5s" or "x3"
+	Summary() string
+}
+
+// AttributeAdjustFact changes an attribute by Value
+type AttributeAdjustFact struct {
+	Value  int    `json:"value"`
+	Target string `json:"target,omitempty"`
+}
+
+func (f AttributeAdjustFact) Summary() string {
+	return fmt.Sprintf("%+d %s", f.Value, f.Target)
+}
+
+// BuffFact applies a boon or condition. Duration is in seconds.
+type BuffFact struct {
+	Status      string `json:"status"`
+	Description string `json:"description,omitempty"`
+	ApplyCount  int    `json:"apply_count,omitempty"`
+	Duration    int    `json:"duration,omitempty"`
+}
+
+func (f BuffFact) Summary() string {
+	var b strings.Builder
+	b.WriteString(f.Status)
+	if f.ApplyCount > 1 {
+		fmt.Fprintf(&b, " x%d", f.ApplyCount)
+	}
+	if f.Duration > 0 {
+		fmt.Fprintf(&b, " (%ds)", f.Duration)
+	}
+	if f.Description != "" {
+		b.WriteString(": " + f.Description)
+	}
+	return b.String()
+}
+
+// PrefixedBuffFact is a buff shown after another, such as a condition applied
+// by a boon
+type PrefixedBuffFact struct {
+	BuffFact
+	Prefix FactPrefix `json:"prefix"`
+}
+
+// FactPrefix is the buff shown before a PrefixedBuffFact
+type FactPrefix struct {
+	Text        string `json:"text,omitempty"`
+	Icon        string `json:"icon,omitempty"`
+	Status      string `json:"status,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+func (f PrefixedBuffFact) Summary() string {
+	if f.Prefix.Status == "" {
+		return f.BuffFact.Summary()
+	}
+	return f.Prefix.Status + " → " + f.BuffFact.Summary()
+}
+
+// BuffConversionFact grants Percent of the Source attribute as Target
+type BuffConversionFact struct {
+	Source  string `json:"source"`
+	Target  string `json:"target"`
+	Percent int    `json:"percent"`
+}
+
+func (f BuffConversionFact) Summary() string {
+	return fmt.Sprintf("%d%% of %s as %s", f.Percent, f.Source, f.Target)
+}
+
+// ComboFieldFact is the combo field a skill leaves
+type ComboFieldFact struct {
+	FieldType string `json:"field_type"`
+}
+
+func (f ComboFieldFact) Summary() string {
+	return f.FieldType
+}
+
+// ComboFinisherFact is a combo finisher with a Percent chance to trigger
+type ComboFinisherFact struct {
+	FinisherType string `json:"finisher_type"`
+	Percent      int    `json:"percent"`
+}
+
+func (f ComboFinisherFact) Summary() string {
+	if f.Percent > 0 && f.Percent < 100 {
+		return fmt.Sprintf("%s (%d%% chance)", f.FinisherType, f.Percent)
+	}
+	return f.FinisherType
+}
+
+// DamageFact is a strike over HitCount hits with a total power coefficient of
+// DmgMultiplier
+type DamageFact struct {
+	HitCount      int     `json:"hit_count"`
+	DmgMultiplier float64 `json:"dmg_multiplier"`
+}
+
+func (f DamageFact) Summary() string {
+	summary := "coefficient " + formatFactNumber(f.DmgMultiplier)
+	if f.HitCount > 1 {
+		summary = fmt.Sprintf("%dx, %s", f.HitCount, summary)
+	}
+	return summary
+}
+
+// DistanceFact is a distance in game units, such as a leap
+type DistanceFact struct {
+	Distance int `json:"distance"`
+}
+
+func (f DistanceFact) Summary() string {
+	return strconv.Itoa(f.Distance)
+}
+
+// DurationFact and TimeFact are lengths of time in seconds
+type DurationFact struct {
+	Duration float64 `json:"duration"`
+}
+
+func (f DurationFact) Summary() string {
+	return formatFactNumber(f.Duration) + "s"
+}
+
+type TimeFact struct {
+	Duration float64 `json:"duration"`
+}
+
+func (f TimeFact) Summary() string {
+	return formatFactNumber(f.Duration) + "s"
+}
+
+// HealFact and HealingAdjustFact heal over HitCount pulses
+type HealFact struct {
+	HitCount int `json:"hit_count"`
+}
+
+func (f HealFact) Summary() string {
+	return fmt.Sprintf("%dx", f.HitCount)
+}
+
+type HealingAdjustFact struct {
+	HitCount int `json:"hit_count"`
+}
+
+func (f HealingAdjustFact) Summary() string {
+	return fmt.Sprintf("%dx", f.HitCount)
+}
+
+// NumberFact is a plain count, such as the number of targets
+type NumberFact struct {
+	Value int `json:"value"`
+}
+
+func (f NumberFact) Summary() string {
+	return strconv.Itoa(f.Value)
+}
+
+// PercentFact is a percentage, such as a damage or movement speed bonus
+type PercentFact struct {
+	Percent float64 `json:"percent"`
+}
+
+func (f PercentFact) Summary() string {
+	return formatFactNumber(f.Percent) + "%"
+}
+
+// RadiusFact is an area of effect radius in game units
+type RadiusFact struct {
+	Distance int `json:"distance"`
+}
+
+func (f RadiusFact) Summary() string {
+	return strconv.Itoa(f.Distance)
+}
+
+// RangeFact is a skill's range in game units
+type RangeFact struct {
+	Value int `json:"value"`
+}
+
+func (f RangeFact) Summary() string {
+	return strconv.Itoa(f.Value)
+}
+
+// RechargeFact is a cooldown in seconds
+type RechargeFact struct {
+	Value float64 `json:"value"`
+}
+
+func (f RechargeFact) Summary() string {
+	return formatFactNumber(f.Value) + "s"
+}
+
+// StunBreakFact and UnblockableFact are flags that are true when present
+type StunBreakFact struct {
+	Value bool `json:"value"`
+}
+
+func (f StunBreakFact) Summary() string {
+	return ""
+}
+
+type UnblockableFact struct {
+	Value bool `json:"value"`
+}
+
+func (f UnblockableFact) Summary() string {
+	return ""
+}
+
+// NoDataFact is a line of text without a value
+type NoDataFact struct{}
+
+func (f NoDataFact) Summary() string {
+	return ""
+}
+
+// UnknownFact keeps the fields of a fact type this package does not know, so
+// it survives being decoded and encoded again
+type UnknownFact struct {
+	Fields map[string]json.RawMessage
+}
+
+func (f UnknownFact) Summary() string {
+	return ""
+}
+
+// newFactDetail returns an empty detail for the fact type, or nil if unknown
+func newFactDetail(factType string) FactDetail {
+	switch factType {
+	case FactAttributeAdjust:
+		return &AttributeAdjustFact{}
+	case FactBuff:
+		return &BuffFact{}
+	case FactBuffConversion:
+		return &BuffConversionFact{}
+	case FactComboField:
+		return &ComboFieldFact{}
+	case FactComboFinisher:
+		return &ComboFinisherFact{}
+	case FactDamage:
+		return &DamageFact{}
+	case FactDistance:
+		return &DistanceFact{}
+	case FactDuration:
+		return &DurationFact{}
+	case FactHeal:
+		return &HealFact{}
+	case FactHealingAdjust:
+		return &HealingAdjustFact{}
+	case FactNoData:
+		return &NoDataFact{}
+	case FactNumber:
+		return &NumberFact{}
+	case FactPercent:
+		return &PercentFact{}
+	case FactPrefixedBuff:
+		return &PrefixedBuffFact{}
+	case FactRadius:
+		return &RadiusFact{}
+	case FactRange:
+		return &RangeFact{}
+	case FactRecharge:
+		return &RechargeFact{}
+	case FactStunBreak:
+		return &StunBreakFact{}
+	case FactTime:
+		return &TimeFact{}
+	case FactUnblockable:
+		return &UnblockableFact{}
+	}
+	return nil
+}
+
+// factHeader holds the fields every fact has
+type factHeader struct {
+	Type string `json:"type"`
+	Text string `json:"text,omitempty"`
+	Icon string `json:"icon,omitempty"`
+}
+
+// UnmarshalJSON decodes the common fields, then the rest into the Detail type
+// matching the fact's type
+func (f *SkillFact) UnmarshalJSON(data []byte) error {
+	var header factHeader
+	if err := json.Unmarshal(data, &header); err != nil {
+		return err
+	}
+	*f = SkillFact{Type: header.Type, Text: header.Text, Icon: header.Icon}
+
+	detail := newFactDetail(header.Type)
+	if detail == nil {
+		var fields map[string]json.RawMessage
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return err
+		}
+		for _, name := range []string{"type", "text", "icon"} {
+			delete(fields, name)
+		}
+		f.Detail = UnknownFact{Fields: fields}
+		return nil
+	}
+	if err := json.Unmarshal(data, detail); err != nil {
+		return fmt.Errorf("invalid %s fact: %w", header.Type, err)
+	}
+
+	// Details are stored as values, so copies of a fact never share one
+	f.Detail = derefDetail(detail)
+	return nil
+}
+
+// MarshalJSON writes the fact in the API's flat format
+func (f SkillFact) MarshalJSON() ([]byte, error) {
+	fields := make(map[string]json.RawMessage)
+	switch detail := f.Detail.(type) {
+	case nil:
+	case UnknownFact:
+		maps.Copy(fields, detail.Fields)
+	default:
+		data, err := json.Marshal(detail)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &fields); err != nil {
+			return nil, err
+		}
+	}
+
+	header, err := json.Marshal(factHeader{Type: f.Type, Text: f.Text, Icon: f.Icon})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(header, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(fields)
+}
+
+// Summary is the fact's value as shown in a tooltip, empty for facts that are
+// only text
+func (f SkillFact) Summary() string {
+	if f.Detail == nil {
+		return ""
+	}
+	return f.Detail.Summary()
+}
+
+// String renders the fact as one tooltip line, such as "Recharge: 20s"
+func (f SkillFact) String() string {
+	label := f.Text
+	if label == "" {
+		label = f.Type
+	}
+	if summary := f.Summary(); summary != "" {
+		return label + ": " + summary
+	}
+	return label
+}
+
+// TraitedFact is a fact that only applies while a trait is selected. It
+// replaces the fact at index Overrides, or is added after the others when
+// Overrides is nil.
+type TraitedFact struct {
+	SkillFact
+	RequiresTrait int  `json:"requires_trait"`
+	Overrides     *int `json:"overrides,omitempty"`
+}
+
+// traitedFactFields are the fields TraitedFact adds to a fact
+type traitedFactFields struct {
+	RequiresTrait int  `json:"requires_trait"`
+	Overrides     *int `json:"overrides,omitempty"`
+}
+
+func (f *TraitedFact) UnmarshalJSON(data []byte) error {
+	var fields traitedFactFields
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	if err := f.SkillFact.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	f.RequiresTrait = fields.RequiresTrait
+	f.Overrides = fields.Overrides
+	return nil
+}
+
+func (f TraitedFact) MarshalJSON() ([]byte, error) {
+	data, err := f.SkillFact.MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	extra, err := json.Marshal(traitedFactFields{RequiresTrait: f.RequiresTrait, Overrides: f.Overrides})
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(extra, &merged); err != nil {
+		return nil, err
+	}
+	return json.Marshal(merged)
+}
+
+// RenderFacts returns the skill's tooltip facts with the traited facts of the
+// selected traits applied
+func (s *Skill) RenderFacts(traits []int) []SkillFact {
+	return renderFacts(s.Facts, s.TraitedFacts, traits)
+}
+
+// RenderFacts returns the trait's tooltip facts with the traited facts of the
+// selected traits applied
+func (t *Trait) RenderFacts(traits []int) []SkillFact {
+	return renderFacts(t.Facts, t.TraitedFacts, traits)
+}
+
+// renderFacts applies the traited facts whose trait is selected, in order.
+// Overrides index the untraited facts, so additions never shift them.
+func renderFacts(facts []SkillFact, traited []TraitedFact, traits []int) []SkillFact {
+	rendered := slices.Clone(facts)
+	for _, fact := range traited {
+		if !slices.Contains(traits, fact.RequiresTrait) {
+			continue
+		}
+		if fact.Overrides != nil && *fact.Overrides >= 0 && *fact.Overrides < len(facts) {
+			rendered[*fact.Overrides] = fact.SkillFact
+		} else {
+			rendered = append(rendered, fact.SkillFact)
+		}
+	}
+	return rendered
+}
+
+// derefDetail turns the pointer newFactDetail returned into a value
+func derefDetail(detail FactDetail) FactDetail {
+	return reflect.ValueOf(detail).Elem().Interface().(FactDetail)
+}
+
+// formatFactNumber drops a trailing ".0" from whole numbers
+func formatFactNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
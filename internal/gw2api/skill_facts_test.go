@@ -0,0 +1,130 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+const testSkillJSON = `{
+	"id": 5491,
+	"name": "Fireball",
+	"facts": [
+		{"text": "Recharge", "type": "Recharge", "value": 1.5},
+		{"text": "Damage", "type": "Damage", "hit_count": 1, "dmg_multiplier": 0.8},
+		{"text": "Apply Buff/Condition", "type": "Buff", "status": "Burning", "description": "Burning damage over time.", "apply_count": 1, "duration": 2},
+		{"text": "Combo Finisher", "type": "ComboFinisher", "finisher_type": "Projectile", "percent": 20},
+		{"text": "Range", "type": "Range", "value": 1200},
+		{"text": "Shimmer", "type": "Sparkle", "glow": 3}
+	],
+	"traited_facts": [
+		{"text": "Range", "type": "Range", "value": 1500, "requires_trait": 100, "overrides": 4},
+		{"text": "Recharge", "type": "Recharge", "value": 1.2, "requires_trait": 200, "overrides": 0},
+		{"text": "Might", "type": "Buff", "status": "Might", "apply_count": 2, "duration": 5, "requires_trait": 100}
+	]
+}`
+
+func decodeTestSkill(t *testing.T) *Skill {
+	t.Helper()
+	var skill Skill
+	if err := json.Unmarshal([]byte(testSkillJSON), &skill); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	return &skill
+}
+
+func TestSkillFactDecoding(t *testing.T) {
+	skill := decodeTestSkill(t)
+
+	expected := []FactDetail{
+		RechargeFact{Value: 1.5},
+		DamageFact{HitCount: 1, DmgMultiplier: 0.8},
+		BuffFact{Status: "Burning", Description: "Burning damage over time.", ApplyCount: 1, Duration: 2},
+		ComboFinisherFact{FinisherType: "Projectile", Percent: 20},
+		RangeFact{Value: 1200},
+		UnknownFact{Fields: map[string]json.RawMessage{"glow": json.RawMessage("3")}},
+	}
+	for i, fact := range skill.Facts {
+		if !reflect.DeepEqual(fact.Detail, expected[i]) {
+			t.Errorf("fact %d detail = %#v, expected %#v", i, fact.Detail, expected[i])
+		}
+	}
+
+	if got := skill.TraitedFacts[0]; got.RequiresTrait != 100 || got.Overrides == nil || *got.Overrides != 4 {
+		t.Errorf("traited fact = %+v, expected trait 100 overriding fact 4", got)
+	}
+	if got := skill.TraitedFacts[2]; got.Overrides != nil {
+		t.Errorf("traited fact without overrides has Overrides %d", *got.Overrides)
+	}
+
+	lines := []string{
+		"Recharge: 1.5s",
+		"Damage: coefficient 0.8",
+		"Apply Buff/Condition: Burning (2s): Burning damage over time.",
+		"Combo Finisher: Projectile (20% chance)",
+		"Range: 1200",
+		"Shimmer",
+	}
+	for i, fact := range skill.Facts {
+		if got := fact.String(); got != lines[i] {
+			t.Errorf("fact %d = %q, expected %q", i, got, lines[i])
+		}
+	}
+}
+
+func TestSkillFactRoundTrip(t *testing.T) {
+	skill := decodeTestSkill(t)
+
+	data, err := json.Marshal(skill)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	var again Skill
+	if err := json.Unmarshal(data, &again); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+	if !reflect.DeepEqual(skill, &again) {
+		t.Errorf("round trip changed the skill:\n%s", data)
+	}
+
+	// Cached skills are handed out as deep copies
+	if copied := deepCopy(skill); !reflect.DeepEqual(skill, copied) {
+		t.Errorf("deepCopy changed the skill")
+	}
+}
+
+func TestRenderFacts(t *testing.T) {
+	skill := decodeTestSkill(t)
+
+	tests := []struct {
+		name     string
+		traits   []int
+		recharge float64
+		rangeVal int
+		count    int
+	}{
+		{"no traits", nil, 1.5, 1200, 6},
+		{"override and addition", []int{100}, 1.5, 1500, 7},
+		{"override at index 0", []int{200}, 1.2, 1200, 6},
+		{"both", []int{100, 200}, 1.2, 1500, 7},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			facts := skill.RenderFacts(tt.traits)
+			if len(facts) != tt.count {
+				t.Fatalf("got %d facts, expected %d", len(facts), tt.count)
+			}
+			if got := facts[0].Detail.(RechargeFact).Value; got != tt.recharge {
+				t.Errorf("recharge = %v, expected %v", got, tt.recharge)
+			}
+			if got := facts[4].Detail.(RangeFact).Value; got != tt.rangeVal {
+				t.Errorf("range = %d, expected %d", got, tt.rangeVal)
+			}
+		})
+	}
+
+	// Rendering never changes the skill's own facts
+	if got := skill.Facts[4].Detail.(RangeFact).Value; got != 1200 {
+		t.Errorf("skill range changed to %d", got)
+	}
+}
@@ -0,0 +1,151 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// SkinCache provides in-memory caching of skins
+type SkinCache struct {
+	jsonlCache[SkinDetail]
+}
+
+// SkinCacheStats tracks skin cache performance
+type SkinCacheStats struct {
+	LoadedSkins  int
+	LoadTime     time.Duration
+	CacheHits    int64
+	CacheMisses  int64
+	LastLoadTime time.Time
+}
+
+// NewSkinCache creates a new skin cache
+func NewSkinCache() *SkinCache {
+	return &SkinCache{
+		jsonlCache: newJSONLCache("skins", func(skin *SkinDetail) int { return skin.ID }),
+	}
+}
+
+// SearchSkins performs in-memory search on cached skins
+func (sc *SkinCache) SearchSkins(options SkinSearchOptions) []*SkinDetail {
+	name := strings.ToLower(options.Name)
+	return sc.search(options.Limit, func(skin *SkinDetail) bool {
+		return matchesSkinCriteria(skin, name, options)
+	})
+}
+
+// Stats returns cache statistics
+func (sc *SkinCache) Stats() SkinCacheStats {
+	s := sc.snapshot()
+	return SkinCacheStats{
+		LoadedSkins:  s.loaded,
+		LoadTime:     s.loadTime,
+		CacheHits:    s.hits,
+		CacheMisses:  s.misses,
+		LastLoadTime: s.lastLoadTime,
+	}
+}
+
+// SkinSearchOptions represents search options for skins. Types, weight classes
+// and rarities are matched ignoring case.
+type SkinSearchOptions struct {
+	Name          string   // Partial name to search for
+	Types         []string // Filter by skin type, such as Weapon, or by detail type, such as Sword
+	WeightClasses []string // Filter armor skins by weight class, such as Heavy
+	Rarities      []Rarity // Filter by rarity
+	Limit         int      // Maximum number of results to return (0 = 50)
+}
+
+// matchesSkinCriteria checks if a skin matches the search criteria, given the
+// lowercased name to look for
+func matchesSkinCriteria(skin *SkinDetail, name string, options SkinSearchOptions) bool {
+	if name != "" && !strings.Contains(strings.ToLower(skin.Name), name) {
+		return false
+	}
+	if len(options.Types) > 0 && !containsFold(options.Types, skin.Type) && !containsFold(options.Types, skin.Details.Type) {
+		return false
+	}
+	if len(options.WeightClasses) > 0 && !containsFold(options.WeightClasses, skin.Details.WeightClass) {
+		return false
+	}
+	return len(options.Rarities) == 0 || slices.ContainsFunc(options.Rarities, func(rarity Rarity) bool {
+		return strings.EqualFold(string(rarity), skin.Rarity)
+	})
+}
+
+// containsFold reports whether values holds s, ignoring case. An empty s never matches.
+func containsFold(values []string, s string) bool {
+	return s != "" && slices.ContainsFunc(values, func(value string) bool {
+		return strings.EqualFold(value, s)
+	})
+}
+
+// SearchSkins searches for skins based on the provided criteria.
+// This function requires the data cache to have skins loaded.
+func (c *Client) SearchSkins(ctx context.Context, options SkinSearchOptions) ([]*SkinDetail, error) {
+	if c.dataCache != nil && c.dataCache.GetSkinCache().IsLoaded() {
+		return c.dataCache.GetSkinCache().SearchSkins(options), nil
+	}
+
+	return nil, fmt.Errorf("skin search requires data cache to be loaded")
+}
+
+// GetAllSkins returns every skin, following pagination. There are too many
+// skins to request with ids=all, so the endpoint is paged through instead.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetAllSkins(ctx context.Context, options ...RequestOption) ([]*SkinDetail, error) {
+	results, _, err := GetAllPaged[SkinDetail](ctx, c, "/v2/skins", maxIDsPerRequest, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	ptrs := make([]*SkinDetail, len(results))
+	for i := range results {
+		ptrs[i] = &results[i]
+	}
+	return ptrs, nil
+}
+
+// GetSkinForItem returns the skin an item shows, or for items that unlock skins,
+// such as transmutation items, the first skin it unlocks. Items without a skin
+// give an error matching ErrNotFound.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/skins
+// Scopes: None (public endpoint)
+func (c *Client) GetSkinForItem(ctx context.Context, itemID int) (*SkinDetail, error) {
+	item, err := c.GetItem(ctx, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	skinID := item.DefaultSkin
+	if skinID == 0 && item.Details != nil && len(item.Details.Skins) > 0 {
+		skinID = item.Details.Skins[0]
+	}
+	if skinID == 0 {
+		return nil, fmt.Errorf("item %d has no skin: %w", itemID, ErrNotFound)
+	}
+
+	skins, err := c.GetSkins(ctx, []int{skinID})
+	if err != nil {
+		return nil, err
+	}
+	if len(skins) == 0 {
+		return nil, fmt.Errorf("skin %d of item %d: %w", skinID, itemID, ErrNotFound)
+	}
+	return skins[0], nil
+}
+
+// GetItemsForSkin returns the cached items that show or unlock a skin, in the
+// order they were loaded. This function requires the data cache to have items loaded.
+func (c *Client) GetItemsForSkin(ctx context.Context, skinID int) ([]*Item, error) {
+	if c.dataCache == nil || !c.dataCache.GetItemCache().IsLoaded() {
+		return nil, fmt.Errorf("finding items by skin requires data cache to be loaded")
+	}
+
+	itemCache := c.dataCache.GetItemCache()
+	return itemCache.GetByIDs(itemCache.ItemsForSkin(skinID)), nil
+}
@@ -0,0 +1,147 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"testing"
+)
+
+func skinIDsOf(skins []*SkinDetail) []int {
+	ids := make([]int, len(skins))
+	for i, skin := range skins {
+		ids[i] = skin.ID
+	}
+	return ids
+}
+
+func TestSearchSkins(t *testing.T) {
+	sc := NewSkinCache()
+	if err := sc.LoadFromFile(writeJSONLFixture(t,
+		`{"id": 1, "name": "Bolt", "type": "Weapon", "rarity": "Exotic", "details": {"type": "Sword"}}`,
+		`{"id": 2, "name": "Zap Bolt", "type": "Weapon", "rarity": "Rare", "details": {"type": "Staff"}}`,
+		`{"id": 3, "name": "Bolted Helm", "type": "Armor", "rarity": "Exotic", "details": {"type": "Helm", "weight_class": "Heavy"}}`,
+		`{"id": 4, "name": "Mask", "type": "Armor", "rarity": "Basic", "details": {"type": "Helm", "weight_class": "Light"}}`,
+	)); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		options  SkinSearchOptions
+		expected []int
+	}{
+		{"name", SkinSearchOptions{Name: "bolt"}, []int{1, 2, 3}},
+		{"name and type", SkinSearchOptions{Name: "Bolt", Types: []string{"weapon"}}, []int{1, 2}},
+		{"detail type", SkinSearchOptions{Types: []string{"Helm"}}, []int{3, 4}},
+		{"weight", SkinSearchOptions{WeightClasses: []string{"heavy", "medium"}}, []int{3}},
+		{"rarity", SkinSearchOptions{Rarities: []Rarity{"exotic"}}, []int{1, 3}},
+		{"limit", SkinSearchOptions{Name: "bolt", Limit: 1}, []int{1}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := skinIDsOf(sc.SearchSkins(test.options)); !slices.Equal(got, test.expected) {
+				t.Errorf("SearchSkins(%+v) = %v, expected %v", test.options, got, test.expected)
+			}
+		})
+	}
+}
+
+func TestItemsForSkin(t *testing.T) {
+	ic := NewItemCache()
+	if err := ic.LoadFromFile(writeJSONLFixture(t,
+		`{"id": 10, "name": "Sword", "default_skin": 5}`,
+		`{"id": 11, "name": "Sword Skin", "details": {"skins": [5, 6]}}`,
+		`{"id": 12, "name": "Junk"}`,
+	)); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := ic.ItemsForSkin(5); !slices.Equal(got, []int{10, 11}) {
+		t.Errorf("ItemsForSkin(5) = %v, expected [10 11]", got)
+	}
+	if got := ic.ItemsForSkin(7); got != nil {
+		t.Errorf("ItemsForSkin(7) = %v, expected none", got)
+	}
+
+	// Replacing an item moves its index entries
+	ic.replace([]*Item{{ID: 10, DefaultSkin: 7}})
+	if got := ic.ItemsForSkin(5); !slices.Equal(got, []int{11}) {
+		t.Errorf("ItemsForSkin(5) after replace = %v, expected [11]", got)
+	}
+	if got := ic.ItemsForSkin(7); !slices.Equal(got, []int{10}) {
+		t.Errorf("ItemsForSkin(7) after replace = %v, expected [10]", got)
+	}
+}
+
+// newSkinClient returns a client for a fake API with 450 skins, served in
+// pages, and three items
+func newSkinClient(t *testing.T) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch r.URL.Path {
+		case "/v2/skins":
+			if ids := query.Get("ids"); ids != "" {
+				id, _ := strconv.Atoi(ids)
+				json.NewEncoder(w).Encode([]SkinDetail{{ID: id, Name: "Skin " + ids}})
+				return
+			}
+			page, _ := strconv.Atoi(query.Get("page"))
+			size, _ := strconv.Atoi(query.Get("page_size"))
+			var skins []SkinDetail
+			for id := page*size + 1; id <= min((page+1)*size, 450); id++ {
+				skins = append(skins, SkinDetail{ID: id})
+			}
+			w.Header().Set("X-Page", strconv.Itoa(page))
+			w.Header().Set("X-Page-Total", strconv.Itoa((450+size-1)/size))
+			json.NewEncoder(w).Encode(skins)
+		case "/v2/items":
+			items := map[string]string{
+				"10": `[{"id": 10, "default_skin": 5}]`,
+				"11": `[{"id": 11, "details": {"skins": [6, 7]}}]`,
+				"12": `[{"id": 12}]`,
+			}
+			w.Write([]byte(items[query.Get("ids")]))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+}
+
+func TestGetAllSkins(t *testing.T) {
+	skins, err := newSkinClient(t).GetAllSkins(context.Background())
+	if err != nil {
+		t.Fatalf("GetAllSkins: %v", err)
+	}
+	if len(skins) != 450 || skins[0].ID != 1 || skins[449].ID != 450 {
+		t.Errorf("GetAllSkins returned %d skins, expected 450 in ID order", len(skins))
+	}
+}
+
+func TestGetSkinForItem(t *testing.T) {
+	client := newSkinClient(t)
+	ctx := context.Background()
+
+	for itemID, expected := range map[int]int{10: 5, 11: 6} {
+		skin, err := client.GetSkinForItem(ctx, itemID)
+		if err != nil {
+			t.Fatalf("GetSkinForItem(%d): %v", itemID, err)
+		}
+		if skin.ID != expected {
+			t.Errorf("GetSkinForItem(%d) = skin %d, expected %d", itemID, skin.ID, expected)
+		}
+	}
+
+	if _, err := client.GetSkinForItem(ctx, 12); !errors.Is(err, ErrNotFound) {
+		t.Errorf("GetSkinForItem on an item without a skin = %v, expected ErrNotFound", err)
+	}
+}
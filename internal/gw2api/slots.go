@@ -0,0 +1,133 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+)
+
+// UnknownItemName is the name of the placeholder item ResolveSlots gives slots
+// whose item could not be fetched
+const UnknownItemName = "Unknown item"
+
+// SlotRef is a stack of items in a storage slot, such as a bank slot or a slot
+// in one of a character's bags
+type SlotRef struct {
+	Container int    `json:"container"` // Bag index for character inventories, 0 for other storage
+	Index     int    `json:"index"`     // Slot within the container
+	ItemID    int    `json:"item_id"`
+	Count     int    `json:"count"`
+	Binding   string `json:"binding,omitempty"`
+	BoundTo   string `json:"bound_to,omitempty"`
+}
+
+// ResolvedSlot is a slot joined with its item
+type ResolvedSlot struct {
+	SlotRef
+	Item *Item `json:"item"`
+	// Unknown is set when the item could not be fetched. Item is then a
+	// placeholder with only the ID and UnknownItemName set.
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// UnresolvedItemsError reports the items ResolveSlots could not fetch
+type UnresolvedItemsError struct {
+	IDs []int // Sorted
+	Err error // The first failed request, or nil if the API doesn't know the items
+}
+
+func (e *UnresolvedItemsError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("failed to resolve %d items: %v", len(e.IDs), e.Err)
+	}
+	return fmt.Sprintf("failed to resolve %d items: unknown item IDs %v", len(e.IDs), e.IDs)
+}
+
+func (e *UnresolvedItemsError) Unwrap() error {
+	return e.Err
+}
+
+// ResolveSlots joins slots with their items. Each item is fetched once, in
+// requests of up to 200 IDs. A request that fails doesn't fail the others:
+// slots whose item could not be fetched keep their place with a placeholder
+// item, and the returned error is an *UnresolvedItemsError listing the IDs.
+// The slots are returned in the given order either way.
+func ResolveSlots(ctx context.Context, client *Client, slots []SlotRef) ([]ResolvedSlot, error) {
+	ids := make([]int, len(slots))
+	for i, slot := range slots {
+		ids[i] = slot.ItemID
+	}
+	ids = uniqueIDs(ids)
+
+	results, fetchErr := client.GetItems(ctx, ids)
+	items := make(map[int]*Item, len(results))
+	for _, item := range results {
+		if item != nil {
+			items[item.ID] = item
+		}
+	}
+
+	resolved := make([]ResolvedSlot, len(slots))
+	var unresolved []int
+	for i, slot := range slots {
+		resolved[i].SlotRef = slot
+		if item, found := items[slot.ItemID]; found {
+			resolved[i].Item = item
+			continue
+		}
+		resolved[i].Item = &Item{ID: slot.ItemID, Name: UnknownItemName}
+		resolved[i].Unknown = true
+		unresolved = append(unresolved, slot.ItemID)
+	}
+
+	if len(unresolved) > 0 {
+		return resolved, &UnresolvedItemsError{IDs: uniqueIDs(unresolved), Err: fetchErr}
+	}
+	return resolved, nil
+}
+
+// BankSlotRefs returns the filled slots of the account bank
+func BankSlotRefs(bank []BankSlot) []SlotRef {
+	var refs []SlotRef
+	for i, slot := range bank {
+		if slot.ID != 0 {
+			refs = append(refs, SlotRef{Index: i, ItemID: slot.ID, Count: slot.Count, Binding: slot.Binding, BoundTo: slot.BoundTo})
+		}
+	}
+	return refs
+}
+
+// InventorySlotRefs returns the filled slots of the shared inventory
+func InventorySlotRefs(inventory []InventorySlot) []SlotRef {
+	var refs []SlotRef
+	for i, slot := range inventory {
+		if slot.ID != 0 {
+			refs = append(refs, SlotRef{Index: i, ItemID: slot.ID, Count: slot.Count, Binding: slot.Binding, BoundTo: slot.BoundTo})
+		}
+	}
+	return refs
+}
+
+// MaterialSlotRefs returns the material storage slots holding any materials
+func MaterialSlotRefs(materials []MaterialSlot) []SlotRef {
+	var refs []SlotRef
+	for i, slot := range materials {
+		if slot.ID != 0 && slot.Count > 0 {
+			refs = append(refs, SlotRef{Index: i, ItemID: slot.ID, Count: slot.Count, Binding: slot.Binding})
+		}
+	}
+	return refs
+}
+
+// CharacterInventorySlotRefs returns the filled slots of a character's bags,
+// with each bag's index as the container
+func CharacterInventorySlotRefs(inventory *CharacterInventory) []SlotRef {
+	var refs []SlotRef
+	for bagIndex, bag := range inventory.Bags {
+		for i, slot := range bag.Inventory {
+			if slot.ID != 0 {
+				refs = append(refs, SlotRef{Container: bagIndex, Index: i, ItemID: slot.ID, Count: slot.Count, Binding: slot.Binding, BoundTo: slot.BoundTo})
+			}
+		}
+	}
+	return refs
+}
@@ -0,0 +1,135 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+)
+
+// newSlotsTestClient returns a client for an API that knows items 1 to 999
+// except 250, and fails any request that asks for item 500
+func newSlotsTestClient(t *testing.T, requests *atomic.Int32) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		var items []Item
+		for _, field := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			id, _ := strconv.Atoi(field)
+			if id == 500 {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"text": "internal error"}`))
+				return
+			}
+			if id > 0 && id < 1000 && id != 250 {
+				items = append(items, Item{ID: id, Name: "Item " + field})
+			}
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+	t.Cleanup(server.Close)
+	return NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+}
+
+func TestResolveSlots(t *testing.T) {
+	var requests atomic.Int32
+	client := newSlotsTestClient(t, &requests)
+
+	// 250 distinct items, each in two slots, so two requests after deduping
+	var slots []SlotRef
+	for i := range 500 {
+		slots = append(slots, SlotRef{Index: i, ItemID: i%250 + 600, Count: 1})
+	}
+
+	resolved, err := ResolveSlots(context.Background(), client, slots)
+	if err != nil {
+		t.Fatalf("ResolveSlots: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("made %d requests, expected 2", got)
+	}
+	if len(resolved) != len(slots) {
+		t.Fatalf("resolved %d slots, expected %d", len(resolved), len(slots))
+	}
+	for i, slot := range resolved {
+		if slot.Index != i || slot.Item == nil || slot.Item.ID != slot.ItemID || slot.Unknown {
+			t.Fatalf("slot %d = %+v", i, slot)
+		}
+	}
+}
+
+func TestResolveSlotsPartialFailure(t *testing.T) {
+	var requests atomic.Int32
+	client := newSlotsTestClient(t, &requests)
+
+	// Items 401 to 600 fill one request, which fails because of item 500.
+	// Item 250 is in the other request, which the API doesn't know.
+	var slots []SlotRef
+	for id := 201; id <= 600; id++ {
+		slots = append(slots, SlotRef{Index: len(slots), ItemID: id, Count: 2})
+	}
+
+	resolved, err := ResolveSlots(context.Background(), client, slots)
+	var unresolved *UnresolvedItemsError
+	if !errors.As(err, &unresolved) {
+		t.Fatalf("error = %v, expected an UnresolvedItemsError", err)
+	}
+	var httpErr HTTPError
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusInternalServerError {
+		t.Errorf("error does not wrap the failed request: %v", err)
+	}
+
+	expected := []int{250}
+	for id := 401; id <= 600; id++ {
+		expected = append(expected, id)
+	}
+	if !slices.Equal(unresolved.IDs, expected) {
+		t.Errorf("unresolved IDs = %v, expected 250 and 401 to 600", unresolved.IDs)
+	}
+
+	if len(resolved) != len(slots) {
+		t.Fatalf("resolved %d slots, expected %d with placeholders", len(resolved), len(slots))
+	}
+	for _, slot := range resolved {
+		failed := slot.ItemID == 250 || slot.ItemID > 400
+		if slot.Unknown != failed {
+			t.Errorf("item %d Unknown = %v, expected %v", slot.ItemID, slot.Unknown, failed)
+		}
+		if failed && (slot.Item.ID != slot.ItemID || slot.Item.Name != UnknownItemName || slot.Count != 2) {
+			t.Errorf("placeholder for item %d = %+v", slot.ItemID, slot)
+		}
+	}
+}
+
+func TestSlotRefs(t *testing.T) {
+	bank := []BankSlot{{ID: 1, Count: 3}, {}, {ID: 2, Count: 1, Binding: "Character", BoundTo: "Tester"}}
+	if refs := BankSlotRefs(bank); !slices.Equal(refs, []SlotRef{
+		{Index: 0, ItemID: 1, Count: 3},
+		{Index: 2, ItemID: 2, Count: 1, Binding: "Character", BoundTo: "Tester"},
+	}) {
+		t.Errorf("BankSlotRefs = %+v", refs)
+	}
+
+	materials := []MaterialSlot{{ID: 19721, Count: 0}, {ID: 19976, Count: 250}}
+	if refs := MaterialSlotRefs(materials); len(refs) != 1 || refs[0].ItemID != 19976 || refs[0].Index != 1 {
+		t.Errorf("MaterialSlotRefs = %+v, expected only the filled slot", refs)
+	}
+
+	inventory := &CharacterInventory{Bags: []CharacterBag{
+		{Inventory: []CharacterInventorySlot{{}, {ID: 5, Count: 1}}},
+		{},
+		{Inventory: []CharacterInventorySlot{{ID: 6, Count: 2}}},
+	}}
+	if refs := CharacterInventorySlotRefs(inventory); !slices.Equal(refs, []SlotRef{
+		{Container: 0, Index: 1, ItemID: 5, Count: 1},
+		{Container: 2, Index: 0, ItemID: 6, Count: 2},
+	}) {
+		t.Errorf("CharacterInventorySlotRefs = %+v", refs)
+	}
+}
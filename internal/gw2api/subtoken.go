@@ -0,0 +1,54 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+)
+
+// SubtokenOptions limits what a subtoken can do. Zero values leave the
+// corresponding restriction off.
+type SubtokenOptions struct {
+	Expire      time.Time // When the subtoken stops working
+	Permissions []string  // Scopes to keep, a subset of the API key's
+	URLs        []string  // Endpoints the subtoken may call, such as "/v2/account/bank"
+}
+
+// CreateSubtoken creates a subtoken of the client's API key with the given restrictions.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/createsubtoken
+// Scopes: account
+func (c *Client) CreateSubtoken(ctx context.Context, opts SubtokenOptions) (string, error) {
+	var options []RequestOption
+	if !opts.Expire.IsZero() {
+		options = append(options, WithParam("expire", opts.Expire.UTC().Format(time.RFC3339)))
+	}
+	if len(opts.Permissions) > 0 {
+		options = append(options, WithParam("permissions", strings.Join(opts.Permissions, ",")))
+	}
+	if len(opts.URLs) > 0 {
+		options = append(options, WithParam("urls", strings.Join(opts.URLs, ",")))
+	}
+
+	result, err := c.GetCreateSubtoken(ctx, options...)
+	if err != nil {
+		return "", err
+	}
+	if result.Subtoken == "" {
+		return "", errors.New("API returned an empty subtoken")
+	}
+	return result.Subtoken, nil
+}
+
+// WithSubtoken creates a subtoken and returns a copy of the client that
+// authenticates with it, for passing a limited client to code that should not
+// hold the full API key. The copy shares the rate limiter, data cache and HTTP
+// client with the original.
+func (c *Client) WithSubtoken(ctx context.Context, opts SubtokenOptions) (*Client, error) {
+	subtoken, err := c.CreateSubtoken(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.withAPIKey(subtoken), nil
+}
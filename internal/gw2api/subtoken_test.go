@@ -0,0 +1,102 @@
+package gw2api
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestHeaderAuthAcrossRetries(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		headers [][]string
+		queries []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		headers = append(headers, r.Header.Values("Authorization"))
+		queries = append(queries, r.URL.RawQuery)
+		attempt := len(headers)
+		mu.Unlock()
+
+		if attempt < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"text":"API not active"}`))
+			return
+		}
+		w.Write([]byte(`{"id":1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithBaseURL(server.URL),
+		WithAPIKey("secret-key"),
+		WithRetryConfig(&RetryConfig{MaxRetries: 2, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond, BackoffMultiple: 1}),
+		WithRateLimit(1000),
+	)
+	if _, err := client.GetBuild(context.Background()); err != nil {
+		t.Fatalf("GetBuild: %v", err)
+	}
+
+	if len(headers) != 3 {
+		t.Fatalf("got %d attempts, expected 3", len(headers))
+	}
+	for i := range headers {
+		if len(headers[i]) != 1 || headers[i][0] != "Bearer secret-key" {
+			t.Errorf("attempt %d Authorization = %q, expected a single bearer token", i+1, headers[i])
+		}
+		if queries[i] != "lang=en" {
+			t.Errorf("attempt %d query = %q, expected no access token", i+1, queries[i])
+		}
+	}
+}
+
+func TestWithSubtoken(t *testing.T) {
+	var createQuery string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/createsubtoken":
+			if r.Header.Get("Authorization") != "Bearer full-key" {
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"text":"invalid key"}`))
+				return
+			}
+			createQuery = r.URL.RawQuery
+			w.Write([]byte(`{"subtoken":"limited-key"}`))
+		case "/v2/account":
+			if r.Header.Get("Authorization") != "Bearer limited-key" {
+				w.WriteHeader(http.StatusForbidden)
+				w.Write([]byte(`{"text":"requires scope account"}`))
+				return
+			}
+			w.Write([]byte(`{"name":"Test.1234"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(WithBaseURL(server.URL), WithAPIKey("full-key"), WithRetries(0), WithRateLimit(1000))
+	limited, err := client.WithSubtoken(context.Background(), SubtokenOptions{
+		Expire:      time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+		Permissions: []string{"account", "inventories"},
+		URLs:        []string{"/v2/account", "/v2/account/bank"},
+	})
+	if err != nil {
+		t.Fatalf("WithSubtoken: %v", err)
+	}
+
+	expected := "expire=2030-01-02T03%3A04%3A05Z&lang=en&permissions=account%2Cinventories&urls=%2Fv2%2Faccount%2C%2Fv2%2Faccount%2Fbank"
+	if createQuery != expected {
+		t.Errorf("createsubtoken query = %q, expected %q", createQuery, expected)
+	}
+
+	account, err := limited.GetAccount(context.Background())
+	if err != nil || account.Name != "Test.1234" {
+		t.Errorf("GetAccount with the subtoken = %+v, %v", account, err)
+	}
+	if client.apiKey != "full-key" {
+		t.Errorf("original client key changed to %q", client.apiKey)
+	}
+}
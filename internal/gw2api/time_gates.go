@@ -0,0 +1,57 @@
+package gw2api
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// TimeGate is a crafted item the game only allows a few crafts of a day, such
+// as the Lump of Mithrillium used in ascended weapons
+type TimeGate struct {
+	ItemID int    `json:"item_id"`
+	Name   string `json:"name"`
+	PerDay int    `json:"per_day"` // Crafts allowed each day
+}
+
+// Days returns how many days crafting count of the item takes at its daily limit
+func (g TimeGate) Days(count int) int {
+	if count <= 0 || g.PerDay <= 0 {
+		return 0
+	}
+	return (count + g.PerDay - 1) / g.PerDay
+}
+
+//go:embed time_gates.json
+var defaultTimeGates []byte
+
+// DefaultTimeGates returns a curated list of the common daily crafts, keyed by
+// item ID. Add to it with ReadTimeGates for items it doesn't know.
+func DefaultTimeGates() map[int]TimeGate {
+	gates, err := ReadTimeGates(bytes.NewReader(defaultTimeGates))
+	if err != nil {
+		panic("invalid default time gates: " + err.Error())
+	}
+	byItem := make(map[int]TimeGate, len(gates))
+	for _, gate := range gates {
+		byItem[gate.ItemID] = gate
+	}
+	return byItem
+}
+
+// ReadTimeGates reads a JSON array of time gates in the format of
+// DefaultTimeGates, checking each has an item ID and a daily limit
+func ReadTimeGates(r io.Reader) ([]TimeGate, error) {
+	var gates []TimeGate
+	if err := json.NewDecoder(r).Decode(&gates); err != nil {
+		return nil, fmt.Errorf("invalid time gates: %w", err)
+	}
+	for i, gate := range gates {
+		if gate.ItemID <= 0 || gate.PerDay <= 0 {
+			return nil, fmt.Errorf("time gate %d: needs an item_id and a per_day above 0", i+1)
+		}
+	}
+	return gates, nil
+}
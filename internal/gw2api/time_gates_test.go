@@ -0,0 +1,32 @@
+package gw2api
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDefaultTimeGates(t *testing.T) {
+	gates := DefaultTimeGates()
+	mithrillium, found := gates[46742]
+	if !found || mithrillium.Name != "Lump of Mithrillium" || mithrillium.PerDay != 1 {
+		t.Errorf("Lump of Mithrillium = %+v, %v", mithrillium, found)
+	}
+	if days := mithrillium.Days(5); days != 5 {
+		t.Errorf("Days(5) = %d, expected 5", days)
+	}
+	if days := (TimeGate{PerDay: 3}).Days(7); days != 3 {
+		t.Errorf("Days(7) at 3 a day = %d, expected 3", days)
+	}
+}
+
+func TestReadTimeGates(t *testing.T) {
+	gates, err := ReadTimeGates(strings.NewReader(`[{"item_id": 1, "name": "Pot", "per_day": 2}]`))
+	if err != nil || len(gates) != 1 || gates[0].PerDay != 2 {
+		t.Errorf("ReadTimeGates = %+v, %v", gates, err)
+	}
+	for _, input := range []string{`[{"item_id": 1}]`, `[{"per_day": 1}]`, `{`} {
+		if _, err := ReadTimeGates(strings.NewReader(input)); err == nil {
+			t.Errorf("ReadTimeGates(%s) succeeded", input)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// timestampLayouts are the formats timestamps come in. Most endpoints send
+// RFC 3339 with or without fractional seconds; a few leave off the zone, which
+// is always UTC.
+var timestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+}
+
+// ParseTimestamp parses a timestamp as the API sends it. Empty strings and
+// the placeholders some endpoints send for unset times, such as
+// "0001-01-01T00:00:00Z", give the zero time.
+func ParseTimestamp(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range timestampLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			if IsZeroTimestamp(t) {
+				return time.Time{}, nil
+			}
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid timestamp %q", s)
+}
+
+// IsZeroTimestamp reports whether t is unset: the zero time, or a placeholder
+// the API sends instead, which is the first instant of year 1 or of the Unix
+// epoch in any zone
+func IsZeroTimestamp(t time.Time) bool {
+	if t.IsZero() {
+		return true
+	}
+	t = t.UTC()
+	return t.Year() <= 1 || t.Equal(time.Unix(0, 0))
+}
+
+// RelativeTime describes t relative to now in the largest whole unit, such as
+// "3h ago" or "in 2d". Times within a minute are "just now", and unset times
+// give "".
+func RelativeTime(t, now time.Time) string {
+	if IsZeroTimestamp(t) {
+		return ""
+	}
+	d := now.Sub(t)
+	future := d < 0
+	if future {
+		d = -d
+	}
+
+	var amount string
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		amount = fmt.Sprintf("%dm", int(d/time.Minute))
+	case d < 24*time.Hour:
+		amount = fmt.Sprintf("%dh", int(d/time.Hour))
+	case d < 365*24*time.Hour:
+		amount = fmt.Sprintf("%dd", int(d/(24*time.Hour)))
+	default:
+		amount = fmt.Sprintf("%dy", int(d/(365*24*time.Hour)))
+	}
+	if future {
+		return "in " + amount
+	}
+	return amount + " ago"
+}
+
+// timestamp decodes a time with ParseTimestamp, for the UnmarshalJSON methods
+// of types whose endpoints send timestamps time.Time doesn't accept
+type timestamp time.Time
+
+// UnmarshalJSON decodes a timestamp string, leaving null as the zero time
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	parsed, err := ParseTimestamp(s)
+	if err != nil {
+		return err
+	}
+	*t = timestamp(parsed)
+	return nil
+}
+
+// UnmarshalJSON decodes an account, accepting the timestamps ParseTimestamp does
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type plain Account
+	decoded := struct {
+		*plain
+		Created      timestamp `json:"created"`
+		LastModified timestamp `json:"last_modified"`
+	}{plain: (*plain)(a)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	a.Created, a.LastModified = time.Time(decoded.Created), time.Time(decoded.LastModified)
+	return nil
+}
+
+// UnmarshalJSON decodes a character, accepting the timestamps ParseTimestamp does
+func (c *CharacterCore) UnmarshalJSON(data []byte) error {
+	type plain CharacterCore
+	decoded := struct {
+		*plain
+		Created      timestamp `json:"created"`
+		LastModified timestamp `json:"last_modified"`
+	}{plain: (*plain)(c)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	c.Created, c.LastModified = time.Time(decoded.Created), time.Time(decoded.LastModified)
+	return nil
+}
+
+// UnmarshalJSON decodes a guild member, whose join time is a placeholder or
+// null for members who joined before the API recorded it
+func (m *GuildMember) UnmarshalJSON(data []byte) error {
+	type plain GuildMember
+	decoded := struct {
+		*plain
+		Joined timestamp `json:"joined"`
+	}{plain: (*plain)(m)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	m.Joined = time.Time(decoded.Joined)
+	return nil
+}
+
+// UnmarshalJSON decodes a leaderboard entry, accepting the timestamps
+// ParseTimestamp does
+func (e *PvPLeaderboardEntry) UnmarshalJSON(data []byte) error {
+	type plain PvPLeaderboardEntry
+	decoded := struct {
+		*plain
+		Date timestamp `json:"date"`
+	}{plain: (*plain)(e)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	e.Date = time.Time(decoded.Date)
+	return nil
+}
+
+// UnmarshalJSON decodes a Wizard's Vault season, accepting the timestamps
+// ParseTimestamp does
+func (s *WizardsVaultSeason) UnmarshalJSON(data []byte) error {
+	type plain WizardsVaultSeason
+	decoded := struct {
+		*plain
+		Start timestamp `json:"start"`
+		End   timestamp `json:"end"`
+	}{plain: (*plain)(s)}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	s.Start, s.End = time.Time(decoded.Start), time.Time(decoded.End)
+	return nil
+}
@@ -0,0 +1,104 @@
+package gw2api
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// decodeFixture decodes a fixture under testdata with the type's own unmarshaller
+func decodeFixture(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", path))
+	if err != nil {
+		t.Fatalf("reading fixture: %v", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		t.Fatalf("%s: %v", path, err)
+	}
+}
+
+func TestTimestampFixtures(t *testing.T) {
+	utc := func(year int, month time.Month, day, hour, min, sec, msec int) time.Time {
+		return time.Date(year, month, day, hour, min, sec, msec*int(time.Millisecond), time.UTC)
+	}
+
+	var account Account
+	decodeFixture(t, "timestamps/account.json", &account)
+	if !account.Created.Equal(utc(2014, 2, 23, 19, 17, 0, 0)) || !account.LastModified.Equal(utc(2026, 10, 15, 8, 41, 12, 503)) {
+		t.Errorf("account times = %v, %v", account.Created, account.LastModified)
+	}
+	if account.Name != "Player.1234" || account.Age.Hours() != 2136 || !account.Commander {
+		t.Errorf("account = %+v, other fields not decoded", account)
+	}
+
+	var characters []CharacterCore
+	decodeFixture(t, "timestamps/character_core.json", &characters)
+	if !characters[0].LastModified.Equal(utc(2026, 10, 14, 21, 3, 55, 0)) || characters[0].Deaths != 1204 {
+		t.Errorf("first character = %+v", characters[0])
+	}
+	// No zone means UTC, and the placeholder is unset
+	if !characters[1].Created.Equal(utc(2026, 10, 15, 12, 0, 0, 0)) || !characters[1].LastModified.IsZero() {
+		t.Errorf("second character times = %v, %v", characters[1].Created, characters[1].LastModified)
+	}
+
+	var log []GuildLog
+	decodeFixture(t, "timestamps/guild_log.json", &log)
+	for i, expected := range []time.Time{utc(2026, 10, 14, 19, 22, 5, 0), utc(2026, 10, 14, 19, 20, 41, 0), utc(2026, 10, 1, 2, 0, 0, 0)} {
+		if !log[i].Time.Equal(expected) {
+			t.Errorf("log entry %d at %v, expected %v", log[i].ID, log[i].Time, expected)
+		}
+	}
+
+	var members []GuildMember
+	decodeFixture(t, "timestamps/guild_members.json", &members)
+	if !members[0].Joined.IsZero() || !members[1].Joined.IsZero() || !members[2].Joined.Equal(utc(2026, 10, 14, 19, 22, 5, 0)) {
+		t.Errorf("joined = %v, %v, %v, expected two unset", members[0].Joined, members[1].Joined, members[2].Joined)
+	}
+	if members[1].Rank != "Officer" {
+		t.Errorf("member = %+v, other fields not decoded", members[1])
+	}
+
+	// Bad timestamps are still errors
+	if err := json.Unmarshal([]byte(`{"created": "yesterday"}`), &account); err == nil {
+		t.Error("decoded an account created yesterday")
+	}
+}
+
+func TestIsZeroTimestamp(t *testing.T) {
+	for _, tt := range []struct {
+		t        time.Time
+		expected bool
+	}{
+		{time.Time{}, true},
+		{time.Date(1, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600)), true},
+		{time.Unix(0, 0), true},
+		{time.Date(2012, 8, 28, 0, 0, 0, 0, time.UTC), false},
+	} {
+		if got := IsZeroTimestamp(tt.t); got != tt.expected {
+			t.Errorf("IsZeroTimestamp(%v) = %v, expected %v", tt.t, got, tt.expected)
+		}
+	}
+}
+
+func TestRelativeTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for _, tt := range []struct {
+		t        time.Time
+		expected string
+	}{
+		{now.Add(-30 * time.Second), "just now"},
+		{now.Add(-5 * time.Minute), "5m ago"},
+		{now.Add(-3*time.Hour - 59*time.Minute), "3h ago"},
+		{now.Add(-50 * time.Hour), "2d ago"},
+		{now.Add(-800 * 24 * time.Hour), "2y ago"},
+		{now.Add(90 * time.Minute), "in 1h"},
+		{time.Time{}, ""},
+	} {
+		if got := RelativeTime(tt.t, now); got != tt.expected {
+			t.Errorf("RelativeTime(%v) = %q, expected %q", tt.t, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,238 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+)
+
+// UnlockKind identifies a wardrobe unlock type that GetUnlockSource can resolve
+type UnlockKind string
+
+const (
+	UnlockKindOutfit    UnlockKind = "outfit"
+	UnlockKindGlider    UnlockKind = "glider"
+	UnlockKindMountSkin UnlockKind = "mount_skin"
+	UnlockKindFinisher  UnlockKind = "finisher"
+	UnlockKindMini      UnlockKind = "mini"
+	UnlockKindNovelty   UnlockKind = "novelty"
+)
+
+// UnlockSourceType classifies how an unlock is obtained
+type UnlockSourceType string
+
+const (
+	UnlockSourceTradable    UnlockSourceType = "Tradable"    // An unlock item is listed on the trading post
+	UnlockSourceAchievement UnlockSourceType = "Achievement" // An unlock item is part of an achievement
+	UnlockSourceGemStore    UnlockSourceType = "GemStore"    // Neither tradable nor from achievements, usually the gem store
+	UnlockSourceUnknown     UnlockSourceType = "Unknown"     // The unlock itself could not be found
+)
+
+// UnlockSource describes how an outfit, glider, mount skin, finisher, mini or novelty can be obtained
+type UnlockSource struct {
+	Kind              UnlockKind       `json:"kind"`
+	ID                int              `json:"id"`
+	Name              string           `json:"name"`
+	Source            UnlockSourceType `json:"source"`
+	ItemID            int              `json:"item_id,omitempty"`            // Unlock item that determined the source
+	Price             int              `json:"price,omitempty"`              // Cheapest trading post listing in copper
+	AchievementID     int              `json:"achievement_id,omitempty"`     // Achievement that awards the unlock or its item
+	AchievementName   string           `json:"achievement_name,omitempty"`   // Name of that achievement
+	AchievementPoints int              `json:"achievement_points,omitempty"` // AP for completing every tier of it
+}
+
+// unlockDefinition is the part of an unlock the resolver needs
+type unlockDefinition struct {
+	ID          int
+	Name        string
+	UnlockItems []int
+	// Bit is the achievement bit that refers to the unlock itself, such as a
+	// Minipet bit for a mini; zero for unlocks only rewarded through items
+	Bit AchievementBitKey
+}
+
+// GetUnlockSource resolves how a single outfit, glider, mount skin, finisher, mini or novelty
+// is obtained. Without a loaded achievement data cache, achievement sources are only found
+// among the achievements GetAchievementsGranting scans.
+func (c *Client) GetUnlockSource(ctx context.Context, kind UnlockKind, id int) (*UnlockSource, error) {
+	sources, err := c.GetUnlockSources(ctx, kind, []int{id})
+	if err != nil {
+		return nil, err
+	}
+	return sources[0], nil
+}
+
+// GetUnlockSources resolves how each of the given unlocks is obtained, in the order of ids
+func (c *Client) GetUnlockSources(ctx context.Context, kind UnlockKind, ids []int) ([]*UnlockSource, error) {
+	definitions, err := c.unlockDefinitions(ctx, kind, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	var itemIDs []int
+	for _, def := range definitions {
+		itemIDs = append(itemIDs, def.UnlockItems...)
+	}
+
+	items, err := c.lookupItems(ctx, itemIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unlock items: %w", err)
+	}
+
+	// Only items that can be sold are worth a price lookup
+	var tradableIDs []int
+	for id, item := range items {
+		if item.IsTradable() {
+			tradableIDs = append(tradableIDs, id)
+		}
+	}
+
+	prices, err := c.lookupPrices(ctx, tradableIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get unlock item prices: %w", err)
+	}
+
+	// A failed scan only hides achievement sources, which then resolve to the gem store
+	granting, err := c.achievementGranter(ctx)
+	if err != nil {
+		granting = func(bitType string, id int) []*Achievement { return nil }
+	}
+
+	sources := make([]*UnlockSource, len(ids))
+	for i, id := range ids {
+		def, found := definitions[id]
+		if !found {
+			sources[i] = &UnlockSource{Kind: kind, ID: id, Source: UnlockSourceUnknown}
+			continue
+		}
+		sources[i] = resolveUnlockSource(kind, def, prices, granting)
+	}
+	return sources, nil
+}
+
+// resolveUnlockSource classifies one unlock, preferring the trading post, then
+// achievements, then falling back to the gem store
+func resolveUnlockSource(kind UnlockKind, def unlockDefinition, prices map[int]*Price, granting achievementGranter) *UnlockSource {
+	source := &UnlockSource{Kind: kind, ID: def.ID, Name: def.Name, Source: UnlockSourceGemStore}
+
+	for _, itemID := range def.UnlockItems {
+		price, found := prices[itemID]
+		if !found {
+			continue
+		}
+
+		cost := unitPrice(price)
+		if cost == 0 {
+			continue
+		}
+
+		if source.Source != UnlockSourceTradable || cost < source.Price {
+			source.Source = UnlockSourceTradable
+			source.ItemID = itemID
+			source.Price = cost
+		}
+	}
+	if source.Source == UnlockSourceTradable {
+		return source
+	}
+
+	if def.Bit.ID != 0 {
+		if achievements := granting(def.Bit.Type, def.Bit.ID); len(achievements) > 0 {
+			setAchievementSource(source, achievements[0])
+			return source
+		}
+	}
+	for _, itemID := range def.UnlockItems {
+		if achievements := granting("Item", itemID); len(achievements) > 0 {
+			setAchievementSource(source, achievements[0])
+			source.ItemID = itemID
+			return source
+		}
+	}
+
+	return source
+}
+
+// setAchievementSource marks an unlock as granted by an achievement
+func setAchievementSource(source *UnlockSource, achievement *Achievement) {
+	source.Source = UnlockSourceAchievement
+	source.AchievementID = achievement.ID
+	source.AchievementName = achievement.Name
+	source.AchievementPoints = achievementTierPoints(achievement)
+}
+
+// unlockDefinitions fetches the names and unlock items for the given unlocks, keyed by ID
+func (c *Client) unlockDefinitions(ctx context.Context, kind UnlockKind, ids []int) (map[int]unlockDefinition, error) {
+	definitions := make(map[int]unlockDefinition, len(ids))
+	if err := c.addUnlockDefinitions(ctx, kind, ids, definitions); err != nil {
+		return nil, fmt.Errorf("failed to get %s definitions: %w", kind, err)
+	}
+	return definitions, nil
+}
+
+// addUnlockDefinitions fetches the unlocks of a kind into definitions
+func (c *Client) addUnlockDefinitions(ctx context.Context, kind UnlockKind, ids []int, definitions map[int]unlockDefinition) error {
+	switch kind {
+	case UnlockKindOutfit:
+		outfits, err := c.GetOutfits(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, outfit := range outfits {
+			definitions[outfit.ID] = unlockDefinition{ID: outfit.ID, Name: outfit.Name, UnlockItems: outfit.UnlockItems}
+		}
+	case UnlockKindGlider:
+		gliders, err := c.GetGliders(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, glider := range gliders {
+			definitions[glider.ID] = unlockDefinition{ID: glider.ID, Name: glider.Name, UnlockItems: glider.UnlockItems}
+		}
+	case UnlockKindMountSkin:
+		// Mount skins don't list unlock items, so they can only resolve to the gem store
+		skins, err := c.GetMountSkins(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, skin := range skins {
+			definitions[skin.ID] = unlockDefinition{ID: skin.ID, Name: skin.Name}
+		}
+	case UnlockKindFinisher:
+		finishers, err := c.GetFinishers(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, finisher := range finishers {
+			definitions[finisher.ID] = unlockDefinition{ID: finisher.ID, Name: finisher.Name, UnlockItems: finisher.UnlockItems}
+		}
+	case UnlockKindMini:
+		minis, err := c.GetMinis(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, mini := range minis {
+			definitions[mini.ID] = miniDefinition(mini)
+		}
+	case UnlockKindNovelty:
+		novelties, err := c.GetNovelties(ctx, ids)
+		if err != nil {
+			return err
+		}
+		for _, novelty := range novelties {
+			definitions[novelty.ID] = unlockDefinition{ID: novelty.ID, Name: novelty.Name, UnlockItems: novelty.UnlockItem}
+		}
+	default:
+		return fmt.Errorf("unknown unlock kind: %s", kind)
+	}
+	return nil
+}
+
+// miniDefinition returns the unlock definition of a mini, which achievements
+// can grant either directly through a Minipet bit or through its item
+func miniDefinition(mini *MiniDetail) unlockDefinition {
+	def := unlockDefinition{ID: mini.ID, Name: mini.Name, Bit: AchievementBitKey{Type: "Minipet", ID: mini.ID}}
+	if mini.ItemID != 0 {
+		def.UnlockItems = []int{mini.ItemID}
+	}
+	return def
+}
@@ -0,0 +1,56 @@
+package gw2api
+
+import (
+	"context"
+	"slices"
+)
+
+// UnlockComparison splits the unlocks of a kind between two accounts, A and
+// B. Each list is sorted by ID.
+type UnlockComparison struct {
+	Kind  UnlockKind `json:"kind"`
+	Both  []int      `json:"both"`
+	OnlyA []int      `json:"only_a"`
+	OnlyB []int      `json:"only_b"`
+}
+
+// CompareUnlocks returns the unlocks both accounts have and those only one of
+// them has, from the unlock IDs of each. Duplicate IDs are counted once.
+func CompareUnlocks[T ~int](kind UnlockKind, a, b []T) *UnlockComparison {
+	inB := make(map[int]bool, len(b))
+	for _, id := range b {
+		inB[int(id)] = true
+	}
+
+	comparison := &UnlockComparison{Kind: kind}
+	inA := make(map[int]bool, len(a))
+	for _, id := range uniqueIDs(unlockIDs(a)) {
+		inA[id] = true
+		if inB[id] {
+			comparison.Both = append(comparison.Both, id)
+		} else {
+			comparison.OnlyA = append(comparison.OnlyA, id)
+		}
+	}
+	comparison.OnlyB = slices.DeleteFunc(uniqueIDs(unlockIDs(b)), func(id int) bool { return inA[id] })
+	return comparison
+}
+
+// GetAccountUnlockIDs returns the IDs of the unlocks of a kind the account
+// has, for any of the WardrobeKinds
+// Scopes: account, unlocks
+func (c *Client) GetAccountUnlockIDs(ctx context.Context, kind UnlockKind) ([]int, error) {
+	return c.accountWardrobeIDs(ctx, kind)
+}
+
+// GetWardrobeEntries returns the definitions of unlocks of a kind, in the
+// order of ids. IDs without a definition are left out, and Unlocked isn't set,
+// as no account is checked.
+// Scopes: None (public endpoint)
+func (c *Client) GetWardrobeEntries(ctx context.Context, kind UnlockKind, ids []int) ([]WardrobeEntry, error) {
+	entries, err := c.wardrobeEntries(ctx, kind, ids)
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
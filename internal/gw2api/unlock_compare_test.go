@@ -0,0 +1,35 @@
+package gw2api
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestCompareUnlocks(t *testing.T) {
+	tests := []struct {
+		name               string
+		a, b               []int
+		both, onlyA, onlyB []int
+	}{
+		{name: "overlap", a: []int{3, 1, 2}, b: []int{4, 2, 3}, both: []int{2, 3}, onlyA: []int{1}, onlyB: []int{4}},
+		{name: "same", a: []int{1, 2}, b: []int{2, 1}, both: []int{1, 2}},
+		{name: "disjoint", a: []int{1}, b: []int{2}, onlyA: []int{1}, onlyB: []int{2}},
+		{name: "one empty", a: []int{5, 6}, onlyA: []int{5, 6}},
+		{name: "duplicates", a: []int{1, 1, 2}, b: []int{2, 2, 3, 3}, both: []int{2}, onlyA: []int{1}, onlyB: []int{3}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			comparison := CompareUnlocks(UnlockKindGlider, test.a, test.b)
+			if !slices.Equal(comparison.Both, test.both) || !slices.Equal(comparison.OnlyA, test.onlyA) || !slices.Equal(comparison.OnlyB, test.onlyB) {
+				t.Errorf("both %v, only A %v, only B %v; want %v, %v and %v",
+					comparison.Both, comparison.OnlyA, comparison.OnlyB, test.both, test.onlyA, test.onlyB)
+			}
+		})
+	}
+
+	// Typed unlock IDs work too
+	comparison := CompareUnlocks(UnlockKindOutfit, []Outfit{1, 2}, []Outfit{2})
+	if comparison.Kind != UnlockKindOutfit || !slices.Equal(comparison.Both, []int{2}) || !slices.Equal(comparison.OnlyA, []int{1}) {
+		t.Errorf("typed comparison = %+v", comparison)
+	}
+}
@@ -0,0 +1,116 @@
+package gw2api
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAchievementCacheAchievementsForBit(t *testing.T) {
+	ac := NewAchievementCache()
+	path := writeJSONLFixture(t,
+		`{"id": 1, "name": "Glider Collector", "bits": [{"type": "Item", "id": 500}, {"type": "Text", "text": "Fly"}]}`,
+		`{"id": 2, "name": "Glider Master", "bits": [{"type": "Item", "id": 500}, {"type": "Skin", "id": 500}]}`,
+	)
+	if err := ac.LoadFromFile(path); err != nil {
+		t.Fatalf("LoadFromFile: %v", err)
+	}
+
+	if ids := ac.AchievementsForBit("Item", 500); !reflect.DeepEqual(ids, []int{1, 2}) {
+		t.Errorf("AchievementsForBit(Item, 500) = %v, expected [1 2]", ids)
+	}
+	// Bit types are indexed separately
+	if ids := ac.AchievementsForBit("Skin", 500); !reflect.DeepEqual(ids, []int{2}) {
+		t.Errorf("AchievementsForBit(Skin, 500) = %v, expected [2]", ids)
+	}
+
+	// Replacing an achievement drops its old bits from the index
+	ac.replace([]*Achievement{{ID: 2, Name: "Glider Master"}})
+	if ids := ac.AchievementsForBit("Item", 500); !reflect.DeepEqual(ids, []int{1}) {
+		t.Errorf("AchievementsForBit(Item, 500) after replace = %v, expected [1]", ids)
+	}
+	if ids := ac.AchievementsForBit("Skin", 500); len(ids) != 0 {
+		t.Errorf("AchievementsForBit(Skin, 500) after replace = %v, expected none", ids)
+	}
+}
+
+func TestResolveUnlockSource(t *testing.T) {
+	prices := map[int]*Price{
+		100: {ID: 100, Sells: PriceInfo{Quantity: 4, UnitPrice: 25000}, Buys: PriceInfo{Quantity: 10, UnitPrice: 20000}},
+		101: {ID: 101, Sells: PriceInfo{Quantity: 1, UnitPrice: 18000}},
+		102: {ID: 102, Buys: PriceInfo{Quantity: 3, UnitPrice: 9000}},
+		103: {ID: 103}, // Listed but with no orders at all
+	}
+	achievements := map[AchievementBitKey]*Achievement{
+		{Type: "Item", ID: 200}:  {ID: 7, Name: "Wings of Glory", Tiers: []AchievementTier{{Count: 1, Points: 5}, {Count: 5, Points: 10}}},
+		{Type: "Minipet", ID: 9}: {ID: 8, Name: "Mini Collector"},
+	}
+	granting := func(bitType string, id int) []*Achievement {
+		if achievement, found := achievements[AchievementBitKey{Type: bitType, ID: id}]; found {
+			return []*Achievement{achievement}
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name     string
+		def      unlockDefinition
+		expected UnlockSource
+	}{
+		{
+			name:     "cheapest tradable item wins",
+			def:      unlockDefinition{ID: 1, Name: "Outfit", UnlockItems: []int{100, 101}},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 1, Name: "Outfit", Source: UnlockSourceTradable, ItemID: 101, Price: 18000},
+		},
+		{
+			name:     "buy orders are used without sell listings",
+			def:      unlockDefinition{ID: 2, Name: "Outfit", UnlockItems: []int{102}},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 2, Name: "Outfit", Source: UnlockSourceTradable, ItemID: 102, Price: 9000},
+		},
+		{
+			name:     "trading post is preferred over achievements",
+			def:      unlockDefinition{ID: 3, Name: "Outfit", UnlockItems: []int{200, 101}},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 3, Name: "Outfit", Source: UnlockSourceTradable, ItemID: 101, Price: 18000},
+		},
+		{
+			name:     "achievement reward",
+			def:      unlockDefinition{ID: 4, Name: "Outfit", UnlockItems: []int{103, 200}},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 4, Name: "Outfit", Source: UnlockSourceAchievement, ItemID: 200, AchievementID: 7, AchievementName: "Wings of Glory", AchievementPoints: 15},
+		},
+		{
+			name:     "achievement bit for the unlock itself",
+			def:      unlockDefinition{ID: 9, Name: "Mini", UnlockItems: []int{300}, Bit: AchievementBitKey{Type: "Minipet", ID: 9}},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 9, Name: "Mini", Source: UnlockSourceAchievement, AchievementID: 8, AchievementName: "Mini Collector"},
+		},
+		{
+			name:     "gem store fallback",
+			def:      unlockDefinition{ID: 5, Name: "Outfit", UnlockItems: []int{300}},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 5, Name: "Outfit", Source: UnlockSourceGemStore},
+		},
+		{
+			name:     "no unlock items",
+			def:      unlockDefinition{ID: 6, Name: "Outfit"},
+			expected: UnlockSource{Kind: UnlockKindOutfit, ID: 6, Name: "Outfit", Source: UnlockSourceGemStore},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			source := resolveUnlockSource(UnlockKindOutfit, tt.def, prices, granting)
+			if *source != tt.expected {
+				t.Errorf("resolveUnlockSource = %+v\nexpected %+v", *source, tt.expected)
+			}
+		})
+	}
+}
+
+func TestIsTradable(t *testing.T) {
+	if !(&Item{Flags: []string{"NoSalvage"}}).IsTradable() {
+		t.Error("item without binding flags should be tradable")
+	}
+	if (&Item{Flags: []string{"AccountBound", "NoSell"}}).IsTradable() {
+		t.Error("account bound item should not be tradable")
+	}
+	if (&Item{Flags: []string{"SoulbindOnAcquire"}}).IsTradable() {
+		t.Error("soulbound item should not be tradable")
+	}
+}
@@ -0,0 +1,74 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+// ClaimableVaultListings are the Wizard's Vault rewards the account can buy
+// right now
+type ClaimableVaultListings struct {
+	Acclaim  int                     `json:"acclaim"` // Astral Acclaim in the wallet
+	Listings []ClaimableVaultListing `json:"listings"`
+}
+
+// ClaimableVaultListing is a reward that is affordable and under its limit
+type ClaimableVaultListing struct {
+	Listing  WizardsVaultListing `json:"listing"`
+	Item     *Item               `json:"item,omitempty"`
+	Quantity int                 `json:"quantity"` // How many the acclaim buys now, up to the limit
+}
+
+// vaultListingRanks orders listing types: the season's featured rewards first,
+// then the rotating legacy ones, then the rest
+var vaultListingRanks = map[string]int{"Featured": 0, "Legacy": 1, "Normal": 2}
+
+// GetClaimableVaultListings returns the Wizard's Vault rewards the account can
+// afford and hasn't bought up to their limit. They are sorted by a simple
+// guess at value: featured rewards, then legacy ones, then the rest, and the
+// most expensive first within each, since the vault prices rewards roughly by
+// what they are worth.
+// Scopes: account, wallet
+func (c *Client) GetClaimableVaultListings(ctx context.Context) (*ClaimableVaultListings, error) {
+	listings, items, acclaim, err := c.getVaultState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return claimableVaultListings(listings, items, acclaim), nil
+}
+
+func claimableVaultListings(listings []WizardsVaultListing, items map[int]*Item, acclaim int) *ClaimableVaultListings {
+	claimable := &ClaimableVaultListings{Acclaim: acclaim, Listings: []ClaimableVaultListing{}}
+	for _, listing := range listings {
+		if listing.Cost <= 0 || !listing.Affordable(acclaim) {
+			continue
+		}
+		quantity := acclaim / listing.Cost
+		if listing.PurchaseLimit > 0 {
+			quantity = min(quantity, listing.PurchaseLimit-listing.Purchased)
+		}
+		claimable.Listings = append(claimable.Listings, ClaimableVaultListing{
+			Listing:  listing,
+			Item:     items[listing.ItemID],
+			Quantity: quantity,
+		})
+	}
+
+	slices.SortStableFunc(claimable.Listings, func(a, b ClaimableVaultListing) int {
+		return cmp.Or(
+			cmp.Compare(vaultListingRank(a.Listing.Type), vaultListingRank(b.Listing.Type)),
+			cmp.Compare(b.Listing.Cost, a.Listing.Cost),
+			cmp.Compare(a.Listing.ID, b.Listing.ID),
+		)
+	})
+	return claimable
+}
+
+// vaultListingRank ranks unknown listing types last
+func vaultListingRank(listingType string) int {
+	if rank, found := vaultListingRanks[listingType]; found {
+		return rank
+	}
+	return len(vaultListingRanks)
+}
@@ -0,0 +1,49 @@
+package gw2api
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func TestGetClaimableVaultListings(t *testing.T) {
+	client := newVaultFixtureClient(t)
+
+	claimable, err := client.GetClaimableVaultListings(context.Background())
+	if err != nil {
+		t.Fatalf("GetClaimableVaultListings: %v", err)
+	}
+	if claimable.Acclaim != 1100 {
+		t.Errorf("Acclaim = %d, expected 1100", claimable.Acclaim)
+	}
+
+	// The featured kit, the legacy keys, then the rest by cost. The clovers
+	// are at their limit, and the gold has 3 purchases left.
+	type step struct{ listing, quantity int }
+	var got []step
+	for _, entry := range claimable.Listings {
+		got = append(got, step{entry.Listing.ID, entry.Quantity})
+	}
+	expected := []step{{1, 1}, {6, 7}, {4, 1}, {5, 1}, {2, 3}}
+	if !slices.Equal(got, expected) {
+		t.Errorf("listings = %+v, expected %+v", got, expected)
+	}
+	if item := claimable.Listings[0].Item; item == nil || item.Name != "Legendary Starter Kit" {
+		t.Errorf("first item = %+v, expected the kit", item)
+	}
+}
+
+func TestWizardsVaultListingAffordable(t *testing.T) {
+	limited := WizardsVaultListing{WizardsVaultListingDetail: WizardsVaultListingDetail{Cost: 200}, Purchased: 1, PurchaseLimit: 2}
+	if !limited.Affordable(200) || limited.Affordable(199) {
+		t.Error("a listing under its limit is affordable at exactly its cost")
+	}
+	limited.Purchased = 2
+	if limited.Affordable(1000) {
+		t.Error("a listing at its limit is affordable")
+	}
+	unlimited := WizardsVaultListing{WizardsVaultListingDetail: WizardsVaultListingDetail{Cost: 150}, Purchased: 40}
+	if !unlimited.Affordable(150) {
+		t.Error("an unlimited listing is not affordable")
+	}
+}
@@ -0,0 +1,187 @@
+package gw2api
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CurrencyAstralAcclaim is the wallet currency spent in the Wizard's Vault
+const CurrencyAstralAcclaim = 63
+
+// DefaultVaultPriorities is the purchase order used when none is given
+var DefaultVaultPriorities = []string{"legendary starter kit", "skins", "gold"}
+
+// VaultPurchasePlan is what to buy from the Wizard's Vault with the acclaim
+// left this season
+type VaultPurchasePlan struct {
+	Acclaim         int              `json:"acclaim"`           // Astral Acclaim in the wallet
+	CoinsPerAcclaim float64          `json:"coins_per_acclaim"` // From the gold listing, 0 when there is none
+	Purchased       []VaultPlanEntry `json:"purchased"`         // Listings bought up to their limit
+	Plan            []VaultPlanEntry `json:"plan"`              // In the order to buy them
+}
+
+// VaultPlanEntry is a number of purchases of one listing
+type VaultPlanEntry struct {
+	Listing        WizardsVaultListing `json:"listing"`
+	Item           *Item               `json:"item,omitempty"`
+	Priority       string              `json:"priority,omitempty"` // The priority keyword the listing matched
+	Quantity       int                 `json:"quantity"`
+	Cost           int                 `json:"cost"`            // Acclaim for Quantity purchases
+	GoldEquivalent int                 `json:"gold_equivalent"` // Coins the same acclaim buys through the gold listing
+	Affordable     bool                `json:"affordable"`
+	AcclaimAfter   int                 `json:"acclaim_after"` // Acclaim left after this and earlier affordable entries
+}
+
+// GetVaultPurchasePlan plans Wizard's Vault purchases in priority order. Each
+// priority is "gold", "skins" or text to find in reward names, such as
+// "legendary starter kit". Rewards that no longer fit in the acclaim left are
+// kept in the plan, marked as not affordable.
+// Scopes: account, wallet
+func (c *Client) GetVaultPurchasePlan(ctx context.Context, priorities []string) (*VaultPurchasePlan, error) {
+	if len(priorities) == 0 {
+		priorities = DefaultVaultPriorities
+	}
+
+	listings, items, acclaim, err := c.getVaultState(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return planVaultPurchases(listings, items, acclaim, priorities), nil
+}
+
+// getVaultState fetches the account's vault listings, their reward items and
+// the Astral Acclaim in the wallet
+func (c *Client) getVaultState(ctx context.Context) ([]WizardsVaultListing, map[int]*Item, int, error) {
+	listings, err := c.GetAccountWizardsVaultListings(ctx)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get vault listings: %w", err)
+	}
+	wallet, err := c.GetAccountWallet(ctx)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to get wallet: %w", err)
+	}
+
+	itemIDs := make([]int, len(listings))
+	for i, listing := range listings {
+		itemIDs[i] = listing.ItemID
+	}
+	items, err := c.lookupItems(ctx, itemIDs)
+	if err != nil {
+		return nil, nil, 0, fmt.Errorf("failed to look up vault rewards: %w", err)
+	}
+
+	acclaim := 0
+	for _, currency := range wallet {
+		if currency.ID == CurrencyAstralAcclaim {
+			acclaim = currency.Value
+		}
+	}
+	return listings, items, acclaim, nil
+}
+
+// planVaultPurchases spends acclaim on the listings matching each priority in
+// turn, cheapest first within a priority
+func planVaultPurchases(listings []WizardsVaultListing, items map[int]*Item, acclaim int, priorities []string) *VaultPurchasePlan {
+	plan := &VaultPurchasePlan{Acclaim: acclaim, CoinsPerAcclaim: coinsPerAcclaim(listings, items)}
+
+	planned := make(map[int]bool)
+	for _, listing := range listings {
+		if listing.LimitReached() {
+			plan.Purchased = append(plan.Purchased, plan.entry(listing, items, "", listing.Purchased))
+			planned[listing.ID] = true
+		}
+	}
+
+	left := acclaim
+	for _, priority := range priorities {
+		var matches []WizardsVaultListing
+		for _, listing := range listings {
+			if !planned[listing.ID] && listing.Cost > 0 && matchesVaultPriority(priority, items[listing.ItemID]) {
+				matches = append(matches, listing)
+			}
+		}
+		slices.SortStableFunc(matches, func(a, b WizardsVaultListing) int {
+			return cmp.Or(cmp.Compare(a.Cost, b.Cost), cmp.Compare(a.ID, b.ID))
+		})
+
+		for _, listing := range matches {
+			planned[listing.ID] = true
+
+			// Unlimited listings are planned as far as the acclaim goes
+			remaining := listing.PurchaseLimit - listing.Purchased
+			if listing.PurchaseLimit == 0 {
+				remaining = max(left/listing.Cost, 1)
+			}
+
+			if affordable := min(remaining, left/listing.Cost); affordable > 0 {
+				entry := plan.entry(listing, items, priority, affordable)
+				left -= entry.Cost
+				entry.Affordable, entry.AcclaimAfter = true, left
+				plan.Plan = append(plan.Plan, entry)
+				remaining -= affordable
+			}
+			if remaining > 0 {
+				entry := plan.entry(listing, items, priority, remaining)
+				entry.AcclaimAfter = left
+				plan.Plan = append(plan.Plan, entry)
+			}
+		}
+	}
+	return plan
+}
+
+// entry builds a plan entry for quantity purchases of listing
+func (p *VaultPurchasePlan) entry(listing WizardsVaultListing, items map[int]*Item, priority string, quantity int) VaultPlanEntry {
+	cost := quantity * listing.Cost
+	return VaultPlanEntry{
+		Listing:        listing,
+		Item:           items[listing.ItemID],
+		Priority:       priority,
+		Quantity:       quantity,
+		Cost:           cost,
+		GoldEquivalent: int(float64(cost) * p.CoinsPerAcclaim),
+	}
+}
+
+// goldName matches the names of the vault's gold rewards, but not "Golden"
+var goldName = regexp.MustCompile(`(?i)\bgold\b`)
+
+// isVaultGold reports whether a reward is coins
+func isVaultGold(item *Item) bool {
+	return item != nil && goldName.MatchString(item.Name)
+}
+
+// coinsPerAcclaim values acclaim by the best gold listing, in coins
+func coinsPerAcclaim(listings []WizardsVaultListing, items map[int]*Item) float64 {
+	best := 0.0
+	for _, listing := range listings {
+		if listing.Cost > 0 && isVaultGold(items[listing.ItemID]) {
+			best = max(best, float64(listing.ItemCount*10000)/float64(listing.Cost))
+		}
+	}
+	return best
+}
+
+// skinItemTypes are item types that unlock a skin when used or equipped
+var skinItemTypes = []string{"Armor", "Back", "Weapon"}
+
+// matchesVaultPriority reports whether a reward falls under a priority keyword
+func matchesVaultPriority(priority string, item *Item) bool {
+	if item == nil {
+		return false
+	}
+	switch strings.ToLower(strings.TrimSpace(priority)) {
+	case "gold":
+		return isVaultGold(item)
+	case "skins", "skin":
+		if slices.Contains(skinItemTypes, item.Type) {
+			return true
+		}
+		return item.Details != nil && (item.Details.Type == "Transmutation" || item.Details.UnlockType == "Skin")
+	}
+	return strings.Contains(strings.ToLower(item.Name), strings.ToLower(strings.TrimSpace(priority)))
+}
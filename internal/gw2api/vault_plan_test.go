@@ -0,0 +1,83 @@
+package gw2api
+
+import (
+	"context"
+	"slices"
+	"testing"
+)
+
+func newVaultFixtureClient(t *testing.T) *Client {
+	return newFixtureClient(t, "vault", map[string]string{
+		"/v2/account/wizardsvault/listings": "listings.json",
+		"/v2/account/wallet":                "wallet.json",
+		"/v2/items":                         "items.json",
+	})
+}
+
+// planStep is the parts of a plan entry the tests compare
+type planStep struct {
+	listing    int
+	quantity   int
+	affordable bool
+	after      int
+}
+
+func planSteps(plan *VaultPurchasePlan) []planStep {
+	var steps []planStep
+	for _, entry := range plan.Plan {
+		steps = append(steps, planStep{entry.Listing.ID, entry.Quantity, entry.Affordable, entry.AcclaimAfter})
+	}
+	return steps
+}
+
+func TestGetVaultPurchasePlan(t *testing.T) {
+	client := newVaultFixtureClient(t)
+
+	plan, err := client.GetVaultPurchasePlan(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("GetVaultPurchasePlan: %v", err)
+	}
+
+	if plan.Acclaim != 1100 {
+		t.Errorf("Acclaim = %d, expected 1100", plan.Acclaim)
+	}
+	if plan.CoinsPerAcclaim != 500 {
+		t.Errorf("CoinsPerAcclaim = %v, expected 500 from 1 gold for 20 acclaim", plan.CoinsPerAcclaim)
+	}
+	if len(plan.Purchased) != 1 || plan.Purchased[0].Listing.ID != 3 || plan.Purchased[0].Quantity != 10 {
+		t.Errorf("Purchased = %+v, expected the 10 clovers", plan.Purchased)
+	}
+
+	// The kit first, then the cheaper skin, the skin that no longer fits,
+	// and the gold left until the limit
+	expected := []planStep{
+		{1, 1, true, 300},
+		{5, 1, true, 100},
+		{4, 1, false, 100},
+		{2, 3, true, 40},
+	}
+	if got := planSteps(plan); !slices.Equal(got, expected) {
+		t.Errorf("plan = %+v, expected %+v", got, expected)
+	}
+	if kit := plan.Plan[0]; kit.Priority != "legendary starter kit" || kit.GoldEquivalent != 400000 || kit.Item.Name != "Legendary Starter Kit" {
+		t.Errorf("kit entry = %+v, expected 40 gold equivalent", kit)
+	}
+}
+
+func TestVaultPlanUnlimitedListing(t *testing.T) {
+	client := newVaultFixtureClient(t)
+
+	plan, err := client.GetVaultPurchasePlan(context.Background(), []string{"gold", "Chest Key"})
+	if err != nil {
+		t.Fatalf("GetVaultPurchasePlan: %v", err)
+	}
+
+	// Keys have no limit, so as many are planned as the acclaim left buys
+	expected := []planStep{
+		{2, 3, true, 1040},
+		{6, 6, true, 140},
+	}
+	if got := planSteps(plan); !slices.Equal(got, expected) {
+		t.Errorf("plan = %+v, expected %+v", got, expected)
+	}
+}
@@ -0,0 +1,109 @@
+package gw2api
+
+import (
+	"slices"
+	"time"
+)
+
+// VendorCache provides in-memory caching of vendors with a reverse index from
+// item ID to the vendors selling it
+type VendorCache struct {
+	jsonlCache[Vendor]
+	vendorsByItem map[int][]int // ItemID -> IDs of vendors selling it
+}
+
+// VendorCacheStats tracks vendor cache performance
+type VendorCacheStats struct {
+	LoadedVendors int
+	LoadTime      time.Duration
+	CacheHits     int64
+	CacheMisses   int64
+	LastLoadTime  time.Time
+}
+
+// NewVendorCache creates a new vendor cache
+func NewVendorCache() *VendorCache {
+	vc := &VendorCache{
+		jsonlCache:    newJSONLCache("vendors", func(vendor *Vendor) int { return vendor.ID }),
+		vendorsByItem: make(map[int][]int),
+	}
+	vc.resetIndex = func() { vc.vendorsByItem = make(map[int][]int) }
+	vc.index = func(vendor *Vendor) {
+		for _, sale := range vendor.Sells {
+			if !slices.Contains(vc.vendorsByItem[sale.ItemID], vendor.ID) {
+				vc.vendorsByItem[sale.ItemID] = append(vc.vendorsByItem[sale.ItemID], vendor.ID)
+			}
+		}
+	}
+	vc.unindex = func(vendor *Vendor) {
+		for _, sale := range vendor.Sells {
+			vc.vendorsByItem[sale.ItemID] = removeID(vc.vendorsByItem[sale.ItemID], vendor.ID)
+		}
+	}
+	return vc
+}
+
+// VendorsForItem returns the cached vendors selling an item, in vendor ID order
+func (vc *VendorCache) VendorsForItem(itemID int) []*Vendor {
+	vc.mutex.RLock()
+	defer vc.mutex.RUnlock()
+
+	ids := vc.vendorsByItem[itemID]
+	if len(ids) == 0 {
+		vc.misses.Add(1)
+		return nil
+	}
+
+	vc.hits.Add(1)
+	ids = slices.Sorted(slices.Values(ids))
+	vendors := make([]*Vendor, 0, len(ids))
+	for _, id := range ids {
+		vendors = append(vendors, deepCopy(vc.byID[id]))
+	}
+	return vendors
+}
+
+// Stats returns cache statistics
+func (vc *VendorCache) Stats() VendorCacheStats {
+	s := vc.snapshot()
+	return VendorCacheStats{
+		LoadedVendors: s.loaded,
+		LoadTime:      s.loadTime,
+		CacheHits:     s.hits,
+		CacheMisses:   s.misses,
+		LastLoadTime:  s.lastLoadTime,
+	}
+}
+
+// CurrencyCache provides in-memory caching of wallet currencies
+type CurrencyCache struct {
+	jsonlCache[Currency]
+}
+
+// CurrencyCacheStats tracks currency cache performance
+type CurrencyCacheStats struct {
+	LoadedCurrencies int
+	LoadTime         time.Duration
+	CacheHits        int64
+	CacheMisses      int64
+	LastLoadTime     time.Time
+}
+
+// NewCurrencyCache creates a new currency cache
+func NewCurrencyCache() *CurrencyCache {
+	return &CurrencyCache{
+		jsonlCache: newJSONLCache("currencies", func(currency *Currency) int { return currency.ID }),
+	}
+}
+
+// Stats returns cache statistics
+func (cc *CurrencyCache) Stats() CurrencyCacheStats {
+	s := cc.snapshot()
+	return CurrencyCacheStats{
+		LoadedCurrencies: s.loaded,
+		LoadTime:         s.loadTime,
+		CacheHits:        s.hits,
+		CacheMisses:      s.misses,
+		LastLoadTime:     s.lastLoadTime,
+	}
+}
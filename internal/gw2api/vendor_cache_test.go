@@ -0,0 +1,86 @@
+package gw2api
+
+import (
+	"context"
+	"testing"
+)
+
+func newVendorTestClient(t *testing.T) *Client {
+	t.Helper()
+	dir := writeDataDir(t, map[string]string{
+		"vendors.json": `{"id": 1, "type": "Karma", "sells": [{"item_id": 100, "cost": [{"currency_id": 2, "quantity": 2100}, {"currency_id": 1, "quantity": 15050}]}, {"item_id": 101, "cost": [{"item_id": 19925, "quantity": 3}]}]}` + "\n" +
+			`{"id": 2, "type": "Badge", "sells": [{"item_id": 100, "cost": [{"currency_id": 15, "quantity": 50}]}]}` + "\n" +
+			`{"id": 3, "type": "Karma", "sells": [{"item_id": 101, "cost": [{"currency_id": 99, "quantity": 1}]}]}` + "\n",
+		"currencies.json": `{"id": 1, "name": "Coin"}` + "\n" + `{"id": 2, "name": "Karma"}` + "\n" + `{"id": 15, "name": "Badge of Honor"}` + "\n",
+		"items.json":      `{"id": 100, "name": "Karma Sword"}` + "\n" + `{"id": 101, "name": "Shard Shield"}` + "\n" + `{"id": 19925, "name": "Obsidian Shard"}` + "\n",
+	})
+	client, err := NewClientE(WithDataCache(dir), WithRetries(0), WithBaseURL("http://127.0.0.1:0"))
+	if err != nil {
+		t.Fatalf("NewClientE: %v", err)
+	}
+	return client
+}
+
+func TestVendorsForItem(t *testing.T) {
+	client := newVendorTestClient(t)
+	cache := client.DataCache().GetVendorCache()
+
+	vendors := cache.VendorsForItem(100)
+	if len(vendors) != 2 || vendors[0].ID != 1 || vendors[1].ID != 2 {
+		t.Fatalf("vendors for item 100 = %+v, expected vendors 1 and 2", vendors)
+	}
+	if vendors := cache.VendorsForItem(19925); len(vendors) != 0 {
+		t.Errorf("vendors for a cost item = %+v, expected none", vendors)
+	}
+
+	vendors[0].Sells = nil
+	if again := cache.VendorsForItem(100); len(again[0].Sells) != 2 {
+		t.Error("VendorsForItem returned the cached vendor rather than a copy")
+	}
+}
+
+func TestGetVendorOffers(t *testing.T) {
+	client := newVendorTestClient(t)
+
+	offers, err := client.GetVendorOffers(context.Background(), 100)
+	if err != nil {
+		t.Fatalf("GetVendorOffers: %v", err)
+	}
+	if len(offers) != 2 {
+		t.Fatalf("offers = %+v, expected 2", offers)
+	}
+	if got := offers[0].String(); got != "2100 Karma + 1g 50s 50c" {
+		t.Errorf("karma vendor offer = %q", got)
+	}
+	if got := offers[1].String(); got != "50 Badge of Honor" {
+		t.Errorf("badge vendor offer = %q", got)
+	}
+
+	offers, _ = client.GetVendorOffers(context.Background(), 101)
+	if len(offers) != 2 || offers[0].String() != "3 Obsidian Shard" {
+		t.Fatalf("offers for item 101 = %+v", offers)
+	}
+	// The currency is neither cached nor reachable through the API
+	if got := offers[1].String(); got != "1 currency 99" {
+		t.Errorf("offer in an unknown currency = %q", got)
+	}
+
+	if offers, err := client.GetVendorOffers(context.Background(), 19925); err != nil || offers != nil {
+		t.Errorf("offers for an item no vendor sells = %+v, %v", offers, err)
+	}
+}
+
+func TestCoinsString(t *testing.T) {
+	tests := map[Coins]string{
+		0:       "0c",
+		5:       "5c",
+		100:     "1s",
+		10005:   "1g 5c",
+		1234567: "123g 45s 67c",
+	}
+	for coins, expected := range tests {
+		if got := coins.String(); got != expected {
+			t.Errorf("Coins(%d) = %q, expected %q", int(coins), got, expected)
+		}
+	}
+}
@@ -0,0 +1,105 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// VendorOffer is a vendor selling an item, with the costs resolved to names
+type VendorOffer struct {
+	VendorID   int           `json:"vendor_id"`
+	VendorType string        `json:"vendor_type,omitempty"`
+	Costs      []VendorPrice `json:"costs"`
+}
+
+// VendorPrice is one part of what a vendor asks for an item, such as 42 karma
+type VendorPrice struct {
+	Quantity   int    `json:"quantity"`
+	CurrencyID int    `json:"currency_id,omitempty"`
+	ItemID     int    `json:"item_id,omitempty"`
+	Name       string `json:"name"` // Empty if the currency or item is unknown
+	Icon       string `json:"icon,omitempty"`
+}
+
+// String formats the price, such as "1g 50s" for coin, "2100 Karma" or
+// "3 Obsidian Shard"
+func (p VendorPrice) String() string {
+	if p.CurrencyID == CurrencyCoin {
+		return Coins(p.Quantity).String()
+	}
+
+	name := p.Name
+	if name == "" && p.CurrencyID != 0 {
+		name = "currency " + strconv.Itoa(p.CurrencyID)
+	} else if name == "" {
+		name = "item " + strconv.Itoa(p.ItemID)
+	}
+	return fmt.Sprintf("%d %s", p.Quantity, name)
+}
+
+// String formats all of the offer's costs, such as "1g 50s + 2100 Karma"
+func (o VendorOffer) String() string {
+	costs := make([]string, len(o.Costs))
+	for i, cost := range o.Costs {
+		costs[i] = cost.String()
+	}
+	return strings.Join(costs, " + ")
+}
+
+// GetVendorOffers returns the vendors selling an item with their costs resolved
+// to currency and item names. Vendors are only known from the cached vendors
+// data file, so nothing is returned without it. Currencies and items whose
+// names can't be fetched are left unnamed rather than failing the lookup.
+func (c *Client) GetVendorOffers(ctx context.Context, itemID int) ([]VendorOffer, error) {
+	if c.dataCache == nil || !c.dataCache.GetVendorCache().IsLoaded() {
+		return nil, nil
+	}
+	vendors := c.dataCache.GetVendorCache().VendorsForItem(itemID)
+	if len(vendors) == 0 {
+		return nil, nil
+	}
+
+	var offers []VendorOffer
+	var currencyIDs, itemIDs []int
+	for _, vendor := range vendors {
+		for _, sale := range vendor.Sells {
+			if sale.ItemID != itemID {
+				continue
+			}
+			offer := VendorOffer{VendorID: vendor.ID, VendorType: vendor.Type}
+			for _, cost := range sale.Cost {
+				offer.Costs = append(offer.Costs, VendorPrice{Quantity: cost.Quantity, CurrencyID: cost.CurrencyID, ItemID: cost.ItemID})
+				if cost.CurrencyID != 0 {
+					currencyIDs = append(currencyIDs, cost.CurrencyID)
+				} else {
+					itemIDs = append(itemIDs, cost.ItemID)
+				}
+			}
+			offers = append(offers, offer)
+		}
+	}
+
+	currencies := make(map[int]*Currency)
+	if ids := uniqueIDs(currencyIDs); len(ids) > 0 {
+		if results, err := c.GetCurrencies(ctx, ids); err == nil {
+			for _, currency := range results {
+				currencies[currency.ID] = currency
+			}
+		}
+	}
+	items, _ := c.lookupItems(ctx, itemIDs)
+
+	for i := range offers {
+		for j := range offers[i].Costs {
+			cost := &offers[i].Costs[j]
+			if currency, found := currencies[cost.CurrencyID]; found && cost.CurrencyID != 0 {
+				cost.Name, cost.Icon = currency.Name, currency.Icon
+			} else if item, found := items[cost.ItemID]; found && cost.ItemID != 0 {
+				cost.Name, cost.Icon = item.Name, item.Icon
+			}
+		}
+	}
+	return offers, nil
+}
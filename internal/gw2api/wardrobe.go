@@ -0,0 +1,240 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// WardrobeKinds lists the unlock kinds GetWardrobePage can list
+var WardrobeKinds = []UnlockKind{UnlockKindGlider, UnlockKindMountSkin, UnlockKindOutfit, UnlockKindFinisher, UnlockKindMini, UnlockKindNovelty}
+
+// WardrobeEntry is one glider, mount skin, outfit, finisher, mini or novelty
+type WardrobeEntry struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Icon     string `json:"icon"`
+	Group    string `json:"group,omitempty"` // Mount a skin belongs to, or a novelty's slot
+	Unlocked bool   `json:"unlocked"`        // Only meaningful when the page's AccountKnown is set
+
+	// The achievement that grants a locked entry, when the achievement data
+	// cache is loaded and has one
+	AchievementID     int    `json:"achievement_id,omitempty"`
+	AchievementName   string `json:"achievement_name,omitempty"`
+	AchievementPoints int    `json:"achievement_points,omitempty"`
+
+	def unlockDefinition // For looking up the granting achievement
+}
+
+// WardrobePage is one page of every unlock of a kind, in ID order
+type WardrobePage struct {
+	Kind     UnlockKind      `json:"kind"`
+	Entries  []WardrobeEntry `json:"entries"`
+	Page     int             `json:"page"`
+	PageSize int             `json:"page_size"`
+	Total    int             `json:"total"`    // Unlocks of this kind across all pages
+	Unlocked int             `json:"unlocked"` // Unlocks of this kind the account has, across all pages
+
+	// AccountKnown is set when the unlocks were checked against the account.
+	// Without an API key, or when the key lacks the unlocks scope, entries are
+	// listed without their unlock status.
+	AccountKnown bool `json:"account_known"`
+	MissingScope bool `json:"missing_scope,omitempty"`
+}
+
+// HasMore reports whether there are pages after this one
+func (p *WardrobePage) HasMore() bool {
+	return (p.Page+1)*p.PageSize < p.Total
+}
+
+// MissingUnlocks returns the IDs in all that are not in unlocked, in the order of all
+func MissingUnlocks[T ~int](all []int, unlocked []T) []int {
+	owned := make(map[int]bool, len(unlocked))
+	for _, id := range unlocked {
+		owned[int(id)] = true
+	}
+
+	var missing []int
+	for _, id := range all {
+		if !owned[id] {
+			missing = append(missing, id)
+		}
+	}
+	return missing
+}
+
+// GetWardrobePage returns page (counting from 0) of every unlock of the kind,
+// fetching definitions only for that page. When the client has an API key, each
+// entry is marked with whether the account has unlocked it, and locked entries
+// name the achievement that grants them if the achievement data cache knows
+// one. Page sizes are capped at the API's limit of 200 IDs per request.
+// Scopes: account, unlocks (optional)
+func (c *Client) GetWardrobePage(ctx context.Context, kind UnlockKind, page, pageSize int) (*WardrobePage, error) {
+	if pageSize <= 0 || pageSize > maxIDsPerRequest {
+		pageSize = maxIDsPerRequest
+	}
+	page = max(page, 0)
+
+	all, err := c.wardrobeIDs(ctx, kind)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s unlocks: %w", kind, err)
+	}
+	result := &WardrobePage{Kind: kind, Page: page, PageSize: pageSize, Total: len(all)}
+
+	owned := make(map[int]bool)
+	if c.HasAPIKey() {
+		unlocked, err := c.accountWardrobeIDs(ctx, kind)
+		switch {
+		case errors.Is(err, ErrMissingScope):
+			result.MissingScope = true
+		case err != nil:
+			return nil, fmt.Errorf("failed to get account %s unlocks: %w", kind, err)
+		default:
+			result.AccountKnown = true
+			result.Unlocked = len(all) - len(MissingUnlocks(all, unlocked))
+			for _, id := range unlocked {
+				owned[id] = true
+			}
+		}
+	}
+
+	start := min(page*pageSize, len(all))
+	ids := all[start:min(start+pageSize, len(all))]
+	if len(ids) == 0 {
+		return result, nil
+	}
+
+	entries, err := c.wardrobeEntries(ctx, kind, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s definitions: %w", kind, err)
+	}
+	// Scanning the API for sources on every page would be too slow, so they
+	// are only shown with a loaded cache
+	granting := c.cachedAchievementGranter()
+	for i := range entries {
+		entry := &entries[i]
+		entry.Unlocked = owned[entry.ID]
+		if result.AccountKnown && !entry.Unlocked && granting != nil {
+			source := resolveUnlockSource(kind, entry.def, nil, granting)
+			entry.AchievementID = source.AchievementID
+			entry.AchievementName = source.AchievementName
+			entry.AchievementPoints = source.AchievementPoints
+		}
+	}
+	result.Entries = entries
+	return result, nil
+}
+
+// wardrobeIDs lists every unlock of the kind
+func (c *Client) wardrobeIDs(ctx context.Context, kind UnlockKind) ([]int, error) {
+	switch kind {
+	case UnlockKindGlider:
+		return c.GetGliderIDs(ctx)
+	case UnlockKindMountSkin:
+		return c.GetMountSkinIDs(ctx)
+	case UnlockKindOutfit:
+		return c.GetOutfitIDs(ctx)
+	case UnlockKindFinisher:
+		return c.GetFinisherIDs(ctx)
+	case UnlockKindMini:
+		return c.GetMiniIDs(ctx)
+	case UnlockKindNovelty:
+		return c.GetNoveltyIDs(ctx)
+	}
+	return nil, fmt.Errorf("unknown unlock kind: %s", kind)
+}
+
+// accountWardrobeIDs lists the unlocks of the kind the account has
+func (c *Client) accountWardrobeIDs(ctx context.Context, kind UnlockKind) ([]int, error) {
+	switch kind {
+	case UnlockKindGlider:
+		unlocked, err := c.GetAccountGliders(ctx)
+		return unlockIDs(unlocked), err
+	case UnlockKindMountSkin:
+		unlocked, err := c.GetAccountMountSkins(ctx)
+		return unlockIDs(unlocked), err
+	case UnlockKindOutfit:
+		unlocked, err := c.GetAccountOutfits(ctx)
+		return unlockIDs(unlocked), err
+	case UnlockKindFinisher:
+		unlocked, err := c.GetAccountFinishers(ctx)
+		ids := make([]int, len(unlocked))
+		for i, finisher := range unlocked {
+			ids[i] = finisher.ID
+		}
+		return ids, err
+	case UnlockKindMini:
+		unlocked, err := c.GetAccountMinis(ctx)
+		return unlockIDs(unlocked), err
+	case UnlockKindNovelty:
+		unlocked, err := c.GetAccountNovelties(ctx)
+		return unlockIDs(unlocked), err
+	}
+	return nil, fmt.Errorf("unknown unlock kind: %s", kind)
+}
+
+// wardrobeEntries fetches the definitions of unlocks, in the order of ids. IDs
+// without a definition are left out.
+func (c *Client) wardrobeEntries(ctx context.Context, kind UnlockKind, ids []int) ([]WardrobeEntry, error) {
+	byID := make(map[int]WardrobeEntry, len(ids))
+	switch kind {
+	case UnlockKindGlider:
+		gliders, err := c.GetGliders(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, glider := range gliders {
+			byID[glider.ID] = WardrobeEntry{ID: glider.ID, Name: glider.Name, Icon: glider.Icon, def: unlockDefinition{UnlockItems: glider.UnlockItems}}
+		}
+	case UnlockKindMountSkin:
+		skins, err := c.GetMountSkins(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, skin := range skins {
+			byID[skin.ID] = WardrobeEntry{ID: skin.ID, Name: skin.Name, Icon: skin.Icon, Group: skin.Mount}
+		}
+	case UnlockKindOutfit:
+		outfits, err := c.GetOutfits(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, outfit := range outfits {
+			byID[outfit.ID] = WardrobeEntry{ID: outfit.ID, Name: outfit.Name, Icon: outfit.Icon, def: unlockDefinition{UnlockItems: outfit.UnlockItems}}
+		}
+	case UnlockKindFinisher:
+		finishers, err := c.GetFinishers(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, finisher := range finishers {
+			byID[finisher.ID] = WardrobeEntry{ID: finisher.ID, Name: finisher.Name, Icon: finisher.Icon, def: unlockDefinition{UnlockItems: finisher.UnlockItems}}
+		}
+	case UnlockKindMini:
+		minis, err := c.GetMinis(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, mini := range minis {
+			byID[mini.ID] = WardrobeEntry{ID: mini.ID, Name: mini.Name, Icon: mini.Icon, def: miniDefinition(mini)}
+		}
+	case UnlockKindNovelty:
+		novelties, err := c.GetNovelties(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, novelty := range novelties {
+			byID[novelty.ID] = WardrobeEntry{ID: novelty.ID, Name: novelty.Name, Icon: novelty.Icon, Group: novelty.Slot, def: unlockDefinition{UnlockItems: novelty.UnlockItem}}
+		}
+	default:
+		return nil, fmt.Errorf("unknown unlock kind: %s", kind)
+	}
+
+	entries := make([]WardrobeEntry, 0, len(ids))
+	for _, id := range ids {
+		if entry, found := byID[id]; found {
+			entries = append(entries, entry)
+		}
+	}
+	return entries, nil
+}
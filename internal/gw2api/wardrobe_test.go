@@ -0,0 +1,110 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newWardrobeClient returns a client for a fake API with five gliders, of
+// which the account has unlocked 2 and 5. accountStatus is the status of the
+// account gliders endpoint.
+func newWardrobeClient(t *testing.T, accountStatus int, options ...ClientOption) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/gliders":
+			ids := r.URL.Query().Get("ids")
+			if ids == "" {
+				w.Write([]byte(`[1, 2, 3, 4, 5]`))
+				return
+			}
+			var gliders []GliderDetail
+			for id := range strings.SplitSeq(ids, ",") {
+				n, _ := strconv.Atoi(id)
+				gliders = append(gliders, GliderDetail{ID: n, Name: "Glider " + id, Icon: "https://render.example/" + id + ".png"})
+			}
+			json.NewEncoder(w).Encode(gliders)
+		case "/v2/account/gliders":
+			w.WriteHeader(accountStatus)
+			if accountStatus == http.StatusOK {
+				w.Write([]byte(`[2, 5]`))
+			} else {
+				w.Write([]byte(`{"text": "requires scope unlocks"}`))
+			}
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "not found"}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return NewClient(append([]ClientOption{WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000)}, options...)...)
+}
+
+func TestGetWardrobePage(t *testing.T) {
+	client := newWardrobeClient(t, http.StatusOK, WithAPIKey("key"))
+	ctx := context.Background()
+
+	first, err := client.GetWardrobePage(ctx, UnlockKindGlider, 0, 3)
+	if err != nil {
+		t.Fatalf("GetWardrobePage: %v", err)
+	}
+	if !first.AccountKnown || first.Total != 5 || first.Unlocked != 2 || !first.HasMore() {
+		t.Errorf("first page = %+v, expected 2 of 5 unlocked with more pages", first)
+	}
+	var unlocked []int
+	for _, entry := range first.Entries {
+		if entry.Unlocked {
+			unlocked = append(unlocked, entry.ID)
+		}
+	}
+	if len(first.Entries) != 3 || first.Entries[0].Name != "Glider 1" || !slices.Equal(unlocked, []int{2}) {
+		t.Errorf("first page entries = %+v, expected gliders 1-3 with 2 unlocked", first.Entries)
+	}
+
+	last, err := client.GetWardrobePage(ctx, UnlockKindGlider, 1, 3)
+	if err != nil {
+		t.Fatalf("GetWardrobePage: %v", err)
+	}
+	if len(last.Entries) != 2 || last.Entries[1].ID != 5 || !last.Entries[1].Unlocked || last.HasMore() {
+		t.Errorf("last page = %+v, expected gliders 4 and 5 and no more pages", last)
+	}
+
+	past, err := client.GetWardrobePage(ctx, UnlockKindGlider, 7, 3)
+	if err != nil || len(past.Entries) != 0 {
+		t.Errorf("page past the end = %+v, %v, expected no entries", past, err)
+	}
+}
+
+func TestGetWardrobePageWithoutAccount(t *testing.T) {
+	ctx := context.Background()
+
+	page, err := newWardrobeClient(t, http.StatusOK).GetWardrobePage(ctx, UnlockKindGlider, 0, 0)
+	if err != nil {
+		t.Fatalf("GetWardrobePage without a key: %v", err)
+	}
+	if page.AccountKnown || page.Unlocked != 0 || len(page.Entries) != 5 || page.PageSize != maxIDsPerRequest {
+		t.Errorf("page = %+v, expected all five gliders without unlock status", page)
+	}
+
+	page, err = newWardrobeClient(t, http.StatusForbidden, WithAPIKey("key")).GetWardrobePage(ctx, UnlockKindGlider, 0, 0)
+	if err != nil {
+		t.Fatalf("GetWardrobePage without the unlocks scope: %v", err)
+	}
+	if page.AccountKnown || !page.MissingScope || len(page.Entries) != 5 {
+		t.Errorf("page = %+v, expected the gliders with the scope reported missing", page)
+	}
+}
+
+func TestMissingUnlocks(t *testing.T) {
+	missing := MissingUnlocks([]int{4, 1, 3, 2}, []Glider{3, 1, 9})
+	if !slices.Equal(missing, []int{4, 2}) {
+		t.Errorf("MissingUnlocks = %v, expected [4 2]", missing)
+	}
+}
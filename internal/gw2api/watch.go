@@ -0,0 +1,68 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"time"
+)
+
+// BuildChange is a game build seen by WatchBuild
+type BuildChange struct {
+	Previous int // 0 for the build current when watching started
+	Current  int
+	Time     time.Time
+}
+
+// WatchBuild polls the game build every interval until ctx is done. The build
+// is fetched once before it returns, and that build is the first value sent,
+// with Previous 0; after that a value is sent each time the build changes.
+// Each wait is jittered by up to a tenth of the interval so watchers started
+// together don't poll together. Failed polls are retried at the next interval.
+// With WithBuildCache, polls within the cache lifetime see the cached build.
+// The channel is closed when ctx is done.
+func (c *Client) WatchBuild(ctx context.Context, interval time.Duration) (<-chan BuildChange, error) {
+	if interval <= 0 {
+		return nil, errors.New("watch interval must be positive")
+	}
+	build, err := c.GetBuild(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	changes := make(chan BuildChange, 1)
+	changes <- BuildChange{Current: build.ID, Time: time.Now()}
+	go func() {
+		defer close(changes)
+		current := build.ID
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(jitter(interval)):
+			}
+
+			build, err := c.GetBuild(ctx)
+			if err != nil || build.ID == current {
+				continue
+			}
+			change := BuildChange{Previous: current, Current: build.ID, Time: time.Now()}
+			current = build.ID
+			select {
+			case <-ctx.Done():
+				return
+			case changes <- change:
+			}
+		}
+	}()
+	return changes, nil
+}
+
+// jitter returns interval moved randomly by up to a tenth either way
+func jitter(interval time.Duration) time.Duration {
+	spread := int64(interval / 10)
+	if spread <= 0 {
+		return interval
+	}
+	return interval + time.Duration(rand.Int64N(2*spread+1)-spread)
+}
@@ -0,0 +1,79 @@
+package gw2api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWatchBuild(t *testing.T) {
+	// The build changes on the third and fifth polls and one poll fails
+	builds := []int{100, 100, 101, 0, 102}
+	var polls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := int(polls.Add(1)) - 1
+		build := builds[min(n, len(builds)-1)]
+		if build == 0 {
+			http.Error(w, `{"text": "unavailable"}`, http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprintf(w, `{"id": %d}`, build)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(WithBaseURL(server.URL), WithRetries(0), WithRateLimit(1000))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	changes, err := client.WatchBuild(ctx, 5*time.Millisecond)
+	if err != nil {
+		t.Fatalf("WatchBuild: %v", err)
+	}
+
+	expected := [][2]int{{0, 100}, {100, 101}, {101, 102}}
+	for _, want := range expected {
+		change, ok := <-changes
+		if !ok {
+			t.Fatalf("channel closed before change %v", want)
+		}
+		if change.Previous != want[0] || change.Current != want[1] || change.Time.IsZero() {
+			t.Errorf("change = %+v, expected %d -> %d", change, want[0], want[1])
+		}
+	}
+
+	cancel()
+	for range changes {
+	}
+}
+
+func TestWatchBuildErrors(t *testing.T) {
+	client := NewClient()
+	if _, err := client.WatchBuild(context.Background(), 0); err == nil {
+		t.Error("WatchBuild with no interval succeeded")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"text": "unavailable"}`, http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(server.Close)
+
+	client = NewClient(WithBaseURL(server.URL), WithRetries(0))
+	if _, err := client.WatchBuild(context.Background(), time.Minute); err == nil {
+		t.Error("WatchBuild succeeded without a first build")
+	}
+}
+
+func TestJitter(t *testing.T) {
+	for range 100 {
+		if d := jitter(time.Minute); d < 54*time.Second || d > 66*time.Second {
+			t.Fatalf("jitter(1m) = %v, expected within 6s", d)
+		}
+	}
+	if d := jitter(time.Nanosecond); d != time.Nanosecond {
+		t.Errorf("jitter(1ns) = %v", d)
+	}
+}
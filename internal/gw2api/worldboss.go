@@ -0,0 +1,116 @@
+package gw2api
+
+import (
+	"slices"
+	"time"
+)
+
+// WorldBossSchedule describes when a world boss spawns each day.
+// The API does not expose spawn times, so the timetable is baked into the package.
+type WorldBossSchedule struct {
+	ID     string          // ID as returned by /v2/worldbosses and /v2/account/worldbosses
+	Name   string          // Display name
+	Spawns []time.Duration // Spawn times as offsets from 00:00 UTC, ascending
+}
+
+// UpcomingWorldBoss is a world boss paired with its next spawn time
+type UpcomingWorldBoss struct {
+	ID        string
+	Name      string
+	NextSpawn time.Time
+}
+
+// WorldBossSchedules is the daily UTC world boss timetable
+var WorldBossSchedules = []WorldBossSchedule{
+	{ID: "admiral_taidha_covington", Name: "Admiral Taidha Covington", Spawns: repeatingSpawns(0, 3*time.Hour)},
+	{ID: "svanir_shaman_chief", Name: "Svanir Shaman Chief", Spawns: repeatingSpawns(15*time.Minute, 2*time.Hour)},
+	{ID: "megadestroyer", Name: "Megadestroyer", Spawns: repeatingSpawns(30*time.Minute, 3*time.Hour)},
+	{ID: "fire_elemental", Name: "Fire Elemental", Spawns: repeatingSpawns(45*time.Minute, 2*time.Hour)},
+	{ID: "the_shatterer", Name: "The Shatterer", Spawns: repeatingSpawns(time.Hour, 3*time.Hour)},
+	{ID: "drakkar", Name: "Drakkar", Spawns: repeatingSpawns(time.Hour+5*time.Minute, 2*time.Hour)},
+	{ID: "great_jungle_wurm", Name: "Great Jungle Wurm", Spawns: repeatingSpawns(time.Hour+15*time.Minute, 2*time.Hour)},
+	{ID: "modniir_ulgoth", Name: "Modniir Ulgoth", Spawns: repeatingSpawns(time.Hour+30*time.Minute, 3*time.Hour)},
+	{ID: "shadow_behemoth", Name: "Shadow Behemoth", Spawns: repeatingSpawns(time.Hour+45*time.Minute, 2*time.Hour)},
+	{ID: "inquest_golem_mark_ii", Name: "Golem Mark II", Spawns: repeatingSpawns(2*time.Hour, 3*time.Hour)},
+	{ID: "claw_of_jormag", Name: "Claw of Jormag", Spawns: repeatingSpawns(2*time.Hour+30*time.Minute, 3*time.Hour)},
+	{ID: "tequatl_the_sunless", Name: "Tequatl the Sunless", Spawns: fixedSpawns("00:00", "03:00", "07:00", "11:30", "16:00", "19:00")},
+	{ID: "triple_trouble_wurm", Name: "Triple Trouble", Spawns: fixedSpawns("01:00", "04:00", "08:00", "12:30", "17:00", "20:00")},
+	{ID: "karka_queen", Name: "Karka Queen", Spawns: fixedSpawns("02:00", "06:00", "10:30", "15:00", "18:00", "23:00")},
+}
+
+// repeatingSpawns returns every spawn in a UTC day starting at first and repeating every interval
+func repeatingSpawns(first, interval time.Duration) []time.Duration {
+	var spawns []time.Duration
+	for offset := first; offset < 24*time.Hour; offset += interval {
+		spawns = append(spawns, offset)
+	}
+	return spawns
+}
+
+// fixedSpawns parses "HH:MM" UTC clock times into offsets from midnight
+func fixedSpawns(clock ...string) []time.Duration {
+	spawns := make([]time.Duration, len(clock))
+	for i, c := range clock {
+		t, err := time.Parse("15:04", c)
+		if err != nil {
+			panic("invalid world boss spawn time: " + c)
+		}
+		spawns[i] = time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute
+	}
+	return spawns
+}
+
+// FindWorldBossSchedule looks up the timetable for a world boss ID
+func FindWorldBossSchedule(bossID string) (WorldBossSchedule, bool) {
+	for _, schedule := range WorldBossSchedules {
+		if schedule.ID == bossID {
+			return schedule, true
+		}
+	}
+	return WorldBossSchedule{}, false
+}
+
+// Next returns the first spawn at or after now, in UTC
+func (s WorldBossSchedule) Next(now time.Time) time.Time {
+	if len(s.Spawns) == 0 {
+		return time.Time{}
+	}
+
+	now = now.UTC()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	for _, offset := range s.Spawns {
+		if spawn := midnight.Add(offset); !spawn.Before(now) {
+			return spawn
+		}
+	}
+
+	// Every spawn today has passed, so the next one is tomorrow's first
+	return midnight.AddDate(0, 0, 1).Add(s.Spawns[0])
+}
+
+// NextSpawn returns the next spawn time at or after now for a world boss ID.
+// The zero time is returned for unknown bosses.
+func NextSpawn(bossID string, now time.Time) time.Time {
+	schedule, found := FindWorldBossSchedule(bossID)
+	if !found {
+		return time.Time{}
+	}
+	return schedule.Next(now)
+}
+
+// UpcomingWorldBosses returns every scheduled world boss ordered by soonest spawn
+func UpcomingWorldBosses(now time.Time) []UpcomingWorldBoss {
+	upcoming := make([]UpcomingWorldBoss, len(WorldBossSchedules))
+	for i, schedule := range WorldBossSchedules {
+		upcoming[i] = UpcomingWorldBoss{
+			ID:        schedule.ID,
+			Name:      schedule.Name,
+			NextSpawn: schedule.Next(now),
+		}
+	}
+
+	slices.SortStableFunc(upcoming, func(a, b UpcomingWorldBoss) int {
+		return a.NextSpawn.Compare(b.NextSpawn)
+	})
+	return upcoming
+}
@@ -0,0 +1,96 @@
+package gw2api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextSpawn(t *testing.T) {
+	at := func(s string) time.Time {
+		ts, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatalf("bad test time %q: %v", s, err)
+		}
+		return ts
+	}
+
+	tests := []struct {
+		name     string
+		bossID   string
+		now      string
+		expected string
+	}{
+		{
+			name:     "later the same day",
+			bossID:   "tequatl_the_sunless",
+			now:      "2024-03-10T10:00:00Z",
+			expected: "2024-03-10T11:30:00Z",
+		},
+		{
+			name:     "exactly on spawn counts as next",
+			bossID:   "tequatl_the_sunless",
+			now:      "2024-03-10T16:00:00Z",
+			expected: "2024-03-10T16:00:00Z",
+		},
+		{
+			name:     "wraps past UTC midnight",
+			bossID:   "tequatl_the_sunless",
+			now:      "2024-03-10T19:00:01Z",
+			expected: "2024-03-11T00:00:00Z",
+		},
+		{
+			name:     "wraps past month end",
+			bossID:   "karka_queen",
+			now:      "2024-03-31T23:30:00Z",
+			expected: "2024-04-01T02:00:00Z",
+		},
+		{
+			name:     "repeating boss last slot of the day",
+			bossID:   "shadow_behemoth",
+			now:      "2024-03-10T23:00:00Z",
+			expected: "2024-03-10T23:45:00Z",
+		},
+		{
+			name:     "repeating boss rolls to next day",
+			bossID:   "shadow_behemoth",
+			now:      "2024-03-10T23:46:00Z",
+			expected: "2024-03-11T01:45:00Z",
+		},
+		{
+			name:     "non-UTC input is normalised to UTC day",
+			bossID:   "admiral_taidha_covington",
+			now:      "2024-03-10T23:30:00-02:00", // 01:30 UTC on the 11th
+			expected: "2024-03-11T03:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NextSpawn(tt.bossID, at(tt.now))
+			if !got.Equal(at(tt.expected)) {
+				t.Errorf("NextSpawn(%q, %s) = %s, expected %s", tt.bossID, tt.now, got.Format(time.RFC3339), tt.expected)
+			}
+		})
+	}
+
+	if got := NextSpawn("not_a_boss", time.Now()); !got.IsZero() {
+		t.Errorf("NextSpawn for unknown boss = %s, expected zero time", got)
+	}
+}
+
+func TestUpcomingWorldBossesSorted(t *testing.T) {
+	now := time.Date(2024, 3, 10, 22, 50, 0, 0, time.UTC)
+	upcoming := UpcomingWorldBosses(now)
+
+	if len(upcoming) != len(WorldBossSchedules) {
+		t.Fatalf("got %d bosses, expected %d", len(upcoming), len(WorldBossSchedules))
+	}
+	for i := 1; i < len(upcoming); i++ {
+		if upcoming[i].NextSpawn.Before(upcoming[i-1].NextSpawn) {
+			t.Errorf("bosses not sorted: %s before %s", upcoming[i-1].ID, upcoming[i].ID)
+		}
+	}
+	if expected := time.Date(2024, 3, 10, 23, 0, 0, 0, time.UTC); !upcoming[0].NextSpawn.Equal(expected) {
+		t.Errorf("first upcoming spawn = %s, expected %s", upcoming[0].NextSpawn, expected)
+	}
+}
@@ -1,6 +1,10 @@
 package gw2api
 
-import "time"
+import (
+	"context"
+	"strconv"
+	"time"
+)
 
 // WvWAbility represents a WvW ability
 type WvWAbility struct {
@@ -51,11 +55,11 @@ type WvWMatchScores struct {
 	Green int `json:"green"`
 }
 
-// WvWMatchWorlds represents match worlds
+// WvWMatchWorlds is the main world or team on each side of a match
 type WvWMatchWorlds struct {
-	Red   []int `json:"red"`
-	Blue  []int `json:"blue"`
-	Green []int `json:"green"`
+	Red   int `json:"red"`
+	Blue  int `json:"blue"`
+	Green int `json:"green"`
 }
 
 // WvWMatchAllWorlds represents all worlds in a match
@@ -282,4 +286,146 @@ type WvWMapStats struct {
 	Type   string `json:"type"`
 	Kills  int    `json:"kills"`
 	Deaths int    `json:"deaths"`
-}
\ No newline at end of file
+}
+
+// GetWvWAbilityIDs returns all WvW ability IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/abilities
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWAbilityIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/wvw/abilities", options...)
+}
+
+// GetWvWAbility returns a specific WvW ability by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/abilities
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWAbility(ctx context.Context, id int, options ...RequestOption) (*WvWAbility, error) {
+	return GetByID[WvWAbility](ctx, c, "/v2/wvw/abilities", id, options...)
+}
+
+// GetWvWGuilds returns WvW guilds.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/guilds
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWGuilds(ctx context.Context, options ...RequestOption) ([]WvWGuild, error) {
+	return GetAll[WvWGuild](ctx, c, "/v2/wvw/guilds", options...)
+}
+
+// GetWvWMatches returns WvW matches.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/matches
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWMatches(ctx context.Context, options ...RequestOption) ([]WvWMatch, error) {
+	return GetAll[WvWMatch](ctx, c, "/v2/wvw/matches", options...)
+}
+
+// GetWvWMatchByWorld returns the current match of a world or, since the World
+// Restructuring, a team.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/matches
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWMatchByWorld(ctx context.Context, worldID int, options ...RequestOption) (*WvWMatch, error) {
+	options = append(options, WithParam("world", strconv.Itoa(worldID)))
+	return GetSingle[WvWMatch](ctx, c, "/v2/wvw/matches", options...)
+}
+
+// GetWvWMatchOverview returns WvW match overview.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/matches/overview
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWMatchOverview(ctx context.Context, options ...RequestOption) (*WvWMatchOverview, error) {
+	return GetSingle[WvWMatchOverview](ctx, c, "/v2/wvw/matches/overview", options...)
+}
+
+// GetWvWMatchScores returns WvW match scores.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/matches/scores
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWMatchScores(ctx context.Context, options ...RequestOption) (*WvWMatchScores, error) {
+	return GetSingle[WvWMatchScores](ctx, c, "/v2/wvw/matches/scores", options...)
+}
+
+// GetWvWMatchStats returns WvW match statistics.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/matches/stats
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWMatchStats(ctx context.Context, options ...RequestOption) (*WvWMatchStats, error) {
+	return GetSingle[WvWMatchStats](ctx, c, "/v2/wvw/matches/stats", options...)
+}
+
+// GetWvWMatchStatsTeams returns detailed WvW match statistics by team.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/matches/stats/teams
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWMatchStatsTeams(ctx context.Context, matchID string, options ...RequestOption) (*WvWMatchStatsTeams, error) {
+	return GetSingle[WvWMatchStatsTeams](ctx, c, "/v2/wvw/matches/"+matchID+"/stats/teams", options...)
+}
+
+// GetWvWObjectiveIDs returns all WvW objective IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/objectives
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWObjectiveIDs(ctx context.Context, options ...RequestOption) ([]string, error) {
+	return GetAll[string](ctx, c, "/v2/wvw/objectives", options...)
+}
+
+// GetWvWObjective returns a specific WvW objective by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/objectives
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWObjective(ctx context.Context, id string, options ...RequestOption) (*WvWObjective, error) {
+	return GetSingle[WvWObjective](ctx, c, "/v2/wvw/objectives/"+id, options...)
+}
+
+// GetWvWRankIDs returns all WvW rank IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/ranks
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWRankIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/wvw/ranks", options...)
+}
+
+// GetWvWRank returns a specific WvW rank by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/ranks
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWRank(ctx context.Context, id int, options ...RequestOption) (*WvWRank, error) {
+	return GetByID[WvWRank](ctx, c, "/v2/wvw/ranks", id, options...)
+}
+
+// GetAllWvWRanks returns every WvW rank title with the rank it starts at.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/ranks
+// Scopes: None (public endpoint)
+func (c *Client) GetAllWvWRanks(ctx context.Context, options ...RequestOption) ([]WvWRank, error) {
+	return GetAll[WvWRank](ctx, c, "/v2/wvw/ranks", options...)
+}
+
+// GetWvWRewardTrackIDs returns all WvW reward track IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/rewardtracks
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWRewardTrackIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/wvw/rewardtracks", options...)
+}
+
+// GetWvWRewardTrack returns a specific WvW reward track by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/rewardtracks
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWRewardTrack(ctx context.Context, id int, options ...RequestOption) (*WvWRewardTrack, error) {
+	return GetByID[WvWRewardTrack](ctx, c, "/v2/wvw/rewardtracks", id, options...)
+}
+
+// GetWvWTimers returns WvW timers.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/timers
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWTimers(ctx context.Context, options ...RequestOption) (*WvWTimer, error) {
+	return GetSingle[WvWTimer](ctx, c, "/v2/wvw/timers", options...)
+}
+
+// GetWvWUpgradeIDs returns all WvW upgrade IDs.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/upgrades
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWUpgradeIDs(ctx context.Context, options ...RequestOption) ([]int, error) {
+	return GetIDs[int](ctx, c, "/v2/wvw/upgrades", options...)
+}
+
+// GetWvWUpgrade returns a specific WvW upgrade by ID.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/upgrades
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWUpgrade(ctx context.Context, id int, options ...RequestOption) (*WvWUpgrade, error) {
+	return GetByID[WvWUpgrade](ctx, c, "/v2/wvw/upgrades", id, options...)
+}
+
+// GetAllWvWObjectives returns every WvW objective.
+// Wiki: https://wiki.guildwars2.com/wiki/API:2/wvw/objectives
+// Scopes: None (public endpoint)
+func (c *Client) GetAllWvWObjectives(ctx context.Context, options ...RequestOption) ([]WvWObjective, error) {
+	return GetAll[WvWObjective](ctx, c, "/v2/wvw/objectives", options...)
+}
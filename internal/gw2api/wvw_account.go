@@ -0,0 +1,149 @@
+package gw2api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// UnmarshalJSON also accepts the "team" and "guild" names some responses use
+// for the team and guild IDs
+func (w *WvWInfo) UnmarshalJSON(data []byte) error {
+	type plain WvWInfo
+	var decoded struct {
+		plain
+		Team  int    `json:"team"`
+		Guild string `json:"guild"`
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*w = WvWInfo(decoded.plain)
+	if w.TeamID == 0 {
+		w.TeamID = decoded.Team
+	}
+	if w.GuildID == "" {
+		w.GuildID = decoded.Guild
+	}
+	return nil
+}
+
+// WvWRankTitle returns the title held at rank: the one with the highest
+// MinRank at or below it. Ranks below every title have none.
+func WvWRankTitle(ranks []WvWRank, rank int) (*WvWRank, bool) {
+	var best *WvWRank
+	for i := range ranks {
+		if ranks[i].MinRank <= rank && (best == nil || ranks[i].MinRank > best.MinRank) {
+			best = &ranks[i]
+		}
+	}
+	return best, best != nil
+}
+
+// WvWTeamRegion returns "NA" or "EU" for a team or world ID, or "" when the
+// ID isn't in either range. Teams have five-digit IDs, such as 11001 for
+// North America and 12001 for Europe, and worlds four-digit ones.
+func WvWTeamRegion(id int) string {
+	for id >= 10000 {
+		id %= 10000
+	}
+	switch id / 1000 {
+	case 1:
+		return "NA"
+	case 2:
+		return "EU"
+	}
+	return ""
+}
+
+// GetWvWTeamName names a WvW team or world. Team names are listed with the
+// worlds; a team that isn't is named by its region and ID.
+// Scopes: None (public endpoint)
+func (c *Client) GetWvWTeamName(ctx context.Context, teamID int) (string, error) {
+	world, err := c.GetWorld(ctx, teamID)
+	if err == nil && world.Name != "" {
+		return world.Name, nil
+	}
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return "", err
+	}
+	if region := WvWTeamRegion(teamID); region != "" {
+		return fmt.Sprintf("%s team %d", region, teamID), nil
+	}
+	return fmt.Sprintf("Team %d", teamID), nil
+}
+
+// WvWStatus is the account's WvW rank and team with its current match
+type WvWStatus struct {
+	Rank      int    `json:"rank"`
+	RankTitle string `json:"rank_title,omitempty"`
+
+	TeamID   int    `json:"team_id"`
+	TeamName string `json:"team_name,omitempty"`
+	Region   string `json:"region,omitempty"`
+
+	GuildID   string `json:"guild_id,omitempty"`
+	GuildName string `json:"guild_name,omitempty"`
+	GuildTag  string `json:"guild_tag,omitempty"`
+
+	Match *WvWMatch `json:"match,omitempty"`
+	Color string    `json:"color,omitempty"` // The team's side in the match: red, blue or green
+}
+
+// GetAccountWvWStatus returns the account's WvW rank with its title, its team
+// and guild by name, and the match the team is playing. Names and the match
+// are left out when they can't be found, such as before the account has a
+// team.
+// Scopes: account
+func (c *Client) GetAccountWvWStatus(ctx context.Context) (*WvWStatus, error) {
+	info, err := c.GetAccountWvW(ctx)
+	if err != nil {
+		return nil, err
+	}
+	status := &WvWStatus{Rank: info.Rank, TeamID: info.TeamID, GuildID: info.GuildID, Region: WvWTeamRegion(info.TeamID)}
+
+	ranks, err := c.GetAllWvWRanks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WvW ranks: %w", err)
+	}
+	if title, found := WvWRankTitle(ranks, info.Rank); found {
+		status.RankTitle = title.Title
+	}
+
+	if info.GuildID != "" {
+		if guild, err := c.GetGuild(ctx, info.GuildID); err == nil {
+			status.GuildName, status.GuildTag = guild.Name, guild.Tag
+		}
+	}
+
+	if info.TeamID == 0 {
+		return status, nil
+	}
+	if status.TeamName, err = c.GetWvWTeamName(ctx, info.TeamID); err != nil {
+		return nil, fmt.Errorf("failed to name WvW team: %w", err)
+	}
+	match, err := c.GetWvWMatchByWorld(ctx, info.TeamID)
+	if err != nil && !errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("failed to get WvW match: %w", err)
+	}
+	if match != nil {
+		status.Match = match
+		status.Color = match.AllWorlds.color(info.TeamID)
+	}
+	return status, nil
+}
+
+// color returns the side a world or team plays on, or "" if it isn't in the match
+func (w WvWMatchAllWorlds) color(id int) string {
+	for _, side := range []struct {
+		color string
+		ids   []int
+	}{{"red", w.Red}, {"blue", w.Blue}, {"green", w.Green}} {
+		if slices.Contains(side.ids, id) {
+			return side.color
+		}
+	}
+	return ""
+}
@@ -0,0 +1,81 @@
+package gw2api
+
+import (
+	"context"
+	"testing"
+)
+
+func TestWvWRankTitle(t *testing.T) {
+	var ranks []WvWRank
+	decodeStrict(t, "wvw/ranks.json", &ranks)
+
+	tests := []struct {
+		rank     int
+		expected string
+	}{
+		{0, ""},
+		{1, "Invader"},
+		{4, "Invader"},
+		{5, "Assaulter"},
+		{9, "Assaulter"},
+		{10, "Raider"},
+		{149, "Raider"},
+		{150, "Bronze Invader"},
+		{159, "Bronze Invader"},
+		{160, "Bronze Assaulter"},
+		{10000, "Bronze Assaulter"},
+	}
+	for _, test := range tests {
+		title, found := WvWRankTitle(ranks, test.rank)
+		got := ""
+		if found {
+			got = title.Title
+		}
+		if got != test.expected || found != (test.expected != "") {
+			t.Errorf("WvWRankTitle(%d) = %q, %v, expected %q", test.rank, got, found, test.expected)
+		}
+	}
+}
+
+func TestWvWTeamRegion(t *testing.T) {
+	for id, expected := range map[int]string{11005: "NA", 12001: "EU", 1008: "NA", 2104: "EU", 0: "", 3000: ""} {
+		if got := WvWTeamRegion(id); got != expected {
+			t.Errorf("WvWTeamRegion(%d) = %q, expected %q", id, got, expected)
+		}
+	}
+}
+
+func TestGetAccountWvWStatus(t *testing.T) {
+	client := newFixtureClient(t, "wvw", map[string]string{
+		"/v2/account/wvw": "account_wvw.json",
+		"/v2/wvw/ranks":   "ranks.json",
+		"/v2/worlds":      "worlds.json",
+		"/v2/guild/4BBB52AA-D768-4FC6-8EDE-C299F2822F0F": "guild.json",
+		"/v2/wvw/matches": "match.json",
+	})
+
+	status, err := client.GetAccountWvWStatus(context.Background())
+	if err != nil {
+		t.Fatalf("GetAccountWvWStatus: %v", err)
+	}
+	if status.Rank != 152 || status.RankTitle != "Bronze Invader" {
+		t.Errorf("rank = %d %q, expected 152 Bronze Invader", status.Rank, status.RankTitle)
+	}
+	if status.TeamID != 11005 || status.TeamName != "Moogooloo" || status.Region != "NA" {
+		t.Errorf("team = %d %q %q, expected 11005 Moogooloo in NA", status.TeamID, status.TeamName, status.Region)
+	}
+	if status.GuildName != "Lords of the Mists" || status.GuildTag != "MIST" {
+		t.Errorf("guild = %q [%s], expected Lords of the Mists [MIST]", status.GuildName, status.GuildTag)
+	}
+	if status.Match == nil || status.Match.ID != "1-2" || status.Color != "blue" || status.Match.Worlds.Blue != 11005 {
+		t.Errorf("match = %+v on %q, expected 1-2 on blue", status.Match, status.Color)
+	}
+}
+
+func TestGetWvWTeamNameUnlisted(t *testing.T) {
+	client := newFixtureClient(t, "wvw", nil)
+	name, err := client.GetWvWTeamName(context.Background(), 12003)
+	if err != nil || name != "EU team 12003" {
+		t.Errorf("GetWvWTeamName = %q, %v, expected EU team 12003", name, err)
+	}
+}
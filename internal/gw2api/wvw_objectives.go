@@ -0,0 +1,145 @@
+package gw2api
+
+import "time"
+
+// wvwYakTiers are the yaks an objective needs delivered since its capture to
+// reach tiers 1, 2 and 3: 20, then 40 more, then 80 more. The API doesn't
+// list them, and they haven't changed since yaks started upgrading objectives.
+var wvwYakTiers = []int{20, 60, 140}
+
+// upgradedByYaks reports whether yaks upgrade objectives of a type. Camps,
+// ruins and spawns don't take deliveries.
+func upgradedByYaks(objectiveType string) bool {
+	switch objectiveType {
+	case "Tower", "Keep", "Castle":
+		return true
+	}
+	return false
+}
+
+// Tier returns the upgrade tier, 0 to 3, the objective has reached from the
+// yaks delivered since it was captured. Objectives yaks don't upgrade are
+// always tier 0.
+func (o WvWMatchObjective) Tier() int {
+	if !upgradedByYaks(o.Type) {
+		return 0
+	}
+	tier := 0
+	for _, yaks := range wvwYakTiers {
+		if o.YaksDelivered >= yaks {
+			tier++
+		}
+	}
+	return tier
+}
+
+// ClaimedFor returns how long the guild in ClaimedBy has held its claim at
+// now, or 0 when the objective is unclaimed
+func (o WvWMatchObjective) ClaimedFor(now time.Time) time.Duration {
+	if o.ClaimedBy == "" || o.ClaimedAt == nil || now.Before(*o.ClaimedAt) {
+		return 0
+	}
+	return now.Sub(*o.ClaimedAt)
+}
+
+// UpgradeETA estimates how long until an objective reaches its next tier, if
+// yaks keep arriving at the rate they have since it was captured. It reports
+// false for objectives already at tier 3, ones yaks don't upgrade, and ones
+// no yak has reached yet, which give no rate to go on.
+func UpgradeETA(objective WvWMatchObjective, now time.Time) (time.Duration, bool) {
+	tier := objective.Tier()
+	if !upgradedByYaks(objective.Type) || tier == len(wvwYakTiers) || objective.YaksDelivered == 0 {
+		return 0, false
+	}
+	held := now.Sub(objective.LastFlipped)
+	if held <= 0 {
+		return 0, false
+	}
+	remaining := wvwYakTiers[tier] - objective.YaksDelivered
+	return held * time.Duration(remaining) / time.Duration(objective.YaksDelivered), true
+}
+
+// WvWTeamObjectives totals the objectives one side of a match holds
+type WvWTeamObjectives struct {
+	Objectives    int `json:"objectives"`
+	PointsPerTick int `json:"points_per_tick"` // War score gained every five minute tick
+	Tier3         int `json:"tier3"`           // Towers, keeps and castles at tier 3
+	Claimed       int `json:"claimed"`         // Objectives a guild has claimed
+}
+
+// WvWObjectiveSummary totals the objectives of each side of a match.
+// Neutral objectives count for no one.
+type WvWObjectiveSummary struct {
+	Red   WvWTeamObjectives `json:"red"`
+	Blue  WvWTeamObjectives `json:"blue"`
+	Green WvWTeamObjectives `json:"green"`
+}
+
+// ObjectiveSummary totals the objectives each side holds across the maps of
+// the match. Each side's PointsPerTick is the war score it gains per tick
+// while nothing changes hands.
+func (m *WvWMatch) ObjectiveSummary() *WvWObjectiveSummary {
+	summary := &WvWObjectiveSummary{}
+	for _, matchMap := range m.Maps {
+		for _, objective := range matchMap.Objectives {
+			team := summary.team(objective.Owner)
+			if team == nil {
+				continue
+			}
+			team.Objectives++
+			team.PointsPerTick += objective.PointsTick
+			if objective.Tier() == 3 {
+				team.Tier3++
+			}
+			if objective.ClaimedBy != "" {
+				team.Claimed++
+			}
+		}
+	}
+	return summary
+}
+
+// team returns the totals of the side an objective owner names, or nil for
+// neutral objectives
+func (s *WvWObjectiveSummary) team(owner string) *WvWTeamObjectives {
+	switch owner {
+	case "Red":
+		return &s.Red
+	case "Blue":
+		return &s.Blue
+	case "Green":
+		return &s.Green
+	}
+	return nil
+}
+
+// WvWObjectiveProgress is an objective of a match with its upgrade progress
+// at a point in time
+type WvWObjectiveProgress struct {
+	WvWMatchObjective
+	Map               string `json:"map"` // Type of the map, such as "Center"
+	Tier              int    `json:"tier"`
+	ClaimedForSeconds int    `json:"claimed_for_seconds,omitempty"`
+	NextTierSeconds   int    `json:"next_tier_seconds,omitempty"` // From UpgradeETA, when it has an estimate
+}
+
+// ObjectiveProgress returns the progress of every objective of the match at
+// now, map by map
+func (m *WvWMatch) ObjectiveProgress(now time.Time) []WvWObjectiveProgress {
+	var progress []WvWObjectiveProgress
+	for _, matchMap := range m.Maps {
+		for _, objective := range matchMap.Objectives {
+			entry := WvWObjectiveProgress{
+				WvWMatchObjective: objective,
+				Map:               matchMap.Type,
+				Tier:              objective.Tier(),
+				ClaimedForSeconds: int(objective.ClaimedFor(now) / time.Second),
+			}
+			if eta, found := UpgradeETA(objective, now); found {
+				entry.NextTierSeconds = int(eta / time.Second)
+			}
+			progress = append(progress, entry)
+		}
+	}
+	return progress
+}
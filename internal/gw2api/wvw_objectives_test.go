@@ -0,0 +1,82 @@
+package gw2api
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWvWObjectiveSummary(t *testing.T) {
+	var match WvWMatch
+	decodeStrict(t, "wvw/match_objectives.json", &match)
+	summary := match.ObjectiveSummary()
+
+	// The fixture was captured an hour into its last skirmish, twelve ticks in,
+	// and nothing changed hands during it
+	const ticks = 12
+	skirmish := match.Skirmishes[len(match.Skirmishes)-1]
+	for _, side := range []struct {
+		name  string
+		team  WvWTeamObjectives
+		score int
+	}{
+		{"red", summary.Red, skirmish.Scores.Red},
+		{"blue", summary.Blue, skirmish.Scores.Blue},
+		{"green", summary.Green, skirmish.Scores.Green},
+	} {
+		if side.team.PointsPerTick*ticks != side.score {
+			t.Errorf("%s gains %d per tick, but scored %d in %d ticks", side.name, side.team.PointsPerTick, side.score, ticks)
+		}
+	}
+
+	expected := WvWObjectiveSummary{
+		Red:   WvWTeamObjectives{Objectives: 6, PointsPerTick: 36, Tier3: 2, Claimed: 1},
+		Blue:  WvWTeamObjectives{Objectives: 5, PointsPerTick: 24, Tier3: 1, Claimed: 1},
+		Green: WvWTeamObjectives{Objectives: 4, PointsPerTick: 18, Tier3: 1},
+	}
+	if *summary != expected {
+		t.Errorf("summary = %+v, expected %+v", *summary, expected)
+	}
+}
+
+func TestWvWObjectiveUpgrades(t *testing.T) {
+	var match WvWMatch
+	decodeStrict(t, "wvw/match_objectives.json", &match)
+	objectives := make(map[string]WvWMatchObjective)
+	for _, matchMap := range match.Maps {
+		for _, objective := range matchMap.Objectives {
+			objectives[objective.ID] = objective
+		}
+	}
+	now := time.Date(2025, 6, 6, 11, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		id   string
+		tier int
+		eta  time.Duration // 0 for no estimate
+	}{
+		{"38-9", 3, 0},                             // Castle at tier 3
+		{"38-6", 2, 6 * time.Hour},                 // 70 yaks in 6 hours, 70 more to go
+		{"38-3", 0, 80 * time.Minute},              // 10 yaks in 80 minutes, 10 more to go
+		{"95-40", 1, 9*time.Hour + 20*time.Minute}, // 25 yaks in 400 minutes, 35 more to go
+		{"38-15", 0, 0},                            // Camps aren't upgraded by yaks
+		{"1099-100", 0, 0},                         // No yaks yet
+	}
+	for _, test := range tests {
+		objective := objectives[test.id]
+		if tier := objective.Tier(); tier != test.tier {
+			t.Errorf("%s is tier %d, expected %d", test.id, tier, test.tier)
+		}
+		eta, found := UpgradeETA(objective, now)
+		if found != (test.eta != 0) || eta != test.eta {
+			t.Errorf("UpgradeETA(%s) = %v, %v, expected %v", test.id, eta, found, test.eta)
+		}
+	}
+
+	castle := objectives["38-9"]
+	if claimed := castle.ClaimedFor(now); claimed != 4*time.Hour+55*time.Minute {
+		t.Errorf("castle claimed for %v, expected 4h55m", claimed)
+	}
+	if claimed := objectives["38-6"].ClaimedFor(now); claimed != 0 {
+		t.Errorf("unclaimed keep claimed for %v", claimed)
+	}
+}
@@ -0,0 +1,307 @@
+// Package proxy serves a read-only subset of the Guild Wars 2 API through a
+// gw2api.Client, so tools that should not hold an API key, such as stream
+// overlays and spreadsheets, can read account data with the server's key.
+package proxy
+
+import (
+	"errors"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// Prefix is the path the proxy is served under. /proxy/v2/items forwards to
+// /v2/items.
+const Prefix = "/proxy/v2/"
+
+// DefaultAllowed is the endpoint whitelist used when none is configured
+var DefaultAllowed = []string{
+	"account",
+	"achievements",
+	"build",
+	"characters",
+	"colors",
+	"commerce/listings",
+	"commerce/prices",
+	"currencies",
+	"items",
+	"itemstats",
+	"minis",
+	"outfits",
+	"pvp/stats",
+	"recipes",
+	"skills",
+	"skins",
+	"worlds",
+}
+
+// Endpoints that are never proxied, whatever the whitelist says, because they
+// describe or mint API keys
+var deniedEndpoints = []string{"tokeninfo", "createsubtoken"}
+
+// Endpoints whose responses depend on the API key. Their responses are cached
+// for AccountTTL rather than StaticTTL.
+var accountEndpoints = []string{
+	"account",
+	"characters",
+	"commerce/delivery",
+	"commerce/transactions",
+	"pvp/games",
+	"pvp/standings",
+	"pvp/stats",
+}
+
+// Query parameters passed on to the API. Anything else is dropped.
+var forwardedParams = []string{"id", "ids", "lang", "page", "page_size", "v"}
+
+// Config configures a Handler
+type Config struct {
+	// Allowed lists the endpoints that may be proxied, relative to /v2. An
+	// entry also allows the paths below it, so "characters" allows
+	// "characters/Name/inventory".
+	Allowed []string
+
+	// RequestsPerSecond and Burst limit each client IP. Zero disables the limit.
+	RequestsPerSecond float64
+	Burst             int
+
+	// StaticTTL and AccountTTL are how long game data and account data
+	// responses are cached. Zero disables caching for that class.
+	StaticTTL  time.Duration
+	AccountTTL time.Duration
+
+	// AllowOrigin is sent as Access-Control-Allow-Origin, "*" when empty
+	AllowOrigin string
+}
+
+// Handler forwards whitelisted GET requests to the API
+type Handler struct {
+	client   *gw2api.Client
+	cache    cache.Cache
+	config   Config
+	limiters *ipLimiters
+}
+
+// cachedResponse is a response body kept in the cache, with its paging headers
+type cachedResponse struct {
+	body       []byte
+	pagination *gw2api.PaginationResponse
+}
+
+// New returns a handler that forwards through client, caching responses in
+// responseCache
+func New(client *gw2api.Client, responseCache cache.Cache, config Config) *Handler {
+	if config.Allowed == nil {
+		config.Allowed = DefaultAllowed
+	}
+	if config.AllowOrigin == "" {
+		config.AllowOrigin = "*"
+	}
+	h := &Handler{client: client, cache: responseCache, config: config}
+	if config.RequestsPerSecond > 0 {
+		h.limiters = newIPLimiters(rate.Limit(config.RequestsPerSecond), max(config.Burst, 1))
+	}
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Access-Control-Allow-Origin", h.config.AllowOrigin)
+	w.Header().Set("Access-Control-Expose-Headers", "X-Page, X-Page-Size, X-Page-Total, X-Result-Total, X-Cache")
+
+	switch r.Method {
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", "GET, OPTIONS")
+		w.Header().Set("Access-Control-Max-Age", "86400")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	case http.MethodGet:
+	default:
+		w.Header().Set("Allow", "GET, OPTIONS")
+		writeError(w, http.StatusMethodNotAllowed, "the proxy is read-only")
+		return
+	}
+
+	endpoint, ok := strings.CutPrefix(r.URL.Path, Prefix)
+	endpoint = strings.Trim(endpoint, "/")
+	if !ok || endpoint == "" {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	// The whitelist matches path prefixes, so a path that climbs out of a
+	// listed endpoint, or hides a slash from the check, must not get through
+	if path.Clean(endpoint) != endpoint || strings.Contains(strings.ToLower(r.URL.EscapedPath()), "%2f") {
+		writeError(w, http.StatusBadRequest, "invalid endpoint path")
+		return
+	}
+	if !h.allowed(endpoint) {
+		writeError(w, http.StatusForbidden, "endpoint /v2/"+endpoint+" is not available through this proxy")
+		return
+	}
+
+	// Requests always use the server's key. Rejecting a client's key rather
+	// than ignoring it makes clear it was never used.
+	if r.Header.Get("Authorization") != "" || r.URL.Query().Has("access_token") {
+		writeError(w, http.StatusBadRequest, "this proxy does not accept access tokens")
+		return
+	}
+
+	if h.limiters != nil && !h.limiters.allow(clientIP(r)) {
+		w.Header().Set("Retry-After", "1")
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+
+	params := forwardedQuery(r.URL.Query())
+	ttl := h.config.StaticTTL
+	if isAccountEndpoint(endpoint) {
+		ttl = h.config.AccountTTL
+	}
+
+	key := endpoint + "?" + params.Encode()
+	if ttl > 0 {
+		if cached, found := h.cache.Get(key); found {
+			writeResponse(w, cached.(cachedResponse), ttl, "HIT")
+			return
+		}
+	}
+
+	var options []gw2api.RequestOption
+	for name := range params {
+		options = append(options, gw2api.WithParam(name, params.Get(name)))
+	}
+	body, pagination, err := h.client.GetRaw(r.Context(), "/v2/"+endpoint, options...)
+	if err != nil {
+		var httpErr gw2api.HTTPError
+		if errors.As(err, &httpErr) {
+			writeError(w, httpErr.StatusCode, httpErr.Message)
+			return
+		}
+		log.Printf("Proxy request for /v2/%s failed: %v", endpoint, err)
+		writeError(w, http.StatusBadGateway, "the Guild Wars 2 API request failed")
+		return
+	}
+
+	response := cachedResponse{body: body, pagination: pagination}
+	if ttl > 0 {
+		h.cache.Set(key, response, ttl)
+	}
+	writeResponse(w, response, ttl, "MISS")
+}
+
+// allowed reports whether the whitelist covers endpoint
+func (h *Handler) allowed(endpoint string) bool {
+	if matchesAny(endpoint, deniedEndpoints) {
+		return false
+	}
+	return matchesAny(endpoint, h.config.Allowed)
+}
+
+func isAccountEndpoint(endpoint string) bool {
+	return matchesAny(endpoint, accountEndpoints)
+}
+
+// matchesAny reports whether endpoint is one of the entries or below one
+func matchesAny(endpoint string, entries []string) bool {
+	return slices.ContainsFunc(entries, func(entry string) bool {
+		entry = strings.Trim(entry, "/")
+		return endpoint == entry || strings.HasPrefix(endpoint, entry+"/")
+	})
+}
+
+// forwardedQuery keeps the query parameters the API needs, dropping the rest
+func forwardedQuery(query url.Values) url.Values {
+	params := make(url.Values)
+	for _, name := range forwardedParams {
+		if query.Has(name) {
+			params.Set(name, query.Get(name))
+		}
+	}
+	return params
+}
+
+func writeResponse(w http.ResponseWriter, response cachedResponse, ttl time.Duration, cacheStatus string) {
+	if p := response.pagination; p != nil {
+		w.Header().Set("X-Page", strconv.Itoa(p.Page))
+		w.Header().Set("X-Page-Size", strconv.Itoa(p.PageSize))
+		w.Header().Set("X-Page-Total", strconv.Itoa(p.PageTotal))
+		w.Header().Set("X-Result-Total", strconv.Itoa(p.Total))
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "max-age="+strconv.Itoa(int(ttl.Seconds())))
+	w.Header().Set("X-Cache", cacheStatus)
+	w.Write(response.body)
+}
+
+// writeError writes an error in the API's own {"text": ...} format
+func writeError(w http.ResponseWriter, status int, text string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	w.Write([]byte(`{"text":` + strconv.Quote(text) + "}\n"))
+}
+
+// clientIP is the host part of the connection's remote address. Forwarding
+// headers are not trusted, since any client can set them.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ipLimiters keeps a rate limiter per client IP, forgetting IPs that have been
+// idle for a while
+type ipLimiters struct {
+	mutex     sync.Mutex
+	limit     rate.Limit
+	burst     int
+	limiters  map[string]*ipLimiter
+	lastPrune time.Time
+}
+
+type ipLimiter struct {
+	limiter  *rate.Limiter
+	lastSeen time.Time
+}
+
+// ipIdleTimeout is how long an IP's limiter is kept after its last request
+const ipIdleTimeout = 10 * time.Minute
+
+func newIPLimiters(limit rate.Limit, burst int) *ipLimiters {
+	return &ipLimiters{limit: limit, burst: burst, limiters: make(map[string]*ipLimiter), lastPrune: time.Now()}
+}
+
+// allow reports whether ip may make another request now
+func (l *ipLimiters) allow(ip string) bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	now := time.Now()
+	if now.Sub(l.lastPrune) > time.Minute {
+		for key, entry := range l.limiters {
+			if now.Sub(entry.lastSeen) > ipIdleTimeout {
+				delete(l.limiters, key)
+			}
+		}
+		l.lastPrune = now
+	}
+
+	entry, found := l.limiters[ip]
+	if !found {
+		entry = &ipLimiter{limiter: rate.NewLimiter(l.limit, l.burst)}
+		l.limiters[ip] = entry
+	}
+	entry.lastSeen = now
+	return entry.limiter.AllowN(now, 1)
+}
@@ -0,0 +1,212 @@
+package proxy
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+const testKey = "SERVER-KEY-1234"
+
+// upstream is a fake API that echoes the key back in account responses and
+// records the requests it receives
+type upstream struct {
+	mutex    sync.Mutex
+	requests []*http.Request
+}
+
+func (u *upstream) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u.mutex.Lock()
+	u.requests = append(u.requests, r)
+	u.mutex.Unlock()
+
+	switch r.URL.Path {
+	case "/v2/items":
+		w.Header().Set("X-Page", "0")
+		w.Header().Set("X-Page-Size", "2")
+		w.Header().Set("X-Page-Total", "5")
+		w.Header().Set("X-Result-Total", "10")
+		w.Write([]byte(`[{"id":1},{"id":2}]`))
+	case "/v2/account":
+		w.Write([]byte(`{"name":"Tester.1234","key":"` + strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ") + `"}`))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"text":"no such endpoint"}`))
+	}
+}
+
+func (u *upstream) count() int {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	return len(u.requests)
+}
+
+func (u *upstream) last() *http.Request {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	return u.requests[len(u.requests)-1]
+}
+
+func newTestProxy(t *testing.T, config Config) (*Handler, *upstream) {
+	t.Helper()
+	api := &upstream{}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithAPIKey(testKey), gw2api.WithRetries(0))
+	return New(client, cache.NewLRUCache(100), config), api
+}
+
+func get(h http.Handler, target string, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, target, nil)
+	for name, values := range header {
+		r.Header[name] = values
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestProxyForwardsAndCaches(t *testing.T) {
+	h, api := newTestProxy(t, Config{StaticTTL: time.Hour})
+
+	w := get(h, "/proxy/v2/items?ids=1,2&lang=de&page=0&callback=x", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+	if got := w.Body.String(); got != `[{"id":1},{"id":2}]` {
+		t.Errorf("body = %s", got)
+	}
+	if got := w.Header().Get("X-Page-Total"); got != "5" {
+		t.Errorf("X-Page-Total = %q, expected 5", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Access-Control-Allow-Origin = %q, expected *", got)
+	}
+	if got := w.Header().Get("X-Cache"); got != "MISS" {
+		t.Errorf("X-Cache = %q, expected MISS", got)
+	}
+
+	query := api.last().URL.Query()
+	if query.Get("ids") != "1,2" || query.Get("lang") != "de" || query.Has("callback") {
+		t.Errorf("forwarded query = %s, expected ids and lang without callback", api.last().URL.RawQuery)
+	}
+	if got := api.last().Header.Get("Authorization"); got != "Bearer "+testKey {
+		t.Errorf("Authorization = %q, expected the server's key", got)
+	}
+
+	w = get(h, "/proxy/v2/items?ids=1,2&lang=de&page=0", nil)
+	if got := w.Header().Get("X-Cache"); got != "HIT" || api.count() != 1 {
+		t.Errorf("X-Cache = %q after %d upstream requests, expected a cache hit", got, api.count())
+	}
+}
+
+func TestProxyRedactsKey(t *testing.T) {
+	h, api := newTestProxy(t, Config{})
+
+	w := get(h, "/proxy/v2/account", nil)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", w.Code, w.Body)
+	}
+	if strings.Contains(w.Body.String(), testKey) {
+		t.Errorf("response echoes the API key: %s", w.Body)
+	}
+
+	// Account endpoints have no TTL here, so nothing is cached
+	get(h, "/proxy/v2/account", nil)
+	if api.count() != 2 {
+		t.Errorf("%d upstream requests, expected 2 with caching disabled", api.count())
+	}
+}
+
+func TestProxyRejects(t *testing.T) {
+	h, api := newTestProxy(t, Config{Allowed: []string{"items", "account", "tokeninfo"}})
+
+	tests := []struct {
+		name   string
+		method string
+		target string
+		header http.Header
+		status int
+	}{
+		{"endpoint not whitelisted", http.MethodGet, "/proxy/v2/recipes", nil, http.StatusForbidden},
+		{"token endpoints", http.MethodGet, "/proxy/v2/tokeninfo", nil, http.StatusForbidden},
+		{"prefix without separator", http.MethodGet, "/proxy/v2/itemstats", nil, http.StatusForbidden},
+		{"client token in query", http.MethodGet, "/proxy/v2/account?access_token=mine", nil, http.StatusBadRequest},
+		{"client token in header", http.MethodGet, "/proxy/v2/account", http.Header{"Authorization": {"Bearer mine"}}, http.StatusBadRequest},
+		{"writes", http.MethodPost, "/proxy/v2/items", nil, http.StatusMethodNotAllowed},
+		{"no endpoint", http.MethodGet, "/proxy/v2/", nil, http.StatusNotFound},
+		{"dot segments", http.MethodGet, "/proxy/v2/items/../tokeninfo", nil, http.StatusBadRequest},
+		{"encoded dot segments", http.MethodGet, "/proxy/v2/items/%2E%2E/tokeninfo", nil, http.StatusBadRequest},
+		{"encoded slashes", http.MethodGet, "/proxy/v2/items%2F..%2Ftokeninfo", nil, http.StatusBadRequest},
+		{"encoded slash in an ID", http.MethodGet, "/proxy/v2/items%2f1", nil, http.StatusBadRequest},
+		{"empty segment", http.MethodGet, "/proxy/v2/items//1", nil, http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(tt.method, tt.target, nil)
+			for name, values := range tt.header {
+				r.Header[name] = values
+			}
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Errorf("status = %d, expected %d (body %s)", w.Code, tt.status, w.Body)
+			}
+		})
+	}
+	if api.count() != 0 {
+		t.Errorf("%d requests reached the API, expected none", api.count())
+	}
+}
+
+func TestProxyUpstreamErrors(t *testing.T) {
+	h, _ := newTestProxy(t, Config{})
+
+	w := get(h, "/proxy/v2/items/missing", nil)
+	if w.Code != http.StatusNotFound || !strings.Contains(w.Body.String(), "no such endpoint") {
+		t.Errorf("status = %d, body %s, expected the API's 404", w.Code, w.Body)
+	}
+}
+
+func TestProxyPreflight(t *testing.T) {
+	h, _ := newTestProxy(t, Config{AllowOrigin: "https://overlay.example"})
+
+	r := httptest.NewRequest(http.MethodOptions, "/proxy/v2/items", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusNoContent {
+		t.Errorf("status = %d, expected 204", w.Code)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "https://overlay.example" {
+		t.Errorf("Access-Control-Allow-Origin = %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Methods"); !strings.Contains(got, "GET") {
+		t.Errorf("Access-Control-Allow-Methods = %q", got)
+	}
+}
+
+func TestProxyRateLimit(t *testing.T) {
+	h, _ := newTestProxy(t, Config{RequestsPerSecond: 0.001, Burst: 2, StaticTTL: time.Hour})
+
+	for i, expected := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		if w := get(h, "/proxy/v2/items", nil); w.Code != expected {
+			t.Errorf("request %d: status = %d, expected %d", i+1, w.Code, expected)
+		}
+	}
+
+	// Other clients have their own allowance
+	r := httptest.NewRequest(http.MethodGet, "/proxy/v2/items", nil)
+	r.RemoteAddr = "192.0.2.99:1234"
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK {
+		t.Errorf("other client: status = %d, expected 200", w.Code)
+	}
+}
@@ -0,0 +1,232 @@
+// Package salvage estimates what equipment salvages into and what that is worth
+// on the trading post, from a static table of community-documented rates.
+package salvage
+
+import (
+	"bytes"
+	"context"
+	_ "embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"sync"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+//go:embed rates.json
+var defaultRates []byte
+
+// Table is a set of salvage rates
+type Table struct {
+	Source string `json:"source"` // Where the rates come from
+	Kit    Kit    `json:"kit"`
+	Rules  []Rule `json:"rules"` // Matched in order, the first match wins
+}
+
+// Kit is the salvage kit the rates assume
+type Kit struct {
+	Name       string `json:"name"`
+	CostPerUse int    `json:"cost_per_use"` // Coins each salvage uses up
+}
+
+// Rule gives the expected outputs of salvaging equipment of some rarities,
+// levels and types
+type Rule struct {
+	Rarities    []string `json:"rarities"`
+	MinLevel    int      `json:"min_level"`
+	MaxLevel    int      `json:"max_level"`
+	Tier        int      `json:"tier"`                   // Crafting material tier of the outputs
+	Types       []string `json:"types"`                  // Item types, such as Armor or Weapon
+	WeightClass string   `json:"weight_class,omitempty"` // Only armor of this weight, if set
+	Outputs     []Output `json:"outputs"`
+}
+
+// Output is a material a salvage yields
+type Output struct {
+	ItemID     int     `json:"item_id"`
+	Name       string  `json:"name"`
+	PerSalvage float64 `json:"per_salvage"` // Average count per salvage
+}
+
+// Matches reports whether the rule covers the item
+func (r *Rule) Matches(item *gw2api.Item) bool {
+	if !slices.Contains(r.Rarities, item.Rarity) || !slices.Contains(r.Types, item.Type) {
+		return false
+	}
+	if item.Level < r.MinLevel || item.Level > r.MaxLevel {
+		return false
+	}
+	if r.WeightClass != "" && (item.Details == nil || item.Details.WeightClass != r.WeightClass) {
+		return false
+	}
+	return true
+}
+
+// ParseTable reads a salvage rates table as JSON
+func ParseTable(r io.Reader) (*Table, error) {
+	var table Table
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&table); err != nil {
+		return nil, fmt.Errorf("invalid salvage rates: %w", err)
+	}
+	for i, rule := range table.Rules {
+		if len(rule.Rarities) == 0 || len(rule.Types) == 0 || rule.MinLevel > rule.MaxLevel || len(rule.Outputs) == 0 {
+			return nil, fmt.Errorf("salvage rule %d: needs rarities, types, outputs and a level range", i)
+		}
+		for _, output := range rule.Outputs {
+			if output.ItemID <= 0 || output.PerSalvage <= 0 {
+				return nil, fmt.Errorf("salvage rule %d: invalid output %+v", i, output)
+			}
+		}
+	}
+	return &table, nil
+}
+
+// DefaultTable returns the rates embedded from rates.json
+var DefaultTable = sync.OnceValue(func() *Table {
+	table, err := ParseTable(bytes.NewReader(defaultRates))
+	if err != nil {
+		panic(err)
+	}
+	return table
+})
+
+// Match returns the first rule covering the item, or nil if the item can't be
+// salvaged or the table has no rates for it
+func (t *Table) Match(item *gw2api.Item) *Rule {
+	if item.HasFlag(gw2api.ItemFlagNoSalvage) {
+		return nil
+	}
+	for i := range t.Rules {
+		if t.Rules[i].Matches(item) {
+			return &t.Rules[i]
+		}
+	}
+	return nil
+}
+
+// PriceSource looks up trading post prices; *gw2api.Client is one
+type PriceSource interface {
+	GetCommercePrices(ctx context.Context, itemIDs []int, options ...gw2api.RequestOption) ([]*gw2api.Price, error)
+}
+
+// Estimate is the expected result of salvaging one item
+type Estimate struct {
+	Kit     Kit            `json:"kit"`
+	Tier    int            `json:"tier"`
+	Outputs []PricedOutput `json:"outputs"`
+	Gross   int            `json:"gross"`    // Outputs at their sell prices
+	Fees    int            `json:"fees"`     // Trading post fees for selling them
+	KitCost int            `json:"kit_cost"` // Coins of kit used up
+	Value   int            `json:"value"`    // Gross less fees and the kit
+}
+
+// PricedOutput is an expected salvage output with its trading post value
+type PricedOutput struct {
+	Output
+	UnitPrice int     `json:"unit_price"` // Zero if the material has no price
+	Value     float64 `json:"value"`      // PerSalvage times UnitPrice
+}
+
+// ExpectedSalvageValue estimates the value of salvaging the item using the
+// default rates, pricing the outputs with client. It returns nil if the item
+// can't be salvaged or has no rates.
+func ExpectedSalvageValue(ctx context.Context, client PriceSource, item *gw2api.Item) (*Estimate, error) {
+	return DefaultTable().ExpectedValue(ctx, client, item)
+}
+
+// ExpectedValue estimates the value of salvaging the item, pricing the outputs
+// with client. It returns nil if the item can't be salvaged or has no rates.
+func (t *Table) ExpectedValue(ctx context.Context, client PriceSource, item *gw2api.Item) (*Estimate, error) {
+	rule := t.Match(item)
+	if rule == nil {
+		return nil, nil
+	}
+
+	ids := make([]int, len(rule.Outputs))
+	for i, output := range rule.Outputs {
+		ids[i] = output.ItemID
+	}
+	prices, err := client.GetCommercePrices(ctx, ids)
+	if err != nil && !errors.Is(err, gw2api.ErrNotFound) {
+		return nil, fmt.Errorf("failed to price salvage outputs: %w", err)
+	}
+	byID := make(map[int]*gw2api.Price, len(prices))
+	for _, price := range prices {
+		byID[price.ID] = price
+	}
+
+	estimate := &Estimate{Kit: t.Kit, Tier: rule.Tier, KitCost: t.Kit.CostPerUse}
+	var gross float64
+	for _, output := range rule.Outputs {
+		priced := PricedOutput{Output: output}
+		if price, found := byID[output.ItemID]; found {
+			priced.UnitPrice = sellPrice(price)
+			priced.Value = output.PerSalvage * float64(priced.UnitPrice)
+		}
+		gross += priced.Value
+		estimate.Outputs = append(estimate.Outputs, priced)
+	}
+	estimate.Gross = int(math.Round(gross))
+	estimate.Fees = gw2api.TradingPostFees(estimate.Gross)
+	estimate.Value = estimate.Gross - estimate.Fees - estimate.KitCost
+	return estimate, nil
+}
+
+// sellPrice is what a unit can be listed for: the lowest sell listing, or the
+// highest buy order when nothing is listed
+func sellPrice(price *gw2api.Price) int {
+	if price.Sells.Quantity == 0 {
+		return price.Buys.UnitPrice
+	}
+	return price.Sells.UnitPrice
+}
+
+// Ways of getting rid of an item, as in Option.Name
+const (
+	OptionVendor  = "Vendor"
+	OptionTrading = "Trading post"
+	OptionSalvage = "Salvage"
+)
+
+// Option is one way of getting rid of an item and what it earns
+type Option struct {
+	Name  string `json:"name"`
+	Value int    `json:"value"`
+	Best  bool   `json:"best"` // Earns the most of the options
+}
+
+// Compare lists what selling the item to a vendor, listing it on the trading
+// post after fees and salvaging it earn, leaving out options that aren't
+// available. price and estimate may be nil. The option earning the most is
+// marked best.
+func Compare(item *gw2api.Item, price *gw2api.Price, estimate *Estimate) []Option {
+	var options []Option
+	if item.CanSellToVendor() {
+		options = append(options, Option{Name: OptionVendor, Value: item.VendorValue})
+	}
+	if price != nil && item.IsTradable() {
+		if listing := sellPrice(price); listing > 0 {
+			options = append(options, Option{Name: OptionTrading, Value: listing - gw2api.TradingPostFees(listing)})
+		}
+	}
+	if estimate != nil {
+		options = append(options, Option{Name: OptionSalvage, Value: estimate.Value})
+	}
+
+	best := -1
+	for i, option := range options {
+		if best < 0 || option.Value > options[best].Value {
+			best = i
+		}
+	}
+	if best >= 0 {
+		options[best].Best = true
+	}
+	return options
+}
@@ -0,0 +1,113 @@
+package salvage
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// fixedPrices prices items from a map, leaving out items it has no price for
+type fixedPrices map[int]gw2api.Price
+
+func (p fixedPrices) GetCommercePrices(ctx context.Context, itemIDs []int, options ...gw2api.RequestOption) ([]*gw2api.Price, error) {
+	var prices []*gw2api.Price
+	for _, id := range itemIDs {
+		if price, found := p[id]; found {
+			price.ID = id
+			prices = append(prices, &price)
+		}
+	}
+	return prices, nil
+}
+
+func sells(unitPrice int) gw2api.Price {
+	return gw2api.Price{Sells: gw2api.PriceInfo{UnitPrice: unitPrice, Quantity: 100}}
+}
+
+func TestExpectedValue(t *testing.T) {
+	table, err := ParseTable(strings.NewReader(`{
+		"kit": {"name": "Test Kit", "cost_per_use": 10},
+		"rules": [
+			{"rarities": ["Exotic"], "min_level": 80, "max_level": 80, "tier": 6, "types": ["Armor"], "weight_class": "Heavy",
+			 "outputs": [{"item_id": 1, "name": "Ecto", "per_salvage": 1}, {"item_id": 2, "name": "Ore", "per_salvage": 1.5}]},
+			{"rarities": ["Exotic"], "min_level": 80, "max_level": 80, "types": ["Armor", "Weapon"],
+			 "outputs": [{"item_id": 1, "name": "Ecto", "per_salvage": 0.5}, {"item_id": 3, "name": "Unpriced", "per_salvage": 2}]}
+		]
+	}`))
+	if err != nil {
+		t.Fatalf("ParseTable: %v", err)
+	}
+	prices := fixedPrices{
+		1: sells(3000),
+		// No sell listings, so the buy order price is used
+		2: {Buys: gw2api.PriceInfo{UnitPrice: 200, Quantity: 5}},
+	}
+	ctx := context.Background()
+
+	heavy := &gw2api.Item{Type: "Armor", Rarity: "Exotic", Level: 80, Details: &gw2api.ItemDetails{WeightClass: "Heavy"}}
+	estimate, err := table.ExpectedValue(ctx, prices, heavy)
+	if err != nil {
+		t.Fatalf("ExpectedValue: %v", err)
+	}
+	// 3000 + 1.5 * 200 = 3300, less 165 + 330 in fees and 10 for the kit
+	if estimate.Tier != 6 || estimate.Gross != 3300 || estimate.Fees != 495 || estimate.KitCost != 10 || estimate.Value != 2795 {
+		t.Errorf("heavy armor estimate = %+v, expected tier 6, 3300 gross and 2795 value", estimate)
+	}
+	if len(estimate.Outputs) != 2 || estimate.Outputs[1].UnitPrice != 200 || estimate.Outputs[1].Value != 300 {
+		t.Errorf("heavy armor outputs = %+v, expected ore at 200 each", estimate.Outputs)
+	}
+
+	// Other armor falls through to the second rule; the unpriced output is worth nothing
+	light := &gw2api.Item{Type: "Armor", Rarity: "Exotic", Level: 80, Details: &gw2api.ItemDetails{WeightClass: "Light"}}
+	if estimate, err := table.ExpectedValue(ctx, prices, light); err != nil || estimate.Gross != 1500 || estimate.Outputs[1].UnitPrice != 0 {
+		t.Errorf("light armor estimate = %+v, %v, expected 1500 gross", estimate, err)
+	}
+
+	for name, item := range map[string]*gw2api.Item{
+		"rare":       {Type: "Weapon", Rarity: "Rare", Level: 80},
+		"low level":  {Type: "Weapon", Rarity: "Exotic", Level: 79},
+		"no salvage": {Type: "Weapon", Rarity: "Exotic", Level: 80, Flags: []string{gw2api.ItemFlagNoSalvage}},
+	} {
+		if estimate, err := table.ExpectedValue(ctx, prices, item); estimate != nil || err != nil {
+			t.Errorf("%s: estimate = %+v, %v, expected none", name, estimate, err)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	item := &gw2api.Item{VendorValue: 300}
+	price := sells(1000)
+	estimate := &Estimate{Value: 1200}
+
+	options := Compare(item, &price, estimate)
+	// Listing at 1000 earns 1000 - 50 - 100
+	expected := []Option{{Name: OptionVendor, Value: 300}, {Name: OptionTrading, Value: 850}, {Name: OptionSalvage, Value: 1200, Best: true}}
+	if len(options) != len(expected) {
+		t.Fatalf("Compare = %+v, expected %+v", options, expected)
+	}
+	for i := range expected {
+		if options[i] != expected[i] {
+			t.Errorf("option %d = %+v, expected %+v", i, options[i], expected[i])
+		}
+	}
+
+	// Account bound items can't be listed
+	bound := &gw2api.Item{VendorValue: 300, Flags: []string{gw2api.ItemFlagAccountBound}}
+	options = Compare(bound, &price, nil)
+	if len(options) != 1 || options[0].Name != OptionVendor || !options[0].Best {
+		t.Errorf("Compare(bound) = %+v, expected only the vendor", options)
+	}
+}
+
+func TestDefaultTable(t *testing.T) {
+	table := DefaultTable()
+	if table.Kit.Name == "" || len(table.Rules) == 0 {
+		t.Fatalf("DefaultTable = %+v, expected a kit and rules", table)
+	}
+	item := &gw2api.Item{Type: "Armor", Rarity: "Exotic", Level: 80, Details: &gw2api.ItemDetails{WeightClass: "Light"}}
+	if rule := table.Match(item); rule == nil {
+		t.Error("no default rule for level 80 exotic light armor")
+	}
+}
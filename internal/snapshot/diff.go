@@ -0,0 +1,133 @@
+package snapshot
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// Diff is what changed between two snapshots. Sections missing from either
+// snapshot are not compared, and are listed in Incomparable instead.
+type Diff struct {
+	From              time.Time        `json:"from"`
+	To                time.Time        `json:"to"`
+	Currencies        []Change         `json:"currencies,omitempty"`
+	Items             []Change         `json:"items,omitempty"`       // Net across all storage
+	NewUnlocks        map[string][]int `json:"new_unlocks,omitempty"` // Keyed by unlock kind
+	AchievementPoints int              `json:"achievement_points"`    // AP gained
+	MasteryPoints     int              `json:"mastery_points"`        // Mastery points earned
+	Incomparable      []string         `json:"incomparable,omitempty"`
+}
+
+// Change is the amount of one currency or item before and after
+type Change struct {
+	ID     int `json:"id"`
+	Before int `json:"before"`
+	After  int `json:"after"`
+}
+
+// Delta returns the amount gained, or a negative amount if some was lost
+func (c Change) Delta() int {
+	return c.After - c.Before
+}
+
+// Empty reports whether nothing changed in the compared sections
+func (d *Diff) Empty() bool {
+	return len(d.Currencies) == 0 && len(d.Items) == 0 && len(d.NewUnlocks) == 0 &&
+		d.AchievementPoints == 0 && d.MasteryPoints == 0
+}
+
+// Compare returns what changed from the old snapshot to the new one
+func Compare(old, current *Snapshot) *Diff {
+	diff := &Diff{From: old.Metadata.TakenAt, To: current.Metadata.TakenAt}
+
+	compared := make(map[string]bool, len(Sections))
+	for _, section := range Sections {
+		if old.Has(section) && current.Has(section) {
+			compared[section] = true
+		} else if old.Has(section) || current.Has(section) {
+			diff.Incomparable = append(diff.Incomparable, section)
+		}
+	}
+
+	if compared[SectionWallet] {
+		diff.Currencies = changes(old.Wallet, current.Wallet)
+	}
+
+	// Items are netted across the storage both snapshots recorded, so moving an
+	// item from a character to the bank is not a change
+	diff.Items = changes(old.itemTotalsIn(compared), current.itemTotalsIn(compared))
+
+	if compared[SectionUnlocks] {
+		for _, kind := range []string{UnlockSkins, UnlockMinis, UnlockOutfits} {
+			if added := newIDs(old.Unlocks[kind], current.Unlocks[kind]); len(added) > 0 {
+				if diff.NewUnlocks == nil {
+					diff.NewUnlocks = make(map[string][]int)
+				}
+				diff.NewUnlocks[kind] = added
+			}
+		}
+	}
+	if compared[SectionAchievements] {
+		diff.AchievementPoints = current.AchievementPoints - old.AchievementPoints
+	}
+	if compared[SectionMasteries] {
+		diff.MasteryPoints = current.MasteryPoints.Earned - old.MasteryPoints.Earned
+	}
+
+	return diff
+}
+
+// itemTotalsIn returns item counts summed over the storage locations that
+// belong to the given sections
+func (s *Snapshot) itemTotalsIn(sections map[string]bool) map[int]int {
+	totals := make(map[int]int)
+	for location, items := range s.Storage {
+		section := location
+		if _, ok := characterName(location); ok {
+			section = SectionCharacters
+		}
+		if !sections[section] {
+			continue
+		}
+		for itemID, count := range items {
+			totals[itemID] += count
+		}
+	}
+	return totals
+}
+
+// changes lists the IDs whose amount differs, largest gain first and largest
+// loss last
+func changes(old, current map[int]int) []Change {
+	var result []Change
+	for id, before := range old {
+		if after := current[id]; after != before {
+			result = append(result, Change{ID: id, Before: before, After: after})
+		}
+	}
+	for id, after := range current {
+		if _, found := old[id]; !found && after != 0 {
+			result = append(result, Change{ID: id, After: after})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Change) int {
+		if c := cmp.Compare(b.Delta(), a.Delta()); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+	return result
+}
+
+// newIDs returns the IDs in current that are not in old, both sorted
+func newIDs(old, current []int) []int {
+	var added []int
+	for _, id := range current {
+		if _, found := slices.BinarySearch(old, id); !found {
+			added = append(added, id)
+		}
+	}
+	return added
+}
@@ -0,0 +1,179 @@
+// Package snapshot records an account's progress at a point in time, so two
+// snapshots can be compared to see what changed between them.
+package snapshot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Version is the snapshot schema version written by this package
+const Version = 1
+
+// Sections of a snapshot. Each is fetched separately, so one can be skipped when
+// the API key lacks its scope without losing the others.
+const (
+	SectionWallet       = "wallet"
+	SectionBank         = "bank"
+	SectionMaterials    = "materials"
+	SectionShared       = "shared_inventory"
+	SectionCharacters   = "characters"
+	SectionUnlocks      = "unlocks"
+	SectionAchievements = "achievements"
+	SectionMasteries    = "masteries"
+)
+
+// Sections lists every section in the order they are reported
+var Sections = []string{
+	SectionWallet,
+	SectionBank,
+	SectionMaterials,
+	SectionShared,
+	SectionCharacters,
+	SectionUnlocks,
+	SectionAchievements,
+	SectionMasteries,
+}
+
+// Kinds of unlock recorded in a snapshot
+const (
+	UnlockSkins   = "skins"
+	UnlockMinis   = "minis"
+	UnlockOutfits = "outfits"
+)
+
+// Snapshot is an account's state at one point in time. Item and currency maps
+// are keyed by ID, and storage is keyed by location, such as "bank" or
+// "character:Name".
+type Snapshot struct {
+	Metadata          Metadata               `json:"metadata"`
+	Wallet            map[int]int            `json:"wallet,omitempty"`
+	Storage           map[string]map[int]int `json:"storage,omitempty"`
+	Unlocks           map[string][]int       `json:"unlocks,omitempty"`
+	AchievementPoints int                    `json:"achievement_points,omitempty"`
+	MasteryPoints     MasteryPoints          `json:"mastery_points,omitzero"`
+}
+
+// Metadata describes how a snapshot was taken
+type Metadata struct {
+	Version  int               `json:"version"`
+	TakenAt  time.Time         `json:"taken_at"`
+	Account  string            `json:"account,omitempty"`
+	Sections []string          `json:"sections"`          // Sections that were recorded
+	Skipped  map[string]string `json:"skipped,omitempty"` // Sections left out, with the reason
+}
+
+// MasteryPoints is the account's mastery point totals
+type MasteryPoints struct {
+	Earned int `json:"earned"`
+	Spent  int `json:"spent"`
+}
+
+// New returns an empty snapshot taken at the given time
+func New(takenAt time.Time) *Snapshot {
+	return &Snapshot{
+		Metadata: Metadata{Version: Version, TakenAt: takenAt.UTC()},
+		Storage:  make(map[string]map[int]int),
+		Unlocks:  make(map[string][]int),
+	}
+}
+
+// Has reports whether the snapshot recorded the section
+func (s *Snapshot) Has(section string) bool {
+	return slices.Contains(s.Metadata.Sections, section)
+}
+
+// markRecorded adds the section to the metadata, keeping sections in report order
+func (s *Snapshot) markRecorded(section string) {
+	if s.Has(section) {
+		return
+	}
+	s.Metadata.Sections = append(s.Metadata.Sections, section)
+	slices.SortFunc(s.Metadata.Sections, func(a, b string) int {
+		return slices.Index(Sections, a) - slices.Index(Sections, b)
+	})
+}
+
+// markSkipped records why a section is missing from the snapshot
+func (s *Snapshot) markSkipped(section, reason string) {
+	if s.Metadata.Skipped == nil {
+		s.Metadata.Skipped = make(map[string]string)
+	}
+	s.Metadata.Skipped[section] = reason
+}
+
+// addItems adds count of an item to a storage location
+func (s *Snapshot) addItems(location string, itemID, count int) {
+	if itemID == 0 || count == 0 {
+		return // Empty slot
+	}
+	if s.Storage[location] == nil {
+		s.Storage[location] = make(map[int]int)
+	}
+	s.Storage[location][itemID] += count
+}
+
+// characterPrefix starts the storage location of a character's inventory
+const characterPrefix = "character:"
+
+func characterLocation(name string) string {
+	return characterPrefix + name
+}
+
+// characterName returns the character a storage location belongs to, if any
+func characterName(location string) (string, bool) {
+	return strings.CutPrefix(location, characterPrefix)
+}
+
+// ItemTotals returns the count of each item across all storage locations
+func (s *Snapshot) ItemTotals() map[int]int {
+	totals := make(map[int]int)
+	for _, items := range s.Storage {
+		for itemID, count := range items {
+			totals[itemID] += count
+		}
+	}
+	return totals
+}
+
+// WriteFile saves the snapshot as indented JSON
+func (s *Snapshot) WriteFile(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return nil
+}
+
+// ReadFile loads a snapshot written by WriteFile
+func ReadFile(path string) (*Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var s Snapshot
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", path, err)
+	}
+	if s.Metadata.Version != Version {
+		return nil, fmt.Errorf("snapshot %s has version %d, expected %d", path, s.Metadata.Version, Version)
+	}
+	if s.Storage == nil {
+		s.Storage = make(map[string]map[int]int)
+	}
+	if s.Unlocks == nil {
+		s.Unlocks = make(map[string][]int)
+	}
+	for _, ids := range s.Unlocks {
+		slices.Sort(ids) // Compare expects sorted IDs
+	}
+	return &s, nil
+}
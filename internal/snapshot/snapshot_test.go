@@ -0,0 +1,164 @@
+package snapshot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func testSnapshot(takenAt time.Time) *Snapshot {
+	s := New(takenAt)
+	s.Metadata.Account = "Test.1234"
+	for _, section := range Sections {
+		s.markRecorded(section)
+	}
+	s.Wallet = map[int]int{1: 10000, 2: 500}
+	s.addItems(SectionBank, 19721, 10)
+	s.addItems(SectionMaterials, 19700, 250)
+	s.addItems(characterLocation("Test Character"), 19721, 5)
+	s.Unlocks[UnlockSkins] = []int{1, 5}
+	s.Unlocks[UnlockMinis] = []int{3}
+	s.Unlocks[UnlockOutfits] = []int{}
+	s.AchievementPoints = 1000
+	s.MasteryPoints = MasteryPoints{Earned: 50, Spent: 40}
+	return s
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := testSnapshot(time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC))
+	original.markSkipped("example", "requires scope example")
+
+	path := filepath.Join(t.TempDir(), "snap.json")
+	if err := original.WriteFile(path); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	loaded, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if !reflect.DeepEqual(original, loaded) {
+		t.Errorf("round trip changed the snapshot:\n%+v\n%+v", original, loaded)
+	}
+}
+
+func TestDiff(t *testing.T) {
+	old := testSnapshot(time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+	current := testSnapshot(time.Date(2024, 6, 8, 0, 0, 0, 0, time.UTC))
+
+	current.Wallet[1] = 7500 // Spent gold
+	delete(current.Wallet, 2)
+	current.Wallet[3] = 20
+
+	// Ectoplasm moved from the character to the bank, with 2 more looted
+	current.Storage[characterLocation("Test Character")] = nil
+	current.Storage[SectionBank][19721] = 17
+	current.Storage[SectionMaterials][19700] = 0
+	current.addItems(SectionMaterials, 24277, 3)
+
+	current.Unlocks[UnlockSkins] = []int{1, 2, 5}
+	current.Unlocks[UnlockOutfits] = []int{7}
+	current.AchievementPoints = 1015
+	current.MasteryPoints = MasteryPoints{Earned: 51, Spent: 51}
+
+	diff := Compare(old, current)
+	expectedCurrencies := []Change{{ID: 3, After: 20}, {ID: 2, Before: 500}, {ID: 1, Before: 10000, After: 7500}}
+	if !slices.Equal(diff.Currencies, expectedCurrencies) {
+		t.Errorf("currencies = %+v, expected %+v", diff.Currencies, expectedCurrencies)
+	}
+	expectedItems := []Change{{ID: 24277, After: 3}, {ID: 19721, Before: 15, After: 17}, {ID: 19700, Before: 250}}
+	if !slices.Equal(diff.Items, expectedItems) {
+		t.Errorf("items = %+v, expected %+v", diff.Items, expectedItems)
+	}
+	expectedUnlocks := map[string][]int{UnlockSkins: {2}, UnlockOutfits: {7}}
+	if !reflect.DeepEqual(diff.NewUnlocks, expectedUnlocks) {
+		t.Errorf("current unlocks = %v, expected %v", diff.NewUnlocks, expectedUnlocks)
+	}
+	if diff.AchievementPoints != 15 || diff.MasteryPoints != 1 {
+		t.Errorf("gained %d AP and %d mastery points, expected 15 and 1", diff.AchievementPoints, diff.MasteryPoints)
+	}
+	if diff.Empty() || len(diff.Incomparable) != 0 {
+		t.Errorf("Empty() = %v, incomparable %v", diff.Empty(), diff.Incomparable)
+	}
+
+	if same := Compare(old, old); !same.Empty() {
+		t.Errorf("comparing a snapshot with itself = %+v, expected no changes", same)
+	}
+}
+
+func TestDiffSkipsMissingSections(t *testing.T) {
+	old := testSnapshot(time.Now())
+	current := testSnapshot(time.Now())
+
+	// The new key could not read the bank or wallet, which must not look like losing everything
+	current.Metadata.Sections = slices.DeleteFunc(current.Metadata.Sections, func(s string) bool {
+		return s == SectionBank || s == SectionWallet
+	})
+	delete(current.Storage, SectionBank)
+	current.Wallet = nil
+	current.addItems(characterLocation("Test Character"), 19721, 1)
+
+	diff := Compare(old, current)
+	if len(diff.Currencies) != 0 {
+		t.Errorf("currencies = %+v, expected the wallet to be skipped", diff.Currencies)
+	}
+	if expected := []Change{{ID: 19721, Before: 5, After: 6}}; !slices.Equal(diff.Items, expected) {
+		t.Errorf("items = %+v, expected only the character inventory change %+v", diff.Items, expected)
+	}
+	if !slices.Equal(diff.Incomparable, []string{SectionWallet, SectionBank}) {
+		t.Errorf("incomparable = %v", diff.Incomparable)
+	}
+}
+
+func TestTakeSkipsMissingScopes(t *testing.T) {
+	responses := map[string]string{
+		"/v2/account":                    `{"name":"Test.1234"}`,
+		"/v2/account/wallet":             `[{"id":1,"value":100}]`,
+		"/v2/account/bank":               `[{"id":19721,"count":3},null,{"id":19721,"count":2}]`,
+		"/v2/account/materials":          `[{"id":19700,"category":5,"count":0},{"id":19701,"category":5,"count":7}]`,
+		"/v2/account/inventory":          `[null]`,
+		"/v2/characters":                 `["Alpha","Beta"]`,
+		"/v2/characters/Alpha/inventory": `{"bags":[{"id":8932,"size":20,"inventory":[{"id":19721,"count":1},null]},null]}`,
+		"/v2/characters/Beta/inventory":  `{"bags":[]}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if body, found := responses[r.URL.Path]; found {
+			w.Write([]byte(body))
+			return
+		}
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"text":"requires scope progression"}`))
+	}))
+	defer server.Close()
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(server.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	snap, err := Take(context.Background(), client, 2)
+	if err != nil {
+		t.Fatalf("Take: %v", err)
+	}
+
+	if !slices.Equal(snap.Metadata.Sections, []string{SectionWallet, SectionBank, SectionMaterials, SectionShared, SectionCharacters}) {
+		t.Errorf("sections = %v", snap.Metadata.Sections)
+	}
+	for _, section := range []string{SectionUnlocks, SectionAchievements, SectionMasteries} {
+		if _, found := snap.Metadata.Skipped[section]; !found {
+			t.Errorf("%s is not recorded as skipped: %v", section, snap.Metadata.Skipped)
+		}
+	}
+	if snap.Metadata.Account != "Test.1234" || snap.Wallet[1] != 100 {
+		t.Errorf("account %q, wallet %v", snap.Metadata.Account, snap.Wallet)
+	}
+	expectedTotals := map[int]int{19721: 6, 19701: 7, 8932: 1}
+	if totals := snap.ItemTotals(); !reflect.DeepEqual(totals, expectedTotals) {
+		t.Errorf("item totals = %v, expected %v", totals, expectedTotals)
+	}
+	if len(snap.Unlocks) != 0 {
+		t.Errorf("unlocks = %v, expected none without the scope", snap.Unlocks)
+	}
+}
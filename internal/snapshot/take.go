@@ -0,0 +1,278 @@
+package snapshot
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// DefaultConcurrency is the number of requests Take makes at once when none is given
+const DefaultConcurrency = 4
+
+// fetcher fills in one part of a snapshot. It returns a function that applies
+// the result, so fetches can run concurrently while updates stay serial.
+type fetcher func(ctx context.Context) (func(*Snapshot), error)
+
+// task is a fetch for one section. Characters are fetched one task per
+// character, all reporting to the same section.
+type task struct {
+	section string
+	fetch   fetcher
+}
+
+// Take records a snapshot of the account behind the client's API key, making at
+// most concurrency requests at once. Sections the key has no scope for are
+// listed in Metadata.Skipped; any other API error fails the snapshot.
+func Take(ctx context.Context, client *gw2api.Client, concurrency int) (*Snapshot, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultConcurrency
+	}
+
+	account, err := client.GetAccount(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get account: %w", err)
+	}
+
+	snap := New(time.Now())
+	snap.Metadata.Account = account.Name
+
+	tasks := []task{
+		{SectionWallet, fetchWallet(client)},
+		{SectionBank, fetchBank(client)},
+		{SectionMaterials, fetchMaterials(client)},
+		{SectionShared, fetchShared(client)},
+		{SectionUnlocks, fetchUnlocks(client)},
+		{SectionAchievements, fetchAchievements(client)},
+		{SectionMasteries, fetchMasteries(client)},
+	}
+
+	// Character inventories need the character list first
+	names, err := client.GetCharacterNames(ctx)
+	switch {
+	case errors.Is(err, gw2api.ErrMissingScope):
+		snap.markSkipped(SectionCharacters, err.Error())
+	case err != nil:
+		return nil, fmt.Errorf("failed to list characters: %w", err)
+	default:
+		snap.markRecorded(SectionCharacters)
+		for _, name := range names {
+			tasks = append(tasks, task{SectionCharacters, fetchCharacter(client, name)})
+		}
+	}
+
+	if err := run(ctx, snap, tasks, concurrency); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// run performs the tasks with bounded concurrency, applying each result to snap
+func run(ctx context.Context, snap *Snapshot, tasks []task, concurrency int) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		firstErr error
+		skipped  = make(map[string]string)
+	)
+	limit := make(chan struct{}, concurrency)
+
+	for _, t := range tasks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			limit <- struct{}{}
+			defer func() { <-limit }()
+
+			apply, err := t.fetch(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case errors.Is(err, gw2api.ErrMissingScope):
+				skipped[t.section] = err.Error()
+			case err != nil:
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to fetch %s: %w", t.section, err)
+					cancel()
+				}
+			default:
+				apply(snap)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+
+	// A section missing any part, such as one character, is dropped as a whole
+	for section, reason := range skipped {
+		snap.markSkipped(section, reason)
+		snap.Metadata.Sections = slices.DeleteFunc(snap.Metadata.Sections, func(s string) bool { return s == section })
+		switch section {
+		case SectionWallet:
+			snap.Wallet = nil
+		case SectionBank, SectionMaterials, SectionShared:
+			delete(snap.Storage, section)
+		case SectionCharacters:
+			for location := range snap.Storage {
+				if _, ok := characterName(location); ok {
+					delete(snap.Storage, location)
+				}
+			}
+		case SectionUnlocks:
+			clear(snap.Unlocks)
+		case SectionAchievements:
+			snap.AchievementPoints = 0
+		case SectionMasteries:
+			snap.MasteryPoints = MasteryPoints{}
+		}
+	}
+	return nil
+}
+
+func fetchWallet(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		wallet, err := client.GetAccountWallet(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			s.Wallet = make(map[int]int, len(wallet))
+			for _, currency := range wallet {
+				s.Wallet[currency.ID] = currency.Value
+			}
+			s.markRecorded(SectionWallet)
+		}, nil
+	}
+}
+
+func fetchBank(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		bank, err := client.GetAccountBank(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			for _, slot := range bank {
+				s.addItems(SectionBank, slot.ID, slot.Count)
+			}
+			s.markRecorded(SectionBank)
+		}, nil
+	}
+}
+
+func fetchMaterials(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		materials, err := client.GetAccountMaterials(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			for _, slot := range materials {
+				s.addItems(SectionMaterials, slot.ID, slot.Count)
+			}
+			s.markRecorded(SectionMaterials)
+		}, nil
+	}
+}
+
+func fetchShared(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		shared, err := client.GetAccountInventory(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			for _, slot := range shared {
+				s.addItems(SectionShared, slot.ID, slot.Count)
+			}
+			s.markRecorded(SectionShared)
+		}, nil
+	}
+}
+
+func fetchCharacter(client *gw2api.Client, name string) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		inventory, err := client.GetCharacterInventory(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			location := characterLocation(name)
+			for _, bag := range inventory.Bags {
+				s.addItems(location, bag.ID, 1)
+				for _, slot := range bag.Inventory {
+					s.addItems(location, slot.ID, slot.Count)
+				}
+			}
+		}, nil
+	}
+}
+
+func fetchUnlocks(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		skins, err := client.GetAccountSkins(ctx)
+		if err != nil {
+			return nil, err
+		}
+		minis, err := client.GetAccountMinis(ctx)
+		if err != nil {
+			return nil, err
+		}
+		outfits, err := client.GetAccountOutfits(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			s.Unlocks[UnlockSkins] = sortedIDs(skins)
+			s.Unlocks[UnlockMinis] = sortedIDs(minis)
+			s.Unlocks[UnlockOutfits] = sortedIDs(outfits)
+			s.markRecorded(SectionUnlocks)
+		}, nil
+	}
+}
+
+func fetchAchievements(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		points, err := client.GetAccountAchievementPoints(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			s.AchievementPoints = points.Total
+			s.markRecorded(SectionAchievements)
+		}, nil
+	}
+}
+
+func fetchMasteries(client *gw2api.Client) fetcher {
+	return func(ctx context.Context) (func(*Snapshot), error) {
+		summary, err := client.GetAccountMasteryPointSummary(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return func(s *Snapshot) {
+			s.MasteryPoints = MasteryPoints{Earned: summary.Earned, Spent: summary.Spent}
+			s.markRecorded(SectionMasteries)
+		}, nil
+	}
+}
+
+func sortedIDs[T ~int](unlocked []T) []int {
+	ids := make([]int, len(unlocked))
+	for i, id := range unlocked {
+		ids[i] = int(id)
+	}
+	slices.Sort(ids)
+	return ids
+}
@@ -0,0 +1,18 @@
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+//go:embed assets/templates assets/static
+var embeddedAssets embed.FS
+
+// embeddedSub returns an embedded asset directory, which always exists
+func embeddedSub(dir string) fs.FS {
+	sub, err := fs.Sub(embeddedAssets, dir)
+	if err != nil {
+		panic(err)
+	}
+	return sub
+}
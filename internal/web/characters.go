@@ -0,0 +1,45 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// characterListTTL is how long the account's character names are remembered
+// between the inventory, account and character list pages
+const characterListTTL = 5 * time.Minute
+
+// characterListKey is the key of the character names in Server.characterList
+const characterListKey = "characters"
+
+// characterNames returns the account's character names, reusing a recent list
+func (s *Server) characterNames(ctx context.Context) ([]string, error) {
+	if value, found := s.characterList.Get(characterListKey); found {
+		if names, ok := value.([]string); ok {
+			return names, nil
+		}
+	}
+	names, err := s.client.GetCharacterNames(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s.characterList.Set(characterListKey, names, characterListTTL)
+	return names, nil
+}
+
+// renderCharacterError reports a failed character lookup. A character the API
+// no longer knows, usually because it was deleted, gets a page saying so and
+// is dropped from the remembered character list so it stops being offered.
+func (s *Server) renderCharacterError(w http.ResponseWriter, err error, name string) {
+	var notFound *gw2api.CharacterNotFoundError
+	if errors.As(err, &notFound) {
+		s.characterList.Delete(characterListKey)
+		s.renderNotFound(w, "Character no longer exists", "There is no character named "+notFound.Name+" on this account any more. It may have been deleted or renamed.")
+		return
+	}
+	s.renderLookupError(w, err, "Character not found", "There is no character named "+name+" on this account.")
+}
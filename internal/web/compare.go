@@ -0,0 +1,171 @@
+package web
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// compareScopes are the only permissions a pasted token may have. The page
+// only reads unlocks, so a token that could do more is refused rather than
+// used, even for one request.
+var compareScopes = []string{"account", "unlocks"}
+
+// ComparePageData is the data for the unlock comparison page
+type ComparePageData struct {
+	Tabs    []WardrobeTab
+	Active  WardrobeTab
+	HasKey  bool   // Whether the server has an API key for the first account
+	Error   string // Why the comparison couldn't be made
+	Columns []CompareColumn
+}
+
+// CompareColumn is one column of a comparison
+type CompareColumn struct {
+	Label   string
+	Entries []gw2api.WardrobeEntry
+}
+
+// handleComparePage shows the form for comparing the server's account with another
+func (s *Server) handleComparePage(w http.ResponseWriter, r *http.Request) {
+	tab, found := findWardrobeTab(r.URL.Query().Get("kind"))
+	if !found {
+		tab = wardrobeTabs[0]
+	}
+	s.renderCompare(w, http.StatusOK, ComparePageData{Active: tab})
+}
+
+// handleCompare compares the unlocks of the server's account with those of
+// the account a pasted API key or subtoken belongs to. The token is sent in
+// the form body, so it stays out of URLs and logs, and is only held for the
+// length of the request.
+func (s *Server) handleCompare(w http.ResponseWriter, r *http.Request) {
+	tab, found := findWardrobeTab(r.FormValue("kind"))
+	if !found {
+		http.Error(w, "Unknown unlock category", http.StatusBadRequest)
+		return
+	}
+	data := ComparePageData{Active: tab}
+	if !s.client.HasAPIKey() {
+		s.renderCompare(w, http.StatusOK, data)
+		return
+	}
+
+	other, err := s.compareClient(r.Context(), strings.TrimSpace(r.FormValue("token")))
+	if err != nil {
+		data.Error = err.Error()
+		s.renderCompare(w, http.StatusBadRequest, data)
+		return
+	}
+
+	// Both accounts are fetched at once
+	var (
+		wg   sync.WaitGroup
+		ids  [2][]int
+		errs [2]error
+	)
+	for i, client := range []*gw2api.Client{s.client, other} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ids[i], errs[i] = client.GetAccountUnlockIDs(r.Context(), tab.Kind)
+		}()
+	}
+	wg.Wait()
+	for i, err := range errs {
+		whose := "this server's account"
+		if i == 1 {
+			whose = "the other account"
+		}
+		switch {
+		case errors.Is(err, gw2api.ErrMissingScope):
+			data.Error = fmt.Sprintf("the API key for %s lacks the 'unlocks' scope", whose)
+		case err != nil:
+			data.Error = fmt.Sprintf("failed to load the %s of %s: %v", strings.ToLower(tab.Label), whose, err)
+		}
+		if data.Error != "" {
+			s.renderCompare(w, http.StatusBadGateway, data)
+			return
+		}
+	}
+
+	comparison := gw2api.CompareUnlocks(tab.Kind, ids[0], ids[1])
+	entries, err := s.client.GetWardrobeEntries(r.Context(), tab.Kind, slices.Concat(comparison.Both, comparison.OnlyA, comparison.OnlyB))
+	if err != nil {
+		data.Error = fmt.Sprintf("failed to load the %s: %v", strings.ToLower(tab.Label), err)
+		s.renderCompare(w, http.StatusBadGateway, data)
+		return
+	}
+	byID := make(map[int]gw2api.WardrobeEntry, len(entries))
+	for _, entry := range entries {
+		byID[entry.ID] = entry
+	}
+	column := func(label string, ids []int) CompareColumn {
+		result := CompareColumn{Label: label}
+		for _, id := range ids {
+			entry, found := byID[id]
+			if !found {
+				entry = gw2api.WardrobeEntry{ID: id, Name: fmt.Sprintf("Unknown %d", id)}
+			}
+			result.Entries = append(result.Entries, entry)
+		}
+		return result
+	}
+	data.Columns = []CompareColumn{
+		column("Both", comparison.Both),
+		column("Only this server's account", comparison.OnlyA),
+		column("Only the other account", comparison.OnlyB),
+	}
+	s.renderCompare(w, http.StatusOK, data)
+}
+
+// compareClient returns a client for a pasted API key or subtoken, after
+// checking with the API that it only has compareScopes. The client keeps no
+// conditional responses, which would hold on to the token.
+func (s *Server) compareClient(ctx context.Context, token string) (*gw2api.Client, error) {
+	if token == "" {
+		return nil, errors.New("paste an API key or subtoken for the other account")
+	}
+	// Subtokens are JWTs rather than keys, so only keys can be checked offline
+	if strings.Count(token, ".") != 2 {
+		if err := gw2api.ValidateAPIKeyFormat(token); err != nil {
+			return nil, errors.New("that isn't an API key or subtoken")
+		}
+	}
+
+	client := s.client.With(gw2api.WithAPIKey(token), gw2api.WithConditionalRequests(0))
+	info, err := client.CheckAPIKey(ctx)
+	if errors.Is(err, gw2api.ErrInvalidAPIKey) {
+		return nil, errors.New("the API did not accept the token")
+	} else if err != nil {
+		return nil, errors.New("could not check the token, try again later")
+	}
+	if !slices.Contains(info.Permissions, "unlocks") {
+		return nil, errors.New("the token needs the 'unlocks' scope")
+	}
+	for _, permission := range info.Permissions {
+		if !slices.Contains(compareScopes, permission) {
+			return nil, fmt.Errorf("the token also has the '%s' scope; create a subtoken with only 'account' and 'unlocks', so this page can't do more than read unlocks", permission)
+		}
+	}
+	return client.With(gw2api.WithPermissions(info.Permissions...)), nil
+}
+
+// renderCompare writes the comparison page
+func (s *Server) renderCompare(w http.ResponseWriter, status int, data ComparePageData) {
+	data.Tabs = wardrobeTabs
+	data.HasKey = s.client.HasAPIKey()
+	page := PageData{Title: "Compare Unlocks - GW2 Items & Crafting", Content: data}
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	if err := s.templates.Render(w, "compare", page); err != nil {
+		log.Printf("Failed to render compare page: %v", err)
+	}
+}
@@ -0,0 +1,83 @@
+package web
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PinBuy marks an ingredient the user wants to buy instead of craft
+const PinBuy = -1
+
+// CraftingPins holds the user's choices in a crafting tree, mapping an
+// ingredient item ID to the recipe to craft it with, or PinBuy. Ingredients
+// without a pin use the cheapest recipe.
+type CraftingPins map[int]int
+
+// parseCraftingPins reads pins from repeated "pin" query parameters of the form
+// itemID:recipeID, itemID:buy or itemID:auto. Later values override earlier ones,
+// so a form can carry the current pins as hidden inputs ahead of its selects.
+func parseCraftingPins(values url.Values) (CraftingPins, error) {
+	pins := make(CraftingPins)
+	for _, value := range values["pin"] {
+		itemStr, choice, found := strings.Cut(value, ":")
+		if !found {
+			return nil, fmt.Errorf("invalid pin %q: expected itemID:recipeID", value)
+		}
+		itemID, err := strconv.Atoi(itemStr)
+		if err != nil || itemID <= 0 {
+			return nil, fmt.Errorf("invalid pin %q: bad item ID", value)
+		}
+
+		switch choice {
+		case "auto":
+			delete(pins, itemID)
+		case "buy":
+			pins[itemID] = PinBuy
+		default:
+			recipeID, err := strconv.Atoi(choice)
+			if err != nil || recipeID <= 0 {
+				return nil, fmt.Errorf("invalid pin %q: bad recipe ID", value)
+			}
+			pins[itemID] = recipeID
+		}
+	}
+	return pins, nil
+}
+
+// Values returns the pins in their query parameter form, ordered by item ID
+func (p CraftingPins) Values() []string {
+	itemIDs := make([]int, 0, len(p))
+	for itemID := range p {
+		itemIDs = append(itemIDs, itemID)
+	}
+	slices.Sort(itemIDs)
+
+	values := make([]string, len(itemIDs))
+	for i, itemID := range itemIDs {
+		choice := strconv.Itoa(p[itemID])
+		if p[itemID] == PinBuy {
+			choice = "buy"
+		}
+		values[i] = fmt.Sprintf("%d:%s", itemID, choice)
+	}
+	return values
+}
+
+// Query encodes the pins as a query string, so a tree with choices can be shared by URL
+func (p CraftingPins) Query() string {
+	if len(p) == 0 {
+		return ""
+	}
+	return url.Values{"pin": p.Values()}.Encode()
+}
+
+// craftingTreeURL returns the address of a recipe's crafting tree with the given pins
+func craftingTreeURL(recipeID int, pins CraftingPins) string {
+	if query := pins.Query(); query != "" {
+		return fmt.Sprintf("/crafting/%d?%s", recipeID, query)
+	}
+	return fmt.Sprintf("/crafting/%d", recipeID)
+}
@@ -0,0 +1,411 @@
+package web
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// Item and recipe IDs in the test tree
+const (
+	testRoot         = 1   // Crafted from 2x intermediate and 1x leather
+	testIntermediate = 2   // Crafted either from 3x ore or 1x gem
+	testLeather      = 3   // Base material
+	testOre          = 4   // Base material
+	testGem          = 5   // Base material
+	testRootRecipe   = 100 // Root recipe
+	testOreRecipe    = 200 // Intermediate from ore, the cheapest
+	testGemRecipe    = 201 // Intermediate from a gem
+)
+
+// newTestCraftingCache returns a request cache holding a small tree, so it can
+// be built without a client or data cache
+func newTestCraftingCache() *RequestCache {
+	cache := NewRequestCache()
+
+	recipes := []*gw2api.RecipeDetail{
+		{ID: testRootRecipe, OutputItemID: testRoot, OutputItemCount: 1, Ingredients: []gw2api.RecipeIngredient{
+			{ItemID: testIntermediate, Count: 2},
+			{ItemID: testLeather, Count: 1},
+		}},
+		{ID: testOreRecipe, OutputItemID: testIntermediate, OutputItemCount: 1, Ingredients: []gw2api.RecipeIngredient{
+			{ItemID: testOre, Count: 3},
+		}},
+		{ID: testGemRecipe, OutputItemID: testIntermediate, OutputItemCount: 1, Ingredients: []gw2api.RecipeIngredient{
+			{ItemID: testGem, Count: 1},
+		}},
+	}
+	for _, recipe := range recipes {
+		cache.recipes[recipe.ID] = recipe
+	}
+
+	prices := map[int]int{testRoot: 1000, testIntermediate: 100, testLeather: 5, testOre: 10, testGem: 95}
+	for itemID, unitPrice := range prices {
+		cache.items[itemID] = &gw2api.Item{ID: itemID}
+		cache.prices[itemID] = &gw2api.Price{ID: itemID, Sells: gw2api.PriceInfo{UnitPrice: unitPrice}}
+		cache.outputRecipes[itemID] = nil
+	}
+	cache.outputRecipes[testIntermediate] = []int{testOreRecipe, testGemRecipe}
+
+	return cache
+}
+
+func buildTestCraftingTree(pins CraftingPins) *CraftingTreeData {
+	cache := newTestCraftingCache()
+	s := &Server{}
+	return s.summarizeCraftingTree(context.Background(), cache, cache.recipes[testRootRecipe], cache.items[testRoot], 1, 8, pins)
+}
+
+// materialCounts maps base material item IDs to the quantity required
+func materialCounts(data *CraftingTreeData) map[int]int {
+	counts := make(map[int]int)
+	for _, material := range data.BaseMaterials {
+		counts[material.Item.ID] = material.TotalRequired
+	}
+	return counts
+}
+
+func assertMaterials(t *testing.T, data *CraftingTreeData, expected map[int]int) {
+	t.Helper()
+	counts := materialCounts(data)
+	if len(counts) != len(expected) {
+		t.Errorf("base materials = %v, expected %v", counts, expected)
+		return
+	}
+	for itemID, count := range expected {
+		if counts[itemID] != count {
+			t.Errorf("base materials = %v, expected %v", counts, expected)
+			return
+		}
+	}
+}
+
+func TestCraftingTreeCheapestRecipe(t *testing.T) {
+	data := buildTestCraftingTree(nil)
+
+	intermediate := data.Tree.Children[0]
+	if intermediate.Recipe == nil || intermediate.Recipe.ID != testOreRecipe {
+		t.Fatalf("intermediate recipe = %+v, expected the cheaper ore recipe", intermediate.Recipe)
+	}
+	if intermediate.Pinned {
+		t.Error("intermediate is pinned without a pin")
+	}
+	if len(intermediate.AlternativeRecipes) != 2 {
+		t.Fatalf("got %d alternative recipes, expected 2", len(intermediate.AlternativeRecipes))
+	}
+	if !intermediate.AlternativeRecipes[0].Selected || intermediate.AlternativeRecipes[1].Selected {
+		t.Error("the ore recipe should be the selected alternative")
+	}
+	if cost := intermediate.AlternativeRecipes[1].Cost; cost != 95 {
+		t.Errorf("gem recipe cost = %d, expected 95", cost)
+	}
+
+	// 2 intermediates from 3 ore each, plus the leather
+	if data.TotalCraftCost != 2*3*10+5 {
+		t.Errorf("total craft cost = %d, expected 65", data.TotalCraftCost)
+	}
+	assertMaterials(t, data, map[int]int{testOre: 6, testLeather: 1})
+}
+
+func TestCraftingTreePinBuyCollapsesSubtree(t *testing.T) {
+	data := buildTestCraftingTree(CraftingPins{testIntermediate: PinBuy})
+
+	intermediate := data.Tree.Children[0]
+	if intermediate.Recipe != nil || intermediate.HasRecipe || len(intermediate.Children) != 0 {
+		t.Errorf("bought intermediate still has a subtree: recipe %+v, %d children", intermediate.Recipe, len(intermediate.Children))
+	}
+	if !intermediate.Pinned {
+		t.Error("bought intermediate is not marked as pinned")
+	}
+	if len(intermediate.AlternativeRecipes) != 2 {
+		t.Errorf("got %d alternative recipes, expected both to stay selectable", len(intermediate.AlternativeRecipes))
+	}
+
+	if data.TotalCraftCost != 2*100+5 {
+		t.Errorf("total craft cost = %d, expected 205", data.TotalCraftCost)
+	}
+	assertMaterials(t, data, map[int]int{testIntermediate: 2, testLeather: 1})
+}
+
+func TestCraftingTreePinRecipe(t *testing.T) {
+	// The gem recipe costs more than buying, but a pin forces crafting it
+	data := buildTestCraftingTree(CraftingPins{testIntermediate: testGemRecipe})
+
+	intermediate := data.Tree.Children[0]
+	if intermediate.Recipe == nil || intermediate.Recipe.ID != testGemRecipe {
+		t.Fatalf("intermediate recipe = %+v, expected the pinned gem recipe", intermediate.Recipe)
+	}
+	if !intermediate.Pinned || !intermediate.Crafts() {
+		t.Errorf("pinned recipe: Pinned = %v, Crafts() = %v, expected both", intermediate.Pinned, intermediate.Crafts())
+	}
+	if intermediate.CanCraft {
+		t.Error("crafting from gems should not be reported as cheaper than buying")
+	}
+
+	if data.TotalCraftCost != 2*95+5 {
+		t.Errorf("total craft cost = %d, expected 195", data.TotalCraftCost)
+	}
+	assertMaterials(t, data, map[int]int{testGem: 2, testLeather: 1})
+}
+
+func TestCraftingTreeIgnoresUnknownPins(t *testing.T) {
+	// A recipe that does not make the item, and a buy pin on a base material
+	data := buildTestCraftingTree(CraftingPins{testIntermediate: 999, testLeather: PinBuy})
+
+	intermediate := data.Tree.Children[0]
+	if intermediate.Pinned || intermediate.Recipe == nil || intermediate.Recipe.ID != testOreRecipe {
+		t.Errorf("invalid pin was not ignored: pinned %v, recipe %+v", intermediate.Pinned, intermediate.Recipe)
+	}
+	if leather := data.Tree.Children[1]; leather.Pinned {
+		t.Error("base material without recipes is marked as pinned")
+	}
+	assertMaterials(t, data, map[int]int{testOre: 6, testLeather: 1})
+}
+
+func TestCraftingTreeDepthPricing(t *testing.T) {
+	cache := newTestCraftingCache()
+	// Only 2 ore at the best price, then a jump; the tree needs 6
+	cache.listings[testOre] = &gw2api.Listing{ID: testOre, Sells: []gw2api.ListingInfo{
+		{UnitPrice: 10, Quantity: 2},
+		{UnitPrice: 20, Quantity: 100},
+	}}
+	// Deep enough at the best price to stay under the threshold
+	cache.listings[testLeather] = &gw2api.Listing{ID: testLeather, Sells: []gw2api.ListingInfo{
+		{UnitPrice: 5, Quantity: 100},
+	}}
+
+	s := &Server{depthThreshold: 0.05}
+	data := s.summarizeCraftingTree(context.Background(), cache, cache.recipes[testRootRecipe], cache.items[testRoot], 1, 8, nil)
+
+	ore := data.Tree.Children[0].Children[0]
+	// (2*10 + 4*20) / 6, rounded up
+	if !ore.DepthPriced || ore.BuyPrice != 17 {
+		t.Errorf("ore = %d each, depth priced %v, expected the order book average of 17", ore.BuyPrice, ore.DepthPriced)
+	}
+	if leather := data.Tree.Children[1]; leather.DepthPriced || leather.BuyPrice != 5 {
+		t.Errorf("leather = %d each, depth priced %v, expected the best price", leather.BuyPrice, leather.DepthPriced)
+	}
+
+	// Without the option the best price is used
+	plain := (&Server{}).summarizeCraftingTree(context.Background(), cache, cache.recipes[testRootRecipe], cache.items[testRoot], 1, 8, nil)
+	if ore := plain.Tree.Children[0].Children[0]; ore.DepthPriced || ore.BuyPrice != 10 {
+		t.Errorf("ore without depth pricing = %d each, depth priced %v", ore.BuyPrice, ore.DepthPriced)
+	}
+}
+
+func TestCraftingTreeTimeGates(t *testing.T) {
+	cache := newTestCraftingCache()
+	// The intermediate is account bound and time-gated, the leather account
+	// bound with no recipe
+	for _, itemID := range []int{testIntermediate, testLeather} {
+		cache.items[itemID] = &gw2api.Item{ID: itemID, Name: fmt.Sprintf("Item %d", itemID), Flags: []string{gw2api.ItemFlagAccountBound}}
+		delete(cache.prices, itemID)
+	}
+	s := &Server{timeGates: map[int]gw2api.TimeGate{testIntermediate: {ItemID: testIntermediate, PerDay: 1}}}
+	data := s.summarizeCraftingTree(context.Background(), cache, cache.recipes[testRootRecipe], cache.items[testRoot], 3, 8, nil)
+
+	intermediate, leather := data.Tree.Children[0], data.Tree.Children[1]
+	if intermediate.Purchasable || !intermediate.TimeGated || intermediate.PerDay != 1 || !intermediate.Crafts() {
+		t.Errorf("intermediate = %+v, expected a crafted time-gated item that can't be bought", intermediate)
+	}
+	if leather.Purchasable || leather.TimeGated {
+		t.Errorf("leather = %+v, expected an item that can't be bought", leather)
+	}
+	if !data.Tree.Purchasable {
+		t.Error("root can't be bought, expected its price to count")
+	}
+
+	// Only ore is left to buy
+	assertMaterials(t, data, map[int]int{testOre: 18})
+	if len(data.MustCraft) != 2 {
+		t.Fatalf("MustCraft = %+v, expected the intermediate and leather", data.MustCraft)
+	}
+	if first := data.MustCraft[0]; first.Item.ID != testIntermediate || first.TotalRequired != 6 || first.Days != 6 {
+		t.Errorf("first must craft = %+v, expected 6 intermediates over 6 days", first)
+	}
+	if second := data.MustCraft[1]; second.Item.ID != testLeather || second.HasRecipe || second.Days != 0 {
+		t.Errorf("second must craft = %+v, expected leather without a recipe", second)
+	}
+	if data.EstimatedDays != 6 {
+		t.Errorf("EstimatedDays = %d, expected 6", data.EstimatedDays)
+	}
+
+	templates, err := NewTemplates(embeddedSub("assets/templates"), false)
+	if err != nil {
+		t.Fatalf("NewTemplates: %v", err)
+	}
+	var buf bytes.Buffer
+	if err := templates.Render(&buf, "crafting_tree", PageData{Title: "Crafting Tree", Content: data}); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+	if page := buf.String(); !strings.Contains(page, "Time-gated: 1/day") || !strings.Contains(page, "Can't be bought") {
+		t.Error("rendered tree does not show the time gate and the item that can't be bought")
+	}
+	buf.Reset()
+	if err := templates.Render(&buf, "crafting_summary_partial", map[string]any{"CraftingData": data}); err != nil {
+		t.Fatalf("Render summary: %v", err)
+	}
+	if summary := buf.String(); !strings.Contains(summary, "about <span class=\"font-medium text-gray-800\">6 days</span>") || !strings.Contains(summary, "Earn in game") {
+		t.Errorf("summary does not list the must-craft items:\n%s", summary)
+	}
+}
+
+func TestCraftingTreeRendersPins(t *testing.T) {
+	templates, err := NewTemplates(embeddedSub("assets/templates"), false)
+	if err != nil {
+		t.Fatalf("NewTemplates: %v", err)
+	}
+
+	data := buildTestCraftingTree(CraftingPins{testIntermediate: PinBuy})
+	var buf bytes.Buffer
+	if err := templates.Render(&buf, "crafting_tree", PageData{Title: "Crafting Tree", Content: data}); err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	page := buf.String()
+	for _, expected := range []string{
+		`<input type="hidden" name="pin" value="2:buy">`,
+		`<option value="2:buy" selected>`,
+		`<option value="2:200">`,
+		`/crafting/summary/100?pin=2%3Abuy`,
+	} {
+		if !strings.Contains(page, expected) {
+			t.Errorf("rendered tree is missing %s", expected)
+		}
+	}
+}
+
+func TestParseCraftingPins(t *testing.T) {
+	values := url.Values{"pin": {"2:buy", "7:300", "9:400", "9:auto", "2:201"}}
+	pins, err := parseCraftingPins(values)
+	if err != nil {
+		t.Fatalf("parseCraftingPins: %v", err)
+	}
+	if len(pins) != 2 || pins[2] != 201 || pins[7] != 300 {
+		t.Errorf("pins = %v, expected map[2:201 7:300]", pins)
+	}
+
+	// Encoding is ordered so equal choices always share a URL
+	if query := (CraftingPins{7: 300, 2: PinBuy}).Query(); query != "pin=2%3Abuy&pin=7%3A300" {
+		t.Errorf("Query() = %q", query)
+	}
+	roundTrip, err := parseCraftingPins(url.Values{"pin": CraftingPins{7: 300, 2: PinBuy}.Values()})
+	if err != nil || len(roundTrip) != 2 || roundTrip[2] != PinBuy || roundTrip[7] != 300 {
+		t.Errorf("round trip = %v, %v", roundTrip, err)
+	}
+	if url := craftingTreeURL(100, nil); url != "/crafting/100" {
+		t.Errorf("craftingTreeURL without pins = %q", url)
+	}
+
+	for _, invalid := range []string{"2", "x:buy", "2:", "2:-4", "0:300", "2:craft"} {
+		if _, err := parseCraftingPins(url.Values{"pin": {invalid}}); err == nil {
+			t.Errorf("parseCraftingPins(%q) = nil error, expected an error", invalid)
+		}
+	}
+}
+
+func TestOfficialRecipesOnly(t *testing.T) {
+	dir := t.TempDir()
+	recipes := `{"id":1,"output_item_id":19626,"ingredients":[{"item_id":19721,"count":1}]}` + "\n"
+	custom := `{"id":1000001,"output_item_id":19626,"ingredients":[{"item_id":19675,"count":77}],"source":"mystic_forge"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "recipes.json"), []byte(recipes), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, gw2api.CustomRecipesFileName), []byte(custom), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := gw2api.NewClient(gw2api.WithDataCache(dir))
+
+	all := (&Server{client: client}).recipesForOutput(context.Background(), NewRequestCache(), 19626)
+	if !slices.Equal(all, []int{1, 1000001}) {
+		t.Errorf("recipes for Gift of Fortune = %v, expected the official and forge recipes", all)
+	}
+
+	official := (&Server{client: client, officialRecipesOnly: true}).recipesForOutput(context.Background(), NewRequestCache(), 19626)
+	if !slices.Equal(official, []int{1}) {
+		t.Errorf("official recipes for Gift of Fortune = %v, expected only recipe 1", official)
+	}
+}
+
+func TestCraftingTreeReusesRecipeSearches(t *testing.T) {
+	// The test tree served by a fake API, without a data cache
+	tree := newTestCraftingCache()
+	var searches atomic.Int32
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var ids []int
+		for _, id := range strings.Split(r.URL.Query().Get("ids"), ",") {
+			if n, err := strconv.Atoi(id); err == nil {
+				ids = append(ids, n)
+			}
+		}
+		var response any
+		switch r.URL.Path {
+		case "/v2/recipes/search":
+			searches.Add(1)
+			output, _ := strconv.Atoi(r.URL.Query().Get("output"))
+			response = tree.outputRecipes[output]
+		case "/v2/recipes":
+			var recipes []*gw2api.RecipeDetail
+			for _, id := range ids {
+				recipes = append(recipes, tree.recipes[id])
+			}
+			response = recipes
+		case "/v2/items":
+			var items []*gw2api.Item
+			for _, id := range ids {
+				items = append(items, tree.items[id])
+			}
+			response = items
+		case "/v2/commerce/prices":
+			var prices []*gw2api.Price
+			for _, id := range ids {
+				prices = append(prices, tree.prices[id])
+			}
+			response = prices
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if response == nil || reflect.ValueOf(response).IsNil() {
+			response = []int{}
+		}
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	s := &Server{client: client}
+	ctx := context.Background()
+
+	first := s.buildCraftingTree(ctx, tree.recipes[testRootRecipe], tree.items[testRoot], 1, nil)
+	if len(first.BaseMaterials) == 0 {
+		t.Fatal("the first tree has no base materials")
+	}
+	searched := searches.Load()
+	if searched == 0 {
+		t.Fatal("the first tree made no recipe searches")
+	}
+
+	second := s.buildCraftingTree(ctx, tree.recipes[testRootRecipe], tree.items[testRoot], 1, nil)
+	assertMaterials(t, second, materialCounts(first))
+	if again := searches.Load() - searched; again != 0 {
+		t.Errorf("the second tree made %d recipe searches, want none", again)
+	}
+	if hits := client.Stats().RecipeSearchHits; hits != int64(searched) {
+		t.Errorf("RecipeSearchHits = %d, want %d", hits, searched)
+	}
+}
@@ -0,0 +1,63 @@
+package web
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// ErrorPageData is the data for the error page
+type ErrorPageData struct {
+	PageData
+	Status  int
+	Heading string
+	Message string
+}
+
+// ServeHTTP handles a request through the server's middleware
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.handler.ServeHTTP(w, r)
+}
+
+// route dispatches to the registered routes, rendering the not found page
+// for paths that match none of them
+func (s *Server) route(w http.ResponseWriter, r *http.Request) {
+	if _, pattern := s.ServeMux.Handler(r); pattern == "" {
+		s.renderNotFound(w, "Page not found", "There is nothing at "+r.URL.Path+".")
+		return
+	}
+	s.ServeMux.ServeHTTP(w, r)
+}
+
+// renderError writes the error page with the given status
+func (s *Server) renderError(w http.ResponseWriter, status int, heading, message string) {
+	data := ErrorPageData{
+		PageData: PageData{Title: heading + " - GW2 Items & Crafting"},
+		Status:   status,
+		Heading:  heading,
+		Message:  message,
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	w.WriteHeader(status)
+	if err := s.templates.Render(w, "error", data); err != nil {
+		log.Printf("Failed to render error page: %v", err)
+	}
+}
+
+// renderNotFound writes the 404 page
+func (s *Server) renderNotFound(w http.ResponseWriter, heading, message string) {
+	s.renderError(w, http.StatusNotFound, heading, message)
+}
+
+// renderLookupError reports a failed API lookup, as a 404 when the API does not
+// know the ID and as a 502 otherwise so an outage is not mistaken for a bad link
+func (s *Server) renderLookupError(w http.ResponseWriter, err error, heading, message string) {
+	if err == nil || errors.Is(err, gw2api.ErrNotFound) {
+		s.renderNotFound(w, heading, message)
+		return
+	}
+	s.renderError(w, http.StatusBadGateway, "Guild Wars 2 API unavailable", "The request to the Guild Wars 2 API failed: "+err.Error())
+}
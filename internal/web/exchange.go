@@ -0,0 +1,69 @@
+package web
+
+import (
+	"net/http"
+	"time"
+
+	"j5.nz/gw2/internal/exchangehistory"
+)
+
+// Sparkline size in SVG units
+const (
+	sparklineWidth  = 300
+	sparklineHeight = 40
+)
+
+// exchangeWindows are the periods summarized on the exchange page
+var exchangeWindows = []struct {
+	label  string
+	window time.Duration
+}{
+	{"24 hours", 24 * time.Hour},
+	{"7 days", 7 * 24 * time.Hour},
+}
+
+// ExchangePageData is the data for the gem exchange page
+type ExchangePageData struct {
+	Enabled   bool
+	Latest    exchangehistory.Sample
+	HasLatest bool
+	Windows   []ExchangeWindow
+}
+
+// ExchangeWindow summarizes the rates over one period, with sparkline points
+// for each rate
+type ExchangeWindow struct {
+	Label             string
+	Summary           exchangehistory.Summary
+	CoinsPerGemPoints string
+	GemsPerGoldPoints string
+}
+
+// handleExchangePage shows the recent gem exchange rate trend
+func (s *Server) handleExchangePage(w http.ResponseWriter, r *http.Request) {
+	data := ExchangePageData{Enabled: s.exchangeHistory != nil}
+	if s.exchangeHistory != nil {
+		data.Latest, data.HasLatest = s.exchangeHistory.Latest()
+
+		now := time.Now()
+		for _, period := range exchangeWindows {
+			samples := s.exchangeHistory.Since(now.Add(-period.window))
+			data.Windows = append(data.Windows, ExchangeWindow{
+				Label:   period.label,
+				Summary: s.exchangeHistory.Summarize(period.window, now),
+				CoinsPerGemPoints: exchangehistory.Sparkline(samples, sparklineWidth, sparklineHeight, func(sample exchangehistory.Sample) float64 {
+					return float64(sample.CoinsPerGem)
+				}),
+				GemsPerGoldPoints: exchangehistory.Sparkline(samples, sparklineWidth, sparklineHeight, func(sample exchangehistory.Sample) float64 {
+					return sample.GemsPerGold
+				}),
+			})
+		}
+	}
+
+	page := PageData{Title: "Gem Exchange - GW2 Items & Crafting", Content: data}
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "exchange", page); err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -2,20 +2,29 @@ package web
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
+	"html"
+	"math"
 	"net/http"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"j5.nz/gw2/internal/gw2api"
+	"j5.nz/gw2/internal/salvage"
 )
 
 // handleHome renders the main page
 func (s *Server) handleHome(w http.ResponseWriter, r *http.Request) {
+	home := HomePageData{SearchLanguage: searchLanguage(w, r)}
+	if s.client != nil && s.client.DataCache() != nil {
+		home.SearchLanguages = s.client.DataCache().GetItemCache().Languages()
+	}
 	data := PageData{
-		Title: "GW2 Items & Crafting",
+		Title:   "GW2 Items & Crafting",
+		Content: home,
 	}
 	
 	w.Header().Set("Content-Type", "text/html")
@@ -34,23 +43,102 @@ func (s *Server) handleItemSearch(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Search items using cache
-	items, err := s.searchItems(r.Context(), query)
+	// A pasted item chat link jumps straight to the item page
+	if strings.HasPrefix(query, "[&") {
+		link, err := gw2api.ParseChatLink(query)
+		if err != nil || link.Type != gw2api.ChatLinkItem {
+			w.Header().Set("Content-Type", "text/html")
+			fmt.Fprintf(w, `<div id="search-results" class="mt-4"><p class="text-gray-500">%s is not an item chat link</p></div>`, html.EscapeString(query))
+			return
+		}
+		w.Header().Set("HX-Redirect", fmt.Sprintf("/items/%d", link.ID))
+		return
+	}
+
+	page := 1
+	if value := r.FormValue("page"); value != "" {
+		var err error
+		page, err = strconv.Atoi(value)
+		if err != nil || page < 1 {
+			http.Error(w, "Invalid page", http.StatusBadRequest)
+			return
+		}
+	}
+
+	sortKey, order, err := parseSearchSort(r.FormValue("sort"), r.FormValue("order"))
 	if err != nil {
+		http.Error(w, "Invalid sort: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+	var rarity gw2api.Rarity
+	if value := r.FormValue("rarity"); value != "" {
+		if rarity, err = gw2api.ParseRarity(value); err != nil {
+			http.Error(w, "Invalid rarity: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// Search items using cache
+	lang := searchLanguage(w, r)
+	ids, err := s.searchItemIDs(r.Context(), query, lang, rarity)
+	loading := err != nil && s.cacheLoading()
+	if err != nil && !loading {
 		http.Error(w, "Search error: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
+	if sortKey != "" && len(ids) > 0 {
+		if ids, err = s.sortSearchResults(r.Context(), ids, lang, sortKey, order); err != nil {
+			http.Error(w, "Search error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
 
-	// Get prices for the first 10 items only (for performance)
-	itemsWithPrices := s.addPricesToItems(r.Context(), items, 10)
+	w.Header().Set("Content-Type", "text/html")
+	// With a filter chosen, the results keep their controls so it can be changed
+	if len(ids) == 0 && (rarity == "" || page > 1) {
+		if err := s.templates.Render(w, "item_no_results", ItemSearchData{Query: query, Loading: loading}); err != nil {
+			http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		}
+		return
+	}
 
-	data := ItemSearchData{
-		Query: query,
-		Items: itemsWithPrices,
+	start := min((page-1)*searchPageSize, len(ids))
+	end := min(start+searchPageSize, len(ids))
+	var items []*gw2api.Item
+	if start < end {
+		items, err = s.client.GetItems(r.Context(), ids[start:end], s.interactive...)
+		if err != nil {
+			http.Error(w, "Search error: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
 	}
 
-	w.Header().Set("Content-Type", "text/html")
-	if err := s.templates.Render(w, "item_results", data); err != nil {
+	// Only the page being shown needs prices; earlier pages already have
+	// theirs, and sorting by price fetched them all
+	data := ItemSearchData{
+		Query:       query,
+		Items:       s.addPricesToItems(r.Context(), items, 0),
+		Shown:       end,
+		Total:       len(ids),
+		Truncated:   len(ids) >= maxSearchResults,
+		Lang:        lang,
+		Rarity:      rarity,
+		Sort:        sortKey,
+		Order:       order,
+		SortOptions: searchSortOptions,
+		Rarities:    gw2api.Rarities,
+	}
+	if end < len(ids) {
+		data.NextPage = page + 1
+		data.LoadMoreVals = data.searchVals(map[string]string{"page": strconv.Itoa(page + 1)})
+	}
+
+	// Later pages are rows appended in place of the load more button
+	name := "item_results"
+	if page > 1 {
+		name = "item_result_rows"
+	}
+	if err := s.templates.Render(w, name, data); err != nil {
 		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
 		return
 	}
@@ -66,26 +154,37 @@ func (s *Server) handleItemPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get item details
-	items, err := s.client.GetItems(r.Context(), []int{itemID})
+	items, err := s.client.GetItems(r.Context(), []int{itemID}, s.interactive...)
 	if err != nil || len(items) == 0 {
-		http.Error(w, "Item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("There is no item with ID %d.", itemID))
 		return
 	}
 	item := items[0]
 
 	// Get price
-	price, hasPrice := s.getItemPrice(r.Context(), itemID)
+	priceEntry, hasPrice := s.getItemPriceEntry(r.Context(), itemID)
 
 	// Get recipes that create this item
 	recipes, _ := s.getRecipesForItem(r.Context(), itemID)
 
+	// Get vendors selling this item
+	vendors, _ := s.client.GetVendorOffers(r.Context(), itemID)
+
+	// Compare selling with salvaging; the estimate is skipped if pricing fails
+	estimate, _ := salvage.ExpectedSalvageValue(r.Context(), s.client, item)
+
 	data := PageData{
 		Title: item.Name + " - GW2 Items & Crafting",
 		Content: ItemDetailData{
-			Item:     item,
-			Price:    price,
-			HasPrice: hasPrice,
-			Recipes:  recipes,
+			Item:       newItemView(item),
+			Price:      priceEntry.price(),
+			HasPrice:   hasPrice,
+			BuyChange:  priceEntry.BuyChange(),
+			SellChange: priceEntry.SellChange(),
+			Recipes:    recipes,
+			Vendors:    vendors,
+			Salvage:    estimate,
+			SellWays:   salvage.Compare(item, priceEntry.price(), estimate),
 		},
 	}
 
@@ -96,6 +195,45 @@ func (s *Server) handleItemPage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleSkillPage shows a skill's tooltip facts. Selected traits can be given
+// as a comma-separated traits query parameter to apply their traited facts.
+func (s *Server) handleSkillPage(w http.ResponseWriter, r *http.Request) {
+	skillID, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil {
+		http.Error(w, "Invalid skill ID", http.StatusBadRequest)
+		return
+	}
+
+	var traits []int
+	for field := range strings.SplitSeq(r.URL.Query().Get("traits"), ",") {
+		if traitID, err := strconv.Atoi(strings.TrimSpace(field)); err == nil {
+			traits = append(traits, traitID)
+		}
+	}
+
+	skills, err := s.client.GetSkills(r.Context(), []int{skillID}, s.interactive...)
+	if err != nil || len(skills) == 0 {
+		s.renderLookupError(w, err, "Skill not found", fmt.Sprintf("There is no skill with ID %d.", skillID))
+		return
+	}
+	skill := skills[0]
+
+	data := PageData{
+		Title: skill.Name + " - GW2 Items & Crafting",
+		Content: SkillPageData{
+			Skill:  skill,
+			Facts:  skill.RenderFacts(traits),
+			Traits: traits,
+		},
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "skill_page", data); err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+		return
+	}
+}
+
 // handleItemDetail shows detailed item information
 func (s *Server) handleItemDetail(w http.ResponseWriter, r *http.Request) {
 	idStr := r.PathValue("id")
@@ -106,24 +244,35 @@ func (s *Server) handleItemDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get item details
-	items, err := s.client.GetItems(r.Context(), []int{itemID})
+	items, err := s.client.GetItems(r.Context(), []int{itemID}, s.interactive...)
 	if err != nil || len(items) == 0 {
-		http.Error(w, "Item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("There is no item with ID %d.", itemID))
 		return
 	}
 	item := items[0]
 
 	// Get price
-	price, hasPrice := s.getItemPrice(r.Context(), itemID)
+	priceEntry, hasPrice := s.getItemPriceEntry(r.Context(), itemID)
 
 	// Get recipes that create this item
 	recipes, _ := s.getRecipesForItem(r.Context(), itemID)
 
+	// Get vendors selling this item
+	vendors, _ := s.client.GetVendorOffers(r.Context(), itemID)
+
+	// Compare selling with salvaging; the estimate is skipped if pricing fails
+	estimate, _ := salvage.ExpectedSalvageValue(r.Context(), s.client, item)
+
 	data := ItemDetailData{
-		Item:     item,
-		Price:    price,
-		HasPrice: hasPrice,
-		Recipes:  recipes,
+		Item:       newItemView(item),
+		Price:      priceEntry.price(),
+		HasPrice:   hasPrice,
+		BuyChange:  priceEntry.BuyChange(),
+		SellChange: priceEntry.SellChange(),
+		Recipes:    recipes,
+		Vendors:    vendors,
+		Salvage:    estimate,
+		SellWays:   salvage.Compare(item, priceEntry.price(), estimate),
 	}
 
 	w.Header().Set("Content-Type", "text/html")
@@ -143,17 +292,17 @@ func (s *Server) handleRecipeDetail(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get recipe details
-	recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID})
+	recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID}, s.interactive...)
 	if err != nil || len(recipes) == 0 {
-		http.Error(w, "Recipe not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Recipe not found", fmt.Sprintf("There is no recipe with ID %d.", recipeID))
 		return
 	}
 	recipe := recipes[0]
 
 	// Get output item
-	outputItems, err := s.client.GetItems(r.Context(), []int{recipe.OutputItemID})
+	outputItems, err := s.client.GetItems(r.Context(), []int{recipe.OutputItemID}, s.interactive...)
 	if err != nil || len(outputItems) == 0 {
-		http.Error(w, "Output item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("Recipe %d makes item %d, which the API does not know.", recipeID, recipe.OutputItemID))
 		return
 	}
 	outputItem := outputItems[0]
@@ -175,8 +324,8 @@ func (s *Server) handleRecipeDetail(w http.ResponseWriter, r *http.Request) {
 			Title: "Recipe: " + outputItem.Name,
 		},
 		RecipeDetailData: RecipeDetailData{
-			Recipe:      recipe,
-			OutputItem:  outputItem,
+			Recipe:      newRecipeView(recipe),
+			OutputItem:  newItemView(outputItem),
 			Ingredients: ingredients,
 			TotalCost:   totalCost,
 		},
@@ -198,24 +347,35 @@ func (s *Server) handleCraftingTree(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pins, err := parseCraftingPins(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get recipe details
-	recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID})
+	recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID}, s.interactive...)
 	if err != nil || len(recipes) == 0 {
-		http.Error(w, "Recipe not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Recipe not found", fmt.Sprintf("There is no recipe with ID %d.", recipeID))
 		return
 	}
 	recipe := recipes[0]
 
 	// Get output item
-	outputItems, err := s.client.GetItems(r.Context(), []int{recipe.OutputItemID})
+	outputItems, err := s.client.GetItems(r.Context(), []int{recipe.OutputItemID}, s.interactive...)
 	if err != nil || len(outputItems) == 0 {
-		http.Error(w, "Output item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("Recipe %d makes item %d, which the API does not know.", recipeID, recipe.OutputItemID))
 		return
 	}
 	outputItem := outputItems[0]
 
 	// Build the complete crafting tree
-	craftingData := s.buildCraftingTree(r.Context(), recipe, outputItem, 1)
+	craftingData := s.buildCraftingTree(r.Context(), recipe, outputItem, 1, pins)
+
+	// Keep the address bar on the canonical URL for the choices, so it can be shared
+	if r.Header.Get("HX-Request") == "true" {
+		w.Header().Set("HX-Push-Url", craftingTreeURL(recipeID, pins))
+	}
 
 	data := PageData{
 		Title:   "Crafting Tree: " + outputItem.Name,
@@ -253,32 +413,40 @@ func (s *Server) handleCraftingNode(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pins, err := parseCraftingPins(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get recipe and item details
 	var recipe *gw2api.RecipeDetail
 	if recipeID > 0 {
-		recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID})
+		recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID}, s.interactive...)
 		if err == nil && len(recipes) > 0 {
 			recipe = recipes[0]
 		}
 	}
 
-	items, err := s.client.GetItems(r.Context(), []int{itemID})
+	items, err := s.client.GetItems(r.Context(), []int{itemID}, s.interactive...)
 	if err != nil || len(items) == 0 {
-		http.Error(w, "Item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("There is no item with ID %d.", itemID))
 		return
 	}
 	item := items[0]
 
 	// Build single node with immediate children only (depth 1)
 	cache := NewRequestCache()
-	node := s.buildSingleCraftingNode(r.Context(), cache, recipe, item, quantity, 0, 1)
+	node := s.buildSingleCraftingNode(r.Context(), cache, recipe, item, quantity, 0, 1, pins)
 
 	data := struct {
 		Node *CraftingNode
 		Level int
+		Pins  CraftingPins
 	}{
 		Node: node,
 		Level: 0,
+		Pins:  pins,
 	}
 
 	w.Header().Set("Content-Type", "text/html")
@@ -319,18 +487,24 @@ func (s *Server) handleCraftingNodeExpand(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	pins, err := parseCraftingPins(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get recipe details
 	var recipe *gw2api.RecipeDetail
 	if recipeID > 0 {
-		recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID})
+		recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID}, s.interactive...)
 		if err == nil && len(recipes) > 0 {
 			recipe = recipes[0]
 		}
 	}
 
-	items, err := s.client.GetItems(r.Context(), []int{itemID})
+	items, err := s.client.GetItems(r.Context(), []int{itemID}, s.interactive...)
 	if err != nil || len(items) == 0 {
-		http.Error(w, "Item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("There is no item with ID %d.", itemID))
 		return
 	}
 	item := items[0]
@@ -339,18 +513,20 @@ func (s *Server) handleCraftingNodeExpand(w http.ResponseWriter, r *http.Request
 	if level == 1 {
 		// Build minimal node to get children count
 		cache := NewRequestCache()
-		node := s.buildSingleCraftingNode(r.Context(), cache, recipe, item, quantity, level, 1)
+		node := s.buildSingleCraftingNode(r.Context(), cache, recipe, item, quantity, level, 1, pins)
 		
 		data := struct {
 			ParentItemID     int
 			ParentRecipeID   int  
 			ParentQuantity   int
 			ChildrenCount    int
+			Pins             CraftingPins
 		}{
 			ParentItemID:   itemID,
 			ParentRecipeID: recipeID,
 			ParentQuantity: quantity,
 			ChildrenCount:  len(node.Children),
+			Pins:           pins,
 		}
 		
 		w.Header().Set("Content-Type", "text/html")
@@ -363,7 +539,7 @@ func (s *Server) handleCraftingNodeExpand(w http.ResponseWriter, r *http.Request
 
 	// Build children nodes for expansion (allow deeper recursion for proper recipe discovery)
 	cache := NewRequestCache()
-	node := s.buildSingleCraftingNode(r.Context(), cache, recipe, item, quantity, level, 4)
+	node := s.buildSingleCraftingNode(r.Context(), cache, recipe, item, quantity, level, 4, pins)
 
 	data := struct {
 		Children       []*CraftingNode
@@ -371,12 +547,14 @@ func (s *Server) handleCraftingNodeExpand(w http.ResponseWriter, r *http.Request
 		ParentItemID   int
 		ParentRecipeID int
 		ParentQuantity int
+		Pins           CraftingPins
 	}{
 		Children:       node.Children,
 		Level:          level + 1,
 		ParentItemID:   itemID,
 		ParentRecipeID: recipeID,
 		ParentQuantity: quantity,
+		Pins:           pins,
 	}
 
 	w.Header().Set("Content-Type", "text/html")
@@ -395,24 +573,30 @@ func (s *Server) handleCraftingSummary(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	pins, err := parseCraftingPins(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get recipe details
-	recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID})
+	recipes, err := s.client.GetRecipes(r.Context(), []int{recipeID}, s.interactive...)
 	if err != nil || len(recipes) == 0 {
-		http.Error(w, "Recipe not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Recipe not found", fmt.Sprintf("There is no recipe with ID %d.", recipeID))
 		return
 	}
 	recipe := recipes[0]
 
 	// Get output item
-	outputItems, err := s.client.GetItems(r.Context(), []int{recipe.OutputItemID})
+	outputItems, err := s.client.GetItems(r.Context(), []int{recipe.OutputItemID}, s.interactive...)
 	if err != nil || len(outputItems) == 0 {
-		http.Error(w, "Output item not found", http.StatusNotFound)
+		s.renderLookupError(w, err, "Item not found", fmt.Sprintf("Recipe %d makes item %d, which the API does not know.", recipeID, recipe.OutputItemID))
 		return
 	}
 	outputItem := outputItems[0]
 
 	// Build the complete crafting tree for cost analysis only
-	craftingData := s.buildCraftingTree(r.Context(), recipe, outputItem, 1)
+	craftingData := s.buildCraftingTree(r.Context(), recipe, outputItem, 1, pins)
 
 	data := struct {
 		CraftingData *CraftingTreeData
@@ -434,10 +618,25 @@ func (s *Server) handleSetAPIKey(w http.ResponseWriter, r *http.Request) {
 	// TODO: Implement session storage for API key
 	// For now, just validate the key works
 	if apiKey != "" {
-		client := gw2api.NewClient(gw2api.WithAPIKey(apiKey))
-		_, err := client.GetAccount(r.Context())
-		if err != nil {
-			http.Error(w, "Invalid API key", http.StatusBadRequest)
+		// Catch typos and partial pastes without a round trip to the API
+		if err := gw2api.ValidateAPIKeyFormat(apiKey); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		// Derive from the server's client so the check shares its data cache
+		// and rate limit rather than loading another copy
+		var client *gw2api.Client
+		if s.client != nil {
+			client = s.client.With(gw2api.WithAPIKey(apiKey))
+		} else {
+			client = gw2api.NewClient(gw2api.WithAPIKey(apiKey))
+		}
+		if _, err := client.CheckAPIKey(r.Context()); errors.Is(err, gw2api.ErrInvalidAPIKey) {
+			http.Error(w, "Invalid API key: the API did not accept it", http.StatusBadRequest)
+			return
+		} else if err != nil {
+			http.Error(w, "Could not check the API key, try again later", http.StatusBadGateway)
 			return
 		}
 	}
@@ -448,15 +647,83 @@ func (s *Server) handleSetAPIKey(w http.ResponseWriter, r *http.Request) {
 
 // Helper functions
 
-// searchItems searches for items using the client's cache
-func (s *Server) searchItems(ctx context.Context, query string) ([]*gw2api.Item, error) {
+// searchItems searches for items using the client's cache, matching names in lang if it is loaded
+// Item search results are shown a page at a time, from at most maxSearchResults
+// matches
+const (
+	searchPageSize   = 20
+	maxSearchResults = 500
+)
+
+// searchResultTTL is how long the matches for a query are remembered. HTMX
+// often sends the same query several times in a row, and load more requests
+// repeat it for every page.
+const searchResultTTL = 30 * time.Second
+
+// searchItemIDs returns the IDs of the items whose names match query, in
+// search order, keeping only those of rarity if it is set
+func (s *Server) searchItemIDs(ctx context.Context, query string, lang gw2api.Language, rarity gw2api.Rarity) ([]int, error) {
+	// Names are matched case-insensitively, so the key is too
+	key := string(lang) + "\x00" + string(rarity) + "\x00" + strings.ToLower(query)
+	if value, found := s.searchResults.Get(key); found {
+		if ids, ok := value.([]int); ok {
+			return ids, nil
+		}
+	}
+
 	// Use the client's SearchItems method which uses the cache
 	options := gw2api.ItemSearchOptions{
-		Name:  query,
-		Limit: 20, // Limit to 20 results for better performance
+		Name:     query,
+		Limit:    maxSearchResults,
+		Language: lang,
 	}
-	
-	return s.client.SearchItems(ctx, options)
+	if rarity != "" {
+		options.RarityFilter = []gw2api.Rarity{rarity}
+	}
+	items, err := s.client.SearchItems(ctx, options)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	// Item names in other languages may not have loaded yet
+	if !s.cacheLoading() {
+		s.searchResults.Set(key, ids, searchResultTTL)
+	}
+	return ids, nil
+}
+
+// cacheLoading reports whether the data cache is still loading, so lookups
+// that need it should say so rather than fail
+func (s *Server) cacheLoading() bool {
+	return s.client != nil && s.client.DataCache() != nil && s.client.DataCache().Loading()
+}
+
+// searchLanguageCookie remembers the language item names are searched in
+const searchLanguageCookie = "lang"
+
+// searchLanguage returns the language to search item names in. A lang query or
+// form parameter is remembered in a cookie, and an empty one clears it to search
+// the default names again.
+func searchLanguage(w http.ResponseWriter, r *http.Request) gw2api.Language {
+	if _, given := r.URL.Query()["lang"]; given || r.PostFormValue("lang") != "" {
+		lang, ok := gw2api.ParseLanguage(r.FormValue("lang"))
+		if !ok {
+			http.SetCookie(w, &http.Cookie{Name: searchLanguageCookie, Path: "/", MaxAge: -1})
+			return ""
+		}
+		http.SetCookie(w, &http.Cookie{Name: searchLanguageCookie, Value: string(lang), Path: "/", MaxAge: 365 * 24 * 60 * 60})
+		return lang
+	}
+	if cookie, err := r.Cookie(searchLanguageCookie); err == nil {
+		if lang, ok := gw2api.ParseLanguage(cookie.Value); ok {
+			return lang
+		}
+	}
+	return ""
 }
 
 // addPricesToItems fetches prices for items with optional limit
@@ -480,15 +747,17 @@ func (s *Server) addPricesToItems(ctx context.Context, items []*gw2api.Item, lim
 	
 	// Build results
 	for i, item := range items {
-		if price, hasPrice := priceMap[item.ID]; hasPrice && i < priceLimit {
+		if entry, hasPrice := priceMap[item.ID]; hasPrice && i < priceLimit {
 			results[i] = &ItemWithPrice{
-				Item:     item,
-				Price:    price,
-				HasPrice: true,
+				ItemView:   newItemView(item),
+				Price:      entry.Price,
+				HasPrice:   true,
+				BuyChange:  entry.BuyChange(),
+				SellChange: entry.SellChange(),
 			}
 		} else {
 			results[i] = &ItemWithPrice{
-				Item:     item,
+				ItemView: newItemView(item),
 				Price:    nil,
 				HasPrice: false,
 			}
@@ -500,46 +769,54 @@ func (s *Server) addPricesToItems(ctx context.Context, items []*gw2api.Item, lim
 
 // getItemPrice gets item price (cached or fresh)
 func (s *Server) getItemPrice(ctx context.Context, itemID int) (*gw2api.Price, bool) {
+	entry, found := s.getItemPriceEntry(ctx, itemID)
+	return entry.price(), found
+}
+
+// getItemPriceEntry gets an item's price (cached or fresh) along with the
+// price observed before it
+func (s *Server) getItemPriceEntry(ctx context.Context, itemID int) (*PriceEntry, bool) {
 	// Check cache first
 	priceCache := &PriceCache{cache: s.priceCache}
-	if price, found := priceCache.GetPrice(itemID); found {
-		return price, true
+	if entry, found := priceCache.GetEntry(itemID); found {
+		return entry, true
 	}
 
 	// Fetch from API
-	prices, err := s.client.GetCommercePrices(ctx, []int{itemID})
+	prices, err := s.client.GetCommercePrices(ctx, []int{itemID}, s.interactive...)
 	if err != nil || len(prices) == 0 {
 		return nil, false
 	}
 
-	price := prices[0]
-	priceCache.SetPrice(itemID, price)
-	return price, true
+	return priceCache.SetPrice(itemID, prices[0]), true
 }
 
+// maxPriceIDsPerRequest is the most prices the API returns for one request
+const maxPriceIDsPerRequest = 200
+
+
 // batchGetPrices fetches multiple item prices with caching
-func (s *Server) batchGetPrices(ctx context.Context, itemIDs []int) map[int]*gw2api.Price {
+func (s *Server) batchGetPrices(ctx context.Context, itemIDs []int) map[int]*PriceEntry {
 	priceCache := &PriceCache{cache: s.priceCache}
-	result := make(map[int]*gw2api.Price)
+	result := make(map[int]*PriceEntry)
 	var uncachedIDs []int
 	
 	// Check cache first
 	for _, itemID := range itemIDs {
-		if price, found := priceCache.GetPrice(itemID); found {
-			result[itemID] = price
+		if entry, found := priceCache.GetEntry(itemID); found {
+			result[itemID] = entry
 		} else {
 			uncachedIDs = append(uncachedIDs, itemID)
 		}
 	}
 	
-	// Fetch uncached prices in batch
-	if len(uncachedIDs) > 0 {
-		prices, err := s.client.GetCommercePrices(ctx, uncachedIDs)
+	// Fetch uncached prices in batches the API accepts
+	for chunk := range slices.Chunk(uncachedIDs, maxPriceIDsPerRequest) {
+		prices, err := s.client.GetCommercePrices(ctx, chunk, s.interactive...)
 		if err == nil {
 			for _, price := range prices {
 				if price != nil {
-					result[price.ID] = price
-					priceCache.SetPrice(price.ID, price)
+					result[price.ID] = priceCache.SetPrice(price.ID, price)
 				}
 			}
 		}
@@ -556,25 +833,27 @@ func (s *Server) getRecipesForItem(ctx context.Context, itemID int) (*ItemRecipe
 	}
 
 	// Get recipes that create this item
-	createRecipeIDs, err := s.searchRecipesByOutput(ctx, itemID)
+	createRecipeIDs, err := s.client.SearchRecipesByOutput(ctx, itemID, s.interactive...)
+	createRecipeIDs = s.filterRecipeIDs(createRecipeIDs)
 	if err == nil && len(createRecipeIDs) > 0 {
 		// Limit to first 5 for performance
 		if len(createRecipeIDs) > 5 {
 			createRecipeIDs = createRecipeIDs[:5]
 		}
-		if createRecipes, err := s.client.GetRecipes(ctx, createRecipeIDs); err == nil {
+		if createRecipes, err := s.client.GetRecipes(ctx, createRecipeIDs, s.interactive...); err == nil {
 			result.CreatesItem = s.enrichRecipesWithOutputItems(ctx, createRecipes)
 		}
 	}
 
 	// Get recipes that use this item as ingredient
-	useRecipeIDs, err := s.searchRecipesByInput(ctx, itemID)
+	useRecipeIDs, err := s.client.SearchRecipesByInput(ctx, itemID, s.interactive...)
+	useRecipeIDs = s.filterRecipeIDs(useRecipeIDs)
 	if err == nil && len(useRecipeIDs) > 0 {
 		// Limit to first 10 for performance
 		if len(useRecipeIDs) > 10 {
 			useRecipeIDs = useRecipeIDs[:10]
 		}
-		if useRecipes, err := s.client.GetRecipes(ctx, useRecipeIDs); err == nil {
+		if useRecipes, err := s.client.GetRecipes(ctx, useRecipeIDs, s.interactive...); err == nil {
 			result.UsesItem = s.enrichRecipesWithOutputItems(ctx, useRecipes)
 		}
 	}
@@ -599,13 +878,13 @@ func (s *Server) enrichRecipesWithOutputItems(ctx context.Context, recipes []*gw
 	}
 
 	// Fetch all output items in one API call
-	items, err := s.client.GetItems(ctx, itemIDs)
+	items, err := s.client.GetItems(ctx, itemIDs, s.interactive...)
 	if err != nil {
 		// If we can't get items, return recipes without output items
 		result := make([]*RecipeWithOutput, len(recipes))
 		for i, recipe := range recipes {
 			result[i] = &RecipeWithOutput{
-				Recipe:     recipe,
+				Recipe:     newRecipeView(recipe),
 				OutputItem: nil,
 			}
 		}
@@ -622,125 +901,20 @@ func (s *Server) enrichRecipesWithOutputItems(ctx context.Context, recipes []*gw
 	result := make([]*RecipeWithOutput, len(recipes))
 	for i, recipe := range recipes {
 		result[i] = &RecipeWithOutput{
-			Recipe:     recipe,
-			OutputItem: itemMap[recipe.OutputItemID],
+			Recipe:     newRecipeView(recipe),
+			OutputItem: newItemView(itemMap[recipe.OutputItemID]),
 		}
 	}
 
 	return result
 }
 
-// getRecipesForItemLegacy finds recipes that create the given item (legacy compatibility)
-func (s *Server) getRecipesForItemLegacy(ctx context.Context, itemID int) ([]*gw2api.RecipeDetail, error) {
-	recipes, err := s.getRecipesForItem(ctx, itemID)
-	if err != nil {
-		return []*gw2api.RecipeDetail{}, err
-	}
-	
-	// Extract just the recipe details for legacy compatibility
-	result := make([]*gw2api.RecipeDetail, len(recipes.CreatesItem))
-	for i, recipeWithOutput := range recipes.CreatesItem {
-		result[i] = recipeWithOutput.Recipe
-	}
-	return result, nil
-}
-
-// searchRecipesByOutput searches for recipes that create a specific item using cache or API  
-func (s *Server) searchRecipesByOutput(ctx context.Context, itemID int) ([]int, error) {
-	// Try to search cached recipes first
-	if s.client.DataCache() != nil && s.client.DataCache().GetRecipeCache().IsLoaded() {
-		recipeIDs := s.client.DataCache().GetRecipeCache().SearchByOutput(itemID)
-		if len(recipeIDs) > 0 {
-			// Limit to first 5 for performance
-			if len(recipeIDs) > 5 {
-				recipeIDs = recipeIDs[:5]
-			}
-			return recipeIDs, nil
-		}
-	}
-	
-	// Fallback to API search if cache not available or no results
-	return s.searchAPIRecipesByOutput(ctx, itemID)
-}
-
-// searchRecipesByInput searches for recipes that use a specific item as ingredient
-func (s *Server) searchRecipesByInput(ctx context.Context, itemID int) ([]int, error) {
-	// Try to search cached recipes first
-	if s.client.DataCache() != nil && s.client.DataCache().GetRecipeCache().IsLoaded() {
-		recipeIDs := s.client.DataCache().GetRecipeCache().SearchByInput(itemID)
-		if len(recipeIDs) > 0 {
-			// Limit to first 10 for performance
-			if len(recipeIDs) > 10 {
-				recipeIDs = recipeIDs[:10]
-			}
-			return recipeIDs, nil
-		}
-	}
-	
-	// Fallback to API search if cache not available or no results
-	return s.searchAPIRecipesByInput(ctx, itemID)
-}
-
-// searchAPIRecipesByInput searches for recipes using the API (fallback)
-func (s *Server) searchAPIRecipesByInput(ctx context.Context, itemID int) ([]int, error) {
-	url := fmt.Sprintf("https://api.guildwars2.com/v2/recipes/search?input=%d", itemID)
-	
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
+// filterRecipeIDs drops supplemental recipes when the server only uses official ones
+func (s *Server) filterRecipeIDs(recipeIDs []int) []int {
+	if !s.officialRecipesOnly {
+		return recipeIDs
 	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode == 404 {
-		return []int{}, nil
-	}
-	
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var recipeIDs []int
-	if err := json.Unmarshal(body, &recipeIDs); err != nil {
-		return nil, err
-	}
-
-	return recipeIDs, nil
-}
-
-// searchAPIRecipesByOutput searches for recipes using the API (fallback)
-func (s *Server) searchAPIRecipesByOutput(ctx context.Context, itemID int) ([]int, error) {
-	url := fmt.Sprintf("https://api.guildwars2.com/v2/recipes/search?output=%d", itemID)
-	
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-	
-	if resp.StatusCode == 404 {
-		return []int{}, nil
-	}
-	
-	if resp.StatusCode != 200 {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
-	}
-	
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var recipeIDs []int
-	if err := json.Unmarshal(body, &recipeIDs); err != nil {
-		return nil, err
-	}
-
-	return recipeIDs, nil
+	return slices.DeleteFunc(recipeIDs, gw2api.IsCustomRecipeID)
 }
 
 // buildIngredientsWithCosts builds ingredient list with items and costs
@@ -754,14 +928,23 @@ func (s *Server) buildIngredientsWithCosts(ctx context.Context, ingredients []gw
 	}
 
 	// Fetch items
-	items, err := s.client.GetItems(ctx, itemIDs)
+	items, err := s.client.GetItems(ctx, itemIDs, s.interactive...)
 	if err != nil {
 		return results
 	}
 
+	// Unknown items are left out of the results, so match them up by ID
+	byID := make(map[int]*gw2api.Item, len(items))
+	for _, item := range items {
+		byID[item.ID] = item
+	}
+
 	// Build results with prices
 	for i, ing := range ingredients {
-		item := items[i]
+		item := byID[ing.ItemID]
+		if item == nil {
+			item = &gw2api.Item{ID: ing.ItemID}
+		}
 		price, hasPrice := s.getItemPrice(ctx, ing.ItemID)
 		
 		cost := 0
@@ -771,7 +954,7 @@ func (s *Server) buildIngredientsWithCosts(ctx context.Context, ingredients []gw
 
 		results[i] = &IngredientWithItem{
 			RecipeIngredient: &ing,
-			Item:             item,
+			Item:             newItemView(item),
 			Price:            price,
 			HasPrice:         hasPrice,
 			Cost:             cost,
@@ -783,17 +966,19 @@ func (s *Server) buildIngredientsWithCosts(ctx context.Context, ingredients []gw
 
 // CharacterWithDetails represents a character with core information
 type CharacterWithDetails struct {
-	Name       string
-	Level      int
-	Profession string
-	Race       string
-	Guild      string
+	Name         string
+	Level        int
+	Profession   string
+	Race         string
+	Guild        string
+	Created      time.Time
+	LastModified time.Time
 }
 
 // handleInventoryPage renders the inventory page with character names only
 func (s *Server) handleInventoryPage(w http.ResponseWriter, r *http.Request) {
 	// Get character names only (single API call)
-	characterNames, err := s.client.GetCharacterNames(r.Context())
+	characterNames, err := s.characterNames(r.Context())
 	if err != nil {
 		// If API fails, show page with error message
 		data := struct {
@@ -830,7 +1015,7 @@ func (s *Server) handleInventoryPage(w http.ResponseWriter, r *http.Request) {
 
 // handleCharacters returns character list as HTMX response
 func (s *Server) handleCharacters(w http.ResponseWriter, r *http.Request) {
-	characterNames, err := s.client.GetCharacterNames(r.Context())
+	characterNames, err := s.characterNames(r.Context())
 	if err != nil {
 		http.Error(w, "Failed to fetch characters: "+err.Error(), http.StatusInternalServerError)
 		return
@@ -858,6 +1043,36 @@ type InventoryItem struct {
 	SlotIndex int
 }
 
+// itemLookupTimeout bounds how long a storage page waits for item details.
+// Items still missing then are shown as unknown rather than failing the page.
+const itemLookupTimeout = 15 * time.Second
+
+// resolveSlots joins storage slots with their items. The warning says how many
+// items could not be loaded, or is empty when all were.
+func (s *Server) resolveSlots(ctx context.Context, slots []gw2api.SlotRef) ([]InventoryItem, string) {
+	ctx, cancel := context.WithTimeout(ctx, itemLookupTimeout)
+	defer cancel()
+
+	resolved, err := gw2api.ResolveSlots(ctx, s.client, slots)
+	items := make([]InventoryItem, len(resolved))
+	for i, slot := range resolved {
+		items[i] = InventoryItem{
+			Item:      slot.Item,
+			Count:     slot.Count,
+			Binding:   slot.Binding,
+			BoundTo:   slot.BoundTo,
+			BagIndex:  slot.Container,
+			SlotIndex: slot.Index,
+		}
+	}
+
+	var unresolved *gw2api.UnresolvedItemsError
+	if errors.As(err, &unresolved) {
+		return items, fmt.Sprintf("Details for %d items could not be loaded; they are shown as unknown items.", len(unresolved.IDs))
+	}
+	return items, ""
+}
+
 // handleCharacterInventory renders character details and inventory page  
 func (s *Server) handleCharacterInventory(w http.ResponseWriter, r *http.Request) {
 	characterName := r.PathValue("character")
@@ -869,63 +1084,16 @@ func (s *Server) handleCharacterInventory(w http.ResponseWriter, r *http.Request
 	// Get character inventory
 	inventory, err := s.client.GetCharacterInventory(r.Context(), characterName)
 	if err != nil {
-		http.Error(w, "Failed to fetch character inventory: "+err.Error(), http.StatusInternalServerError)
+		s.renderCharacterError(w, err, characterName)
 		return
 	}
 	
-	// Collect all unique item IDs for batch fetching
-	var itemIDs []int
-	itemIDSet := make(map[int]bool)
-	
-	for _, bag := range inventory.Bags {
-		for _, slot := range bag.Inventory {
-			if slot.ID != 0 && !itemIDSet[slot.ID] {
-				itemIDs = append(itemIDs, slot.ID)
-				itemIDSet[slot.ID] = true
-			}
-		}
-	}
-	
-	// Fetch item details in batch
-	var itemDetails []*gw2api.Item
-	if len(itemIDs) > 0 {
-		itemDetails, err = s.client.GetItems(r.Context(), itemIDs)
-		if err != nil {
-			http.Error(w, "Failed to fetch item details: "+err.Error(), http.StatusInternalServerError)
-			return
-		}
-	}
-	
-	// Create item lookup map
-	itemMap := make(map[int]*gw2api.Item)
-	for _, item := range itemDetails {
-		itemMap[item.ID] = item
-	}
-	
-	// Build inventory items list
-	var inventoryItems []InventoryItem
-	
-	for bagIndex, bag := range inventory.Bags {
-		for slotIndex, slot := range bag.Inventory {
-			if slot.ID != 0 {
-				if item, found := itemMap[slot.ID]; found {
-					inventoryItems = append(inventoryItems, InventoryItem{
-						Item:      item,
-						Count:     slot.Count,
-						Binding:   slot.Binding,
-						BoundTo:   slot.BoundTo,
-						BagIndex:  bagIndex,
-						SlotIndex: slotIndex,
-					})
-				}
-			}
-		}
-	}
+	inventoryItems, warning := s.resolveSlots(r.Context(), gw2api.CharacterInventorySlotRefs(inventory))
 	
 	// Get character details
 	core, err := s.client.GetCharacterCore(r.Context(), characterName)
 	if err != nil {
-		http.Error(w, "Failed to fetch character details: "+err.Error(), http.StatusInternalServerError)
+		s.renderCharacterError(w, err, characterName)
 		return
 	}
 
@@ -934,16 +1102,20 @@ func (s *Server) handleCharacterInventory(w http.ResponseWriter, r *http.Request
 		PageData
 		Character CharacterWithDetails
 		Items     []InventoryItem
+		Warning   string
 	}{
 		PageData: PageData{Title: characterName + " - Character Details"},
 		Character: CharacterWithDetails{
-			Name:       core.Name,
-			Level:      core.Level,
-			Profession: core.Profession,
-			Race:       core.Race,
-			Guild:      core.Guild,
+			Name:         core.Name,
+			Level:        core.Level,
+			Profession:   core.Profession,
+			Race:         core.Race,
+			Guild:        core.Guild,
+			Created:      core.Created,
+			LastModified: core.LastModified,
 		},
-		Items: inventoryItems,
+		Items:   inventoryItems,
+		Warning: warning,
 	}
 	
 	if err := s.templates.Render(w, "character_detail", data); err != nil {
@@ -967,7 +1139,7 @@ func (s *Server) handleAccountPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Get character names
-	characterNames, err := s.client.GetCharacterNames(r.Context())
+	characterNames, err := s.characterNames(r.Context())
 	if err != nil {
 		data := PageData{
 			Title:   "My Account",
@@ -994,6 +1166,94 @@ func (s *Server) handleAccountPage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// NearlyDoneData is the account page's nearly done achievements card
+type NearlyDoneData struct {
+	Achievements []gw2api.NearlyDoneAchievement
+	Error        string
+}
+
+// handleNearlyDoneAchievements renders the achievements closest to completion.
+// The card loads after the account page, so errors are shown inside it rather
+// than as an error page.
+func (s *Server) handleNearlyDoneAchievements(w http.ResponseWriter, r *http.Request) {
+	var data NearlyDoneData
+	if s.client == nil {
+		data.Error = "API key not configured"
+	} else {
+		achievements, err := s.client.GetNearlyCompleteAchievements(r.Context(), 10)
+		switch {
+		case errors.Is(err, gw2api.ErrMissingScope):
+			data.Error = "Achievement progress needs an API key with the 'progression' scope."
+		case err != nil:
+			data.Error = "Failed to load achievements: " + err.Error()
+		default:
+			data.Achievements = achievements
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "nearly_done", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// missingDyesLimit is how many of the cheapest missing dyes the account page lists
+const missingDyesLimit = 25
+
+// MissingDyesData is the account page's missing dyes card
+type MissingDyesData struct {
+	Dyes     *gw2api.MissingDyes
+	Shown    []gw2api.MissingDye // The cheapest of Dyes.Ranked
+	Rarity   string
+	MaxPrice string
+	Rarities []string
+	Error    string
+}
+
+// handleMissingDyes renders the missing dyes, cheapest on the trading post
+// first, optionally limited to a rarity and a maximum price. Like the nearly
+// done card, errors are shown inside the card.
+func (s *Server) handleMissingDyes(w http.ResponseWriter, r *http.Request) {
+	data := MissingDyesData{
+		Rarity:   r.URL.Query().Get("rarity"),
+		MaxPrice: r.URL.Query().Get("max_price"),
+		Rarities: gw2api.DyeRarities,
+	}
+	var maxPrice gw2api.Coins
+	if data.MaxPrice != "" {
+		var err error
+		if maxPrice, err = gw2api.ParseCoins(data.MaxPrice); err != nil {
+			data.Error = "Invalid maximum price: " + err.Error()
+		}
+	}
+
+	switch {
+	case data.Error != "":
+	case s.client == nil:
+		data.Error = "API key not configured"
+	default:
+		missing, err := s.client.GetMissingDyesRanked(r.Context())
+		switch {
+		case errors.Is(err, gw2api.ErrMissingScope):
+			data.Error = "Dye unlocks need an API key with the 'unlocks' scope."
+		case err != nil:
+			data.Error = "Failed to load dyes: " + err.Error()
+		default:
+			var rarities []string
+			if data.Rarity != "" {
+				rarities = []string{data.Rarity}
+			}
+			data.Dyes = missing.Filter(rarities, maxPrice)
+			data.Shown = data.Dyes.Ranked[:min(len(data.Dyes.Ranked), missingDyesLimit)]
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "missing_dyes", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // handleBankPage shows account bank
 func (s *Server) handleBankPage(w http.ResponseWriter, r *http.Request) {
 	if s.client == nil {
@@ -1022,35 +1282,13 @@ func (s *Server) handleBankPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Convert to inventory items and get item details
-	var inventoryItems []InventoryItem
-	var itemIDs []int
-	
-	for slot, bankItem := range bankItems {
-		if bankItem.ID != 0 {
-			itemIDs = append(itemIDs, bankItem.ID)
-			inventoryItems = append(inventoryItems, InventoryItem{
-				Count:     bankItem.Count,
-				BagIndex:  0,
-				SlotIndex: slot,
-			})
-		}
-	}
-
-	// Get item details
-	if len(itemIDs) > 0 {
-		items, err := s.client.GetItems(r.Context(), itemIDs)
-		if err == nil {
-			for i, item := range items {
-				inventoryItems[i].Item = item
-			}
-		}
-	}
+	inventoryItems, warning := s.resolveSlots(r.Context(), gw2api.BankSlotRefs(bankItems))
 
 	data := PageData{
 		Title: "Bank",
 		Content: map[string]interface{}{
-			"Items": inventoryItems,
+			"Items":   inventoryItems,
+			"Warning": warning,
 		},
 	}
 
@@ -1061,6 +1299,47 @@ func (s *Server) handleBankPage(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GuildTreasuryPageData is the data for the guild treasury page
+type GuildTreasuryPageData struct {
+	GuildID   string
+	GuildName string // Empty when the guild details could not be fetched
+	Treasury  *gw2api.GuildTreasuryDetail
+}
+
+// handleGuildTreasuryPage shows what a guild's treasury holds against what its upgrades need
+func (s *Server) handleGuildTreasuryPage(w http.ResponseWriter, r *http.Request) {
+	guildID := r.PathValue("id")
+
+	treasury, err := s.client.GetGuildTreasuryDetailed(r.Context(), guildID)
+	var leaderErr *gw2api.GuildLeaderError
+	switch {
+	case errors.As(err, &leaderErr):
+		s.renderError(w, http.StatusForbidden, "Guild leader required", "Only the guild leader's API key can read the treasury of guild "+guildID+".")
+		return
+	case errors.Is(err, gw2api.ErrMissingScope):
+		s.renderError(w, http.StatusForbidden, "Missing API key permission", "Reading a guild treasury needs an API key with the 'guilds' scope.")
+		return
+	case err != nil:
+		s.renderLookupError(w, err, "Guild not found", "There is no guild with ID "+guildID+".")
+		return
+	}
+
+	data := GuildTreasuryPageData{GuildID: guildID, Treasury: treasury}
+	if guild, err := s.client.GetGuild(r.Context(), guildID); err == nil {
+		data.GuildName = guild.Name
+	}
+
+	title := "Guild Treasury"
+	if data.GuildName != "" {
+		title = data.GuildName + " - Guild Treasury"
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "guild_treasury", PageData{Title: title, Content: data}); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 // handleSharedInventoryPage shows shared inventory slots
 func (s *Server) handleSharedInventoryPage(w http.ResponseWriter, r *http.Request) {
 	if s.client == nil {
@@ -1089,37 +1368,13 @@ func (s *Server) handleSharedInventoryPage(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Convert to inventory items and get item details
-	var inventoryItems []InventoryItem
-	var itemIDs []int
-	
-	for slot, sharedItem := range sharedItems {
-		if sharedItem.ID != 0 {
-			itemIDs = append(itemIDs, sharedItem.ID)
-			inventoryItems = append(inventoryItems, InventoryItem{
-				Count:     sharedItem.Count,
-				Binding:   sharedItem.Binding,
-				BoundTo:   sharedItem.BoundTo,
-				BagIndex:  0,
-				SlotIndex: slot,
-			})
-		}
-	}
-
-	// Get item details
-	if len(itemIDs) > 0 {
-		items, err := s.client.GetItems(r.Context(), itemIDs)
-		if err == nil {
-			for i, item := range items {
-				inventoryItems[i].Item = item
-			}
-		}
-	}
+	inventoryItems, warning := s.resolveSlots(r.Context(), gw2api.InventorySlotRefs(sharedItems))
 
 	data := PageData{
 		Title: "Shared Inventory",
 		Content: map[string]interface{}{
-			"Items": inventoryItems,
+			"Items":   inventoryItems,
+			"Warning": warning,
 		},
 	}
 
@@ -1131,7 +1386,7 @@ func (s *Server) handleSharedInventoryPage(w http.ResponseWriter, r *http.Reques
 }
 
 // buildCraftingTree builds a crafting dependency tree with optimized batched requests
-func (s *Server) buildCraftingTree(ctx context.Context, recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int) *CraftingTreeData {
+func (s *Server) buildCraftingTree(ctx context.Context, recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int, pins CraftingPins) *CraftingTreeData {
 	cache := NewRequestCache()
 	const maxDepth = 8 // Allow deeper recursion to reach base materials
 	
@@ -1140,17 +1395,45 @@ func (s *Server) buildCraftingTree(ctx context.Context, recipe *gw2api.RecipeDet
 	requiredRecipes := make(map[int]bool) 
 	requiredPrices := make(map[int]bool)
 	
-	s.collectRequiredData(recipe, item, quantity, 0, maxDepth, requiredItems, requiredRecipes, requiredPrices, make(map[int]bool))
+	s.collectRequiredData(ctx, cache, recipe, item, quantity, 0, maxDepth, pins, requiredItems, requiredRecipes, requiredPrices, make(map[int]bool))
 	
 	// Phase 2: Batch fetch all required data
 	s.batchFetchData(ctx, cache, requiredItems, requiredRecipes, requiredPrices)
 	
 	// Phase 3: Build the complete tree from cached data
-	rootNode := s.buildOptimizedCraftingNode(cache, recipe, item, quantity, 0, maxDepth, make(map[int]bool))
+	return s.summarizeCraftingTree(ctx, cache, recipe, item, quantity, maxDepth, pins)
+}
+
+// summarizeCraftingTree builds the tree from cached data and totals its costs and base materials
+func (s *Server) summarizeCraftingTree(ctx context.Context, cache *RequestCache, recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int, maxDepth int, pins CraftingPins) *CraftingTreeData {
+	rootNode := s.buildOptimizedCraftingNode(ctx, cache, recipe, item, quantity, 0, maxDepth, pins, make(map[int]bool))
 	
 	// Collect base materials
 	baseMaterials := make(map[int]*MaterialSummary)
 	s.collectBaseMaterials(rootNode, baseMaterials)
+
+	// Collect what has to be crafted or gathered by the account
+	mustCraft := make(map[int]*MustCraftSummary)
+	collectMustCraft(rootNode, mustCraft)
+	if !rootNode.TimeGated {
+		// The item being crafted is only listed for its daily limit
+		delete(mustCraft, item.ID)
+	}
+	mustCraftList := make([]*MustCraftSummary, 0, len(mustCraft))
+	estimatedDays := 0
+	for _, summary := range mustCraft {
+		if summary.TimeGated {
+			summary.Days = gw2api.TimeGate{PerDay: summary.PerDay}.Days(summary.TotalRequired)
+			estimatedDays = max(estimatedDays, summary.Days)
+		}
+		mustCraftList = append(mustCraftList, summary)
+	}
+	slices.SortFunc(mustCraftList, func(a, b *MustCraftSummary) int {
+		if a.Days != b.Days {
+			return b.Days - a.Days
+		}
+		return strings.Compare(a.Item.Name, b.Item.Name)
+	})
 	
 	// Convert map to slice
 	materialsList := make([]*MaterialSummary, 0, len(baseMaterials))
@@ -1187,15 +1470,22 @@ func (s *Server) buildCraftingTree(ctx context.Context, recipe *gw2api.RecipeDet
 		SavingsPercent:    savingsPercent,
 		ExtraCost:         extraCost,
 		IsCraftingCheaper: isCraftingCheaper,
+		Pins:              pins,
+		MustCraft:         mustCraftList,
+		EstimatedDays:     estimatedDays,
 	}
 }
 
 // Legacy buildCraftingNode - replaced with optimized version
 // func (s *Server) buildCraftingNode(...) - REMOVED for performance
 
-// collectBaseMaterials recursively collects all base materials needed
+// collectBaseMaterials recursively collects all base materials needed. Ones
+// that can't be bought are left to collectMustCraft.
 func (s *Server) collectBaseMaterials(node *CraftingNode, materials map[int]*MaterialSummary) {
-	if !node.HasRecipe || !node.CanCraft {
+	if !node.Crafts() {
+		if !node.Purchasable {
+			return
+		}
 		// This is a base material
 		if existing, exists := materials[node.Item.ID]; exists {
 			existing.TotalRequired += node.RequiredCount
@@ -1217,9 +1507,75 @@ func (s *Server) collectBaseMaterials(node *CraftingNode, materials map[int]*Mat
 	}
 }
 
+// collectMustCraft recursively collects the nodes that can't be bought and the
+// time-gated ones that are crafted, totalling the count of each item
+func collectMustCraft(node *CraftingNode, summaries map[int]*MustCraftSummary) {
+	if !node.Purchasable || (node.TimeGated && node.Crafts()) {
+		if summary, found := summaries[node.Item.ID]; found {
+			summary.TotalRequired += node.RequiredCount
+		} else {
+			summaries[node.Item.ID] = &MustCraftSummary{
+				Item:          node.Item,
+				TotalRequired: node.RequiredCount,
+				HasRecipe:     node.HasRecipe,
+				TimeGated:     node.TimeGated,
+				PerDay:        node.PerDay,
+			}
+		}
+	}
+	if !node.Crafts() {
+		return
+	}
+	for _, child := range node.Children {
+		collectMustCraft(child, summaries)
+	}
+}
+
+// recipesForOutput returns the IDs of recipes that create an item, memoized in the request cache.
+// An item whose recipes can't be searched for is treated as having none.
+func (s *Server) recipesForOutput(ctx context.Context, cache *RequestCache, itemID int) []int {
+	if recipeIDs, found := cache.outputRecipes[itemID]; found {
+		return recipeIDs
+	}
+	
+	recipeIDs, err := s.client.SearchRecipesByOutput(ctx, itemID, s.interactive...)
+	if err != nil {
+		recipeIDs = nil
+	}
+	recipeIDs = s.filterRecipeIDs(recipeIDs)
+	cache.outputRecipes[itemID] = recipeIDs
+	return recipeIDs
+}
+
+// recipeByID returns a recipe from the request cache, fetching it into the cache if it's missing
+func (s *Server) recipeByID(ctx context.Context, cache *RequestCache, recipeID int) *gw2api.RecipeDetail {
+	if recipe, found := cache.recipes[recipeID]; found {
+		return recipe
+	}
+	recipes, err := s.client.GetRecipes(ctx, []int{recipeID}, s.interactive...)
+	if err != nil || len(recipes) == 0 {
+		return nil
+	}
+	cache.recipes[recipeID] = recipes[0]
+	return recipes[0]
+}
+
+// pinnedRecipe returns the user's choice for an ingredient: a recipe ID from
+// recipeIDs, PinBuy, or 0 when the ingredient has no usable pin
+func pinnedRecipe(pins CraftingPins, itemID int, recipeIDs []int) int {
+	choice, found := pins[itemID]
+	if !found || len(recipeIDs) == 0 {
+		return 0
+	}
+	if choice == PinBuy || slices.Contains(recipeIDs, choice) {
+		return choice
+	}
+	return 0
+}
+
 // collectRequiredData performs first pass to collect all IDs needed for the tree
-func (s *Server) collectRequiredData(recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int, level int, maxDepth int, 
-	requiredItems, requiredRecipes, requiredPrices map[int]bool, visited map[int]bool) {
+func (s *Server) collectRequiredData(ctx context.Context, cache *RequestCache, recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int, level int, maxDepth int, 
+	pins CraftingPins, requiredItems, requiredRecipes, requiredPrices map[int]bool, visited map[int]bool) {
 	
 	// Prevent infinite recursion and respect depth limits
 	if visited[item.ID] || level >= maxDepth {
@@ -1247,19 +1603,21 @@ func (s *Server) collectRequiredData(recipe *gw2api.RecipeDetail, item *gw2api.I
 		requiredItems[ingredient.ItemID] = true
 		requiredPrices[ingredient.ItemID] = true
 		
-		// Look for ALL recipes that create this ingredient (using cache first)
-		if s.client.DataCache() != nil && s.client.DataCache().GetRecipeCache().IsLoaded() {
-			recipeIDs := s.client.DataCache().GetRecipeCache().SearchByOutput(ingredient.ItemID)
-			for _, recipeID := range recipeIDs {
-				// Collect ALL recipes for this ingredient
-				requiredRecipes[recipeID] = true
-				if cachedRecipe, found := s.client.DataCache().GetRecipeCache().GetByID(recipeID); found && cachedRecipe != nil {
-					// Recursively collect for each recipe found (use first one for dependency collection to avoid exponential explosion)
-					if len(recipeIDs) == 1 || recipeID == recipeIDs[0] {
-						s.collectRequiredData(cachedRecipe, &gw2api.Item{ID: ingredient.ItemID}, 
-							ingredient.Count*quantity, level+1, maxDepth, requiredItems, requiredRecipes, requiredPrices, visited)
-					}
-				}
+		// Look for ALL recipes that create this ingredient so the tree can offer them as alternatives
+		recipeIDs := s.recipesForOutput(ctx, cache, ingredient.ItemID)
+		for _, recipeID := range recipeIDs {
+			requiredRecipes[recipeID] = true
+		}
+		
+		// Recurse into a single recipe to avoid exponential explosion: the pinned one, else the first
+		followID := pinnedRecipe(pins, ingredient.ItemID, recipeIDs)
+		if followID == 0 && len(recipeIDs) > 0 {
+			followID = recipeIDs[0]
+		}
+		if followID > 0 {
+			if followRecipe := s.recipeByID(ctx, cache, followID); followRecipe != nil {
+				s.collectRequiredData(ctx, cache, followRecipe, &gw2api.Item{ID: ingredient.ItemID}, 
+					ingredient.Count*quantity, level+1, maxDepth, pins, requiredItems, requiredRecipes, requiredPrices, visited)
 			}
 		}
 	}
@@ -1285,7 +1643,7 @@ func (s *Server) batchFetchData(ctx context.Context, cache *RequestCache, requir
 	
 	// Batch fetch items
 	if len(itemIDs) > 0 {
-		if items, err := s.client.GetItems(ctx, itemIDs); err == nil {
+		if items, err := s.client.GetItems(ctx, itemIDs, s.interactive...); err == nil {
 			for _, item := range items {
 				cache.items[item.ID] = item
 			}
@@ -1294,7 +1652,7 @@ func (s *Server) batchFetchData(ctx context.Context, cache *RequestCache, requir
 	
 	// Batch fetch recipes
 	if len(recipeIDs) > 0 {
-		if recipes, err := s.client.GetRecipes(ctx, recipeIDs); err == nil {
+		if recipes, err := s.client.GetRecipes(ctx, recipeIDs, s.interactive...); err == nil {
 			for _, recipe := range recipes {
 				cache.recipes[recipe.ID] = recipe
 			}
@@ -1311,27 +1669,40 @@ func (s *Server) batchFetchData(ctx context.Context, cache *RequestCache, requir
 			}
 			
 			chunk := priceIDs[i:end]
-			if prices, err := s.client.GetCommercePrices(ctx, chunk); err == nil {
+			if prices, err := s.client.GetCommercePrices(ctx, chunk, s.interactive...); err == nil {
 				for _, price := range prices {
 					cache.prices[price.ID] = price
 				}
 			}
 		}
 	}
+
+	// Order books for depth pricing, which only matters for items we have a price for
+	if s.depthThreshold > 0 {
+		var listingIDs []int
+		for _, id := range priceIDs {
+			if _, found := cache.listings[id]; !found && cache.prices[id] != nil {
+				listingIDs = append(listingIDs, id)
+			}
+		}
+		for chunk := range slices.Chunk(listingIDs, 200) {
+			if listings, err := s.client.GetCommerceListings(ctx, chunk, s.interactive...); err == nil {
+				for _, listing := range listings {
+					cache.listings[listing.ID] = listing
+				}
+			}
+		}
+	}
 }
 
 // buildOptimizedCraftingNode builds nodes using cached data (no API calls)
-func (s *Server) buildOptimizedCraftingNode(cache *RequestCache, recipe *gw2api.RecipeDetail, item *gw2api.Item, 
-	quantity int, level int, maxDepth int, visited map[int]bool) *CraftingNode {
+func (s *Server) buildOptimizedCraftingNode(ctx context.Context, cache *RequestCache, recipe *gw2api.RecipeDetail, item *gw2api.Item, 
+	quantity int, level int, maxDepth int, pins CraftingPins, visited map[int]bool) *CraftingNode {
 	
 	// Prevent infinite recursion and respect depth limits
 	if visited[item.ID] || level >= maxDepth {
-		price := cache.prices[item.ID]
-		unitCost := 0
-		if price != nil {
-			unitCost = price.Sells.UnitPrice
-		}
-		return &CraftingNode{
+		unitCost, depthPriced := s.buyPrice(cache, item.ID, quantity)
+		node := &CraftingNode{
 			Item:          item,
 			Recipe:        nil,
 			RequiredCount: quantity,
@@ -1343,7 +1714,10 @@ func (s *Server) buildOptimizedCraftingNode(cache *RequestCache, recipe *gw2api.
 			TotalBuyCost:  quantity * unitCost,
 			Children:      []*CraftingNode{},
 			Level:         level,
+			DepthPriced:   depthPriced,
 		}
+		s.annotateCraftingNode(node)
+		return node
 	}
 	
 	visited[item.ID] = true
@@ -1358,13 +1732,11 @@ func (s *Server) buildOptimizedCraftingNode(cache *RequestCache, recipe *gw2api.
 	}
 	
 	// Get cached market price
-	price := cache.prices[item.ID]
-	buyPrice := 0
-	if price != nil {
-		buyPrice = price.Sells.UnitPrice
-	}
+	buyPrice, depthPriced := s.buyPrice(cache, item.ID, quantity)
 	node.BuyPrice = buyPrice
+	node.DepthPriced = depthPriced
 	node.TotalBuyCost = quantity * buyPrice
+	s.annotateCraftingNode(node)
 	
 	if recipe == nil {
 		// No recipe - must buy
@@ -1388,40 +1760,42 @@ func (s *Server) buildOptimizedCraftingNode(cache *RequestCache, recipe *gw2api.
 		
 		requiredCount := ingredient.Count * quantity
 		
-		// Find best recipe for this ingredient (cheapest to craft)
+		// Use the user's pinned choice, otherwise the cheapest recipe to craft
+		recipeIDs := s.recipesForOutput(ctx, cache, ingredient.ItemID)
+		alternatives := summarizeRecipes(cache, recipeIDs)
+		choice := pinnedRecipe(pins, ingredient.ItemID, recipeIDs)
+		
 		var ingredientRecipe *gw2api.RecipeDetail
-		if s.client.DataCache() != nil && s.client.DataCache().GetRecipeCache().IsLoaded() {
-			recipeIDs := s.client.DataCache().GetRecipeCache().SearchByOutput(ingredient.ItemID)
-			var bestRecipe *gw2api.RecipeDetail
-			var bestCost int = -1
-			
-			for _, recipeID := range recipeIDs {
-				if candidateRecipe := cache.recipes[recipeID]; candidateRecipe != nil {
-					// Quick cost estimate for this recipe
-					recipeCost := 0
-					for _, subIngredient := range candidateRecipe.Ingredients {
-						if subPrice := cache.prices[subIngredient.ItemID]; subPrice != nil {
-							recipeCost += subIngredient.Count * subPrice.Sells.UnitPrice
-						}
-					}
-					
-					// Choose the cheapest recipe, or first one if costs are equal
-					if bestCost == -1 || recipeCost < bestCost {
-						bestRecipe = candidateRecipe
-						bestCost = recipeCost
-					}
+		switch {
+		case choice == PinBuy:
+			// Bought, so there is no subtree
+		case choice > 0:
+			ingredientRecipe = cache.recipes[choice]
+		default:
+			var bestRecipe *RecipeSummary
+			for _, candidate := range alternatives {
+				// Choose the cheapest recipe, or first one if costs are equal
+				if bestRecipe == nil || candidate.Cost < bestRecipe.Cost {
+					bestRecipe = candidate
 				}
 			}
-			ingredientRecipe = bestRecipe
+			if bestRecipe != nil {
+				ingredientRecipe = cache.recipes[bestRecipe.ID]
+			}
 		}
 		
 		// Recursively build child node
-		childNode := s.buildOptimizedCraftingNode(cache, ingredientRecipe, ingredientItem, requiredCount, level+1, maxDepth, visited)
+		childNode := s.buildOptimizedCraftingNode(ctx, cache, ingredientRecipe, ingredientItem, requiredCount, level+1, maxDepth, pins, visited)
+		childNode.Pinned = choice == PinBuy || (choice > 0 && ingredientRecipe != nil)
+		childNode.AlternativeRecipes = alternatives
+		for _, alternative := range alternatives {
+			alternative.Selected = ingredientRecipe != nil && alternative.ID == ingredientRecipe.ID
+		}
 		node.Children = append(node.Children, childNode)
 		
-		// For cost calculation, use the cheaper option (buy vs craft)
+		// For cost calculation, use the cheaper option (buy vs craft) unless the user pinned the recipe
 		childCostForTotal := childNode.TotalCost
-		if childNode.HasRecipe && !childNode.CanCraft && childNode.BuyPrice > 0 {
+		if childNode.HasRecipe && !childNode.Crafts() && childNode.BuyPrice > 0 {
 			// If buying is cheaper, use buy cost for the total
 			childCostForTotal = requiredCount * childNode.BuyPrice
 		}
@@ -1434,8 +1808,9 @@ func (s *Server) buildOptimizedCraftingNode(cache *RequestCache, recipe *gw2api.
 		craftCostPerItem = totalCraftCost / quantity
 	}
 	
-	// Determine cost effectiveness (with 10% margin for cost stability)
-	isCraftingCheaper := totalCraftCost < int(float64(quantity*buyPrice)*0.9) || buyPrice == 0
+	// Determine cost effectiveness (with 10% margin for cost stability).
+	// Items that can't be bought have nothing to compare against.
+	isCraftingCheaper := totalCraftCost < int(float64(quantity*buyPrice)*0.9) || buyPrice == 0 || !node.Purchasable
 	
 	// Always set crafting costs, but flag whether it's economical
 	node.CanCraft = isCraftingCheaper
@@ -1445,8 +1820,73 @@ func (s *Server) buildOptimizedCraftingNode(cache *RequestCache, recipe *gw2api.
 	return node
 }
 
+// annotateCraftingNode marks whether the node's item can be bought and whether
+// crafting it is time-gated. Account bound items never have a price, so they
+// are the ones that can't be bought.
+func (s *Server) annotateCraftingNode(node *CraftingNode) {
+	node.Purchasable = node.BuyPrice > 0 || node.Item.IsTradable()
+	if gate, found := s.timeGates[node.Item.ID]; found {
+		node.TimeGated = true
+		node.PerDay = gate.PerDay
+	}
+}
+
+// buyPrice is the unit price of buying quantity of an item. It is the best sell
+// listing, unless depth pricing is on and filling the whole order from the book
+// averages more than the threshold above it; then the average is returned and
+// depthPriced is true.
+func (s *Server) buyPrice(cache *RequestCache, itemID int, quantity int) (price int, depthPriced bool) {
+	if cached := cache.prices[itemID]; cached != nil {
+		price = cached.Sells.UnitPrice
+	}
+	listing := cache.listings[itemID]
+	if s.depthThreshold <= 0 || listing == nil || price == 0 || quantity <= 1 {
+		return price, false
+	}
+
+	fill := gw2api.EstimateAcquisitionCost(listing, quantity)
+	if fill.Filled == 0 {
+		return price, false
+	}
+	// Round up so the total is never understated
+	average := int(math.Ceil(fill.AveragePrice()))
+	if float64(average-price) <= s.depthThreshold*float64(price) {
+		return price, false
+	}
+	return average, true
+}
+
+// summarizeRecipes describes the fetched recipes among recipeIDs, costing each
+// by the trading post price of its direct ingredients
+func summarizeRecipes(cache *RequestCache, recipeIDs []int) []*RecipeSummary {
+	var summaries []*RecipeSummary
+	for _, recipeID := range recipeIDs {
+		recipe := cache.recipes[recipeID]
+		if recipe == nil {
+			continue
+		}
+		
+		cost := 0
+		for _, subIngredient := range recipe.Ingredients {
+			if subPrice := cache.prices[subIngredient.ItemID]; subPrice != nil {
+				cost += subIngredient.Count * subPrice.Sells.UnitPrice
+			}
+		}
+		summaries = append(summaries, &RecipeSummary{
+			ID:          recipe.ID,
+			Type:        recipe.Type,
+			Disciplines: recipe.Disciplines,
+			MinRating:   recipe.MinRating,
+			OutputCount: recipe.OutputItemCount,
+			Source:      recipe.Source,
+			Cost:        cost,
+		})
+	}
+	return summaries
+}
+
 // buildSingleCraftingNode builds a single node with limited depth for HTMX loading
-func (s *Server) buildSingleCraftingNode(ctx context.Context, cache *RequestCache, recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int, level int, maxDepth int) *CraftingNode {
+func (s *Server) buildSingleCraftingNode(ctx context.Context, cache *RequestCache, recipe *gw2api.RecipeDetail, item *gw2api.Item, quantity int, level int, maxDepth int, pins CraftingPins) *CraftingNode {
 	// Collect required data for this single node
 	requiredItems := map[int]bool{item.ID: true}
 	requiredRecipes := make(map[int]bool)
@@ -1459,11 +1899,8 @@ func (s *Server) buildSingleCraftingNode(ctx context.Context, cache *RequestCach
 			requiredPrices[ingredient.ItemID] = true
 
 			// Always collect child recipe data so we know if items are craftable
-			if s.client.DataCache() != nil && s.client.DataCache().GetRecipeCache().IsLoaded() {
-				recipeIDs := s.client.DataCache().GetRecipeCache().SearchByOutput(ingredient.ItemID)
-				for _, recipeID := range recipeIDs {
-					requiredRecipes[recipeID] = true
-				}
+			for _, recipeID := range s.recipesForOutput(ctx, cache, ingredient.ItemID) {
+				requiredRecipes[recipeID] = true
 			}
 		}
 	}
@@ -1472,5 +1909,5 @@ func (s *Server) buildSingleCraftingNode(ctx context.Context, cache *RequestCach
 	s.batchFetchData(ctx, cache, requiredItems, requiredRecipes, requiredPrices)
 
 	// Build the node using the cached data
-	return s.buildOptimizedCraftingNode(cache, recipe, item, quantity, level, level+maxDepth, make(map[int]bool))
+	return s.buildOptimizedCraftingNode(ctx, cache, recipe, item, quantity, level, level+maxDepth, pins, make(map[int]bool))
 }
\ No newline at end of file
@@ -0,0 +1,782 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/exchangehistory"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// newTestServer returns a web server whose client talks to a fake API
+// answering every request with the given status and body
+func newTestServer(t *testing.T, status int, body string, options ...ServerOption) *Server {
+	t.Helper()
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(
+		gw2api.WithBaseURL(upstream.URL),
+		gw2api.WithRetries(0),
+		gw2api.WithRateLimit(1000),
+	)
+	server, err := NewServer(client, cache.NewLRUCache(100), options...)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return server
+}
+
+func TestErrorPages(t *testing.T) {
+	notFound := `{"text":"all ids provided are invalid"}`
+	tests := []struct {
+		name           string
+		path           string
+		upstreamStatus int
+		upstreamBody   string
+		expectedStatus int
+		expectedText   string
+	}{
+		{"unknown route", "/no/such/page", http.StatusOK, `[]`, http.StatusNotFound, "Page not found"},
+		{"unknown item", "/items/99999999", http.StatusNotFound, notFound, http.StatusNotFound, "Item not found"},
+		{"unknown item detail", "/item/99999999", http.StatusNotFound, notFound, http.StatusNotFound, "Item not found"},
+		{"unknown recipe", "/recipe/99999999", http.StatusNotFound, notFound, http.StatusNotFound, "Recipe not found"},
+		{"unknown crafting tree", "/crafting/99999999", http.StatusNotFound, notFound, http.StatusNotFound, "Recipe not found"},
+		{"unknown skill", "/skills/99999999", http.StatusNotFound, notFound, http.StatusNotFound, "Skill not found"},
+		{"unknown character", "/inventory/Nobody", http.StatusNotFound, `{"text":"no such character"}`, http.StatusNotFound, "Character no longer exists"},
+		{"item during outage", "/items/19721", http.StatusServiceUnavailable, `{"text":"API not active"}`, http.StatusBadGateway, "API unavailable"},
+		{"recipe during outage", "/recipe/1", http.StatusInternalServerError, `{"text":"internal error"}`, http.StatusBadGateway, "API unavailable"},
+		{"treasury of another guild", "/guild/ABC/treasury", http.StatusForbidden, `{"text":"access restricted to guild leaders"}`, http.StatusForbidden, "Guild leader required"},
+		{"treasury without scope", "/guild/ABC/treasury", http.StatusForbidden, `{"text":"requires scope guilds"}`, http.StatusForbidden, "Missing API key permission"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newTestServer(t, tt.upstreamStatus, tt.upstreamBody)
+
+			recorder := httptest.NewRecorder()
+			server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("status = %d, expected %d", recorder.Code, tt.expectedStatus)
+			}
+			if contentType := recorder.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/html") {
+				t.Errorf("Content-Type = %q, expected text/html", contentType)
+			}
+
+			body := recorder.Body.String()
+			// The page must come from the templates, not http.Error's plain text
+			if !strings.Contains(body, "<!DOCTYPE html>") {
+				t.Error("response was not rendered through the base template")
+			}
+			if !strings.Contains(body, tt.expectedText) {
+				t.Errorf("response does not mention %q", tt.expectedText)
+			}
+			if !strings.Contains(body, `hx-post="/search/items"`) {
+				t.Error("error page has no search box")
+			}
+		})
+	}
+}
+
+func TestHomeStillServed(t *testing.T) {
+	server := newTestServer(t, http.StatusOK, `[]`)
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, expected 200", recorder.Code)
+	}
+	if strings.Contains(recorder.Body.String(), "Page not found") {
+		t.Error("home page rendered as not found")
+	}
+}
+
+func TestNearlyDoneCardWithoutScope(t *testing.T) {
+	server := newTestServer(t, http.StatusForbidden, `{"text":"requires scope progression"}`)
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/account/nearly-done", nil))
+
+	// The card is swapped in by htmx, which ignores error responses
+	if recorder.Code != http.StatusOK {
+		t.Errorf("status = %d, expected 200", recorder.Code)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, "progression") || strings.Contains(body, "<!DOCTYPE html>") {
+		t.Errorf("body = %q, expected only the card with the scope message", body)
+	}
+}
+
+func TestMissingDyesCard(t *testing.T) {
+	server := newTestServer(t, http.StatusForbidden, `{"text":"requires scope unlocks"}`)
+
+	for path, expected := range map[string]string{
+		"/account/dyes":                 "&#39;unlocks&#39; scope",
+		"/account/dyes?max_price=5x":    "Invalid maximum price",
+		"/account/dyes?rarity=Uncommon": `<option value="Uncommon" selected>`,
+	} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, expected 200", path, recorder.Code)
+		}
+		if body := recorder.Body.String(); !strings.Contains(body, expected) || strings.Contains(body, "<!DOCTYPE html>") {
+			t.Errorf("%s: body = %q, expected only the card with %q", path, body, expected)
+		}
+	}
+}
+
+func TestSkillPageFacts(t *testing.T) {
+	server := newTestServer(t, http.StatusOK, `[{
+		"id": 5491,
+		"name": "Fireball",
+		"facts": [
+			{"text": "Recharge", "type": "Recharge", "value": 1.5},
+			{"text": "Range", "type": "Range", "value": 1200}
+		],
+		"traited_facts": [
+			{"text": "Range", "type": "Range", "value": 1500, "requires_trait": 100, "overrides": 1}
+		]
+	}]`)
+
+	for path, expected := range map[string]string{
+		"/skills/5491":            "1200",
+		"/skills/5491?traits=100": "1500",
+	} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+		if recorder.Code != http.StatusOK {
+			t.Errorf("%s: status = %d, expected 200", path, recorder.Code)
+		}
+		if body := recorder.Body.String(); !strings.Contains(body, "1.5s") || !strings.Contains(body, expected) {
+			t.Errorf("%s: page does not show the recharge and a range of %s", path, expected)
+		}
+	}
+}
+
+func TestExchangePage(t *testing.T) {
+	store, err := exchangehistory.Open(filepath.Join(t.TempDir(), "exchange.csv"), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	store.Append(exchangehistory.Sample{Time: now.Add(-2 * time.Hour), CoinsPerGem: 2400, GemsPerGold: 3.5})
+	store.Append(exchangehistory.Sample{Time: now.Add(-time.Hour), CoinsPerGem: 2512, GemsPerGold: 3.25})
+
+	server, err := NewServer(gw2api.NewClient(), cache.NewLRUCache(10), WithExchangeHistory(store))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/exchange", nil))
+
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", recorder.Code)
+	}
+	body := recorder.Body.String()
+	for _, expected := range []string{"25s 12c", "24s", "3.25", "3.50", "<polyline", "(1h ago)"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("exchange page does not show %q", expected)
+		}
+	}
+}
+
+func TestWardrobePage(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/account/mounts/skins":
+			w.Write([]byte(`[1]`))
+		case r.URL.Query().Has("ids"):
+			w.Write([]byte(`[{"id": 1, "name": "Raptor Skin", "mount": "raptor", "icon": "https://render.example/1.png"}, {"id": 2, "name": "Jackal Skin", "mount": "jackal", "icon": "https://render.example/2.png"}]`))
+		default:
+			w.Write([]byte(`[1, 2]`))
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithAPIKey("key"), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/wardrobe/mount-skins", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", recorder.Code)
+	}
+	body := recorder.Body.String()
+	for _, expected := range []string{"1 of 2 unlocked", "Raptor Skin (raptor) - unlocked", "Jackal Skin (jackal) - locked", "opacity-40", `href="/wardrobe/finishers"`} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("wardrobe page does not show %q", expected)
+		}
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/wardrobe/mount-skins/grid?page=1", nil))
+	if body := recorder.Body.String(); recorder.Code != http.StatusOK || strings.Contains(body, "<!DOCTYPE html>") || !strings.Contains(body, "Nothing to show") {
+		t.Errorf("grid page past the end: status %d, body %q", recorder.Code, body)
+	}
+
+	recorder = httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/wardrobe/hats", nil))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("unknown section status = %d, expected 404", recorder.Code)
+	}
+}
+
+func TestWardrobeAchievementSources(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/v2/account/minis":
+			w.Write([]byte(`[1]`))
+		case r.URL.Query().Has("ids"):
+			w.Write([]byte(`[{"id": 1, "name": "Mini Rytlock", "item_id": 100}, {"id": 2, "name": "Mini Llama", "item_id": 200}, {"id": 3, "name": "Mini Skritt", "item_id": 300}]`))
+		default:
+			w.Write([]byte(`[1, 2, 3]`))
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	dir := t.TempDir()
+	achievements := `{"id": 7, "name": "Llama Herder", "tiers": [{"count": 1, "points": 10}], "bits": [{"type": "Minipet", "id": 2}]}
+{"id": 8, "name": "Rytlock Fan", "tiers": [{"count": 1, "points": 5}], "bits": [{"type": "Item", "id": 100}]}
+`
+	if err := os.WriteFile(filepath.Join(dir, "achievements.json"), []byte(achievements), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithAPIKey("key"), gw2api.WithRetries(0), gw2api.WithRateLimit(1000), gw2api.WithDataCache(dir))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/wardrobe/minis", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", recorder.Code)
+	}
+	body := recorder.Body.String()
+	if !strings.Contains(body, "Granted by Llama Herder (10 AP)") {
+		t.Error("locked mini does not show its granting achievement")
+	}
+	// Unlocked entries and entries without a known source don't name one
+	if strings.Contains(body, "Rytlock Fan") || strings.Count(body, "Granted by") != 1 {
+		t.Errorf("wardrobe page names unexpected sources: %s", body)
+	}
+}
+
+func TestBankPageUnknownItems(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/account/bank":
+			w.Write([]byte(`[{"id": 19976, "count": 250}, null, {"id": 123456789, "count": 1}]`))
+		case "/v2/items":
+			w.Write([]byte(`[{"id": 19976, "name": "Mystic Coin", "rarity": "Rare"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithAPIKey("key"), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/bank", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status = %d, expected 200", recorder.Code)
+	}
+	body := recorder.Body.String()
+	for _, expected := range []string{"Mystic Coin", gw2api.UnknownItemName, "/items/123456789", "Details for 1 items could not be loaded"} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("bank page does not show %q", expected)
+		}
+	}
+}
+
+func TestDeletedCharacter(t *testing.T) {
+	var deleted atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/characters":
+			if deleted.Load() {
+				w.Write([]byte(`["Aldric Stormhand"]`))
+			} else {
+				w.Write([]byte(`["Aldric Stormhand", "Deleted Alt"]`))
+			}
+		default:
+			// The API's answer for a character name it doesn't know
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "no such character"}`))
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithAPIKey("key"), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	get := func(path string) (int, string) {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder.Code, recorder.Body.String()
+	}
+
+	if _, body := get("/inventory"); !strings.Contains(body, "Deleted Alt") {
+		t.Fatal("inventory page does not list Deleted Alt")
+	}
+	deleted.Store(true)
+
+	status, body := get("/inventory/" + url.PathEscape("Deleted Alt"))
+	if status != http.StatusNotFound || !strings.Contains(body, "Character no longer exists") {
+		t.Errorf("deleted character page: status %d, expected 404 saying the character no longer exists", status)
+	}
+	// The remembered character list is dropped, so the deleted character stops being offered
+	if _, body := get("/inventory"); strings.Contains(body, "Deleted Alt") {
+		t.Error("inventory page still lists Deleted Alt")
+	}
+}
+
+func TestItemPageSalvage(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/items":
+			w.Write([]byte(`[{"id": 500, "name": "Exotic Helm", "type": "Armor", "rarity": "Exotic", "level": 80, "vendor_value": 300, "details": {"weight_class": "Heavy"}}]`))
+		case "/v2/commerce/prices":
+			var prices []string
+			for id, sell := range map[string]int{"500": 1000, "19721": 3000, "19701": 200} {
+				if strings.Contains(r.URL.Query().Get("ids"), id) {
+					prices = append(prices, fmt.Sprintf(`{"id": %s, "buys": {"unit_price": 1, "quantity": 1}, "sells": {"unit_price": %d, "quantity": 10}}`, id, sell))
+				}
+			}
+			w.Write([]byte("[" + strings.Join(prices, ",") + "]"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	// Both the item detail panel and the full item page compare the options
+	for _, path := range []string{"/item/500", "/items/500"} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("%s: status = %d, expected 200", path, recorder.Code)
+		}
+		body := recorder.Body.String()
+		// Salvaging earns 3000 + 1.5 * 200 less 495 in fees and 61 for the kit,
+		// beating 850 from listing and 300 from the vendor
+		for _, expected := range []string{"Sell or Salvage", "Tier 6 materials", "Glob of Ectoplasm", "8s 50c", "27s 44c", "Salvage <span class=\"text-green-700\">(best)</span>"} {
+			if !strings.Contains(body, expected) {
+				t.Errorf("%s does not show %q", path, expected)
+			}
+		}
+	}
+}
+
+func TestItemSearchPaging(t *testing.T) {
+	// 45 matching items make two full pages and a partial one
+	var items strings.Builder
+	for id := 1; id <= 45; id++ {
+		fmt.Fprintf(&items, `{"id": %d, "name": "Widget %02d"}`+"\n", id, id)
+	}
+	items.WriteString(`{"id": 100, "name": "Gadget"}` + "\n")
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(items.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// The fake API records the items each price request asks for
+	var pricedIDs []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("ids")
+		pricedIDs = append(pricedIDs, ids)
+		var prices []string
+		for id := range strings.SplitSeq(ids, ",") {
+			prices = append(prices, `{"id": `+id+`, "buys": {"unit_price": 90}, "sells": {"unit_price": 100}}`)
+		}
+		w.Write([]byte("[" + strings.Join(prices, ",") + "]"))
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000), gw2api.WithDataCache(dir))
+	server, err := NewServer(client, cache.NewLRUCache(1000))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	search := func(values url.Values) (int, string) {
+		r := httptest.NewRequest(http.MethodPost, "/search/items", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, r)
+		return recorder.Code, recorder.Body.String()
+	}
+
+	status, body := search(url.Values{"query": {"widget"}})
+	if status != http.StatusOK || !strings.Contains(body, "<table") || strings.Count(body, `hx-get="/item/`) != 20 {
+		t.Fatalf("first page: status %d, %d rows", status, strings.Count(body, `hx-get="/item/`))
+	}
+	if !strings.Contains(body, `id="load-more-row"`) || !strings.Contains(body, "20 of 45 shown") || !strings.Contains(body, "&#34;page&#34;:&#34;2&#34;") {
+		t.Error("first page has no load more button for page 2")
+	}
+	// Buying at 90 and selling at 100 loses 5c to the 15c of fees
+	if !strings.Contains(body, "10.0% spread, flip -5c") {
+		t.Error("first page doesn't show the spread and flip profit")
+	}
+
+	// Later pages are bare rows, priced only for the items they add
+	status, body = search(url.Values{"query": {"Widget"}, "page": {"3"}})
+	if status != http.StatusOK || strings.Contains(body, "<table") || strings.Count(body, `hx-get="/item/`) != 5 {
+		t.Fatalf("last page: status %d, %d rows", status, strings.Count(body, `hx-get="/item/`))
+	}
+	if strings.Contains(body, "load-more-row") {
+		t.Error("last page has a load more button")
+	}
+	if len(pricedIDs) != 2 || pricedIDs[1] != "41,42,43,44,45" {
+		t.Errorf("price requests = %q, expected the first page and then the 5 new items", pricedIDs)
+	}
+
+	// Both searches scanned the item cache once between them
+	if stats := server.searchResults.Stats(); stats.Misses != 1 || stats.Hits != 1 {
+		t.Errorf("search result cache has %d hits and %d misses, expected 1 of each", stats.Hits, stats.Misses)
+	}
+
+	status, body = search(url.Values{"query": {"sprocket"}})
+	if status != http.StatusOK || !strings.Contains(body, `No items found for "sprocket"`) || strings.Contains(body, "<table") {
+		t.Errorf("no results: status %d, body %s", status, body)
+	}
+
+	if status, _ := search(url.Values{"query": {"widget"}, "page": {"0"}}); status != http.StatusBadRequest {
+		t.Errorf("page 0: status %d, expected %d", status, http.StatusBadRequest)
+	}
+}
+
+func TestItemSearchSort(t *testing.T) {
+	// 25 widgets of alternating rarity, where every fifth has no price
+	var items strings.Builder
+	for id := 1; id <= 25; id++ {
+		rarity := "Fine"
+		if id%2 == 0 {
+			rarity = "Rare"
+		}
+		fmt.Fprintf(&items, `{"id": %d, "name": "Widget %02d", "level": %d, "rarity": %q}`+"\n", id, id, id*7%30, rarity)
+	}
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(items.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var pricedIDs []string
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ids := r.URL.Query().Get("ids")
+		pricedIDs = append(pricedIDs, ids)
+		var prices []string
+		for field := range strings.SplitSeq(ids, ",") {
+			id, _ := strconv.Atoi(field)
+			if id%5 != 0 {
+				prices = append(prices, fmt.Sprintf(`{"id": %d, "buys": {"unit_price": %d}, "sells": {"unit_price": %d}}`, id, 1000-id*10, id*10))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(prices, ",") + "]"))
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000), gw2api.WithDataCache(dir))
+	server, err := NewServer(client, cache.NewLRUCache(1000))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	rowPattern := regexp.MustCompile(`hx-get="/item/(\d+)"`)
+	search := func(params ...string) (int, string, []int) {
+		values := url.Values{"query": {"widget"}}
+		for i := 0; i+1 < len(params); i += 2 {
+			values.Set(params[i], params[i+1])
+		}
+		r := httptest.NewRequest(http.MethodPost, "/search/items", strings.NewReader(values.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, r)
+		var ids []int
+		for _, match := range rowPattern.FindAllStringSubmatch(recorder.Body.String(), -1) {
+			id, _ := strconv.Atoi(match[1])
+			ids = append(ids, id)
+		}
+		return recorder.Code, recorder.Body.String(), ids
+	}
+	level := func(id int) int { return id * 7 % 30 }
+
+	// Sorting by price fetches every result's price in one request, and the
+	// unpriced items come last in either order
+	_, _, first := search("sort", "sell", "order", "asc")
+	_, _, rest := search("sort", "sell", "order", "asc", "page", "2")
+	if len(pricedIDs) == 0 || strings.Count(pricedIDs[0], ",") != 24 {
+		t.Errorf("price requests = %q, expected all 25 results at once", pricedIDs)
+	}
+	if slices.Contains(first, 5) || !slices.Equal(rest, []int{5, 10, 15, 20, 25}) {
+		t.Errorf("pages = %v and %v, expected the unpriced items on the second", first, rest)
+	}
+
+	for _, test := range []struct {
+		params []string
+		first  int
+		sorted func(a, b int) bool // Whether a may come before b
+	}{
+		{[]string{"sort", "name"}, 1, func(a, b int) bool { return a < b }},
+		{[]string{"sort", "name", "order", "desc"}, 25, func(a, b int) bool { return a > b }},
+		{[]string{"sort", "level"}, 17, func(a, b int) bool { return level(a) >= level(b) }},
+		{[]string{"sort", "level", "order", "asc"}, 13, func(a, b int) bool { return level(a) <= level(b) }},
+		{[]string{"sort", "sell"}, 24, func(a, b int) bool { return a > b }},
+		{[]string{"sort", "sell", "order", "asc"}, 1, func(a, b int) bool { return a < b }},
+		{[]string{"sort", "buy"}, 1, func(a, b int) bool { return a < b }},
+		{[]string{"sort", "buy", "order", "asc"}, 24, func(a, b int) bool { return a > b }},
+	} {
+		status, body, ids := search(test.params...)
+		if status != http.StatusOK || len(ids) != searchPageSize {
+			t.Fatalf("%v: status %d, %d rows", test.params, status, len(ids))
+		}
+		if ids[0] != test.first {
+			t.Errorf("%v: first row %d, expected %d", test.params, ids[0], test.first)
+		}
+		for i := 1; i < len(ids); i++ {
+			if !test.sorted(ids[i-1], ids[i]) {
+				t.Errorf("%v: %d comes before %d", test.params, ids[i-1], ids[i])
+			}
+		}
+		// The sort carries over to the next page
+		if !strings.Contains(body, "&#34;sort&#34;:&#34;"+test.params[1]+"&#34;") {
+			t.Errorf("%v: load more button drops the sort", test.params)
+		}
+	}
+
+	// Filtering by rarity, together with a sort
+	status, body, ids := search("rarity", "rare", "sort", "name")
+	if status != http.StatusOK || len(ids) != 12 || ids[0] != 2 || ids[11] != 24 {
+		t.Errorf("rare widgets: status %d, rows %v", status, ids)
+	}
+	if !strings.Contains(body, `<option value="Rare" selected>`) || strings.Contains(body, "load-more-row") {
+		t.Error("rare widgets: filter isn't selected, or there is a load more button")
+	}
+	status, body, _ = search("rarity", "legendary")
+	if status != http.StatusOK || !strings.Contains(body, `No Legendary items found for`) || !strings.Contains(body, `name="rarity"`) {
+		t.Errorf("legendary widgets: status %d, body %s", status, body)
+	}
+
+	for _, params := range [][]string{{"sort", "price"}, {"sort", "name", "order", "up"}, {"rarity", "shiny"}} {
+		if status, _, _ := search(params...); status != http.StatusBadRequest {
+			t.Errorf("%v: status %d, expected %d", params, status, http.StatusBadRequest)
+		}
+	}
+}
+
+func TestHealthAndReadiness(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "items.json"), []byte(`{"id": 1, "name": "Sword"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	dataCache := gw2api.NewDataCache()
+	client := gw2api.NewClient(gw2api.WithSharedDataCache(dataCache))
+	server, err := NewServer(client, cache.NewLRUCache(100))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	get := func(server *Server, path string) (int, string) {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		return recorder.Code, recorder.Body.String()
+	}
+
+	if status, _ := get(server, "/healthz"); status != http.StatusOK {
+		t.Errorf("/healthz status %d, expected 200", status)
+	}
+
+	// Not ready until the cache has loaded
+	status, body := get(server, "/readyz")
+	var readiness ReadinessStatus
+	if err := json.Unmarshal([]byte(body), &readiness); err != nil {
+		t.Fatalf("/readyz body %q: %v", body, err)
+	}
+	if status != http.StatusServiceUnavailable || readiness.Ready || !readiness.Cache.Enabled || readiness.Cache.Loaded {
+		t.Errorf("/readyz before loading: status %d, %+v", status, readiness)
+	}
+
+	if err := dataCache.LoadFromDirectory(dir); err != nil {
+		t.Fatal(err)
+	}
+	status, body = get(server, "/readyz")
+	readiness = ReadinessStatus{}
+	json.Unmarshal([]byte(body), &readiness)
+	if status != http.StatusOK || !readiness.Ready || readiness.Cache.Kinds["items"] != 1 || readiness.Upstream != nil {
+		t.Errorf("/readyz after loading: status %d, %+v", status, readiness)
+	}
+
+	// With the upstream check, the API must answer too
+	down := newTestServer(t, http.StatusServiceUnavailable, `{"text": "unavailable"}`, WithUpstreamReadiness())
+	status, body = get(down, "/readyz")
+	readiness = ReadinessStatus{}
+	json.Unmarshal([]byte(body), &readiness)
+	if status != http.StatusServiceUnavailable || readiness.Upstream == nil || readiness.Upstream.OK || readiness.Upstream.Error == "" {
+		t.Errorf("/readyz with the API down: status %d, %s", status, body)
+	}
+
+	up := newTestServer(t, http.StatusOK, `{"id": 115267}`, WithUpstreamReadiness())
+	status, body = get(up, "/readyz")
+	readiness = ReadinessStatus{}
+	json.Unmarshal([]byte(body), &readiness)
+	if status != http.StatusOK || readiness.Upstream == nil || readiness.Upstream.Build != 115267 {
+		t.Errorf("/readyz with the API up: status %d, %s", status, body)
+	}
+}
+
+func TestComparePage(t *testing.T) {
+	const (
+		serverKey = "01234567-89AB-CDEF-0123-456789ABCDEF0123456789AB-CDEF-0123-456789ABCDEF"
+		narrow    = "narrow.sub.token"
+		broad     = "broad.sub.token"
+	)
+	// Each account request waits for the other, so they must be made at once
+	var arrived sync.WaitGroup
+	arrived.Add(2)
+	var concurrent atomic.Bool
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		switch r.URL.Path {
+		case "/v2/tokeninfo":
+			switch key {
+			case narrow:
+				w.Write([]byte(`{"id": "1", "name": "compare", "permissions": ["account", "unlocks"]}`))
+			case broad:
+				w.Write([]byte(`{"id": "2", "name": "everything", "permissions": ["account", "unlocks", "wallet"]}`))
+			default:
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(`{"text": "Invalid access token"}`))
+			}
+		case "/v2/account/gliders":
+			arrived.Done()
+			done := make(chan struct{})
+			go func() { arrived.Wait(); close(done) }()
+			select {
+			case <-done:
+				concurrent.Store(true)
+			case <-time.After(2 * time.Second):
+			}
+			if key == serverKey {
+				w.Write([]byte(`[1, 2, 3]`))
+			} else {
+				w.Write([]byte(`[2, 3, 4]`))
+			}
+		case "/v2/gliders":
+			w.Write([]byte(`[{"id": 1, "name": "Alpha Glider"}, {"id": 2, "name": "Beta Glider"}, {"id": 3, "name": "Gamma Glider"}, {"id": 4, "name": "Delta Glider"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithAPIKey(serverKey), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	compare := func(kind, token string) (int, string) {
+		form := url.Values{"kind": {kind}, "token": {token}}
+		request := httptest.NewRequest(http.MethodPost, "/compare", strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, request)
+		return recorder.Code, recorder.Body.String()
+	}
+
+	code, body := compare("gliders", narrow)
+	if code != http.StatusOK {
+		t.Fatalf("status %d: %s", code, body)
+	}
+	if !concurrent.Load() {
+		t.Error("the two accounts were fetched one after the other")
+	}
+	// Split the page into its three columns
+	columns := strings.Split(body, "<h2")[1:]
+	if len(columns) != 3 {
+		t.Fatalf("%d columns, want 3", len(columns))
+	}
+	expected := []struct {
+		label string
+		names []string
+	}{
+		{"Both", []string{"Beta Glider", "Gamma Glider"}},
+		{"Only this server&#39;s account", []string{"Alpha Glider"}},
+		{"Only the other account", []string{"Delta Glider"}},
+	}
+	all := []string{"Alpha Glider", "Beta Glider", "Gamma Glider", "Delta Glider"}
+	for i, column := range expected {
+		if !strings.Contains(columns[i], column.label) {
+			t.Errorf("column %d = %q, want %s", i, columns[i], column.label)
+		}
+		for _, name := range all {
+			if strings.Contains(columns[i], name) != slices.Contains(column.names, name) {
+				t.Errorf("column %s has %s wrong", column.label, name)
+			}
+		}
+	}
+	if strings.Contains(body, narrow) {
+		t.Error("the page echoes the token")
+	}
+
+	for _, tt := range []struct {
+		name, kind, token, message string
+		status                     int
+	}{
+		{"broad token", "gliders", broad, "also has the &#39;wallet&#39; scope", http.StatusBadRequest},
+		{"rejected token", "gliders", "other.sub.token", "did not accept", http.StatusBadRequest},
+		{"not a token", "gliders", "hunter2", "isn&#39;t an API key", http.StatusBadRequest},
+		{"no token", "gliders", "", "paste an API key", http.StatusBadRequest},
+		{"unknown kind", "capes", narrow, "Unknown unlock category", http.StatusBadRequest},
+	} {
+		code, body := compare(tt.kind, tt.token)
+		if code != tt.status || !strings.Contains(body, tt.message) {
+			t.Errorf("%s: status %d, body %q; want %d with %q", tt.name, code, body, tt.status, tt.message)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/compare?kind=outfits", nil))
+	if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), `value="outfits" selected`) {
+		t.Errorf("form: status %d, body %q", recorder.Code, recorder.Body.String())
+	}
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// upstreamCheckInterval is how long the result of the readiness check's build
+// request is reused, so frequent probes don't each call the API
+const upstreamCheckInterval = 30 * time.Second
+
+// upstreamCheckTimeout bounds the build request made by the readiness check
+const upstreamCheckTimeout = 5 * time.Second
+
+// upstreamCheck remembers the last build request made by the readiness check
+type upstreamCheck struct {
+	mutex     sync.Mutex
+	checkedAt time.Time
+	build     int
+	err       error
+}
+
+// ReadinessStatus is the body of /readyz
+type ReadinessStatus struct {
+	Ready    bool            `json:"ready"`
+	Cache    CacheReadiness  `json:"cache"`
+	Upstream *UpstreamStatus `json:"upstream,omitempty"` // Only with WithUpstreamReadiness
+}
+
+// CacheReadiness is the loading progress of the data cache
+type CacheReadiness struct {
+	Enabled bool           `json:"enabled"`
+	Loading bool           `json:"loading"`
+	Loaded  bool           `json:"loaded"`
+	Error   string         `json:"error,omitempty"`
+	Kinds   map[string]int `json:"kinds,omitempty"` // Records loaded so far, by kind
+}
+
+// UpstreamStatus is the result of the readiness check's build request
+type UpstreamStatus struct {
+	OK        bool      `json:"ok"`
+	Build     int       `json:"build,omitempty"`
+	Error     string    `json:"error,omitempty"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// handleHealthz reports that the server is up
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain")
+	w.Write([]byte("ok\n"))
+}
+
+// handleReadyz reports whether the server is ready for traffic: the data cache
+// has finished loading and, with WithUpstreamReadiness, the API answered a
+// build request. The status is 200 when ready and 503 otherwise.
+func (s *Server) handleReadyz(w http.ResponseWriter, r *http.Request) {
+	status := s.readiness(r.Context())
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	if !status.Ready {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+	json.NewEncoder(w).Encode(status)
+}
+
+// readiness gathers the readiness status
+func (s *Server) readiness(ctx context.Context) ReadinessStatus {
+	status := ReadinessStatus{Ready: true}
+
+	if s.client != nil && s.client.DataCache() != nil {
+		dataCache := s.client.DataCache()
+		status.Cache = CacheReadiness{
+			Enabled: true,
+			Loading: dataCache.Loading(),
+			Loaded:  dataCache.Loaded(),
+			Kinds:   make(map[string]int),
+		}
+		for _, kind := range dataCache.Stats().Kinds {
+			status.Cache.Kinds[kind.Kind] = kind.Records
+		}
+		err := s.client.DataCacheError()
+		if err == nil {
+			err = dataCache.LoadError()
+		}
+		if err != nil {
+			status.Cache.Error = err.Error()
+		}
+		status.Ready = status.Cache.Loaded
+	}
+
+	if s.upstream != nil && s.client != nil {
+		upstream := s.checkUpstream(ctx)
+		status.Upstream = &upstream
+		status.Ready = status.Ready && upstream.OK
+	}
+	return status
+}
+
+// checkUpstream requests the game build, reusing a recent result
+func (s *Server) checkUpstream(ctx context.Context) UpstreamStatus {
+	check := s.upstream
+	check.mutex.Lock()
+	defer check.mutex.Unlock()
+
+	if check.checkedAt.IsZero() || time.Since(check.checkedAt) >= upstreamCheckInterval {
+		ctx, cancel := context.WithTimeout(ctx, upstreamCheckTimeout)
+		defer cancel()
+
+		check.build, check.err = 0, nil
+		build, err := s.client.GetBuild(ctx)
+		if err != nil {
+			check.err = err
+		} else {
+			check.build = build.ID
+		}
+		check.checkedAt = time.Now()
+	}
+
+	status := UpstreamStatus{OK: check.err == nil, Build: check.build, CheckedAt: check.checkedAt}
+	if check.err != nil {
+		status.Error = check.err.Error()
+	}
+	return status
+}
@@ -0,0 +1,138 @@
+package web
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// unmatchedRoute is logged as the route of requests no pattern matched, so
+// mistyped paths don't each get their own entry
+const unmatchedRoute = "(unmatched)"
+
+// responseRecorder remembers the status and size of a response
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	bytes  int
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if r.status == 0 {
+		r.status = status
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	if r.status == 0 {
+		r.status = http.StatusOK
+	}
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// recordResponse wraps w in a responseRecorder unless it already is one
+func recordResponse(w http.ResponseWriter) *responseRecorder {
+	if rec, ok := w.(*responseRecorder); ok {
+		return rec
+	}
+	return &responseRecorder{ResponseWriter: w}
+}
+
+// apiCallsKey is the context key for the upstream API call counter of a request
+type apiCallsKey struct{}
+
+// countAPICall is a client response hook that counts each API request sent
+// for the page request in its context
+func countAPICall(info gw2api.RequestInfo) {
+	if info.Context == nil || info.URL == "" {
+		return // No request was sent
+	}
+	if calls, ok := info.Context.Value(apiCallsKey{}).(*atomic.Int32); ok {
+		calls.Add(1)
+	}
+}
+
+// logRequests logs every request with its route pattern, status, duration,
+// size and the number of API calls it made. Requests taking slowThreshold or
+// longer are logged as warnings; zero disables the warning.
+func (s *Server) logRequests(next http.Handler, logger *slog.Logger, slowThreshold time.Duration) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		calls := new(atomic.Int32)
+		r = r.WithContext(context.WithValue(r.Context(), apiCallsKey{}, calls))
+		rec := recordResponse(w)
+
+		next.ServeHTTP(rec, r)
+
+		// The mux fills in the pattern it matched
+		route := unmatchedRoute
+		if r.Pattern != "" {
+			_, path, found := strings.Cut(r.Pattern, " ")
+			if !found {
+				path = r.Pattern
+			}
+			route = path
+		}
+		status := rec.status
+		if status == 0 {
+			status = http.StatusOK
+		}
+
+		duration := time.Since(start)
+		level := slog.LevelInfo
+		if slowThreshold > 0 && duration >= slowThreshold {
+			level = slog.LevelWarn
+		}
+		logger.LogAttrs(r.Context(), level, "request",
+			slog.String("method", r.Method),
+			slog.String("route", route),
+			slog.Int("status", status),
+			slog.Duration("duration", duration),
+			slog.Int("bytes", rec.bytes),
+			slog.Int("api_calls", int(calls.Load())),
+		)
+	})
+}
+
+// recoverPanics turns a panicking handler into a 500 error page and logs the
+// stack, instead of dropping the connection
+func (s *Server) recoverPanics(next http.Handler, logger *slog.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := recordResponse(w)
+		defer func() {
+			err := recover()
+			if err == nil {
+				return
+			}
+			if err == http.ErrAbortHandler {
+				panic(err) // Deliberate abort, let net/http handle it
+			}
+
+			logger.Error("handler panicked",
+				"method", r.Method,
+				"path", r.URL.Path,
+				"panic", err,
+				"stack", string(debug.Stack()),
+			)
+			// The page can only be replaced if nothing was sent yet
+			if rec.status == 0 {
+				s.renderError(rec, http.StatusInternalServerError, "Something went wrong", "The page failed to load. Try again, and report it if it keeps happening.")
+			}
+		}()
+		next.ServeHTTP(rec, r)
+	})
+}
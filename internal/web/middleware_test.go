@@ -0,0 +1,109 @@
+package web
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// logEntry is one request line written by the JSON log handler
+type logEntry struct {
+	Level    string `json:"level"`
+	Msg      string `json:"msg"`
+	Method   string `json:"method"`
+	Route    string `json:"route"`
+	Status   int    `json:"status"`
+	Bytes    int    `json:"bytes"`
+	APICalls int    `json:"api_calls"`
+	Panic    string `json:"panic"`
+	Stack    string `json:"stack"`
+}
+
+// serveLogged makes one request against a server logging to a buffer and
+// returns the response and the log entries written
+func serveLogged(t *testing.T, server *Server, logs *bytes.Buffer, path string) (*httptest.ResponseRecorder, []logEntry) {
+	t.Helper()
+	logs.Reset()
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+
+	var entries []logEntry
+	for line := range strings.Lines(logs.String()) {
+		var entry logEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return recorder, entries
+}
+
+func TestRequestLogging(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	server := newTestServer(t, http.StatusNotFound, `{"text":"all ids provided are invalid"}`, WithRequestLogging(logger, time.Hour))
+
+	recorder, entries := serveLogged(t, server, &logs, "/items/99999999")
+	if len(entries) != 1 {
+		t.Fatalf("got %d log entries, expected 1", len(entries))
+	}
+	entry := entries[0]
+	if entry.Level != "INFO" || entry.Method != "GET" || entry.Route != "/items/{id}" || entry.Status != http.StatusNotFound {
+		t.Errorf("entry = %+v, expected an INFO line for GET /items/{id} with status 404", entry)
+	}
+	if entry.Bytes != recorder.Body.Len() {
+		t.Errorf("logged %d bytes, response has %d", entry.Bytes, recorder.Body.Len())
+	}
+	if entry.APICalls == 0 {
+		t.Error("no API calls counted for an item page")
+	}
+
+	_, entries = serveLogged(t, server, &logs, "/no/such/page")
+	if len(entries) != 1 || entries[0].Route != unmatchedRoute || entries[0].APICalls != 0 {
+		t.Errorf("entries = %+v, expected one unmatched route without API calls", entries)
+	}
+}
+
+func TestSlowRequestWarning(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	server := newTestServer(t, http.StatusOK, `[]`, WithRequestLogging(logger, time.Nanosecond))
+
+	_, entries := serveLogged(t, server, &logs, "/")
+	if len(entries) != 1 || entries[0].Level != "WARN" || entries[0].Route != "/{$}" {
+		t.Errorf("entries = %+v, expected one WARN line for the home page", entries)
+	}
+}
+
+func TestPanicRecovery(t *testing.T) {
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	server := newTestServer(t, http.StatusOK, `[]`, WithRequestLogging(logger, 0))
+	server.HandleFunc("GET /panic", func(w http.ResponseWriter, r *http.Request) {
+		panic("template exploded")
+	})
+
+	recorder, entries := serveLogged(t, server, &logs, "/panic")
+	if recorder.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, expected 500", recorder.Code)
+	}
+	if body := recorder.Body.String(); !strings.Contains(body, "<!DOCTYPE html>") || !strings.Contains(body, "Something went wrong") {
+		t.Error("panic was not rendered through the error template")
+	}
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d log entries, expected the panic and the request", len(entries))
+	}
+	if panicked := entries[0]; panicked.Level != "ERROR" || panicked.Panic != "template exploded" || !strings.Contains(panicked.Stack, "goroutine") {
+		t.Errorf("panic entry = %+v, expected an ERROR line with the panic and stack", panicked)
+	}
+	if request := entries[1]; request.Route != "/panic" || request.Status != http.StatusInternalServerError {
+		t.Errorf("request entry = %+v, expected /panic with status 500", request)
+	}
+}
@@ -0,0 +1,128 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// priceFreshFor is how long a cached price is shown before it is refetched
+const priceFreshFor = 3 * time.Hour
+
+// priceHistoryTTL is how long a price stays cached after fetching. Past
+// priceFreshFor it is only kept to compare the next fetch against.
+const priceHistoryTTL = 24 * time.Hour
+
+// maxPriceIDs is the most IDs /api/v1/prices answers for in one request
+const maxPriceIDs = 200
+
+// PriceEntry is a cached trading post price along with the price observed
+// before it, which is usually one priceFreshFor earlier
+type PriceEntry struct {
+	Price         *gw2api.Price `json:"price"`
+	FetchedAt     time.Time     `json:"fetched_at"`
+	PreviousPrice *gw2api.Price `json:"previous_price,omitempty"` // Nil until the price has been refetched
+	PreviousAt    time.Time     `json:"previous_at,omitzero"`
+}
+
+// PriceChange is how much a price moved since the previous observation
+type PriceChange struct {
+	Percent float64   `json:"percent"`
+	Since   time.Time `json:"since"`
+}
+
+// Up reports whether the price rose
+func (c *PriceChange) Up() bool {
+	return c.Percent > 0
+}
+
+// Abs returns the size of the change in percent
+func (c *PriceChange) Abs() float64 {
+	return math.Abs(c.Percent)
+}
+
+// price returns the cached price, or nil for a nil entry
+func (e *PriceEntry) price() *gw2api.Price {
+	if e == nil {
+		return nil
+	}
+	return e.Price
+}
+
+// BuyChange returns the change in the highest buy order, or nil when there is
+// no previous observation or either has no buy orders
+func (e *PriceEntry) BuyChange() *PriceChange {
+	if e == nil || e.PreviousPrice == nil {
+		return nil
+	}
+	return priceChange(e.Price.Buys.UnitPrice, e.PreviousPrice.Buys.UnitPrice, e.PreviousAt)
+}
+
+// SellChange returns the change in the lowest sell listing, or nil when there
+// is no previous observation or either has no listings
+func (e *PriceEntry) SellChange() *PriceChange {
+	if e == nil || e.PreviousPrice == nil {
+		return nil
+	}
+	return priceChange(e.Price.Sells.UnitPrice, e.PreviousPrice.Sells.UnitPrice, e.PreviousAt)
+}
+
+// priceChange compares two unit prices, where 0 means there were no orders
+func priceChange(current, previous int, since time.Time) *PriceChange {
+	if current == 0 || previous == 0 {
+		return nil
+	}
+	return &PriceChange{Percent: float64(current-previous) / float64(previous) * 100, Since: since}
+}
+
+// APIPrice is a price in /api/v1/prices responses
+type APIPrice struct {
+	*gw2api.Price
+	FetchedAt  time.Time    `json:"fetched_at"`
+	BuyChange  *PriceChange `json:"buy_change,omitempty"`  // Since the previous observation, if any
+	SellChange *PriceChange `json:"sell_change,omitempty"` // Since the previous observation, if any
+}
+
+// handleAPIPrices returns the cached trading post prices of the comma-separated
+// ids, with how each moved since it was last fetched
+func (s *Server) handleAPIPrices(w http.ResponseWriter, r *http.Request) {
+	var ids []int
+	for field := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+		if field = strings.TrimSpace(field); field == "" {
+			continue
+		}
+		id, err := strconv.Atoi(field)
+		if err != nil || id <= 0 {
+			http.Error(w, fmt.Sprintf("Invalid item ID %q", field), http.StatusBadRequest)
+			return
+		}
+		ids = append(ids, id)
+	}
+	if len(ids) == 0 || len(ids) > maxPriceIDs {
+		http.Error(w, fmt.Sprintf("Give between 1 and %d item IDs in ids", maxPriceIDs), http.StatusBadRequest)
+		return
+	}
+
+	entries := s.batchGetPrices(r.Context(), ids)
+	prices := make([]APIPrice, 0, len(entries))
+	for _, id := range ids {
+		if entry, found := entries[id]; found {
+			prices = append(prices, APIPrice{
+				Price:      entry.Price,
+				FetchedAt:  entry.FetchedAt,
+				BuyChange:  entry.BuyChange(),
+				SellChange: entry.SellChange(),
+			})
+			delete(entries, id) // Repeated IDs are answered once
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(prices)
+}
@@ -0,0 +1,149 @@
+package web
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestPriceChanges(t *testing.T) {
+	var sell atomic.Int32
+	sell.Store(1000)
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v2/items":
+			w.Write([]byte(`[{"id": 500, "name": "Exotic Helm", "type": "Armor", "rarity": "Exotic"}]`))
+		case "/v2/commerce/prices":
+			fmt.Fprintf(w, `[{"id": 500, "buys": {"unit_price": 0, "quantity": 0}, "sells": {"unit_price": %d, "quantity": 10}}]`, sell.Load())
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	clock := &testClock{now: time.Now()}
+	priceCache := cache.NewLRUCache(10)
+	priceCache.SetClock(clock)
+	server, err := NewServer(client, priceCache)
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	getPrices := func() []APIPrice {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/prices?ids=500,500", nil))
+		if recorder.Code != http.StatusOK {
+			t.Fatalf("status = %d, expected 200", recorder.Code)
+		}
+		var prices []APIPrice
+		if err := json.Unmarshal(recorder.Body.Bytes(), &prices); err != nil || len(prices) != 1 {
+			t.Fatalf("prices = %s, %v, expected one price", recorder.Body.String(), err)
+		}
+		return prices
+	}
+
+	// The first observation has nothing to compare against
+	if prices := getPrices(); prices[0].SellChange != nil || prices[0].Sells.UnitPrice != 1000 {
+		t.Errorf("first prices = %+v, expected 1000 with no change", prices[0])
+	}
+
+	// Once the price goes stale it is refetched and compared with the old one
+	entry, _ := (&PriceCache{cache: priceCache}).lookup(lastPriceKey(500))
+	clock.Advance(priceFreshFor + time.Second)
+	sell.Store(1100)
+	prices := getPrices()
+	if change := prices[0].SellChange; change == nil || change.Percent != 10 || !change.Since.Equal(entry.FetchedAt) {
+		t.Errorf("sell change = %+v, expected +10%% since the first fetch", change)
+	}
+	if prices[0].BuyChange != nil {
+		t.Errorf("buy change = %+v, expected none without buy orders", prices[0].BuyChange)
+	}
+
+	// Pages show the change as an arrow
+	for _, path := range []string{"/items/500", "/item/500"} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if body := recorder.Body.String(); !strings.Contains(body, "&#9650; 10.0%") {
+			t.Errorf("%s does not show the sell price rising 10%%", path)
+		}
+	}
+
+	for _, query := range []string{"", "ids=abc", "ids=" + strings.Repeat("1,", maxPriceIDs+1)} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/prices?"+query, nil))
+		if recorder.Code != http.StatusBadRequest {
+			t.Errorf("?%s: status = %d, expected 400", query, recorder.Code)
+		}
+	}
+}
+
+// testClock is a cache.Clock the tests move forward by hand
+type testClock struct {
+	mutex sync.Mutex
+	now   time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.now
+}
+
+func (c *testClock) Advance(d time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestPriceCacheExpiry(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+	lru := cache.NewLRUCache(10)
+	lru.SetClock(clock)
+	prices := &PriceCache{cache: lru}
+
+	prices.SetPrice(1, &gw2api.Price{ID: 1})
+	clock.Advance(priceFreshFor - time.Minute)
+	if _, found := prices.GetPrice(1); !found {
+		t.Error("price was dropped before going stale")
+	}
+
+	// Stale prices aren't served, but are still the previous observation
+	clock.Advance(time.Minute + time.Second)
+	if _, found := prices.GetPrice(1); found {
+		t.Error("stale price was served")
+	}
+	if entry := prices.SetPrice(1, &gw2api.Price{ID: 1}); entry.PreviousPrice == nil {
+		t.Error("refetched price has no previous observation")
+	}
+
+	// After priceHistoryTTL the history is gone too
+	clock.Advance(priceHistoryTTL + time.Second)
+	if entry := prices.SetPrice(1, &gw2api.Price{ID: 1}); entry.PreviousPrice != nil {
+		t.Error("price older than the history TTL was kept")
+	}
+}
+
+func TestDecodePriceCacheEntry(t *testing.T) {
+	fetchedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	saved, _ := json.Marshal(&PriceEntry{Price: &gw2api.Price{ID: 1}, FetchedAt: fetchedAt})
+	value, err := DecodePriceCacheEntry("price_1", saved)
+	if entry, ok := value.(*PriceEntry); err != nil || !ok || entry.Price.ID != 1 || !entry.FetchedAt.Equal(fetchedAt) {
+		t.Errorf("DecodePriceCacheEntry = %+v, %v", value, err)
+	}
+
+	// Bare prices from older snapshots are restored as stale entries
+	value, err = DecodePriceCacheEntry("price_2", json.RawMessage(`{"id": 2, "sells": {"unit_price": 5}}`))
+	if entry, ok := value.(*PriceEntry); err != nil || !ok || entry.Price.Sells.UnitPrice != 5 || !entry.FetchedAt.IsZero() {
+		t.Errorf("DecodePriceCacheEntry(legacy) = %+v, %v", value, err)
+	}
+}
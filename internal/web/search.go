@@ -0,0 +1,198 @@
+package web
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// searchSorts are the keys item search results can be sorted by, each with
+// the order it starts in. Without a key, results keep the search's order.
+var searchSorts = map[string]string{
+	"name":  "asc",
+	"level": "desc",
+	"buy":   "desc",
+	"sell":  "desc",
+}
+
+// SearchSortOption is one of the sort buttons above the search results
+type SearchSortOption struct {
+	Key   string // Empty for the search's own order
+	Label string
+}
+
+// searchSortOptions are the sort buttons in the order they are shown
+var searchSortOptions = []SearchSortOption{
+	{Key: "", Label: "Relevance"},
+	{Key: "name", Label: "Name"},
+	{Key: "level", Label: "Level"},
+	{Key: "buy", Label: "Buy Price"},
+	{Key: "sell", Label: "Sell Price"},
+}
+
+// parseSearchSort validates the sort and order parameters of a search. An
+// empty order is the key's starting order.
+func parseSearchSort(key, order string) (string, string, error) {
+	if key == "" {
+		return "", "", nil
+	}
+	start, found := searchSorts[key]
+	if !found {
+		return "", "", fmt.Errorf("unknown sort %q", key)
+	}
+	switch order {
+	case "":
+		order = start
+	case "asc", "desc":
+	default:
+		return "", "", fmt.Errorf("unknown order %q, expected asc or desc", order)
+	}
+	return key, order, nil
+}
+
+// searchVals returns the hx-vals of a request repeating this search, with
+// changes applied on top. An empty change removes the parameter.
+func (d ItemSearchData) searchVals(changes map[string]string) string {
+	vals := map[string]string{"query": d.Query}
+	if d.Lang != "" {
+		vals["lang"] = string(d.Lang)
+	}
+	if d.Rarity != "" {
+		vals["rarity"] = string(d.Rarity)
+	}
+	if d.Sort != "" {
+		vals["sort"], vals["order"] = d.Sort, d.Order
+	}
+	for name, value := range changes {
+		if value == "" {
+			delete(vals, name)
+		} else {
+			vals[name] = value
+		}
+	}
+	encoded, _ := json.Marshal(vals)
+	return string(encoded)
+}
+
+// SortVals returns the hx-vals of the sort button for key, which reverses the
+// order when the results are already sorted by it
+func (d ItemSearchData) SortVals(key string) string {
+	order := searchSorts[key]
+	if key != "" && key == d.Sort {
+		order = "asc"
+		if d.Order == "asc" {
+			order = "desc"
+		}
+	}
+	return d.searchVals(map[string]string{"sort": key, "order": order})
+}
+
+// FilterVals returns the hx-vals of the rarity filter, which sends its own value
+func (d ItemSearchData) FilterVals() string {
+	return d.searchVals(map[string]string{"rarity": ""})
+}
+
+// SortArrow returns the arrow shown on the sort button for key, if the
+// results are sorted by it
+func (d ItemSearchData) SortArrow(key string) string {
+	switch {
+	case key == "" || key != d.Sort:
+		return ""
+	case d.Order == "asc":
+		return "▲"
+	default:
+		return "▼"
+	}
+}
+
+// sortSearchResults orders the IDs of search results by key. Sorting by
+// price needs the price of every result, which is fetched and cached, so the
+// pages shown later don't fetch it again. Items without a price go last in
+// either order, and ties keep the search's order.
+func (s *Server) sortSearchResults(ctx context.Context, ids []int, lang gw2api.Language, key, order string) ([]int, error) {
+	type sortable struct {
+		id    int
+		known bool
+		value int
+		name  string
+	}
+	results := make([]sortable, len(ids))
+	for i, id := range ids {
+		results[i].id = id
+	}
+
+	switch key {
+	case "name", "level":
+		items, err := s.client.GetItems(ctx, ids, s.interactive...)
+		if err != nil {
+			return nil, err
+		}
+		byID := make(map[int]*gw2api.Item, len(items))
+		for _, item := range items {
+			byID[item.ID] = item
+		}
+		for i := range results {
+			item, found := byID[results[i].id]
+			if !found {
+				continue
+			}
+			results[i].known = true
+			results[i].value = item.Level
+			results[i].name = strings.ToLower(s.displayName(item, lang))
+		}
+	case "buy", "sell":
+		prices := s.batchGetPrices(ctx, ids)
+		for i := range results {
+			entry, found := prices[results[i].id]
+			if !found || entry.Price == nil {
+				continue
+			}
+			results[i].value = entry.Price.Buys.UnitPrice
+			if key == "sell" {
+				results[i].value = entry.Price.Sells.UnitPrice
+			}
+			results[i].known = results[i].value > 0
+		}
+	}
+
+	slices.SortStableFunc(results, func(a, b sortable) int {
+		if a.known != b.known {
+			if a.known {
+				return -1
+			}
+			return 1
+		}
+		var c int
+		if key == "name" {
+			c = strings.Compare(a.name, b.name)
+		} else {
+			c = cmp.Compare(a.value, b.value)
+		}
+		if order == "desc" {
+			c = -c
+		}
+		return c
+	})
+
+	sorted := make([]int, len(results))
+	for i, result := range results {
+		sorted[i] = result.id
+	}
+	return sorted, nil
+}
+
+// displayName returns an item's name in lang, if its names are loaded, or its
+// default name
+func (s *Server) displayName(item *gw2api.Item, lang gw2api.Language) string {
+	if lang != "" && s.client.DataCache() != nil {
+		if name, found := s.client.DataCache().GetItemCache().LocalizedName(item.ID, lang); found {
+			return name
+		}
+	}
+	return item.Name
+}
@@ -1,43 +1,198 @@
 package web
 
 import (
+	"encoding/json"
 	"fmt"
+	"log/slog"
 	"net/http"
+	"os"
 	"time"
 
 	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/exchangehistory"
 	"j5.nz/gw2/internal/gw2api"
 )
 
 // Server represents the web server
 type Server struct {
-	client     *gw2api.Client
-	priceCache cache.Cache
-	templates  *Templates
+	client              *gw2api.Client
+	priceCache          cache.Cache
+	searchResults       cache.Cache // Recent item search matches, see searchItemIDs
+	characterList       cache.Cache // Recent character names, see characterNames
+	templates           *Templates
+	officialRecipesOnly bool    // Leave supplemental recipes, such as Mystic Forge ones, out of trees and searches
+	depthThreshold      float64 // Price large purchases from the order book when it is this much above the best price
+	timeGates           map[int]gw2api.TimeGate // Daily-limited crafts, by item ID
+	exchangeHistory     *exchangehistory.Store
+	interactive         []gw2api.RequestOption // Latency bounds for API calls made while a page loads
+	upstream            *upstreamCheck // Set when readiness includes an API request
+	handler             http.Handler // Routes wrapped in middleware
 	*http.ServeMux
 }
 
-// NewServer creates a new web server
-func NewServer(client *gw2api.Client, priceCache cache.Cache) *Server {
+// ServerOption configures the web server
+type ServerOption func(*serverConfig)
+
+type serverConfig struct {
+	templateDir         string
+	officialRecipesOnly bool
+	depthThreshold      float64
+	timeGates           []gw2api.TimeGate
+	exchangeHistory     *exchangehistory.Store
+	upstreamReadiness   bool
+	requestLogger       *slog.Logger
+	slowRequest         time.Duration
+	interactiveRetries  int
+	interactiveTimeout  time.Duration
+}
+
+// Defaults for WithInteractiveRequests. Someone is waiting on every page, so
+// failing fast beats the client's patient retries.
+const (
+	defaultInteractiveRetries = 0
+	defaultInteractiveTimeout = 3 * time.Second
+)
+
+// WithTemplateDir loads templates from a directory on disk instead of the copies
+// embedded in the binary, re-parsing them on every request so edits show up
+// without a restart
+func WithTemplateDir(dir string) ServerOption {
+	return func(c *serverConfig) {
+		c.templateDir = dir
+	}
+}
+
+// WithOfficialRecipesOnly leaves recipes from custom_recipes.json, such as
+// Mystic Forge recipes, out of crafting trees and recipe searches
+func WithOfficialRecipesOnly() ServerOption {
+	return func(c *serverConfig) {
+		c.officialRecipesOnly = true
+	}
+}
+
+// WithDepthPricing prices bought ingredients in crafting trees from the trading
+// post order book when buying the required count costs more than threshold
+// above the best price, such as 0.05 for 5%. Top-of-book prices understate the
+// cost of buying hundreds of a material at once.
+func WithDepthPricing(threshold float64) ServerOption {
+	return func(c *serverConfig) {
+		c.depthThreshold = threshold
+	}
+}
+
+// WithTimeGates marks more items as time-gated in crafting trees, on top of
+// gw2api.DefaultTimeGates. A gate for an item already listed replaces it.
+func WithTimeGates(gates []gw2api.TimeGate) ServerOption {
+	return func(c *serverConfig) {
+		c.timeGates = append(c.timeGates, gates...)
+	}
+}
+
+// WithExchangeHistory shows the gem exchange rates recorded in store on the
+// /exchange page
+func WithExchangeHistory(store *exchangehistory.Store) ServerOption {
+	return func(c *serverConfig) {
+		c.exchangeHistory = store
+	}
+}
+
+// WithUpstreamReadiness makes /readyz also require a successful request for
+// the game build, proving the API can be reached. The result is reused for
+// upstreamCheckInterval so frequent probes don't each call the API.
+func WithUpstreamReadiness() ServerOption {
+	return func(c *serverConfig) {
+		c.upstreamReadiness = true
+	}
+}
+
+// WithRequestLogging logs every request to logger with its route pattern,
+// status, duration, size and upstream API call count. Requests taking
+// slowThreshold or longer are logged as warnings; zero disables the warning.
+// Panics are logged to the same logger.
+func WithRequestLogging(logger *slog.Logger, slowThreshold time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		c.requestLogger = logger
+		c.slowRequest = slowThreshold
+	}
+}
+
+// WithInteractiveRequests sets how many times API calls made while rendering a
+// page are retried and how long each may take in total, overriding the
+// client's retry settings. Zero retries fails on the first error, and a zero
+// timeout leaves only the request's own deadline.
+func WithInteractiveRequests(retries int, timeout time.Duration) ServerOption {
+	return func(c *serverConfig) {
+		c.interactiveRetries = retries
+		c.interactiveTimeout = timeout
+	}
+}
+
+// NewServer creates a new web server. Panics in handlers are recovered and
+// shown as an error page.
+func NewServer(client *gw2api.Client, priceCache cache.Cache, options ...ServerOption) (*Server, error) {
+	config := &serverConfig{
+		interactiveRetries: defaultInteractiveRetries,
+		interactiveTimeout: defaultInteractiveTimeout,
+	}
+	for _, option := range options {
+		option(config)
+	}
+
 	s := &Server{
-		client:     client,
-		priceCache: priceCache,
-		ServeMux:   http.NewServeMux(),
+		client:              client,
+		priceCache:          priceCache,
+		searchResults:       cache.NewLRUCache(100),
+		characterList:       cache.NewLRUCache(1),
+		officialRecipesOnly: config.officialRecipesOnly,
+		depthThreshold:      config.depthThreshold,
+		timeGates:           gw2api.DefaultTimeGates(),
+		exchangeHistory:     config.exchangeHistory,
+		ServeMux:            http.NewServeMux(),
+		interactive: []gw2api.RequestOption{
+			gw2api.WithRequestRetries(config.interactiveRetries),
+			gw2api.WithRequestTimeout(config.interactiveTimeout),
+		},
+	}
+	for _, gate := range config.timeGates {
+		s.timeGates[gate.ItemID] = gate
+	}
+	if config.upstreamReadiness {
+		s.upstream = &upstreamCheck{}
 	}
 
 	// Initialize templates
-	s.templates = NewTemplates()
+	var err error
+	if config.templateDir != "" {
+		s.templates, err = NewTemplates(os.DirFS(config.templateDir), true)
+	} else {
+		s.templates, err = NewTemplates(embeddedSub("assets/templates"), false)
+	}
+	if err != nil {
+		return nil, err
+	}
 
 	// Setup routes
 	s.setupRoutes()
 
-	return s
+	// Recover panics inside the logging, so they are logged as 500s
+	panicLogger := slog.Default()
+	if config.requestLogger != nil {
+		panicLogger = config.requestLogger
+	}
+	s.handler = s.recoverPanics(http.HandlerFunc(s.route), panicLogger)
+	if config.requestLogger != nil {
+		s.client = client.With(gw2api.WithResponseHook(countAPICall))
+		s.handler = s.logRequests(s.handler, config.requestLogger, config.slowRequest)
+	}
+
+	return s, nil
 }
 
 func (s *Server) setupRoutes() {
 	// Main pages
-	s.HandleFunc("GET /", s.handleHome)
+	s.HandleFunc("GET /{$}", s.handleHome)
 	s.HandleFunc("GET /items/{id}", s.handleItemPage)
+	s.HandleFunc("GET /skills/{id}", s.handleSkillPage)
 	s.HandleFunc("GET /inventory", s.handleInventoryPage)
 	
 	// HTMX endpoints
@@ -51,9 +206,26 @@ func (s *Server) setupRoutes() {
 	s.HandleFunc("GET /characters", s.handleCharacters)
 	s.HandleFunc("GET /inventory/{character}", s.handleCharacterInventory)
 	s.HandleFunc("GET /account", s.handleAccountPage)
+	s.HandleFunc("GET /account/nearly-done", s.handleNearlyDoneAchievements)
+	s.HandleFunc("GET /account/dyes", s.handleMissingDyes)
 	s.HandleFunc("GET /bank", s.handleBankPage)
 	s.HandleFunc("GET /shared", s.handleSharedInventoryPage)
+	s.HandleFunc("GET /guild/{id}/treasury", s.handleGuildTreasuryPage)
+	s.HandleFunc("GET /exchange", s.handleExchangePage)
+	s.HandleFunc("GET /wardrobe", s.handleWardrobe)
+	s.HandleFunc("GET /wardrobe/{kind}", s.handleWardrobePage)
+	s.HandleFunc("GET /wardrobe/{kind}/grid", s.handleWardrobeGrid)
+	s.HandleFunc("GET /compare", s.handleComparePage)
+	s.HandleFunc("POST /compare", s.handleCompare)
 	
+	// JSON API
+	s.HandleFunc("GET /api/v1/prices", s.handleAPIPrices)
+	s.HandleFunc("GET /api/v1/wvw/matches/{team}", s.handleAPIWvWMatch)
+
+	// Health and readiness probes
+	s.HandleFunc("GET /healthz", s.handleHealthz)
+	s.HandleFunc("GET /readyz", s.handleReadyz)
+
 	// API key handling
 	s.HandleFunc("POST /api-key", s.handleSetAPIKey)
 	
@@ -63,27 +235,74 @@ func (s *Server) setupRoutes() {
 
 // staticFileHandler serves static files
 func (s *Server) staticFileHandler() http.Handler {
-	return http.StripPrefix("/static/", http.FileServer(http.Dir("internal/web/assets/static")))
+	return http.StripPrefix("/static/", http.FileServer(http.FS(embeddedSub("assets/static"))))
 }
 
-// PriceCache wraps the trading post price cache with proper TTL
+// PriceCache wraps the trading post price cache. Each price is stored twice:
+// under a fresh key that expires after priceFreshFor, which is what is served,
+// and under a history key kept for priceHistoryTTL, so the next fetch can
+// record how the price changed. The cache's TTLs decide both.
 type PriceCache struct {
 	cache cache.Cache
 }
 
+// freshPriceKey and lastPriceKey are the keys of an item's fresh price and
+// its last observation. Snapshots from before the fresh key only restore the
+// last observations, so those prices are refetched.
+func freshPriceKey(itemID int) string { return fmt.Sprintf("price_fresh_%d", itemID) }
+func lastPriceKey(itemID int) string  { return fmt.Sprintf("price_%d", itemID) }
+
 // GetPrice gets a cached price or returns nil if not found/expired
 func (pc *PriceCache) GetPrice(itemID int) (*gw2api.Price, bool) {
-	key := fmt.Sprintf("price_%d", itemID)
+	if entry, found := pc.GetEntry(itemID); found {
+		return entry.Price, true
+	}
+	return nil, false
+}
+
+// GetEntry gets a cached price fetched within priceFreshFor, along with the
+// price observed before it
+func (pc *PriceCache) GetEntry(itemID int) (*PriceEntry, bool) {
+	return pc.lookup(freshPriceKey(itemID))
+}
+
+// SetPrice caches a freshly fetched price, keeping the last observed price as
+// the previous observation
+func (pc *PriceCache) SetPrice(itemID int, price *gw2api.Price) *PriceEntry {
+	entry := &PriceEntry{Price: price, FetchedAt: time.Now()}
+	if old, found := pc.lookup(lastPriceKey(itemID)); found && !old.FetchedAt.IsZero() {
+		entry.PreviousPrice, entry.PreviousAt = old.Price, old.FetchedAt
+	}
+	pc.cache.Set(freshPriceKey(itemID), entry, priceFreshFor)
+	pc.cache.Set(lastPriceKey(itemID), entry, priceHistoryTTL)
+	return entry
+}
+
+// lookup returns the entry cached under key
+func (pc *PriceCache) lookup(key string) (*PriceEntry, bool) {
 	if value, found := pc.cache.Get(key); found {
-		if price, ok := value.(*gw2api.Price); ok {
-			return price, true
+		if entry, ok := value.(*PriceEntry); ok && entry.Price != nil {
+			return entry, true
 		}
 	}
 	return nil, false
 }
 
-// SetPrice caches a price for 3 hours
-func (pc *PriceCache) SetPrice(itemID int, price *gw2api.Price) {
-	key := fmt.Sprintf("price_%d", itemID)
-	pc.cache.Set(key, price, 3*time.Hour)
-}
\ No newline at end of file
+// DecodePriceCacheEntry decodes a price cache value saved with
+// cache.WriteSnapshot, for passing to cache.ReadSnapshot. Snapshots from before
+// prices kept their history hold bare prices, which are restored as stale
+// entries so they are refetched.
+func DecodePriceCacheEntry(key string, value json.RawMessage) (any, error) {
+	var entry PriceEntry
+	if err := json.Unmarshal(value, &entry); err != nil {
+		return nil, err
+	}
+	if entry.Price == nil {
+		var price gw2api.Price
+		if err := json.Unmarshal(value, &price); err != nil {
+			return nil, err
+		}
+		entry = PriceEntry{Price: &price}
+	}
+	return &entry, nil
+}
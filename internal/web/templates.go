@@ -4,234 +4,171 @@ import (
 	"fmt"
 	"html/template"
 	"io"
+	"io/fs"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
 
 	"j5.nz/gw2/internal/gw2api"
+	"j5.nz/gw2/internal/salvage"
 )
 
 // Templates handles HTML template rendering
 type Templates struct {
+	fsys      fs.FS
+	reload    bool // Re-parse on every render, for editing templates without restarting
+	mutex     sync.RWMutex
 	templates map[string]*template.Template
 }
 
-// NewTemplates creates a new template handler
-func NewTemplates() *Templates {
-	t := &Templates{
-		templates: make(map[string]*template.Template),
-	}
-	
-	// Load templates
-	t.loadTemplates()
-	
-	return t
-}
-
-func (t *Templates) loadTemplates() {
-	// Template functions
-	funcMap := template.FuncMap{
-		"lower": func(s string) string {
-			return strings.ToLower(s)
-		},
-		"join": func(slice []string, sep string) string {
-			return strings.Join(slice, sep)
-		},
-		"divide": func(a, b int) int {
-			if b == 0 {
-				return 0
-			}
-			return a / b
-		},
-		"multiply": func(a int, b float64) float64 {
-			return float64(a) * b
-		},
-		"subtract": func(a, b interface{}) float64 {
-			var aVal, bVal float64
-			switch v := a.(type) {
-			case int:
-				aVal = float64(v)
-			case float64:
-				aVal = v
-			}
-			switch v := b.(type) {
-			case int:
-				bVal = float64(v)
-			case float64:
-				bVal = v
-			}
-			return aVal - bVal
-		},
-		"formatCurrency": func(copper int) string {
-			if copper == 0 {
-				return "0c"
-			}
-			
-			gold := copper / 10000
-			remaining := copper % 10000
-			silver := remaining / 100
-			copperLeft := remaining % 100
-			
-			var parts []string
-			if gold > 0 {
-				parts = append(parts, fmt.Sprintf("%dg", gold))
-			}
-			if silver > 0 {
-				parts = append(parts, fmt.Sprintf("%ds", silver))
-			}
-			if copperLeft > 0 || len(parts) == 0 {
-				parts = append(parts, fmt.Sprintf("%dc", copperLeft))
-			}
-			
-			return strings.Join(parts, " ")
-		},
-		"atoi": func(s string) int {
-			i, _ := strconv.Atoi(s)
-			return i
-		},
-		"add": func(a, b int) int {
-			return a + b
-		},
-		"substr": func(s string, start, length int) string {
-			if start >= len(s) {
-				return ""
-			}
-			end := start + length
-			if end > len(s) {
-				end = len(s)
-			}
-			return s[start:end]
-		},
-	}
-
-	// Base template with common layout
-	base := template.Must(template.New("base").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-	))
-	t.templates["base"] = base
-
-	// Home page
-	home := template.Must(template.New("index").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/index.html",
-	))
-	t.templates["index"] = home
-
-	// Item page
-	itemPage := template.Must(template.New("item_page").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/item_page.html",
-	))
-	t.templates["item_page"] = itemPage
+// templateFiles lists the files each named template is parsed from, relative
+// to the templates directory
+var templateFiles = map[string][]string{
+	// Pages that inherit from base
+	"base":             {"base.html"},
+	"index":            {"base.html", "index.html"},
+	"item_page":        {"base.html", "item_page.html", "partials/item_salvage.html", "partials/price_change.html"},
+	"inventory":        {"base.html", "inventory.html"},
+	"character_detail": {"base.html", "character_detail.html"},
+	"account":          {"base.html", "account.html"},
+	"bank":             {"base.html", "bank.html"},
+	"shared":           {"base.html", "shared.html"},
+	"recipe_page":      {"base.html", "recipe_page.html"},
+	"crafting_tree":    {"base.html", "crafting_tree.html", "partials/crafting_choice.html"},
+	"error":            {"base.html", "error.html"},
+	"guild_treasury":   {"base.html", "guild_treasury.html"},
+	"skill_page":       {"base.html", "skill_page.html"},
+	"exchange":         {"base.html", "exchange.html"},
+	"wardrobe":         {"base.html", "wardrobe.html", "partials/wardrobe_grid.html"},
+	"compare":          {"base.html", "compare.html"},
 
 	// Partials for HTMX
-	itemResults := template.Must(template.New("item_results").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/item_results.html",
-	))
-	t.templates["item_results"] = itemResults
-
-	itemDetail := template.Must(template.New("item_detail").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/item_detail.html",
-	))
-	t.templates["item_detail"] = itemDetail
-
-	recipeTree := template.Must(template.New("recipe_tree").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/recipe_tree.html",
-	))
-	t.templates["recipe_tree"] = recipeTree
-
-	// Inventory page
-	inventory := template.Must(template.New("inventory").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/inventory.html",
-	))
-	t.templates["inventory"] = inventory
-
-	// Character detail page
-	characterDetail := template.Must(template.New("character_detail").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/character_detail.html",
-	))
-	t.templates["character_detail"] = characterDetail
-
-	// Character list partial
-	characterList := template.Must(template.New("character_list").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/character_list.html",
-	))
-	t.templates["character_list"] = characterList
-
-	// Character inventory partial
-	characterInventory := template.Must(template.New("character_inventory").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/character_inventory.html",
-	))
-	t.templates["character_inventory"] = characterInventory
-
-	// Account page
-	account := template.Must(template.New("account").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/account.html",
-	))
-	t.templates["account"] = account
-
-	// Bank page
-	bank := template.Must(template.New("bank").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/bank.html",
-	))
-	t.templates["bank"] = bank
-
-	// Shared inventory page
-	shared := template.Must(template.New("shared").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/shared.html",
-	))
-	t.templates["shared"] = shared
-
-	// Recipe page
-	recipePage := template.Must(template.New("recipe_page").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/recipe_page.html",
-	))
-	t.templates["recipe_page"] = recipePage
-
-	// Crafting tree page
-	craftingTree := template.Must(template.New("crafting_tree").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/base.html",
-		"internal/web/assets/templates/crafting_tree.html",
-	))
-	t.templates["crafting_tree"] = craftingTree
-
-	// Partial templates for HTMX
-	craftingSummaryPartial := template.Must(template.New("").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/crafting_summary_partial.html",
-	))
-	t.templates["crafting_summary_partial"] = craftingSummaryPartial
+	"item_results":              {"partials/item_results.html", "partials/item_result_rows.html", "partials/price_change.html"},
+	"item_result_rows":          {"partials/item_result_rows.html", "partials/price_change.html"},
+	"item_no_results":           {"partials/item_no_results.html"},
+	"item_detail":               {"partials/item_detail.html", "partials/item_salvage.html", "partials/price_change.html"},
+	"recipe_tree":               {"partials/recipe_tree.html"},
+	"character_list":            {"partials/character_list.html"},
+	"character_inventory":       {"partials/character_inventory.html"},
+	"crafting_summary_partial":  {"partials/crafting_summary_partial.html"},
+	"crafting_node_partial":     {"partials/crafting_node_partial.html", "partials/crafting_choice.html"},
+	"crafting_children_partial": {"partials/crafting_children_partial.html", "partials/crafting_choice.html"},
+	"crafting_expand_button":    {"partials/crafting_expand_button.html"},
+	"nearly_done":               {"partials/nearly_done.html"},
+	"missing_dyes":              {"partials/missing_dyes.html"},
+	"wardrobe_grid":             {"partials/wardrobe_grid.html"},
+}
 
-	craftingNodePartial := template.Must(template.New("").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/crafting_node_partial.html",
-	))
-	t.templates["crafting_node_partial"] = craftingNodePartial
+// NewTemplates parses all templates from fsys, which holds the contents of the
+// templates directory. With reload set, templates are re-parsed on every render.
+func NewTemplates(fsys fs.FS, reload bool) (*Templates, error) {
+	t := &Templates{fsys: fsys, reload: reload}
+	if err := t.loadTemplates(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
 
-	craftingChildrenPartial := template.Must(template.New("").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/crafting_children_partial.html",
-	))
-	t.templates["crafting_children_partial"] = craftingChildrenPartial
+// templateFuncs are the helper functions available to every template
+var templateFuncs = template.FuncMap{
+	"lower": func(s string) string {
+		return strings.ToLower(s)
+	},
+	"join": func(slice []string, sep string) string {
+		return strings.Join(slice, sep)
+	},
+	"divide": func(a, b int) int {
+		if b == 0 {
+			return 0
+		}
+		return a / b
+	},
+	"multiply": func(a int, b float64) float64 {
+		return float64(a) * b
+	},
+	"subtract": func(a, b interface{}) float64 {
+		var aVal, bVal float64
+		switch v := a.(type) {
+		case int:
+			aVal = float64(v)
+		case float64:
+			aVal = v
+		}
+		switch v := b.(type) {
+		case int:
+			bVal = float64(v)
+		case float64:
+			bVal = v
+		}
+		return aVal - bVal
+	},
+	"formatCurrency": func(copper int) string {
+		return gw2api.Coins(copper).String()
+	},
+	"atoi": func(s string) int {
+		i, _ := strconv.Atoi(s)
+		return i
+	},
+	"add": func(a, b int) int {
+		return a + b
+	},
+	// localTime shows a time in the server's zone, or "" when unset
+	"localTime": func(t time.Time) string {
+		if gw2api.IsZeroTimestamp(t) {
+			return ""
+		}
+		return t.Local().Format("2006-01-02 15:04")
+	},
+	// ago shows how long before now a time was, such as "3h ago"
+	"ago": func(t time.Time) string {
+		return gw2api.RelativeTime(t, time.Now())
+	},
+	"substr": func(s string, start, length int) string {
+		if start >= len(s) {
+			return ""
+		}
+		end := start + length
+		if end > len(s) {
+			end = len(s)
+		}
+		return s[start:end]
+	},
+}
 
-	craftingExpandButton := template.Must(template.New("").Funcs(funcMap).ParseFiles(
-		"internal/web/assets/templates/partials/crafting_expand_button.html",
-	))
-	t.templates["crafting_expand_button"] = craftingExpandButton
+func (t *Templates) loadTemplates() error {
+	templates := make(map[string]*template.Template, len(templateFiles))
+	for name, files := range templateFiles {
+		tmpl, err := template.New(name).Funcs(templateFuncs).ParseFS(t.fsys, files...)
+		if err != nil {
+			return fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+		templates[name] = tmpl
+	}
 
+	t.mutex.Lock()
+	t.templates = templates
+	t.mutex.Unlock()
+	return nil
 }
 
 // Render executes a template with the given data
 func (t *Templates) Render(w io.Writer, name string, data interface{}) error {
+	if t.reload {
+		if err := t.loadTemplates(); err != nil {
+			return err
+		}
+	}
+
+	t.mutex.RLock()
 	tmpl, exists := t.templates[name]
+	t.mutex.RUnlock()
 	if !exists {
 		return fmt.Errorf("template %s not found", name)
 	}
 	
 	// For pages that inherit from base, execute the base template
-	if name == "index" || name == "item_page" || name == "inventory" || name == "character_detail" || name == "account" || name == "bank" || name == "shared" || name == "recipe_page" || name == "crafting_tree" {
+	if name == "index" || name == "item_page" || name == "inventory" || name == "character_detail" || name == "account" || name == "bank" || name == "shared" || name == "recipe_page" || name == "crafting_tree" || name == "error" || name == "guild_treasury" || name == "skill_page" || name == "exchange" || name == "wardrobe" || name == "compare" {
 		return tmpl.ExecuteTemplate(w, "base.html", data)
 	}
 	
@@ -239,6 +176,10 @@ func (t *Templates) Render(w io.Writer, name string, data interface{}) error {
 	switch name {
 	case "item_results":
 		return tmpl.ExecuteTemplate(w, "item_results.html", data)
+	case "item_result_rows":
+		return tmpl.ExecuteTemplate(w, "item_result_rows.html", data)
+	case "item_no_results":
+		return tmpl.ExecuteTemplate(w, "item_no_results.html", data)
 	case "item_detail":
 		return tmpl.ExecuteTemplate(w, "item_detail.html", data)
 	case "recipe_tree":
@@ -255,6 +196,12 @@ func (t *Templates) Render(w io.Writer, name string, data interface{}) error {
 		return tmpl.ExecuteTemplate(w, "crafting_children_partial.html", data)
 	case "crafting_expand_button":
 		return tmpl.ExecuteTemplate(w, "crafting_expand_button.html", data)
+	case "nearly_done":
+		return tmpl.ExecuteTemplate(w, "nearly_done.html", data)
+	case "missing_dyes":
+		return tmpl.ExecuteTemplate(w, "missing_dyes.html", data)
+	case "wardrobe_grid":
+		return tmpl.ExecuteTemplate(w, "wardrobe_grid.html", data)
 	default:
 		return tmpl.Execute(w, data)
 	}
@@ -266,21 +213,42 @@ type PageData struct {
 	Content interface{}
 }
 
+// HomePageData is the search form's language choice
+type HomePageData struct {
+	SearchLanguage  gw2api.Language   // Language names are searched in, empty for the default
+	SearchLanguages []gw2api.Language // Languages with item names loaded in the data cache
+}
+
 type ItemSearchData struct {
-	Query string
-	Items []*ItemWithPrice
+	Query        string
+	Items        []*ItemWithPrice // The page of results being shown
+	Shown        int              // Results shown up to and including this page
+	Total        int              // Results found, capped at maxSearchResults
+	Truncated    bool             // Total hit the cap, so more items may match
+	NextPage     int              // Page to load next, 0 on the last page
+	LoadMoreVals string           // hx-vals of the load more button, as JSON
+	Loading      bool             // Nothing was searched because the data cache is still loading
+
+	Lang        gw2api.Language
+	Rarity      gw2api.Rarity      // Only items of this rarity, if set
+	Sort        string             // Key of searchSorts the results are sorted by, if any
+	Order       string             // asc or desc
+	SortOptions []SearchSortOption // Sort buttons to show
+	Rarities    []gw2api.Rarity    // Options of the rarity filter
 }
 
 type ItemWithPrice struct {
-	*gw2api.Item
-	Price    *gw2api.Price
-	HasPrice bool
+	*ItemView
+	Price      *gw2api.Price
+	HasPrice   bool
+	BuyChange  *PriceChange // Since the price was last fetched, nil if unknown
+	SellChange *PriceChange
 }
 
 // RecipeWithOutput represents a recipe with its output item details
 type RecipeWithOutput struct {
-	Recipe     *gw2api.RecipeDetail
-	OutputItem *gw2api.Item
+	Recipe     *RecipeView
+	OutputItem *ItemView
 }
 
 // ItemRecipes represents both types of recipes for an item
@@ -290,22 +258,34 @@ type ItemRecipes struct {
 }
 
 type ItemDetailData struct {
-	Item     *gw2api.Item
-	Price    *gw2api.Price
-	HasPrice bool
-	Recipes  *ItemRecipes
+	Item       *ItemView
+	Price      *gw2api.Price
+	HasPrice   bool
+	BuyChange  *PriceChange // Since the price was last fetched, nil if unknown
+	SellChange *PriceChange
+	Recipes    *ItemRecipes
+	Vendors    []gw2api.VendorOffer
+	Salvage    *salvage.Estimate // Nil if the item has no salvage rates
+	SellWays   []salvage.Option  // Vendor, trading post and salvage values
+}
+
+// SkillPageData is a skill with its facts rendered for the selected traits
+type SkillPageData struct {
+	Skill  *gw2api.Skill
+	Facts  []gw2api.SkillFact
+	Traits []int
 }
 
 type RecipeDetailData struct {
-	Recipe      *gw2api.RecipeDetail
-	OutputItem  *gw2api.Item
+	Recipe      *RecipeView
+	OutputItem  *ItemView
 	Ingredients []*IngredientWithItem
 	TotalCost   int
 }
 
 type IngredientWithItem struct {
 	*gw2api.RecipeIngredient
-	Item     *gw2api.Item
+	Item     *ItemView
 	Price    *gw2api.Price
 	HasPrice bool
 	Cost     int // count * unit_price
@@ -324,6 +304,42 @@ type CraftingNode struct {
 	TotalBuyCost  int  // RequiredCount * BuyPrice for easy template access
 	Children      []*CraftingNode // Ingredients needed if crafting
 	Level         int  // Tree depth level
+
+	AlternativeRecipes []*RecipeSummary // Every recipe that makes this item, for choosing between them
+	Pinned             bool             // The recipe, or buying when Recipe is nil, was chosen by the user
+	DepthPriced        bool             // BuyPrice is the average over the order book, not the best price
+	Purchasable        bool             // False for account bound items with no trading post price
+	TimeGated          bool             // Crafting is limited to PerDay a day
+	PerDay             int
+}
+
+// Crafts reports whether the node is crafted rather than bought: either crafting
+// is cheaper or the user pinned the recipe
+func (n *CraftingNode) Crafts() bool {
+	return n.HasRecipe && (n.CanCraft || n.Pinned)
+}
+
+// RecipeSummary describes one way to craft an ingredient
+type RecipeSummary struct {
+	ID          int
+	Type        string
+	Disciplines []string
+	MinRating   int
+	OutputCount int
+	Source      string // Empty for official recipes, e.g. gw2api.RecipeSourceMysticForge otherwise
+	Cost        int    // Trading post cost of one craft's direct ingredients
+	Selected    bool   // Used by the tree, whether picked as cheapest or pinned
+}
+
+// MustCraftSummary is an ingredient the trading post can't supply or that can
+// only be crafted a few times a day, with the whole tree's need for it
+type MustCraftSummary struct {
+	Item          *gw2api.Item
+	TotalRequired int
+	HasRecipe     bool // False when the item must be obtained some other way
+	TimeGated     bool
+	PerDay        int
+	Days          int // Days of crafting at PerDay, 0 unless TimeGated
 }
 
 // MaterialSummary represents aggregated base materials needed
@@ -346,6 +362,9 @@ type CraftingTreeData struct {
 	SavingsPercent  float64
 	ExtraCost       int  // Absolute value when crafting costs more than buying
 	IsCraftingCheaper bool // True if crafting is cheaper than buying
+	Pins            CraftingPins // User choices the tree was built with
+	MustCraft       []*MustCraftSummary // Ingredients left out of the buy-vs-craft comparison
+	EstimatedDays   int  // Days the time-gated crafts take, done in parallel
 }
 
 // RequestCache provides memoization for a single crafting tree request
@@ -355,6 +374,8 @@ type RequestCache struct {
 	recipeSearch map[int]*ItemRecipes         // itemID -> recipes that create/use it
 	prices      map[int]*gw2api.Price         // itemID -> price
 	craftNodes  map[string]*CraftingNode      // "itemID:quantity:level" -> node
+	outputRecipes map[int][]int               // itemID -> IDs of recipes that create it
+	listings    map[int]*gw2api.Listing       // itemID -> order book, only fetched for depth pricing
 }
 
 // NewRequestCache creates a new request-scoped cache
@@ -365,5 +386,7 @@ func NewRequestCache() *RequestCache {
 		recipeSearch: make(map[int]*ItemRecipes),
 		prices:      make(map[int]*gw2api.Price),
 		craftNodes:  make(map[string]*CraftingNode),
+		outputRecipes: make(map[int][]int),
+		listings:    make(map[int]*gw2api.Listing),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,27 @@
+package web
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestEmbeddedTemplatesParse(t *testing.T) {
+	templates, err := NewTemplates(embeddedSub("assets/templates"), false)
+	if err != nil {
+		t.Fatalf("NewTemplates: %v", err)
+	}
+	for name := range templateFiles {
+		if _, found := templates.templates[name]; !found {
+			t.Errorf("template %s was not loaded", name)
+		}
+	}
+}
+
+func TestNewTemplatesMissingFile(t *testing.T) {
+	fsys := fstest.MapFS{
+		"base.html": {Data: []byte(`{{define "base.html"}}{{end}}`)},
+	}
+	if _, err := NewTemplates(fsys, false); err == nil {
+		t.Error("expected an error for missing templates instead of a panic")
+	}
+}
@@ -0,0 +1,105 @@
+package web
+
+import (
+	"slices"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// Templates never see items or recipes from the client directly. Those may be
+// shared with the data cache and with other requests, so handlers map them to
+// the view structs below, which belong to the request being rendered and are
+// safe to trim or append to.
+
+// ItemView is the part of an item the templates show
+type ItemView struct {
+	ID            int
+	Name          string
+	LocalizedName string // Name in the search language, when it isn't the default
+	DisplayName   string // LocalizedName if set, otherwise Name
+	Icon          string
+	Description   string
+	ChatLink      string
+	Type          string
+	Rarity        string
+	Level         int
+	VendorValue   int
+	Flags         []string
+	Details       *ItemDetailsView // Nil for items without stats
+}
+
+// ItemDetailsView is the armor and weapon stats of an item
+type ItemDetailsView struct {
+	Type        string
+	WeightClass string
+	Defense     int
+	MinPower    int
+	MaxPower    int
+}
+
+// newItemView copies what the templates need from an item, which may be nil
+func newItemView(item *gw2api.Item) *ItemView {
+	if item == nil {
+		return nil
+	}
+	view := &ItemView{
+		ID:            item.ID,
+		Name:          item.Name,
+		LocalizedName: item.LocalizedName,
+		DisplayName:   item.Name,
+		Icon:          item.Icon,
+		Description:   item.Description,
+		ChatLink:      item.ChatLink,
+		Type:          item.Type,
+		Rarity:        item.Rarity,
+		Level:         item.Level,
+		VendorValue:   item.VendorValue,
+		Flags:         slices.Clone(item.Flags),
+	}
+	if item.LocalizedName != "" {
+		view.DisplayName = item.LocalizedName
+	}
+	if item.Details != nil {
+		view.Details = &ItemDetailsView{
+			Type:        item.Details.Type,
+			WeightClass: item.Details.WeightClass,
+			Defense:     item.Details.Defense,
+			MinPower:    item.Details.MinPower,
+			MaxPower:    item.Details.MaxPower,
+		}
+	}
+	return view
+}
+
+// RecipeView is the part of a recipe the templates show
+type RecipeView struct {
+	ID              int
+	Type            string
+	OutputItemID    int
+	OutputItemCount int
+	TimeToCraftMS   int
+	Disciplines     []string
+	MinRating       int
+	Flags           []string
+	Ingredients     []gw2api.RecipeIngredient
+	Source          string // Empty for official recipes
+}
+
+// newRecipeView copies what the templates need from a recipe, which may be nil
+func newRecipeView(recipe *gw2api.RecipeDetail) *RecipeView {
+	if recipe == nil {
+		return nil
+	}
+	return &RecipeView{
+		ID:              recipe.ID,
+		Type:            recipe.Type,
+		OutputItemID:    recipe.OutputItemID,
+		OutputItemCount: recipe.OutputItemCount,
+		TimeToCraftMS:   recipe.TimeToCraftMS,
+		Disciplines:     slices.Clone(recipe.Disciplines),
+		MinRating:       recipe.MinRating,
+		Flags:           slices.Clone(recipe.Flags),
+		Ingredients:     slices.Clone(recipe.Ingredients),
+		Source:          recipe.Source,
+	}
+}
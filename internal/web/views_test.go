@@ -0,0 +1,168 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// newCachedTestServer returns a web server whose client has three items, three
+// recipes and a vendor in its data cache, and a fake API that only knows prices
+func newCachedTestServer(t *testing.T) *Server {
+	t.Helper()
+
+	dir := t.TempDir()
+	files := map[string]string{
+		// Each item is both made by and used in a recipe, so the item pages
+		// never fall back to the live recipe search
+		"items.json": `{"id": 1, "name": "Sword", "rarity": "Exotic", "flags": ["NoSell"], "details": {"min_power": 900, "max_power": 1000}}
+{"id": 2, "name": "Ingot", "rarity": "Basic"}
+{"id": 3, "name": "Leather", "rarity": "Fine"}
+`,
+		"recipes.json": `{"id": 100, "type": "Sword", "output_item_id": 1, "output_item_count": 1, "disciplines": ["Weaponsmith"], "ingredients": [{"item_id": 2, "count": 5}, {"item_id": 3, "count": 1}]}
+{"id": 101, "type": "Refinement", "output_item_id": 2, "output_item_count": 1, "disciplines": ["Weaponsmith"], "ingredients": [{"item_id": 1, "count": 1}]}
+{"id": 102, "type": "Refinement", "output_item_id": 3, "output_item_count": 1, "disciplines": ["Leatherworker"], "ingredients": [{"item_id": 1, "count": 1}]}
+`,
+		"vendors.json": `{"id": 1, "type": "Karma", "sells": [{"item_id": 2, "cost": [{"currency_id": 2, "quantity": 2100}, {"currency_id": 1, "quantity": 10050}]}]}
+`,
+		"currencies.json": `{"id": 1, "name": "Coin"}
+{"id": 2, "name": "Karma"}
+`,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/commerce/prices" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "not found"}`))
+			return
+		}
+		var prices []gw2api.Price
+		for id := range strings.SplitSeq(r.URL.Query().Get("ids"), ",") {
+			var price gw2api.Price
+			json.Unmarshal([]byte(`{"id": `+id+`, "buys": {"unit_price": 90}, "sells": {"unit_price": 100}}`), &price)
+			prices = append(prices, price)
+		}
+		json.NewEncoder(w).Encode(prices)
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(
+		gw2api.WithBaseURL(upstream.URL),
+		gw2api.WithRetries(0),
+		gw2api.WithRateLimit(1000),
+		gw2api.WithDataCache(dir),
+	)
+	server, err := NewServer(client, cache.NewLRUCache(100))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+	return server
+}
+
+func TestViewsDoNotShareCacheData(t *testing.T) {
+	server := newCachedTestServer(t)
+	itemCache := server.client.DataCache().GetItemCache()
+	recipeCache := server.client.DataCache().GetRecipeCache()
+
+	item, _ := itemCache.GetByIDRef(1)
+	view := newItemView(item)
+	view.Name = view.Name[:2]
+	view.Flags[0] = "AccountBound"
+	view.Details.MinPower = 0
+
+	recipe, _ := recipeCache.GetByIDRef(100)
+	recipeView := newRecipeView(recipe)
+	recipeView.Disciplines = append(recipeView.Disciplines[:0], "Huntsman")
+	recipeView.Ingredients[0].Count = 99
+
+	item, _ = itemCache.GetByIDRef(1)
+	if item.Name != "Sword" || item.Flags[0] != "NoSell" || item.Details.MinPower != 900 {
+		t.Errorf("cached item changed through its view: %+v", item)
+	}
+	recipe, _ = recipeCache.GetByIDRef(100)
+	if recipe.Disciplines[0] != "Weaponsmith" || recipe.Ingredients[0].Count != 5 {
+		t.Errorf("cached recipe changed through its view: %+v", recipe)
+	}
+	if newItemView(nil) != nil || newRecipeView(nil) != nil {
+		t.Error("views of nil entries should be nil")
+	}
+}
+
+// TestConcurrentRendering renders the item and recipe pages from many requests
+// at once. Run it with -race to check handlers don't share data while rendering.
+func TestConcurrentRendering(t *testing.T) {
+	server := newCachedTestServer(t)
+
+	requests := []struct {
+		newRequest func() *http.Request
+		expected   string
+	}{
+		{func() *http.Request { return httptest.NewRequest(http.MethodGet, "/items/1", nil) }, "Weaponsmith"},
+		{func() *http.Request { return httptest.NewRequest(http.MethodGet, "/item/2", nil) }, "Ingot"},
+		{func() *http.Request { return httptest.NewRequest(http.MethodGet, "/recipe/100", nil) }, "Leather"},
+		{func() *http.Request {
+			r := httptest.NewRequest(http.MethodPost, "/search/items", strings.NewReader(url.Values{"query": {"e"}}.Encode()))
+			r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			return r
+		}, "Leather"},
+	}
+
+	const workers, rounds = 8, 10
+	var wg sync.WaitGroup
+	failures := make(chan string, workers*rounds*len(requests))
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range rounds {
+				for _, tt := range requests {
+					request := tt.newRequest()
+					recorder := httptest.NewRecorder()
+					server.ServeHTTP(recorder, request)
+					if recorder.Code != http.StatusOK || !strings.Contains(recorder.Body.String(), tt.expected) {
+						failures <- request.URL.Path + ": " + recorder.Result().Status + ", expected " + tt.expected
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(failures)
+
+	for failure := range failures {
+		t.Error(failure)
+	}
+}
+
+func TestItemPageVendors(t *testing.T) {
+	server := newCachedTestServer(t)
+
+	for _, path := range []string{"/items/2", "/item/2"} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		body := recorder.Body.String()
+		if recorder.Code != http.StatusOK || !strings.Contains(body, "Available from vendors") || !strings.Contains(body, "for 2100 Karma &#43; 1g 50c") {
+			t.Errorf("%s does not show the vendor offer: status %d", path, recorder.Code)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/items/3", nil))
+	if strings.Contains(recorder.Body.String(), "Available from vendors") {
+		t.Error("item no vendor sells shows a vendor section")
+	}
+}
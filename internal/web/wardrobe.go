@@ -0,0 +1,120 @@
+package web
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// wardrobePageSize is how many unlocks each grid request loads. Further pages
+// load as the end of the grid scrolls into view.
+const wardrobePageSize = 120
+
+// WardrobeTab is one section of the wardrobe
+type WardrobeTab struct {
+	Slug  string // Path segment, as in /wardrobe/{slug}
+	Label string
+	Kind  gw2api.UnlockKind
+}
+
+// wardrobeTabs are the wardrobe sections in the order they are shown
+var wardrobeTabs = []WardrobeTab{
+	{Slug: "gliders", Label: "Gliders", Kind: gw2api.UnlockKindGlider},
+	{Slug: "mount-skins", Label: "Mount Skins", Kind: gw2api.UnlockKindMountSkin},
+	{Slug: "outfits", Label: "Outfits", Kind: gw2api.UnlockKindOutfit},
+	{Slug: "finishers", Label: "Finishers", Kind: gw2api.UnlockKindFinisher},
+	{Slug: "minis", Label: "Minis", Kind: gw2api.UnlockKindMini},
+	{Slug: "novelties", Label: "Novelties", Kind: gw2api.UnlockKindNovelty},
+}
+
+// WardrobePageData is the data for a wardrobe section
+type WardrobePageData struct {
+	Tabs   []WardrobeTab
+	Active WardrobeTab
+	Grid   WardrobeGridData
+}
+
+// WardrobeGridData is one page of a wardrobe grid
+type WardrobeGridData struct {
+	Tab      WardrobeTab
+	Page     *gw2api.WardrobePage
+	NextPage int
+	Error    string
+}
+
+// findWardrobeTab returns the tab for a path segment
+func findWardrobeTab(slug string) (WardrobeTab, bool) {
+	for _, tab := range wardrobeTabs {
+		if tab.Slug == slug {
+			return tab, true
+		}
+	}
+	return WardrobeTab{}, false
+}
+
+// handleWardrobe opens the first wardrobe section
+func (s *Server) handleWardrobe(w http.ResponseWriter, r *http.Request) {
+	http.Redirect(w, r, "/wardrobe/"+wardrobeTabs[0].Slug, http.StatusFound)
+}
+
+// handleWardrobePage shows the first page of a wardrobe section, with locked
+// unlocks greyed out when the server has an API key
+func (s *Server) handleWardrobePage(w http.ResponseWriter, r *http.Request) {
+	tab, found := findWardrobeTab(r.PathValue("kind"))
+	if !found {
+		s.renderNotFound(w, "Wardrobe section not found", "There is no wardrobe section called "+r.PathValue("kind")+".")
+		return
+	}
+
+	page, err := s.client.GetWardrobePage(r.Context(), tab.Kind, 0, wardrobePageSize)
+	if err != nil {
+		s.renderLookupError(w, err, "Wardrobe section not found", "The "+tab.Label+" could not be found.")
+		return
+	}
+
+	data := PageData{
+		Title: tab.Label + " - Wardrobe - GW2 Items & Crafting",
+		Content: WardrobePageData{
+			Tabs:   wardrobeTabs,
+			Active: tab,
+			Grid:   WardrobeGridData{Tab: tab, Page: page, NextPage: 1},
+		},
+	}
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "wardrobe", data); err != nil {
+		http.Error(w, "Template error: "+err.Error(), http.StatusInternalServerError)
+	}
+}
+
+// handleWardrobeGrid renders a further page of a wardrobe grid for HTMX to
+// append. Errors are shown in the grid, since HTMX ignores error responses.
+func (s *Server) handleWardrobeGrid(w http.ResponseWriter, r *http.Request) {
+	tab, found := findWardrobeTab(r.PathValue("kind"))
+	if !found {
+		s.renderNotFound(w, "Wardrobe section not found", "There is no wardrobe section called "+r.PathValue("kind")+".")
+		return
+	}
+	pageNumber, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || pageNumber < 0 {
+		http.Error(w, "Invalid page", http.StatusBadRequest)
+		return
+	}
+
+	data := WardrobeGridData{Tab: tab, NextPage: pageNumber + 1}
+	page, err := s.client.GetWardrobePage(r.Context(), tab.Kind, pageNumber, wardrobePageSize)
+	switch {
+	case errors.Is(err, gw2api.ErrNotFound):
+		data.Error = "This page of " + tab.Label + " could not be found."
+	case err != nil:
+		data.Error = "Failed to load more " + tab.Label + ": " + err.Error()
+	default:
+		data.Page = page
+	}
+
+	w.Header().Set("Content-Type", "text/html")
+	if err := s.templates.Render(w, "wardrobe_grid", data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,46 @@
+package web
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"j5.nz/gw2/internal/gw2api"
+)
+
+// APIWvWMatch is a match in /api/v1/wvw/matches responses
+type APIWvWMatch struct {
+	ID         string                        `json:"id"`
+	Summary    *gw2api.WvWObjectiveSummary   `json:"summary"`
+	Objectives []gw2api.WvWObjectiveProgress `json:"objectives"`
+}
+
+// handleAPIWvWMatch returns the objectives of the match a team or world
+// plays in, with each side's totals and each objective's upgrade progress
+func (s *Server) handleAPIWvWMatch(w http.ResponseWriter, r *http.Request) {
+	teamID, err := strconv.Atoi(r.PathValue("team"))
+	if err != nil || teamID <= 0 {
+		http.Error(w, fmt.Sprintf("Invalid team ID %q", r.PathValue("team")), http.StatusBadRequest)
+		return
+	}
+
+	match, err := s.client.GetWvWMatchByWorld(r.Context(), teamID)
+	if errors.Is(err, gw2api.ErrNotFound) {
+		http.Error(w, fmt.Sprintf("No match for team %d", teamID), http.StatusNotFound)
+		return
+	} else if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get the match: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	response := APIWvWMatch{
+		ID:         match.ID,
+		Summary:    match.ObjectiveSummary(),
+		Objectives: match.ObjectiveProgress(time.Now()),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
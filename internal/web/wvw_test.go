@@ -0,0 +1,59 @@
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"j5.nz/gw2/internal/cache"
+	"j5.nz/gw2/internal/gw2api"
+)
+
+func TestAPIWvWMatch(t *testing.T) {
+	upstream := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/wvw/matches" || r.URL.Query().Get("world") != "11004" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"text": "world not currently in a match"}`))
+			return
+		}
+		w.Write([]byte(`{"id": "1-2", "maps": [{"id": 38, "type": "Center", "objectives": [
+			{"id": "38-9", "type": "Castle", "owner": "Red", "last_flipped": "2025-06-06T06:00:00Z", "points_tick": 12, "yaks_delivered": 150},
+			{"id": "38-15", "type": "Camp", "owner": "Blue", "last_flipped": "2025-06-06T08:30:00Z", "points_tick": 2}
+		]}]}`))
+	}))
+	t.Cleanup(upstream.Close)
+
+	client := gw2api.NewClient(gw2api.WithBaseURL(upstream.URL), gw2api.WithRetries(0), gw2api.WithRateLimit(1000))
+	server, err := NewServer(client, cache.NewLRUCache(10))
+	if err != nil {
+		t.Fatalf("NewServer: %v", err)
+	}
+
+	recorder := httptest.NewRecorder()
+	server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/wvw/matches/11004", nil))
+	if recorder.Code != http.StatusOK {
+		t.Fatalf("status %d: %s", recorder.Code, recorder.Body)
+	}
+	var match APIWvWMatch
+	if err := json.Unmarshal(recorder.Body.Bytes(), &match); err != nil {
+		t.Fatal(err)
+	}
+	if match.ID != "1-2" || match.Summary.Red.PointsPerTick != 12 || match.Summary.Red.Tier3 != 1 || match.Summary.Blue.PointsPerTick != 2 {
+		t.Errorf("match = %+v, summary = %+v", match, match.Summary)
+	}
+	if len(match.Objectives) != 2 || match.Objectives[0].Map != "Center" || match.Objectives[0].Tier != 3 {
+		t.Errorf("objectives = %+v", match.Objectives)
+	}
+
+	for path, status := range map[string]int{
+		"/api/v1/wvw/matches/11005": http.StatusNotFound,
+		"/api/v1/wvw/matches/red":   http.StatusBadRequest,
+	} {
+		recorder := httptest.NewRecorder()
+		server.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		if recorder.Code != status {
+			t.Errorf("%s: status %d, expected %d", path, recorder.Code, status)
+		}
+	}
+}